@@ -0,0 +1,289 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleArchive dispatches archive subcommands
+func handleArchive(profile string, args []string) {
+	if len(args) == 0 {
+		handleArchiveList(profile, nil)
+		return
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		handleArchiveList(profile, args[1:])
+	case "show":
+		handleArchiveShow(profile, args[1:])
+	case "restore":
+		handleArchiveRestore(profile, args[1:])
+	case "delete", "rm":
+		handleArchiveDelete(profile, args[1:])
+	case "help", "--help", "-h":
+		printArchiveHelp()
+		return
+	default:
+		fmt.Printf("Unknown archive command: %s\n", args[0])
+		fmt.Println()
+		printArchiveHelp()
+		exit(1)
+	}
+}
+
+// printArchiveHelp prints usage for archive commands
+func printArchiveHelp() {
+	fmt.Println("Usage: agent-deck archive <command> [options]")
+	fmt.Println()
+	fmt.Println("Archived sessions were removed with 'remove --archive' (or 'a' in the TUI")
+	fmt.Println("delete prompt). Their settings and final output are kept until deleted here.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  list              List archived sessions")
+	fmt.Println("  show <id>         Print an archived session's final output")
+	fmt.Println("  restore <id>      Restore an archived session")
+	fmt.Println("  delete <id>       Permanently delete an archived session")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck archive")
+	fmt.Println("  agent-deck archive show \"My Project\"")
+	fmt.Println("  agent-deck archive restore abc12345")
+}
+
+// handleArchiveList lists archived sessions
+func handleArchiveList(profile string, args []string) {
+	fs := flag.NewFlagSet("archive list", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck archive list [options]")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	defer storage.Close()
+
+	archived, err := storage.ListArchived()
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	if *jsonOutput {
+		out.Print("", map[string]interface{}{
+			"profile":  storage.Profile(),
+			"archived": archived,
+		})
+		return
+	}
+	if len(archived) == 0 {
+		fmt.Printf("No archived sessions in profile '%s'.\n", storage.Profile())
+		return
+	}
+
+	fmt.Printf("%-*s %-*s %-16s %s\n", tableColTitle, "TITLE", tableColGroup, "GROUP", "ARCHIVED", "ID")
+	fmt.Println(strings.Repeat("-", tableColTitle+tableColGroup+16+tableColIDDisplay+3))
+	for _, a := range archived {
+		idDisplay := a.ID
+		if len(idDisplay) > tableColIDDisplay {
+			idDisplay = idDisplay[:tableColIDDisplay]
+		}
+		fmt.Printf("%-*s %-*s %-16s %s\n",
+			tableColTitle, truncate(a.Title, tableColTitle),
+			tableColGroup, truncate(a.GroupPath, tableColGroup),
+			a.ArchivedAt.Format("2006-01-02 15:04"), idDisplay)
+	}
+	fmt.Printf("\nTotal: %d archived\n", len(archived))
+}
+
+// handleArchiveShow prints an archived session's transcript
+func handleArchiveShow(profile string, args []string) {
+	fs := flag.NewFlagSet("archive show", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck archive show <id|title> [options]")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	storage, archived := resolveArchivedArg(out, profile, fs.Arg(0))
+	defer storage.Close()
+
+	if *jsonOutput {
+		out.Print("", archived)
+		return
+	}
+	fmt.Printf("Session: %s (%s)\n", archived.Title, archived.ID)
+	fmt.Printf("Path:    %s\n", FormatPath(archived.ProjectPath))
+	fmt.Printf("Archived: %s\n\n", archived.ArchivedAt.Format("2006-01-02 15:04:05"))
+	if archived.Transcript == "" {
+		fmt.Println("(no output was captured)")
+		return
+	}
+	fmt.Println(archived.Transcript)
+}
+
+// handleArchiveRestore moves an archived session back into the session list
+func handleArchiveRestore(profile string, args []string) {
+	fs := flag.NewFlagSet("archive restore", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck archive restore <id|title> [options]")
+		fmt.Println()
+		fmt.Println("Restores the session stopped; start it with 'agent-deck session start'.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	storage, archived := resolveArchivedArg(out, profile, fs.Arg(0))
+	defer storage.Close()
+
+	instances, groups, err := storage.LoadWithGroups()
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	for _, inst := range instances {
+		if inst.ID == archived.ID {
+			out.Error(fmt.Sprintf("session %s already exists", archived.ID), ErrCodeAlreadyExists)
+			exit(1)
+		}
+	}
+
+	inst, err := archived.Instance()
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	instances = append(instances, inst)
+	if err := storage.SaveWithGroups(instances, session.NewGroupTreeWithGroups(instances, groups)); err != nil {
+		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	if err := storage.DeleteArchived(archived.ID); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Restored session: %s (start it with: agent-deck session start %s)", inst.Title, TruncateID(inst.ID)), map[string]interface{}{
+		"success": true,
+		"id":      inst.ID,
+		"title":   inst.Title,
+		"profile": storage.Profile(),
+	})
+}
+
+// handleArchiveDelete permanently deletes an archived session
+func handleArchiveDelete(profile string, args []string) {
+	fs := flag.NewFlagSet("archive delete", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck archive delete <id|title> [options]")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	storage, archived := resolveArchivedArg(out, profile, fs.Arg(0))
+	defer storage.Close()
+
+	if err := storage.DeleteArchived(archived.ID); err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	out.Success(fmt.Sprintf("Deleted archived session: %s", archived.Title), map[string]interface{}{
+		"success": true,
+		"id":      archived.ID,
+		"title":   archived.Title,
+	})
+}
+
+// resolveArchivedArg opens the profile's storage and finds an archived
+// session by ID, ID prefix, or exact title. Exits on failure.
+func resolveArchivedArg(out *CLIOutput, profile, identifier string) (*session.Storage, *session.ArchivedSession) {
+	if identifier == "" {
+		out.Error("archived session ID or title is required", ErrCodeNotFound)
+		exit(1)
+	}
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	archived, err := storage.ListArchived()
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	var matches []*session.ArchivedSession
+	for _, a := range archived {
+		if a.ID == identifier || a.Title == identifier {
+			matches = []*session.ArchivedSession{a}
+			break
+		}
+		if strings.HasPrefix(a.ID, identifier) {
+			matches = append(matches, a)
+		}
+	}
+	switch {
+	case len(matches) == 0:
+		out.Error(fmt.Sprintf("archived session not found: %s", identifier), ErrCodeNotFound)
+		exit(2)
+	case len(matches) > 1:
+		out.Error(fmt.Sprintf("ambiguous ID prefix %q matches %d archived sessions", identifier, len(matches)), ErrCodeAmbiguous)
+		exit(1)
+	}
+
+	full, err := storage.GetArchived(matches[0].ID)
+	if err != nil {
+		code := ErrCodeInvalidOperation
+		if errors.Is(err, session.ErrArchivedNotFound) {
+			code = ErrCodeNotFound
+		}
+		out.Error(err.Error(), code)
+		exit(1)
+	}
+	return storage, full
+}
@@ -2,12 +2,15 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
+	"github.com/sahilm/fuzzy"
+
 	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
@@ -113,7 +116,7 @@ func (c *CLIOutput) printJSON(data interface{}) {
 	output, err := json.MarshalIndent(data, "", "  ")
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to format JSON: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 	fmt.Println(string(output))
 }
@@ -138,82 +141,39 @@ const (
 // ResolveSession finds a session by flexible matching (title, ID prefix, or path)
 // Returns the matched session or nil with an error message
 func ResolveSession(identifier string, instances []*session.Instance) (*session.Instance, string, string) {
-	if identifier == "" {
-		return nil, "session identifier is required", ErrCodeNotFound
-	}
-
-	var matches []*session.Instance
-
-	// Try exact title match first
-	for _, inst := range instances {
-		if inst.Title == identifier {
-			return inst, "", ""
-		}
-	}
-
-	// Try ID prefix match (minimum 6 chars for prefix to avoid too many matches)
-	if len(identifier) >= 6 {
-		for _, inst := range instances {
-			if strings.HasPrefix(inst.ID, identifier) {
-				matches = append(matches, inst)
-			}
-		}
-	}
-
-	if len(matches) == 1 {
-		return matches[0], "", ""
-	}
-
-	if len(matches) > 1 {
-		var names []string
-		for _, m := range matches {
-			names = append(names, fmt.Sprintf("%s (%s)", m.Title, m.ID[:12]))
-		}
-		return nil, fmt.Sprintf("'%s' matches multiple sessions:\n  - %s\nUse full ID or more specific title.",
-			identifier, strings.Join(names, "\n  - ")), ErrCodeAmbiguous
-	}
-
-	// Try path match - collect all sessions at this path
-	var pathMatches []*session.Instance
-	for _, inst := range instances {
-		if inst.ProjectPath == identifier {
-			pathMatches = append(pathMatches, inst)
-		}
-	}
-
-	if len(pathMatches) == 1 {
-		return pathMatches[0], "", ""
+	inst, err := session.ResolveInstance(identifier, instances)
+	if err == nil {
+		return inst, "", ""
 	}
-
-	if len(pathMatches) > 1 {
-		var names []string
-		for _, m := range pathMatches {
-			names = append(names, fmt.Sprintf("%s (%s)", m.Title, m.ID[:12]))
-		}
-		return nil, fmt.Sprintf("path '%s' has multiple sessions:\n  - %s\nUse title or ID to specify.",
-			identifier, strings.Join(names, "\n  - ")), ErrCodeAmbiguous
+	if errors.Is(err, session.ErrSessionAmbiguous) {
+		return nil, err.Error(), ErrCodeAmbiguous
 	}
-
-	return nil, fmt.Sprintf("session '%s' not found", identifier), ErrCodeNotFound
+	return nil, err.Error(), ErrCodeNotFound
 }
 
-// GetCurrentSessionID detects the current agent-deck session from tmux environment
+// GetCurrentSessionID detects the current agent-deck session from the tmux, Zellij or screen environment
 // Returns session ID or empty string if not in an agent-deck session
 func GetCurrentSessionID() string {
-	// Check if we're in tmux
-	if os.Getenv("TMUX") == "" {
-		return ""
+	// Zellij exports the session name directly, screen as "<pid>.<name>"
+	sessionName := os.Getenv("ZELLIJ_SESSION_NAME")
+	if sty := os.Getenv("STY"); sessionName == "" && sty != "" {
+		_, sessionName, _ = strings.Cut(sty, ".")
 	}
+	if sessionName == "" {
+		// Check if we're in tmux
+		if os.Getenv("TMUX") == "" {
+			return ""
+		}
 
-	// Get current tmux session name
-	cmd := exec.Command("tmux", "display-message", "-p", "#S")
-	output, err := cmd.Output()
-	if err != nil {
-		return ""
+		// Get current tmux session name
+		cmd := exec.Command("tmux", "display-message", "-p", "#S")
+		output, err := cmd.Output()
+		if err != nil {
+			return ""
+		}
+		sessionName = strings.TrimSpace(string(output))
 	}
 
-	sessionName := strings.TrimSpace(string(output))
-
 	// Parse agent-deck session name: agentdeck_<title>_<id>
 	if !strings.HasPrefix(sessionName, "agentdeck_") {
 		return ""
@@ -243,6 +203,39 @@ func ResolveSessionOrCurrent(identifier string, instances []*session.Instance) (
 	return ResolveSession(identifier, instances)
 }
 
+// ResolveSessionFuzzy resolves a session like ResolveSessionOrCurrent, but falls
+// back to fuzzy title matching when nothing matches exactly. A single fuzzy hit
+// is returned; multiple hits are listed (best first) in an ambiguity error.
+func ResolveSessionFuzzy(identifier string, instances []*session.Instance) (*session.Instance, string, string) {
+	inst, errMsg, errCode := ResolveSessionOrCurrent(identifier, instances)
+	if inst != nil || errCode != ErrCodeNotFound || identifier == "" {
+		return inst, errMsg, errCode
+	}
+
+	titles := make([]string, len(instances))
+	for i, s := range instances {
+		titles[i] = s.Title
+	}
+	matches := fuzzy.Find(identifier, titles)
+	if len(matches) == 0 {
+		return nil, errMsg, errCode
+	}
+	if len(matches) == 1 {
+		return instances[matches[0].Index], "", ""
+	}
+
+	var names []string
+	for i, m := range matches {
+		if i == 5 {
+			names = append(names, fmt.Sprintf("... and %d more", len(matches)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", instances[m.Index].Title, TruncateID(instances[m.Index].ID)))
+	}
+	return nil, fmt.Sprintf("'%s' matches multiple sessions:\n  - %s\nUse full ID or more specific title.",
+		identifier, strings.Join(names, "\n  - ")), ErrCodeAmbiguous
+}
+
 // StatusSymbol returns the symbol for a status
 func StatusSymbol(status session.Status) string {
 	switch status {
@@ -250,6 +243,8 @@ func StatusSymbol(status session.Status) string {
 		return "●"
 	case session.StatusWaiting:
 		return "◐"
+	case session.StatusNeedsInput:
+		return "◆"
 	case session.StatusIdle:
 		return "○"
 	case session.StatusError:
@@ -266,10 +261,14 @@ func StatusString(status session.Status) string {
 		return "running"
 	case session.StatusWaiting:
 		return "waiting"
+	case session.StatusNeedsInput:
+		return "needs_input"
 	case session.StatusIdle:
 		return "idle"
 	case session.StatusError:
 		return "error"
+	case session.StatusStarting:
+		return "starting"
 	default:
 		return "unknown"
 	}
@@ -4,6 +4,8 @@ import (
 	"flag"
 	"reflect"
 	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 func TestNormalizeArgs(t *testing.T) {
@@ -210,3 +212,42 @@ func TestNormalizeArgsIntegration(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSessionFuzzy(t *testing.T) {
+	instances := []*session.Instance{
+		{ID: "aaaaaaaa-1111", Title: "payments-service"},
+		{ID: "bbbbbbbb-2222", Title: "web-frontend"},
+		{ID: "cccccccc-3333", Title: "web-backend"},
+	}
+
+	t.Run("exact title wins", func(t *testing.T) {
+		inst, _, _ := ResolveSessionFuzzy("web-frontend", instances)
+		if inst == nil || inst.ID != "bbbbbbbb-2222" {
+			t.Fatalf("expected web-frontend, got %v", inst)
+		}
+	})
+
+	t.Run("unique fuzzy match", func(t *testing.T) {
+		inst, _, _ := ResolveSessionFuzzy("paysvc", instances)
+		if inst == nil || inst.ID != "aaaaaaaa-1111" {
+			t.Fatalf("expected payments-service, got %v", inst)
+		}
+	})
+
+	t.Run("ambiguous fuzzy match", func(t *testing.T) {
+		inst, msg, code := ResolveSessionFuzzy("webend", instances)
+		if inst != nil {
+			t.Fatalf("expected no match, got %s", inst.Title)
+		}
+		if code != ErrCodeAmbiguous {
+			t.Fatalf("expected %s, got %s (%s)", ErrCodeAmbiguous, code, msg)
+		}
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		inst, _, code := ResolveSessionFuzzy("zzz", instances)
+		if inst != nil || code != ErrCodeNotFound {
+			t.Fatalf("expected not found, got %v / %s", inst, code)
+		}
+	})
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cliCommand describes a top-level agent-deck subcommand.
+type cliCommand struct {
+	Name    string
+	Aliases []string
+	Usage   string // Name column shown in help, e.g. "add, new <path>"
+	Summary string
+	Hidden  bool // Internal commands (hooks, proxies) are not listed in help
+
+	// Run executes the command. Nil for commands that continue into the TUI
+	// launch in main() (currently only "web").
+	Run func(profile string, args []string)
+}
+
+// commandTable returns all top-level commands in help display order.
+func commandTable() []*cliCommand {
+	return []*cliCommand{
+		{Name: "add", Aliases: []string{"new"}, Usage: "add, new <path>", Summary: "Add a new session (without launching the TUI)", Run: handleAdd},
+		{Name: "launch", Usage: "launch [path]", Summary: "Add, start, and optionally send a message in one step", Run: handleLaunch},
+		{Name: "try", Usage: "try <name>", Summary: "Quick experiment (create/find dated folder + session)", Run: handleTry},
+		{Name: "up", Usage: "up", Summary: "Create missing sessions from an agent-deck.yaml manifest", Run: handleUp},
+		{Name: "import", Usage: "import <source>", Summary: "Create sessions from claude-squad, tmuxinator or tmuxp", Run: handleImport},
+		{Name: "export", Usage: "export [group]", Summary: "Write a group or the whole deck as a manifest or tmuxinator project", Run: handleExport},
+		{Name: "list", Aliases: []string{"ls"}, Usage: "list, ls", Summary: "List all sessions", Run: handleList},
+		{Name: "remove", Aliases: []string{"rm"}, Usage: "remove, rm", Summary: "Remove a session", Run: handleRemove},
+		{Name: "rename", Aliases: []string{"mv"}, Usage: "rename, mv", Summary: "Rename a session", Run: handleRename},
+		{Name: "kill", Usage: "kill <id>", Summary: "Kill a session's tmux process (keeps the session)", Run: handleSessionStop},
+		{Name: "archive", Usage: "archive", Summary: "List, view, and restore archived sessions", Run: handleArchive},
+		{Name: "restore", Usage: "restore [backup]", Summary: "List or restore automatic session backups", Run: handleRestore},
+		{Name: "prune", Usage: "prune", Summary: "Remove sessions whose tmux session no longer exists", Run: handlePrune},
+		{Name: "resurrect", Usage: "resurrect [id]", Summary: "Recreate sessions whose tmux session is gone (e.g. after a reboot)", Run: handleResurrect},
+		{Name: "status", Usage: "status", Summary: "Show session status summary", Run: handleStatus},
+		{Name: "events", Usage: "events", Summary: "Stream status changes as JSON lines", Run: handleEvents},
+		{Name: "statusline", Usage: "statusline", Summary: "Print a status summary for tmux or a desktop bar", Run: handleStatusline},
+		{Name: "attach", Usage: "attach <id>", Summary: "Attach to a session (fuzzy title match)", Run: handleSessionAttach},
+		{Name: "popup", Usage: "popup", Summary: "Quick session switcher for tmux display-popup", Run: handlePopup},
+		{Name: "fork", Usage: "fork <id>", Summary: "Fork a Claude session into a new session", Run: handleSessionFork},
+		{Name: "send", Usage: "send <id> [msg]", Summary: "Send a message to a session (reads stdin if omitted)", Run: handleSessionSend},
+		{Name: "logs", Usage: "logs <id> [-f]", Summary: "Print a session's output, optionally following it", Run: handleLogs},
+		{Name: "usage", Usage: "usage", Summary: "Show token usage and estimated cost per session", Run: handleUsage},
+		{Name: "session", Usage: "session", Summary: "Manage session lifecycle", Run: handleSession},
+		{Name: "mcp", Usage: "mcp", Summary: "Manage MCP servers", Run: handleMCP},
+		{Name: "skill", Usage: "skill", Summary: "Manage Claude skills", Run: handleSkill},
+		{Name: "codex-hooks", Usage: "codex-hooks", Summary: "Manage Codex notify hook integration", Run: withoutProfile(handleCodexHooks)},
+		{Name: "group", Usage: "group", Summary: "Manage groups", Run: handleGroup},
+		{Name: "worktree", Aliases: []string{"wt"}, Usage: "worktree, wt", Summary: "Manage git worktrees", Run: handleWorktree},
+		{Name: "daemon", Usage: "daemon", Summary: "Keep notifications, webhooks and auto-restart running without the TUI", Run: handleDaemon},
+		{Name: "web", Usage: "web", Summary: "Start TUI with web UI server running alongside"},
+		{Name: "conductor", Usage: "conductor", Summary: "Manage conductor meta-agent orchestration", Run: handleConductor},
+		{Name: "profile", Usage: "profile", Summary: "Manage profiles", Run: withoutProfile(handleProfile)},
+		{Name: "update", Usage: "update", Summary: "Check for and install updates", Run: withoutProfile(handleUpdate)},
+		{Name: "uninstall", Usage: "uninstall", Summary: "Uninstall Agent Deck", Run: withoutProfile(handleUninstall)},
+		{Name: "version", Aliases: []string{"--version", "-v"}, Usage: "version", Summary: "Show version", Run: func(string, []string) {
+			fmt.Printf("Agent Deck v%s\n", Version)
+		}},
+		{Name: "help", Aliases: []string{"--help", "-h"}, Usage: "help [command]", Summary: "Show this help, or help for a command", Run: handleHelp},
+
+		// Internal entry points invoked by hooks and MCP configs.
+		{Name: "mcp-proxy", Hidden: true, Run: func(_ string, args []string) {
+			if len(args) < 1 {
+				fmt.Fprintln(os.Stderr, "Usage: agent-deck mcp-proxy <socket-path>")
+				exit(1)
+			}
+			runMCPProxy(args[0])
+		}},
+		{Name: "hook-handler", Hidden: true, Run: func(string, []string) { handleHookHandler() }},
+		{Name: "codex-notify", Hidden: true, Run: func(string, []string) { handleCodexNotify() }},
+		{Name: "hooks", Hidden: true, Run: withoutProfile(handleHooks)},
+	}
+}
+
+// withoutProfile adapts a handler that doesn't take a profile.
+func withoutProfile(fn func(args []string)) func(string, []string) {
+	return func(_ string, args []string) { fn(args) }
+}
+
+// lookupCommand finds a command by name or alias. Returns nil if unknown.
+func lookupCommand(name string) *cliCommand {
+	for _, cmd := range commandTable() {
+		if cmd.Name == name {
+			return cmd
+		}
+		for _, alias := range cmd.Aliases {
+			if alias == name {
+				return cmd
+			}
+		}
+	}
+	return nil
+}
+
+// handleHelp prints general help, or delegates to a command's own --help.
+func handleHelp(profile string, args []string) {
+	if len(args) == 0 {
+		printHelp()
+		return
+	}
+	cmd := lookupCommand(args[0])
+	if cmd == nil || cmd.Name == "help" {
+		fmt.Fprintf(os.Stderr, "Error: unknown command: %s\n", args[0])
+		exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+	if cmd.Run == nil {
+		// TUI-launching commands print their flag help from main()
+		fmt.Printf("Usage: agent-deck %s [options]\n\n%s.\n", cmd.Name, cmd.Summary)
+		return
+	}
+	cmd.Run(profile, []string{"--help"})
+}
+
+// printCommandList prints the visible commands as an aligned two-column list.
+func printCommandList() {
+	for _, cmd := range commandTable() {
+		if cmd.Hidden {
+			continue
+		}
+		fmt.Printf("  %-16s %s\n", cmd.Usage, cmd.Summary)
+	}
+}
+
+// globalOptions holds flags accepted before the subcommand.
+type globalOptions struct {
+	profile    string
+	storage    string
+	tmuxSocket string
+	debug      bool
+}
+
+// extractGlobalFlags extracts global options from args. --storage,
+// --tmux-socket (or --socket) and --debug are only recognized before the subcommand so they can't collide with
+// subcommand flags; -p/--profile keeps its anywhere-in-args behavior.
+func extractGlobalFlags(args []string) (globalOptions, []string) {
+	var opts globalOptions
+	var remaining []string
+
+	i := 0
+parse:
+	for ; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--debug":
+			opts.debug = true
+		case strings.HasPrefix(arg, "--storage="):
+			opts.storage = strings.TrimPrefix(arg, "--storage=")
+		case arg == "--storage" && i+1 < len(args):
+			opts.storage = args[i+1]
+			i++
+		case strings.HasPrefix(arg, "--tmux-socket="), strings.HasPrefix(arg, "--socket="):
+			opts.tmuxSocket = arg[strings.Index(arg, "=")+1:]
+		case (arg == "--tmux-socket" || arg == "--socket") && i+1 < len(args):
+			opts.tmuxSocket = args[i+1]
+			i++
+		case arg == "-p" || arg == "--profile" || strings.HasPrefix(arg, "-p=") || strings.HasPrefix(arg, "--profile="):
+			remaining = append(remaining, arg)
+			if !strings.Contains(arg, "=") && i+1 < len(args) {
+				remaining = append(remaining, args[i+1])
+				i++
+			}
+		default:
+			break parse
+		}
+	}
+	remaining = append(remaining, args[i:]...)
+
+	opts.profile, remaining = extractProfileFlag(remaining)
+	return opts, remaining
+}
+
+// applyGlobalOptions propagates global options through the environment so
+// every package (and child processes such as hooks) sees the same settings.
+func applyGlobalOptions(opts globalOptions) {
+	if opts.profile != "" {
+		// Propagate explicit profile selection so config lookups (e.g., per-profile Claude config)
+		// resolve consistently across all command paths in this process.
+		_ = os.Setenv("AGENTDECK_PROFILE", opts.profile)
+	}
+	if opts.storage != "" {
+		dir := opts.storage
+		if strings.HasPrefix(dir, "~/") {
+			if home, err := os.UserHomeDir(); err == nil {
+				dir = filepath.Join(home, dir[2:])
+			}
+		}
+		if abs, err := filepath.Abs(dir); err == nil {
+			dir = abs
+		}
+		_ = os.Setenv("AGENTDECK_HOME", dir)
+	}
+	if opts.tmuxSocket != "" {
+		_ = os.Setenv("AGENTDECK_TMUX_SOCKET", opts.tmuxSocket)
+	}
+	if opts.debug {
+		_ = os.Setenv("AGENTDECK_DEBUG", "1")
+	}
+}
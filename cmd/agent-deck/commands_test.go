@@ -0,0 +1,113 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLookupCommand(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"add", "add"},
+		{"new", "add"},
+		{"ls", "list"},
+		{"wt", "worktree"},
+		{"-v", "version"},
+		{"--help", "help"},
+		{"hook-handler", "hook-handler"},
+		{"web", "web"},
+		{"fork", "fork"},
+	}
+	for _, tt := range tests {
+		cmd := lookupCommand(tt.arg)
+		if cmd == nil {
+			t.Errorf("lookupCommand(%q) = nil, want %q", tt.arg, tt.want)
+			continue
+		}
+		if cmd.Name != tt.want {
+			t.Errorf("lookupCommand(%q) = %q, want %q", tt.arg, cmd.Name, tt.want)
+		}
+	}
+
+	if cmd := lookupCommand("definitely-not-a-command"); cmd != nil {
+		t.Errorf("expected nil for unknown command, got %q", cmd.Name)
+	}
+}
+
+func TestCommandTableNoDuplicateNames(t *testing.T) {
+	seen := make(map[string]string)
+	for _, cmd := range commandTable() {
+		for _, name := range append([]string{cmd.Name}, cmd.Aliases...) {
+			if owner, ok := seen[name]; ok {
+				t.Errorf("%q registered by both %q and %q", name, owner, cmd.Name)
+			}
+			seen[name] = cmd.Name
+		}
+		if cmd.Run == nil && cmd.Name != "web" {
+			t.Errorf("command %q has no Run func", cmd.Name)
+		}
+	}
+}
+
+func TestExtractGlobalFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantOpts globalOptions
+		wantArgs []string
+	}{
+		{
+			name:     "no flags",
+			args:     []string{"list"},
+			wantArgs: []string{"list"},
+		},
+		{
+			name:     "storage and debug before command",
+			args:     []string{"--storage", "/tmp/deck", "--debug", "list", "--json"},
+			wantOpts: globalOptions{storage: "/tmp/deck", debug: true},
+			wantArgs: []string{"list", "--json"},
+		},
+		{
+			name:     "storage with equals and profile",
+			args:     []string{"--storage=/tmp/deck", "-p", "work", "status"},
+			wantOpts: globalOptions{storage: "/tmp/deck", profile: "work"},
+			wantArgs: []string{"status"},
+		},
+		{
+			name:     "tmux socket before command",
+			args:     []string{"--tmux-socket", "agentdeck", "session", "list"},
+			wantOpts: globalOptions{tmuxSocket: "agentdeck"},
+			wantArgs: []string{"session", "list"},
+		},
+		{
+			name:     "socket is short for tmux socket",
+			args:     []string{"--socket=agentdeck", "list"},
+			wantOpts: globalOptions{tmuxSocket: "agentdeck"},
+			wantArgs: []string{"list"},
+		},
+		{
+			name:     "debug after command is left to the command",
+			args:     []string{"session", "send", "x", "--debug"},
+			wantArgs: []string{"session", "send", "x", "--debug"},
+		},
+		{
+			name:     "profile after command still extracted",
+			args:     []string{"list", "--profile", "work"},
+			wantOpts: globalOptions{profile: "work"},
+			wantArgs: []string{"list"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, args := extractGlobalFlags(tt.args)
+			if opts != tt.wantOpts {
+				t.Errorf("opts = %+v, want %+v", opts, tt.wantOpts)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -36,7 +36,7 @@ func handleConductor(profile string, args []string) {
 		fmt.Fprintf(os.Stderr, "Unknown conductor command: %s\n", args[0])
 		fmt.Fprintln(os.Stderr)
 		printConductorHelp()
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -90,22 +90,22 @@ func handleConductorSetup(profile string, args []string) {
 
 	name, extras, err := parseConductorSetupArgs(fs, args)
 	if err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	if name == "" {
 		fmt.Fprintln(os.Stderr, "Error: conductor name is required")
 		fmt.Fprintln(os.Stderr, "Usage: agent-deck [-p profile] conductor setup <name>")
-		os.Exit(1)
+		exit(1)
 	}
 	if len(extras) > 0 {
 		fmt.Fprintf(os.Stderr, "Error: unexpected arguments: %s\n", strings.Join(extras, " "))
-		os.Exit(1)
+		exit(1)
 	}
 
 	if err := session.ValidateConductorName(name); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 	resolvedProfile := session.GetEffectiveProfile(profile)
 
@@ -124,7 +124,7 @@ func handleConductorSetup(profile string, args []string) {
 	config, err := session.LoadUserConfig()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	settings := config.Conductor
@@ -159,7 +159,7 @@ func handleConductorSetup(profile string, args []string) {
 			token = strings.TrimSpace(token)
 			if token == "" {
 				fmt.Fprintln(os.Stderr, "Error: token is required")
-				os.Exit(1)
+				exit(1)
 			}
 
 			fmt.Print("Your Telegram user ID: ")
@@ -168,7 +168,7 @@ func handleConductorSetup(profile string, args []string) {
 			userID, err := strconv.ParseInt(userIDStr, 10, 64)
 			if err != nil || userID == 0 {
 				fmt.Fprintln(os.Stderr, "Error: valid user ID is required")
-				os.Exit(1)
+				exit(1)
 			}
 
 			telegram = session.TelegramSettings{Token: token, UserID: userID}
@@ -196,7 +196,7 @@ func handleConductorSetup(profile string, args []string) {
 			botToken = strings.TrimSpace(botToken)
 			if botToken == "" {
 				fmt.Fprintln(os.Stderr, "Error: bot token is required")
-				os.Exit(1)
+				exit(1)
 			}
 
 			fmt.Print("Slack app token (xapp-...): ")
@@ -204,7 +204,7 @@ func handleConductorSetup(profile string, args []string) {
 			appToken = strings.TrimSpace(appToken)
 			if appToken == "" {
 				fmt.Fprintln(os.Stderr, "Error: app token is required")
-				os.Exit(1)
+				exit(1)
 			}
 
 			fmt.Print("Slack channel ID (C01234...): ")
@@ -212,7 +212,7 @@ func handleConductorSetup(profile string, args []string) {
 			channelID = strings.TrimSpace(channelID)
 			if channelID == "" {
 				fmt.Fprintln(os.Stderr, "Error: channel ID is required")
-				os.Exit(1)
+				exit(1)
 			}
 
 			slack = session.SlackSettings{BotToken: botToken, AppToken: appToken, ChannelID: channelID}
@@ -230,7 +230,7 @@ func handleConductorSetup(profile string, args []string) {
 
 		if err := session.SaveUserConfig(config); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving config: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println()
 		fmt.Println("[ok] Conductor config saved to config.toml")
@@ -239,7 +239,7 @@ func handleConductorSetup(profile string, args []string) {
 	// Step 3: Install/update shared CLAUDE.md
 	if err := session.InstallSharedClaudeMD(*sharedClaudeMD); err != nil {
 		fmt.Fprintf(os.Stderr, "Error installing shared CLAUDE.md: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 	if !*jsonOutput {
 		fmt.Println("[ok] Shared CLAUDE.md installed/updated")
@@ -252,7 +252,7 @@ func handleConductorSetup(profile string, args []string) {
 
 	if err := session.SetupConductor(name, resolvedProfile, heartbeatEnabled, *description, *claudeMD); err != nil {
 		fmt.Fprintf(os.Stderr, "Error setting up conductor %s: %v\n", name, err)
-		os.Exit(1)
+		exit(1)
 	}
 	if !*jsonOutput {
 		fmt.Printf("  [ok] Directory, CLAUDE.md, and meta.json created\n")
@@ -263,13 +263,13 @@ func handleConductorSetup(profile string, args []string) {
 	storage, err := session.NewStorageWithProfile(resolvedProfile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading storage for %s: %v\n", resolvedProfile, err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, groups, err := storage.LoadWithGroups()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error loading sessions for %s: %v\n", resolvedProfile, err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Check if session already exists
@@ -308,7 +308,7 @@ func handleConductorSetup(profile string, args []string) {
 
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving session for %s: %v\n", resolvedProfile, err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Step 6: Install heartbeat timer (if heartbeat enabled)
@@ -335,7 +335,7 @@ func handleConductorSetup(profile string, args []string) {
 
 		if err := session.InstallBridgeScript(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error installing bridge.py: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		if !*jsonOutput {
 			fmt.Println("[ok] bridge.py installed")
@@ -438,13 +438,13 @@ func handleConductorTeardown(_ string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, flagArgs)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	if !*allConductors && name == "" {
 		fmt.Fprintln(os.Stderr, "Error: conductor name or --all is required")
 		fmt.Fprintln(os.Stderr, "Usage: agent-deck conductor teardown <name> or --all")
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Auto-migrate before teardown so we can find legacy conductors
@@ -457,7 +457,7 @@ func handleConductorTeardown(_ string, args []string) {
 		targets, err = session.ListConductors()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error listing conductors: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		if len(targets) == 0 {
 			if *jsonOutput {
@@ -471,7 +471,7 @@ func handleConductorTeardown(_ string, args []string) {
 		meta, err := session.LoadConductorMeta(name)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: conductor %q not found: %v\n", name, err)
-			os.Exit(1)
+			exit(1)
 		}
 		targets = []session.ConductorMeta{*meta}
 	}
@@ -619,7 +619,7 @@ func handleConductorStatus(_ string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, flagArgs)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	settings := session.GetConductorSettings()
@@ -642,7 +642,7 @@ func handleConductorStatus(_ string, args []string) {
 		meta, err := session.LoadConductorMeta(name)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: conductor %q not found: %v\n", name, err)
-			os.Exit(1)
+			exit(1)
 		}
 		conductors = []session.ConductorMeta{*meta}
 	} else {
@@ -650,7 +650,7 @@ func handleConductorStatus(_ string, args []string) {
 		conductors, err = session.ListConductors()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error listing conductors: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -686,7 +686,8 @@ func handleConductorStatus(_ string, args []string) {
 						cs.SessionID = inst.ID
 						cs.SessionDone = true
 						_ = inst.UpdateStatus()
-						cs.Running = inst.Status == session.StatusRunning || inst.Status == session.StatusWaiting || inst.Status == session.StatusIdle
+						cs.Running = inst.Status == session.StatusRunning || inst.Status == session.StatusWaiting ||
+							inst.Status == session.StatusNeedsInput || inst.Status == session.StatusIdle
 						break
 					}
 				}
@@ -780,7 +781,7 @@ func handleConductorList(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Auto-migrate
@@ -798,7 +799,7 @@ func handleConductorList(profile string, args []string) {
 	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing conductors: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if *jsonOutput {
@@ -831,7 +832,8 @@ func handleConductorList(profile string, args []string) {
 					if inst.Title == sessionTitle {
 						found = true
 						_ = inst.UpdateStatus()
-						if inst.Status == session.StatusRunning || inst.Status == session.StatusWaiting || inst.Status == session.StatusIdle {
+						if inst.Status == session.StatusRunning || inst.Status == session.StatusWaiting ||
+							inst.Status == session.StatusNeedsInput || inst.Status == session.StatusIdle {
 							statusText = "running"
 						} else {
 							statusText = "stopped"
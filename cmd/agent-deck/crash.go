@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/term"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// crashLogName is the file under the agent-deck directory that crash
+// reports are appended to.
+const crashLogName = "crash.log"
+
+// terminalResetSequence undoes what the TUI turns on: it leaves the
+// alternate screen, shows the cursor and stops mouse, focus and bracketed
+// paste reporting.
+const terminalResetSequence = "\x1b[?1049l\x1b[?25h" +
+	"\x1b[?1000l\x1b[?1002l\x1b[?1003l\x1b[?1006l" +
+	"\x1b[?1004l\x1b[?2004l"
+
+// savedTerminal is the stdin state from before the TUI switched the
+// terminal to raw mode, restored if it crashes.
+var savedTerminal *term.State
+
+// saveTerminalState records the terminal state for handleCrash. Call it
+// before starting the TUI.
+func saveTerminalState() {
+	if state, err := term.GetState(int(os.Stdin.Fd())); err == nil {
+		savedTerminal = state
+	}
+}
+
+// crashLogPath returns where crash reports go.
+func crashLogPath() string {
+	dir, err := session.GetAgentDeckDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, crashLogName)
+}
+
+// logCrashOutput also sends the runtime's report of an unrecovered panic
+// in any other goroutine to the crash log. Those skip handleCrash, which
+// only sees panics on the main goroutine.
+func logCrashOutput() {
+	path := crashLogPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close() // SetCrashOutput keeps its own duplicate
+	_ = debug.SetCrashOutput(f, debug.CrashOptions{})
+}
+
+// handleCrash recovers a panic on the main goroutine outside the TUI, puts
+// the terminal back the way it was and writes a crash report before
+// exiting. Deferred at the top of main. The TUI's panics are Bubble Tea's
+// to catch; see crashReportingModel.
+func handleCrash() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	stack := debug.Stack()
+
+	fmt.Fprint(os.Stdout, terminalResetSequence)
+	if savedTerminal != nil {
+		_ = term.Restore(int(os.Stdin.Fd()), savedTerminal)
+	}
+
+	path := crashLogPath()
+	if err := appendCrashReport(path, r, stack, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "agent-deck crashed: %v\n\n%s\n", r, stack)
+		fmt.Fprintf(os.Stderr, "Could not write crash report: %v\n", err)
+	} else {
+		fmt.Fprintf(os.Stderr, "agent-deck crashed: %v\n", r)
+		fmt.Fprintf(os.Stderr, "Crash report written to %s\n", path)
+	}
+	exit(2)
+}
+
+// crashReportingModel writes a crash report for a panic in the model's
+// Update or View, or in a command it returns, then panics on so Bubble Tea
+// restores the terminal and Run returns tea.ErrProgramPanic.
+type crashReportingModel struct {
+	tea.Model
+}
+
+func (m crashReportingModel) Init() tea.Cmd {
+	defer reportPanic()
+	return reportingCmd(m.Model.Init())
+}
+
+func (m crashReportingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer reportPanic()
+	model, cmd := m.Model.Update(msg)
+	m.Model = model
+	return m, reportingCmd(cmd)
+}
+
+func (m crashReportingModel) View() string {
+	defer reportPanic()
+	return m.Model.View()
+}
+
+// reportingCmd wraps cmd, and the commands of a batch it returns, so their
+// panics are reported too. Bubble Tea runs commands on their own goroutines.
+func reportingCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		defer reportPanic()
+		msg := cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, c := range batch {
+				batch[i] = reportingCmd(c)
+			}
+		}
+		return msg
+	}
+}
+
+// reportPanic appends a report for a panic in progress to the crash log,
+// then panics again with the same value. Deferred.
+func reportPanic() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	_ = appendCrashReport(crashLogPath(), r, debug.Stack(), time.Now())
+	panic(r)
+}
+
+// appendCrashReport appends the report for panic r to the file at path.
+func appendCrashReport(path string, r any, stack []byte, at time.Time) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	writeCrashReport(f, r, stack, at)
+	return f.Close()
+}
+
+func writeCrashReport(w io.Writer, r any, stack []byte, at time.Time) {
+	fmt.Fprintf(w, "=== agent-deck %s crashed at %s ===\n", Version, at.Format(time.RFC3339))
+	fmt.Fprintf(w, "panic: %v\n\n%s\n", r, stack)
+}
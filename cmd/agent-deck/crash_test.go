@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestAppendCrashReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", crashLogName)
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, r := range []any{"first", "second"} {
+		if err := appendCrashReport(path, r, []byte("goroutine 1 [running]:"), at); err != nil {
+			t.Fatalf("appendCrashReport: %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := string(data)
+	if got := strings.Count(report, "=== agent-deck "+Version+" crashed at 2026-01-02T03:04:05Z ==="); got != 2 {
+		t.Errorf("want a header per crash, got %d in:\n%s", got, report)
+	}
+	for _, want := range []string{"panic: first", "panic: second", "goroutine 1 [running]:"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
+
+type panickyModel struct{}
+
+func (panickyModel) Init() tea.Cmd { return nil }
+func (panickyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg == "boom" {
+		panic("update failed")
+	}
+	return panickyModel{}, func() tea.Msg { panic("command failed") }
+}
+func (panickyModel) View() string { return "" }
+
+func TestCrashReportingModel(t *testing.T) {
+	t.Setenv("AGENTDECK_HOME", t.TempDir())
+	m := crashReportingModel{panickyModel{}}
+
+	mustPanic := func(name string, fn func()) {
+		t.Helper()
+		defer func() {
+			if recover() == nil {
+				t.Errorf("%s: the panic should go on to Bubble Tea", name)
+			}
+		}()
+		fn()
+	}
+	mustPanic("update", func() { m.Update("boom") })
+	_, cmd := m.Update("ok")
+	mustPanic("command", func() { cmd() })
+
+	data, err := os.ReadFile(crashLogPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"panic: update failed", "panic: command failed"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("crash log missing %q:\n%s", want, data)
+		}
+	}
+}
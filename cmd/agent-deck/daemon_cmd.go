@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/daemon"
+	"github.com/asheshgoplani/agent-deck/internal/ipc"
+	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleDaemon dispatches daemon subcommands
+func handleDaemon(profile string, args []string) {
+	if len(args) == 0 {
+		handleDaemonStart(profile, nil)
+		return
+	}
+
+	switch args[0] {
+	case "start":
+		handleDaemonStart(profile, args[1:])
+	case "run":
+		handleDaemonRun(profile, args[1:])
+	case "stop":
+		handleDaemonStop(profile, args[1:])
+	case "status":
+		handleDaemonStatus(profile, args[1:])
+	case "help", "--help", "-h":
+		printDaemonHelp()
+	default:
+		fmt.Printf("Unknown daemon command: %s\n", args[0])
+		fmt.Println()
+		printDaemonHelp()
+		exit(1)
+	}
+}
+
+// printDaemonHelp prints usage for daemon commands
+func printDaemonHelp() {
+	fmt.Println("Usage: agent-deck daemon <command> [options]")
+	fmt.Println()
+	fmt.Println("Keep polling statuses, sending notifications and webhooks, restarting")
+	fmt.Println("crashed sessions and feeding queued prompts while no TUI is open. While")
+	fmt.Println("a TUI is open for the profile it does this itself, and the daemon waits.")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  start    Start the daemon in the background (default)")
+	fmt.Println("  run      Run the daemon in the foreground (for systemd, launchd)")
+	fmt.Println("  stop     Stop the daemon")
+	fmt.Println("  status   Show whether the daemon is running")
+	fmt.Println()
+	fmt.Println("Each profile has its own daemon, logging to daemon.log in the profile directory.")
+	fmt.Println("It answers list, status, create, kill and send requests (JSON-RPC 2.0, one per")
+	fmt.Println("line) on daemon.sock there; list, status and kill use it while it runs.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  agent-deck daemon")
+	fmt.Println("  agent-deck -p work daemon start")
+	fmt.Println("  agent-deck daemon status --json")
+	fmt.Println("  agent-deck daemon stop")
+}
+
+// handleDaemonStart starts `agent-deck daemon run` detached from the terminal
+func handleDaemonStart(profile string, args []string) {
+	fs := flag.NewFlagSet("daemon start", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	if pid := daemon.RunningPID(profile); pid != 0 {
+		out.Error(fmt.Sprintf("daemon is already running (pid %d)", pid), ErrCodeAlreadyExists)
+		exit(1)
+	}
+
+	logPath, err := daemonLogPath(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	logFile, err := os.OpenFile(logPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to open %s: %v", logPath, err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	defer logFile.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to find agent-deck: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	cmd := exec.Command(exe, daemonRunArgs(os.Args[1:])...)
+	cmd.Stdout = logFile // Anything not logged, e.g. a panic
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		out.Error(fmt.Sprintf("failed to start daemon: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	// Wait for it to claim its pid file, or to fail
+	deadline := time.After(5 * time.Second)
+	for daemon.RunningPID(profile) != cmd.Process.Pid {
+		select {
+		case <-exited:
+			out.Error(fmt.Sprintf("daemon exited during startup; see %s", FormatPath(logPath)), ErrCodeInvalidOperation)
+			exit(1)
+		case <-deadline:
+			out.Error(fmt.Sprintf("daemon did not start in time; see %s", FormatPath(logPath)), ErrCodeInvalidOperation)
+			exit(1)
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+
+	out.Success(fmt.Sprintf("Daemon started (pid %d), logging to %s", cmd.Process.Pid, FormatPath(logPath)), map[string]interface{}{
+		"success": true,
+		"pid":     cmd.Process.Pid,
+		"log":     logPath,
+	})
+}
+
+// daemonRunArgs turns this process's arguments (global flags, then
+// "daemon ...") into those for `daemon run`, keeping the global flags.
+func daemonRunArgs(args []string) []string {
+	var runArgs []string
+	for _, arg := range args {
+		if arg == "daemon" {
+			break
+		}
+		runArgs = append(runArgs, arg)
+	}
+	return append(runArgs, "daemon", "run")
+}
+
+// daemonLogPath returns the daemon's log file for profile.
+func daemonLogPath(profile string) (string, error) {
+	dir, err := session.GetProfileDir(session.GetEffectiveProfile(profile))
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, daemon.LogFileName), nil
+}
+
+// callDaemon asks profile's daemon to answer method, decoding its result
+// into result. It reports false when no daemon answered, so the caller
+// reads storage itself; errors are the daemon's answer.
+func callDaemon(profile, method string, params, result any) (bool, error) {
+	client, err := ipc.Dial(profile)
+	if err != nil {
+		return false, nil
+	}
+	defer client.Close()
+
+	err = client.Call(method, params, result)
+	var rpcErr *ipc.Error
+	if err != nil && !errors.As(err, &rpcErr) {
+		return false, nil // The daemon went away mid-call
+	}
+	return true, err
+}
+
+// daemonErrCode maps an error from the daemon to a CLI error code.
+func daemonErrCode(err error) string {
+	var rpcErr *ipc.Error
+	if !errors.As(err, &rpcErr) {
+		return ErrCodeInvalidOperation
+	}
+	switch rpcErr.Code {
+	case ipc.CodeNotFound:
+		return ErrCodeNotFound
+	case ipc.CodeAmbiguous:
+		return ErrCodeAmbiguous
+	default:
+		return ErrCodeInvalidOperation
+	}
+}
+
+// handleDaemonRun runs the daemon in the foreground until it is signalled
+func handleDaemonRun(profile string, args []string) {
+	fs := flag.NewFlagSet("daemon run", flag.ExitOnError)
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	logPath, err := daemonLogPath(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+	level := "info"
+	if os.Getenv("AGENTDECK_DEBUG") != "" {
+		level = "debug"
+	}
+	logging.Init(logging.Config{
+		LogDir:   filepath.Dir(logPath),
+		FileName: daemon.LogFileName,
+		Level:    level,
+		Format:   "text",
+	})
+	defer logging.Shutdown()
+
+	d, err := daemon.New(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := d.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, daemon.ErrRunning) {
+			exit(2)
+		}
+		exit(1)
+	}
+}
+
+// handleDaemonStop signals the daemon to exit and waits for it
+func handleDaemonStop(profile string, args []string) {
+	fs := flag.NewFlagSet("daemon stop", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
+
+	pid := daemon.RunningPID(profile)
+	if pid == 0 {
+		out.Error("daemon is not running", ErrCodeNotFound)
+		exit(2)
+	}
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		out.Error(fmt.Sprintf("failed to stop daemon (pid %d): %v", pid, err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	for deadline := time.Now().Add(5 * time.Second); daemon.RunningPID(profile) == pid; {
+		if time.Now().After(deadline) {
+			out.Error(fmt.Sprintf("daemon (pid %d) did not exit", pid), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	out.Success(fmt.Sprintf("Daemon stopped (pid %d)", pid), map[string]interface{}{
+		"success": true,
+		"pid":     pid,
+	})
+}
+
+// handleDaemonStatus reports whether the daemon is running
+func handleDaemonStatus(profile string, args []string) {
+	fs := flag.NewFlagSet("daemon status", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	out := NewCLIOutput(*jsonOutput, false)
+
+	pid := daemon.RunningPID(profile)
+	human := "Daemon is not running"
+	if pid != 0 {
+		human = fmt.Sprintf("Daemon is running (pid %d)", pid)
+	}
+	out.Print(human+"\n", map[string]interface{}{
+		"running": pid != 0,
+		"pid":     pid,
+		"profile": session.GetEffectiveProfile(profile),
+	})
+	if pid == 0 {
+		exit(1)
+	}
+}
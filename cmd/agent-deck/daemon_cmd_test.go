@@ -0,0 +1,21 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDaemonRunArgs(t *testing.T) {
+	tests := []struct {
+		args, want []string
+	}{
+		{[]string{"daemon"}, []string{"daemon", "run"}},
+		{[]string{"daemon", "start", "--json"}, []string{"daemon", "run"}},
+		{[]string{"-p", "work", "--tmux-socket", "deck", "daemon", "start"}, []string{"-p", "work", "--tmux-socket", "deck", "daemon", "run"}},
+	}
+	for _, tt := range tests {
+		if got := daemonRunArgs(tt.args); !slices.Equal(got, tt.want) {
+			t.Errorf("daemonRunArgs(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
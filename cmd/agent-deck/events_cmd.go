@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/daemon"
+	"github.com/asheshgoplani/agent-deck/internal/notify"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// eventStatuses are the statuses --status accepts.
+var eventStatuses = []session.Status{
+	session.StatusRunning, session.StatusWaiting, session.StatusNeedsInput,
+	session.StatusIdle, session.StatusError, session.StatusStarting,
+}
+
+// handleEvents prints status changes as JSON lines until interrupted
+func handleEvents(profile string, args []string) {
+	fs := flag.NewFlagSet("events", flag.ExitOnError)
+	group := fs.String("group", "", "Only sessions in this group and its subgroups")
+	statusFilter := fs.String("status", "", "Only changes to these statuses (comma-separated)")
+	initial := fs.Bool("initial", false, "Start with one event per session giving its current status")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck events [options]")
+		fmt.Println()
+		fmt.Println("Print one JSON line per session status change, as webhooks receive them,")
+		fmt.Println("until interrupted. Statuses are polled like the TUI does, whether or not")
+		fmt.Println("a TUI or daemon is running.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck events")
+		fmt.Println("  agent-deck events --status waiting,needs_input | jq -r .title")
+		fmt.Println("  agent-deck -p work events --group backend --initial >> deck.jsonl")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	statuses, err := parseEventStatuses(*statusFilter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	enc := json.NewEncoder(os.Stdout)
+	emit := func(inst *session.Instance, oldStatus, newStatus session.Status) {
+		if !eventMatches(inst, newStatus, *group, statuses) {
+			return
+		}
+		event := notify.NewWebhookEvent(inst.ID, inst.Title, inst.Tool, inst.GroupPath, inst.ProjectPath,
+			string(oldStatus), string(newStatus), time.Now())
+		if err := enc.Encode(event); err != nil {
+			exit(1) // Nobody is reading any more
+		}
+	}
+
+	stream := &eventStream{last: make(map[string]session.Status), initial: *initial, emit: emit}
+	if err := daemon.Watch(ctx, profile, stream.handle); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+}
+
+// eventStream turns status polls into events. It remembers the last status
+// it reported for each session itself, because Watch reloads sessions from
+// storage when another process saves them, and a reloaded session starts
+// out with that process's view of its status until its next poll.
+type eventStream struct {
+	last    map[string]session.Status
+	primed  bool // The first full poll, which polls every session, has been seen
+	initial bool // Report every session's status at the first full poll
+	emit    func(inst *session.Instance, oldStatus, newStatus session.Status)
+}
+
+// handle reports what changed in one poll.
+func (s *eventStream) handle(diff session.StatusDiff) {
+	if !s.primed {
+		if !diff.Full {
+			return // The first full poll covers these
+		}
+		for _, inst := range diff.Instances {
+			status := inst.GetStatusThreadSafe()
+			s.last[inst.ID] = status
+			if s.initial {
+				s.emit(inst, "", status)
+			}
+		}
+		s.primed = true
+		return
+	}
+
+	for _, c := range diff.Changes {
+		old, seen := s.last[c.Instance.ID]
+		if !seen {
+			old = c.Old // Created since
+		} else if old == c.New {
+			continue // A reloaded session catching up
+		}
+		s.last[c.Instance.ID] = c.New
+		s.emit(c.Instance, old, c.New)
+	}
+}
+
+// parseEventStatuses parses --status.
+func parseEventStatuses(list string) ([]session.Status, error) {
+	var statuses []session.Status
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		status := session.Status(s)
+		valid := false
+		for _, known := range eventStatuses {
+			valid = valid || status == known
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown status %q (want running, waiting, needs_input, idle, error or starting)", s)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// eventMatches reports whether inst going to status passes the --group and
+// --status filters.
+func eventMatches(inst *session.Instance, status session.Status, group string, statuses []session.Status) bool {
+	if group != "" && inst.GroupPath != group && !strings.HasPrefix(inst.GroupPath, group+"/") {
+		return false
+	}
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
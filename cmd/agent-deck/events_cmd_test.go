@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestParseEventStatuses(t *testing.T) {
+	statuses, err := parseEventStatuses("waiting, needs_input,")
+	if err != nil {
+		t.Fatalf("parseEventStatuses: %v", err)
+	}
+	if len(statuses) != 2 || statuses[0] != session.StatusWaiting || statuses[1] != session.StatusNeedsInput {
+		t.Errorf("got %v", statuses)
+	}
+	if statuses, err := parseEventStatuses(""); err != nil || statuses != nil {
+		t.Errorf("empty filter = %v, %v; want no statuses", statuses, err)
+	}
+	if _, err := parseEventStatuses("waiting,done"); err == nil {
+		t.Error("expected an error for an unknown status")
+	}
+}
+
+func TestEventMatches(t *testing.T) {
+	inst := &session.Instance{GroupPath: "work/backend"}
+	waiting := []session.Status{session.StatusWaiting}
+	tests := []struct {
+		group    string
+		statuses []session.Status
+		status   session.Status
+		want     bool
+	}{
+		{"", nil, session.StatusRunning, true},
+		{"work", nil, session.StatusRunning, true},
+		{"work/backend", waiting, session.StatusWaiting, true},
+		{"work/back", nil, session.StatusRunning, false},
+		{"personal", nil, session.StatusRunning, false},
+		{"", waiting, session.StatusRunning, false},
+	}
+	for _, tt := range tests {
+		if got := eventMatches(inst, tt.status, tt.group, tt.statuses); got != tt.want {
+			t.Errorf("eventMatches(group %q, statuses %v, %s) = %v, want %v", tt.group, tt.statuses, tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestEventStream(t *testing.T) {
+	api := &session.Instance{ID: "a", Title: "api", Status: session.StatusIdle}
+	web := &session.Instance{ID: "b", Title: "web", Status: session.StatusRunning}
+
+	for _, initial := range []bool{false, true} {
+		var got []string
+		stream := &eventStream{last: make(map[string]session.Status), initial: initial,
+			emit: func(inst *session.Instance, oldStatus, newStatus session.Status) {
+				got = append(got, inst.Title+":"+string(oldStatus)+">"+string(newStatus))
+			}}
+		api.Status, web.Status = session.StatusIdle, session.StatusRunning
+
+		// A change before the first full poll is left to it
+		stream.handle(session.StatusDiff{Changes: []session.StatusChange{{Instance: api, Old: session.StatusError, New: session.StatusIdle}}})
+		stream.handle(session.StatusDiff{Instances: []*session.Instance{api}, Full: true})
+
+		// A session created since
+		web.Status = session.StatusWaiting
+		stream.handle(session.StatusDiff{Changes: []session.StatusChange{{Instance: web, Old: session.StatusRunning, New: session.StatusWaiting}}})
+
+		// A copy reloaded from storage with a stale status, which the poll
+		// corrects, changed nothing
+		reloaded := &session.Instance{ID: "a", Title: "api", Status: session.StatusIdle}
+		stream.handle(session.StatusDiff{Changes: []session.StatusChange{{Instance: reloaded, Old: session.StatusRunning, New: session.StatusIdle}}})
+		stream.handle(session.StatusDiff{Changes: []session.StatusChange{{Instance: reloaded, Old: session.StatusIdle, New: session.StatusError}}})
+
+		want := []string{"web:running>waiting", "api:idle>error"}
+		if initial {
+			want = append([]string{"api:>idle"}, want...)
+		}
+		if len(got) != len(want) {
+			t.Errorf("initial=%v: got %v, want %v", initial, got, want)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("initial=%v: got %v, want %v", initial, got, want)
+				break
+			}
+		}
+	}
+}
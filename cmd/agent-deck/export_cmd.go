@@ -0,0 +1,110 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleExport writes a group, or the whole deck, as a manifest or
+// tmuxinator project
+func handleExport(profile string, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", session.ExportManifestFormat, "Output format: manifest or tmuxinator")
+	output := fs.String("output", "", "Write to this file instead of stdout")
+	outputShort := fs.String("o", "", "Write to this file (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck export [group] [options]")
+		fmt.Println()
+		fmt.Println("Write the sessions in a group (and its subgroups), or every session, as")
+		fmt.Println("a file that recreates them on another machine.")
+		fmt.Println()
+		fmt.Println("Formats:")
+		fmt.Println("  manifest     An agent-deck.yaml for 'agent-deck up'. Paths inside the")
+		fmt.Println("               output file's directory are written relative to it")
+		fmt.Println("  tmuxinator   A tmuxinator project with one window per session, which")
+		fmt.Println("               'agent-deck import tmuxinator' also reads")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck export work -o agent-deck.yaml")
+		fmt.Println("  agent-deck export > deck.yaml")
+		fmt.Println("  agent-deck export work --format tmuxinator -o ~/.config/tmuxinator/work.yml")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	if fs.NArg() > 1 {
+		fs.Usage()
+		exit(1)
+	}
+
+	// The export itself goes to stdout; errors go to stderr
+	out := NewCLIOutput(false, false)
+
+	*format = strings.ToLower(*format)
+	if !slices.Contains(session.ExportFormats, *format) {
+		out.Error(fmt.Sprintf("unknown format %q (supported: %s)", *format, strings.Join(session.ExportFormats, ", ")), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	_, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	groupPath := ""
+	if name := fs.Arg(0); name != "" {
+		groupTree := session.NewGroupTreeWithGroups(instances, groups)
+		groupPath = resolveGroupPathForAdd(groupTree, name)
+		if _, exists := groupTree.Groups[groupPath]; !exists {
+			out.Error(fmt.Sprintf("group not found: %s", name), ErrCodeNotFound)
+			exit(2)
+		}
+	}
+
+	outPath := mergeFlags(*output, *outputShort)
+	baseDir := ""
+	if outPath != "" && *format == session.ExportManifestFormat {
+		if baseDir, err = filepath.Abs(filepath.Dir(outPath)); err != nil {
+			out.Error(fmt.Sprintf("failed to resolve %s: %v", outPath, err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+	}
+
+	manifest, err := session.ExportManifest(instances, groupPath, baseDir)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		exit(2)
+	}
+	var data []byte
+	if *format == session.ExportTmuxinatorFormat {
+		data, err = session.MarshalTmuxinator(manifest)
+	} else {
+		data, err = session.MarshalManifest(manifest)
+	}
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to encode %s: %v", *format, err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	if outPath == "" {
+		_, _ = os.Stdout.Write(data)
+		return
+	}
+	if err := os.WriteFile(outPath, data, 0o644); err != nil {
+		out.Error(fmt.Sprintf("failed to write %s: %v", outPath, err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "Exported %d sessions to %s\n", len(manifest.Sessions), FormatPath(outPath))
+}
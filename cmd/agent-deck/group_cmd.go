@@ -3,7 +3,6 @@ package main
 import (
 	"flag"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
@@ -35,7 +34,7 @@ func handleGroup(profile string, args []string) {
 		fmt.Printf("Unknown group command: %s\n", args[0])
 		fmt.Println()
 		printGroupHelp()
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -78,7 +77,7 @@ func handleGroupList(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
@@ -87,13 +86,13 @@ func handleGroupList(profile string, args []string) {
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, groups, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Build group tree
@@ -102,10 +101,11 @@ func handleGroupList(profile string, args []string) {
 	if *jsonOutput {
 		// Build JSON output structure
 		type groupStatusJSON struct {
-			Running int `json:"running"`
-			Waiting int `json:"waiting"`
-			Idle    int `json:"idle"`
-			Error   int `json:"error"`
+			Running    int `json:"running"`
+			NeedsInput int `json:"needs_input"`
+			Waiting    int `json:"waiting"`
+			Idle       int `json:"idle"`
+			Error      int `json:"error"`
 		}
 
 		type groupJSON struct {
@@ -127,6 +127,8 @@ func handleGroupList(profile string, args []string) {
 						switch sess.Status {
 						case session.StatusRunning:
 							status.Running++
+						case session.StatusNeedsInput:
+							status.NeedsInput++
 						case session.StatusWaiting:
 							status.Waiting++
 						case session.StatusIdle:
@@ -248,7 +250,7 @@ func handleGroupList(profile string, args []string) {
 		sessCount := groupTree.SessionCountForGroup(g.Path)
 		statusStr := ""
 		if sessCount > 0 {
-			running, waiting, idle := 0, 0, 0
+			running, needsInput, waiting, idle := 0, 0, 0, 0
 			// Count status recursively for all sessions in this group and subgroups
 			for path, subGroup := range groupTree.Groups {
 				if path == g.Path || strings.HasPrefix(path, g.Path+"/") {
@@ -257,6 +259,8 @@ func handleGroupList(profile string, args []string) {
 						switch sess.Status {
 						case session.StatusRunning:
 							running++
+						case session.StatusNeedsInput:
+							needsInput++
 						case session.StatusWaiting:
 							waiting++
 						case session.StatusIdle:
@@ -269,6 +273,9 @@ func handleGroupList(profile string, args []string) {
 			if running > 0 {
 				parts = append(parts, fmt.Sprintf("● %d", running))
 			}
+			if needsInput > 0 {
+				parts = append(parts, fmt.Sprintf("◆ %d", needsInput))
+			}
 			if waiting > 0 {
 				parts = append(parts, fmt.Sprintf("◐ %d", waiting))
 			}
@@ -319,7 +326,7 @@ func handleGroupCreate(profile string, args []string) {
 	args = reorderGroupArgs(args)
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
@@ -328,20 +335,20 @@ func handleGroupCreate(profile string, args []string) {
 	if name == "" {
 		out.Error("group name is required", ErrCodeNotFound)
 		fmt.Println("Usage: agent-deck group create <name> [--parent <group>]")
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Load sessions and groups
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, groups, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Build group tree
@@ -355,7 +362,7 @@ func handleGroupCreate(profile string, args []string) {
 		parentPath := normalizeGroupPath(*parent)
 		if _, exists := groupTree.Groups[parentPath]; !exists {
 			out.Error(fmt.Sprintf("parent group '%s' not found", *parent), ErrCodeNotFound)
-			os.Exit(2)
+			exit(2)
 		}
 		newGroup = groupTree.CreateSubgroup(parentPath, name)
 		fullPath = newGroup.Path
@@ -380,7 +387,7 @@ func handleGroupCreate(profile string, args []string) {
 	// Save
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if existingGroup {
@@ -406,6 +413,8 @@ func handleGroupUpdate(profile string, args []string) {
 	fs := flag.NewFlagSet("group update", flag.ExitOnError)
 	defaultPath := fs.String("default-path", "", "Default working directory for new sessions in this group")
 	clearDefaultPath := fs.Bool("clear-default-path", false, "Clear group default working directory")
+	mute := fs.String("mute", "", "Notifications for the group's sessions and subgroups: on (muted) or off")
+	sortFlag := fs.String("sort", "", "Session order: manual, activity, status, name or created")
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	quiet := fs.Bool("quiet", false, "Minimal output")
 	quietShort := fs.Bool("q", false, "Minimal output (short)")
@@ -421,12 +430,14 @@ func handleGroupUpdate(profile string, args []string) {
 		fmt.Println("Examples:")
 		fmt.Println("  agent-deck group update mobile --default-path /path/to/repo")
 		fmt.Println("  agent-deck group update mobile --clear-default-path")
+		fmt.Println("  agent-deck group update experiments --mute on")
+		fmt.Println("  agent-deck group update work --sort activity")
 	}
 
 	args = reorderGroupArgs(args)
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
@@ -434,25 +445,47 @@ func handleGroupUpdate(profile string, args []string) {
 	name := fs.Arg(0)
 	if name == "" {
 		out.Error("group name is required", ErrCodeNotFound)
-		fmt.Println("Usage: agent-deck group update <name> [--default-path <path>|--clear-default-path]")
-		os.Exit(1)
+		fmt.Println("Usage: agent-deck group update <name> [--default-path <path>|--clear-default-path] [--mute on|off] [--sort <mode>]")
+		exit(1)
 	}
 
-	if (*defaultPath == "" && !*clearDefaultPath) || (*defaultPath != "" && *clearDefaultPath) {
-		out.Error("specify exactly one of --default-path or --clear-default-path", ErrCodeInvalidOperation)
-		os.Exit(1)
+	if *defaultPath != "" && *clearDefaultPath {
+		out.Error("specify only one of --default-path or --clear-default-path", ErrCodeInvalidOperation)
+		exit(1)
+	}
+	if *defaultPath == "" && !*clearDefaultPath && *mute == "" && *sortFlag == "" {
+		out.Error("specify --default-path, --clear-default-path, --mute or --sort", ErrCodeInvalidOperation)
+		exit(1)
+	}
+	var sortMode session.GroupSortMode
+	if *sortFlag != "" {
+		m, ok := session.ParseGroupSortMode(*sortFlag)
+		if !ok {
+			out.Error(fmt.Sprintf("invalid --sort %q: use manual, activity, status, name or created", *sortFlag), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		sortMode = m
+	}
+	var muted bool
+	if *mute != "" {
+		m, err := parseOnOff(*mute)
+		if err != nil {
+			out.Error(fmt.Sprintf("invalid --mute: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		muted = m
 	}
 
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, groups, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	groupTree := session.NewGroupTreeWithGroups(instances, groups)
@@ -470,18 +503,41 @@ func handleGroupUpdate(profile string, args []string) {
 	}
 	if !exists {
 		out.Error(fmt.Sprintf("group '%s' not found", name), ErrCodeNotFound)
-		os.Exit(2)
+		exit(2)
 	}
 
+	if *mute != "" {
+		groupTree.Groups[groupPath].Muted = muted
+	}
+	if *sortFlag != "" {
+		groupTree.Groups[groupPath].SortMode = sortMode
+	}
 	if *clearDefaultPath {
 		groupTree.SetDefaultPathForGroup(groupPath, "")
-	} else {
+	} else if *defaultPath != "" {
 		groupTree.SetDefaultPathForGroup(groupPath, *defaultPath)
 	}
 
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
+	}
+
+	if *defaultPath == "" && !*clearDefaultPath && *mute == "" {
+		out.Success(fmt.Sprintf("Sorted group %s by %s", groupPath, sortMode.Label()), map[string]interface{}{
+			"success": true,
+			"path":    groupPath,
+			"sort":    sortMode.Label(),
+		})
+		return
+	}
+	if *defaultPath == "" && !*clearDefaultPath {
+		out.Success(fmt.Sprintf("Set mute %s for group: %s", formatOnOff(muted), groupPath), map[string]interface{}{
+			"success": true,
+			"path":    groupPath,
+			"muted":   muted,
+		})
+		return
 	}
 
 	currentDefaultPath := groupTree.DefaultPathForGroup(groupPath)
@@ -524,7 +580,7 @@ func handleGroupDelete(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
@@ -533,20 +589,20 @@ func handleGroupDelete(profile string, args []string) {
 	if name == "" {
 		out.Error("group name is required", ErrCodeNotFound)
 		fmt.Println("Usage: agent-deck group delete <name> [--force]")
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Load sessions and groups
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, groups, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Build group tree
@@ -569,13 +625,13 @@ func handleGroupDelete(profile string, args []string) {
 
 	if !exists {
 		out.Error(fmt.Sprintf("group '%s' not found", name), ErrCodeNotFound)
-		os.Exit(2)
+		exit(2)
 	}
 
 	// Check if group is protected (default group)
 	if groupPath == session.DefaultGroupPath {
 		out.Error("cannot delete the default group", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Count sessions in group and subgroups
@@ -589,7 +645,7 @@ func handleGroupDelete(profile string, args []string) {
 	// Check if group has sessions and --force not specified
 	if sessionCount > 0 && !*force {
 		out.Error(fmt.Sprintf("group '%s' has %d sessions. Use --force to move them to parent.", name, sessionCount), ErrCodeGroupNotEmpty)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Determine where sessions will be moved
@@ -615,7 +671,7 @@ func handleGroupDelete(profile string, args []string) {
 	// Save
 	if err := storage.SaveWithGroups(groupTree.GetAllInstances(), groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	out.Success(fmt.Sprintf("Deleted group: %s", name), map[string]interface{}{
@@ -652,7 +708,7 @@ func handleGroupMove(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	out := NewCLIOutput(*jsonOutput, *quiet || *quietShort)
@@ -663,26 +719,26 @@ func handleGroupMove(profile string, args []string) {
 	if sessionID == "" {
 		out.Error("session identifier is required", ErrCodeNotFound)
 		fmt.Println("Usage: agent-deck group move <session-id> <group>")
-		os.Exit(1)
+		exit(1)
 	}
 
 	if fs.NArg() < 2 {
 		out.Error("target group is required", ErrCodeNotFound)
 		fmt.Println("Usage: agent-deck group move <session-id> <group>")
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Load sessions and groups
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, groups, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Find the session
@@ -690,9 +746,9 @@ func handleGroupMove(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -726,7 +782,7 @@ func handleGroupMove(profile string, args []string) {
 	// Save
 	if err := storage.SaveWithGroups(groupTree.GetAllInstances(), groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	toGroup := targetGroupPath
@@ -42,7 +42,7 @@ func mapEventToStatus(event string) string {
 	case "Stop":
 		return "waiting" // Claude finished, back at prompt waiting for user
 	case "PermissionRequest":
-		return "waiting" // Claude needs permission approval
+		return "waiting" // Claude needs permission approval (UpdateStatus shows it as needs_input)
 	case "Notification":
 		// Notification events with permission_prompt|elicitation_dialog matcher
 		// are mapped to "waiting" by the caller after checking the matcher.
@@ -132,11 +132,7 @@ func writeHookStatus(instanceID, status, sessionID, event string) {
 
 // getHooksDir returns the path to the hooks status directory.
 func getHooksDir() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(os.TempDir(), ".agent-deck", "hooks")
-	}
-	return filepath.Join(home, ".agent-deck", "hooks")
+	return session.GetHooksDir()
 }
 
 // cleanStaleHookFiles removes hook status files older than 24 hours.
@@ -166,7 +162,7 @@ func cleanStaleHookFiles() {
 func handleHooks(args []string) {
 	if len(args) == 0 {
 		fmt.Fprintln(os.Stderr, "Usage: agent-deck hooks <install|uninstall|status>")
-		os.Exit(1)
+		exit(1)
 	}
 
 	switch args[0] {
@@ -179,7 +175,7 @@ func handleHooks(args []string) {
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown hooks subcommand: %s\n", args[0])
 		fmt.Fprintln(os.Stderr, "Usage: agent-deck hooks <install|uninstall|status>")
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -188,7 +184,7 @@ func handleHooksInstall() {
 	installed, err := session.InjectClaudeHooks(configDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error installing hooks: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 	if installed {
 		fmt.Println("Claude Code hooks installed successfully.")
@@ -203,7 +199,7 @@ func handleHooksUninstall() {
 	removed, err := session.RemoveClaudeHooks(configDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error removing hooks: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 	if removed {
 		fmt.Println("Claude Code hooks removed successfully.")
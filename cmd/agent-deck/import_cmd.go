@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleImport creates sessions from another tool's configuration
+func handleImport(profile string, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	start := fs.Bool("start", false, "Also start the imported sessions")
+	dryRun := fs.Bool("dry-run", false, "Show what would be created without changing anything")
+	baseDir := fs.String("path", "", "Directory for windows without an absolute root (default: current directory)")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck import <source> [project|file] [options]")
+		fmt.Println()
+		fmt.Println("Create sessions from another tool's configuration. Like 'agent-deck up',")
+		fmt.Println("sessions that already exist (same title and path) are left untouched.")
+		fmt.Println()
+		fmt.Println("Sources:")
+		fmt.Println("  claude-squad   Instances in ~/.claude-squad/state.json (or the given file),")
+		fmt.Println("                 in the 'claude-squad' group, running in their worktrees")
+		fmt.Println("  tmuxinator     A project (name or .yml file): one session per window,")
+		fmt.Println("                 in a group named after the project")
+		fmt.Println("  tmuxp          A workspace (name or .yaml/.json file), like tmuxinator")
+		fmt.Println()
+		fmt.Println("A window's command comes from the window or its first pane; other panes")
+		fmt.Println("aren't imported. A missing or relative root is taken from the current")
+		fmt.Println("directory (or --path), as tmuxinator and tmuxp do.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck import claude-squad")
+		fmt.Println("  agent-deck import tmuxinator blog --dry-run")
+		fmt.Println("  agent-deck import tmuxp ./workspace.yaml --start")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	quietMode := *quiet || *quietShort
+	out := NewCLIOutput(*jsonOutput, quietMode)
+
+	rest := fs.Args()
+	if len(rest) == 0 || len(rest) > 2 {
+		fs.Usage()
+		exit(1)
+	}
+	source := strings.ToLower(rest[0])
+	if !slices.Contains(session.ImportSources, source) {
+		out.Error(fmt.Sprintf("unknown import source %q (supported: %s)", rest[0], strings.Join(session.ImportSources, ", ")), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	var nameOrPath string
+	if len(rest) == 2 {
+		nameOrPath = rest[1]
+	}
+
+	dir := *baseDir
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to resolve path: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	manifest, path, err := session.ImportManifest(source, nameOrPath, dir)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeNotFound)
+		exit(2)
+	}
+
+	applyManifest(out, profile, manifest, path, manifestOptions{
+		start: *start, dryRun: *dryRun, jsonOutput: *jsonOutput, quiet: quietMode,
+	})
+}
@@ -73,7 +73,7 @@ func handleLaunch(profile string, args []string) {
 	args = reorderArgsForFlagParsing(args)
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -86,14 +86,14 @@ func handleLaunch(profile string, args []string) {
 		path, err = os.Getwd()
 		if err != nil {
 			out.Error(fmt.Sprintf("failed to get current directory: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
 		var err error
 		path, err = filepath.Abs(path)
 		if err != nil {
 			out.Error(fmt.Sprintf("failed to resolve path: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -101,11 +101,11 @@ func handleLaunch(profile string, args []string) {
 	info, err := os.Stat(path)
 	if err != nil {
 		out.Error(fmt.Sprintf("path does not exist: %s", path), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 	if !info.IsDir() {
 		out.Error(fmt.Sprintf("path is not a directory: %s", path), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Merge flags
@@ -127,7 +127,7 @@ func handleLaunch(profile string, args []string) {
 		tool := detectTool(sessionCommand)
 		if tool != "claude" {
 			out.Error("--resume-session only works with Claude sessions (-c claude)", ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -136,24 +136,24 @@ func handleLaunch(profile string, args []string) {
 	if wtBranch != "" {
 		if !git.IsGitRepo(path) {
 			out.Error(fmt.Sprintf("%s is not a git repository", path), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		repoRoot, err := git.GetWorktreeBaseRoot(path)
 		if err != nil {
 			out.Error(fmt.Sprintf("failed to get repo root: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := git.ValidateBranchName(wtBranch); err != nil {
 			out.Error(fmt.Sprintf("invalid branch name: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		branchExists := git.BranchExists(repoRoot, wtBranch)
 		if createNewBranch && branchExists {
 			out.Error(fmt.Sprintf("branch '%s' already exists (remove -b flag to use existing branch)", wtBranch), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		wtSettings := session.GetWorktreeSettings()
@@ -172,17 +172,17 @@ func handleLaunch(profile string, args []string) {
 
 		if err := os.MkdirAll(filepath.Dir(worktreePath), 0o755); err != nil {
 			out.Error(fmt.Sprintf("failed to create parent directory: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if _, err := os.Stat(worktreePath); err == nil {
 			out.Error(fmt.Sprintf("worktree already exists at %s", worktreePath), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := git.CreateWorktree(repoRoot, worktreePath, wtBranch); err != nil {
 			out.Error(fmt.Sprintf("failed to create worktree: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		worktreeRepoRoot = repoRoot
@@ -193,7 +193,7 @@ func handleLaunch(profile string, args []string) {
 	storage, instances, groups, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve parent session if specified
@@ -203,11 +203,11 @@ func handleLaunch(profile string, args []string) {
 		parentInstance, errMsg, _ = ResolveSession(sessionParent, instances)
 		if parentInstance == nil {
 			out.Error(errMsg, ErrCodeNotFound)
-			os.Exit(1)
+			exit(1)
 		}
 		if parentInstance.IsSubSession() {
 			out.Error("cannot create sub-session of a sub-session (single level only)", ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 		sessionGroup = parentInstance.GroupPath
 	}
@@ -227,7 +227,7 @@ func handleLaunch(profile string, args []string) {
 				fmt.Sprintf("session already exists: %s (%s)", existingInst.Title, existingInst.ID),
 				ErrCodeAlreadyExists,
 			)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -243,14 +243,7 @@ func handleLaunch(profile string, args []string) {
 		newInstance.SetParentWithPath(parentInstance.ID, parentInstance.ProjectPath)
 	}
 
-	if sessionCommand != "" {
-		newInstance.Tool = detectTool(sessionCommand)
-		if toolDef := session.GetToolDef(newInstance.Tool); toolDef != nil {
-			newInstance.Command = toolDef.Command
-		} else {
-			newInstance.Command = sessionCommand
-		}
-	}
+	setInstanceCommand(newInstance, sessionCommand)
 
 	if *wrapper != "" {
 		newInstance.Wrapper = *wrapper
@@ -286,7 +279,7 @@ func handleLaunch(profile string, args []string) {
 
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save session: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Attach MCPs if specified
@@ -295,12 +288,12 @@ func handleLaunch(profile string, args []string) {
 		for _, mcpName := range mcpFlags {
 			if _, exists := availableMCPs[mcpName]; !exists {
 				out.Error(fmt.Sprintf("MCP '%s' not found in config.toml", mcpName), ErrCodeNotFound)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 		if err := session.WriteMCPJsonFromConfig(path, mcpFlags); err != nil {
 			out.Error(fmt.Sprintf("failed to write MCPs: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -308,12 +301,12 @@ func handleLaunch(profile string, args []string) {
 	if initialMessage != "" {
 		if err := newInstance.StartWithMessage(initialMessage); err != nil {
 			out.Error(fmt.Sprintf("failed to start session: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
 		if err := newInstance.Start(); err != nil {
 			out.Error(fmt.Sprintf("failed to start session: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -323,7 +316,7 @@ func handleLaunch(profile string, args []string) {
 	// Save again with updated state (session ID, tmux name)
 	if err := saveSessionData(storage, instances); err != nil {
 		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Send message if provided and StartWithMessage wasn't used
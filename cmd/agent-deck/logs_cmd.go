@@ -0,0 +1,169 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// handleLogs prints a session's pane output, optionally following new output
+func handleLogs(profile string, args []string) {
+	fs := flag.NewFlagSet("logs", flag.ExitOnError)
+	follow := fs.Bool("follow", false, "Keep printing new output until the session exits")
+	followShort := fs.Bool("f", false, "Keep printing new output (short)")
+	lines := fs.Int("lines", 100, "Number of scrollback lines to print first (0 or less = all captured)")
+	linesShort := fs.Int("n", 100, "Number of scrollback lines (short)")
+	interval := fs.Duration("interval", time.Second, "Poll interval when following")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck logs <id|title> [options]")
+		fmt.Println()
+		fmt.Println("Print a session's terminal output without attaching.")
+		fmt.Println("Output is read from the pane scrollback (last 2000 lines).")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck logs my-project")
+		fmt.Println("  agent-deck logs my-project -n 20 -f")
+		fmt.Println("  agent-deck logs my-project -n 0         # All captured lines")
+		fmt.Println("  agent-deck logs my-project --follow --interval 250ms")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	lineCount := *lines
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "n" {
+			lineCount = *linesShort
+		}
+	})
+
+	_, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSessionFuzzy(fs.Arg(0), instances)
+	if inst == nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", errMsg)
+		if errCode == ErrCodeNotFound {
+			exit(2)
+		}
+		exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	tmuxSess := inst.GetTmuxSession()
+	if tmuxSess == nil || !inst.Exists() {
+		fmt.Fprintf(os.Stderr, "Error: session '%s' is not running\n", inst.Title)
+		exit(1)
+	}
+
+	prev, err := captureLogLines(tmuxSess)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+	printLogLines(tailLines(prev, lineCount))
+
+	if !*follow && !*followShort {
+		return
+	}
+
+	for {
+		time.Sleep(*interval)
+		if !tmuxSess.Exists() {
+			fmt.Fprintf(os.Stderr, "Session '%s' exited\n", inst.Title)
+			return
+		}
+		cur, err := captureLogLines(tmuxSess)
+		if err != nil {
+			continue
+		}
+		added, ok := appendedLines(prev, cur)
+		if !ok {
+			// Screen was cleared or redrawn beyond recognition: start over
+			// from the visible tail rather than dumping the whole history.
+			fmt.Println("--")
+			added = tailLines(cur, lineCount)
+		}
+		printLogLines(added)
+		prev = cur
+	}
+}
+
+// captureLogLines captures the pane scrollback as lines, without trailing blanks
+func captureLogLines(tmuxSess *tmux.Session) ([]string, error) {
+	content, err := tmuxSess.CaptureFullHistory()
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// tailLines returns the last n lines, or all of them when n is 0 or less
+func tailLines(lines []string, n int) []string {
+	if n > 0 && len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
+
+func printLogLines(lines []string) {
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
+
+// appendedLines returns the lines of cur that come after the end of prev.
+// It anchors on the last few lines of prev; since agent TUIs redraw their
+// bottom rows (prompt boxes, spinners), it retries with up to 10 trailing
+// lines of prev dropped. Returns ok=false when no anchor is found.
+func appendedLines(prev, cur []string) ([]string, bool) {
+	const anchorLen = 5
+	const maxDrop = 10
+
+	if len(prev) == 0 {
+		return cur, true
+	}
+
+	for drop := 0; drop <= maxDrop && drop < len(prev); drop++ {
+		base := prev[:len(prev)-drop]
+		n := anchorLen
+		if len(base) < n {
+			n = len(base)
+		}
+		anchor := base[len(base)-n:]
+		for end := len(cur); end >= n; end-- {
+			if linesEqual(cur[end-n:end], anchor) {
+				return cur[end:], true
+			}
+		}
+	}
+	return nil, false
+}
+
+func linesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
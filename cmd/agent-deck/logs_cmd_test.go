@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTailLines(t *testing.T) {
+	lines := []string{"a", "b", "c"}
+	tests := []struct {
+		n    int
+		want []string
+	}{
+		{2, []string{"b", "c"}},
+		{5, lines},
+		{0, lines},
+		{-1, lines},
+	}
+	for _, tt := range tests {
+		if got := tailLines(lines, tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("tailLines(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestAppendedLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		prev   []string
+		cur    []string
+		want   []string
+		wantOK bool
+	}{
+		{
+			name:   "no previous output",
+			prev:   nil,
+			cur:    []string{"a", "b"},
+			want:   []string{"a", "b"},
+			wantOK: true,
+		},
+		{
+			name:   "unchanged",
+			prev:   []string{"a", "b", "c"},
+			cur:    []string{"a", "b", "c"},
+			want:   []string{},
+			wantOK: true,
+		},
+		{
+			name:   "appended",
+			prev:   []string{"a", "b", "c"},
+			cur:    []string{"a", "b", "c", "d", "e"},
+			want:   []string{"d", "e"},
+			wantOK: true,
+		},
+		{
+			name:   "scrolled off the top",
+			prev:   []string{"1", "2", "3", "4", "5", "6", "7"},
+			cur:    []string{"3", "4", "5", "6", "7", "8"},
+			want:   []string{"8"},
+			wantOK: true,
+		},
+		{
+			name:   "redrawn prompt at the bottom",
+			prev:   []string{"out1", "out2", "out3", "out4", "out5", "> prompt"},
+			cur:    []string{"out1", "out2", "out3", "out4", "out5", "out6", "> prompt"},
+			want:   []string{"out6", "> prompt"},
+			wantOK: true,
+		},
+		{
+			name:   "screen cleared",
+			prev:   []string{"a", "b", "c"},
+			cur:    []string{"x", "y"},
+			want:   nil,
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := appendedLines(tt.prev, tt.cur)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("appendedLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
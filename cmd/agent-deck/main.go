@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -22,9 +23,13 @@ import (
 	"github.com/muesli/termenv"
 
 	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/ipc"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/mcppool"
+	"github.com/asheshgoplani/agent-deck/internal/notify"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 	"github.com/asheshgoplani/agent-deck/internal/ui"
 	"github.com/asheshgoplani/agent-deck/internal/update"
 	"github.com/asheshgoplani/agent-deck/internal/web"
@@ -182,98 +187,63 @@ func initColorProfile() {
 }
 
 func main() {
-	// Extract global -p/--profile flag before subcommand dispatch
-	profile, args := extractProfileFlag(os.Args[1:])
-	if profile != "" {
-		// Propagate explicit profile selection so config lookups (e.g., per-profile Claude config)
-		// resolve consistently across all command paths in this process.
-		_ = os.Setenv("AGENTDECK_PROFILE", profile)
+	// Restores the terminal and writes crash.log if anything below panics
+	defer handleCrash()
+
+	// Extract global flags (-p/--profile, --storage, --tmux-socket/--socket, --debug) before subcommand dispatch
+	opts, args := extractGlobalFlags(os.Args[1:])
+	applyGlobalOptions(opts)
+	profile := opts.profile
+
+	// Session logs and MCP logs live in the data directory, which --storage,
+	// AGENTDECK_HOME and XDG_DATA_HOME can move
+	if dataDir, err := session.GetAgentDeckDir(); err == nil {
+		tmux.SetDataDir(dataDir)
+		mcppool.SetLogDir(filepath.Join(dataDir, "logs"))
 	}
 
+	// Non-default profiles get their own tmux name prefix (agentdeck_<profile>_...)
+	// so their sessions never mix with another profile's
+	if effective := session.GetEffectiveProfile(profile); effective != session.DefaultProfile {
+		tmux.SetSessionNamespace(effective)
+	}
+
+	// Run sessions on a dedicated tmux server when configured (tmux -L <name>)
+	tmux.SetSocketName(session.GetTmuxSocket())
+
+	mux, err := tmux.NewMultiplexer(session.GetMultiplexer())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+	tmux.SetMultiplexer(mux)
+
 	var webEnabled bool
 	var webArgs []string
 
 	// Handle subcommands
 	if len(args) > 0 {
-		switch args[0] {
-		case "version", "--version", "-v":
-			fmt.Printf("Agent Deck v%s\n", Version)
-			return
-		case "help", "--help", "-h":
-			printHelp()
-			return
-		case "add":
-			handleAdd(profile, args[1:])
-			return
-		case "list", "ls":
-			handleList(profile, args[1:])
-			return
-		case "remove", "rm":
-			handleRemove(profile, args[1:])
-			return
-		case "rename", "mv":
-			handleRename(profile, args[1:])
-			return
-		case "status":
-			handleStatus(profile, args[1:])
-			return
-		case "profile":
-			handleProfile(args[1:])
-			return
-		case "update":
-			handleUpdate(args[1:])
-			return
-		case "session":
-			handleSession(profile, args[1:])
-			return
-		case "mcp":
-			handleMCP(profile, args[1:])
-			return
-		case "skill":
-			handleSkill(profile, args[1:])
-			return
-		case "mcp-proxy":
-			if len(args) < 2 {
-				fmt.Fprintln(os.Stderr, "Usage: agent-deck mcp-proxy <socket-path>")
-				os.Exit(1)
+		cmd := lookupCommand(args[0])
+		if cmd == nil {
+			fmt.Fprintf(os.Stderr, "Error: unknown command: %s\n", args[0])
+			fmt.Fprintln(os.Stderr, "Run 'agent-deck help' for usage.")
+			exit(1)
+			return // unreachable, satisfies staticcheck SA5011
+		}
+		if cmd.Run != nil {
+			// --debug logs commands to debug.log as it does the TUI
+			if os.Getenv("AGENTDECK_DEBUG") != "" {
+				if baseDir, err := session.GetAgentDeckDir(); err == nil {
+					logging.Init(newLoggingConfig(baseDir, true))
+					defer logging.Shutdown()
+				}
 			}
-			runMCPProxy(args[1])
-			return
-		case "group":
-			handleGroup(profile, args[1:])
-			return
-		case "try":
-			handleTry(profile, args[1:])
-			return
-		case "launch":
-			handleLaunch(profile, args[1:])
-			return
-		case "conductor":
-			handleConductor(profile, args[1:])
-			return
-		case "worktree", "wt":
-			handleWorktree(profile, args[1:])
-			return
-		case "web":
-			webEnabled = true
-			webArgs = append(webArgs, args[1:]...)
-			// fall through to TUI launch below
-		case "uninstall":
-			handleUninstall(args[1:])
-			return
-		case "hook-handler":
-			handleHookHandler()
-			return
-		case "codex-notify":
-			handleCodexNotify()
-			return
-		case "hooks":
-			handleHooks(args[1:])
-			return
-		case "codex-hooks":
-			handleCodexHooks(args[1:])
+			cmd.Run(profile, args[1:])
 			return
 		}
+		// web: fall through to TUI launch below
+		webEnabled = true
+		webArgs = append(webArgs, args[1:]...)
 	}
 
 	// Block TUI launch inside a managed session to prevent infinite nesting.
@@ -289,9 +259,18 @@ func main() {
 		fmt.Fprintln(os.Stderr, "  agent-deck list                    # List sessions")
 		fmt.Fprintln(os.Stderr, "")
 		fmt.Fprintln(os.Stderr, "To open the TUI, detach first with Ctrl+Q.")
-		os.Exit(1)
+		exit(1)
 	}
 
+	// Switching profiles in the TUI quits it and relaunches on the new profile.
+	// Deferred first so it runs after all other cleanup.
+	var switchProfile string
+	defer func() {
+		if switchProfile != "" {
+			relaunchWithProfile(switchProfile)
+		}
+	}()
+
 	// Set version for UI update checking
 	ui.SetVersion(Version)
 
@@ -305,12 +284,12 @@ func main() {
 		return
 	}
 
-	// Check if tmux is available
-	if _, err := exec.LookPath("tmux"); err != nil {
-		fmt.Println("Error: tmux not found in PATH")
-		fmt.Println("\nAgent Deck requires tmux. Install with:")
-		fmt.Println("  brew install tmux")
-		os.Exit(1)
+	// Check if the multiplexer (tmux unless configured otherwise) is available
+	if _, err := exec.LookPath(mux.Name()); err != nil {
+		fmt.Printf("Error: %s not found in PATH\n", mux.Name())
+		fmt.Printf("\nAgent Deck requires %s. Install with:\n", mux.Name())
+		fmt.Printf("  brew install %s\n", mux.Name())
+		exit(1)
 	}
 
 	// Create storage early to register instance via SQLite
@@ -330,7 +309,7 @@ func main() {
 			if electErr == nil && !isFirst {
 				fmt.Println("Error: agent-deck is already running for this profile")
 				fmt.Println("Set [instances] allow_multiple = true in config.toml to allow multiple instances")
-				os.Exit(1)
+				exit(1)
 			}
 		}
 	}
@@ -344,7 +323,7 @@ func main() {
 			_ = db.ResignPrimary()
 			_ = db.UnregisterInstance()
 		}
-		os.Exit(0)
+		exit(0)
 	}()
 
 	// Set up structured logging (JSONL format with rotation)
@@ -352,52 +331,7 @@ func main() {
 	// When not set, logs are discarded to avoid TUI interference
 	debugMode := os.Getenv("AGENTDECK_DEBUG") != ""
 	if baseDir, err := session.GetAgentDeckDir(); err == nil {
-		logCfg := logging.Config{
-			Debug:                 debugMode,
-			LogDir:                baseDir,
-			Level:                 "debug",
-			Format:                "json",
-			MaxSizeMB:             10,
-			MaxBackups:            5,
-			MaxAgeDays:            10,
-			Compress:              true,
-			RingBufferSize:        10 * 1024 * 1024,
-			AggregateIntervalSecs: 30,
-		}
-
-		// Override defaults from user config if available
-		if userCfg, err := session.LoadUserConfig(); err == nil {
-			ls := userCfg.Logs
-			if ls.DebugLevel != "" {
-				logCfg.Level = ls.DebugLevel
-			}
-			if ls.DebugFormat != "" {
-				logCfg.Format = ls.DebugFormat
-			}
-			if ls.DebugMaxMB > 0 {
-				logCfg.MaxSizeMB = ls.DebugMaxMB
-			}
-			if ls.DebugBackups > 0 {
-				logCfg.MaxBackups = ls.DebugBackups
-			}
-			if ls.DebugRetentionDays > 0 {
-				logCfg.MaxAgeDays = ls.DebugRetentionDays
-			}
-			if ls.DebugCompress {
-				logCfg.Compress = ls.DebugCompress
-			}
-			if ls.RingBufferMB > 0 {
-				logCfg.RingBufferSize = ls.RingBufferMB * 1024 * 1024
-			}
-			if ls.PprofEnabled {
-				logCfg.PprofEnabled = ls.PprofEnabled
-			}
-			if ls.AggregateIntervalS > 0 {
-				logCfg.AggregateIntervalSecs = ls.AggregateIntervalS
-			}
-		}
-
-		logging.Init(logCfg)
+		logging.Init(newLoggingConfig(baseDir, debugMode))
 		defer logging.Shutdown()
 
 		if debugMode {
@@ -435,7 +369,7 @@ func main() {
 		server, err := buildWebServer(effectiveProfile, webArgs, liveMenuData)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: web server setup failed: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		go func() {
 			if err := server.Start(); err != nil {
@@ -451,10 +385,17 @@ func main() {
 		}()
 	}
 
+	// Bubble Tea catches panics in the TUI and restores the terminal;
+	// crashReportingModel keeps a report in crash.log first
+	saveTerminalState()
+	logCrashOutput()
 	p := tea.NewProgram(
-		homeModel,
+		crashReportingModel{homeModel},
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
+		tea.WithReportFocus(), // desktop notifications only while unfocused
+		// Terminal notifications share the output, written between frames
+		tea.WithOutput(notify.Stdout()),
 	)
 
 	// Start maintenance worker (background goroutine, respects config toggle)
@@ -465,8 +406,87 @@ func main() {
 	})
 
 	if _, err := p.Run(); err != nil {
+		if errors.Is(err, tea.ErrProgramPanic) {
+			fmt.Fprintf(os.Stderr, "agent-deck crashed. Crash report written to %s\n", crashLogPath())
+			exit(2)
+		}
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		exit(1)
+	}
+	switchProfile = homeModel.SwitchProfile()
+}
+
+// exit flushes the debug log and exits with code. Use it instead of os.Exit,
+// which skips the deferred logging.Shutdown that would otherwise flush it.
+func exit(code int) {
+	logging.Shutdown()
+	os.Exit(code)
+}
+
+// newLoggingConfig returns the debug log settings for baseDir: the defaults,
+// overridden by [logs] in config.toml.
+func newLoggingConfig(baseDir string, debug bool) logging.Config {
+	logCfg := logging.Config{
+		Debug:                 debug,
+		LogDir:                baseDir,
+		Level:                 "debug",
+		Format:                "json",
+		MaxSizeMB:             10,
+		MaxBackups:            5,
+		MaxAgeDays:            10,
+		Compress:              true,
+		RingBufferSize:        10 * 1024 * 1024,
+		AggregateIntervalSecs: 30,
+	}
+
+	// Override defaults from user config if available
+	if userCfg, err := session.LoadUserConfig(); err == nil {
+		ls := userCfg.Logs
+		if ls.DebugLevel != "" {
+			logCfg.Level = ls.DebugLevel
+		}
+		if ls.DebugFormat != "" {
+			logCfg.Format = ls.DebugFormat
+		}
+		if ls.DebugMaxMB > 0 {
+			logCfg.MaxSizeMB = ls.DebugMaxMB
+		}
+		if ls.DebugBackups > 0 {
+			logCfg.MaxBackups = ls.DebugBackups
+		}
+		if ls.DebugRetentionDays > 0 {
+			logCfg.MaxAgeDays = ls.DebugRetentionDays
+		}
+		if ls.DebugCompress {
+			logCfg.Compress = ls.DebugCompress
+		}
+		if ls.RingBufferMB > 0 {
+			logCfg.RingBufferSize = ls.RingBufferMB * 1024 * 1024
+		}
+		if ls.PprofEnabled {
+			logCfg.PprofEnabled = ls.PprofEnabled
+		}
+		if ls.AggregateIntervalS > 0 {
+			logCfg.AggregateIntervalSecs = ls.AggregateIntervalS
+		}
+	}
+	return logCfg
+}
+
+// relaunchWithProfile replaces the current process with agent-deck running on
+// another profile, keeping the other original arguments (e.g. "web").
+func relaunchWithProfile(profile string) {
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to switch profile: %v\n", err)
+		exit(1)
+	}
+	_, rest := extractProfileFlag(os.Args[1:])
+	argv := append([]string{exe, "-p", profile}, rest...)
+	if err := syscall.Exec(exe, argv, os.Environ()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to switch profile: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Run: agent-deck -p %s\n", profile)
+		exit(1)
 	}
 }
 
@@ -522,6 +542,7 @@ func reorderArgsForFlagParsing(args []string) []string {
 		"-m": true, "--message": true,
 		"-p": true, "--parent": true,
 		"--mcp":     true,
+		"--name":    true,
 		"--wrapper": true,
 		"-w":        true, "--worktree": true,
 		"--location":       true,
@@ -632,11 +653,27 @@ func resolveGroupPathForAdd(groupTree *session.GroupTree, groupSelector string)
 	return groupSelector
 }
 
+// setInstanceCommand sets the tool and command for a new instance from a
+// user-supplied command string. Empty commands leave the instance unchanged.
+func setInstanceCommand(inst *session.Instance, command string) {
+	if command == "" {
+		return
+	}
+	inst.Tool = detectTool(command)
+	// For custom tools, resolve the actual shell command (e.g. "glm" → "claude")
+	if toolDef := session.GetToolDef(inst.Tool); toolDef != nil {
+		inst.Command = toolDef.Command
+	} else {
+		inst.Command = command
+	}
+}
+
 // handleAdd adds a new session from CLI
 func handleAdd(profile string, args []string) {
 	fs := flag.NewFlagSet("add", flag.ExitOnError)
 	title := fs.String("title", "", "Session title (defaults to folder name)")
 	titleShort := fs.String("t", "", "Session title (short)")
+	name := fs.String("name", "", "Session name (alias for --title)")
 	group := fs.String("group", "", "Group path (defaults to parent folder)")
 	groupShort := fs.String("g", "", "Group path (short)")
 	command := fs.String("cmd", "", "Command to run (e.g., 'claude', 'opencode')")
@@ -656,6 +693,7 @@ func handleAdd(profile string, args []string) {
 	newBranch := fs.Bool("b", false, "Create new branch (use with --worktree)")
 	newBranchLong := fs.Bool("new-branch", false, "Create new branch")
 	worktreeLocation := fs.String("location", "", "Worktree location: sibling, subdirectory, or custom path")
+	projectEnv := fs.String("env", "", "Load the project's .envrc/.env before starting: auto, direnv, dotenv or off (default: [shell] project_env)")
 
 	// MCP flag - can be specified multiple times
 	var mcpFlags []string
@@ -669,8 +707,9 @@ func handleAdd(profile string, args []string) {
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck add [path] [options]")
+		fmt.Println("       agent-deck new [path] [options]")
 		fmt.Println()
-		fmt.Println("Add a new session to Agent Deck.")
+		fmt.Println("Add a new session to Agent Deck without launching the TUI.")
 		fmt.Println()
 		fmt.Println("Arguments:")
 		fmt.Println("  [path]    Project directory (defaults to current directory)")
@@ -688,6 +727,8 @@ func handleAdd(profile string, args []string) {
 		fmt.Println("  agent-deck add -t \"Research\" -c claude --mcp memory --mcp sequential-thinking /tmp/x")
 		fmt.Println("  agent-deck add -c opencode --wrapper \"nvim +'terminal {command}' +'startinsert'\" .")
 		fmt.Println("  agent-deck add --quick -c claude .   # Auto-generated name")
+		fmt.Println("  agent-deck new --name api -c claude -g work ~/src/api")
+		fmt.Println("  agent-deck add --env direnv -c claude .  # Start with the project's .envrc loaded")
 		fmt.Println()
 		fmt.Println("Worktree Examples:")
 		fmt.Println("  agent-deck add -w feature/login .    # Create worktree for existing branch")
@@ -701,7 +742,7 @@ func handleAdd(profile string, args []string) {
 	args = reorderArgsForFlagParsing(args)
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Path argument is optional; if omitted with -g/--group, we'll try group default_path.
@@ -718,17 +759,23 @@ func handleAdd(profile string, args []string) {
 	createNewBranch := *newBranch || *newBranchLong
 
 	// Merge short and long flags
-	sessionTitle := mergeFlags(*title, *titleShort)
+	sessionTitle := mergeFlags(mergeFlags(*title, *name), *titleShort)
 	sessionGroup := mergeFlags(*group, *groupShort)
 	sessionCommand := mergeFlags(*command, *commandShort)
 	sessionParent := mergeFlags(*parent, *parentShort)
 
+	envMode, err := session.ParseProjectEnvMode(*projectEnv)
+	if err != nil {
+		fmt.Printf("Error: invalid --env: %v\n", err)
+		exit(1)
+	}
+
 	// Validate --resume-session requires Claude
 	if *resumeSession != "" {
 		tool := detectTool(sessionCommand)
 		if tool != "claude" {
 			fmt.Println("Error: --resume-session only works with Claude sessions (-c claude)")
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -736,13 +783,13 @@ func handleAdd(profile string, args []string) {
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		fmt.Printf("Error: failed to initialize storage: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, groups, err := storage.LoadWithGroups()
 	if err != nil {
 		fmt.Printf("Error: failed to load sessions: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	groupTree := session.NewGroupTreeWithGroups(instances, groups)
@@ -754,13 +801,13 @@ func handleAdd(profile string, args []string) {
 		parentInstance, errMsg, _ = ResolveSession(sessionParent, instances)
 		if parentInstance == nil {
 			fmt.Printf("Error: %s\n", errMsg)
-			os.Exit(1)
+			exit(1)
 			return // unreachable, satisfies staticcheck SA5011
 		}
 		// Sub-sessions cannot have sub-sessions (single level only)
 		if parentInstance.IsSubSession() {
 			fmt.Printf("Error: cannot create sub-session of a sub-session (single level only)\n")
-			os.Exit(1)
+			exit(1)
 		}
 		// Inherit group from parent
 		sessionGroup = parentInstance.GroupPath
@@ -776,13 +823,13 @@ func handleAdd(profile string, args []string) {
 			path, err = os.Getwd()
 			if err != nil {
 				fmt.Printf("Error: failed to get current directory: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		} else {
 			path, err = filepath.Abs(rawPathArg)
 			if err != nil {
 				fmt.Printf("Error: failed to resolve path: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 	} else {
@@ -794,7 +841,7 @@ func handleAdd(profile string, args []string) {
 			path, err = os.Getwd()
 			if err != nil {
 				fmt.Printf("Error: failed to get current directory: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 	}
@@ -803,11 +850,11 @@ func handleAdd(profile string, args []string) {
 	info, err := os.Stat(path)
 	if err != nil {
 		fmt.Printf("Error: path does not exist: %s\n", path)
-		os.Exit(1)
+		exit(1)
 	}
 	if !info.IsDir() {
 		fmt.Printf("Error: path is not a directory: %s\n", path)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Handle worktree creation
@@ -816,20 +863,20 @@ func handleAdd(profile string, args []string) {
 		// Validate path is a git repo
 		if !git.IsGitRepo(path) {
 			fmt.Fprintf(os.Stderr, "Error: %s is not a git repository\n", path)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Get repo root (resolve through worktrees to prevent nesting)
 		repoRoot, err := git.GetWorktreeBaseRoot(path)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to get repo root: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Pre-validate branch name for better error messages
 		if err := git.ValidateBranchName(wtBranch); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: invalid branch name: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Check -b flag logic: if -b is passed, branch must NOT exist (user wants new branch)
@@ -840,7 +887,7 @@ func handleAdd(profile string, args []string) {
 				"Error: branch '%s' already exists (remove -b flag to use existing branch)\n",
 				wtBranch,
 			)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Determine worktree location: CLI flag overrides config
@@ -862,20 +909,20 @@ func handleAdd(profile string, args []string) {
 		// Ensure parent directory exists (needed for subdirectory mode)
 		if err := os.MkdirAll(filepath.Dir(worktreePath), 0o755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create parent directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Check if worktree already exists
 		if _, err := os.Stat(worktreePath); err == nil {
 			fmt.Fprintf(os.Stderr, "Error: worktree already exists at %s\n", worktreePath)
 			fmt.Fprintf(os.Stderr, "Tip: Use 'agent-deck add %s' to add the existing worktree\n", worktreePath)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Create worktree
 		if err := git.CreateWorktree(repoRoot, worktreePath, wtBranch); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: failed to create worktree: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		fmt.Printf("Created worktree at: %s\n", worktreePath)
@@ -890,7 +937,7 @@ func handleAdd(profile string, args []string) {
 	}
 
 	// Track if user provided explicit title or we auto-generated from folder name
-	userProvidedTitle := (mergeFlags(*title, *titleShort) != "")
+	userProvidedTitle := (mergeFlags(mergeFlags(*title, *name), *titleShort) != "")
 	isQuick := *quickCreate || *quickCreateShort
 
 	if isQuick && !userProvidedTitle {
@@ -903,7 +950,7 @@ func handleAdd(profile string, args []string) {
 		// User provided explicit title - check for exact duplicate (same title AND path)
 		if isDupe, existingInst := isDuplicateSession(instances, sessionTitle, path); isDupe {
 			fmt.Printf("Session already exists with same title and path: %s (%s)\n", existingInst.Title, existingInst.ID)
-			os.Exit(0)
+			exit(0)
 		}
 	}
 
@@ -921,20 +968,13 @@ func handleAdd(profile string, args []string) {
 	}
 
 	// Set command if provided
-	if sessionCommand != "" {
-		newInstance.Tool = detectTool(sessionCommand)
-		// For custom tools, resolve the actual shell command (e.g. "glm" → "claude")
-		if toolDef := session.GetToolDef(newInstance.Tool); toolDef != nil {
-			newInstance.Command = toolDef.Command
-		} else {
-			newInstance.Command = sessionCommand
-		}
-	}
+	setInstanceCommand(newInstance, sessionCommand)
 
 	// Set wrapper if provided
 	if *wrapper != "" {
 		newInstance.Wrapper = *wrapper
 	}
+	newInstance.ProjectEnv = envMode
 
 	// Set worktree fields if created
 	if worktreePath != "" {
@@ -972,7 +1012,7 @@ func handleAdd(profile string, args []string) {
 
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		fmt.Printf("Error: failed to save session: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Attach MCPs if specified
@@ -986,14 +1026,14 @@ func handleAdd(profile string, args []string) {
 				for name := range availableMCPs {
 					fmt.Printf("  • %s\n", name)
 				}
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
 		// Write MCPs to .mcp.json
 		if err := session.WriteMCPJsonFromConfig(path, mcpFlags); err != nil {
 			fmt.Printf("Error: failed to write MCPs: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -1070,6 +1110,7 @@ func handleList(profile string, args []string) {
 	fs := flag.NewFlagSet("list", flag.ExitOnError)
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	allProfiles := fs.Bool("all", false, "List sessions from all profiles")
+	tag := fs.String("tag", "", "Only list sessions with this tag")
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck list [options]")
@@ -1083,31 +1124,30 @@ func handleList(profile string, args []string) {
 		fmt.Println("  agent-deck list                    # List from default profile")
 		fmt.Println("  agent-deck -p work list            # List from 'work' profile")
 		fmt.Println("  agent-deck list --all              # List from all profiles")
+		fmt.Println("  agent-deck list --tag bug          # Only sessions tagged 'bug'")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	if *allProfiles {
-		handleListAllProfiles(*jsonOutput)
+		handleListAllProfiles(*jsonOutput, *tag)
 		return
 	}
 
-	storage, err := session.NewStorageWithProfile(profile)
-	if err != nil {
-		fmt.Printf("Error: failed to initialize storage: %v\n", err)
-		os.Exit(1)
-	}
-
-	instances, _, err := storage.LoadWithGroups()
+	sessions, profileName, err := listSessions(profile, *tag, *jsonOutput)
 	if err != nil {
-		fmt.Printf("Error: failed to load sessions: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("Error: %v\n", err)
+		exit(1)
 	}
 
-	if len(instances) == 0 {
-		fmt.Printf("No sessions found in profile '%s'.\n", storage.Profile())
+	if len(sessions) == 0 {
+		if *tag != "" {
+			fmt.Printf("No sessions tagged '%s' in profile '%s'.\n", *tag, profileName)
+			return
+		}
+		fmt.Printf("No sessions found in profile '%s'.\n", profileName)
 		return
 	}
 
@@ -1121,60 +1161,61 @@ func handleList(profile string, args []string) {
 			Tool      string    `json:"tool"`
 			Command   string    `json:"command,omitempty"`
 			Status    string    `json:"status"`
+			Tags      []string  `json:"tags,omitempty"`
 			Profile   string    `json:"profile"`
 			CreatedAt time.Time `json:"created_at"`
 		}
-		sessions := make([]sessionJSON, len(instances))
-		for i, inst := range instances {
-			_ = inst.UpdateStatus()
-			sessions[i] = sessionJSON{
-				ID:        inst.ID,
-				Title:     inst.Title,
-				Path:      inst.ProjectPath,
-				Group:     inst.GroupPath,
-				Tool:      inst.Tool,
-				Command:   inst.Command,
-				Status:    StatusString(inst.Status),
-				Profile:   storage.Profile(),
-				CreatedAt: inst.CreatedAt,
+		output := make([]sessionJSON, len(sessions))
+		for i, s := range sessions {
+			output[i] = sessionJSON{
+				ID:        s.ID,
+				Title:     s.Title,
+				Path:      s.Path,
+				Group:     s.Group,
+				Tool:      s.Tool,
+				Command:   s.Command,
+				Status:    StatusString(session.Status(s.Status)),
+				Tags:      s.Tags,
+				Profile:   profileName,
+				CreatedAt: s.CreatedAt,
 			}
 		}
-		output, err := json.MarshalIndent(sessions, "", "  ")
+		data, err := json.MarshalIndent(output, "", "  ")
 		if err != nil {
 			fmt.Printf("Error: failed to format JSON output: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		fmt.Println(string(output))
+		fmt.Println(string(data))
 		return
 	}
 
 	// Table output
-	fmt.Printf("Profile: %s\n\n", storage.Profile())
+	fmt.Printf("Profile: %s\n\n", profileName)
 	fmt.Printf("%-*s %-*s %-*s %s\n", tableColTitle, "TITLE", tableColGroup, "GROUP", tableColPath, "PATH", "ID")
 	fmt.Println(strings.Repeat("-", tableColTitle+tableColGroup+tableColPath+tableColIDDisplay+5))
-	for _, inst := range instances {
-		title := truncate(inst.Title, tableColTitle)
-		group := truncate(inst.GroupPath, tableColGroup)
-		path := truncate(inst.ProjectPath, tableColPath)
+	for _, s := range sessions {
+		title := truncate(s.Title, tableColTitle)
+		group := truncate(s.Group, tableColGroup)
+		path := truncate(s.Path, tableColPath)
 		// Safe ID display with bounds check to prevent panic
-		idDisplay := inst.ID
+		idDisplay := s.ID
 		if len(idDisplay) > tableColIDDisplay {
 			idDisplay = idDisplay[:tableColIDDisplay]
 		}
 		fmt.Printf("%-*s %-*s %-*s %s\n", tableColTitle, title, tableColGroup, group, tableColPath, path, idDisplay)
 	}
-	fmt.Printf("\nTotal: %d sessions\n", len(instances))
+	fmt.Printf("\nTotal: %d sessions\n", len(sessions))
 
 	// Show update notice if available
 	printUpdateNotice()
 }
 
 // handleListAllProfiles lists sessions from all profiles
-func handleListAllProfiles(jsonOutput bool) {
+func handleListAllProfiles(jsonOutput bool, tag string) {
 	profiles, err := session.ListProfiles()
 	if err != nil {
 		fmt.Printf("Error: failed to list profiles: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if len(profiles) == 0 {
@@ -1190,6 +1231,7 @@ func handleListAllProfiles(jsonOutput bool) {
 			Group     string    `json:"group"`
 			Tool      string    `json:"tool"`
 			Command   string    `json:"command,omitempty"`
+			Tags      []string  `json:"tags,omitempty"`
 			Profile   string    `json:"profile"`
 			CreatedAt time.Time `json:"created_at"`
 		}
@@ -1204,7 +1246,7 @@ func handleListAllProfiles(jsonOutput bool) {
 			if err != nil {
 				continue
 			}
-			for _, inst := range instances {
+			for _, inst := range filterByTag(instances, tag) {
 				allSessions = append(allSessions, sessionJSON{
 					ID:        inst.ID,
 					Title:     inst.Title,
@@ -1212,6 +1254,7 @@ func handleListAllProfiles(jsonOutput bool) {
 					Group:     inst.GroupPath,
 					Tool:      inst.Tool,
 					Command:   inst.Command,
+					Tags:      inst.Tags,
 					Profile:   profileName,
 					CreatedAt: inst.CreatedAt,
 				})
@@ -1221,7 +1264,7 @@ func handleListAllProfiles(jsonOutput bool) {
 		output, err := json.MarshalIndent(allSessions, "", "  ")
 		if err != nil {
 			fmt.Printf("Error: failed to format JSON output: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println(string(output))
 		return
@@ -1238,6 +1281,7 @@ func handleListAllProfiles(jsonOutput bool) {
 		if err != nil {
 			continue
 		}
+		instances = filterByTag(instances, tag)
 
 		if len(instances) == 0 {
 			continue
@@ -1265,26 +1309,70 @@ func handleListAllProfiles(jsonOutput bool) {
 	fmt.Printf("Total: %d sessions across %d profiles\n", totalSessions, len(profiles))
 }
 
+// filterByTag returns the sessions carrying tag, or all sessions if tag is "".
+// listSessions returns profile's sessions tagged tag (all of them when tag
+// is empty) and the profile's name. A running daemon answers with the
+// statuses it polls; otherwise sessions are read from storage, refreshing
+// their statuses from tmux if withStatus is set.
+func listSessions(profile, tag string, withStatus bool) ([]ipc.SessionInfo, string, error) {
+	var list ipc.ListResult
+	if ok, err := callDaemon(profile, ipc.MethodList, ipc.ListParams{Tag: tag}, &list); ok {
+		return list.Sessions, list.Profile, err
+	}
+
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		return nil, "", err
+	}
+	instances = filterByTag(instances, tag)
+	sessions := make([]ipc.SessionInfo, len(instances))
+	for i, inst := range instances {
+		if withStatus {
+			_ = inst.UpdateStatus()
+		}
+		sessions[i] = ipc.NewSessionInfo(inst, inst.Status)
+	}
+	return sessions, storage.Profile(), nil
+}
+
+func filterByTag(instances []*session.Instance, tag string) []*session.Instance {
+	if tag == "" {
+		return instances
+	}
+	var matched []*session.Instance
+	for _, inst := range instances {
+		if inst.HasTag(tag) {
+			matched = append(matched, inst)
+		}
+	}
+	return matched
+}
+
 // handleRemove removes a session by ID or title
 func handleRemove(profile string, args []string) {
 	fs := flag.NewFlagSet("remove", flag.ExitOnError)
+	archive := fs.Bool("archive", false, "Archive the session and its output so it can be restored later")
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 	quiet := fs.Bool("quiet", false, "Minimal output")
 	quietShort := fs.Bool("q", false, "Minimal output (short)")
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck remove <id|title>")
+		fmt.Println("Usage: agent-deck remove <id|title> [--archive]")
 		fmt.Println()
 		fmt.Println("Remove a session by ID or title.")
 		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
 		fmt.Println("Examples:")
 		fmt.Println("  agent-deck remove abc12345")
 		fmt.Println("  agent-deck remove \"My Project\"")
+		fmt.Println("  agent-deck remove abc12345 --archive # Keep it in 'agent-deck archive'")
 		fmt.Println("  agent-deck -p work remove abc12345   # Remove from 'work' profile")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -1296,13 +1384,13 @@ func handleRemove(profile string, args []string) {
 		if !*jsonOutput {
 			fs.Usage()
 		}
-		os.Exit(1)
+		exit(1)
 	}
 
 	storage, instances, groups, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Use shared ResolveSession for consistent matching (ambiguity detection, min prefix length)
@@ -1310,14 +1398,22 @@ func handleRemove(profile string, args []string) {
 	if inst == nil {
 		out.Error(fmt.Sprintf("%s (profile '%s')", errMsg, storage.Profile()), errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 	}
 
 	removedID := inst.ID
 	removedTitle := inst.Title
 
+	// Archive before killing so the transcript can still be captured
+	if *archive {
+		if err := storage.ArchiveInstance(inst, inst.CaptureTranscript()); err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			exit(1)
+		}
+	}
+
 	// Always attempt to kill the tmux session, even if Exists() returns false.
 	// The saved status may be stale (e.g., "error" in DB but tmux session still alive).
 	// Kill() is safe to call on non-existent sessions (returns error which we handle).
@@ -1329,8 +1425,9 @@ func handleRemove(profile string, args []string) {
 		}
 	}
 
-	// Clean up worktree directory if this is a worktree session
-	if inst.IsWorktree() {
+	// Clean up worktree directory if this is a worktree session.
+	// Archived sessions keep their worktree so they can be restored.
+	if inst.IsWorktree() && !*archive {
 		if err := git.RemoveWorktree(inst.WorktreeRepoRoot, inst.WorktreePath, false); err != nil {
 			if !*jsonOutput {
 				fmt.Printf("Warning: failed to remove worktree: %v\n", err)
@@ -1359,21 +1456,241 @@ func handleRemove(profile string, args []string) {
 
 	if err := storage.SaveWithGroups(newInstances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
+	verb := "Removed"
+	if *archive {
+		verb = "Archived"
+	}
 	out.Success(
-		fmt.Sprintf("Removed session: %s (from profile '%s')", removedTitle, storage.Profile()),
+		fmt.Sprintf("%s session: %s (from profile '%s')", verb, removedTitle, storage.Profile()),
 		map[string]interface{}{
-			"success": true,
-			"id":      removedID,
-			"title":   removedTitle,
-			"removed": true,
-			"profile": storage.Profile(),
+			"success":  true,
+			"id":       removedID,
+			"title":    removedTitle,
+			"removed":  true,
+			"archived": *archive,
+			"profile":  storage.Profile(),
 		},
 	)
 }
 
+// handlePrune removes sessions whose tmux session no longer exists
+func handlePrune(profile string, args []string) {
+	fs := flag.NewFlagSet("prune", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Show what would be removed without removing")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck prune [options]")
+		fmt.Println()
+		fmt.Println("Remove all sessions whose tmux session no longer exists.")
+		fmt.Println("Worktree directories are left on disk (see 'agent-deck worktree cleanup').")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck prune --dry-run         # List dead sessions")
+		fmt.Println("  agent-deck prune                   # Remove them")
+		fmt.Println("  agent-deck -p work prune           # Prune 'work' profile")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	quietMode := *quiet || *quietShort
+	out := NewCLIOutput(*jsonOutput, quietMode)
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	var dead, alive []*session.Instance
+	for _, inst := range instances {
+		if inst.Exists() {
+			alive = append(alive, inst)
+		} else {
+			dead = append(dead, inst)
+		}
+	}
+
+	pruned := make([]map[string]string, 0, len(dead))
+	for _, inst := range dead {
+		pruned = append(pruned, map[string]string{"id": inst.ID, "title": inst.Title})
+	}
+
+	if len(dead) == 0 {
+		out.Success(fmt.Sprintf("No dead sessions in profile '%s'", storage.Profile()), map[string]interface{}{
+			"success": true,
+			"pruned":  pruned,
+			"dry_run": *dryRun,
+			"profile": storage.Profile(),
+		})
+		return
+	}
+
+	if !*dryRun {
+		// Direct SQL DELETE first so a concurrent TUI save can't resurrect the rows
+		// (same approach as handleRemove).
+		for _, inst := range dead {
+			if err := storage.DeleteInstance(inst.ID); err != nil && !*jsonOutput && !quietMode {
+				fmt.Printf("Warning: direct delete of %s failed: %v\n", inst.Title, err)
+			}
+		}
+
+		groupTree := session.NewGroupTreeWithGroups(alive, groups)
+		if err := storage.SaveWithGroups(alive, groupTree); err != nil {
+			out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+	}
+
+	if !*jsonOutput && !quietMode {
+		verb := "Removed"
+		if *dryRun {
+			verb = "Would remove"
+		}
+		fmt.Printf("%s %d dead session(s) from profile '%s':\n", verb, len(dead), storage.Profile())
+		for _, inst := range dead {
+			fmt.Printf("  %s %s (%s)\n", bulletSymbol, inst.Title, TruncateID(inst.ID))
+		}
+		return
+	}
+
+	out.Print("", map[string]interface{}{
+		"success": true,
+		"pruned":  pruned,
+		"dry_run": *dryRun,
+		"profile": storage.Profile(),
+	})
+}
+
+func handleResurrect(profile string, args []string) {
+	fs := flag.NewFlagSet("resurrect", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "Show what would be recreated without recreating")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck resurrect [id|title] [options]")
+		fmt.Println()
+		fmt.Println("Recreate sessions whose tmux session no longer exists (e.g. after a reboot),")
+		fmt.Println("in the same directory and with the same command. Claude sessions resume their")
+		fmt.Println("conversation (--resume). Without an id, all such sessions are recreated.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck resurrect --dry-run     # List sessions that are gone")
+		fmt.Println("  agent-deck resurrect               # Recreate all of them")
+		fmt.Println("  agent-deck resurrect my-project    # Recreate one session")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	quietMode := *quiet || *quietShort
+	out := NewCLIOutput(*jsonOutput, quietMode)
+
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	var targets []*session.Instance
+	if identifier := fs.Arg(0); identifier != "" {
+		inst, errMsg, errCode := ResolveSession(identifier, instances)
+		if inst == nil {
+			out.Error(errMsg, errCode)
+			if errCode == ErrCodeNotFound {
+				exit(2)
+			}
+			exit(1)
+			return // unreachable, satisfies staticcheck SA5011
+		}
+		if inst.Exists() {
+			out.Error(fmt.Sprintf("session '%s' is still running", inst.Title), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		targets = []*session.Instance{inst}
+	} else {
+		targets = session.GoneSessions(instances)
+	}
+
+	if len(targets) == 0 {
+		out.Success(fmt.Sprintf("No sessions to resurrect in profile '%s'", storage.Profile()), map[string]interface{}{
+			"success":     true,
+			"resurrected": []map[string]string{},
+			"dry_run":     *dryRun,
+			"profile":     storage.Profile(),
+		})
+		return
+	}
+
+	resurrected := make([]map[string]string, 0, len(targets))
+	failed := make([]map[string]string, 0)
+	for _, inst := range targets {
+		entry := map[string]string{"id": inst.ID, "title": inst.Title}
+		if !*dryRun {
+			if err := inst.Resurrect(); err != nil {
+				entry["error"] = err.Error()
+				failed = append(failed, entry)
+				continue
+			}
+			// A fresh Claude conversation (no prior ID): capture the new ID
+			if inst.Tool == "claude" && inst.ClaudeSessionID == "" {
+				inst.PostStartSync(3 * time.Second)
+			}
+		}
+		resurrected = append(resurrected, entry)
+	}
+
+	if !*dryRun && len(resurrected) > 0 {
+		groupTree := session.NewGroupTreeWithGroups(instances, groups)
+		if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+			out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+	}
+
+	if !*jsonOutput && !quietMode {
+		verb := "Resurrected"
+		if *dryRun {
+			verb = "Would resurrect"
+		}
+		fmt.Printf("%s %d session(s) in profile '%s':\n", verb, len(resurrected), storage.Profile())
+		for _, entry := range resurrected {
+			fmt.Printf("  %s %s (%s)\n", bulletSymbol, entry["title"], TruncateID(entry["id"]))
+		}
+		for _, entry := range failed {
+			fmt.Printf("  %s %s (%s): %s\n", errorSymbol, entry["title"], TruncateID(entry["id"]), entry["error"])
+		}
+	} else {
+		out.Print("", map[string]interface{}{
+			"success":     len(failed) == 0,
+			"resurrected": resurrected,
+			"failed":      failed,
+			"dry_run":     *dryRun,
+			"profile":     storage.Profile(),
+		})
+	}
+
+	if len(failed) > 0 {
+		exit(1)
+	}
+}
+
 func handleRename(profile string, args []string) {
 	fs := flag.NewFlagSet("rename", flag.ExitOnError)
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
@@ -1392,7 +1709,7 @@ func handleRename(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -1405,22 +1722,22 @@ func handleRename(profile string, args []string) {
 		if !*jsonOutput {
 			fs.Usage()
 		}
-		os.Exit(1)
+		exit(1)
 	}
 
 	storage, instances, groups, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	inst, errMsg, errCode := ResolveSession(identifier, instances)
 	if inst == nil {
 		out.Error(fmt.Sprintf("%s (profile '%s')", errMsg, storage.Profile()), errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 	}
 
 	oldTitle := inst.Title
@@ -1432,7 +1749,7 @@ func handleRename(profile string, args []string) {
 				fmt.Sprintf("session with title %q already exists at path %q (id: %s)", newTitle, inst.ProjectPath, existing.ID),
 				ErrCodeInvalidOperation,
 			)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -1442,7 +1759,7 @@ func handleRename(profile string, args []string) {
 	groupTree := session.NewGroupTreeWithGroups(instances, groups)
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	out.Success(
@@ -1457,33 +1774,28 @@ func handleRename(profile string, args []string) {
 	)
 }
 
-// statusCounts holds session counts by status
-type statusCounts struct {
-	running int
-	waiting int
-	idle    int
-	err     int
-	total   int
-}
+// Exit codes for "agent-deck status <id>". 1 and 2 keep their CLI-wide
+// meaning (failure, session not found); waiting (including needs input) is 0
+// so scripts can write "if agent-deck status foo -q; then ...".
+const (
+	statusExitWaiting = 0
+	statusExitRunning = 3
+	statusExitIdle    = 4
+	statusExitStopped = 5
+)
 
-// countByStatus counts sessions by their status
-func countByStatus(instances []*session.Instance) statusCounts {
-	var counts statusCounts
-	for _, inst := range instances {
-		_ = inst.UpdateStatus() // Refresh status from tmux
-		switch inst.Status {
-		case session.StatusRunning:
-			counts.running++
-		case session.StatusWaiting:
-			counts.waiting++
-		case session.StatusIdle:
-			counts.idle++
-		case session.StatusError:
-			counts.err++
-		}
-		counts.total++
-	}
-	return counts
+// statusExitCode maps a session status to its "status <id>" exit code
+func statusExitCode(status session.Status) int {
+	switch status {
+	case session.StatusWaiting, session.StatusNeedsInput:
+		return statusExitWaiting
+	case session.StatusRunning, session.StatusStarting:
+		return statusExitRunning
+	case session.StatusIdle:
+		return statusExitIdle
+	default:
+		return statusExitStopped
+	}
 }
 
 // handleStatus shows session status summary
@@ -1496,104 +1808,106 @@ func handleStatus(profile string, args []string) {
 	jsonOutput := fs.Bool("json", false, "Output as JSON")
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck status [options]")
+		fmt.Println("Usage: agent-deck status [id|title] [options]")
 		fmt.Println()
-		fmt.Println("Show a summary of session statuses.")
+		fmt.Println("Show a summary of session statuses, or the status of one session.")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
 		fmt.Println()
+		fmt.Println("Exit codes (with a session argument):")
+		fmt.Println("  0  waiting (needs attention)")
+		fmt.Println("  1  failure")
+		fmt.Println("  2  session not found")
+		fmt.Println("  3  running")
+		fmt.Println("  4  idle")
+		fmt.Println("  5  stopped or error")
+		fmt.Println()
 		fmt.Println("Examples:")
 		fmt.Println("  agent-deck status              # Quick summary")
 		fmt.Println("  agent-deck status -v           # Detailed list")
 		fmt.Println("  agent-deck status -q           # Just waiting count")
 		fmt.Println("  agent-deck -p work status      # Status for 'work' profile")
+		fmt.Println("  agent-deck status my-project   # One session, exit code reflects status")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
-	// Load sessions
-	storage, err := session.NewStorageWithProfile(profile)
-	if err != nil {
-		fmt.Printf("Error: failed to initialize storage: %v\n", err)
-		os.Exit(1)
+	if identifier := fs.Arg(0); identifier != "" {
+		handleSessionStatusQuery(profile, identifier, *jsonOutput, *quiet || *quietShort)
+		return
 	}
 
-	instances, _, err := storage.LoadWithGroups()
+	sessions, profileName, err := listSessions(profile, "", true)
 	if err != nil {
-		fmt.Printf("Error: failed to load sessions: %v\n", err)
-		os.Exit(1)
+		fmt.Printf("Error: %v\n", err)
+		exit(1)
 	}
 
-	if len(instances) == 0 {
+	if len(sessions) == 0 {
 		if *jsonOutput {
-			fmt.Println(`{"waiting": 0, "running": 0, "idle": 0, "error": 0, "total": 0}`)
+			fmt.Println(`{"waiting": 0, "needs_input": 0, "running": 0, "idle": 0, "error": 0, "total": 0}`)
 		} else if *quiet || *quietShort {
 			fmt.Println("0")
 		} else {
-			fmt.Printf("No sessions in profile '%s'.\n", storage.Profile())
+			fmt.Printf("No sessions in profile '%s'.\n", profileName)
 		}
 		return
 	}
 
 	// Count by status
-	counts := countByStatus(instances)
+	var counts ipc.StatusCounts
+	for _, s := range sessions {
+		counts.Add(session.Status(s.Status))
+	}
 
 	// Output based on flags
 	if *jsonOutput {
-		type statusJSON struct {
-			Waiting int `json:"waiting"`
-			Running int `json:"running"`
-			Idle    int `json:"idle"`
-			Error   int `json:"error"`
-			Total   int `json:"total"`
-		}
-		output, _ := json.Marshal(statusJSON{
-			Waiting: counts.waiting,
-			Running: counts.running,
-			Idle:    counts.idle,
-			Error:   counts.err,
-			Total:   counts.total,
-		})
+		output, _ := json.Marshal(counts)
 		fmt.Println(string(output))
 	} else if *quiet || *quietShort {
-		fmt.Println(counts.waiting)
+		// Sessions blocked on approval wait on the user too
+		fmt.Println(counts.Waiting + counts.NeedsInput)
 	} else if *verbose || *verboseShort {
 		// Detailed output grouped by status
 		printStatusGroup := func(label, symbol string, status session.Status) {
-			var matching []*session.Instance
-			for _, inst := range instances {
-				if inst.Status == status {
-					matching = append(matching, inst)
+			var matching []ipc.SessionInfo
+			for _, s := range sessions {
+				if session.Status(s.Status) == status {
+					matching = append(matching, s)
 				}
 			}
 			if len(matching) == 0 {
 				return
 			}
 			fmt.Printf("%s (%d):\n", label, len(matching))
-			for _, inst := range matching {
-				path := inst.ProjectPath
+			for _, s := range matching {
+				path := s.Path
 				home, _ := os.UserHomeDir()
 				if strings.HasPrefix(path, home) {
 					path = "~" + path[len(home):]
 				}
-				fmt.Printf("  %s %-16s %-10s %s\n", symbol, inst.Title, inst.Tool, path)
+				fmt.Printf("  %s %-16s %-10s %s\n", symbol, s.Title, s.Tool, path)
 			}
 			fmt.Println()
 		}
 
+		printStatusGroup("NEEDS INPUT", "◆", session.StatusNeedsInput)
 		printStatusGroup("WAITING", "◐", session.StatusWaiting)
 		printStatusGroup("RUNNING", "●", session.StatusRunning)
 		printStatusGroup("IDLE", "○", session.StatusIdle)
 		printStatusGroup("ERROR", "✕", session.StatusError)
 
-		fmt.Printf("Total: %d sessions in profile '%s'\n", counts.total, storage.Profile())
+		fmt.Printf("Total: %d sessions in profile '%s'\n", counts.Total, profileName)
 	} else {
 		// Compact output
+		if counts.NeedsInput > 0 {
+			fmt.Printf("%d needs input • ", counts.NeedsInput)
+		}
 		fmt.Printf("%d waiting • %d running • %d idle\n",
-			counts.waiting, counts.running, counts.idle)
+			counts.Waiting, counts.Running, counts.Idle)
 	}
 
 	// Show update notice if available (skip for JSON/quiet output)
@@ -1602,6 +1916,58 @@ func handleStatus(profile string, args []string) {
 	}
 }
 
+// handleSessionStatusQuery prints one session's status and exits with the
+// matching statusExit* code.
+func handleSessionStatusQuery(profile, identifier string, jsonOutput, quiet bool) {
+	out := NewCLIOutput(jsonOutput, quiet)
+
+	var result ipc.StatusResult
+	if ok, err := callDaemon(profile, ipc.MethodStatus, ipc.StatusParams{Session: identifier}, &result); ok {
+		if err != nil {
+			errCode := daemonErrCode(err)
+			out.Error(fmt.Sprintf("%v (profile '%s')", err, session.GetEffectiveProfile(profile)), errCode)
+			if errCode == ErrCodeNotFound {
+				exit(2)
+			}
+			exit(1)
+		}
+		status := session.Status(result.Session.Status)
+		out.Print(StatusString(status)+"\n", map[string]interface{}{
+			"id":      result.Session.ID,
+			"title":   result.Session.Title,
+			"status":  StatusString(status),
+			"profile": result.Profile,
+		})
+		exit(statusExitCode(status))
+	}
+
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	inst, errMsg, errCode := ResolveSession(identifier, instances)
+	if inst == nil {
+		out.Error(fmt.Sprintf("%s (profile '%s')", errMsg, storage.Profile()), errCode)
+		if errCode == ErrCodeNotFound {
+			exit(2)
+		}
+		exit(1)
+		return // unreachable, satisfies staticcheck SA5011
+	}
+
+	_ = inst.UpdateStatus()
+	status := inst.GetStatusThreadSafe()
+	out.Print(StatusString(status)+"\n", map[string]interface{}{
+		"id":      inst.ID,
+		"title":   inst.Title,
+		"status":  StatusString(status),
+		"profile": storage.Profile(),
+	})
+	exit(statusExitCode(status))
+}
+
 // handleProfile manages profiles (list, create, delete, default)
 func handleProfile(args []string) {
 	// Extract --json and -q/--quiet flags from anywhere in args
@@ -1643,7 +2009,7 @@ func handleProfile(args []string) {
 			if !jsonMode {
 				printProfileCreateHelp()
 			}
-			os.Exit(1)
+			exit(1)
 		}
 		handleProfileCreate(out, filteredArgs[1])
 	case "delete", "rm":
@@ -1656,7 +2022,7 @@ func handleProfile(args []string) {
 			if !jsonMode {
 				printProfileDeleteHelp()
 			}
-			os.Exit(1)
+			exit(1)
 		}
 		handleProfileDelete(out, jsonMode, filteredArgs[1])
 	case "default":
@@ -1669,7 +2035,7 @@ func handleProfile(args []string) {
 			config, err := session.LoadConfig()
 			if err != nil {
 				out.Error(fmt.Sprintf("failed to load config: %v", err), ErrCodeInvalidOperation)
-				os.Exit(1)
+				exit(1)
 			}
 			out.Success(fmt.Sprintf("Default profile: %s", config.DefaultProfile), map[string]interface{}{
 				"success":         true,
@@ -1684,7 +2050,7 @@ func handleProfile(args []string) {
 			fmt.Println()
 			printProfileHelp()
 		}
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -1720,7 +2086,7 @@ func handleProfileList(out *CLIOutput, jsonMode bool) {
 	profiles, err := session.ListProfiles()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to list profiles: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	config, _ := session.LoadConfig()
@@ -1766,7 +2132,7 @@ func handleProfileList(out *CLIOutput, jsonMode bool) {
 func handleProfileCreate(out *CLIOutput, name string) {
 	if err := session.CreateProfile(name); err != nil {
 		out.Error(fmt.Sprintf("%v", err), ErrCodeAlreadyExists)
-		os.Exit(1)
+		exit(1)
 	}
 	out.Success(fmt.Sprintf("Created profile: %s", name), map[string]interface{}{
 		"success": true,
@@ -1792,7 +2158,7 @@ func handleProfileDelete(out *CLIOutput, jsonMode bool, name string) {
 
 	if err := session.DeleteProfile(name); err != nil {
 		out.Error(fmt.Sprintf("%v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 	out.Success(fmt.Sprintf("Deleted profile: %s", name), map[string]interface{}{
 		"success": true,
@@ -1804,7 +2170,7 @@ func handleProfileDelete(out *CLIOutput, jsonMode bool, name string) {
 func handleProfileSetDefault(out *CLIOutput, name string) {
 	if err := session.SetDefaultProfile(name); err != nil {
 		out.Error(fmt.Sprintf("%v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 	out.Success(fmt.Sprintf("Default profile set to: %s", name), map[string]interface{}{
 		"success":         true,
@@ -1832,7 +2198,7 @@ func handleUpdate(args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	fmt.Printf("Agent Deck v%s\n", Version)
@@ -1843,7 +2209,7 @@ func handleUpdate(args []string) {
 	info, err := update.CheckForUpdate(Version, true)
 	if err != nil {
 		fmt.Printf("Error checking for updates: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if !info.Available {
@@ -1877,7 +2243,7 @@ func handleUpdate(args []string) {
 	fmt.Println()
 	if err := update.PerformUpdate(info.DownloadURL); err != nil {
 		fmt.Printf("Error installing update: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Update bridge.py if conductor is installed
@@ -1938,33 +2304,18 @@ func printHelp() {
 	fmt.Printf("Agent Deck v%s\n", Version)
 	fmt.Println("Terminal session manager for AI coding agents")
 	fmt.Println()
-	fmt.Println("Usage: agent-deck [-p profile] [command]")
+	fmt.Println("Usage: agent-deck [global options] [command]")
 	fmt.Println()
 	fmt.Println("Global Options:")
 	fmt.Println("  -p, --profile <name>   Use specific profile (default: 'default')")
+	fmt.Println("  --storage <dir>        Use <dir> instead of ~/.agent-deck for all state")
+	fmt.Println("  --tmux-socket <name>   Run sessions on a dedicated tmux server (tmux -L <name>)")
+	fmt.Println("  --socket <name>        Same as --tmux-socket")
+	fmt.Println("  --debug                Write debug logs (same as AGENTDECK_DEBUG=1)")
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  (none)           Start the TUI")
-	fmt.Println("  add <path>       Add a new session")
-	fmt.Println("  launch [path]    Add, start, and optionally send a message in one step")
-	fmt.Println("  try <name>       Quick experiment (create/find dated folder + session)")
-	fmt.Println("  list, ls         List all sessions")
-	fmt.Println("  remove, rm       Remove a session")
-	fmt.Println("  rename, mv       Rename a session")
-	fmt.Println("  status           Show session status summary")
-	fmt.Println("  session          Manage session lifecycle")
-	fmt.Println("  mcp              Manage MCP servers")
-	fmt.Println("  skill            Manage Claude skills")
-	fmt.Println("  codex-hooks      Manage Codex notify hook integration")
-	fmt.Println("  group            Manage groups")
-	fmt.Println("  worktree, wt     Manage git worktrees")
-	fmt.Println("  web              Start TUI with web UI server running alongside")
-	fmt.Println("  conductor        Manage conductor meta-agent orchestration")
-	fmt.Println("  profile          Manage profiles")
-	fmt.Println("  update           Check for and install updates")
-	fmt.Println("  uninstall        Uninstall Agent Deck")
-	fmt.Println("  version          Show version")
-	fmt.Println("  help             Show this help")
+	printCommandList()
 	fmt.Println()
 	fmt.Println("Session Commands:")
 	fmt.Println("  session start <id>        Start a session's tmux process")
@@ -2034,6 +2385,9 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("Environment Variables:")
 	fmt.Println("  AGENTDECK_PROFILE    Default profile to use")
+	fmt.Println("  AGENTDECK_HOME       State and config directory (default: ~/.agent-deck)")
+	fmt.Println("  XDG_DATA_HOME        State goes in $XDG_DATA_HOME/agent-deck if ~/.agent-deck holds no install")
+	fmt.Println("  XDG_CONFIG_HOME      config.toml goes in $XDG_CONFIG_HOME/agent-deck (same condition)")
 	fmt.Println("  AGENTDECK_COLOR      Color mode: truecolor, 256, 16, none")
 	fmt.Println()
 	fmt.Println("Keyboard shortcuts (in TUI):")
@@ -2122,7 +2476,7 @@ func handleUninstall(args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	fmt.Println("╔════════════════════════════════════════╗")
@@ -2136,7 +2490,10 @@ func handleUninstall(args []string) {
 	}
 
 	homeDir, _ := os.UserHomeDir()
-	dataDir := filepath.Join(homeDir, ".agent-deck")
+	dataDir, err := session.GetAgentDeckDir()
+	if err != nil {
+		dataDir = filepath.Join(homeDir, ".agent-deck")
+	}
 
 	// Track what we find
 	type foundItem struct {
@@ -2437,7 +2794,7 @@ func handleUninstall(args []string) {
 					)
 					fmt.Printf("Creating backup at %s...\n", backupFile)
 
-					cmd := exec.Command("tar", "-czf", backupFile, "-C", homeDir, ".agent-deck")
+					cmd := exec.Command("tar", "-czf", backupFile, "-C", filepath.Dir(dataDir), filepath.Base(dataDir))
 					if err := cmd.Run(); err != nil {
 						fmt.Printf("Warning: failed to create backup: %v\n", err)
 					} else {
@@ -2463,7 +2820,7 @@ func handleUninstall(args []string) {
 
 	if *keepData {
 		fmt.Printf("Note: Data directory preserved at %s\n", dataDir)
-		fmt.Println("      Remove manually with: rm -rf ~/.agent-deck")
+		fmt.Printf("      Remove manually with: rm -rf %s\n", dataDir)
 	}
 
 	if *keepTmuxConfig {
@@ -73,8 +73,8 @@ func TestNestedSessionAllowsCLICommands(t *testing.T) {
 	t.Run("subcommands_dispatched_before_nested_check", func(t *testing.T) {
 		// These are all the subcommands that should work inside nested sessions
 		subcommands := []string{
-			"add", "list", "ls", "remove", "rm", "status",
-			"session", "mcp", "skill", "group", "try", "worktree", "wt",
+			"add", "new", "up", "list", "ls", "remove", "rm", "kill", "prune", "resurrect", "archive", "restore", "status",
+			"session", "attach", "popup", "fork", "send", "logs", "mcp", "skill", "group", "try", "worktree", "wt",
 			"profile", "update", "mcp-proxy", "web", "uninstall", "hooks", "codex-hooks", "codex-notify",
 			"version", "--version", "-v",
 			"help", "--help", "-h",
@@ -177,3 +177,29 @@ func TestIsDuplicateSession(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusExitCode(t *testing.T) {
+	tests := []struct {
+		status session.Status
+		want   int
+	}{
+		{session.StatusWaiting, statusExitWaiting},
+		{session.StatusNeedsInput, statusExitWaiting},
+		{session.StatusRunning, statusExitRunning},
+		{session.StatusStarting, statusExitRunning},
+		{session.StatusIdle, statusExitIdle},
+		{session.StatusError, statusExitStopped},
+	}
+	seen := map[int]bool{1: true, 2: true}
+	for _, tt := range tests {
+		if got := statusExitCode(tt.status); got != tt.want {
+			t.Errorf("statusExitCode(%s) = %d, want %d", tt.status, got, tt.want)
+		}
+	}
+	for _, code := range []int{statusExitWaiting, statusExitRunning, statusExitIdle, statusExitStopped} {
+		if seen[code] {
+			t.Errorf("exit code %d collides with another status or CLI error code", code)
+		}
+		seen[code] = true
+	}
+}
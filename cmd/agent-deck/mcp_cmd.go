@@ -15,7 +15,7 @@ import (
 func handleMCP(profile string, args []string) {
 	if len(args) == 0 {
 		printMCPHelp()
-		os.Exit(1)
+		exit(1)
 	}
 
 	switch args[0] {
@@ -34,7 +34,7 @@ func handleMCP(profile string, args []string) {
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unknown mcp command '%s'\n", args[0])
 		printMCPHelp()
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -79,7 +79,7 @@ func handleMCPList(args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -223,7 +223,7 @@ func handleMCPAttached(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -233,13 +233,13 @@ func handleMCPAttached(profile string, args []string) {
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, _, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
@@ -247,7 +247,7 @@ func handleMCPAttached(profile string, args []string) {
 	inst, errMsg, errCode := ResolveSessionOrCurrent(identifier, instances)
 	if inst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(2)
+		exit(2)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -356,7 +356,7 @@ func handleMCPAttach(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -368,7 +368,7 @@ func handleMCPAttach(profile string, args []string) {
 		if !*jsonOutput {
 			fmt.Println("\nUsage: agent-deck mcp attach <session-id> <mcp-name> [options]")
 		}
-		os.Exit(1)
+		exit(1)
 	}
 
 	sessionID := fs.Arg(0)
@@ -378,20 +378,20 @@ func handleMCPAttach(profile string, args []string) {
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, _, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
 	inst, errMsg, errCode := ResolveSession(sessionID, instances)
 	if inst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(2)
+		exit(2)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -405,7 +405,7 @@ func handleMCPAttach(profile string, args []string) {
 				fmt.Printf("  %s %s\n", bulletSymbol, name)
 			}
 		}
-		os.Exit(2)
+		exit(2)
 	}
 
 	scope := session.GetMCPDefaultScope()
@@ -421,14 +421,14 @@ func handleMCPAttach(profile string, args []string) {
 		for _, name := range currentGlobal {
 			if name == mcpName {
 				out.Error(fmt.Sprintf("MCP '%s' is already attached globally", mcpName), ErrCodeAlreadyExists)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 		// Add to list
 		newGlobal := append(currentGlobal, mcpName)
 		if err := session.WriteGlobalMCP(newGlobal); err != nil {
 			out.Error(fmt.Sprintf("failed to write global config: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
 		// Add to local .mcp.json
@@ -437,14 +437,14 @@ func handleMCPAttach(profile string, args []string) {
 		for _, name := range mcpInfo.Local() {
 			if name == mcpName {
 				out.Error(fmt.Sprintf("MCP '%s' is already attached locally", mcpName), ErrCodeAlreadyExists)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 		// Add to local MCPs
 		newLocal := append(mcpInfo.Local(), mcpName)
 		if err := session.WriteMCPJsonFromConfig(inst.ProjectPath, newLocal); err != nil {
 			out.Error(fmt.Sprintf("failed to write .mcp.json: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -512,7 +512,7 @@ func handleMCPDetach(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -524,7 +524,7 @@ func handleMCPDetach(profile string, args []string) {
 		if !*jsonOutput {
 			fmt.Println("\nUsage: agent-deck mcp detach <session-id> <mcp-name> [options]")
 		}
-		os.Exit(1)
+		exit(1)
 	}
 
 	sessionID := fs.Arg(0)
@@ -534,20 +534,20 @@ func handleMCPDetach(profile string, args []string) {
 	storage, err := session.NewStorageWithProfile(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	instances, _, err := storage.LoadWithGroups()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
 	inst, errMsg, errCode := ResolveSession(sessionID, instances)
 	if inst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(2)
+		exit(2)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -571,11 +571,11 @@ func handleMCPDetach(profile string, args []string) {
 		}
 		if !found {
 			out.Error(fmt.Sprintf("MCP '%s' is not attached globally", mcpName), ErrCodeNotFound)
-			os.Exit(2)
+			exit(2)
 		}
 		if err := session.WriteGlobalMCP(newGlobal); err != nil {
 			out.Error(fmt.Sprintf("failed to write global config: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
 		// Remove from local .mcp.json
@@ -592,11 +592,11 @@ func handleMCPDetach(profile string, args []string) {
 		}
 		if !found {
 			out.Error(fmt.Sprintf("MCP '%s' is not attached locally", mcpName), ErrCodeNotFound)
-			os.Exit(2)
+			exit(2)
 		}
 		if err := session.WriteMCPJsonFromConfig(inst.ProjectPath, newLocal); err != nil {
 			out.Error(fmt.Sprintf("failed to write .mcp.json: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -644,7 +644,7 @@ func handleMCPDetach(profile string, args []string) {
 func handleMCPServer(args []string) {
 	if len(args) == 0 {
 		printMCPServerHelp()
-		os.Exit(1)
+		exit(1)
 	}
 
 	switch args[0] {
@@ -659,7 +659,7 @@ func handleMCPServer(args []string) {
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unknown mcp server command '%s'\n", args[0])
 		printMCPServerHelp()
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -698,7 +698,7 @@ func handleMCPServerStart(args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -706,7 +706,7 @@ func handleMCPServerStart(args []string) {
 
 	if fs.NArg() < 1 {
 		out.Error("MCP name is required", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	mcpName := fs.Arg(0)
@@ -715,7 +715,7 @@ func handleMCPServerStart(args []string) {
 	def := session.GetMCPDef(mcpName)
 	if def == nil {
 		out.Error(fmt.Sprintf("MCP '%s' not found in config.toml", mcpName), ErrCodeMCPNotAvailable)
-		os.Exit(2)
+		exit(2)
 	}
 
 	// Check if it's an HTTP MCP with server config
@@ -733,13 +733,13 @@ func handleMCPServerStart(args []string) {
 				fmt.Println("  args = [\"your-server-package\"]")
 			}
 		}
-		os.Exit(2)
+		exit(2)
 	}
 
 	// Start the server
 	if err := session.StartHTTPServer(mcpName, def); err != nil {
 		out.Error(fmt.Sprintf("failed to start HTTP server: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Output result
@@ -772,7 +772,7 @@ func handleMCPServerStop(args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -780,7 +780,7 @@ func handleMCPServerStop(args []string) {
 
 	if fs.NArg() < 1 {
 		out.Error("MCP name is required", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	mcpName := fs.Arg(0)
@@ -789,19 +789,19 @@ func handleMCPServerStop(args []string) {
 	httpPool := session.GetGlobalHTTPPool()
 	if httpPool == nil {
 		out.Error("HTTP pool not initialized (run TUI first)", ErrCodeNotFound)
-		os.Exit(2)
+		exit(2)
 	}
 
 	// Check if server is running
 	if !httpPool.IsRunning(mcpName) {
 		out.Error(fmt.Sprintf("HTTP server '%s' is not running", mcpName), ErrCodeNotFound)
-		os.Exit(2)
+		exit(2)
 	}
 
 	// Stop the server
 	if err := httpPool.Stop(mcpName); err != nil {
 		out.Error(fmt.Sprintf("failed to stop HTTP server: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Output result
@@ -833,7 +833,7 @@ func handleMCPServerStatus(args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -899,7 +899,7 @@ func handleMCPServerStatus(args []string) {
 
 	if mcpName != "" && len(servers) == 0 {
 		out.Error(fmt.Sprintf("HTTP MCP '%s' not found", mcpName), ErrCodeNotFound)
-		os.Exit(2)
+		exit(2)
 	}
 
 	if *jsonOutput {
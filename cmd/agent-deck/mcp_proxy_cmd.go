@@ -27,7 +27,7 @@ func runMCPProxy(socketPath string) {
 		if err != nil {
 			retries++
 			if retries >= maxRetries {
-				os.Exit(1)
+				exit(1)
 			}
 			time.Sleep(retryDelay)
 			if retryDelay < maxRetryDelay {
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+	"github.com/asheshgoplani/agent-deck/internal/ui"
+)
+
+// handlePopup runs a minimal session picker meant for tmux display-popup and
+// switches the calling tmux client to the chosen session.
+func handlePopup(profile string, args []string) {
+	fs := flag.NewFlagSet("popup", flag.ExitOnError)
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck popup")
+		fmt.Println()
+		fmt.Println("Pick a session and switch the current tmux client to it.")
+		fmt.Println("Sessions are listed by status, waiting first. Press 1-9 to")
+		fmt.Println("switch in one keystroke, or j/k and Enter.")
+		fmt.Println()
+		fmt.Println("Bind it to a key in ~/.tmux.conf:")
+		fmt.Println("  bind-key g display-popup -E -w 60% -h 50% \"agent-deck popup\"")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	if os.Getenv("TMUX") == "" {
+		fmt.Fprintln(os.Stderr, "Error: popup must run inside tmux (e.g. from display-popup)")
+		exit(1)
+	}
+
+	storage, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+
+	tmux.RefreshExistingSessions()
+	var live []*session.Instance
+	for _, inst := range instances {
+		if !inst.Exists() {
+			continue
+		}
+		_ = inst.UpdateStatus()
+		live = append(live, inst)
+	}
+
+	switcher := ui.NewQuickSwitcher(live)
+	if _, err := tea.NewProgram(switcher, tea.WithAltScreen()).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+
+	inst := switcher.Selected()
+	if inst == nil {
+		return
+	}
+	tmuxSession := inst.GetTmuxSession()
+	if tmuxSession == nil {
+		fmt.Fprintf(os.Stderr, "Error: no tmux session for '%s'\n", inst.Title)
+		exit(1)
+	}
+
+	// Acknowledge like attach does, so a waiting session turns idle once seen.
+	if inst.GetStatusThreadSafe() == session.StatusWaiting {
+		tmuxSession.Acknowledge()
+		if db := storage.GetDB(); db != nil {
+			_ = db.SetAcknowledged(inst.ID, true)
+		}
+	}
+
+	if err := tmux.SwitchClient(tmuxSession.Name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+}
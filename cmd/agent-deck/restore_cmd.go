@@ -0,0 +1,120 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// handleRestore lists session state backups or restores one of them
+func handleRestore(profile string, args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck restore [backup]")
+		fmt.Println()
+		fmt.Println("Roll sessions and groups back to an automatic backup.")
+		fmt.Println("Without an argument, lists available backups (newest first).")
+		fmt.Println("The backup can be given as its number in the list, \"latest\", or a file path.")
+		fmt.Println("The current state is backed up before restoring, so a restore can be undone.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck restore              # List backups")
+		fmt.Println("  agent-deck restore 2            # Restore the second newest backup")
+		fmt.Println("  agent-deck -p work restore latest")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	quietMode := *quiet || *quietShort
+	out := NewCLIOutput(*jsonOutput, quietMode)
+
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to initialize storage: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	defer storage.Close()
+
+	backups, err := session.ListBackups(storage.Profile())
+	if err != nil {
+		out.Error(fmt.Sprintf("failed to list backups: %v", err), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	if fs.NArg() == 0 {
+		if *jsonOutput {
+			out.Print("", map[string]interface{}{
+				"profile": storage.Profile(),
+				"backups": backups,
+			})
+			return
+		}
+		if len(backups) == 0 {
+			fmt.Printf("No backups for profile '%s'.\n", storage.Profile())
+			return
+		}
+		fmt.Printf("Backups for profile '%s' (newest first):\n", storage.Profile())
+		for i, b := range backups {
+			fmt.Printf("  %2d  %s  %8s  %s\n", i+1, b.Time.Format("2006-01-02 15:04:05"), formatSize(b.Size), FormatPath(b.Path))
+		}
+		fmt.Println()
+		fmt.Println("Restore with: agent-deck restore <number>")
+		return
+	}
+
+	path, errMsg := resolveBackupArg(fs.Arg(0), backups)
+	if path == "" {
+		out.Error(errMsg, ErrCodeNotFound)
+		exit(2)
+	}
+
+	previous, err := storage.RestoreBackup(path)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	out.Success(fmt.Sprintf("Restored %s (previous state saved to %s)", FormatPath(path), FormatPath(previous)), map[string]interface{}{
+		"success":  true,
+		"profile":  storage.Profile(),
+		"restored": path,
+		"previous": previous,
+	})
+}
+
+// resolveBackupArg maps a list number, "latest", or a path to a backup file.
+// Returns "" and an error message if nothing matches.
+func resolveBackupArg(arg string, backups []session.BackupInfo) (string, string) {
+	if arg == "latest" {
+		if len(backups) == 0 {
+			return "", "no backups found"
+		}
+		return backups[0].Path, ""
+	}
+	if n, err := strconv.Atoi(arg); err == nil {
+		if n < 1 || n > len(backups) {
+			return "", fmt.Sprintf("no backup #%d (%d available)", n, len(backups))
+		}
+		return backups[n-1].Path, ""
+	}
+	if info, err := os.Stat(arg); err == nil && !info.IsDir() {
+		if abs, err := filepath.Abs(arg); err == nil {
+			return abs, ""
+		}
+		return arg, ""
+	}
+	return "", fmt.Sprintf("backup not found: %s", arg)
+}
@@ -4,14 +4,19 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/term"
+
 	"github.com/asheshgoplani/agent-deck/internal/clipboard"
 	"github.com/asheshgoplani/agent-deck/internal/git"
+	"github.com/asheshgoplani/agent-deck/internal/ipc"
 	"github.com/asheshgoplani/agent-deck/internal/profile"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/asheshgoplani/agent-deck/internal/tmux"
@@ -21,7 +26,7 @@ import (
 func handleSession(profile string, args []string) {
 	if len(args) == 0 {
 		printSessionHelp()
-		os.Exit(1)
+		exit(1)
 	}
 
 	switch args[0] {
@@ -54,7 +59,7 @@ func handleSession(profile string, args []string) {
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unknown session command: %s\n", args[0])
 		printSessionHelp()
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -69,11 +74,11 @@ func printSessionHelp() {
 	fmt.Println("  stop <id>               Stop/kill session process")
 	fmt.Println("  restart <id>            Restart session (Claude: reload MCPs)")
 	fmt.Println("  fork <id>               Fork Claude session with context")
-	fmt.Println("  attach <id>             Attach to session interactively")
+	fmt.Println("  attach <id>             Attach to session interactively (fuzzy title match)")
 	fmt.Println("  show [id]               Show session details (auto-detect current if no id)")
 	fmt.Println("  current                 Show current session and profile (auto-detect)")
 	fmt.Println("  set <id> <field> <value>  Update session property")
-	fmt.Println("  send <id> <message>     Send a message to a running session")
+	fmt.Println("  send <id> [message]     Send a message to a running session (stdin if omitted)")
 	fmt.Println("  output <id>             Get the last response from a session")
 	fmt.Println("  set-parent <id> <parent>  Link session as sub-session of parent")
 	fmt.Println("  unset-parent <id>       Remove sub-session link")
@@ -136,7 +141,7 @@ func handleSessionStart(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -150,7 +155,7 @@ func handleSessionStart(profile string, args []string) {
 	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
@@ -158,28 +163,28 @@ func handleSessionStart(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Check if already running
 	if inst.Exists() {
 		out.Error(fmt.Sprintf("session '%s' is already running", inst.Title), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Start the session (with or without initial message)
 	if initialMessage != "" {
 		if err := inst.StartWithMessage(initialMessage); err != nil {
 			out.Error(fmt.Sprintf("failed to start session: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
 		if err := inst.Start(); err != nil {
 			out.Error(fmt.Sprintf("failed to start session: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -190,7 +195,7 @@ func handleSessionStart(profile string, args []string) {
 	// Save updated state
 	if err := saveSessionData(storage, instances); err != nil {
 		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Output success
@@ -223,6 +228,7 @@ func handleSessionStop(profile string, args []string) {
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck session stop <id|title> [options]")
+		fmt.Println("       agent-deck kill <id|title> [options]")
 		fmt.Println()
 		fmt.Println("Stop/kill a session's process (tmux session remains).")
 		fmt.Println()
@@ -231,18 +237,37 @@ func handleSessionStop(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
 	quietMode := *quiet || *quietShort
 	out := NewCLIOutput(*jsonOutput, quietMode)
 
+	// A running daemon owns the sessions: let it stop this one
+	var stopped ipc.SessionInfo
+	if ok, err := callDaemon(profile, ipc.MethodKill, ipc.SessionParams{Session: identifier}, &stopped); ok {
+		if err != nil {
+			errCode := daemonErrCode(err)
+			out.Error(err.Error(), errCode)
+			if errCode == ErrCodeNotFound {
+				exit(2)
+			}
+			exit(1)
+		}
+		out.Success(fmt.Sprintf("Stopped session: %s", stopped.Title), map[string]interface{}{
+			"success": true,
+			"id":      stopped.ID,
+			"title":   stopped.Title,
+		})
+		return
+	}
+
 	// Load sessions
 	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
@@ -250,28 +275,28 @@ func handleSessionStop(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Check if not running
 	if !inst.Exists() {
 		out.Error(fmt.Sprintf("session '%s' is not running", inst.Title), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Stop the session by killing the tmux session
 	if err := inst.Kill(); err != nil {
 		out.Error(fmt.Sprintf("failed to stop session: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Save updated state
 	if err := saveSessionData(storage, instances); err != nil {
 		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Output success
@@ -299,7 +324,7 @@ func handleSessionRestart(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -310,7 +335,7 @@ func handleSessionRestart(profile string, args []string) {
 	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
@@ -318,16 +343,16 @@ func handleSessionRestart(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Restart the session
 	if err := inst.Restart(); err != nil {
 		out.Error(fmt.Sprintf("failed to restart session: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// If restart created a fresh session (no prior ID), capture the new ID
@@ -338,7 +363,7 @@ func handleSessionRestart(profile string, args []string) {
 	// Save updated state
 	if err := saveSessionData(storage, instances); err != nil {
 		out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Output success
@@ -366,6 +391,7 @@ func handleSessionFork(profile string, args []string) {
 
 	fs.Usage = func() {
 		fmt.Println("Usage: agent-deck session fork <id|title> [options]")
+		fmt.Println("       agent-deck fork <id|title> [options]")
 		fmt.Println()
 		fmt.Println("Fork a Claude session with conversation context.")
 		fmt.Println()
@@ -378,10 +404,11 @@ func handleSessionFork(profile string, args []string) {
 		fmt.Println("  agent-deck session fork my-project -t \"my-fork\" -g \"experiments\"")
 		fmt.Println("  agent-deck session fork my-project -w fork/experiment")
 		fmt.Println("  agent-deck session fork my-project -w fork/new-idea -b")
+		fmt.Println("  agent-deck fork my-project -t \"alt-approach\" -g experiments")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -396,7 +423,7 @@ func handleSessionFork(profile string, args []string) {
 	storage, instances, groupsData, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
@@ -404,9 +431,9 @@ func handleSessionFork(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -416,7 +443,7 @@ func handleSessionFork(profile string, args []string) {
 			fmt.Sprintf("session '%s' is not a Claude session (tool: %s)", inst.Title, inst.Tool),
 			ErrCodeInvalidOperation,
 		)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Try to capture session ID from tmux if missing (handles pre-fix sessions)
@@ -424,13 +451,14 @@ func handleSessionFork(profile string, args []string) {
 		inst.PostStartSync(2 * time.Second)
 	}
 
-	// Verify it can be forked
+	// Verify it can be forked (picks up /clear and confirms the transcript)
+	inst.SyncClaudeSessionFromDisk()
 	if !inst.CanFork() {
 		out.Error(
 			fmt.Sprintf("session '%s' cannot be forked: no active Claude session ID", inst.Title),
 			ErrCodeInvalidOperation,
 		)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Default title if not provided
@@ -455,17 +483,17 @@ func handleSessionFork(profile string, args []string) {
 	if wtBranch != "" {
 		if !git.IsGitRepo(inst.ProjectPath) {
 			out.Error("session path is not a git repository", ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 		repoRoot, err := git.GetWorktreeBaseRoot(inst.ProjectPath)
 		if err != nil {
 			out.Error(fmt.Sprintf("failed to get repo root: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if !createNewBranch && !git.BranchExists(repoRoot, wtBranch) {
 			out.Error(fmt.Sprintf("branch '%s' does not exist (use -b to create)", wtBranch), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		wtSettings := session.GetWorktreeSettings()
@@ -479,17 +507,17 @@ func handleSessionFork(profile string, args []string) {
 
 		if _, statErr := os.Stat(worktreePath); statErr == nil {
 			out.Error(fmt.Sprintf("worktree path already exists: %s", worktreePath), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := os.MkdirAll(filepath.Dir(worktreePath), 0o755); err != nil {
 			out.Error(fmt.Sprintf("failed to create directory: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := git.CreateWorktree(repoRoot, worktreePath, wtBranch); err != nil {
 			out.Error(fmt.Sprintf("worktree creation failed: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		userConfig, _ := session.LoadUserConfig()
@@ -504,13 +532,13 @@ func handleSessionFork(profile string, args []string) {
 	forkedInst, _, err := inst.CreateForkedInstanceWithOptions(forkTitle, forkGroup, opts)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to create fork: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Start the forked session
 	if err := forkedInst.Start(); err != nil {
 		out.Error(fmt.Sprintf("failed to start forked session: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Capture forked session's new session ID
@@ -528,7 +556,7 @@ func handleSessionFork(profile string, args []string) {
 	// Save
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Output success
@@ -548,47 +576,59 @@ func handleSessionAttach(profile string, args []string) {
 	fs := flag.NewFlagSet("session attach", flag.ExitOnError)
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck session attach <id|title>")
+		fmt.Println("Usage: agent-deck attach <id|title>")
+		fmt.Println("       agent-deck session attach <id|title>")
 		fmt.Println()
 		fmt.Println("Attach to a session interactively.")
+		fmt.Println("Titles that don't match exactly are resolved by fuzzy matching.")
 		fmt.Println("Press Ctrl+Q to detach.")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
 
 	// Load sessions
-	_, instances, _, err := loadSessionData(profile)
+	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 
-	// Resolve session (allow current session detection)
-	inst, errMsg, errCode := ResolveSessionOrCurrent(identifier, instances)
+	// Resolve session (allow current session detection and fuzzy title matching)
+	inst, errMsg, errCode := ResolveSessionFuzzy(identifier, instances)
 	if inst == nil {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", errMsg)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Check if session exists
 	if !inst.Exists() {
 		fmt.Fprintf(os.Stderr, "Error: session '%s' is not running\n", inst.Title)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Attach to the session
 	tmuxSession := inst.GetTmuxSession()
 	if tmuxSession == nil {
 		fmt.Fprintf(os.Stderr, "Error: no tmux session for '%s'\n", inst.Title)
-		os.Exit(1)
+		exit(1)
+	}
+
+	// Acknowledge on attach if the session is waiting, mirroring the TUI so the
+	// session turns idle once the user has looked at it.
+	_ = inst.UpdateStatus()
+	if inst.GetStatusThreadSafe() == session.StatusWaiting {
+		tmuxSession.Acknowledge()
+		if db := storage.GetDB(); db != nil {
+			_ = db.SetAcknowledged(inst.ID, true)
+		}
 	}
 
 	// Create context for attach
@@ -596,7 +636,18 @@ func handleSessionAttach(profile string, args []string) {
 
 	if err := tmuxSession.Attach(ctx); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: failed to attach: %v\n", err)
-		os.Exit(1)
+		exit(1)
+	}
+
+	// If the agent finished while attached, the user has already seen its
+	// output: baseline it so the session doesn't flip back to waiting.
+	// Running sessions are left alone so they stay green through detach.
+	_ = inst.UpdateStatus()
+	if inst.GetStatusThreadSafe() == session.StatusWaiting {
+		tmuxSession.AcknowledgeWithSnapshot()
+		if db := storage.GetDB(); db != nil {
+			_ = db.SetAcknowledged(inst.ID, true)
+		}
 	}
 }
 
@@ -617,7 +668,7 @@ func handleSessionShow(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -628,7 +679,7 @@ func handleSessionShow(profile string, args []string) {
 	_, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session (allow current session detection)
@@ -656,19 +707,20 @@ func handleSessionShow(profile string, args []string) {
 		} else {
 			out.Error(errMsg, errCode)
 			if errCode == ErrCodeNotFound {
-				os.Exit(2)
+				exit(2)
 			}
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
 	// Update status
 	_ = inst.UpdateStatus()
 
-	// Get MCP info if Claude session
+	// Get MCP info if Claude session, and confirm the session ID on disk
 	var mcpInfo *session.MCPInfo
 	if inst.Tool == "claude" {
 		mcpInfo = inst.GetMCPInfo()
+		inst.SyncClaudeSessionFromDisk()
 	}
 
 	// Prepare JSON output
@@ -686,6 +738,30 @@ func handleSessionShow(profile string, args []string) {
 	if inst.Command != "" {
 		jsonData["command"] = inst.Command
 	}
+	if inst.DetectedModel != "" {
+		jsonData["model"] = inst.DetectedModel
+	}
+	if inst.Notes != "" {
+		jsonData["notes"] = inst.Notes
+	}
+	if len(inst.Tags) > 0 {
+		jsonData["tags"] = inst.Tags
+	}
+
+	// Fork lineage: the source may have been deleted since
+	var forkedFrom *session.Instance
+	if inst.ForkedFromID != "" {
+		for _, other := range instances {
+			if other.ID == inst.ForkedFromID {
+				forkedFrom = other
+				break
+			}
+		}
+		jsonData["forked_from"] = inst.ForkedFromID
+		if !inst.ForkedAt.IsZero() {
+			jsonData["forked_at"] = inst.ForkedAt.Format(time.RFC3339)
+		}
+	}
 
 	if inst.Tool == "claude" {
 		jsonData["claude_session_id"] = inst.ClaudeSessionID
@@ -721,7 +797,11 @@ func handleSessionShow(profile string, args []string) {
 		sb.WriteString(fmt.Sprintf("Group:   %s\n", inst.GroupPath))
 	}
 
-	sb.WriteString(fmt.Sprintf("Tool:    %s\n", inst.Tool))
+	if inst.DetectedModel != "" {
+		sb.WriteString(fmt.Sprintf("Tool:    %s (%s)\n", inst.Tool, inst.DetectedModel))
+	} else {
+		sb.WriteString(fmt.Sprintf("Tool:    %s\n", inst.Tool))
+	}
 
 	if inst.Command != "" {
 		sb.WriteString(fmt.Sprintf("Command: %s\n", inst.Command))
@@ -757,8 +837,23 @@ func handleSessionShow(profile string, args []string) {
 		}
 	}
 
+	if len(inst.Tags) > 0 {
+		sb.WriteString(fmt.Sprintf("Tags:    %s\n", strings.Join(inst.Tags, ", ")))
+	}
+	if inst.Notes != "" {
+		sb.WriteString(fmt.Sprintf("Notes:   %s\n", strings.ReplaceAll(inst.Notes, "\n", "\n         ")))
+	}
+
 	sb.WriteString(fmt.Sprintf("Created: %s\n", inst.CreatedAt.Format("2006-01-02 15:04:05")))
 
+	if inst.ForkedFromID != "" {
+		source := inst.ForkedFromID + " (deleted)"
+		if forkedFrom != nil {
+			source = forkedFrom.Title
+		}
+		sb.WriteString(fmt.Sprintf("Forked:  from %s at %s\n", source, inst.ForkedAt.Format("2006-01-02 15:04:05")))
+	}
+
 	if !inst.LastAccessedAt.IsZero() {
 		sb.WriteString(fmt.Sprintf("Accessed: %s\n", inst.LastAccessedAt.Format("2006-01-02 15:04:05")))
 	}
@@ -793,6 +888,13 @@ func handleSessionSet(profile string, args []string) {
 		fmt.Println("  wrapper            Wrapper command (use {command} to include tool command)")
 		fmt.Println("  claude-session-id  Claude conversation ID")
 		fmt.Println("  gemini-session-id  Gemini conversation ID")
+		fmt.Println("  poll-interval      Status poll interval, e.g. 10s (\"default\" = [status] config)")
+		fmt.Println("  cooldown           Activity cooldown, e.g. 2s (\"default\" = [status] config)")
+		fmt.Println("  notes              Free-form notes shown in the TUI preview (\"\" clears)")
+		fmt.Println("  tags               Comma-separated tags, replacing existing ones (\"\" clears)")
+		fmt.Println("  mute               Silence notifications: on or off")
+		fmt.Println("  auto-restart       Restart up to N times after a crash, with backoff (0 or off disables)")
+		fmt.Println("  env                Load the project's .envrc/.env: auto, direnv, dotenv, off or default")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -802,15 +904,21 @@ func handleSessionSet(profile string, args []string) {
 		fmt.Println("  agent-deck session set my-project claude-session-id \"abc123-def456\"")
 		fmt.Println("  agent-deck session set my-project path /new/path/to/project")
 		fmt.Println("  agent-deck session set my-project wrapper \"nvim +'terminal {command}'\"")
+		fmt.Println("  agent-deck session set my-project cooldown 2s")
+		fmt.Println("  agent-deck session set my-project notes \"waiting on review\"")
+		fmt.Println("  agent-deck session set my-project tags bug,client-x")
+		fmt.Println("  agent-deck session set my-project mute on")
+		fmt.Println("  agent-deck session set my-project auto-restart 3")
+		fmt.Println("  agent-deck session set my-project env direnv")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	if fs.NArg() < 3 {
 		fs.Usage()
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -828,24 +936,71 @@ func handleSessionSet(profile string, args []string) {
 		"wrapper":           true,
 		"claude-session-id": true,
 		"gemini-session-id": true,
+		"poll-interval":     true,
+		"cooldown":          true,
+		"notes":             true,
+		"tags":              true,
+		"mute":              true,
+		"auto-restart":      true,
+		"env":               true,
 	}
 
 	if !validFields[field] {
 		out.Error(
 			fmt.Sprintf(
-				"invalid field: %s\nValid fields: title, path, command, tool, wrapper, claude-session-id, gemini-session-id",
+				"invalid field: %s\nValid fields: title, path, command, tool, wrapper, claude-session-id, gemini-session-id, poll-interval, cooldown, notes, tags, mute, auto-restart, env",
 				field,
 			),
 			ErrCodeInvalidOperation,
 		)
-		os.Exit(1)
+		exit(1)
+	}
+
+	var timing time.Duration
+	if field == "poll-interval" || field == "cooldown" {
+		d, err := parseTimingOverride(value)
+		if err != nil {
+			out.Error(fmt.Sprintf("invalid %s: %v", field, err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		timing = d
+	}
+
+	var mute bool
+	if field == "mute" {
+		m, err := parseOnOff(value)
+		if err != nil {
+			out.Error(fmt.Sprintf("invalid mute: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		mute = m
+	}
+
+	var autoRestart int
+	if field == "auto-restart" {
+		n, err := parseAutoRestart(value)
+		if err != nil {
+			out.Error(fmt.Sprintf("invalid auto-restart: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		autoRestart = n
+	}
+
+	var envMode string
+	if field == "env" {
+		m, err := session.ParseProjectEnvMode(value)
+		if err != nil {
+			out.Error(fmt.Sprintf("invalid env: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		envMode = m
 	}
 
 	// Load sessions
 	storage, instances, groupsData, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
@@ -853,9 +1008,9 @@ func handleSessionSet(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -886,7 +1041,7 @@ func handleSessionSet(profile string, args []string) {
 		inst.ClaudeDetectedAt = time.Now()
 		// Also update tmux environment if session is running
 		if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil && tmuxSess.Exists() {
-			_ = exec.Command("tmux", "set-environment", "-t", tmuxSess.Name, "CLAUDE_SESSION_ID", value).Run()
+			_ = tmuxSess.SetEnvironment("CLAUDE_SESSION_ID", value)
 		}
 	case "gemini-session-id":
 		oldValue = inst.GeminiSessionID
@@ -894,15 +1049,44 @@ func handleSessionSet(profile string, args []string) {
 		inst.GeminiDetectedAt = time.Now()
 		// Also update tmux environment if session is running
 		if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil && tmuxSess.Exists() {
-			_ = exec.Command("tmux", "set-environment", "-t", tmuxSess.Name, "GEMINI_SESSION_ID", value).Run()
+			_ = tmuxSess.SetEnvironment("GEMINI_SESSION_ID", value)
 		}
+	case "poll-interval":
+		oldValue = formatTimingOverride(inst.PollInterval)
+		inst.PollInterval = timing
+		value = formatTimingOverride(timing)
+	case "cooldown":
+		oldValue = formatTimingOverride(inst.ActivityCooldown)
+		inst.ActivityCooldown = timing
+		inst.ApplyActivityCooldown()
+		value = formatTimingOverride(timing)
+	case "notes":
+		oldValue = inst.Notes
+		inst.Notes = strings.TrimSpace(value)
+		value = inst.Notes
+	case "tags":
+		oldValue = strings.Join(inst.Tags, ",")
+		inst.Tags = session.ParseTags(value)
+		value = strings.Join(inst.Tags, ",")
+	case "mute":
+		oldValue = formatOnOff(inst.NotifyMuted)
+		inst.NotifyMuted = mute
+		value = formatOnOff(mute)
+	case "auto-restart":
+		oldValue = formatAutoRestart(inst.AutoRestartMax)
+		inst.AutoRestartMax = autoRestart
+		value = formatAutoRestart(autoRestart)
+	case "env":
+		oldValue = formatProjectEnv(inst.ProjectEnv)
+		inst.ProjectEnv = envMode
+		value = formatProjectEnv(envMode)
 	}
 
 	// Save
 	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Output success
@@ -916,6 +1100,81 @@ func handleSessionSet(profile string, args []string) {
 	})
 }
 
+// parseTimingOverride parses a per-session duration override.
+// "default", "0" and "" clear the override.
+func parseTimingOverride(value string) (time.Duration, error) {
+	switch strings.TrimSpace(value) {
+	case "", "0", "default":
+		return 0, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
+
+func formatTimingOverride(d time.Duration) string {
+	if d == 0 {
+		return "default"
+	}
+	return d.String()
+}
+
+// parseOnOff parses a per-session switch: on/off, true/false or yes/no.
+func parseOnOff(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "on", "true", "yes":
+		return true, nil
+	case "off", "false", "no":
+		return false, nil
+	}
+	return false, fmt.Errorf("want on or off, got %q", value)
+}
+
+func formatOnOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+// parseAutoRestart parses the number of automatic restarts allowed after a
+// crash. "off" and "0" disable them.
+func parseAutoRestart(value string) (int, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "off" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("want a restart count or off, got %q", value)
+	}
+	if n < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return n, nil
+}
+
+func formatAutoRestart(n int) string {
+	if n == 0 {
+		return "off"
+	}
+	return strconv.Itoa(n)
+}
+
+// formatProjectEnv shows a session's project environment mode, "default"
+// when it follows [shell] project_env.
+func formatProjectEnv(mode string) string {
+	if mode == "" {
+		return "default"
+	}
+	return mode
+}
+
 // loadSessionData loads storage and session data for a profile
 // The Storage.LoadWithGroups() method already handles tmux reconnection internally
 func loadSessionData(profile string) (*session.Storage, []*session.Instance, []*session.GroupData, error) {
@@ -976,36 +1235,39 @@ func findSessionByTmux(instances []*session.Instance) *session.Instance {
 		return nil
 	}
 
-	sessionName := parts[0]
-	currentPath := parts[1]
+	return matchSessionByTmuxName(instances, parts[0], parts[1])
+}
 
-	// Parse agent-deck session name: agentdeck_<title>_<id>
-	if strings.HasPrefix(sessionName, "agentdeck_") {
-		// Extract title (everything between agentdeck_ and the last _id)
-		withoutPrefix := strings.TrimPrefix(sessionName, "agentdeck_")
-		lastUnderscore := strings.LastIndex(withoutPrefix, "_")
-		if lastUnderscore > 0 {
-			title := withoutPrefix[:lastUnderscore]
+// matchSessionByTmuxName finds the session running in the tmux session
+// sessionName: by its tmux session name, else by the title in an agent-deck
+// name (agentdeck_[<profile>_]<title>_<id>), else by currentPath for other
+// tmux sessions.
+func matchSessionByTmuxName(instances []*session.Instance, sessionName, currentPath string) *session.Instance {
+	for _, inst := range instances {
+		if ts := inst.GetTmuxSession(); ts != nil && ts.Name == sessionName {
+			return inst
+		}
+	}
 
-			// Try to find by title
-			for _, inst := range instances {
-				if strings.EqualFold(inst.Title, title) {
-					return inst
-				}
+	if _, title, ok := tmux.ParseSessionName(sessionName); ok {
+		// Try to find by title
+		for _, inst := range instances {
+			if strings.EqualFold(inst.Title, title) {
+				return inst
 			}
+		}
 
-			// Try to find by sanitized title (replace - with space, etc.)
-			normalizedTitle := strings.ReplaceAll(title, "-", " ")
-			for _, inst := range instances {
-				if strings.EqualFold(inst.Title, normalizedTitle) {
-					return inst
-				}
+		// Try to find by sanitized title (replace - with space, etc.)
+		normalizedTitle := strings.ReplaceAll(title, "-", " ")
+		for _, inst := range instances {
+			if strings.EqualFold(inst.Title, normalizedTitle) {
+				return inst
 			}
-
-			// For agentdeck sessions, we have the title - don't fall back to path matching
-			// as that could match a different session with same path in another profile
-			return nil
 		}
+
+		// For agentdeck sessions, we have the title - don't fall back to path matching
+		// as that could match a different session with same path in another profile
+		return nil
 	}
 
 	// Try to find by path (only for non-agentdeck tmux sessions)
@@ -1026,7 +1288,7 @@ func showTmuxSessionInfo(out *CLIOutput, jsonOutput bool) {
 	output, err := cmd.Output()
 	if err != nil {
 		out.Error("failed to get tmux session info", ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	parts := strings.Split(strings.TrimSpace(string(output)), "\t")
@@ -1043,15 +1305,13 @@ func showTmuxSessionInfo(out *CLIOutput, jsonOutput bool) {
 		windowName = parts[3]
 	}
 
-	// Parse title from session name
+	// Parse title from session name: agentdeck_[<profile>_]<title>_<id>
 	title := sessionName
 	idFragment := ""
-	if strings.HasPrefix(sessionName, "agentdeck_") {
-		withoutPrefix := strings.TrimPrefix(sessionName, "agentdeck_")
-		lastUnderscore := strings.LastIndex(withoutPrefix, "_")
-		if lastUnderscore > 0 {
-			title = withoutPrefix[:lastUnderscore]
-			idFragment = withoutPrefix[lastUnderscore+1:]
+	if _, parsed, ok := tmux.ParseSessionName(sessionName); ok {
+		title = parsed
+		if i := strings.LastIndex(sessionName, "_"); i > len(tmux.SessionPrefix) {
+			idFragment = sessionName[i+1:]
 		}
 	}
 
@@ -1101,12 +1361,12 @@ func handleSessionSetParent(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	if fs.NArg() < 2 {
 		fs.Usage()
-		os.Exit(1)
+		exit(1)
 	}
 
 	sessionID := fs.Arg(0)
@@ -1118,14 +1378,14 @@ func handleSessionSetParent(profile string, args []string) {
 	storage, instances, groupsData, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve the session to be linked
 	inst, errMsg, errCode := ResolveSession(sessionID, instances)
 	if inst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(2)
+		exit(2)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -1133,20 +1393,20 @@ func handleSessionSetParent(profile string, args []string) {
 	parentInst, errMsg, errCode := ResolveSession(parentID, instances)
 	if parentInst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(2)
+		exit(2)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Validate: can't set self as parent
 	if inst.ID == parentInst.ID {
 		out.Error("cannot set session as its own parent", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Validate: parent can't be a sub-session (single level only)
 	if parentInst.IsSubSession() {
 		out.Error("cannot set parent to a sub-session (single level only)", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Validate: session can't already have sub-sessions
@@ -1156,7 +1416,7 @@ func handleSessionSetParent(profile string, args []string) {
 				fmt.Sprintf("session '%s' already has sub-sessions, cannot become a sub-session", inst.Title),
 				ErrCodeInvalidOperation,
 			)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -1168,7 +1428,7 @@ func handleSessionSetParent(profile string, args []string) {
 	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	out.Success(fmt.Sprintf("Linked '%s' as sub-session of '%s'", inst.Title, parentInst.Title), map[string]interface{}{
@@ -1199,12 +1459,12 @@ func handleSessionUnsetParent(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	if fs.NArg() < 1 {
 		fs.Usage()
-		os.Exit(1)
+		exit(1)
 	}
 
 	sessionID := fs.Arg(0)
@@ -1215,21 +1475,21 @@ func handleSessionUnsetParent(profile string, args []string) {
 	storage, instances, groupsData, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve the session
 	inst, errMsg, errCode := ResolveSession(sessionID, instances)
 	if inst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(2)
+		exit(2)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Check if it's actually a sub-session
 	if !inst.IsSubSession() {
 		out.Error(fmt.Sprintf("session '%s' is not a sub-session", inst.Title), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Get parent title for output
@@ -1248,7 +1508,7 @@ func handleSessionUnsetParent(profile string, args []string) {
 	groupTree := session.NewGroupTreeWithGroups(instances, groupsData)
 	if err := storage.SaveWithGroups(instances, groupTree); err != nil {
 		out.Error(fmt.Sprintf("failed to save: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	out.Success(
@@ -1272,11 +1532,14 @@ func handleSessionSend(profile string, args []string) {
 	noWait := fs.Bool("no-wait", false, "Don't wait for agent to be ready (send immediately)")
 	wait := fs.Bool("wait", false, "Block until agent finishes processing, then print output")
 	timeout := fs.Duration("timeout", 10*time.Minute, "Max time to wait for completion (used with --wait)")
+	noEnter := fs.Bool("no-enter", false, "Type the message without pressing Enter")
 
 	fs.Usage = func() {
-		fmt.Println("Usage: agent-deck session send <id|title> <message> [options]")
+		fmt.Println("Usage: agent-deck session send <id|title> [message] [options]")
+		fmt.Println("       agent-deck send <id|title> [message] [options]")
 		fmt.Println()
 		fmt.Println("Send a message to a running session.")
+		fmt.Println("If the message is omitted or \"-\", it is read from stdin.")
 		fmt.Println()
 		fmt.Println("Options:")
 		fs.PrintDefaults()
@@ -1285,29 +1548,39 @@ func handleSessionSend(profile string, args []string) {
 		fmt.Println("  agent-deck session send my-project \"Summarize recent changes\"")
 		fmt.Println("  agent-deck session send my-project \"run tests\" --wait")
 		fmt.Println("  agent-deck session send my-project \"quick ping\" --no-wait")
+		fmt.Println("  agent-deck send my-project \"/clear\" --no-enter")
+		fmt.Println("  git diff | agent-deck send my-project -")
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 	remaining := fs.Args()
 
 	out := NewCLIOutput(*jsonOutput, *quiet)
 
-	if len(remaining) < 2 {
+	if len(remaining) < 1 {
 		fs.Usage()
 		out.Error("session and message are required", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	sessionRef := remaining[0]
 	message := strings.Join(remaining[1:], " ")
+	if message == "" || message == "-" {
+		stdinMessage, err := readMessageFromStdin()
+		if err != nil {
+			out.Error(err.Error(), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		message = stdinMessage
+	}
 
 	// Load sessions
 	_, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(err.Error(), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
@@ -1315,45 +1588,51 @@ func handleSessionSend(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Check if session is running
 	if !inst.Exists() {
 		out.Error(fmt.Sprintf("session '%s' is not running", inst.Title), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Get tmux session
 	tmuxSess := inst.GetTmuxSession()
 	if tmuxSess == nil {
 		out.Error("could not determine tmux session", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
-	// Wait for agent to be ready (unless --no-wait is specified)
-	if !*noWait {
+	// Wait for agent to be ready (unless --no-wait or --no-enter is specified)
+	if !*noWait && !*noEnter {
 		if err := waitForAgentReady(tmuxSess, inst.Tool); err != nil {
 			out.Error(fmt.Sprintf("timeout waiting for agent: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
 	// Send message atomically (text + Enter in single tmux invocation).
 	// --no-wait: fire-and-forget, skip retry/verification overhead entirely.
 	// Otherwise: retry Enter if the agent doesn't start processing promptly.
-	if *noWait {
+	// --no-enter: only type the text, leaving it in the agent's input line.
+	if *noEnter {
+		if err := tmuxSess.SendKeysChunked(message); err != nil {
+			out.Error(fmt.Sprintf("failed to send message: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+	} else if *noWait {
 		if err := tmuxSess.SendKeysAndEnter(message); err != nil {
 			out.Error(fmt.Sprintf("failed to send message: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
 		if err := sendWithRetry(tmuxSess, message, false); err != nil {
 			out.Error(fmt.Sprintf("failed to send message: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -1365,11 +1644,11 @@ func handleSessionSend(profile string, args []string) {
 	})
 
 	// If --wait, block until the agent finishes processing, then print output
-	if *wait {
+	if *wait && !*noEnter {
 		finalStatus, err := waitForCompletion(tmuxSess, *timeout)
 		if err != nil {
 			out.Error(fmt.Sprintf("timeout waiting for completion: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Refresh session ID: the instance was loaded before sending the message,
@@ -1396,17 +1675,34 @@ func handleSessionSend(profile string, args []string) {
 		}
 		if err != nil {
 			out.Error(fmt.Sprintf("failed to get response: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println(response.Content)
 
 		// Exit 1 for error/inactive status
 		if finalStatus == "inactive" || finalStatus == "error" {
-			os.Exit(1)
+			exit(1)
 		}
 	}
 }
 
+// readMessageFromStdin reads a message piped on stdin, trimming the trailing newline.
+// Returns an error when stdin is a terminal or the input is empty.
+func readMessageFromStdin() (string, error) {
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", fmt.Errorf("message is required (pass it as an argument or pipe it on stdin)")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("failed to read stdin: %w", err)
+	}
+	message := strings.TrimRight(string(data), "\r\n")
+	if strings.TrimSpace(message) == "" {
+		return "", fmt.Errorf("message from stdin is empty")
+	}
+	return message, nil
+}
+
 // sendWithRetry sends a message atomically and retries Enter if the agent
 // doesn't start processing within a reasonable time.
 func sendWithRetry(tmuxSess *tmux.Session, message string, skipVerify bool) error {
@@ -1538,7 +1834,7 @@ func handleSessionOutput(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -1549,7 +1845,7 @@ func handleSessionOutput(profile string, args []string) {
 	_, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session (allow current session detection)
@@ -1557,9 +1853,9 @@ func handleSessionOutput(profile string, args []string) {
 	if inst == nil {
 		out.Error(errMsg, errCode)
 		if errCode == ErrCodeNotFound {
-			os.Exit(2)
+			exit(2)
 		}
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
@@ -1567,7 +1863,7 @@ func handleSessionOutput(profile string, args []string) {
 	response, err := inst.GetLastResponse()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to get response: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Copy to clipboard mode
@@ -1576,7 +1872,7 @@ func handleSessionOutput(profile string, args []string) {
 		result, err := clipboard.Copy(response.Content, termInfo.SupportsOSC52)
 		if err != nil {
 			out.Error(fmt.Sprintf("clipboard: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 		jsonData := map[string]interface{}{
 			"success":       true,
@@ -1651,7 +1947,7 @@ func handleSessionCurrent(profileArg string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	quietMode := *quiet || *quietShort
@@ -1660,7 +1956,7 @@ func handleSessionCurrent(profileArg string, args []string) {
 	// Check if we're in a tmux session
 	if os.Getenv("TMUX") == "" {
 		out.Error("not in a tmux session", ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// ═══════════════════════════════════════════════════════════════════
@@ -1670,7 +1966,7 @@ func handleSessionCurrent(profileArg string, args []string) {
 	tmuxSessionName, err := getCurrentTmuxSessionName()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to get current tmux session: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Detect profile: use explicit arg if provided, otherwise auto-detect
@@ -1687,7 +1983,7 @@ func handleSessionCurrent(profileArg string, args []string) {
 			"current tmux session is not an agent-deck session\nHint: Run 'agent-deck list' to see available sessions",
 			ErrCodeNotFound,
 		)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if foundProfile != "" {
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestMatchSessionByTmuxName(t *testing.T) {
+	api := session.NewInstance("my api", "/code/api")
+	other := session.NewInstance("other", "/code/other")
+	instances := []*session.Instance{other, api}
+
+	tests := []struct {
+		name        string
+		sessionName string
+		currentPath string
+		want        *session.Instance
+	}{
+		{"own tmux session", api.GetTmuxSession().Name, "/elsewhere", api},
+		{"default profile name", "agentdeck_my-api_1a2b3c4d", "", api},
+		{"namespaced name", "agentdeck_work_my-api_1a2b3c4d", "", api},
+		{"namespaced one-word title", "agentdeck_work_other_1a2b3c4d", "", other},
+		{"unknown agent-deck title", "agentdeck_work_gone_1a2b3c4d", "/code/api", nil},
+		{"plain tmux session by path", "main", "/code/other", other},
+	}
+	for _, tt := range tests {
+		if got := matchSessionByTmuxName(instances, tt.sessionName, tt.currentPath); got != tt.want {
+			t.Errorf("%s: matchSessionByTmuxName(%q) = %v, want %v", tt.name, tt.sessionName, got, tt.want)
+		}
+	}
+}
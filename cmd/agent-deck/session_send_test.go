@@ -193,3 +193,38 @@ func TestWaitOutputRetrieval_StaleSessionID(t *testing.T) {
 		}
 	})
 }
+
+func TestReadMessageFromStdin(t *testing.T) {
+	withStdin := func(t *testing.T, input string) {
+		t.Helper()
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		orig := os.Stdin
+		os.Stdin = r
+		t.Cleanup(func() { os.Stdin = orig; r.Close() })
+		if _, err := w.WriteString(input); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+	}
+
+	t.Run("trims trailing newline", func(t *testing.T) {
+		withStdin(t, "line one\nline two\n")
+		msg, err := readMessageFromStdin()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg != "line one\nline two" {
+			t.Errorf("got %q", msg)
+		}
+	})
+
+	t.Run("empty input is an error", func(t *testing.T) {
+		withStdin(t, "  \n")
+		if _, err := readMessageFromStdin(); err == nil {
+			t.Error("expected error for empty stdin")
+		}
+	})
+}
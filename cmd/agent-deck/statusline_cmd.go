@@ -0,0 +1,229 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/ipc"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// Statusline formats.
+const (
+	statuslineTmux    = "tmux"
+	statuslinePlain   = "plain"
+	statuslinePolybar = "polybar"
+	statuslineWaybar  = "waybar"
+)
+
+var statuslineFormats = []string{statuslineTmux, statuslinePlain, statuslinePolybar, statuslineWaybar}
+
+// statuslineTooltipTitles is how many titles the waybar tooltip lists per
+// status.
+const statuslineTooltipTitles = 8
+
+// statuslinePart is one status in the statusline, with its TUI symbol and
+// dark-theme color.
+type statuslinePart struct {
+	status session.Status
+	label  string
+	symbol string
+	color  string
+	bold   bool
+	always bool // Shown even when zero
+}
+
+var statuslineParts = []statuslinePart{
+	{session.StatusNeedsInput, "needs input", "◆", "#ff9e64", true, false},
+	{session.StatusRunning, "running", "●", "#9ece6a", false, true},
+	{session.StatusWaiting, "waiting", "◐", "#e0af68", false, true},
+	{session.StatusIdle, "idle", "○", "#787fa0", false, true},
+	{session.StatusError, "stopped", "✕", "#f7768e", false, false},
+}
+
+// count returns the part's count in counts.
+func (p statuslinePart) count(counts ipc.StatusCounts) int {
+	switch p.status {
+	case session.StatusNeedsInput:
+		return counts.NeedsInput
+	case session.StatusRunning:
+		return counts.Running
+	case session.StatusWaiting:
+		return counts.Waiting
+	case session.StatusIdle:
+		return counts.Idle
+	default:
+		return counts.Error
+	}
+}
+
+// handleStatusline prints a one-line status summary for tmux's status bar
+// or a desktop bar.
+func handleStatusline(profile string, args []string) {
+	fs := flag.NewFlagSet("statusline", flag.ExitOnError)
+	format := fs.String("format", statuslineTmux, "Output format: "+strings.Join(statuslineFormats, ", "))
+	plain := fs.Bool("plain", false, "Same as --format plain")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck statusline [options]")
+		fmt.Println()
+		fmt.Println("Print a compact status summary like \"●3 ◐2 ○5\" for a status bar:")
+		fmt.Println("running, waiting and idle sessions, plus ◆ sessions that need input and")
+		fmt.Println("✕ stopped ones when there are any. Prints nothing for an empty deck.")
+		fmt.Println()
+		fmt.Println("Statuses come from the daemon when it runs, else from the last ones the")
+		fmt.Println("TUI or daemon saved, so it never polls tmux itself.")
+		fmt.Println()
+		fmt.Println("Formats:")
+		fmt.Println("  tmux     With tmux color codes (default)")
+		fmt.Println("  plain    Without colors, e.g. for a sketchybar label")
+		fmt.Println("  polybar  With polybar color tags")
+		fmt.Println("  waybar   JSON with text, tooltip and class, for return-type json")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  # ~/.tmux.conf")
+		fmt.Println("  set -g status-right '#(agent-deck statusline) %H:%M'")
+		fmt.Println("  set -g status-interval 5")
+		fmt.Println()
+		fmt.Println("  # waybar config")
+		fmt.Println("  \"custom/agent-deck\": {\"exec\": \"agent-deck statusline --format waybar\", \"return-type\": \"json\", \"interval\": 5}")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	if *plain {
+		*format = statuslinePlain
+	}
+
+	sessions, err := loadStatuslineSessions(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+	var counts ipc.StatusCounts
+	for _, s := range sessions {
+		counts.Add(session.Status(s.Status))
+	}
+
+	switch *format {
+	case statuslineTmux:
+		fmt.Println(formatStatusline(counts, func(p statuslinePart, text string) string {
+			style := "fg=" + p.color
+			if p.bold {
+				style += ",bold"
+			}
+			return "#[" + style + "]" + text + "#[default]"
+		}))
+	case statuslinePlain:
+		fmt.Println(formatStatusline(counts, nil))
+	case statuslinePolybar:
+		fmt.Println(formatStatusline(counts, func(p statuslinePart, text string) string {
+			return "%{F" + p.color + "}" + text + "%{F-}"
+		}))
+	case statuslineWaybar:
+		data, _ := json.Marshal(waybarStatus(sessions, counts))
+		fmt.Println(string(data))
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (want %s)\n", *format, strings.Join(statuslineFormats, ", "))
+		exit(1)
+	}
+}
+
+// loadStatuslineSessions returns profile's sessions with the daemon's
+// statuses if it runs, else the statuses last saved, without polling tmux.
+func loadStatuslineSessions(profile string) ([]ipc.SessionInfo, error) {
+	var list ipc.ListResult
+	if ok, err := callDaemon(profile, ipc.MethodList, ipc.ListParams{}, &list); ok {
+		return list.Sessions, err
+	}
+
+	_, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]ipc.SessionInfo, len(instances))
+	for i, inst := range instances {
+		sessions[i] = ipc.NewSessionInfo(inst, inst.GetStatusThreadSafe())
+	}
+	return sessions, nil
+}
+
+// formatStatusline renders counts, styling each part with style if set.
+func formatStatusline(counts ipc.StatusCounts, style func(p statuslinePart, text string) string) string {
+	if counts.Total == 0 {
+		return ""
+	}
+	var parts []string
+	for _, p := range statuslineParts {
+		n := p.count(counts)
+		if n == 0 && !p.always {
+			continue
+		}
+		part := fmt.Sprintf("%s%d", p.symbol, n)
+		if style != nil {
+			part = style(p, part)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// waybarModule is the JSON a waybar custom module with return-type json
+// reads. Class and alt name the most pressing status, for CSS and
+// format-icons.
+type waybarModule struct {
+	Text    string `json:"text"`
+	Alt     string `json:"alt"`
+	Tooltip string `json:"tooltip"`
+	Class   string `json:"class"`
+}
+
+// waybarStatus builds the waybar module: the plain summary, a tooltip
+// listing sessions by status, and a class of needs-input, waiting, running,
+// idle or empty.
+func waybarStatus(sessions []ipc.SessionInfo, counts ipc.StatusCounts) waybarModule {
+	class := "empty"
+	switch {
+	case counts.NeedsInput > 0:
+		class = "needs-input"
+	case counts.Waiting > 0:
+		class = "waiting"
+	case counts.Running > 0:
+		class = "running"
+	case counts.Total > 0:
+		class = "idle"
+	}
+
+	var lines []string
+	for _, p := range statuslineParts {
+		var titles []string
+		for _, s := range sessions {
+			if session.Status(s.Status) == p.status {
+				// Waybar renders the tooltip as Pango markup
+				titles = append(titles, html.EscapeString(s.Title))
+			}
+		}
+		if len(titles) == 0 {
+			continue
+		}
+		if len(titles) > statuslineTooltipTitles {
+			titles = append(titles[:statuslineTooltipTitles], fmt.Sprintf("and %d more", len(titles)-statuslineTooltipTitles))
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s", p.symbol, p.label, strings.Join(titles, ", ")))
+	}
+
+	return waybarModule{
+		Text:    formatStatusline(counts, nil),
+		Alt:     class,
+		Tooltip: strings.Join(lines, "\n"),
+		Class:   class,
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/ipc"
+)
+
+func TestFormatStatusline(t *testing.T) {
+	tmuxStyle := func(p statuslinePart, text string) string { return "#[fg=" + p.color + "]" + text + "#[default]" }
+	tests := []struct {
+		counts ipc.StatusCounts
+		style  func(statuslinePart, string) string
+		want   string
+	}{
+		{ipc.StatusCounts{}, tmuxStyle, ""},
+		{ipc.StatusCounts{Running: 3, Waiting: 2, Idle: 5, Total: 10}, nil, "●3 ◐2 ○5"},
+		{ipc.StatusCounts{NeedsInput: 1, Idle: 1, Error: 2, Total: 4}, nil, "◆1 ●0 ◐0 ○1 ✕2"},
+		{ipc.StatusCounts{Running: 1, Total: 1}, tmuxStyle, "#[fg=#9ece6a]●1#[default] #[fg=#e0af68]◐0#[default] #[fg=#787fa0]○0#[default]"},
+	}
+	for _, tt := range tests {
+		if got := formatStatusline(tt.counts, tt.style); got != tt.want {
+			t.Errorf("formatStatusline(%+v) = %q, want %q", tt.counts, got, tt.want)
+		}
+	}
+}
+
+func TestWaybarStatus(t *testing.T) {
+	sessions := []ipc.SessionInfo{
+		{Title: "api", Status: "waiting"},
+		{Title: "web", Status: "running"},
+		{Title: "docs", Status: "waiting"},
+	}
+	for i := 0; i < 10; i++ {
+		sessions = append(sessions, ipc.SessionInfo{Title: fmt.Sprintf("s%d", i), Status: "idle"})
+	}
+	counts := ipc.StatusCounts{Waiting: 2, Running: 1, Idle: 10, Total: 13}
+
+	got := waybarStatus(sessions, counts)
+	want := waybarModule{
+		Text:    "●1 ◐2 ○10",
+		Alt:     "waiting",
+		Tooltip: "● running: web\n◐ waiting: api, docs\n○ idle: s0, s1, s2, s3, s4, s5, s6, s7, and 2 more",
+		Class:   "waiting",
+	}
+	if got != want {
+		t.Errorf("waybarStatus =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := waybarStatus(nil, ipc.StatusCounts{}); got.Class != "empty" || got.Text != "" {
+		t.Errorf("empty deck = %+v", got)
+	}
+
+	markup := []ipc.SessionInfo{{Title: "R&D <fix>", Status: "running"}}
+	if got := waybarStatus(markup, ipc.StatusCounts{Running: 1, Total: 1}); got.Tooltip != "● running: R&amp;D &lt;fix&gt;" {
+		t.Errorf("tooltip = %q, want titles escaped for Pango", got.Tooltip)
+	}
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// upResult records what `agent-deck up` did for one manifest entry
+type upResult struct {
+	Title   string `json:"title"`
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Group   string `json:"group"`
+	Action  string `json:"action"` // "created" or "exists"
+	Started bool   `json:"started,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// handleUp creates any sessions from a manifest file that don't exist yet
+func handleUp(profile string, args []string) {
+	fs := flag.NewFlagSet("up", flag.ExitOnError)
+	file := fs.String("file", "", "Manifest file (default: agent-deck.yaml/.json in current directory)")
+	fileShort := fs.String("f", "", "Manifest file (short)")
+	start := fs.Bool("start", false, "Also start sessions that are not running")
+	dryRun := fs.Bool("dry-run", false, "Show what would be created without changing anything")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+	quiet := fs.Bool("quiet", false, "Minimal output")
+	quietShort := fs.Bool("q", false, "Minimal output (short)")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck up [options]")
+		fmt.Println()
+		fmt.Println("Create the sessions described in a manifest file. Sessions that already")
+		fmt.Println("exist (same title and path) are left untouched, so running it twice is safe.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Manifest format (YAML, or JSON with the same keys):")
+		fmt.Println("  group: my-team              # default group (optional)")
+		fmt.Println("  sessions:")
+		fmt.Println("    - name: api")
+		fmt.Println("      path: services/api      # relative to the manifest file")
+		fmt.Println("      command: claude")
+		fmt.Println("    - name: docs")
+		fmt.Println("      path: docs")
+		fmt.Println("      group: writing")
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck up                        # Use ./agent-deck.yaml")
+		fmt.Println("  agent-deck up -f deck.json --start")
+		fmt.Println("  agent-deck -p work up --dry-run")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+
+	quietMode := *quiet || *quietShort
+	out := NewCLIOutput(*jsonOutput, quietMode)
+
+	manifestPath := mergeFlags(*file, *fileShort)
+	if manifestPath == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			out.Error(fmt.Sprintf("failed to get current directory: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+		manifestPath = session.FindManifest(cwd)
+		if manifestPath == "" {
+			out.Error("no manifest found (looked for agent-deck.yaml, agent-deck.json); use -f", ErrCodeNotFound)
+			exit(2)
+		}
+	}
+
+	manifest, err := session.LoadManifest(manifestPath)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+
+	applyManifest(out, profile, manifest, manifestPath, manifestOptions{
+		start: *start, dryRun: *dryRun, jsonOutput: *jsonOutput, quiet: quietMode,
+	})
+}
+
+// manifestOptions are the flags shared by `up` and `import`.
+type manifestOptions struct {
+	start, dryRun, jsonOutput, quiet bool
+}
+
+// applyManifest creates the manifest's sessions that don't exist yet, starts
+// them with opts.start, and reports what it did. source names where the
+// manifest came from. Exits on failure.
+func applyManifest(out *CLIOutput, profile string, manifest *session.Manifest, source string, opts manifestOptions) {
+	storage, instances, groups, err := loadSessionData(profile)
+	if err != nil {
+		out.Error(err.Error(), ErrCodeInvalidOperation)
+		exit(1)
+	}
+	groupTree := session.NewGroupTreeWithGroups(instances, groups)
+
+	// Validate every entry before creating anything, so a typo doesn't leave
+	// a half-applied deck behind.
+	for _, entry := range manifest.Sessions {
+		info, err := os.Stat(entry.Path)
+		if err != nil || !info.IsDir() {
+			out.Error(fmt.Sprintf("session %q: path is not a directory: %s", entry.Name, entry.Path), ErrCodeInvalidOperation)
+			exit(1)
+		}
+	}
+
+	var results []upResult
+	var targets []*session.Instance
+	created := 0
+	for _, entry := range manifest.Sessions {
+		groupPath := resolveGroupPathForAdd(groupTree, entry.Group)
+
+		if isDupe, existing := isDuplicateSession(instances, entry.Name, entry.Path); isDupe {
+			results = append(results, upResult{
+				Title: existing.Title, ID: existing.ID, Path: existing.ProjectPath,
+				Group: existing.GroupPath, Action: "exists",
+			})
+			targets = append(targets, existing)
+			continue
+		}
+
+		var inst *session.Instance
+		if groupPath != "" {
+			inst = session.NewInstanceWithGroup(entry.Name, entry.Path, groupPath)
+		} else {
+			inst = session.NewInstance(entry.Name, entry.Path)
+		}
+		setInstanceCommand(inst, entry.Command)
+		if entry.Wrapper != "" {
+			inst.Wrapper = entry.Wrapper
+		}
+
+		instances = append(instances, inst)
+		targets = append(targets, inst)
+		created++
+		results = append(results, upResult{
+			Title: inst.Title, ID: inst.ID, Path: inst.ProjectPath,
+			Group: inst.GroupPath, Action: "created",
+		})
+	}
+
+	if !opts.dryRun && created > 0 {
+		groupTree = session.NewGroupTreeWithGroups(instances, groups)
+		for _, inst := range targets {
+			if inst.GroupPath != "" {
+				groupTree.CreateGroup(inst.GroupPath)
+			}
+		}
+		if err := storage.SaveWithGroups(instances, groupTree); err != nil {
+			out.Error(fmt.Sprintf("failed to save sessions: %v", err), ErrCodeInvalidOperation)
+			exit(1)
+		}
+	}
+
+	failed := false
+	if opts.start && !opts.dryRun {
+		startedAny := false
+		for i, inst := range targets {
+			if inst.Exists() {
+				continue
+			}
+			if err := inst.Start(); err != nil {
+				results[i].Error = err.Error()
+				failed = true
+				continue
+			}
+			inst.PostStartSync(3 * time.Second)
+			results[i].Started = true
+			startedAny = true
+		}
+		if startedAny {
+			if err := saveSessionData(storage, instances); err != nil {
+				out.Error(fmt.Sprintf("failed to save session state: %v", err), ErrCodeInvalidOperation)
+				exit(1)
+			}
+		}
+	}
+
+	if opts.jsonOutput {
+		out.Print("", map[string]interface{}{
+			"success":  !failed,
+			"manifest": source,
+			"profile":  storage.Profile(),
+			"dry_run":  opts.dryRun,
+			"sessions": results,
+		})
+	} else if !opts.quiet {
+		verb := "Applied"
+		if opts.dryRun {
+			verb = "Would apply"
+		}
+		fmt.Printf("%s %s to profile '%s':\n", verb, FormatPath(source), storage.Profile())
+		for _, r := range results {
+			line := fmt.Sprintf("  %s %-20s %-8s %s", bulletSymbol, r.Title, r.Action, FormatPath(r.Path))
+			if r.Started {
+				line += " (started)"
+			}
+			if r.Error != "" {
+				line += fmt.Sprintf(" (start failed: %s)", r.Error)
+			}
+			fmt.Println(line)
+		}
+		fmt.Printf("%d created, %d already present\n", created, len(results)-created)
+	}
+
+	if failed {
+		exit(1)
+	}
+}
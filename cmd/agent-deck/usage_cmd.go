@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// Column widths for usage command output
+const (
+	usageColTitle = 20
+	usageColTool  = 8
+	usageColModel = 26
+	usageColToks  = 10
+)
+
+// sessionUsage is one row of the usage summary.
+type sessionUsage struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Group string `json:"group"`
+	Tool  string `json:"tool"`
+	session.Usage
+}
+
+// handleUsage prints token usage and estimated cost per session
+func handleUsage(profile string, args []string) {
+	fs := flag.NewFlagSet("usage", flag.ExitOnError)
+	group := fs.String("group", "", "Only sessions in this group (and its subgroups)")
+	groupShort := fs.String("g", "", "Only sessions in this group (short)")
+	tag := fs.String("tag", "", "Only sessions with this tag")
+	jsonOutput := fs.Bool("json", false, "Output as JSON")
+
+	fs.Usage = func() {
+		fmt.Println("Usage: agent-deck usage [options]")
+		fmt.Println()
+		fmt.Println("Show token usage and estimated cost per session, read from the")
+		fmt.Println("Claude and Gemini transcripts. Costs use public API list prices;")
+		fmt.Println("subscription plans are billed differently.")
+		fmt.Println()
+		fmt.Println("Options:")
+		fs.PrintDefaults()
+		fmt.Println()
+		fmt.Println("Examples:")
+		fmt.Println("  agent-deck usage")
+		fmt.Println("  agent-deck usage -g work --json")
+	}
+
+	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
+		exit(1)
+	}
+	groupPath := *group
+	if *groupShort != "" {
+		groupPath = *groupShort
+	}
+	groupPath = strings.ToLower(strings.TrimSpace(groupPath))
+
+	_, instances, _, err := loadSessionData(profile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+	instances = filterByTag(instances, *tag)
+
+	rows := make([]sessionUsage, 0, len(instances))
+	var total session.Usage
+	for _, inst := range instances {
+		if groupPath != "" && inst.GroupPath != groupPath && !strings.HasPrefix(inst.GroupPath, groupPath+"/") {
+			continue
+		}
+		u, ok := inst.ReadUsage()
+		if !ok {
+			continue
+		}
+		rows = append(rows, sessionUsage{ID: inst.ID, Title: inst.Title, Group: inst.GroupPath, Tool: inst.Tool, Usage: u})
+		total = total.Add(u)
+	}
+	sort.SliceStable(rows, func(a, b int) bool { return rows[a].Cost > rows[b].Cost })
+
+	if *jsonOutput {
+		output, err := json.MarshalIndent(struct {
+			Sessions []sessionUsage `json:"sessions"`
+			Total    session.Usage  `json:"total"`
+		}{rows, total}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to format JSON output: %v\n", err)
+			exit(1)
+		}
+		fmt.Println(string(output))
+		return
+	}
+
+	if len(rows) == 0 {
+		fmt.Println("No token usage found (only Claude and Gemini sessions are tracked).")
+		return
+	}
+
+	fmt.Printf("%-*s %-*s %-*s %*s %10s\n",
+		usageColTitle, "TITLE", usageColTool, "TOOL", usageColModel, "MODEL", usageColToks, "TOKENS", "COST")
+	for _, r := range rows {
+		fmt.Printf("%-*s %-*s %-*s %*s %10s\n",
+			usageColTitle, truncate(r.Title, usageColTitle),
+			usageColTool, r.Tool,
+			usageColModel, truncate(r.Model, usageColModel),
+			usageColToks, formatTokenCount(r.TotalTokens()),
+			formatCost(r.Cost))
+	}
+	fmt.Printf("\n%-*s %*s %10s\n",
+		usageColTitle+usageColTool+usageColModel+2, fmt.Sprintf("Total: %d sessions", len(rows)),
+		usageColToks, formatTokenCount(total.TotalTokens()),
+		formatCost(total.Cost))
+}
+
+// formatTokenCount renders a token count compactly: 950, 12.3k, 1.24M.
+func formatTokenCount(n int) string {
+	switch {
+	case n < 1000:
+		return fmt.Sprintf("%d", n)
+	case n < 1_000_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	default:
+		return fmt.Sprintf("%.2fM", float64(n)/1_000_000)
+	}
+}
+
+// formatCost renders an estimated cost in dollars.
+func formatCost(c float64) string {
+	if c > 0 && c < 0.01 {
+		return "<$0.01"
+	}
+	return fmt.Sprintf("$%.2f", c)
+}
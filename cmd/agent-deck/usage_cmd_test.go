@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestFormatTokenCount(t *testing.T) {
+	for n, want := range map[int]string{0: "0", 950: "950", 12345: "12.3k", 1_240_000: "1.24M"} {
+		if got := formatTokenCount(n); got != want {
+			t.Errorf("formatTokenCount(%d) = %q, want %q", n, got, want)
+		}
+	}
+	for c, want := range map[float64]string{0: "$0.00", 0.004: "<$0.01", 3.456: "$3.46"} {
+		if got := formatCost(c); got != want {
+			t.Errorf("formatCost(%v) = %q, want %q", c, got, want)
+		}
+	}
+}
@@ -33,7 +33,7 @@ func handleWorktree(profile string, args []string) {
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown worktree command: %s\n", args[0])
 		printWorktreeUsage()
-		os.Exit(1)
+		exit(1)
 	}
 }
 
@@ -79,7 +79,7 @@ func handleWorktreeList(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	out := NewCLIOutput(*jsonOutput, false)
@@ -88,34 +88,34 @@ func handleWorktreeList(profile string, args []string) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to get current directory: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Check if in a git repo
 	if !git.IsGitRepo(cwd) {
 		out.Error("not in a git repository", ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Get repo root (resolve through worktrees to prevent nesting)
 	repoRoot, err := git.GetWorktreeBaseRoot(cwd)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to get repo root: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// List worktrees
 	worktrees, err := git.ListWorktrees(repoRoot)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to list worktrees: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Load sessions
 	_, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Build session map: path -> session title
@@ -210,7 +210,7 @@ func handleWorktreeInfo(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -220,28 +220,28 @@ func handleWorktreeInfo(profile string, args []string) {
 		out.Error("session identifier is required", ErrCodeNotFound)
 		fmt.Println()
 		fs.Usage()
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Load sessions
 	_, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
 	inst, errMsg, errCode := ResolveSession(identifier, instances)
 	if inst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(1)
+		exit(1)
 		return // unreachable, satisfies staticcheck SA5011
 	}
 
 	// Check if session has worktree info
 	if !inst.IsWorktree() {
 		out.Error(fmt.Sprintf("session '%s' is not in a worktree", inst.Title), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Check if worktree still exists
@@ -298,7 +298,7 @@ func handleWorktreeCleanup(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	out := NewCLIOutput(*jsonOutput, false)
@@ -307,7 +307,7 @@ func handleWorktreeCleanup(profile string, args []string) {
 	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeNotFound)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Find orphaned sessions (WorktreePath set but directory doesn't exist)
@@ -324,7 +324,7 @@ func handleWorktreeCleanup(profile string, args []string) {
 	cwd, err := os.Getwd()
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to get current directory: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Find orphaned worktrees (exist but no session points to them)
@@ -462,7 +462,7 @@ func handleWorktreeCleanup(profile string, args []string) {
 		// Save updated session data (uses existing saveSessionData which rebuilds GroupTree)
 		if err := saveSessionData(storage, remaining); err != nil {
 			out.Error(fmt.Sprintf("failed to save session data: %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -509,7 +509,7 @@ func handleWorktreeFinish(profile string, args []string) {
 	}
 
 	if err := fs.Parse(normalizeArgs(fs, args)); err != nil {
-		os.Exit(1)
+		exit(1)
 	}
 
 	identifier := fs.Arg(0)
@@ -519,28 +519,28 @@ func handleWorktreeFinish(profile string, args []string) {
 		out.Error("session identifier is required", ErrCodeNotFound)
 		fmt.Println()
 		fs.Usage()
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Load sessions
 	storage, instances, _, err := loadSessionData(profile)
 	if err != nil {
 		out.Error(fmt.Sprintf("failed to load sessions: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Resolve session
 	inst, errMsg, errCode := ResolveSessionOrCurrent(identifier, instances)
 	if inst == nil {
 		out.Error(errMsg, errCode)
-		os.Exit(1)
+		exit(1)
 		return
 	}
 
 	// Validate it's a worktree session
 	if !inst.IsWorktree() {
 		out.Error(fmt.Sprintf("session '%s' is not in a worktree", inst.Title), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	repoRoot := inst.WorktreeRepoRoot
@@ -557,12 +557,12 @@ func handleWorktreeFinish(profile string, args []string) {
 				dirty = false
 			} else {
 				out.Error(fmt.Sprintf("failed to check worktree status: %v", err), ErrCodeInvalidOperation)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 		if dirty {
 			out.Error("worktree has uncommitted changes (use --force to override)", ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -572,14 +572,14 @@ func handleWorktreeFinish(profile string, args []string) {
 		targetBranch, err = git.GetDefaultBranch(repoRoot)
 		if err != nil {
 			out.Error(fmt.Sprintf("could not determine target branch: %v\nUse --into <branch> to specify", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
 	// Validate target != source
 	if !*noMerge && targetBranch == worktreeBranch {
 		out.Error(fmt.Sprintf("cannot merge branch '%s' into itself", worktreeBranch), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	// Show summary and confirm
@@ -619,7 +619,7 @@ func handleWorktreeFinish(profile string, args []string) {
 		checkoutOutput, err := cmd.CombinedOutput()
 		if err != nil {
 			out.Error(fmt.Sprintf("failed to checkout %s: %s", targetBranch, strings.TrimSpace(string(checkoutOutput))), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Merge the worktree branch
@@ -628,7 +628,7 @@ func handleWorktreeFinish(profile string, args []string) {
 			abortCmd := exec.Command("git", "-C", repoRoot, "merge", "--abort")
 			_ = abortCmd.Run()
 			out.Error(fmt.Sprintf("merge failed (aborted): %v", err), ErrCodeInvalidOperation)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Printf("  %s Merged successfully\n", successSymbol)
 	}
@@ -670,7 +670,7 @@ func handleWorktreeFinish(profile string, args []string) {
 	}
 	if err := saveSessionData(storage, remaining); err != nil {
 		out.Error(fmt.Sprintf("failed to save session data: %v", err), ErrCodeInvalidOperation)
-		os.Exit(1)
+		exit(1)
 	}
 
 	if *jsonOutput {
@@ -0,0 +1,494 @@
+// Package daemon runs agent-deck's background work without a TUI: status
+// polling, notifications, webhooks, auto-restart and queued prompts. It also
+// answers the CLI and other tools on a unix socket (see package ipc).
+//
+// The daemon steps aside while a TUI is open for its profile, since the TUI
+// does the same work itself (and knows whether the user is looking), and
+// takes over again once the last TUI's heartbeat goes stale.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/notify"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+var daemonLog = logging.ForComponent(logging.CompDaemon)
+
+const (
+	// PIDFileName and LogFileName are the daemon's files in the profile
+	// directory.
+	PIDFileName = "daemon.pid"
+	LogFileName = "daemon.log"
+
+	// refreshEvery is how often the daemon checks for open TUIs and for
+	// sessions changed by other processes.
+	refreshEvery = 5 * time.Second
+)
+
+// ErrRunning is returned by Run when the profile already has a daemon.
+var ErrRunning = errors.New("daemon is already running")
+
+// PIDFile returns the path of profile's pid file.
+func PIDFile(profile string) (string, error) {
+	dir, err := session.GetProfileDir(session.GetEffectiveProfile(profile))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, PIDFileName), nil
+}
+
+// RunningPID returns the pid of profile's daemon, or 0 when none is running.
+func RunningPID(profile string) int {
+	path, err := PIDFile(profile)
+	if err != nil {
+		return 0
+	}
+	return readPIDFile(path)
+}
+
+// readPIDFile returns the pid in path if that process is alive, else 0.
+func readPIDFile(path string) int {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil || proc.Signal(syscall.Signal(0)) != nil {
+		return 0
+	}
+	return pid
+}
+
+// Daemon does the TUI's background work for one profile.
+type Daemon struct {
+	profile       string
+	storage       *session.Storage
+	db            *statedb.StateDB
+	notifications session.NotificationsConfig
+	pushTargets   notify.PushTargets
+
+	mu        sync.Mutex
+	instances []*session.Instance
+	tree      *session.GroupTree
+	loadedAt  int64 // statedb LastModified at the last load or save
+	checkedAt time.Time
+	tuiOpen   bool
+	dnd       bool // Do not disturb, as set in the TUI
+	watchOnly bool // Started by Watch: never steps aside for a TUI
+
+	saveNeeded atomic.Bool
+}
+
+// New loads profile's sessions for a daemon.
+func New(profile string) (*Daemon, error) {
+	storage, err := session.NewStorageWithProfile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	settings := session.GetNotificationsSettings()
+	d := &Daemon{
+		profile:       storage.Profile(),
+		storage:       storage,
+		db:            storage.GetDB(),
+		notifications: settings,
+		pushTargets: notify.PushTargets{
+			NtfyServer:    settings.Ntfy.Server,
+			NtfyTopic:     settings.Ntfy.Topic,
+			NtfyToken:     settings.Ntfy.Token,
+			PushoverToken: settings.Pushover.Token,
+			PushoverUser:  settings.Pushover.User,
+		},
+	}
+	if err := d.load(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Profile returns the profile the daemon serves.
+func (d *Daemon) Profile() string {
+	return d.profile
+}
+
+// Run polls and acts on session statuses until ctx is done. It claims the
+// profile's pid file for as long as it runs.
+func (d *Daemon) Run(ctx context.Context) error {
+	pidPath, err := PIDFile(d.Profile())
+	if err != nil {
+		return err
+	}
+	pidFile, err := lockPIDFile(pidPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Removed while still locked, so no other daemon claims it meanwhile
+		_ = os.Remove(pidPath)
+		_ = pidFile.Close()
+	}()
+
+	// Session code writes statuses through the global handle
+	if d.db != nil && statedb.GetGlobal() == nil {
+		statedb.SetGlobal(d.db)
+	}
+
+	defer d.serveIPC(ctx)()
+
+	daemonLog.Info("daemon_started", slog.String("profile", d.Profile()), slog.Int("pid", os.Getpid()))
+	d.watch(ctx, d.apply)
+	if d.saveNeeded.Swap(false) {
+		d.save()
+	}
+	daemonLog.Info("daemon_stopped", slog.String("profile", d.Profile()))
+	return nil
+}
+
+// Watch hands every status poll of profile's sessions to handle until ctx
+// is done. Unlike Run it only watches: it keeps polling while a TUI is
+// open, runs alongside a daemon, and acts on nothing.
+func Watch(ctx context.Context, profile string, handle func(session.StatusDiff)) error {
+	d, err := New(profile)
+	if err != nil {
+		return err
+	}
+	d.watchOnly = true
+	d.watch(ctx, handle)
+	return nil
+}
+
+// watch polls statuses and hands every poll to handle until ctx is done.
+func (d *Daemon) watch(ctx context.Context, handle func(session.StatusDiff)) {
+	var hookWatcher *session.StatusFileWatcher
+	if session.CheckClaudeHooksInstalled(session.GetClaudeConfigDir()) {
+		var err error
+		if hookWatcher, err = session.NewStatusFileWatcher(nil); err != nil {
+			daemonLog.Warn("hook_watcher_init_failed", slog.String("error", err.Error()))
+		} else {
+			go hookWatcher.Start()
+			defer hookWatcher.Stop()
+		}
+	}
+
+	monitor := session.NewMonitor(d.sessions, session.GetStatusSettings(), func(instances []*session.Instance) {
+		if hookWatcher == nil {
+			return
+		}
+		for _, inst := range instances {
+			if inst.Tool == "claude" || inst.Tool == "codex" {
+				if hs := hookWatcher.GetHookStatus(inst.ID); hs != nil {
+					inst.UpdateHookStatus(hs)
+				}
+			}
+		}
+	})
+	monitor.SetFocused(false) // Nobody is looking: poll active sessions at the base rate
+	go monitor.Start()
+	defer monitor.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case diff := <-monitor.Diffs():
+			handle(diff)
+		}
+	}
+}
+
+// lockPIDFile claims path for this process with an exclusive flock and
+// writes the pid into it, unless another daemon holds the lock. Two daemons
+// starting at once can't both win, and a stale file from a crashed daemon
+// is unlocked so it's simply taken over. The lock lasts until the returned
+// file is closed.
+func lockPIDFile(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return nil, fmt.Errorf("%w (pid %d)", ErrRunning, readPIDFile(path))
+		}
+		return nil, err
+	}
+	if err := f.Truncate(0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if _, err := f.WriteAt([]byte(strconv.Itoa(os.Getpid())+"\n"), 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// load reads the profile's sessions and groups from storage.
+func (d *Daemon) load() error {
+	instances, groups, err := d.storage.LoadWithGroups()
+	if err != nil {
+		return fmt.Errorf("failed to load sessions: %w", err)
+	}
+	d.instances = instances
+	d.tree = session.NewGroupTreeWithGroups(instances, groups)
+	if d.db != nil {
+		d.loadedAt, _ = d.db.LastModified()
+	}
+	return nil
+}
+
+// sessions is the monitor's source. Every refreshEvery it checks whether a
+// TUI is open, and reloads sessions that other processes changed. While a
+// TUI is open it returns nothing, so the daemon doesn't poll at all.
+func (d *Daemon) sessions() []*session.Instance {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if time.Since(d.checkedAt) >= refreshEvery {
+		d.checkedAt = time.Now()
+		d.refreshLocked()
+	}
+	if d.tuiOpen {
+		return nil
+	}
+	return d.instances
+}
+
+// refreshLocked updates what the daemon knows from the state database.
+// Caller holds mu.
+func (d *Daemon) refreshLocked() {
+	if d.db == nil {
+		return
+	}
+	wasOpen := d.tuiOpen
+	if !d.watchOnly {
+		count, err := d.db.AliveInstanceCount()
+		d.tuiOpen = err == nil && count > 0
+		if d.tuiOpen != wasOpen {
+			daemonLog.Info("tui_presence_changed", slog.Bool("tui_open", d.tuiOpen))
+		}
+		if d.tuiOpen {
+			return
+		}
+	}
+
+	d.dnd = readDoNotDisturb(d.db)
+	modified, err := d.db.LastModified()
+	if err != nil || (modified == d.loadedAt && !wasOpen) {
+		return
+	}
+	// Another process changed the sessions: its version wins
+	if err := d.load(); err != nil {
+		daemonLog.Warn("reload_failed", slog.String("error", err.Error()))
+		return
+	}
+	daemonLog.Info("sessions_reloaded", slog.Int("sessions", len(d.instances)))
+}
+
+// readDoNotDisturb returns the TUI's do-not-disturb setting.
+func readDoNotDisturb(db *statedb.StateDB) bool {
+	val, err := db.GetMeta("ui_state")
+	if err != nil || val == "" {
+		return false
+	}
+	var state struct {
+		DoNotDisturb bool `json:"do_not_disturb"`
+	}
+	_ = json.Unmarshal([]byte(val), &state)
+	return state.DoNotDisturb
+}
+
+// apply acts on a monitor poll like the TUI does: notifications and
+// webhooks for status changes, and after full polls queued prompts,
+// auto-restart and status writes for other processes.
+func (d *Daemon) apply(diff session.StatusDiff) {
+	defer func() {
+		if r := recover(); r != nil {
+			daemonLog.Error("apply_panic", slog.Any("panic", r))
+		}
+	}()
+
+	for _, c := range diff.Changes {
+		daemonLog.Debug("status_changed", slog.String("title", c.Instance.Title), slog.String("old", string(c.Old)), slog.String("new", string(c.New)))
+		if d.muted(c.Instance) {
+			continue
+		}
+		d.notify(c)
+		d.postWebhooks(c)
+	}
+	if !diff.Full {
+		return
+	}
+
+	if d.dispatchQueuedPrompts(diff.Instances) {
+		d.saveNeeded.Store(true)
+	}
+	d.autoRestartCrashed(diff.Instances)
+	if d.db != nil {
+		for _, inst := range diff.Instances {
+			_ = d.db.WriteStatus(inst.ID, string(inst.GetStatusThreadSafe()), inst.Tool)
+		}
+	}
+	if d.saveNeeded.Swap(false) {
+		d.save()
+	}
+}
+
+// muted reports whether inst's notifications are off.
+func (d *Daemon) muted(inst *session.Instance) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.dnd || inst.NotifyMuted || session.IsGroupPathMuted(d.tree.MutedGroupPaths(), inst.GroupPath)
+}
+
+// notify sends the desktop and push notifications the TUI would send for a
+// finished turn or a new approval prompt.
+func (d *Daemon) notify(c session.StatusChange) {
+	if !d.notifications.Desktop && !d.pushTargets.Enabled() {
+		return
+	}
+	if !session.ShouldNotify(c.Old, c.New) {
+		return
+	}
+	if c.New == session.StatusWaiting && c.Instance.QueuedPromptCount() > 0 {
+		return // The queue carries on by itself
+	}
+
+	title := fmt.Sprintf("Agent Deck: %s (waiting)", c.Instance.Title)
+	body := "Finished and waiting for input"
+	if c.New == session.StatusNeedsInput {
+		title = fmt.Sprintf("Agent Deck: %s (needs approval)", c.Instance.Title)
+		body = "Blocked on an approval prompt"
+	}
+	if d.pushTargets.Enabled() {
+		go func() {
+			if err := notify.Push(d.pushTargets, title, body, c.New == session.StatusNeedsInput); err != nil {
+				daemonLog.Warn("push_notify_failed", slog.String("title", c.Instance.Title), slog.String("error", err.Error()))
+			}
+		}()
+	}
+	if d.notifications.Desktop {
+		go func() {
+			if _, err := notify.Send(title, body); err != nil {
+				daemonLog.Warn("desktop_notify_failed", slog.String("title", c.Instance.Title), slog.String("error", err.Error()))
+			}
+		}()
+	}
+}
+
+// postWebhooks POSTs a status change to the configured webhooks.
+func (d *Daemon) postWebhooks(c session.StatusChange) {
+	if len(d.notifications.Webhooks) == 0 {
+		return
+	}
+	if statuses := d.notifications.WebhookStatuses; len(statuses) > 0 && !containsStatus(statuses, c.New) {
+		return
+	}
+	inst := c.Instance
+	event := notify.NewWebhookEvent(inst.ID, inst.Title, inst.Tool, inst.GroupPath, inst.ProjectPath,
+		string(c.Old), string(c.New), time.Now())
+	for _, url := range d.notifications.Webhooks {
+		go func(url string) {
+			if err := notify.PostWebhook(url, event); err != nil {
+				daemonLog.Warn("webhook_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+			}
+		}(url)
+	}
+}
+
+func containsStatus(statuses []string, status session.Status) bool {
+	for _, s := range statuses {
+		if session.Status(s) == status {
+			return true
+		}
+	}
+	return false
+}
+
+// dispatchQueuedPrompts sends the next queued prompt to every session that
+// is ready for one, and reports whether any was sent.
+func (d *Daemon) dispatchQueuedPrompts(instances []*session.Instance) bool {
+	sent := false
+	for _, inst := range instances {
+		prompt, ok := inst.TakeQueuedPrompt()
+		if !ok {
+			continue
+		}
+		tmuxSession := inst.GetTmuxSession()
+		if tmuxSession == nil {
+			inst.RequeuePrompt(prompt)
+			continue
+		}
+		if err := tmuxSession.SendKeysAndEnter(prompt); err != nil {
+			daemonLog.Warn("queued_prompt_send_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+			inst.RequeuePrompt(prompt)
+			continue
+		}
+		daemonLog.Info("queued_prompt_sent", slog.String("title", inst.Title), slog.Int("remaining", inst.QueuedPromptCount()))
+		sent = true
+	}
+	return sent
+}
+
+// autoRestartCrashed restarts every session that crashed and is due for an
+// automatic restart. Restarts run in their own goroutines; the next full
+// poll saves their results.
+func (d *Daemon) autoRestartCrashed(instances []*session.Instance) {
+	for _, inst := range instances {
+		attempt, ok := inst.TakeAutoRestart()
+		if !ok {
+			continue
+		}
+		daemonLog.Info("auto_restart", slog.String("title", inst.Title), slog.Int("attempt", attempt), slog.Int("max", inst.AutoRestartMax))
+		go func(inst *session.Instance) {
+			err := inst.Restart()
+			inst.FinishAutoRestart(err)
+			if err != nil {
+				daemonLog.Warn("auto_restart_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+				return
+			}
+			inst.CaptureLoadedMCPs()
+			d.saveNeeded.Store(true)
+		}(inst)
+	}
+}
+
+// save writes the sessions back to storage.
+func (d *Daemon) save() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.saveLocked()
+}
+
+// saveLocked writes the sessions back to storage. Caller holds mu.
+func (d *Daemon) saveLocked() {
+	if err := d.storage.SaveWithGroups(d.instances, d.tree); err != nil {
+		daemonLog.Warn("save_failed", slog.String("error", err.Error()))
+		return
+	}
+	if d.db != nil {
+		d.loadedAt, _ = d.db.LastModified()
+	}
+}
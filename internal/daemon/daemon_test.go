@@ -0,0 +1,85 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/notify"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestLockPIDFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), PIDFileName)
+
+	// A stale pid file is taken over
+	if err := os.WriteFile(path, []byte("999999999999\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	f, err := lockPIDFile(path)
+	if err != nil {
+		t.Fatalf("lockPIDFile over a stale file: %v", err)
+	}
+	if pid := readPIDFile(path); pid != os.Getpid() {
+		t.Errorf("readPIDFile = %d, want %d", pid, os.Getpid())
+	}
+
+	// A locked one is not
+	if _, err := lockPIDFile(path); !errors.Is(err, ErrRunning) {
+		t.Errorf("lockPIDFile while locked = %v, want ErrRunning", err)
+	}
+
+	// Closing releases it
+	f.Close()
+	f, err = lockPIDFile(path)
+	if err != nil {
+		t.Fatalf("lockPIDFile after release: %v", err)
+	}
+	f.Close()
+}
+
+func TestApplyPostsWebhooks(t *testing.T) {
+	events := make(chan notify.WebhookEvent, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event notify.WebhookEvent
+		_ = json.NewDecoder(r.Body).Decode(&event)
+		events <- event
+	}))
+	defer srv.Close()
+
+	loud := &session.Instance{ID: "a", Title: "api", GroupPath: "work"}
+	quiet := &session.Instance{ID: "b", Title: "docs", GroupPath: "work", NotifyMuted: true}
+	d := &Daemon{
+		notifications: session.NotificationsConfig{
+			Webhooks:        []string{srv.URL},
+			WebhookStatuses: []string{string(session.StatusWaiting)},
+		},
+		instances: []*session.Instance{loud, quiet},
+		tree:      session.NewGroupTreeWithGroups([]*session.Instance{loud, quiet}, nil),
+	}
+
+	d.apply(session.StatusDiff{Changes: []session.StatusChange{
+		{Instance: loud, Old: session.StatusWaiting, New: session.StatusRunning},  // Filtered by status
+		{Instance: quiet, Old: session.StatusRunning, New: session.StatusWaiting}, // Muted
+		{Instance: loud, Old: session.StatusRunning, New: session.StatusWaiting},
+	}})
+
+	select {
+	case event := <-events:
+		if event.SessionID != "a" || event.NewStatus != string(session.StatusWaiting) {
+			t.Errorf("got event %+v, want api going to waiting", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no webhook posted")
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected second webhook: %+v", event)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,278 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/ipc"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// serveIPC answers requests on the profile's socket until ctx is done, and
+// returns a function that waits for that and removes the socket. The daemon
+// carries on without the socket if it can't be created.
+func (d *Daemon) serveIPC(ctx context.Context) (wait func()) {
+	path, err := ipc.SocketPath(d.Profile())
+	if err != nil {
+		daemonLog.Warn("ipc_socket_failed", slog.String("error", err.Error()))
+		return func() {}
+	}
+	listener, err := ipc.Listen(path)
+	if err != nil {
+		daemonLog.Warn("ipc_socket_failed", slog.String("path", path), slog.String("error", err.Error()))
+		return func() {}
+	}
+	daemonLog.Info("ipc_listening", slog.String("path", path))
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := ipc.Serve(ctx, listener, d.handleRPC); err != nil {
+			daemonLog.Warn("ipc_serve_failed", slog.String("error", err.Error()))
+		}
+	}()
+	return func() {
+		<-done
+		os.Remove(path)
+	}
+}
+
+// handleRPC answers one request on the socket.
+func (d *Daemon) handleRPC(method string, params json.RawMessage) (result any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			daemonLog.Error("rpc_panic", slog.String("method", method), slog.Any("panic", r))
+			result, err = nil, ipc.Errorf(ipc.CodeInternalError, "internal error")
+		}
+	}()
+
+	switch method {
+	case ipc.MethodList:
+		var p ipc.ListParams
+		if err := ipc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return d.list(p), nil
+	case ipc.MethodStatus:
+		var p ipc.StatusParams
+		if err := ipc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return d.status(p)
+	case ipc.MethodCreate:
+		var p ipc.CreateParams
+		if err := ipc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return d.create(p)
+	case ipc.MethodKill:
+		var p ipc.SessionParams
+		if err := ipc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return d.kill(p)
+	case ipc.MethodSend:
+		var p ipc.SendParams
+		if err := ipc.DecodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return d.send(p)
+	default:
+		return nil, ipc.Errorf(ipc.CodeMethodNotFound, "unknown method %q", method)
+	}
+}
+
+// syncLocked reloads sessions that other processes changed, and returns
+// the statuses to report. While the daemon polls those are its own; while a
+// TUI is open they are the ones the TUI last wrote to the state database.
+// Caller holds mu.
+func (d *Daemon) syncLocked() func(*session.Instance) session.Status {
+	own := func(inst *session.Instance) session.Status { return inst.GetStatusThreadSafe() }
+	if d.db == nil {
+		return own
+	}
+	if modified, err := d.db.LastModified(); err == nil && modified != d.loadedAt {
+		if err := d.load(); err != nil {
+			daemonLog.Warn("reload_failed", slog.String("error", err.Error()))
+		}
+	}
+	if !d.tuiOpen {
+		return own
+	}
+	stored, err := d.db.ReadAllStatuses()
+	if err != nil {
+		return own
+	}
+	return func(inst *session.Instance) session.Status {
+		if row, ok := stored[inst.ID]; ok && row.Status != "" {
+			return session.Status(row.Status)
+		}
+		return own(inst)
+	}
+}
+
+// resolveLocked finds the session ref names, exactly like the CLI does
+// (see session.ResolveInstance). Caller holds mu.
+func (d *Daemon) resolveLocked(ref string) (*session.Instance, error) {
+	inst, err := session.ResolveInstance(ref, d.instances)
+	switch {
+	case err == nil:
+		return inst, nil
+	case errors.Is(err, session.ErrSessionAmbiguous):
+		return nil, ipc.Errorf(ipc.CodeAmbiguous, "%s", err)
+	default:
+		return nil, ipc.Errorf(ipc.CodeNotFound, "%s", err)
+	}
+}
+
+// list answers MethodList.
+func (d *Daemon) list(p ipc.ListParams) ipc.ListResult {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	statusOf := d.syncLocked()
+
+	result := ipc.ListResult{Profile: d.Profile(), Sessions: []ipc.SessionInfo{}}
+	for _, inst := range d.instances {
+		if p.Group != "" && inst.GroupPath != p.Group && !strings.HasPrefix(inst.GroupPath, p.Group+"/") {
+			continue
+		}
+		if p.Tag != "" && !inst.HasTag(p.Tag) {
+			continue
+		}
+		result.Sessions = append(result.Sessions, ipc.NewSessionInfo(inst, statusOf(inst)))
+	}
+	return result
+}
+
+// status answers MethodStatus.
+func (d *Daemon) status(p ipc.StatusParams) (ipc.StatusResult, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	statusOf := d.syncLocked()
+
+	result := ipc.StatusResult{Profile: d.Profile()}
+	if p.Session != "" {
+		inst, err := d.resolveLocked(p.Session)
+		if err != nil {
+			return result, err
+		}
+		info := ipc.NewSessionInfo(inst, statusOf(inst))
+		result.Session = &info
+		result.Counts.Add(statusOf(inst))
+		return result, nil
+	}
+	for _, inst := range d.instances {
+		result.Counts.Add(statusOf(inst))
+	}
+	return result, nil
+}
+
+// create answers MethodCreate.
+func (d *Daemon) create(p ipc.CreateParams) (ipc.SessionInfo, error) {
+	if !filepath.IsAbs(p.Path) {
+		return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidParams, "path must be absolute: %q", p.Path)
+	}
+	if info, err := os.Stat(p.Path); err != nil || !info.IsDir() {
+		return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidParams, "path is not a directory: %s", p.Path)
+	}
+	title := p.Title
+	if title == "" {
+		title = filepath.Base(p.Path)
+	}
+	tool := p.Tool
+	if tool == "" {
+		tool = "shell"
+	}
+
+	var inst *session.Instance
+	if p.Group != "" {
+		inst = session.NewInstanceWithGroupAndTool(title, p.Path, p.Group, tool)
+	} else {
+		inst = session.NewInstanceWithTool(title, p.Path, tool)
+	}
+	switch {
+	case p.Command != "":
+		inst.Command = p.Command
+	case session.GetToolDef(tool) != nil:
+		inst.Command = session.GetToolDef(tool).Command
+	case tool != "shell":
+		inst.Command = tool
+	}
+
+	// Start before taking the lock: it can take a few seconds
+	if p.Start {
+		if err := inst.Start(); err != nil {
+			return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidOperation, "failed to start session: %v", err)
+		}
+		inst.PostStartSync(3 * time.Second)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.syncLocked()
+	d.instances = append(d.instances, inst)
+	d.tree.AddSession(inst)
+	d.saveLocked()
+	daemonLog.Info("rpc_created", slog.String("title", inst.Title), slog.String("id", inst.ID))
+	return ipc.NewSessionInfo(inst, inst.GetStatusThreadSafe()), nil
+}
+
+// kill answers MethodKill: it stops the session's process, keeping the
+// session, like `agent-deck session stop`.
+func (d *Daemon) kill(p ipc.SessionParams) (ipc.SessionInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.syncLocked()
+
+	inst, err := d.resolveLocked(p.Session)
+	if err != nil {
+		return ipc.SessionInfo{}, err
+	}
+	if !inst.Exists() {
+		return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidOperation, "session %q is not running", inst.Title)
+	}
+	if err := inst.Kill(); err != nil {
+		return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidOperation, "failed to stop session: %v", err)
+	}
+	d.saveLocked()
+	daemonLog.Info("rpc_killed", slog.String("title", inst.Title))
+	return ipc.NewSessionInfo(inst, inst.GetStatusThreadSafe()), nil
+}
+
+// send answers MethodSend.
+func (d *Daemon) send(p ipc.SendParams) (ipc.SessionInfo, error) {
+	if p.Message == "" {
+		return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidParams, "message is required")
+	}
+	d.mu.Lock()
+	statusOf := d.syncLocked()
+	inst, err := d.resolveLocked(p.Session)
+	var info ipc.SessionInfo
+	if err == nil {
+		info = ipc.NewSessionInfo(inst, statusOf(inst))
+	}
+	d.mu.Unlock()
+	if err != nil {
+		return ipc.SessionInfo{}, err
+	}
+
+	tmuxSession := inst.GetTmuxSession()
+	if tmuxSession == nil || !inst.Exists() {
+		return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidOperation, "session %q is not running", inst.Title)
+	}
+	if p.NoEnter {
+		err = tmuxSession.SendKeysChunked(p.Message)
+	} else {
+		err = tmuxSession.SendKeysAndEnter(p.Message)
+	}
+	if err != nil {
+		return ipc.SessionInfo{}, ipc.Errorf(ipc.CodeInvalidOperation, "failed to send message: %v", err)
+	}
+	return info, nil
+}
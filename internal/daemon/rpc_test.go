@@ -0,0 +1,137 @@
+package daemon
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/ipc"
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func rpcTestDaemon() *Daemon {
+	api := &session.Instance{ID: "abc123-1", Title: "api", GroupPath: "work", ProjectPath: "/src/api", Status: session.StatusRunning, Tags: []string{"bug"}}
+	web := &session.Instance{ID: "abc123-2", Title: "web", GroupPath: "work/frontend", ProjectPath: "/src/web", Status: session.StatusWaiting}
+	notes := &session.Instance{ID: "def456-1", Title: "notes", GroupPath: "personal", ProjectPath: "/src/web", Status: session.StatusIdle}
+	instances := []*session.Instance{api, web, notes}
+	return &Daemon{
+		profile:   "test",
+		instances: instances,
+		tree:      session.NewGroupTreeWithGroups(instances, nil),
+	}
+}
+
+func TestHandleRPC_List(t *testing.T) {
+	d := rpcTestDaemon()
+	tests := []struct {
+		params string
+		want   []string
+	}{
+		{``, []string{"api", "web", "notes"}},
+		{`{"group":"work"}`, []string{"api", "web"}},
+		{`{"group":"work/frontend"}`, []string{"web"}},
+		{`{"tag":"#bug"}`, []string{"api"}},
+	}
+	for _, tt := range tests {
+		result, err := d.handleRPC(ipc.MethodList, json.RawMessage(tt.params))
+		if err != nil {
+			t.Fatalf("list %s: %v", tt.params, err)
+		}
+		list := result.(ipc.ListResult)
+		var titles []string
+		for _, s := range list.Sessions {
+			titles = append(titles, s.Title)
+		}
+		if len(titles) != len(tt.want) {
+			t.Errorf("list %s = %v, want %v", tt.params, titles, tt.want)
+			continue
+		}
+		for i := range titles {
+			if titles[i] != tt.want[i] {
+				t.Errorf("list %s = %v, want %v", tt.params, titles, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestHandleRPC_Status(t *testing.T) {
+	d := rpcTestDaemon()
+
+	result, err := d.handleRPC(ipc.MethodStatus, nil)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	want := ipc.StatusCounts{Running: 1, Waiting: 1, Idle: 1, Total: 3}
+	if got := result.(ipc.StatusResult); got.Counts != want || got.Profile != "test" {
+		t.Errorf("status = %+v, want counts %+v", got, want)
+	}
+
+	result, err = d.handleRPC(ipc.MethodStatus, json.RawMessage(`{"session":"def456"}`))
+	if err != nil {
+		t.Fatalf("status of one session: %v", err)
+	}
+	if s := result.(ipc.StatusResult).Session; s == nil || s.Title != "notes" || s.Status != "idle" {
+		t.Errorf("status by ID prefix = %+v, want notes idle", s)
+	}
+
+	for params, code := range map[string]int{
+		`{"session":"abc123"}`: ipc.CodeAmbiguous,
+		`{"session":"nope"}`:   ipc.CodeNotFound,
+		`{"session":1}`:        ipc.CodeInvalidParams,
+	} {
+		_, err := d.handleRPC(ipc.MethodStatus, json.RawMessage(params))
+		var rpcErr *ipc.Error
+		if !errors.As(err, &rpcErr) || rpcErr.Code != code {
+			t.Errorf("status %s: got %v, want code %d", params, err, code)
+		}
+	}
+}
+
+func TestHandleRPC_Invalid(t *testing.T) {
+	d := rpcTestDaemon()
+	checks := []struct {
+		method, params string
+		code           int
+	}{
+		{"reboot", ``, ipc.CodeMethodNotFound},
+		{ipc.MethodCreate, `{"path":"relative/dir"}`, ipc.CodeInvalidParams},
+		{ipc.MethodCreate, `{"path":"/nonexistent/agent-deck"}`, ipc.CodeInvalidParams},
+		{ipc.MethodSend, `{"session":"api"}`, ipc.CodeInvalidParams},
+		{ipc.MethodKill, `{}`, ipc.CodeNotFound},
+	}
+	for _, c := range checks {
+		_, err := d.handleRPC(c.method, json.RawMessage(c.params))
+		var rpcErr *ipc.Error
+		if !errors.As(err, &rpcErr) || rpcErr.Code != c.code {
+			t.Errorf("%s %s: got %v, want code %d", c.method, c.params, err, c.code)
+		}
+	}
+}
+
+func TestResolveMatchesCLI(t *testing.T) {
+	d := rpcTestDaemon()
+	for _, ref := range []string{"api", "def456", "abc123", "/src/api", "/src/web", "nope", ""} {
+		want, wantErr := session.ResolveInstance(ref, d.instances)
+		got, err := d.resolveLocked(ref)
+		if got != want {
+			t.Errorf("resolve %q = %v, want %v", ref, got, want)
+		}
+		var rpcErr *ipc.Error
+		switch {
+		case wantErr == nil:
+			if err != nil {
+				t.Errorf("resolve %q: unexpected error %v", ref, err)
+			}
+		case !errors.As(err, &rpcErr):
+			t.Errorf("resolve %q: got %v, want an RPC error", ref, err)
+		case errors.Is(wantErr, session.ErrSessionAmbiguous) != (rpcErr.Code == ipc.CodeAmbiguous):
+			t.Errorf("resolve %q: code %d for %v", ref, rpcErr.Code, wantErr)
+		case rpcErr.Code != ipc.CodeAmbiguous && rpcErr.Code != ipc.CodeNotFound:
+			t.Errorf("resolve %q: code %d, want not found", ref, rpcErr.Code)
+		}
+	}
+	if got, _ := d.resolveLocked("/src/api"); got == nil || got.Title != "api" {
+		t.Errorf("a project path should resolve to its session, got %v", got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -139,6 +140,13 @@ func GenerateWorktreePath(repoDir, branchName, location string) string {
 // CreateWorktree creates a new git worktree at worktreePath for the given branch
 // If the branch doesn't exist, it will be created
 func CreateWorktree(repoDir, worktreePath, branchName string) error {
+	return CreateWorktreeFrom(repoDir, worktreePath, branchName, "")
+}
+
+// CreateWorktreeFrom is CreateWorktree with the starting point for a new
+// branch: baseRef (a branch, tag or commit) instead of HEAD. baseRef is
+// ignored when the branch already exists.
+func CreateWorktreeFrom(repoDir, worktreePath, branchName, baseRef string) error {
 	// Validate branch name first
 	if err := ValidateBranchName(branchName); err != nil {
 		return fmt.Errorf("invalid branch name: %w", err)
@@ -154,6 +162,12 @@ func CreateWorktree(repoDir, worktreePath, branchName string) error {
 	if BranchExists(repoDir, branchName) {
 		// Use existing branch
 		cmd = exec.Command("git", "-C", repoDir, "worktree", "add", worktreePath, branchName)
+	} else if baseRef != "" {
+		// Create new branch from baseRef
+		if err := exec.Command("git", "-C", repoDir, "rev-parse", "--verify", "--quiet", baseRef+"^{commit}").Run(); err != nil {
+			return fmt.Errorf("unknown base branch or commit %q", baseRef)
+		}
+		cmd = exec.Command("git", "-C", repoDir, "worktree", "add", "-b", branchName, worktreePath, baseRef)
 	} else {
 		// Create new branch with -b flag
 		cmd = exec.Command("git", "-C", repoDir, "worktree", "add", "-b", branchName, worktreePath)
@@ -369,6 +383,122 @@ func HasUncommittedChanges(dir string) (bool, error) {
 	return strings.TrimSpace(string(output)) != "", nil
 }
 
+// Status summarizes a working tree: its branch, distance from the upstream
+// and uncommitted changes.
+type Status struct {
+	Branch      string // Empty on a detached HEAD
+	HasUpstream bool
+	Ahead       int // Local commits not on the upstream
+	Behind      int // Upstream commits not merged locally
+	Changed     int // Modified, staged, conflicted and untracked paths
+}
+
+// Dirty reports whether the working tree has uncommitted changes.
+func (s Status) Dirty() bool {
+	return s.Changed > 0
+}
+
+// GetStatus reads the branch, ahead/behind counts and uncommitted changes of
+// dir with a single git status call. It runs with --no-optional-locks so
+// polling doesn't take index.lock from under the user's own git commands.
+func GetStatus(dir string) (Status, error) {
+	cmd := exec.Command("git", "--no-optional-locks", "-C", dir, "status", "--porcelain=v2", "--branch")
+	output, err := cmd.Output()
+	if err != nil {
+		return Status{}, fmt.Errorf("failed to check git status: %w", err)
+	}
+	return parseStatusV2(string(output)), nil
+}
+
+// parseStatusV2 parses `git status --porcelain=v2 --branch` output.
+func parseStatusV2(output string) Status {
+	var st Status
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				st.Branch = head
+			}
+		case strings.HasPrefix(line, "# branch.upstream "):
+			st.HasUpstream = true
+		case strings.HasPrefix(line, "# branch.ab "):
+			_, _ = fmt.Sscanf(strings.TrimPrefix(line, "# branch.ab "), "+%d -%d", &st.Ahead, &st.Behind)
+		case strings.HasPrefix(line, "#"), line == "":
+		default:
+			st.Changed++
+		}
+	}
+	return st
+}
+
+// FileChange is one file in a diff summary.
+type FileChange struct {
+	Path    string
+	Added   int
+	Deleted int
+	Binary  bool // Line counts are unknown
+}
+
+// DiffStat summarizes staged and unstaged changes to tracked files in dir
+// against HEAD, one entry per file (`git diff HEAD --numstat`).
+func DiffStat(dir string) ([]FileChange, error) {
+	cmd := exec.Command("git", "-C", dir, "diff", "HEAD", "--no-ext-diff", "--numstat")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get diff: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return parseNumstat(string(output)), nil
+}
+
+// parseNumstat parses `git diff --numstat` output: added, deleted and path
+// separated by tabs, with "-" counts for binary files.
+func parseNumstat(output string) []FileChange {
+	var changes []FileChange
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		change := FileChange{Path: fields[2]}
+		if fields[0] == "-" && fields[1] == "-" {
+			change.Binary = true
+		} else {
+			change.Added, _ = strconv.Atoi(fields[0])
+			change.Deleted, _ = strconv.Atoi(fields[1])
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// Diff returns the full patch of staged and unstaged changes in dir against
+// HEAD.
+func Diff(dir string) (string, error) {
+	cmd := exec.Command("git", "-C", dir, "diff", "HEAD", "--no-ext-diff")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("failed to get diff: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return string(output), nil
+}
+
+// UntrackedFiles lists the files in dir that git does not track and does not
+// ignore, relative to the repository root.
+func UntrackedFiles(dir string) ([]string, error) {
+	cmd := exec.Command("git", "-C", dir, "ls-files", "--others", "--exclude-standard", "--full-name")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
 // GetDefaultBranch returns the default branch name (e.g. "main" or "master") for the repo
 func GetDefaultBranch(repoDir string) (string, error) {
 	// Try symbolic-ref first (works when remote HEAD is set)
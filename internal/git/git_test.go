@@ -514,6 +514,47 @@ func TestCreateWorktree(t *testing.T) {
 	})
 }
 
+func TestCreateWorktreeFrom(t *testing.T) {
+	revParse := func(t *testing.T, dir, ref string) string {
+		t.Helper()
+		out, err := exec.Command("git", "-C", dir, "rev-parse", ref).Output()
+		if err != nil {
+			t.Fatalf("rev-parse %s: %v", ref, err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+	createBranch(t, dir, "release")
+	// Move HEAD past release so the two starting points differ
+	cmd := exec.Command("git", "commit", "--allow-empty", "-m", "after release")
+	cmd.Dir = dir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	t.Run("new branch starts at base", func(t *testing.T) {
+		worktreePath := filepath.Join(t.TempDir(), "worktree")
+		if err := CreateWorktreeFrom(dir, worktreePath, "hotfix", "release"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got, want := revParse(t, worktreePath, "HEAD"), revParse(t, dir, "release"); got != want {
+			t.Errorf("worktree HEAD = %s, want release commit %s", got, want)
+		}
+		if branch, _ := GetCurrentBranch(worktreePath); branch != "hotfix" {
+			t.Errorf("expected branch hotfix, got %s", branch)
+		}
+	})
+
+	t.Run("unknown base", func(t *testing.T) {
+		worktreePath := filepath.Join(t.TempDir(), "worktree")
+		if err := CreateWorktreeFrom(dir, worktreePath, "other", "no-such-branch"); err == nil {
+			t.Error("expected error for unknown base")
+		}
+	})
+}
+
 func TestListWorktrees(t *testing.T) {
 	t.Run("lists worktrees in repo", func(t *testing.T) {
 		dir := t.TempDir()
@@ -777,6 +818,123 @@ func TestHasUncommittedChanges(t *testing.T) {
 	})
 }
 
+func TestGetStatus(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+	createBranch(t, dir, "feature")
+	cmd := exec.Command("git", "checkout", "-q", "feature")
+	cmd.Dir = dir
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("failed to checkout: %v", err)
+	}
+
+	st, err := GetStatus(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.Branch != "feature" || st.Dirty() || st.HasUpstream {
+		t.Errorf("clean repo status = %+v, want branch feature, clean, no upstream", st)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("modified"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "newfile.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	st, err = GetStatus(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if st.Changed != 2 || !st.Dirty() {
+		t.Errorf("Changed = %d, want 2 (one modified, one untracked)", st.Changed)
+	}
+
+	if _, err := GetStatus(t.TempDir()); err == nil {
+		t.Error("expected error for non-git directory")
+	}
+}
+
+func TestParseStatusV2(t *testing.T) {
+	output := `# branch.oid 1234abcd
+# branch.head main
+# branch.upstream origin/main
+# branch.ab +2 -5
+1 .M N... 100644 100644 100644 aaaa bbbb file.go
+? notes.txt
+`
+	st := parseStatusV2(output)
+	want := Status{Branch: "main", HasUpstream: true, Ahead: 2, Behind: 5, Changed: 2}
+	if st != want {
+		t.Errorf("parseStatusV2 = %+v, want %+v", st, want)
+	}
+
+	detached := parseStatusV2("# branch.oid 1234abcd\n# branch.head (detached)\n")
+	if detached.Branch != "" || detached.Dirty() {
+		t.Errorf("detached HEAD status = %+v, want empty branch and clean", detached)
+	}
+}
+
+func TestDiffHelpers(t *testing.T) {
+	dir := t.TempDir()
+	createTestRepo(t, dir)
+
+	stat, err := DiffStat(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stat) != 0 {
+		t.Errorf("clean repo stat = %+v, want empty", stat)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stat, err = DiffStat(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stat) != 1 || stat[0].Path != "README.md" || stat[0].Added != 1 || stat[0].Deleted != 1 {
+		t.Errorf("DiffStat = %+v, want README.md +1 -1", stat)
+	}
+
+	diff, err := Diff(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(diff, "+changed") {
+		t.Errorf("diff should contain the added line: %q", diff)
+	}
+
+	untracked, err := UntrackedFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(untracked) != 1 || untracked[0] != "new.txt" {
+		t.Errorf("UntrackedFiles = %v, want [new.txt]", untracked)
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	got := parseNumstat("12\t3\tinternal/ui/home.go\n-\t-\tlogo.png\n\n")
+	want := []FileChange{
+		{Path: "internal/ui/home.go", Added: 12, Deleted: 3},
+		{Path: "logo.png", Binary: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseNumstat = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
 func TestGetDefaultBranch(t *testing.T) {
 	t.Run("detects main branch", func(t *testing.T) {
 		dir := t.TempDir()
@@ -0,0 +1,88 @@
+package ipc
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+)
+
+// callTimeout bounds one call, including starting a session for create.
+const callTimeout = 30 * time.Second
+
+// Client calls a daemon over its socket. It is not safe for concurrent use.
+type Client struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	nextID  int
+}
+
+// Dial connects to profile's daemon. It fails quickly when no daemon is
+// running, so callers can fall back to reading storage themselves.
+func Dial(profile string) (*Client, error) {
+	path, err := SocketPath(profile)
+	if err != nil {
+		return nil, err
+	}
+	return DialPath(path)
+}
+
+// DialPath connects to the daemon socket at path.
+func DialPath(path string) (*Client, error) {
+	conn, err := net.DialTimeout("unix", path, 500*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	return &Client{conn: conn, scanner: scanner}, nil
+}
+
+// Close closes the connection.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Call calls method with params and decodes its result into result, which
+// may be nil. Errors returned by the daemon are *Error.
+func (c *Client) Call(method string, params, result any) error {
+	c.nextID++
+	req := Request{JSONRPC: "2.0", ID: json.RawMessage(strconv.Itoa(c.nextID)), Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.Params = data
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	if err := c.conn.SetDeadline(time.Now().Add(callTimeout)); err != nil {
+		return err
+	}
+	if _, err := c.conn.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("daemon closed the connection")
+	}
+	var resp Response
+	if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(resp.Result, result)
+}
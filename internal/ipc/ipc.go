@@ -0,0 +1,193 @@
+// Package ipc is the protocol agent-deck's daemon serves on a unix socket in
+// the profile directory, so the CLI and third-party integrations (editor
+// plugins, status bars) can ask it about sessions instead of each loading
+// the session database and polling tmux themselves.
+//
+// The protocol is JSON-RPC 2.0, one message per line, like the MCP socket
+// proxies:
+//
+//	→ {"jsonrpc":"2.0","id":1,"method":"status","params":{}}
+//	← {"jsonrpc":"2.0","id":1,"result":{"profile":"default","counts":{...}}}
+//
+// A connection may carry any number of requests; responses come back in
+// order.
+package ipc
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// SocketFileName is the daemon's socket in the profile directory.
+const SocketFileName = "daemon.sock"
+
+// Methods served on the socket.
+const (
+	MethodList   = "list"   // ListParams → ListResult
+	MethodStatus = "status" // StatusParams → StatusResult
+	MethodCreate = "create" // CreateParams → SessionInfo
+	MethodKill   = "kill"   // SessionParams → SessionInfo
+	MethodSend   = "send"   // SendParams → SessionInfo
+)
+
+// Error codes. The negative ones are JSON-RPC's own.
+const (
+	CodeParseError       = -32700
+	CodeInvalidRequest   = -32600
+	CodeMethodNotFound   = -32601
+	CodeInvalidParams    = -32602
+	CodeInternalError    = -32603
+	CodeNotFound         = 1 // No such session
+	CodeAmbiguous        = 2 // The session reference matches several sessions
+	CodeInvalidOperation = 3 // E.g. sending to a session that isn't running
+)
+
+// SocketPath returns the path of profile's daemon socket.
+func SocketPath(profile string) (string, error) {
+	dir, err := session.GetProfileDir(session.GetEffectiveProfile(profile))
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SocketFileName), nil
+}
+
+// Request is a JSON-RPC request.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is a JSON-RPC response: a Result or an Error.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC error.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Errorf returns an *Error with code and a formatted message.
+func Errorf(code int, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+// SessionInfo describes a session.
+type SessionInfo struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Path      string    `json:"path"`
+	Group     string    `json:"group"`
+	Tool      string    `json:"tool"`
+	Command   string    `json:"command,omitempty"`
+	Status    string    `json:"status"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewSessionInfo describes inst with the given status.
+func NewSessionInfo(inst *session.Instance, status session.Status) SessionInfo {
+	return SessionInfo{
+		ID:        inst.ID,
+		Title:     inst.Title,
+		Path:      inst.ProjectPath,
+		Group:     inst.GroupPath,
+		Tool:      inst.Tool,
+		Command:   inst.Command,
+		Status:    string(status),
+		Tags:      inst.Tags,
+		CreatedAt: inst.CreatedAt,
+	}
+}
+
+// ListParams filters the sessions listed. Group includes its subgroups.
+type ListParams struct {
+	Group string `json:"group,omitempty"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+// ListResult lists the profile's sessions.
+type ListResult struct {
+	Profile  string        `json:"profile"`
+	Sessions []SessionInfo `json:"sessions"`
+}
+
+// StatusParams asks for one session's status, by ID, ID prefix or title, or
+// for the whole deck's when Session is empty.
+type StatusParams struct {
+	Session string `json:"session,omitempty"`
+}
+
+// StatusResult holds the deck's status counts, or Session's status.
+type StatusResult struct {
+	Profile string       `json:"profile"`
+	Counts  StatusCounts `json:"counts"`
+	Session *SessionInfo `json:"session,omitempty"`
+}
+
+// StatusCounts counts sessions by status.
+type StatusCounts struct {
+	Waiting    int `json:"waiting"`
+	NeedsInput int `json:"needs_input"`
+	Running    int `json:"running"`
+	Idle       int `json:"idle"`
+	Error      int `json:"error"`
+	Total      int `json:"total"`
+}
+
+// Add counts a session with status.
+func (c *StatusCounts) Add(status session.Status) {
+	switch status {
+	case session.StatusRunning:
+		c.Running++
+	case session.StatusNeedsInput:
+		c.NeedsInput++
+	case session.StatusWaiting:
+		c.Waiting++
+	case session.StatusIdle:
+		c.Idle++
+	case session.StatusError:
+		c.Error++
+	}
+	c.Total++
+}
+
+// CreateParams creates a session in Path, which must be an existing
+// directory. Title defaults to the directory's name and Tool to "shell";
+// Command defaults to the tool's command.
+type CreateParams struct {
+	Title   string `json:"title,omitempty"`
+	Path    string `json:"path"`
+	Group   string `json:"group,omitempty"`
+	Tool    string `json:"tool,omitempty"`
+	Command string `json:"command,omitempty"`
+	Start   bool   `json:"start,omitempty"`
+}
+
+// SessionParams names a session by ID, ID prefix or title.
+type SessionParams struct {
+	Session string `json:"session"`
+}
+
+// SendParams types Message into a running session and presses Enter,
+// unless NoEnter is set. Unlike `agent-deck send`, it doesn't wait for the
+// agent to be ready first.
+type SendParams struct {
+	Session string `json:"session"`
+	Message string `json:"message"`
+	NoEnter bool   `json:"no_enter,omitempty"`
+}
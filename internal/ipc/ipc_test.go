@@ -0,0 +1,131 @@
+package ipc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func serveTest(t *testing.T, handler Handler) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), SocketFileName)
+	listener, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = Serve(ctx, listener, handler)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return path
+}
+
+func TestCall(t *testing.T) {
+	path := serveTest(t, func(method string, params json.RawMessage) (any, error) {
+		switch method {
+		case MethodStatus:
+			var p StatusParams
+			if err := DecodeParams(params, &p); err != nil {
+				return nil, err
+			}
+			if p.Session != "" {
+				return nil, Errorf(CodeNotFound, "session %q not found", p.Session)
+			}
+			return StatusResult{Profile: "test", Counts: StatusCounts{Waiting: 2, Total: 2}}, nil
+		case "boom":
+			return nil, errors.New("boom")
+		}
+		return nil, Errorf(CodeMethodNotFound, "unknown method %q", method)
+	})
+
+	client, err := DialPath(path)
+	if err != nil {
+		t.Fatalf("DialPath: %v", err)
+	}
+	defer client.Close()
+
+	var result StatusResult
+	if err := client.Call(MethodStatus, StatusParams{}, &result); err != nil {
+		t.Fatalf("Call: %v", err)
+	}
+	if result.Profile != "test" || result.Counts.Waiting != 2 {
+		t.Errorf("result = %+v", result)
+	}
+
+	// Errors keep their code; others become internal errors
+	for method, code := range map[string]int{MethodStatus: CodeNotFound, "boom": CodeInternalError, "nope": CodeMethodNotFound} {
+		err := client.Call(method, StatusParams{Session: "x"}, nil)
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) || rpcErr.Code != code {
+			t.Errorf("%s: got %v, want code %d", method, err, code)
+		}
+	}
+}
+
+func TestServe_RawLines(t *testing.T) {
+	path := serveTest(t, func(method string, params json.RawMessage) (any, error) {
+		return method, nil
+	})
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Notifications get no response, so the first response is the parse error
+	lines := `{"jsonrpc":"2.0","method":"status"}` + "\n" +
+		"not json\n" +
+		`{"jsonrpc":"2.0","id":"a","method":"list"}` + "\n"
+	if _, err := conn.Write([]byte(lines)); err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(conn)
+	var got []Response
+	for len(got) < 2 && scanner.Scan() {
+		var resp Response
+		if err := json.Unmarshal(scanner.Bytes(), &resp); err != nil {
+			t.Fatalf("bad response %q: %v", scanner.Text(), err)
+		}
+		got = append(got, resp)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d responses, want 2", len(got))
+	}
+	if got[0].Error == nil || got[0].Error.Code != CodeParseError || string(got[0].ID) != "null" {
+		t.Errorf("first response = %+v, want a parse error", got[0])
+	}
+	if string(got[1].ID) != `"a"` || string(got[1].Result) != `"list"` {
+		t.Errorf("second response = %+v, want list's result", got[1])
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), SocketFileName)
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Closing a unix listener removes its file; keep it, as a crash would
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	listener, err := Listen(path)
+	if err != nil {
+		t.Fatalf("Listen over a stale socket: %v", err)
+	}
+	defer listener.Close()
+
+	if _, err := Listen(path); err == nil {
+		t.Error("Listen over a live socket should fail")
+	}
+}
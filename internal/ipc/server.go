@@ -0,0 +1,130 @@
+package ipc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// maxMessageSize bounds one request or response line.
+const maxMessageSize = 10 * 1024 * 1024
+
+// Handler answers a request. Errors that aren't an *Error are reported as
+// internal errors.
+type Handler func(method string, params json.RawMessage) (any, error)
+
+// Listen listens on the socket at path, replacing a stale socket left by a
+// process that died. Only the current user can connect.
+func Listen(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, 500*time.Millisecond); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is in use by another process", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// Serve answers requests on listener's connections with handler until ctx
+// is done, then closes listener.
+func Serve(ctx context.Context, listener net.Listener, handler Handler) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go serveConn(ctx, conn, handler)
+	}
+}
+
+// serveConn answers one connection's requests in order.
+func serveConn(ctx context.Context, conn net.Conn, handler Handler) {
+	defer conn.Close()
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		resp, ok := handle(scanner.Bytes(), handler)
+		if !ok {
+			continue // A notification: no response
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// handle answers one request line. It reports false for notifications,
+// which get no response.
+func handle(line []byte, handler Handler) (Response, bool) {
+	resp := Response{JSONRPC: "2.0", ID: json.RawMessage("null")}
+	var req Request
+	if err := json.Unmarshal(line, &req); err != nil {
+		resp.Error = Errorf(CodeParseError, "parse error: %v", err)
+		return resp, true
+	}
+	if len(req.ID) > 0 {
+		resp.ID = req.ID
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		resp.Error = Errorf(CodeInvalidRequest, "invalid request")
+		return resp, true
+	}
+
+	result, err := handler(req.Method, req.Params)
+	if len(req.ID) == 0 {
+		return resp, false
+	}
+	if err != nil {
+		var rpcErr *Error
+		if !errors.As(err, &rpcErr) {
+			rpcErr = Errorf(CodeInternalError, "%v", err)
+		}
+		resp.Error = rpcErr
+		return resp, true
+	}
+	if resp.Result, err = json.Marshal(result); err != nil {
+		resp.Error = Errorf(CodeInternalError, "failed to encode result: %v", err)
+	}
+	return resp, true
+}
+
+// DecodeParams decodes params into v, reporting failures as invalid
+// params. Missing params leave v as is.
+func DecodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return Errorf(CodeInvalidParams, "invalid params: %v", err)
+	}
+	return nil
+}
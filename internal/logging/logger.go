@@ -22,6 +22,7 @@ const (
 	CompPool    = "pool"
 	CompHTTP    = "http"
 	CompWeb     = "web"
+	CompDaemon  = "daemon"
 )
 
 // Config holds logging configuration.
@@ -29,6 +30,9 @@ type Config struct {
 	// LogDir is the directory for log files (e.g. ~/.agent-deck)
 	LogDir string
 
+	// FileName is the log file in LogDir (default: debug.log)
+	FileName string
+
 	// Level is the minimum log level: "debug", "info", "warn", "error"
 	Level string
 
@@ -68,11 +72,12 @@ var (
 	lumberjackW  *lumberjack.Logger
 )
 
-// Init initializes the global logging system.
+// Init initializes the global logging system, replacing any earlier Init.
 // When debug is false and no log dir is provided, logs are discarded.
 func Init(cfg Config) {
 	globalMu.Lock()
 	defer globalMu.Unlock()
+	shutdownLocked()
 
 	// Defaults
 	if cfg.MaxSizeMB <= 0 {
@@ -111,7 +116,10 @@ func Init(cfg Config) {
 	}
 
 	// Set up lumberjack for rotation
-	logPath := filepath.Join(cfg.LogDir, "debug.log")
+	if cfg.FileName == "" {
+		cfg.FileName = "debug.log"
+	}
+	logPath := filepath.Join(cfg.LogDir, cfg.FileName)
 	lumberjackW = &lumberjack.Logger{
 		Filename:   logPath,
 		MaxSize:    cfg.MaxSizeMB,
@@ -234,7 +242,10 @@ func DumpRingBuffer(path string) error {
 func Shutdown() {
 	globalMu.Lock()
 	defer globalMu.Unlock()
+	shutdownLocked()
+}
 
+func shutdownLocked() {
 	if globalAgg != nil {
 		globalAgg.Stop()
 		globalAgg = nil
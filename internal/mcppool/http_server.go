@@ -141,7 +141,7 @@ func (s *HTTPServer) Start() error {
 	s.mu.Unlock()
 
 	// Create log file
-	logDir := filepath.Join(os.Getenv("HOME"), ".agent-deck", "logs", "http-servers")
+	logDir := mcpLogDir("http-servers")
 	_ = os.MkdirAll(logDir, 0755)
 	s.logFile = filepath.Join(logDir, fmt.Sprintf("%s.log", s.name))
 
@@ -145,7 +145,7 @@ func (p *SocketProxy) Start() error {
 		return nil
 	}
 
-	logDir := filepath.Join(os.Getenv("HOME"), ".agent-deck", "logs", "mcppool")
+	logDir := mcpLogDir("mcppool")
 	_ = os.MkdirAll(logDir, 0755)
 	p.logFile = filepath.Join(logDir, fmt.Sprintf("%s_socket.log", p.name))
 
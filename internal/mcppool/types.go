@@ -1,5 +1,10 @@
 package mcppool
 
+import (
+	"os"
+	"path/filepath"
+)
+
 // ServerStatus represents MCP server state
 type ServerStatus int
 
@@ -27,3 +32,20 @@ func (s ServerStatus) String() string {
 		return "unknown"
 	}
 }
+
+// logBaseDir is where MCP server logs go. Set once at startup.
+var logBaseDir string
+
+// SetLogDir sets the directory MCP server logs are written under
+// (default: ~/.agent-deck/logs).
+func SetLogDir(dir string) {
+	logBaseDir = dir
+}
+
+// mcpLogDir returns the log directory for sub, under SetLogDir's directory.
+func mcpLogDir(sub string) string {
+	if logBaseDir != "" {
+		return filepath.Join(logBaseDir, sub)
+	}
+	return filepath.Join(os.Getenv("HOME"), ".agent-deck", "logs", sub)
+}
@@ -0,0 +1,61 @@
+// Package notify tells the user about session status changes: native desktop
+// notifications, terminal escape sequences, and webhooks.
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/platform"
+)
+
+// appName groups agent-deck's notifications in the notification center.
+const appName = "agent-deck"
+
+// Send shows a desktop notification and returns the method used (e.g.
+// "terminal-notifier", "osascript", "notify-send").
+// The fallback chain is: terminal-notifier → osascript on macOS, notify-send
+// on Linux and WSL.
+func Send(title, body string) (string, error) {
+	name, args, err := desktopCommand(platform.Detect(), title, body, exec.LookPath)
+	if err != nil {
+		return "", err
+	}
+	if out, err := exec.Command(name, args...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%s: %w: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return filepath.Base(name), nil
+}
+
+// desktopCommand picks the notifier command for platform p. lookPath is
+// exec.LookPath, swapped out in tests.
+func desktopCommand(p platform.Platform, title, body string, lookPath func(string) (string, error)) (string, []string, error) {
+	switch p {
+	case platform.PlatformMacOS:
+		// terminal-notifier can be clicked away and groups per app;
+		// osascript is always there
+		if path, err := lookPath("terminal-notifier"); err == nil {
+			return path, []string{"-title", title, "-message", body, "-group", appName}, nil
+		}
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+		return "osascript", []string{"-e", script}, nil
+
+	case platform.PlatformLinux, platform.PlatformWSL1, platform.PlatformWSL2:
+		if path, err := lookPath("notify-send"); err == nil {
+			return path, []string{"--app-name=" + appName, title, body}, nil
+		}
+		return "", nil, fmt.Errorf("notify-send not found (install libnotify)")
+
+	default:
+		return "", nil, fmt.Errorf("unsupported platform: %s", p)
+	}
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
@@ -0,0 +1,174 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/platform"
+)
+
+func lookPathOf(found ...string) func(string) (string, error) {
+	return func(name string) (string, error) {
+		for _, f := range found {
+			if f == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+}
+
+func TestDesktopCommand_MacOSPrefersTerminalNotifier(t *testing.T) {
+	name, args, err := desktopCommand(platform.PlatformMacOS, "api", "waiting", lookPathOf("terminal-notifier"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(name) != "terminal-notifier" || args[1] != "api" || args[3] != "waiting" {
+		t.Errorf("got %s %q", name, args)
+	}
+}
+
+func TestDesktopCommand_MacOSFallsBackToOsascript(t *testing.T) {
+	name, args, err := desktopCommand(platform.PlatformMacOS, `say "hi"`, `C:\tmp`, lookPathOf())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `display notification "C:\\tmp" with title "say \"hi\""`
+	if name != "osascript" || len(args) != 2 || args[1] != want {
+		t.Errorf("got %s %q, want script %s", name, args, want)
+	}
+}
+
+func TestDesktopCommand_Linux(t *testing.T) {
+	name, args, err := desktopCommand(platform.PlatformLinux, "api", "waiting", lookPathOf("notify-send"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filepath.Base(name) != "notify-send" || len(args) != 3 || args[1] != "api" {
+		t.Errorf("got %s %q", name, args)
+	}
+
+	if _, _, err := desktopCommand(platform.PlatformLinux, "api", "waiting", lookPathOf()); err == nil {
+		t.Error("expected an error without notify-send")
+	}
+}
+
+func TestDesktopCommand_Unsupported(t *testing.T) {
+	if _, _, err := desktopCommand(platform.PlatformWindows, "api", "waiting", lookPathOf()); err == nil {
+		t.Error("expected an error on unsupported platforms")
+	}
+}
+
+func TestTerminalSequence(t *testing.T) {
+	tests := []struct {
+		method string
+		inTmux bool
+		want   string
+	}{
+		{TerminalBell, false, "\a"},
+		{TerminalBell, true, "\a"},
+		{TerminalOSC9, false, "\x1b]9;api: done, next\x1b\\"},
+		{TerminalOSC777, false, "\x1b]777;notify;api;done, next\x1b\\"},
+		{TerminalOSC9, true, "\x1bPtmux;\x1b\x1b]9;api: done, next\x1b\x1b\\\x1b\\"},
+		{"", false, ""},
+		{"beep", false, ""},
+	}
+	for _, tt := range tests {
+		// The semicolon would split OSC 777 fields
+		if got := TerminalSequence(tt.method, "api", "done; next", tt.inTmux); got != tt.want {
+			t.Errorf("TerminalSequence(%q, tmux=%v) = %q, want %q", tt.method, tt.inTmux, got, tt.want)
+		}
+	}
+
+	// A control character in a title must not end the sequence early
+	if got := TerminalSequence(TerminalOSC9, "a\x07b", "c", false); got != "\x1b]9;a b: c\x1b\\" {
+		t.Errorf("control characters not stripped: %q", got)
+	}
+}
+
+func TestPostWebhook(t *testing.T) {
+	var got WebhookEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("got %s with Content-Type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	event := NewWebhookEvent("id-1", "api", "claude", "work", "/src/api", "running", "waiting", time.Unix(100, 0))
+	if err := PostWebhook(srv.URL, event); err != nil {
+		t.Fatal(err)
+	}
+	if got.Event != "status_changed" || got.SessionID != "id-1" || got.OldStatus != "running" || got.NewStatus != "waiting" {
+		t.Errorf("event = %+v", got)
+	}
+	if got.Text == "" || got.Text != got.Content {
+		t.Errorf("text = %q, content = %q; want the same summary for Slack and Discord", got.Text, got.Content)
+	}
+
+	if err := PostWebhook(srv.URL+"/fail", event); err == nil {
+		t.Error("expected an error for a 400 response")
+	}
+}
+
+func TestPush(t *testing.T) {
+	var ntfyReq, pushoverReq *http.Request
+	var ntfyBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/my-topic":
+			ntfyReq = r
+			b, _ := io.ReadAll(r.Body)
+			ntfyBody = string(b)
+		case "/pushover":
+			_ = r.ParseForm()
+			pushoverReq = r
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	origURL := pushoverURL
+	pushoverURL = srv.URL + "/pushover"
+	defer func() { pushoverURL = origURL }()
+
+	targets := PushTargets{
+		NtfyServer: srv.URL + "/", NtfyTopic: "my-topic", NtfyToken: "tk",
+		PushoverToken: "app", PushoverUser: "me",
+	}
+	if !targets.Enabled() {
+		t.Fatal("targets should be enabled")
+	}
+	if err := Push(targets, "api", "needs approval", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if ntfyReq == nil || ntfyBody != "needs approval" || ntfyReq.Header.Get("Title") != "api" ||
+		ntfyReq.Header.Get("Priority") != "high" || ntfyReq.Header.Get("Authorization") != "Bearer tk" {
+		t.Errorf("ntfy request = %+v, body %q", ntfyReq, ntfyBody)
+	}
+	if pushoverReq == nil || pushoverReq.PostForm.Get("user") != "me" || pushoverReq.PostForm.Get("message") != "needs approval" ||
+		pushoverReq.PostForm.Get("priority") != "1" {
+		t.Errorf("pushover form = %v", pushoverReq)
+	}
+
+	if err := Push(PushTargets{NtfyServer: srv.URL, NtfyTopic: "other"}, "api", "waiting", false); err == nil {
+		t.Error("expected an error for a 404 response")
+	}
+	if (PushTargets{PushoverToken: "app"}).Enabled() {
+		t.Error("pushover needs both token and user")
+	}
+}
@@ -0,0 +1,102 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// defaultNtfyServer is used when only a topic is configured.
+const defaultNtfyServer = "https://ntfy.sh"
+
+// pushoverURL is the Pushover message API, swapped out in tests.
+var pushoverURL = "https://api.pushover.net/1/messages.json"
+
+// PushTargets are the phone push services to publish to. Empty fields turn
+// a service off.
+type PushTargets struct {
+	NtfyServer string // default https://ntfy.sh
+	NtfyTopic  string
+	NtfyToken  string // access token for protected topics
+
+	PushoverToken string // application token
+	PushoverUser  string // user or group key
+}
+
+// Enabled reports whether any push service is configured.
+func (p PushTargets) Enabled() bool {
+	return p.NtfyTopic != "" || (p.PushoverToken != "" && p.PushoverUser != "")
+}
+
+// Push publishes a notification to every configured service. urgent raises
+// the priority, so the phone alerts even when the topic is set to quiet.
+func Push(p PushTargets, title, body string, urgent bool) error {
+	var errs []error
+	if p.NtfyTopic != "" {
+		if err := pushNtfy(p, title, body, urgent); err != nil {
+			errs = append(errs, fmt.Errorf("ntfy: %w", err))
+		}
+	}
+	if p.PushoverToken != "" && p.PushoverUser != "" {
+		if err := pushPushover(p, title, body, urgent); err != nil {
+			errs = append(errs, fmt.Errorf("pushover: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func pushNtfy(p PushTargets, title, body string, urgent bool) error {
+	server := strings.TrimSuffix(p.NtfyServer, "/")
+	if server == "" {
+		server = defaultNtfyServer
+	}
+	req, err := http.NewRequest(http.MethodPost, server+"/"+url.PathEscape(p.NtfyTopic), strings.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Title", title)
+	req.Header.Set("Tags", "robot")
+	if urgent {
+		req.Header.Set("Priority", "high")
+	}
+	if p.NtfyToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.NtfyToken)
+	}
+	return doRequest(req)
+}
+
+func pushPushover(p PushTargets, title, body string, urgent bool) error {
+	form := url.Values{
+		"token":   {p.PushoverToken},
+		"user":    {p.PushoverUser},
+		"title":   {title},
+		"message": {body},
+	}
+	if urgent {
+		form.Set("priority", "1")
+	}
+	req, err := http.NewRequest(http.MethodPost, pushoverURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return doRequest(req)
+}
+
+// doRequest sends req and fails on any non-2xx response.
+func doRequest(req *http.Request) error {
+	req.Header.Set("User-Agent", appName)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("server returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package notify
+
+import (
+	"os"
+	"strings"
+	"sync"
+)
+
+// Terminal notification methods for [notifications] terminal.
+const (
+	TerminalBell   = "bell"   // BEL: tab badge or bounce in most terminals
+	TerminalOSC9   = "osc9"   // OSC 9: iTerm2, WezTerm, kitty, Windows Terminal
+	TerminalOSC777 = "osc777" // OSC 777: rxvt-unicode, foot, Ghostty, VTE terminals
+)
+
+// TerminalOutput is stdout with writes serialized. The TUI renders through
+// it (tea.WithOutput) so a notification sent from a background goroutine
+// lands between two frames rather than inside one.
+type TerminalOutput struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+var stdout = &TerminalOutput{file: os.Stdout}
+
+// Stdout returns the TerminalOutput SendTerminal writes to.
+func Stdout() *TerminalOutput {
+	return stdout
+}
+
+// Write writes p in one piece.
+func (o *TerminalOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.file.Write(p)
+}
+
+// Read, Close and Fd let Bubble Tea treat the output as the terminal.
+func (o *TerminalOutput) Read(p []byte) (int, error) { return o.file.Read(p) }
+func (o *TerminalOutput) Close() error               { return o.file.Close() }
+func (o *TerminalOutput) Fd() uintptr                { return o.file.Fd() }
+
+// SendTerminal writes the escape sequence for method to the controlling
+// terminal. Unlike Send it works over SSH: the terminal emulator on the
+// user's machine shows the notification. Unknown methods are a no-op.
+func SendTerminal(method, title, body string) error {
+	seq := TerminalSequence(method, title, body, os.Getenv("TMUX") != "")
+	if seq == "" {
+		return nil
+	}
+	_, err := stdout.Write([]byte(seq))
+	return err
+}
+
+// TerminalSequence returns the escape sequence for method, or "" if method
+// is unknown. Inside tmux, OSC sequences are wrapped in tmux's DCS
+// passthrough (needs "set -g allow-passthrough on"); a bell goes through
+// tmux as is.
+func TerminalSequence(method, title, body string, inTmux bool) string {
+	var seq string
+	switch method {
+	case TerminalBell:
+		return "\a"
+	case TerminalOSC9:
+		seq = "\x1b]9;" + oscText(title+": "+body) + "\x1b\\"
+	case TerminalOSC777:
+		seq = "\x1b]777;notify;" + oscText(title) + ";" + oscText(body) + "\x1b\\"
+	default:
+		return ""
+	}
+	if inTmux {
+		// Every ESC inside the passthrough is doubled
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+	return seq
+}
+
+// oscText strips control characters, which would end the sequence early,
+// and semicolons, which separate OSC 777 fields.
+func oscText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		if r == ';' {
+			return ','
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// httpTimeout bounds each webhook and push request, so a dead endpoint
+// can't pile up goroutines.
+const httpTimeout = 10 * time.Second
+
+var httpClient = &http.Client{Timeout: httpTimeout}
+
+// WebhookEvent is the JSON body POSTed to webhooks when a session changes
+// status.
+type WebhookEvent struct {
+	Event     string    `json:"event"` // always "status_changed"
+	SessionID string    `json:"session_id"`
+	Title     string    `json:"title"`
+	Tool      string    `json:"tool"`
+	Group     string    `json:"group"`
+	Path      string    `json:"path"`
+	OldStatus string    `json:"old_status"`
+	NewStatus string    `json:"new_status"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Text and Content repeat the change as a sentence, so Slack ("text")
+	// and Discord ("content") incoming webhooks accept the body as is
+	Text    string `json:"text"`
+	Content string `json:"content"`
+}
+
+// NewWebhookEvent builds the event for a session going from oldStatus to
+// newStatus.
+func NewWebhookEvent(sessionID, title, tool, group, path, oldStatus, newStatus string, at time.Time) WebhookEvent {
+	text := fmt.Sprintf("agent-deck: %s (%s) is now %s", title, tool, newStatus)
+	if oldStatus != "" {
+		text += fmt.Sprintf(" (was %s)", oldStatus)
+	}
+	return WebhookEvent{
+		Event:     "status_changed",
+		SessionID: sessionID,
+		Title:     title,
+		Tool:      tool,
+		Group:     group,
+		Path:      path,
+		OldStatus: oldStatus,
+		NewStatus: newStatus,
+		Timestamp: at.UTC(),
+		Text:      text,
+		Content:   text,
+	}
+}
+
+// PostWebhook POSTs event to url as JSON.
+func PostWebhook(url string, event WebhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return doRequest(req)
+}
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"encoding/json"
 	"os"
+	"regexp"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -32,9 +34,12 @@ type SessionAnalytics struct {
 	// Subagents
 	Subagents []SubagentInfo `json:"subagents"`
 
-	// Cost estimation
+	// Cost estimation, priced per turn by the model that answered it
 	EstimatedCost float64 `json:"estimated_cost"`
 
+	// Model of the latest turn
+	Model string `json:"model,omitempty"`
+
 	// 5-hour billing blocks
 	BillingBlocks []BillingBlock `json:"billing_blocks"`
 }
@@ -83,46 +88,105 @@ type ModelPricing struct {
 	CacheWrite float64
 }
 
-// modelPricing contains pricing per million tokens for each model (as of Jan 2025)
+// modelPricing contains pricing per million tokens by model ID prefix, so
+// dated releases ("claude-sonnet-4-5-20250929") match their family
 var modelPricing = map[string]ModelPricing{
-	"claude-sonnet-4-20250514": {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
-	"claude-opus-4-20250514":   {Input: 15.0, Output: 75.0, CacheRead: 1.50, CacheWrite: 18.75},
-	"claude-3-5-sonnet":        {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
-	"claude-3-5-haiku":         {Input: 0.80, Output: 4.0, CacheRead: 0.08, CacheWrite: 1.0},
+	"claude-opus-4-5":   {Input: 5.0, Output: 25.0, CacheRead: 0.50, CacheWrite: 6.25},
+	"claude-opus-4":     {Input: 15.0, Output: 75.0, CacheRead: 1.50, CacheWrite: 18.75},
+	"claude-sonnet-4":   {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
+	"claude-haiku-4":    {Input: 1.0, Output: 5.0, CacheRead: 0.10, CacheWrite: 1.25},
+	"claude-3-7-sonnet": {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
+	"claude-3-5-sonnet": {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
+	"claude-3-5-haiku":  {Input: 0.80, Output: 4.0, CacheRead: 0.08, CacheWrite: 1.0},
 	// Default fallback uses Sonnet pricing
 	"default": {Input: 3.0, Output: 15.0, CacheRead: 0.30, CacheWrite: 3.75},
 }
 
-// CalculateCost estimates session cost based on token usage and model pricing
-func (a *SessionAnalytics) CalculateCost(model string) float64 {
-	pricing, ok := modelPricing[model]
-	if !ok {
-		pricing = modelPricing["default"]
+// pricingForModel returns the pricing of the longest modelPricing prefix of
+// model, or the default pricing.
+func pricingForModel(model string) ModelPricing {
+	if pricing, ok := modelPricing[model]; ok {
+		return pricing
+	}
+	best := ""
+	for prefix := range modelPricing {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return modelPricing["default"]
 	}
+	return modelPricing[best]
+}
 
-	// Convert to millions
-	inputM := float64(a.InputTokens) / 1_000_000
-	outputM := float64(a.OutputTokens) / 1_000_000
-	cacheReadM := float64(a.CacheReadTokens) / 1_000_000
-	cacheWriteM := float64(a.CacheWriteTokens) / 1_000_000
+// cost prices the given token counts.
+func (p ModelPricing) cost(input, output, cacheRead, cacheWrite int) float64 {
+	return float64(input)/1_000_000*p.Input +
+		float64(output)/1_000_000*p.Output +
+		float64(cacheRead)/1_000_000*p.CacheRead +
+		float64(cacheWrite)/1_000_000*p.CacheWrite
+}
 
-	return inputM*pricing.Input +
-		outputM*pricing.Output +
-		cacheReadM*pricing.CacheRead +
-		cacheWriteM*pricing.CacheWrite
+// modelVersionPart matches a version component of a model ID: "4", "5", "4.5".
+var modelVersionPart = regexp.MustCompile(`^\d{1,2}(\.\d+)?$`)
+
+// ShortModelName shortens a model ID for badges. Claude models become
+// family-version: "claude-opus-4-5-20251101" and "Opus 4.5" are "opus-4.5",
+// "claude-3-5-sonnet-latest" is "sonnet-3.5". Other models ("gemini-2.5-pro",
+// "gpt-5-codex") only lose a provider prefix and date suffix.
+func ShortModelName(model string) string {
+	m := strings.ToLower(strings.TrimSpace(model))
+	if i := strings.LastIndex(m, "/"); i >= 0 {
+		m = m[i+1:]
+	}
+	m = strings.TrimSuffix(strings.ReplaceAll(m, " ", "-"), "-latest")
+	parts := strings.Split(m, "-")
+	if n := len(parts); n > 1 && len(parts[n-1]) == 8 && strings.Trim(parts[n-1], "0123456789") == "" {
+		parts = parts[:n-1]
+	}
+	family := ""
+	var version []string
+	for _, part := range parts {
+		switch {
+		case part == "opus" || part == "sonnet" || part == "haiku":
+			family = part
+		case modelVersionPart.MatchString(part):
+			version = append(version, part)
+		}
+	}
+	if family == "" {
+		return strings.Join(parts, "-")
+	}
+	if len(version) == 0 {
+		return family
+	}
+	return family + "-" + strings.Join(version, ".")
+}
+
+// CalculateCost estimates session cost as if every turn used model.
+// EstimatedCost, set by ParseSessionJSONL, prices each turn by its own model.
+func (a *SessionAnalytics) CalculateCost(model string) float64 {
+	return pricingForModel(model).cost(a.InputTokens, a.OutputTokens, a.CacheReadTokens, a.CacheWriteTokens)
+}
+
+// jsonlUsage is the token usage of one API response.
+type jsonlUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
 }
 
 // jsonlEntry represents a single line in a Claude session JSONL file
 type jsonlEntry struct {
 	Type      string    `json:"type"`
 	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"requestId"`
 	Message   struct {
-		Usage struct {
-			InputTokens              int `json:"input_tokens"`
-			OutputTokens             int `json:"output_tokens"`
-			CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
-			CacheReadInputTokens     int `json:"cache_read_input_tokens"`
-		} `json:"usage"`
+		ID      string     `json:"id"`
+		Model   string     `json:"model"`
+		Usage   jsonlUsage `json:"usage"`
 		Content []struct {
 			Type string `json:"type"`
 			Name string `json:"name"`
@@ -131,8 +195,22 @@ type jsonlEntry struct {
 	AgentID string `json:"agent_id,omitempty"`
 }
 
+// jsonlResponse is one API response's usage. Claude Code writes a line per
+// content block, each repeating the response's usage, so lines are merged
+// by message and request ID.
+type jsonlResponse struct {
+	model string
+	usage jsonlUsage
+}
+
 // ParseSessionJSONL parses a Claude session JSONL file and returns analytics
 func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
+	return ParseSessionJSONLSince(path, time.Time{})
+}
+
+// ParseSessionJSONLSince is ParseSessionJSONL counting only the turns at or
+// after since, e.g. today's usage. A zero since counts every turn.
+func ParseSessionJSONLSince(path string, since time.Time) (*SessionAnalytics, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, err
@@ -144,6 +222,8 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 	}
 	toolCounts := make(map[string]int)
 	var firstTime, lastTime time.Time
+	var responses []*jsonlResponse
+	responseByKey := make(map[string]*jsonlResponse)
 
 	scanner := bufio.NewScanner(file)
 	// Increase buffer for large lines (some tool outputs can be huge)
@@ -160,6 +240,9 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 		if entry.Type != "assistant" {
 			continue
 		}
+		if !since.IsZero() && entry.Timestamp.Before(since) {
+			continue
+		}
 
 		// Track timing
 		if !entry.Timestamp.IsZero() {
@@ -171,19 +254,23 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 			}
 		}
 
-		// Accumulate tokens (cumulative totals for cost calculation)
-		analytics.InputTokens += entry.Message.Usage.InputTokens
-		analytics.OutputTokens += entry.Message.Usage.OutputTokens
-		analytics.CacheReadTokens += entry.Message.Usage.CacheReadInputTokens
-		analytics.CacheWriteTokens += entry.Message.Usage.CacheCreationInputTokens
-
-		// Track current context size (last turn's input + cache read)
-		// This represents the actual context window usage
-		analytics.CurrentContextTokens = entry.Message.Usage.InputTokens +
-			entry.Message.Usage.CacheReadInputTokens
-
-		// Count turn
-		analytics.TotalTurns++
+		// Merge lines of the same response; a later line may carry the
+		// final output count of a streamed response
+		key := ""
+		if entry.Message.ID != "" {
+			key = entry.Message.ID + ":" + entry.RequestID
+		}
+		if resp, ok := responseByKey[key]; ok && key != "" {
+			if entry.Message.Usage.OutputTokens > resp.usage.OutputTokens {
+				resp.usage = entry.Message.Usage
+			}
+		} else {
+			resp := &jsonlResponse{model: entry.Message.Model, usage: entry.Message.Usage}
+			responses = append(responses, resp)
+			if key != "" {
+				responseByKey[key] = resp
+			}
+		}
 
 		// Count tool calls
 		for _, content := range entry.Message.Content {
@@ -193,6 +280,25 @@ func ParseSessionJSONL(path string) (*SessionAnalytics, error) {
 		}
 	}
 
+	// Accumulate tokens (cumulative totals) and cost, one turn per response
+	for _, resp := range responses {
+		u := resp.usage
+		analytics.InputTokens += u.InputTokens
+		analytics.OutputTokens += u.OutputTokens
+		analytics.CacheReadTokens += u.CacheReadInputTokens
+		analytics.CacheWriteTokens += u.CacheCreationInputTokens
+		analytics.EstimatedCost += pricingForModel(resp.model).cost(
+			u.InputTokens, u.OutputTokens, u.CacheReadInputTokens, u.CacheCreationInputTokens)
+		analytics.TotalTurns++
+
+		// Track current context size (last turn's input + cache read)
+		// This represents the actual context window usage
+		analytics.CurrentContextTokens = u.InputTokens + u.CacheReadInputTokens
+		if resp.model != "" && !strings.HasPrefix(resp.model, "<") { // skip "<synthetic>"
+			analytics.Model = resp.model
+		}
+	}
+
 	// Convert tool counts to slice
 	for name, count := range toolCounts {
 		analytics.ToolCalls = append(analytics.ToolCalls, ToolCall{
@@ -154,6 +154,27 @@ func TestParseJSONL_WithTimestamps(t *testing.T) {
 	assert.Equal(t, 10*time.Minute, analytics.Duration)
 }
 
+func TestParseJSONLSince(t *testing.T) {
+	dir := t.TempDir()
+	jsonlPath := filepath.Join(dir, "session.jsonl")
+
+	jsonl := `{"type":"assistant","timestamp":"2025-01-09T23:00:00Z","message":{"usage":{"input_tokens":100,"output_tokens":50}}}
+{"type":"assistant","message":{"usage":{"input_tokens":100,"output_tokens":50}}}
+{"type":"assistant","timestamp":"2025-01-10T10:05:00Z","message":{"usage":{"input_tokens":200,"output_tokens":100}}}`
+
+	err := os.WriteFile(jsonlPath, []byte(jsonl), 0644)
+	require.NoError(t, err)
+
+	analytics, err := ParseSessionJSONLSince(jsonlPath, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, 200, analytics.InputTokens, "earlier and untimed turns are skipped")
+	assert.Equal(t, 1, analytics.TotalTurns)
+
+	analytics, err = ParseSessionJSONLSince(jsonlPath, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, analytics.TotalTurns)
+}
+
 func TestParseJSONL_WithCacheTokens(t *testing.T) {
 	dir := t.TempDir()
 	jsonlPath := filepath.Join(dir, "session.jsonl")
@@ -502,3 +523,88 @@ func TestCalculateBillingBlocks_CustomWindowSize(t *testing.T) {
 	// now is 1h from -1h, so same block 2
 	assert.Equal(t, 2, len(blocks))
 }
+
+func TestParseJSONL_MergesResponseLinesAndPricesPerModel(t *testing.T) {
+	jsonlPath := filepath.Join(t.TempDir(), "session.jsonl")
+
+	// One Opus response split over two lines (text + tool_use, the second
+	// with the final output count), then a Haiku response
+	jsonl := `{"type":"assistant","requestId":"req_1","message":{"id":"msg_1","model":"claude-opus-4-1-20250805","usage":{"input_tokens":1000000,"output_tokens":10},"content":[{"type":"text"}]}}
+{"type":"assistant","requestId":"req_1","message":{"id":"msg_1","model":"claude-opus-4-1-20250805","usage":{"input_tokens":1000000,"output_tokens":100000},"content":[{"type":"tool_use","name":"Read"}]}}
+{"type":"assistant","requestId":"req_2","message":{"id":"msg_2","model":"claude-haiku-4-5-20251001","usage":{"input_tokens":1000000,"output_tokens":0}}}`
+	require.NoError(t, os.WriteFile(jsonlPath, []byte(jsonl), 0644))
+
+	analytics, err := ParseSessionJSONL(jsonlPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2000000, analytics.InputTokens)
+	assert.Equal(t, 100000, analytics.OutputTokens)
+	assert.Equal(t, 2, analytics.TotalTurns)
+	assert.Equal(t, "claude-haiku-4-5-20251001", analytics.Model)
+	require.Len(t, analytics.ToolCalls, 1)
+
+	// Opus: 1M in * $15 + 100K out * $75 = $22.50; Haiku 4.5: 1M in * $1
+	assert.InDelta(t, 23.50, analytics.EstimatedCost, 0.001)
+}
+
+func TestPricingForModel(t *testing.T) {
+	assert.Equal(t, 5.0, pricingForModel("claude-opus-4-5-20251101").Input)
+	assert.Equal(t, 15.0, pricingForModel("claude-opus-4-1-20250805").Input)
+	assert.Equal(t, 3.0, pricingForModel("claude-sonnet-4-5-20250929").Input)
+	assert.Equal(t, modelPricing["default"], pricingForModel("gpt-5"))
+}
+
+func TestShortModelName(t *testing.T) {
+	tests := map[string]string{
+		"claude-opus-4-5-20251101":   "opus-4.5",
+		"claude-sonnet-4-20250514":   "sonnet-4",
+		"claude-3-5-sonnet-latest":   "sonnet-3.5",
+		"Opus 4.1":                   "opus-4.1",
+		"anthropic/claude-haiku-4-5": "haiku-4.5",
+		"gemini-2.5-pro":             "gemini-2.5-pro",
+		"gpt-5-codex":                "gpt-5-codex",
+		"openai/gpt-4o-2024-08-06":   "gpt-4o-2024-08-06",
+		"":                           "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, ShortModelName(in), in)
+	}
+}
+
+func TestParseClaudeLatestModel(t *testing.T) {
+	data := []byte(`{"type":"assistant","message":{"role":"assistant","model":"claude-sonnet-4-5-20250929"}}
+{"type":"user","message":{"role":"user","content":"switch to opus"}}
+{"type":"assistant","message":{"role":"assistant","model":"claude-opus-4-5-20251101"}}
+{"type":"assistant","message":{"role":"assistant","model":"<synthetic>"}}
+`)
+	assert.Equal(t, "claude-opus-4-5-20251101", parseClaudeLatestModel(data))
+	assert.Empty(t, parseClaudeLatestModel([]byte(`{"type":"user","message":{"role":"user","content":"hi"}}`)))
+}
+
+func TestReadUsage_Claude(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("CLAUDE_CONFIG_DIR", configDir)
+	projectPath := t.TempDir()
+	resolved, err := filepath.EvalSymlinks(projectPath)
+	require.NoError(t, err)
+
+	projectDir := filepath.Join(configDir, "projects", ConvertToClaudeDirName(resolved))
+	require.NoError(t, os.MkdirAll(projectDir, 0755))
+	jsonl := `{"type":"assistant","requestId":"r","message":{"id":"m","model":"claude-sonnet-4-5","usage":{"input_tokens":1000,"output_tokens":500}}}`
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "sess-1.jsonl"), []byte(jsonl), 0644))
+
+	inst := &Instance{Tool: "claude", ProjectPath: projectPath, ClaudeSessionID: "sess-1"}
+	u, ok := inst.ReadUsage()
+	require.True(t, ok)
+	assert.Equal(t, 1500, u.TotalTokens())
+	assert.Equal(t, "claude-sonnet-4-5", u.Model)
+	assert.InDelta(t, 0.0105, u.Cost, 1e-9)
+
+	total := u.Add(Usage{InputTokens: 10, Model: "gemini-2.5-pro"})
+	assert.Equal(t, 1510, total.TotalTokens())
+	assert.Empty(t, total.Model, "mixed models have no single model")
+
+	if _, ok := (&Instance{Tool: "codex"}).ReadUsage(); ok {
+		t.Error("codex sessions have no readable usage")
+	}
+}
@@ -0,0 +1,201 @@
+package session
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+// ArchivedSession is a deleted session kept for later viewing or restoring.
+type ArchivedSession struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	ProjectPath string    `json:"project_path"`
+	GroupPath   string    `json:"group_path"`
+	Tool        string    `json:"tool"`
+	ArchivedAt  time.Time `json:"archived_at"`
+	Transcript  string    `json:"transcript,omitempty"` // Final terminal output (only set by GetArchived)
+
+	data json.RawMessage
+}
+
+// ErrArchivedNotFound is returned when no archived session matches an ID.
+var ErrArchivedNotFound = errors.New("archived session not found")
+
+// CaptureTranscript returns the session's scrollback for archiving, or "" if
+// the tmux session is gone.
+func (i *Instance) CaptureTranscript() string {
+	if i.tmuxSession == nil || !i.tmuxSession.Exists() {
+		return ""
+	}
+	content, err := i.PreviewFull()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimRight(content, "\n")
+}
+
+// ArchiveInstance stores a copy of a session and its transcript in the
+// archive. It does not remove the session; callers delete it as usual.
+func (s *Storage) ArchiveInstance(inst *Instance, transcript string) error {
+	data, err := json.Marshal(instanceToData(inst))
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return fmt.Errorf("storage database not initialized")
+	}
+	if err := s.db.SaveArchived(&statedb.ArchivedRow{
+		ID:          inst.ID,
+		Title:       inst.Title,
+		ProjectPath: inst.ProjectPath,
+		GroupPath:   inst.GroupPath,
+		Tool:        inst.Tool,
+		ArchivedAt:  time.Now(),
+		Data:        data,
+		Transcript:  transcript,
+	}); err != nil {
+		return fmt.Errorf("failed to archive session %s: %w", inst.ID, err)
+	}
+	return nil
+}
+
+// ListArchived returns archived sessions, most recently archived first.
+// Transcripts are not included.
+func (s *Storage) ListArchived() ([]*ArchivedSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return nil, nil
+	}
+	rows, err := s.db.LoadArchived()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archived sessions: %w", err)
+	}
+	result := make([]*ArchivedSession, len(rows))
+	for i, r := range rows {
+		result[i] = archivedFromRow(r)
+	}
+	return result, nil
+}
+
+// GetArchived returns an archived session with its transcript.
+func (s *Storage) GetArchived(id string) (*ArchivedSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return nil, fmt.Errorf("storage database not initialized")
+	}
+	row, err := s.db.GetArchived(id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrArchivedNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load archived session %s: %w", id, err)
+	}
+	return archivedFromRow(row), nil
+}
+
+// DeleteArchived permanently removes a session from the archive.
+func (s *Storage) DeleteArchived(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.db == nil {
+		return fmt.Errorf("storage database not initialized")
+	}
+	if err := s.db.DeleteArchived(id); err != nil {
+		return fmt.Errorf("failed to delete archived session %s: %w", id, err)
+	}
+	return nil
+}
+
+// Instance rebuilds the archived session as an Instance. The instance is not
+// saved or started; its tmux session was killed when it was archived, so
+// callers restart it (which resumes Claude/Gemini conversations by ID).
+func (a *ArchivedSession) Instance() (*Instance, error) {
+	var data InstanceData
+	if err := json.Unmarshal(a.data, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode archived session %s: %w", a.ID, err)
+	}
+	data.Status = StatusError
+	instances, _, err := (&Storage{}).convertToInstances(&StorageData{Instances: []*InstanceData{&data}})
+	if err != nil {
+		return nil, err
+	}
+	return instances[0], nil
+}
+
+func archivedFromRow(r *statedb.ArchivedRow) *ArchivedSession {
+	return &ArchivedSession{
+		ID:          r.ID,
+		Title:       r.Title,
+		ProjectPath: r.ProjectPath,
+		GroupPath:   r.GroupPath,
+		Tool:        r.Tool,
+		ArchivedAt:  r.ArchivedAt,
+		Transcript:  r.Transcript,
+		data:        r.Data,
+	}
+}
+
+// instanceToData captures an instance's persisted fields.
+func instanceToData(inst *Instance) *InstanceData {
+	tmuxName := ""
+	if inst.tmuxSession != nil {
+		tmuxName = inst.tmuxSession.Name
+	}
+	return &InstanceData{
+		ID:                 inst.ID,
+		Title:              inst.Title,
+		ProjectPath:        inst.ProjectPath,
+		GroupPath:          inst.GroupPath,
+		Order:              inst.Order,
+		ParentSessionID:    inst.ParentSessionID,
+		Command:            inst.Command,
+		Wrapper:            inst.Wrapper,
+		Tool:               inst.Tool,
+		Status:             inst.Status,
+		CreatedAt:          inst.CreatedAt,
+		LastAccessedAt:     inst.LastAccessedAt,
+		TmuxSession:        tmuxName,
+		Notes:              inst.Notes,
+		Tags:               inst.Tags,
+		WorktreePath:       inst.WorktreePath,
+		WorktreeRepoRoot:   inst.WorktreeRepoRoot,
+		WorktreeBranch:     inst.WorktreeBranch,
+		ClaudeSessionID:    inst.ClaudeSessionID,
+		ClaudeDetectedAt:   inst.ClaudeDetectedAt,
+		GeminiSessionID:    inst.GeminiSessionID,
+		GeminiDetectedAt:   inst.GeminiDetectedAt,
+		GeminiYoloMode:     inst.GeminiYoloMode,
+		GeminiModel:        inst.GeminiModel,
+		OpenCodeSessionID:  inst.OpenCodeSessionID,
+		OpenCodeDetectedAt: inst.OpenCodeDetectedAt,
+		CodexSessionID:     inst.CodexSessionID,
+		CodexDetectedAt:    inst.CodexDetectedAt,
+		LatestPrompt:       inst.LatestPrompt,
+		ToolOptionsJSON:    inst.ToolOptionsJSON,
+		LoadedMCPNames:     inst.LoadedMCPNames,
+		PollInterval:       inst.PollInterval,
+		ActivityCooldown:   inst.ActivityCooldown,
+		NotifyMuted:        inst.NotifyMuted,
+		DetectedModel:      inst.DetectedModel,
+		ForkedFromID:       inst.ForkedFromID,
+		ForkedAt:           inst.ForkedAt,
+		PromptQueue:        inst.QueuedPrompts(),
+		AutoRestartMax:     inst.AutoRestartMax,
+		ProjectEnv:         inst.ProjectEnv,
+	}
+}
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestArchiveInstanceRoundTrip(t *testing.T) {
+	s := newTestStorage(t)
+
+	inst := &Instance{
+		ID: "arch-1", Title: "Archived", ProjectPath: "/tmp/arch", GroupPath: "work",
+		Tool: "claude", Command: "claude", ClaudeSessionID: "abc-123",
+		Status: StatusIdle, CreatedAt: time.Now(), PollInterval: 5 * time.Second,
+	}
+	if err := s.ArchiveInstance(inst, "line one\nline two"); err != nil {
+		t.Fatalf("ArchiveInstance: %v", err)
+	}
+
+	list, err := s.ListArchived()
+	if err != nil {
+		t.Fatalf("ListArchived: %v", err)
+	}
+	if len(list) != 1 || list[0].Title != "Archived" || list[0].Transcript != "" {
+		t.Fatalf("ListArchived = %+v", list)
+	}
+
+	full, err := s.GetArchived("arch-1")
+	if err != nil {
+		t.Fatalf("GetArchived: %v", err)
+	}
+	if full.Transcript != "line one\nline two" {
+		t.Errorf("Transcript = %q", full.Transcript)
+	}
+
+	restored, err := full.Instance()
+	if err != nil {
+		t.Fatalf("Instance: %v", err)
+	}
+	if restored.ID != inst.ID || restored.GroupPath != "work" || restored.ClaudeSessionID != "abc-123" || restored.PollInterval != 5*time.Second {
+		t.Errorf("restored instance lost fields: %+v", restored)
+	}
+	if restored.Status != StatusError {
+		t.Errorf("restored Status = %s, want %s until restarted", restored.Status, StatusError)
+	}
+
+	if err := s.DeleteArchived("arch-1"); err != nil {
+		t.Fatalf("DeleteArchived: %v", err)
+	}
+	if _, err := s.GetArchived("arch-1"); !errors.Is(err, ErrArchivedNotFound) {
+		t.Errorf("GetArchived after delete: err = %v, want ErrArchivedNotFound", err)
+	}
+}
@@ -0,0 +1,89 @@
+package session
+
+import "time"
+
+// Auto-restart backoff: the first restart happens autoRestartBaseDelay after
+// the crash is seen, each following one waits twice as long, up to
+// autoRestartMaxDelay.
+const (
+	autoRestartBaseDelay = 5 * time.Second
+	autoRestartMaxDelay  = 5 * time.Minute
+
+	// autoRestartResetAfter is how long a session must run without crashing
+	// after an automatic restart before its restart count starts over.
+	autoRestartResetAfter = 10 * time.Minute
+)
+
+// autoRestartDelay is how long to wait before restart number attempt+1.
+func autoRestartDelay(attempt int) time.Duration {
+	d := autoRestartBaseDelay
+	for n := 0; n < attempt && d < autoRestartMaxDelay; n++ {
+		d *= 2
+	}
+	return min(d, autoRestartMaxDelay)
+}
+
+// markCrashedLocked records that a session that was up has just gone into
+// StatusError because its tmux session or agent process is gone. Call it
+// before setting Status; sessions already in error (killed, or dead since
+// before the TUI started) are not counted as crashes. Caller holds mu.
+func (i *Instance) markCrashedLocked() {
+	if i.Status != StatusError {
+		i.crashedAt = time.Now()
+	}
+}
+
+// TakeAutoRestart reports whether the session crashed and is due for an
+// automatic restart under its AutoRestartMax policy, and if so records the
+// attempt and returns its number. The caller restarts the session and then
+// calls FinishAutoRestart.
+func (i *Instance) TakeAutoRestart() (int, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.AutoRestartMax <= 0 || i.autoRestarting {
+		return 0, false
+	}
+	now := time.Now()
+	if i.Status != StatusError || i.crashedAt.IsZero() {
+		if i.autoRestarts > 0 && i.Status != StatusError && now.Sub(i.lastAutoRestart) >= autoRestartResetAfter {
+			i.autoRestarts = 0
+		}
+		return 0, false
+	}
+	if i.autoRestarts >= i.AutoRestartMax || now.Sub(i.crashedAt) < autoRestartDelay(i.autoRestarts) {
+		return 0, false
+	}
+	i.autoRestarts++
+	i.lastAutoRestart = now
+	i.crashedAt = time.Time{}
+	i.autoRestarting = true
+	return i.autoRestarts, true
+}
+
+// FinishAutoRestart ends an attempt started by TakeAutoRestart. A failed
+// restart counts as a new crash, so the next attempt backs off from now.
+func (i *Instance) FinishAutoRestart(err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.autoRestarting = false
+	if err != nil {
+		i.crashedAt = time.Now()
+	}
+}
+
+// AutoRestartCount returns how many times the session was restarted
+// automatically since it last ran steadily.
+func (i *Instance) AutoRestartCount() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.autoRestarts
+}
+
+// AutoRestartExhausted reports whether the session is down after using up
+// all its automatic restarts.
+func (i *Instance) AutoRestartExhausted() bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.AutoRestartMax > 0 && i.autoRestarts >= i.AutoRestartMax &&
+		i.Status == StatusError && !i.crashedAt.IsZero()
+}
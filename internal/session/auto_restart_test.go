@@ -0,0 +1,95 @@
+package session
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoRestartDelay(t *testing.T) {
+	assert.Equal(t, 5*time.Second, autoRestartDelay(0))
+	assert.Equal(t, 10*time.Second, autoRestartDelay(1))
+	assert.Equal(t, 40*time.Second, autoRestartDelay(3))
+	assert.Equal(t, 5*time.Minute, autoRestartDelay(10), "capped")
+}
+
+func TestTakeAutoRestart(t *testing.T) {
+	inst := &Instance{Status: StatusRunning, AutoRestartMax: 2}
+
+	_, ok := inst.TakeAutoRestart()
+	assert.False(t, ok, "a running session is left alone")
+
+	inst.markCrashedLocked()
+	inst.Status = StatusError
+	_, ok = inst.TakeAutoRestart()
+	assert.False(t, ok, "waits out the backoff")
+
+	inst.crashedAt = time.Now().Add(-autoRestartDelay(0))
+	n, ok := inst.TakeAutoRestart()
+	assert.True(t, ok)
+	assert.Equal(t, 1, n)
+	_, ok = inst.TakeAutoRestart()
+	assert.False(t, ok, "one restart at a time")
+
+	// The restart fails: back off from now, twice as long
+	inst.FinishAutoRestart(errors.New("tmux gone"))
+	inst.crashedAt = time.Now().Add(-autoRestartDelay(0))
+	_, ok = inst.TakeAutoRestart()
+	assert.False(t, ok)
+	inst.crashedAt = time.Now().Add(-autoRestartDelay(1))
+	n, ok = inst.TakeAutoRestart()
+	assert.True(t, ok)
+	assert.Equal(t, 2, n)
+	inst.FinishAutoRestart(nil)
+
+	// It crashes again with no restarts left
+	inst.Status = StatusWaiting
+	inst.markCrashedLocked()
+	inst.Status = StatusError
+	inst.crashedAt = time.Now().Add(-time.Hour)
+	_, ok = inst.TakeAutoRestart()
+	assert.False(t, ok)
+	assert.True(t, inst.AutoRestartExhausted())
+	assert.Equal(t, 2, inst.AutoRestartCount())
+}
+
+func TestTakeAutoRestartSkipsNonCrashes(t *testing.T) {
+	inst := &Instance{Status: StatusError, AutoRestartMax: 3}
+	inst.markCrashedLocked()
+	assert.True(t, inst.crashedAt.IsZero(), "already down (killed, or dead before the TUI started)")
+
+	inst.Status = StatusRunning
+	inst.AutoRestartMax = 0
+	inst.markCrashedLocked()
+	inst.Status = StatusError
+	inst.crashedAt = time.Now().Add(-time.Hour)
+	_, ok := inst.TakeAutoRestart()
+	assert.False(t, ok, "policy off")
+}
+
+func TestAutoRestartCountResets(t *testing.T) {
+	inst := &Instance{Status: StatusRunning, AutoRestartMax: 3, autoRestarts: 2}
+
+	inst.lastAutoRestart = time.Now().Add(-time.Minute)
+	inst.TakeAutoRestart()
+	assert.Equal(t, 2, inst.AutoRestartCount(), "not steady for long enough")
+
+	inst.lastAutoRestart = time.Now().Add(-autoRestartResetAfter)
+	inst.TakeAutoRestart()
+	assert.Equal(t, 0, inst.AutoRestartCount())
+}
+
+func TestAutoRestartMaxPersists(t *testing.T) {
+	s := newTestStorage(t)
+	inst := &Instance{ID: "r", Title: "r", Tool: "claude", CreatedAt: time.Now(), AutoRestartMax: 3}
+	if err := s.SaveWithGroups([]*Instance{inst}, nil); err != nil {
+		t.Fatal(err)
+	}
+	loaded, _, err := s.LoadLite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, loaded[0].AutoRestartMax)
+}
@@ -0,0 +1,232 @@
+package session
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
+)
+
+const (
+	backupPrefix     = "state-"
+	backupSuffix     = ".db"
+	backupTimeFormat = "20060102-150405"
+)
+
+// BackupInfo describes one session state backup
+type BackupInfo struct {
+	Path string    `json:"path"`
+	Time time.Time `json:"time"`
+	Size int64     `json:"size"`
+}
+
+// GetBackupDir returns the backup directory for a profile
+// (~/.agent-deck/backups/<profile>).
+func GetBackupDir(profile string) (string, error) {
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	profile = filepath.Base(profile)
+	if profile == "." || profile == ".." {
+		return "", fmt.Errorf("invalid profile name: %s", profile)
+	}
+	deckDir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(deckDir, "backups", profile), nil
+}
+
+// ListBackups returns a profile's backups, newest first.
+func ListBackups(profile string) ([]BackupInfo, error) {
+	dir, err := GetBackupDir(profile)
+	if err != nil {
+		return nil, err
+	}
+	return listBackupsIn(dir)
+}
+
+func listBackupsIn(dir string) ([]BackupInfo, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, backupPrefix+"*"+backupSuffix))
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]BackupInfo, 0, len(matches))
+	for _, path := range matches {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		// Prefer the timestamp in the name; mtime changes if the file is copied
+		stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), backupPrefix), backupSuffix)
+		t, err := time.ParseInLocation(backupTimeFormat, stamp[:min(len(stamp), len(backupTimeFormat))], time.Local)
+		if err != nil {
+			t = info.ModTime()
+		}
+		backups = append(backups, BackupInfo{Path: path, Time: t, Size: info.Size()})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].Time.Equal(backups[j].Time) {
+			// Same second: "-N" suffixed names were written later
+			if len(backups[i].Path) != len(backups[j].Path) {
+				return len(backups[i].Path) > len(backups[j].Path)
+			}
+			return backups[i].Path > backups[j].Path
+		}
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
+
+// Backup writes a snapshot of the current session state to the profile's
+// backup directory and prunes old backups. Returns the backup path.
+func (s *Storage) Backup() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.backupLocked()
+}
+
+// backupIfDueLocked takes a backup before a save when backups are enabled
+// and the last one is older than the configured interval. Failures are
+// logged, never returned: a backup problem must not block saving.
+// MUST be called with mu held.
+func (s *Storage) backupIfDueLocked() {
+	settings := GetBackupSettings()
+	if !settings.GetEnabled() || s.db == nil {
+		return
+	}
+	interval := settings.GetMinInterval()
+	if s.lastBackup.IsZero() {
+		if dir, err := GetBackupDir(s.profile); err == nil {
+			if backups, err := listBackupsIn(dir); err == nil && len(backups) > 0 {
+				s.lastBackup = backups[0].Time
+			}
+		}
+	}
+	if !s.lastBackup.IsZero() && time.Since(s.lastBackup) < interval {
+		return
+	}
+	if empty, err := s.db.IsEmpty(); err != nil || empty {
+		return // nothing worth keeping yet
+	}
+	// Record the attempt even on failure so a broken backup dir doesn't add
+	// a failing VACUUM to every save.
+	s.lastBackup = time.Now()
+	if _, err := s.backupLocked(); err != nil {
+		storageLog.Warn("backup_failed", slog.String("profile", s.profile), slog.String("error", err.Error()))
+	}
+}
+
+// backupLocked writes a backup and prunes old ones.
+// MUST be called with mu held.
+func (s *Storage) backupLocked() (string, error) {
+	if s.db == nil {
+		return "", fmt.Errorf("storage database not initialized")
+	}
+	dir, err := GetBackupDir(s.profile)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", fmt.Errorf("failed to create backup directory: %w", err)
+	}
+
+	stamp := time.Now().Format(backupTimeFormat)
+	path := filepath.Join(dir, backupPrefix+stamp+backupSuffix)
+	for n := 1; fileExists(path); n++ {
+		path = filepath.Join(dir, fmt.Sprintf("%s%s-%d%s", backupPrefix, stamp, n, backupSuffix))
+	}
+
+	// Snapshot to a temp name so a crash never leaves a partial backup that
+	// looks restorable.
+	tmp := path + ".tmp"
+	_ = os.Remove(tmp)
+	if err := s.db.BackupTo(tmp); err != nil {
+		_ = os.Remove(tmp)
+		return "", err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return "", fmt.Errorf("failed to finalize backup: %w", err)
+	}
+
+	pruneBackups(dir, GetBackupSettings().GetKeep())
+	return path, nil
+}
+
+// pruneBackups deletes all but the newest keep backups in dir.
+func pruneBackups(dir string, keep int) int {
+	backups, err := listBackupsIn(dir)
+	if err != nil || len(backups) <= keep {
+		return 0
+	}
+	pruned := 0
+	for _, b := range backups[keep:] {
+		if err := os.Remove(b.Path); err != nil {
+			storageLog.Warn("backup_prune_failed", slog.String("path", b.Path), slog.String("error", err.Error()))
+			continue
+		}
+		pruned++
+	}
+	return pruned
+}
+
+// RestoreBackup replaces all sessions and groups with the contents of a
+// backup. The current state is backed up first so the restore can itself be
+// undone; that backup's path is returned. The backup file is left as it is:
+// a copy of it is read.
+func (s *Storage) RestoreBackup(path string) (string, error) {
+	if !fileExists(path) {
+		return "", fmt.Errorf("backup not found: %s", path)
+	}
+
+	// Migrating an older backup rewrites it, so work on a copy
+	tmpDir, err := os.MkdirTemp("", "agentdeck-restore-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpDir)
+	copyPath := filepath.Join(tmpDir, filepath.Base(path))
+	if err := copyFile(path, copyPath); err != nil {
+		return "", fmt.Errorf("failed to copy backup: %w", err)
+	}
+
+	src, err := statedb.Open(copyPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer src.Close()
+	// Upgrades backups taken by older versions; refuses ones from newer versions
+	if err := src.Migrate(); err != nil {
+		return "", fmt.Errorf("failed to read backup: %w", err)
+	}
+	rows, err := src.LoadInstances()
+	if err != nil {
+		return "", fmt.Errorf("failed to read backup sessions: %w", err)
+	}
+	groups, err := src.LoadGroups()
+	if err != nil {
+		return "", fmt.Errorf("failed to read backup groups: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, err := s.backupLocked()
+	if err != nil {
+		return "", fmt.Errorf("failed to back up current state before restoring: %w", err)
+	}
+	if err := s.db.ReplaceState(rows, groups); err != nil {
+		return previous, fmt.Errorf("failed to restore backup: %w", err)
+	}
+	s.setKnownIDs(rowIDs(rows))
+
+	// Touch metadata so a running TUI reloads the restored state
+	_ = s.db.Touch()
+	return previous, nil
+}
@@ -0,0 +1,99 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStorageBackupAndRestore(t *testing.T) {
+	t.Setenv("AGENTDECK_HOME", t.TempDir())
+	s := newTestStorage(t)
+
+	original := []*Instance{
+		{ID: "a", Title: "Alpha", ProjectPath: "/tmp/a", GroupPath: "work", Tool: "shell", CreatedAt: time.Now()},
+		{ID: "b", Title: "Beta", ProjectPath: "/tmp/b", GroupPath: "work", Tool: "shell", CreatedAt: time.Now()},
+	}
+	if err := s.SaveWithGroups(original, NewGroupTree(original)); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+	backup, err := s.Backup()
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+
+	// Lose a session and the group layout
+	if err := s.SaveWithGroups(original[:1], NewGroupTree(nil)); err != nil {
+		t.Fatalf("SaveWithGroups: %v", err)
+	}
+
+	before, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	previous, err := s.RestoreBackup(backup)
+	if err != nil {
+		t.Fatalf("RestoreBackup: %v", err)
+	}
+	if after, err := os.ReadFile(backup); err != nil || string(after) != string(before) {
+		t.Errorf("restoring should leave the backup file untouched (err %v)", err)
+	}
+	if previous == "" || previous == backup {
+		t.Errorf("expected a new backup of the pre-restore state, got %q", previous)
+	}
+
+	instances, groups, err := s.LoadLite()
+	if err != nil {
+		t.Fatalf("LoadLite: %v", err)
+	}
+	if len(instances) != 2 {
+		t.Errorf("restored %d sessions, want 2", len(instances))
+	}
+	foundWork := false
+	for _, g := range groups {
+		if g.Path == "work" {
+			foundWork = true
+		}
+	}
+	if !foundWork {
+		t.Errorf("group layout not restored: %+v", groups)
+	}
+
+	backups, err := ListBackups(s.profile)
+	if err != nil {
+		t.Fatalf("ListBackups: %v", err)
+	}
+	if len(backups) < 2 || backups[0].Path != previous {
+		t.Errorf("expected newest backup to be the pre-restore snapshot, got %+v", backups)
+	}
+}
+
+func TestPruneBackups(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{
+		"state-20260101-100000.db",
+		"state-20260102-100000.db",
+		"state-20260103-100000.db",
+		"state-20260103-100000-1.db",
+		"unrelated.txt",
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("x"), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if pruned := pruneBackups(dir, 2); pruned != 2 {
+		t.Errorf("pruned %d, want 2", pruned)
+	}
+	backups, _ := listBackupsIn(dir)
+	if len(backups) != 2 ||
+		filepath.Base(backups[0].Path) != "state-20260103-100000-1.db" ||
+		filepath.Base(backups[1].Path) != "state-20260103-100000.db" {
+		t.Errorf("kept wrong backups: %+v", backups)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "unrelated.txt")); err != nil {
+		t.Error("pruning removed a non-backup file")
+	}
+}
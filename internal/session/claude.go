@@ -351,9 +351,6 @@ func findActiveSessionIDExcluding(configDir, projectPath string, excludeIDs map[
 		return ""
 	}
 
-	// UUID pattern for session files
-	uuidPattern := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\.jsonl$`)
-
 	var mostRecent string
 	var mostRecentTime time.Time
 
@@ -366,7 +363,7 @@ func findActiveSessionIDExcluding(configDir, projectPath string, excludeIDs map[
 		}
 
 		// Only consider UUID-named files
-		if !uuidPattern.MatchString(base) {
+		if !isUUIDFileName(base) {
 			continue
 		}
 
@@ -397,6 +394,55 @@ func findActiveSessionIDExcluding(configDir, projectPath string, excludeIDs map[
 	return ""
 }
 
+// ClaudeConversation is a conversation Claude has stored for a project.
+type ClaudeConversation struct {
+	SessionID string
+	Summary   string    // Claude's summary, or the first user message
+	ModTime   time.Time // Last write to the transcript
+	FileSize  int64
+}
+
+// ListClaudeConversations returns the conversations stored for projectPath,
+// newest first. Only the head of each transcript is read. Transcripts with no
+// user message (aborted starts) are left out.
+func ListClaudeConversations(projectPath string) ([]ClaudeConversation, error) {
+	resolved := projectPath
+	if r, err := filepath.EvalSymlinks(projectPath); err == nil {
+		resolved = r
+	}
+	dir := filepath.Join(GetClaudeConfigDir(), "projects", ConvertToClaudeDirName(resolved))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read Claude transcripts: %w", err)
+	}
+
+	var convs []ClaudeConversation
+	for _, entry := range entries {
+		if entry.IsDir() || !isUUIDFileName(entry.Name()) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		head, err := parseClaudeJSONLHead(filepath.Join(dir, entry.Name()))
+		if err != nil || head.Summary == "" {
+			continue
+		}
+		convs = append(convs, ClaudeConversation{
+			SessionID: strings.TrimSuffix(entry.Name(), ".jsonl"),
+			Summary:   strings.Join(strings.Fields(head.Summary), " "),
+			ModTime:   info.ModTime(),
+			FileSize:  info.Size(),
+		})
+	}
+	sort.Slice(convs, func(a, b int) bool { return convs[a].ModTime.After(convs[b].ModTime) })
+	return convs, nil
+}
+
 // getProjectSettingsPath returns the path to .claude/settings.local.json for a project
 func getProjectSettingsPath(projectPath string) string {
 	return filepath.Join(projectPath, ".claude", "settings.local.json")
@@ -552,6 +552,11 @@ func TestFindActiveSessionIDExcluding(t *testing.T) {
 	if err := os.WriteFile(filepath.Join(projectDir, sessionB+".jsonl"), []byte("{}"), 0644); err != nil {
 		t.Fatal(err)
 	}
+	// Back-to-back writes can share an mtime; make A clearly older
+	older := time.Now().Add(-30 * time.Second)
+	if err := os.Chtimes(filepath.Join(projectDir, sessionA+".jsonl"), older, older); err != nil {
+		t.Fatal(err)
+	}
 
 	t.Run("no exclude returns most recent", func(t *testing.T) {
 		got := findActiveSessionIDExcluding(configDir, projectPath, nil)
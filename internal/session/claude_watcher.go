@@ -0,0 +1,179 @@
+package session
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// ClaudeTranscriptWatcher watches Claude's per-project transcript directories
+// (~/.claude/projects/<encoded-path>/) and remembers when each session file
+// was last written. The TUI uses it to keep ClaudeSessionID current when
+// Claude switches to a new session file (/clear, /resume) and to confirm the
+// transcript exists, so CanFork doesn't go stale while a session sits idle.
+type ClaudeTranscriptWatcher struct {
+	projectsDir string
+	watcher     *fsnotify.Watcher
+
+	mu     sync.RWMutex
+	dirs   map[string]string               // project path -> transcript dir (added once it exists)
+	writes map[string]map[string]time.Time // transcript dir -> session ID -> last write
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewClaudeTranscriptWatcher creates a watcher for the current Claude config
+// dir. Call Start() to begin watching and Watch() to add projects.
+func NewClaudeTranscriptWatcher() (*ClaudeTranscriptWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ClaudeTranscriptWatcher{
+		projectsDir: filepath.Join(GetClaudeConfigDir(), "projects"),
+		watcher:     watcher,
+		dirs:        make(map[string]string),
+		writes:      make(map[string]map[string]time.Time),
+		ctx:         ctx,
+		cancel:      cancel,
+	}, nil
+}
+
+// Start processes file events until Stop. Must be called in a goroutine.
+func (w *ClaudeTranscriptWatcher) Start() {
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Write) == 0 {
+				continue
+			}
+			if id := transcriptSessionID(event.Name); id != "" {
+				w.record(filepath.Dir(event.Name), id, time.Now())
+			}
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			sessionLog.Warn("claude_transcript_watcher_error", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// Stop shuts down the watcher.
+func (w *ClaudeTranscriptWatcher) Stop() {
+	w.cancel()
+	_ = w.watcher.Close()
+}
+
+// Watch starts watching the transcript dir for projectPath. Claude creates
+// the dir on its first write, so until then every call checks again; after
+// that it is a map lookup.
+func (w *ClaudeTranscriptWatcher) Watch(projectPath string) {
+	w.mu.RLock()
+	_, ok := w.dirs[projectPath]
+	w.mu.RUnlock()
+	if ok {
+		return
+	}
+
+	dir := w.transcriptDir(projectPath)
+	if _, err := os.Stat(dir); err != nil {
+		return
+	}
+	if err := w.watcher.Add(dir); err != nil {
+		sessionLog.Warn("claude_transcript_watch_failed", slog.String("dir", dir), slog.String("error", err.Error()))
+		return
+	}
+	w.mu.Lock()
+	w.dirs[projectPath] = dir
+	w.mu.Unlock()
+
+	// Files written before the watch started
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	for _, entry := range entries {
+		id := transcriptSessionID(entry.Name())
+		if id == "" {
+			continue
+		}
+		if info, err := entry.Info(); err == nil {
+			w.record(dir, id, info.ModTime())
+		}
+	}
+}
+
+// Latest returns the most recently written session in projectPath's
+// transcript dir, skipping IDs for which skip returns true, and when it was
+// written. "" if there is none or the dir isn't watched yet.
+func (w *ClaudeTranscriptWatcher) Latest(projectPath string, skip func(id string) bool) (string, time.Time) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	var latest string
+	var latestAt time.Time
+	for id, at := range w.writes[w.dirs[projectPath]] {
+		if skip != nil && skip(id) {
+			continue
+		}
+		if at.After(latestAt) || (at.Equal(latestAt) && id > latest) {
+			latest, latestAt = id, at
+		}
+	}
+	return latest, latestAt
+}
+
+// Seen reports whether sessionID's transcript exists in projectPath's
+// transcript dir.
+func (w *ClaudeTranscriptWatcher) Seen(projectPath, sessionID string) bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	_, ok := w.writes[w.dirs[projectPath]][sessionID]
+	return ok
+}
+
+func (w *ClaudeTranscriptWatcher) record(dir, sessionID string, at time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.writes[dir] == nil {
+		w.writes[dir] = make(map[string]time.Time)
+	}
+	if at.After(w.writes[dir][sessionID]) {
+		w.writes[dir][sessionID] = at
+	}
+}
+
+// transcriptDir mirrors GetJSONLPath: symlinks in the project path are
+// resolved before encoding (macOS: /tmp -> /private/tmp).
+func (w *ClaudeTranscriptWatcher) transcriptDir(projectPath string) string {
+	resolved := projectPath
+	if r, err := filepath.EvalSymlinks(projectPath); err == nil {
+		resolved = r
+	}
+	return filepath.Join(w.projectsDir, ConvertToClaudeDirName(resolved))
+}
+
+// transcriptSessionID returns the session ID of a Claude transcript file
+// name, or "" for anything else (agent-*.jsonl sidechains, other files).
+func transcriptSessionID(path string) string {
+	base := filepath.Base(path)
+	if !isUUIDFileName(base) {
+		return ""
+	}
+	return strings.TrimSuffix(base, ".jsonl")
+}
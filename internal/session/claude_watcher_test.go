@@ -0,0 +1,137 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestTranscriptDir points CLAUDE_CONFIG_DIR at a temp dir and returns a
+// project path with its (existing) transcript dir.
+func newTestTranscriptDir(t *testing.T) (projectPath, transcriptDir string) {
+	t.Helper()
+	t.Setenv("CLAUDE_CONFIG_DIR", t.TempDir())
+	projectPath = t.TempDir()
+	resolved, err := filepath.EvalSymlinks(projectPath)
+	require.NoError(t, err)
+	transcriptDir = filepath.Join(GetClaudeConfigDir(), "projects", ConvertToClaudeDirName(resolved))
+	require.NoError(t, os.MkdirAll(transcriptDir, 0755))
+	return projectPath, transcriptDir
+}
+
+func writeTranscript(t *testing.T, dir, id string, at time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, id+".jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"sessionId":"`+id+`","type":"user"}`+"\n"), 0644))
+	require.NoError(t, os.Chtimes(path, at, at))
+}
+
+func TestClaudeTranscriptWatcher_LatestAndSeen(t *testing.T) {
+	projectPath, dir := newTestTranscriptDir(t)
+	idA := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	idB := "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"
+	writeTranscript(t, dir, idA, time.Now().Add(-time.Minute))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "agent-1234.jsonl"), []byte("{}"), 0644))
+
+	w, err := NewClaudeTranscriptWatcher()
+	require.NoError(t, err)
+	go w.Start()
+	defer w.Stop()
+
+	w.Watch(projectPath)
+	latest, _ := w.Latest(projectPath, nil)
+	assert.Equal(t, idA, latest, "existing files are picked up on Watch")
+	assert.True(t, w.Seen(projectPath, idA))
+	assert.False(t, w.Seen(projectPath, "agent-1234"))
+
+	// A file written after the watch started arrives as an event
+	writeTranscript(t, dir, idB, time.Now())
+	require.Eventually(t, func() bool {
+		latest, _ := w.Latest(projectPath, nil)
+		return latest == idB
+	}, 2*time.Second, 10*time.Millisecond)
+
+	latest, _ = w.Latest(projectPath, func(id string) bool { return id == idB })
+	assert.Equal(t, idA, latest, "skipped IDs are ignored")
+
+	latest, _ = w.Latest("/not/watched", nil)
+	assert.Empty(t, latest)
+}
+
+func TestSyncClaudeSessionFromWatcher(t *testing.T) {
+	projectPath, dir := newTestTranscriptDir(t)
+	oldID := "11111111-1111-1111-1111-111111111111"
+	newID := "22222222-2222-2222-2222-222222222222"
+	otherID := "33333333-3333-3333-3333-333333333333"
+	writeTranscript(t, dir, oldID, time.Now().Add(-time.Hour))
+
+	w, err := NewClaudeTranscriptWatcher()
+	require.NoError(t, err)
+	defer w.Stop()
+
+	inst := NewInstanceWithTool("watched", projectPath, "claude")
+	inst.ClaudeSessionID = oldID
+	inst.ClaudeDetectedAt = time.Now().Add(-time.Hour)
+	inst.Status = StatusIdle
+	require.False(t, inst.CanFork(), "stale detection and no transcript confirmed yet")
+
+	inst.SyncClaudeSessionFromWatcher(w, nil)
+	assert.Equal(t, oldID, inst.ClaudeSessionID)
+	assert.True(t, inst.CanFork(), "a transcript on disk keeps the ID forkable")
+
+	// /clear: Claude writes a new file while the session is active. Another
+	// session's newer file is not taken
+	writeTranscript(t, dir, newID, time.Now().Add(-time.Second))
+	writeTranscript(t, dir, otherID, time.Now())
+	// The watcher isn't started: record the writes it would have seen
+	w.record(dir, newID, time.Now().Add(-time.Second))
+	w.record(dir, otherID, time.Now())
+	skip := func(id string) bool { return id == otherID }
+
+	inst.SyncClaudeSessionFromWatcher(w, skip)
+	assert.Equal(t, oldID, inst.ClaudeSessionID, "idle sessions don't switch")
+
+	inst.Status = StatusRunning
+	inst.SyncClaudeSessionFromWatcher(w, skip)
+	assert.Equal(t, newID, inst.ClaudeSessionID)
+	assert.True(t, inst.CanFork())
+
+	// Fresh hook data is authoritative: no switching from the watcher
+	inst.ClaudeSessionID = oldID
+	inst.hookStatus = "running"
+	inst.hookLastUpdate = time.Now()
+	inst.SyncClaudeSessionFromWatcher(w, skip)
+	assert.Equal(t, oldID, inst.ClaudeSessionID)
+}
+
+func TestListClaudeConversations(t *testing.T) {
+	projectPath, dir := newTestTranscriptDir(t)
+	write := func(name, body string, at time.Time) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(body), 0644))
+		require.NoError(t, os.Chtimes(path, at, at))
+	}
+	idOld := "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+	idNew := "bbbbbbbb-bbbb-bbbb-bbbb-bbbbbbbbbbbb"
+	idEmpty := "cccccccc-cccc-cccc-cccc-cccccccccccc"
+	now := time.Now()
+	write(idOld+".jsonl", `{"type":"user","message":{"role":"user","content":"fix the\n  login bug"}}`+"\n", now.Add(-time.Hour))
+	write(idNew+".jsonl", `{"type":"summary","summary":"Refactor storage"}`+"\n", now)
+	write(idEmpty+".jsonl", `{"type":"system"}`+"\n", now)
+	write("agent-1234.jsonl", `{"type":"summary","summary":"sidechain"}`+"\n", now)
+
+	convs, err := ListClaudeConversations(projectPath)
+	require.NoError(t, err)
+	require.Len(t, convs, 2, "empty and agent transcripts are skipped")
+	assert.Equal(t, idNew, convs[0].SessionID, "newest first")
+	assert.Equal(t, "Refactor storage", convs[0].Summary)
+	assert.Equal(t, "fix the login bug", convs[1].Summary, "whitespace is collapsed")
+
+	convs, err = ListClaudeConversations(t.TempDir())
+	assert.NoError(t, err)
+	assert.Empty(t, convs, "a project with no transcript dir has no conversations")
+}
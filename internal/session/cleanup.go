@@ -0,0 +1,54 @@
+package session
+
+import (
+	"sort"
+	"time"
+)
+
+// IdleSince returns when the session last printed output or was attached,
+// whichever is later. Output times come from tmux's window_activity, so they
+// survive TUI restarts; without one the session counts from its creation.
+func (i *Instance) IdleSince() time.Time {
+	since := i.CreatedAt
+	if i.LastAccessedAt.After(since) {
+		since = i.LastAccessedAt
+	}
+	if tmuxSess := i.GetTmuxSession(); tmuxSess != nil {
+		if ts := tmuxSess.GetCachedWindowActivity(); ts > 0 {
+			if activity := time.Unix(ts, 0); activity.After(since) {
+				since = activity
+			}
+		}
+	}
+	return since
+}
+
+// IdleCleanupCandidates returns the sessions that have been idle
+// (acknowledged, with no new output) for at least idleFor, longest idle
+// first. idleFor <= 0 returns nothing.
+func IdleCleanupCandidates(instances []*Instance, idleFor time.Duration, now time.Time) []*Instance {
+	if idleFor <= 0 {
+		return nil
+	}
+	type candidate struct {
+		inst  *Instance
+		since time.Time
+	}
+	var found []candidate
+	for _, inst := range instances {
+		if inst.GetStatusThreadSafe() != StatusIdle {
+			continue
+		}
+		if since := inst.IdleSince(); now.Sub(since) >= idleFor {
+			found = append(found, candidate{inst, since})
+		}
+	}
+	sort.SliceStable(found, func(a, b int) bool {
+		return found[a].since.Before(found[b].since)
+	})
+	out := make([]*Instance, len(found))
+	for n, c := range found {
+		out[n] = c.inst
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdleCleanupCandidates(t *testing.T) {
+	now := time.Now()
+	instances := []*Instance{
+		{ID: "recent", Status: StatusIdle, CreatedAt: now.Add(-time.Hour)},
+		{ID: "old", Status: StatusIdle, CreatedAt: now.Add(-48 * time.Hour)},
+		{ID: "oldest", Status: StatusIdle, CreatedAt: now.Add(-96 * time.Hour)},
+		{ID: "attached", Status: StatusIdle, CreatedAt: now.Add(-96 * time.Hour), LastAccessedAt: now.Add(-time.Hour)},
+		{ID: "waiting", Status: StatusWaiting, CreatedAt: now.Add(-96 * time.Hour)},
+		{ID: "dead", Status: StatusError, CreatedAt: now.Add(-96 * time.Hour)},
+	}
+
+	var ids []string
+	for _, inst := range IdleCleanupCandidates(instances, 24*time.Hour, now) {
+		ids = append(ids, inst.ID)
+	}
+	assert.Equal(t, []string{"oldest", "old"}, ids, "idle and quiet for a day, longest idle first")
+
+	assert.Empty(t, IdleCleanupCandidates(instances, 0, now), "policy off")
+}
+
+func TestCleanupSettings(t *testing.T) {
+	assert.Equal(t, time.Duration(0), CleanupSettings{}.IdleAfter())
+	assert.Equal(t, 72*time.Hour, CleanupSettings{IdleHours: 72}.IdleAfter())
+	assert.Equal(t, "archive", CleanupSettings{}.GetAction())
+	assert.Equal(t, "kill", CleanupSettings{Action: "Kill"}.GetAction())
+	assert.Equal(t, "archive", CleanupSettings{Action: "delete"}.GetAction(), "unknown actions fall back to archive")
+}
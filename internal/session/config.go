@@ -31,13 +31,67 @@ type Config struct {
 	Version int `json:"version"`
 }
 
-// GetAgentDeckDir returns the base agent-deck directory (~/.agent-deck)
+// legacyDataMarkers are the entries that make ~/.agent-deck an existing
+// install. Hooks, logs and the like don't count: they are recreated on
+// every run and must not pull an XDG install back to ~/.agent-deck.
+var legacyDataMarkers = []string{ProfilesDirName, ConfigFileName, UserConfigFileName}
+
+// GetAgentDeckDir returns the base agent-deck directory for profiles,
+// backups, hooks and logs. In order of precedence:
+//   - $AGENTDECK_HOME (also set by the --storage global flag)
+//   - ~/.agent-deck, if it holds an existing install (existing installs don't move)
+//   - $XDG_DATA_HOME/agent-deck, if XDG_DATA_HOME is set
+//   - ~/.agent-deck
 func GetAgentDeckDir() (string, error) {
+	if dir := os.Getenv("AGENTDECK_HOME"); dir != "" {
+		return dir, nil
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		return "", fmt.Errorf("failed to get home directory: %w", err)
 	}
-	return filepath.Join(homeDir, ".agent-deck"), nil
+	legacy := filepath.Join(homeDir, ".agent-deck")
+	for _, marker := range legacyDataMarkers {
+		if _, err := os.Stat(filepath.Join(legacy, marker)); err == nil {
+			return legacy, nil
+		}
+	}
+	// The XDG spec requires absolute paths; relative values are ignored
+	if xdg := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(xdg) {
+		return filepath.Join(xdg, "agent-deck"), nil
+	}
+	return legacy, nil
+}
+
+// agentDeckSubdir returns a directory under GetAgentDeckDir, or under the
+// temp directory when that can't be determined.
+func agentDeckSubdir(elem ...string) string {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		dir = filepath.Join(os.TempDir(), ".agent-deck")
+	}
+	return filepath.Join(append([]string{dir}, elem...)...)
+}
+
+// GetConfigDir returns the directory holding config.toml: $XDG_CONFIG_HOME/agent-deck
+// when XDG_CONFIG_HOME is set and neither AGENTDECK_HOME nor an existing
+// ~/.agent-deck/config.toml takes precedence, otherwise GetAgentDeckDir.
+func GetConfigDir() (string, error) {
+	dir, err := GetAgentDeckDir()
+	if err != nil {
+		return "", err
+	}
+	if os.Getenv("AGENTDECK_HOME") != "" {
+		return dir, nil
+	}
+	xdg := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(xdg) {
+		return dir, nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, UserConfigFileName)); err == nil {
+		return dir, nil
+	}
+	return filepath.Join(xdg, "agent-deck"), nil
 }
 
 // GetConfigPath returns the path to the global config file
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetAgentDeckDirAndConfigDir(t *testing.T) {
+	home := t.TempDir()
+	xdgData := filepath.Join(home, "xdg-data")
+	xdgConfig := filepath.Join(home, "xdg-config")
+	legacy := filepath.Join(home, ".agent-deck")
+
+	check := func(name, wantData, wantConfig string) {
+		t.Helper()
+		if got, err := GetAgentDeckDir(); err != nil || got != wantData {
+			t.Errorf("%s: GetAgentDeckDir = %q, %v; want %q", name, got, err, wantData)
+		}
+		if got, err := GetConfigDir(); err != nil || got != wantConfig {
+			t.Errorf("%s: GetConfigDir = %q, %v; want %q", name, got, err, wantConfig)
+		}
+	}
+
+	t.Setenv("HOME", home)
+	t.Setenv("AGENTDECK_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	check("defaults", legacy, legacy)
+
+	t.Setenv("XDG_DATA_HOME", "relative/data")
+	check("relative XDG path ignored", legacy, legacy)
+
+	t.Setenv("XDG_DATA_HOME", xdgData)
+	t.Setenv("XDG_CONFIG_HOME", xdgConfig)
+	check("XDG for new installs", filepath.Join(xdgData, "agent-deck"), filepath.Join(xdgConfig, "agent-deck"))
+
+	// Directories every run recreates don't make an install
+	if err := os.MkdirAll(filepath.Join(legacy, "hooks"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	check("stray ~/.agent-deck/hooks ignored", filepath.Join(xdgData, "agent-deck"), filepath.Join(xdgConfig, "agent-deck"))
+
+	if err := os.MkdirAll(filepath.Join(legacy, ProfilesDirName), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	check("existing ~/.agent-deck keeps state, config follows XDG", legacy, filepath.Join(xdgConfig, "agent-deck"))
+
+	if err := os.WriteFile(filepath.Join(legacy, UserConfigFileName), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	check("existing config.toml stays put", legacy, legacy)
+
+	custom := filepath.Join(home, "custom")
+	t.Setenv("AGENTDECK_HOME", custom)
+	check("AGENTDECK_HOME overrides everything", custom, custom)
+}
@@ -37,15 +37,13 @@ func DiscoverExistingTmuxSessions(existingInstances []*Instance) ([]*Instance, e
 		title := sess.DisplayName
 		groupPath := ""
 		isOrphaned := false
-		if strings.HasPrefix(sess.Name, tmux.SessionPrefix) {
-			isOrphaned = true
-			// Extract title from session name: agentdeck_<title>_<8-char-hash>
-			namePart := strings.TrimPrefix(sess.Name, tmux.SessionPrefix)
-			if lastUnderscore := strings.LastIndex(namePart, "_"); lastUnderscore > 0 {
-				title = namePart[:lastUnderscore]
-			} else {
-				title = namePart
+		if namespace, name, ok := tmux.ParseSessionName(sess.Name); ok {
+			// Sessions of other profiles belong in their own profile's list
+			if namespace != tmux.SessionNamespace() {
+				continue
 			}
+			isOrphaned = true
+			title = name
 			// Put orphaned sessions in a "Recovered" group so user knows they were recovered
 			groupPath = "recovered"
 		}
@@ -56,10 +54,6 @@ func DiscoverExistingTmuxSessions(existingInstances []*Instance) ([]*Instance, e
 			projectPath = "~"
 		}
 
-		// Enable mouse mode for proper scrolling in imported sessions
-		// Ignore errors - non-fatal, older tmux versions may not support all options
-		_ = sess.EnableMouseMode()
-
 		// Determine tool type - for orphaned agent-deck sessions, assume claude (most common)
 		tool := detectToolFromName(title)
 		if isOrphaned && tool == "shell" {
@@ -96,7 +90,7 @@ func GroupByProject(instances []*Instance) map[string][]*Instance {
 }
 
 // FilterByQuery filters sessions by title, project path, tool, or status
-// Supports status filters: "waiting", "running", "idle", "error"
+// Supports status filters: "waiting", "needs_input", "running", "idle", "error"
 func FilterByQuery(instances []*Instance, query string) []*Instance {
 	if query == "" {
 		return instances
@@ -106,10 +100,11 @@ func FilterByQuery(instances []*Instance, query string) []*Instance {
 
 	// Check for status filters
 	statusFilters := map[string]Status{
-		"waiting": StatusWaiting,
-		"running": StatusRunning,
-		"idle":    StatusIdle,
-		"error":   StatusError,
+		"waiting":     StatusWaiting,
+		"needs_input": StatusNeedsInput,
+		"running":     StatusRunning,
+		"idle":        StatusIdle,
+		"error":       StatusError,
 	}
 
 	// If query matches a status filter exactly, filter by status
@@ -13,8 +13,9 @@ import (
 // Order of sourcing (later overrides earlier):
 //  1. Global [shell].env_files (in order)
 //  2. [shell].init_script (for direnv, nvm, etc.)
-//  3. Tool-specific env_file ([claude].env_file, [gemini].env_file, [tools.X].env_file)
-//  4. Inline env vars from [tools.X].env (highest priority)
+//  3. The project's .envrc or .env (see ProjectEnvMode)
+//  4. Tool-specific env_file ([claude].env_file, [gemini].env_file, [tools.X].env_file)
+//  5. Inline env vars from [tools.X].env (highest priority)
 func (i *Instance) buildEnvSourceCommand() string {
 	var sources []string
 	config, _ := LoadUserConfig()
@@ -42,14 +43,19 @@ func (i *Instance) buildEnvSourceCommand() string {
 		}
 	}
 
-	// 3. Tool-specific env_file
+	// 3. Project environment (direnv / .env)
+	if projectEnv := buildProjectEnvCmd(i.ProjectEnvMode(), i.ProjectPath); projectEnv != "" {
+		sources = append(sources, projectEnv)
+	}
+
+	// 4. Tool-specific env_file
 	toolEnvFile := i.getToolEnvFile()
 	if toolEnvFile != "" {
 		resolved := resolveEnvFilePath(toolEnvFile, i.ProjectPath)
 		sources = append(sources, buildSourceCmd(resolved, ignoreMissing))
 	}
 
-	// 4. Inline env vars from [tools.X].env (highest priority)
+	// 5. Inline env vars from [tools.X].env (highest priority)
 	if inlineEnv := i.getToolInlineEnv(); inlineEnv != "" {
 		sources = append(sources, inlineEnv)
 	}
@@ -62,6 +68,62 @@ func (i *Instance) buildEnvSourceCommand() string {
 	return strings.Join(sources, " && ") + " && "
 }
 
+// Project environment modes: how a session loads its project directory's
+// environment before the agent starts.
+const (
+	ProjectEnvOff    = "off"    // Nothing
+	ProjectEnvDirenv = "direnv" // The allowed .envrc, via `direnv export`
+	ProjectEnvDotenv = "dotenv" // .env, with every variable exported
+	ProjectEnvAuto   = "auto"   // direnv when there is an .envrc and direnv is installed, else .env
+)
+
+// ParseProjectEnvMode validates a project environment mode. "default" and
+// "" mean "use the [shell] project_env setting" and return "".
+func ParseProjectEnvMode(s string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(s)); mode {
+	case "", "default":
+		return "", nil
+	case ProjectEnvOff, ProjectEnvDirenv, ProjectEnvDotenv, ProjectEnvAuto:
+		return mode, nil
+	}
+	return "", fmt.Errorf("want auto, direnv, dotenv, off or default, got %q", s)
+}
+
+// ProjectEnvMode returns how the session loads its project's environment:
+// the session's ProjectEnv, else [shell] project_env, else off.
+func (i *Instance) ProjectEnvMode() string {
+	if i.ProjectEnv != "" {
+		return i.ProjectEnv
+	}
+	if config, _ := LoadUserConfig(); config != nil {
+		if mode, err := ParseProjectEnvMode(config.Shell.ProjectEnv); err == nil && mode != "" {
+			return mode
+		}
+	}
+	return ProjectEnvOff
+}
+
+// buildProjectEnvCmd returns the shell command that loads dir's .envrc or
+// .env for mode, or "" for off. Missing files, a missing direnv and a
+// blocked .envrc are skipped so the session still starts.
+func buildProjectEnvCmd(mode, dir string) string {
+	envrc := filepath.Join(dir, ".envrc")
+	dotenv := filepath.Join(dir, ".env")
+	direnv := fmt.Sprintf(`eval "$(cd "%s" && direnv export bash 2>/dev/null)"`, dir)
+	source := fmt.Sprintf(`set -a; source "%s"; set +a`, dotenv)
+
+	switch mode {
+	case ProjectEnvDirenv:
+		return fmt.Sprintf(`if command -v direnv >/dev/null 2>&1; then %s; fi`, direnv)
+	case ProjectEnvDotenv:
+		return fmt.Sprintf(`if [ -f "%s" ]; then %s; fi`, dotenv, source)
+	case ProjectEnvAuto:
+		return fmt.Sprintf(`if [ -f "%s" ] && command -v direnv >/dev/null 2>&1; then %s; elif [ -f "%s" ]; then %s; fi`,
+			envrc, direnv, dotenv, source)
+	}
+	return ""
+}
+
 // buildSourceCmd creates a shell command to source a file.
 // If ignoreMissing is true, wraps in a file existence check.
 func buildSourceCmd(path string, ignoreMissing bool) string {
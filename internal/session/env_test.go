@@ -2,6 +2,7 @@ package session
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -239,3 +240,50 @@ func TestShellSettings_GetIgnoreMissingEnvFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestParseProjectEnvMode(t *testing.T) {
+	for in, want := range map[string]string{"": "", "default": "", "Direnv": "direnv", " auto ": "auto", "off": "off", "dotenv": "dotenv"} {
+		got, err := ParseProjectEnvMode(in)
+		if err != nil || got != want {
+			t.Errorf("ParseProjectEnvMode(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseProjectEnvMode("nix"); err == nil {
+		t.Error("unknown modes should be rejected")
+	}
+}
+
+func TestBuildProjectEnvCmd(t *testing.T) {
+	if buildProjectEnvCmd(ProjectEnvOff, "/p") != "" {
+		t.Error("off should add nothing")
+	}
+	if cmd := buildProjectEnvCmd(ProjectEnvDirenv, "/p"); !strings.Contains(cmd, `cd "/p" && direnv export bash`) {
+		t.Errorf("direnv should export the project's .envrc, got %q", cmd)
+	}
+
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("PROJECT_ENV_TEST=from-dotenv\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, mode := range []string{ProjectEnvDotenv, ProjectEnvAuto} {
+		// No .envrc, so auto falls back to .env
+		script := buildProjectEnvCmd(mode, dir) + ` && bash -c 'echo "$PROJECT_ENV_TEST"'`
+		out, err := exec.Command(bash, "-c", script).Output()
+		if err != nil {
+			t.Fatalf("%s: %v", mode, err)
+		}
+		if got := strings.TrimSpace(string(out)); got != "from-dotenv" {
+			t.Errorf("%s: child process saw %q, want the exported .env value", mode, got)
+		}
+	}
+
+	// A project without .env still starts the command
+	out, err := exec.Command(bash, "-c", buildProjectEnvCmd(ProjectEnvDotenv, t.TempDir())+" && echo started").Output()
+	if err != nil || strings.TrimSpace(string(out)) != "started" {
+		t.Errorf("missing .env should be skipped, got %q, %v", out, err)
+	}
+}
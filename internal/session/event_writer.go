@@ -23,11 +23,7 @@ type StatusEvent struct {
 
 // GetEventsDir returns the path to the events directory.
 func GetEventsDir() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(os.TempDir(), ".agent-deck", "events")
-	}
-	return filepath.Join(home, ".agent-deck", "events")
+	return agentDeckSubdir("events")
 }
 
 // WriteStatusEvent atomically writes a status event to the events directory.
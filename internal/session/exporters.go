@@ -0,0 +1,128 @@
+package session
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Export formats for `agent-deck export`.
+const (
+	ExportManifestFormat   = "manifest"
+	ExportTmuxinatorFormat = "tmuxinator"
+)
+
+// ExportFormats lists the supported export formats.
+var ExportFormats = []string{ExportManifestFormat, ExportTmuxinatorFormat}
+
+// ExportManifest returns a manifest that recreates the sessions in groupPath
+// and its subgroups, or every session when groupPath is empty. Paths under
+// baseDir are written relative to it, as `agent-deck up` resolves them
+// against the manifest's directory; paths under the home directory use ~ so
+// the manifest works for other users.
+func ExportManifest(instances []*Instance, groupPath, baseDir string) (*Manifest, error) {
+	m := &Manifest{Group: groupPath}
+	names := make(map[string]int)
+	for _, inst := range instances {
+		if groupPath != "" && inst.GroupPath != groupPath && !strings.HasPrefix(inst.GroupPath, groupPath+"/") {
+			continue
+		}
+		entry := ManifestSession{
+			Name:    uniqueImportName(names, inst.Title),
+			Path:    exportPath(inst.ProjectPath, baseDir),
+			Command: inst.Command,
+			Wrapper: inst.Wrapper,
+		}
+		if inst.GroupPath != m.Group {
+			entry.Group = inst.GroupPath
+		}
+		m.Sessions = append(m.Sessions, entry)
+	}
+	if len(m.Sessions) == 0 {
+		if groupPath != "" {
+			return nil, fmt.Errorf("no sessions in group %q", groupPath)
+		}
+		return nil, fmt.Errorf("no sessions to export")
+	}
+	return m, nil
+}
+
+// exportPath shortens path for a manifest written to baseDir.
+func exportPath(path, baseDir string) string {
+	if baseDir != "" {
+		if rel, err := filepath.Rel(baseDir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return rel
+		}
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if path == home {
+			return "~"
+		}
+		if strings.HasPrefix(path, home+string(filepath.Separator)) {
+			return "~" + path[len(home):]
+		}
+	}
+	return path
+}
+
+// MarshalManifest encodes m as YAML, the format `agent-deck up` reads.
+func MarshalManifest(m *Manifest) ([]byte, error) {
+	return marshalYAML(m)
+}
+
+// marshalYAML encodes v with the two-space indent used in the docs.
+func marshalYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// tmuxinatorWindow is a window in the hash form tmuxinator and
+// parseTmuxinatorProject both accept.
+type tmuxinatorWindow struct {
+	Root  string   `yaml:"root,omitempty"`
+	Panes []string `yaml:"panes,omitempty"`
+}
+
+// MarshalTmuxinator encodes m as a tmuxinator project: one window per
+// session, running its command (inside its wrapper, if any) in its path.
+// tmuxinator has no groups, so the project is named after m.Group, or
+// "agent-deck" for a whole deck.
+func MarshalTmuxinator(m *Manifest) ([]byte, error) {
+	name := strings.ReplaceAll(m.Group, "/", "-")
+	if name == "" {
+		name = "agent-deck"
+	}
+	project := struct {
+		Name    string                        `yaml:"name"`
+		Windows []map[string]tmuxinatorWindow `yaml:"windows"`
+	}{Name: name}
+
+	for _, s := range m.Sessions {
+		window := tmuxinatorWindow{Root: s.Path}
+		command := s.Command
+		if s.Wrapper != "" {
+			if strings.Contains(s.Wrapper, wrapperPlaceholder) {
+				command = strings.ReplaceAll(s.Wrapper, wrapperPlaceholder, command)
+			} else {
+				command = s.Wrapper
+			}
+		}
+		if command != "" {
+			window.Panes = []string{command}
+		}
+		project.Windows = append(project.Windows, map[string]tmuxinatorWindow{s.Name: window})
+	}
+	return marshalYAML(project)
+}
@@ -0,0 +1,114 @@
+package session
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func exportTestInstances() []*Instance {
+	api := NewInstanceWithGroup("api", "/src/deck/api", "work")
+	api.Command = "claude"
+	web := NewInstanceWithGroup("web", "/src/web", "work/frontend")
+	web.Command = "codex"
+	web.Wrapper = "nvim +'terminal {command}'"
+	dupe := NewInstanceWithGroup("api", "/src/other", "work")
+	notes := NewInstanceWithGroup("notes", "/src/notes", "personal")
+	return []*Instance{api, web, dupe, notes}
+}
+
+func TestExportManifest_Group(t *testing.T) {
+	m, err := ExportManifest(exportTestInstances(), "work", "/src/deck")
+	if err != nil {
+		t.Fatalf("ExportManifest: %v", err)
+	}
+	if m.Group != "work" {
+		t.Errorf("Group = %q, want work", m.Group)
+	}
+	want := []ManifestSession{
+		{Name: "api", Path: "api", Command: "claude"},
+		{Name: "web", Path: "/src/web", Command: "codex", Group: "work/frontend", Wrapper: "nvim +'terminal {command}'"},
+		{Name: "api 2", Path: "/src/other"},
+	}
+	if len(m.Sessions) != len(want) {
+		t.Fatalf("got %d sessions, want %d: %+v", len(m.Sessions), len(want), m.Sessions)
+	}
+	for i, w := range want {
+		if m.Sessions[i] != w {
+			t.Errorf("session %d = %+v, want %+v", i, m.Sessions[i], w)
+		}
+	}
+
+	if _, err := ExportManifest(exportTestInstances(), "empty", ""); err == nil {
+		t.Error("expected an error for a group without sessions")
+	}
+}
+
+func TestExportManifest_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	m, err := ExportManifest(exportTestInstances(), "", "")
+	if err != nil {
+		t.Fatalf("ExportManifest: %v", err)
+	}
+	data, err := MarshalManifest(m)
+	if err != nil {
+		t.Fatalf("MarshalManifest: %v", err)
+	}
+	path := writeImportFile(t, dir, "agent-deck.yaml", string(data))
+	loaded, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v\n%s", err, data)
+	}
+	if len(loaded.Sessions) != 4 || loaded.Sessions[3].Group != "personal" || loaded.Sessions[3].Path != "/src/notes" {
+		t.Errorf("round trip lost sessions: %+v", loaded.Sessions)
+	}
+}
+
+func TestMarshalTmuxinator(t *testing.T) {
+	m, err := ExportManifest(exportTestInstances(), "work", "")
+	if err != nil {
+		t.Fatalf("ExportManifest: %v", err)
+	}
+	data, err := MarshalTmuxinator(m)
+	if err != nil {
+		t.Fatalf("MarshalTmuxinator: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "name: work\n") {
+		t.Errorf("project should be named after the group:\n%s", data)
+	}
+
+	path := writeImportFile(t, t.TempDir(), "work.yml", string(data))
+	imported, _, err := ImportManifest(ImportTmuxinator, path, t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportManifest: %v\n%s", err, data)
+	}
+	want := []ManifestSession{
+		{Name: "api", Path: "/src/deck/api", Command: "claude", Group: "work"},
+		{Name: "web", Path: "/src/web", Command: "nvim +'terminal codex'", Group: "work"},
+		{Name: "api 2", Path: "/src/other", Group: "work"},
+	}
+	if len(imported.Sessions) != len(want) {
+		t.Fatalf("got %d sessions, want %d: %+v", len(imported.Sessions), len(want), imported.Sessions)
+	}
+	for i, w := range want {
+		if imported.Sessions[i] != w {
+			t.Errorf("session %d = %+v, want %+v", i, imported.Sessions[i], w)
+		}
+	}
+}
+
+func TestExportPath(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	tests := []struct{ path, baseDir, want string }{
+		{"/src/deck/api", "/src/deck", "api"},
+		{"/src/deck", "/src/deck", "."},
+		{"/src/other", "/src/deck", "/src/other"},
+		{"/home/me/code/x", "", "~/code/x"},
+		{"/home/meow", "", "/home/meow"},
+	}
+	for _, tt := range tests {
+		if got := exportPath(tt.path, tt.baseDir); got != filepath.FromSlash(tt.want) {
+			t.Errorf("exportPath(%q, %q) = %q, want %q", tt.path, tt.baseDir, got, tt.want)
+		}
+	}
+}
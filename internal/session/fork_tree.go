@@ -0,0 +1,74 @@
+package session
+
+import "sort"
+
+// ForkNode is a session in a fork tree, with the sessions forked from it.
+type ForkNode struct {
+	Instance *Instance
+	Children []*ForkNode
+
+	// ParentMissing is set on a root whose ForkedFromID no longer exists
+	// (the source was deleted)
+	ParentMissing bool
+}
+
+// BuildForkTree groups instances into fork trees. Only sessions that were
+// forked or have forks appear. Roots are ordered by creation time and
+// children by when they were forked.
+func BuildForkTree(instances []*Instance) []*ForkNode {
+	nodes := make(map[string]*ForkNode, len(instances))
+	for _, inst := range instances {
+		nodes[inst.ID] = &ForkNode{Instance: inst}
+	}
+
+	var roots []*ForkNode
+	for _, inst := range instances {
+		node := nodes[inst.ID]
+		if inst.ForkedFromID == "" {
+			continue
+		}
+		// A self or cyclic reference would make the tree unreachable; treat
+		// the session as a root instead
+		if parent, ok := nodes[inst.ForkedFromID]; ok && !forkCycle(nodes, inst.ID) {
+			parent.Children = append(parent.Children, node)
+		} else {
+			node.ParentMissing = !ok
+			roots = append(roots, node)
+		}
+	}
+	for _, inst := range instances {
+		node := nodes[inst.ID]
+		if inst.ForkedFromID == "" && len(node.Children) > 0 {
+			roots = append(roots, node)
+		}
+	}
+
+	for _, node := range nodes {
+		sort.SliceStable(node.Children, func(a, b int) bool {
+			return node.Children[a].Instance.ForkedAt.Before(node.Children[b].Instance.ForkedAt)
+		})
+	}
+	sort.SliceStable(roots, func(a, b int) bool {
+		return roots[a].Instance.CreatedAt.Before(roots[b].Instance.CreatedAt)
+	})
+	return roots
+}
+
+// forkCycle reports whether following ForkedFromID from id leads back to id.
+func forkCycle(nodes map[string]*ForkNode, id string) bool {
+	seen := map[string]bool{}
+	for cur := id; ; {
+		node, ok := nodes[cur]
+		if !ok || node.Instance.ForkedFromID == "" {
+			return false
+		}
+		cur = node.Instance.ForkedFromID
+		if cur == id {
+			return true
+		}
+		if seen[cur] {
+			return false
+		}
+		seen[cur] = true
+	}
+}
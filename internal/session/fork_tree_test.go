@@ -0,0 +1,55 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildForkTree(t *testing.T) {
+	now := time.Now()
+	root := &Instance{ID: "root", CreatedAt: now.Add(-3 * time.Hour)}
+	late := &Instance{ID: "late", ForkedFromID: "root", ForkedAt: now.Add(-time.Hour)}
+	early := &Instance{ID: "early", ForkedFromID: "root", ForkedAt: now.Add(-2 * time.Hour)}
+	grandchild := &Instance{ID: "grandchild", ForkedFromID: "early", ForkedAt: now}
+	orphan := &Instance{ID: "orphan", ForkedFromID: "deleted", ForkedAt: now, CreatedAt: now}
+	plain := &Instance{ID: "plain", CreatedAt: now}
+
+	roots := BuildForkTree([]*Instance{plain, grandchild, late, orphan, early, root})
+	require.Len(t, roots, 2, "sessions without forks are left out")
+
+	assert.Equal(t, "root", roots[0].Instance.ID)
+	require.Len(t, roots[0].Children, 2)
+	assert.Equal(t, "early", roots[0].Children[0].Instance.ID, "children are ordered by fork time")
+	assert.Equal(t, "late", roots[0].Children[1].Instance.ID)
+	require.Len(t, roots[0].Children[0].Children, 1)
+	assert.Equal(t, "grandchild", roots[0].Children[0].Children[0].Instance.ID)
+
+	assert.Equal(t, "orphan", roots[1].Instance.ID)
+	assert.True(t, roots[1].ParentMissing)
+}
+
+func TestBuildForkTree_Cycle(t *testing.T) {
+	a := &Instance{ID: "a", ForkedFromID: "b"}
+	b := &Instance{ID: "b", ForkedFromID: "a"}
+	roots := BuildForkTree([]*Instance{a, b})
+	assert.Len(t, roots, 2, "a cycle must not hide sessions")
+}
+
+func TestForkLineagePersists(t *testing.T) {
+	s := newTestStorage(t)
+	forkedAt := time.Now().Truncate(time.Second)
+	instances := []*Instance{
+		{ID: "src", Title: "src", Tool: "claude", CreatedAt: time.Now()},
+		{ID: "fork", Title: "fork", Tool: "claude", CreatedAt: time.Now(), ForkedFromID: "src", ForkedAt: forkedAt},
+	}
+	require.NoError(t, s.SaveWithGroups(instances, nil))
+
+	loaded, _, err := s.LoadLite()
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	assert.Equal(t, "src", loaded[1].ForkedFromID)
+	assert.True(t, loaded[1].ForkedAt.Equal(forkedAt))
+}
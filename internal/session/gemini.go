@@ -178,6 +178,14 @@ func findGeminiSessionInAllProjects(sessionID string) string {
 // UpdateGeminiAnalyticsFromDisk updates the analytics struct from the session file on disk.
 // Uses mtime caching to skip re-parsing unchanged files (important for 40MB+ session files).
 func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *GeminiSessionAnalytics) error {
+	return UpdateGeminiAnalyticsFromDiskSince(projectPath, sessionID, time.Time{}, analytics)
+}
+
+// UpdateGeminiAnalyticsFromDiskSince is UpdateGeminiAnalyticsFromDisk counting
+// only the messages at or after since, e.g. today's usage. Messages without a
+// timestamp count as sent at the session's last update. A zero since counts
+// every message.
+func UpdateGeminiAnalyticsFromDiskSince(projectPath, sessionID string, since time.Time, analytics *GeminiSessionAnalytics) error {
 	if sessionID == "" || len(sessionID) < 8 {
 		return fmt.Errorf("invalid session ID")
 	}
@@ -202,8 +210,10 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 		return fmt.Errorf("session file not found")
 	}
 
-	// mtime cache: skip re-parse if file hasn't changed since last read
-	if !analytics.LastFileModTime.IsZero() && !fileMtime.IsZero() && fileMtime.Equal(analytics.LastFileModTime) {
+	// mtime cache: skip re-parse if file hasn't changed since last read. A
+	// since filter can give different totals for the same file, so it always
+	// re-parses.
+	if since.IsZero() && !analytics.LastFileModTime.IsZero() && !fileMtime.IsZero() && fileMtime.Equal(analytics.LastFileModTime) {
 		return nil
 	}
 
@@ -217,9 +227,10 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 		StartTime   string `json:"startTime"`
 		LastUpdated string `json:"lastUpdated"`
 		Messages    []struct {
-			Type   string `json:"type"`
-			Model  string `json:"model,omitempty"`
-			Tokens struct {
+			Type      string `json:"type"`
+			Timestamp string `json:"timestamp"`
+			Model     string `json:"model,omitempty"`
+			Tokens    struct {
 				Input  int `json:"input"`
 				Output int `json:"output"`
 			} `json:"tokens"`
@@ -231,14 +242,8 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 	}
 
 	// Parse timestamps
-	startTime, _ := time.Parse(time.RFC3339, session.StartTime)
-	if startTime.IsZero() {
-		startTime, _ = time.Parse("2006-01-02T15:04:05.999Z", session.StartTime)
-	}
-	lastUpdated, _ := time.Parse(time.RFC3339, session.LastUpdated)
-	if lastUpdated.IsZero() {
-		lastUpdated, _ = time.Parse("2006-01-02T15:04:05.999Z", session.LastUpdated)
-	}
+	startTime := parseGeminiTime(session.StartTime)
+	lastUpdated := parseGeminiTime(session.LastUpdated)
 
 	analytics.StartTime = startTime
 	analytics.LastActive = lastUpdated
@@ -253,6 +258,15 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 	analytics.Model = ""
 	for _, msg := range session.Messages {
 		if msg.Type == "gemini" {
+			if !since.IsZero() {
+				sent := parseGeminiTime(msg.Timestamp)
+				if sent.IsZero() {
+					sent = lastUpdated
+				}
+				if sent.Before(since) {
+					continue
+				}
+			}
 			analytics.InputTokens += msg.Tokens.Input
 			analytics.OutputTokens += msg.Tokens.Output
 			analytics.TotalTurns++
@@ -274,6 +288,16 @@ func UpdateGeminiAnalyticsFromDisk(projectPath, sessionID string, analytics *Gem
 	return nil
 }
 
+// parseGeminiTime parses a timestamp from a Gemini session file, with or
+// without RFC 3339's time zone offset. Returns the zero time if s is invalid.
+func parseGeminiTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t, _ = time.Parse("2006-01-02T15:04:05.999Z", s)
+	}
+	return t
+}
+
 // geminiModelCache holds cached model list from the Gemini API
 var (
 	geminiModelCacheMu   sync.Mutex
@@ -299,6 +299,40 @@ func TestUpdateGeminiAnalyticsFromDisk_MtimeCache(t *testing.T) {
 	}
 }
 
+func TestUpdateGeminiAnalyticsFromDiskSince(t *testing.T) {
+	tmpDir := t.TempDir()
+	geminiConfigDirOverride = tmpDir
+	defer func() { geminiConfigDirOverride = "" }()
+
+	projectPath := "/Users/ashesh/test-project"
+	sessionsDir := GetGeminiSessionsDir(projectPath)
+	_ = os.MkdirAll(sessionsDir, 0755)
+
+	// A session from yesterday that was continued today
+	sessionData := `{
+  "sessionId": "abc12345-3333-3333-3333-333333333333",
+  "startTime": "2025-12-22T23:00:00.000Z",
+  "lastUpdated": "2025-12-23T09:00:00.000Z",
+  "messages": [
+    {"type": "gemini", "timestamp": "2025-12-22T23:10:00.000Z", "tokens": {"input": 100, "output": 200}},
+    {"type": "gemini", "timestamp": "2025-12-23T08:00:00.000Z", "tokens": {"input": 10, "output": 20}},
+    {"type": "gemini", "tokens": {"input": 1, "output": 2}}
+  ]
+}`
+	sessionFile := filepath.Join(sessionsDir, "session-2025-12-22T23-00-abc12345.json")
+	_ = os.WriteFile(sessionFile, []byte(sessionData), 0644)
+
+	analytics := &GeminiSessionAnalytics{}
+	since := time.Date(2025, 12, 23, 0, 0, 0, 0, time.UTC)
+	if err := UpdateGeminiAnalyticsFromDiskSince(projectPath, "abc12345-3333-3333-3333-333333333333", since, analytics); err != nil {
+		t.Fatalf("Failed: %v", err)
+	}
+	// Yesterday's message is left out; the untimestamped one counts at lastUpdated
+	if analytics.InputTokens != 11 || analytics.OutputTokens != 22 || analytics.TotalTurns != 2 {
+		t.Errorf("got %d in, %d out, %d turns; want 11, 22, 2", analytics.InputTokens, analytics.OutputTokens, analytics.TotalTurns)
+	}
+}
+
 func TestUpdateGeminiAnalyticsFromDisk_ExtractsModel(t *testing.T) {
 	tmpDir := t.TempDir()
 	geminiConfigDirOverride = tmpDir
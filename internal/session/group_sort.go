@@ -0,0 +1,111 @@
+package session
+
+import (
+	"sort"
+	"strings"
+)
+
+// GroupSortMode is how a group orders its sessions in the list.
+type GroupSortMode string
+
+const (
+	GroupSortManual   GroupSortMode = ""         // By Order, rearranged by hand
+	GroupSortActivity GroupSortMode = "activity" // Most recent output or attach first
+	GroupSortStatus   GroupSortMode = "status"   // Most urgent status first
+	GroupSortName     GroupSortMode = "name"     // Alphabetical by title
+	GroupSortCreated  GroupSortMode = "created"  // Newest first
+)
+
+// GroupSortModes lists the sort modes in the order the TUI cycles through them.
+var GroupSortModes = []GroupSortMode{
+	GroupSortManual,
+	GroupSortActivity,
+	GroupSortStatus,
+	GroupSortName,
+	GroupSortCreated,
+}
+
+// Label returns the mode's display name.
+func (m GroupSortMode) Label() string {
+	if m == GroupSortManual {
+		return "manual"
+	}
+	return string(m)
+}
+
+// Next returns the mode after m in GroupSortModes, wrapping around.
+func (m GroupSortMode) Next() GroupSortMode {
+	for n, mode := range GroupSortModes {
+		if mode == m {
+			return GroupSortModes[(n+1)%len(GroupSortModes)]
+		}
+	}
+	return GroupSortManual
+}
+
+// ParseGroupSortMode parses a mode name as shown by Label.
+func ParseGroupSortMode(s string) (GroupSortMode, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	for _, mode := range GroupSortModes {
+		if s == mode.Label() {
+			return mode, true
+		}
+	}
+	return GroupSortManual, false
+}
+
+// statusSeverity ranks statuses for GroupSortStatus; lower sorts first.
+func statusSeverity(s Status) int {
+	switch s {
+	case StatusError:
+		return 0
+	case StatusNeedsInput:
+		return 1
+	case StatusWaiting:
+		return 2
+	case StatusRunning:
+		return 3
+	case StatusStarting:
+		return 4
+	case StatusIdle:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// SortedSessions returns the group's sessions in its sort mode. Ties keep
+// the manual order. Manual mode returns Sessions itself.
+func (g *Group) SortedSessions() []*Instance {
+	if g.SortMode == GroupSortManual {
+		return g.Sessions
+	}
+	sessions := make([]*Instance, len(g.Sessions))
+	copy(sessions, g.Sessions)
+
+	var less func(a, b *Instance) bool
+	switch g.SortMode {
+	case GroupSortActivity:
+		since := make(map[*Instance]int64, len(sessions))
+		for _, inst := range sessions {
+			since[inst] = inst.IdleSince().UnixNano()
+		}
+		less = func(a, b *Instance) bool { return since[a] > since[b] }
+	case GroupSortStatus:
+		severity := make(map[*Instance]int, len(sessions))
+		for _, inst := range sessions {
+			severity[inst] = statusSeverity(inst.GetStatusThreadSafe())
+		}
+		less = func(a, b *Instance) bool { return severity[a] < severity[b] }
+	case GroupSortName:
+		less = func(a, b *Instance) bool { return strings.ToLower(a.Title) < strings.ToLower(b.Title) }
+	case GroupSortCreated:
+		less = func(a, b *Instance) bool { return a.CreatedAt.After(b.CreatedAt) }
+	default:
+		return g.Sessions
+	}
+	sort.SliceStable(sessions, func(a, b int) bool {
+		return less(sessions[a], sessions[b])
+	})
+	return sessions
+}
@@ -0,0 +1,91 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func flattenedTitles(tree *GroupTree) []string {
+	var titles []string
+	for _, item := range tree.Flatten() {
+		if item.Type == ItemTypeSession {
+			titles = append(titles, item.Session.Title)
+		}
+	}
+	return titles
+}
+
+func TestGroupSortModes(t *testing.T) {
+	now := time.Now()
+	instances := []*Instance{
+		{ID: "1", Title: "beta", GroupPath: "work", Order: 0, Status: StatusIdle, CreatedAt: now.Add(-3 * time.Hour), LastAccessedAt: now.Add(-time.Minute)},
+		{ID: "2", Title: "Alpha", GroupPath: "work", Order: 1, Status: StatusWaiting, CreatedAt: now.Add(-time.Hour)},
+		{ID: "3", Title: "gamma", GroupPath: "work", Order: 2, Status: StatusError, CreatedAt: now.Add(-2 * time.Hour), LastAccessedAt: now.Add(-time.Hour / 2)},
+	}
+	tree := NewGroupTreeWithGroups(instances, []*GroupData{{Name: "Work", Path: "work", Expanded: true}})
+
+	for _, tc := range []struct {
+		mode GroupSortMode
+		want []string
+	}{
+		{GroupSortManual, []string{"beta", "Alpha", "gamma"}},
+		{GroupSortActivity, []string{"beta", "gamma", "Alpha"}},
+		{GroupSortStatus, []string{"gamma", "Alpha", "beta"}},
+		{GroupSortName, []string{"Alpha", "beta", "gamma"}},
+		{GroupSortCreated, []string{"Alpha", "gamma", "beta"}},
+	} {
+		tree.Groups["work"].SortMode = tc.mode
+		got := flattenedTitles(tree)
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: got %v", tc.mode.Label(), got)
+		}
+		for n := range got {
+			if got[n] != tc.want[n] {
+				t.Errorf("%s: got %v, want %v", tc.mode.Label(), got, tc.want)
+				break
+			}
+		}
+	}
+
+	// Sorting never touches the manual order
+	if tree.Groups["work"].Sessions[0].Title != "beta" {
+		t.Error("sorted view reordered group.Sessions")
+	}
+}
+
+func TestGroupSortModeCycleAndParse(t *testing.T) {
+	mode := GroupSortManual
+	for range GroupSortModes {
+		mode = mode.Next()
+	}
+	if mode != GroupSortManual {
+		t.Errorf("cycling all modes ended on %q", mode)
+	}
+	if m, ok := ParseGroupSortMode(" Activity "); !ok || m != GroupSortActivity {
+		t.Errorf("ParseGroupSortMode(activity) = %q, %v", m, ok)
+	}
+	if m, ok := ParseGroupSortMode("manual"); !ok || m != GroupSortManual {
+		t.Errorf("ParseGroupSortMode(manual) = %q, %v", m, ok)
+	}
+	if _, ok := ParseGroupSortMode("size"); ok {
+		t.Error("ParseGroupSortMode accepted an unknown mode")
+	}
+}
+
+func TestGroupSortModePersists(t *testing.T) {
+	s := newTestStorage(t)
+	inst := &Instance{ID: "s", Title: "s", Tool: "shell", GroupPath: "work", CreatedAt: time.Now()}
+	tree := NewGroupTree([]*Instance{inst})
+	tree.Groups["work"].SortMode = GroupSortStatus
+	if err := s.SaveWithGroups([]*Instance{inst}, tree.ShallowCopyForSave()); err != nil {
+		t.Fatal(err)
+	}
+	_, groups, err := s.LoadLite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewGroupTreeWithGroups(nil, groups)
+	if got := loaded.Groups["work"].SortMode; got != GroupSortStatus {
+		t.Errorf("SortMode = %q after reload", got)
+	}
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"unicode"
@@ -46,6 +47,8 @@ type Group struct {
 	Sessions    []*Instance
 	Order       int
 	DefaultPath string // Explicit default path for new sessions in this group
+	Muted       bool   // Notifications off for sessions in this group and its subgroups
+	SortMode    GroupSortMode
 }
 
 // GroupTree manages hierarchical session organization
@@ -124,6 +127,8 @@ func NewGroupTreeWithGroups(instances []*Instance, storedGroups []*GroupData) *G
 			Sessions:    []*Instance{},
 			Order:       gd.Order,
 			DefaultPath: gd.DefaultPath,
+			Muted:       gd.Muted,
+			SortMode:    gd.SortMode,
 		}
 		tree.Groups[gd.Path] = group
 		tree.Expanded[gd.Path] = gd.Expanded
@@ -376,7 +381,7 @@ func (t *GroupTree) Flatten() []Item {
 			parentSessions := []*Instance{}
 			subSessionsByParent := make(map[string][]*Instance) // parentID -> sub-sessions
 
-			for _, sess := range group.Sessions {
+			for _, sess := range group.SortedSessions() {
 				if sess.IsSubSession() {
 					subSessionsByParent[sess.ParentSessionID] = append(subSessionsByParent[sess.ParentSessionID], sess)
 				} else {
@@ -616,6 +621,36 @@ func (t *GroupTree) MoveSessionToGroup(inst *Instance, newGroupPath string) {
 	t.updateGroupDefaultPath(newGroupPath)
 }
 
+// MoveSessionTo moves a session to position index of a group, moving it to
+// that group first if needed. index is clamped to the group's sessions.
+func (t *GroupTree) MoveSessionTo(inst *Instance, groupPath string, index int) {
+	if oldGroupPath := inst.GroupPath; oldGroupPath != groupPath {
+		t.MoveSessionToGroup(inst, groupPath)
+		if oldGroup, exists := t.Groups[oldGroupPath]; exists {
+			for i, s := range oldGroup.Sessions {
+				s.Order = i
+			}
+		}
+	}
+	group, exists := t.Groups[groupPath]
+	if !exists {
+		return
+	}
+
+	for i, s := range group.Sessions {
+		if s.ID == inst.ID {
+			group.Sessions = slices.Delete(group.Sessions, i, i+1)
+			break
+		}
+	}
+	index = max(0, min(index, len(group.Sessions)))
+	group.Sessions = slices.Insert(group.Sessions, index, inst)
+	// Normalize Order for all sessions in group
+	for i, s := range group.Sessions {
+		s.Order = i
+	}
+}
+
 // sanitizeGroupName removes dangerous characters from group names
 // to prevent path traversal and other security issues
 func sanitizeGroupName(name string) string {
@@ -1019,6 +1054,8 @@ func (t *GroupTree) ShallowCopyForSave() *GroupTree {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Muted:       g.Muted,
+			SortMode:    g.SortMode,
 			// Don't copy Sessions - not needed for save, only metadata is saved
 		}
 	}
@@ -1132,3 +1169,35 @@ func (t *GroupTree) updateGroupDefaultPath(groupPath string) {
 		group.DefaultPath = resolveGroupDefaultPath(group.DefaultPath)
 	}
 }
+
+// IsMuted reports whether groupPath or one of its parent groups is muted.
+func (t *GroupTree) IsMuted(groupPath string) bool {
+	for path := groupPath; path != ""; path = getParentPath(path) {
+		if g, ok := t.Groups[path]; ok && g.Muted {
+			return true
+		}
+	}
+	return false
+}
+
+// MutedGroupPaths returns the paths of groups with notifications muted.
+func (t *GroupTree) MutedGroupPaths() map[string]bool {
+	muted := make(map[string]bool)
+	for path, g := range t.Groups {
+		if g.Muted {
+			muted[path] = true
+		}
+	}
+	return muted
+}
+
+// IsGroupPathMuted reports whether groupPath or one of its parent groups is
+// in muted (as returned by MutedGroupPaths).
+func IsGroupPathMuted(muted map[string]bool, groupPath string) bool {
+	for path := groupPath; path != ""; path = getParentPath(path) {
+		if muted[path] {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -1278,6 +1279,52 @@ func TestMoveSessionDownOrder(t *testing.T) {
 	}
 }
 
+func TestMoveSessionTo(t *testing.T) {
+	instances := []*Instance{
+		{ID: "a", Title: "first", GroupPath: "test"},
+		{ID: "b", Title: "second", GroupPath: "test"},
+		{ID: "c", Title: "third", GroupPath: "test"},
+		{ID: "d", Title: "other", GroupPath: "other"},
+	}
+
+	tree := NewGroupTree(instances)
+	order := func(path string) string {
+		var ids []string
+		for i, s := range tree.Groups[path].Sessions {
+			if s.Order != i {
+				t.Errorf("Expected Order %d for session '%s', got %d", i, s.ID, s.Order)
+			}
+			ids = append(ids, s.ID)
+		}
+		return strings.Join(ids, "")
+	}
+
+	tree.MoveSessionTo(instances[0], "test", 2)
+	if got := order("test"); got != "bca" {
+		t.Errorf("Expected order 'bca', got '%s'", got)
+	}
+	tree.MoveSessionTo(instances[0], "test", 0)
+	if got := order("test"); got != "abc" {
+		t.Errorf("Expected order 'abc', got '%s'", got)
+	}
+
+	// Into another group, clamped to its end
+	tree.MoveSessionTo(instances[1], "other", 5)
+	if got := order("other"); got != "db" {
+		t.Errorf("Expected order 'db', got '%s'", got)
+	}
+	if got := order("test"); got != "ac" {
+		t.Errorf("Expected order 'ac', got '%s'", got)
+	}
+	if instances[1].GroupPath != "other" {
+		t.Errorf("Expected GroupPath 'other', got '%s'", instances[1].GroupPath)
+	}
+	tree.MoveSessionTo(instances[2], "other", 0)
+	if got := order("other"); got != "cdb" {
+		t.Errorf("Expected order 'cdb', got '%s'", got)
+	}
+}
+
 func TestSessionOrderPersistence(t *testing.T) {
 	// Simulate sessions with Order values (as if saved after reorder)
 	instances := []*Instance{
@@ -1465,3 +1512,27 @@ func TestBranchOrderingByOrder(t *testing.T) {
 			zebraIdx, alphaIdx)
 	}
 }
+
+func TestGroupMuteCoversSubgroups(t *testing.T) {
+	tree := NewGroupTreeWithGroups([]*Instance{}, []*GroupData{
+		{Name: "Work", Path: "work", Muted: true},
+		{Name: "API", Path: "work/api"},
+		{Name: "Home", Path: "home"},
+	})
+
+	for path, want := range map[string]bool{"work": true, "work/api": true, "home": false, "worker": false} {
+		if got := tree.IsMuted(path); got != want {
+			t.Errorf("IsMuted(%q) = %v, want %v", path, got, want)
+		}
+		if got := IsGroupPathMuted(tree.MutedGroupPaths(), path); got != want {
+			t.Errorf("IsGroupPathMuted(%q) = %v, want %v", path, got, want)
+		}
+	}
+
+	// The save copy keeps the flag
+	for _, g := range tree.ShallowCopyForSave().GroupList {
+		if g.Muted != (g.Path == "work") {
+			t.Errorf("ShallowCopyForSave: %s Muted = %v", g.Path, g.Muted)
+		}
+	}
+}
@@ -203,9 +203,5 @@ func (w *StatusFileWatcher) processFile(filePath string) {
 
 // GetHooksDir returns the path to the hooks status directory.
 func GetHooksDir() string {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(os.TempDir(), ".agent-deck", "hooks")
-	}
-	return filepath.Join(home, ".agent-deck", "hooks")
+	return agentDeckSubdir("hooks")
 }
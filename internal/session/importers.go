@@ -0,0 +1,327 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Import sources for `agent-deck import`. Each importer turns another tool's
+// configuration into a Manifest, which is then applied like `agent-deck up`.
+const (
+	ImportClaudeSquad = "claude-squad"
+	ImportTmuxinator  = "tmuxinator"
+	ImportTmuxp       = "tmuxp"
+)
+
+// ImportSources lists the supported import sources.
+var ImportSources = []string{ImportClaudeSquad, ImportTmuxinator, ImportTmuxp}
+
+// ImportManifest reads a claude-squad, tmuxinator or tmuxp file and returns
+// the equivalent manifest. For tmuxinator and tmuxp, nameOrPath may also be
+// a project name looked up in the tool's config directory; for claude-squad
+// an empty nameOrPath reads ~/.claude-squad/state.json. Sessions without a
+// directory, or with a relative one, resolve against baseDir, the way the
+// tools themselves resolve them against the directory they are run from.
+func ImportManifest(source, nameOrPath, baseDir string) (*Manifest, string, error) {
+	path, err := findImportFile(source, nameOrPath)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var m *Manifest
+	switch source {
+	case ImportClaudeSquad:
+		m, err = parseClaudeSquadState(data)
+	case ImportTmuxinator:
+		m, err = parseTmuxinatorProject(data)
+	case ImportTmuxp:
+		m, err = parseTmuxpSession(data)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := m.normalize(baseDir); err != nil {
+		return nil, "", fmt.Errorf("nothing to import from %s: %w", path, err)
+	}
+	return m, path, nil
+}
+
+// findImportFile resolves the file to import: nameOrPath itself when it
+// exists, else the tool's default location for that name.
+func findImportFile(source, nameOrPath string) (string, error) {
+	home, _ := os.UserHomeDir()
+	var candidates []string
+	switch source {
+	case ImportClaudeSquad:
+		if nameOrPath == "" {
+			candidates = []string{filepath.Join(home, ".claude-squad", "state.json")}
+		}
+	case ImportTmuxinator:
+		for _, dir := range []string{
+			filepath.Join(xdgConfigHome(home), "tmuxinator"),
+			filepath.Join(home, ".tmuxinator"),
+		} {
+			candidates = append(candidates, filepath.Join(dir, nameOrPath+".yml"), filepath.Join(dir, nameOrPath+".yaml"))
+		}
+	case ImportTmuxp:
+		for _, dir := range []string{
+			filepath.Join(home, ".tmuxp"),
+			filepath.Join(xdgConfigHome(home), "tmuxp"),
+		} {
+			for _, ext := range []string{".yaml", ".yml", ".json"} {
+				candidates = append(candidates, filepath.Join(dir, nameOrPath+ext))
+			}
+		}
+	default:
+		return "", fmt.Errorf("unknown import source %q (supported: %s)", source, strings.Join(ImportSources, ", "))
+	}
+
+	if nameOrPath != "" {
+		path := expandTilde(nameOrPath)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if source == ImportClaudeSquad || strings.ContainsRune(nameOrPath, filepath.Separator) {
+			return "", fmt.Errorf("file not found: %s", nameOrPath)
+		}
+	} else if source != ImportClaudeSquad {
+		return "", fmt.Errorf("%s needs a project name or file", source)
+	}
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+	}
+	if nameOrPath == "" {
+		return "", fmt.Errorf("no %s state found at %s", source, candidates[0])
+	}
+	return "", fmt.Errorf("no %s project named %q", source, nameOrPath)
+}
+
+func xdgConfigHome(home string) string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(home, ".config")
+}
+
+// claudeSquadInstance is the part of a claude-squad instance we carry over.
+type claudeSquadInstance struct {
+	Title    string `json:"title"`
+	Path     string `json:"path"`
+	Program  string `json:"program"`
+	Worktree struct {
+		WorktreePath string `json:"worktree_path"`
+	} `json:"worktree"`
+}
+
+// parseClaudeSquadState maps each claude-squad instance to a session in the
+// "claude-squad" group. Sessions run in the instance's worktree while it
+// still exists, else in the repository it was created from.
+func parseClaudeSquadState(data []byte) (*Manifest, error) {
+	var state struct {
+		Instances json.RawMessage `json:"instances"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, err
+	}
+	var instances []claudeSquadInstance
+	if len(state.Instances) > 0 {
+		if err := json.Unmarshal(state.Instances, &instances); err != nil {
+			return nil, fmt.Errorf("instances: %w", err)
+		}
+	}
+
+	m := &Manifest{Group: ImportClaudeSquad}
+	names := make(map[string]int)
+	for _, inst := range instances {
+		path := inst.Path
+		if wt := inst.Worktree.WorktreePath; wt != "" {
+			if info, err := os.Stat(wt); err == nil && info.IsDir() {
+				path = wt
+			}
+		}
+		m.Sessions = append(m.Sessions, ManifestSession{
+			Name:    uniqueImportName(names, inst.Title),
+			Path:    path,
+			Command: inst.Program,
+		})
+	}
+	return m, nil
+}
+
+// parseTmuxinatorProject maps each window of a tmuxinator project to a
+// session in a group named after the project. A window's command is its
+// own, or its first pane's; further panes aren't carried over.
+func parseTmuxinatorProject(data []byte) (*Manifest, error) {
+	var project struct {
+		Name    string                 `yaml:"name"`
+		Root    string                 `yaml:"root"`
+		Windows []map[string]yaml.Node `yaml:"windows"`
+	}
+	if err := yaml.Unmarshal(data, &project); err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Group: project.Name}
+	names := make(map[string]int)
+	for _, window := range project.Windows {
+		for name, node := range window {
+			path, command := project.Root, ""
+			switch node.Kind {
+			case yaml.ScalarNode:
+				command = scalarValue(&node)
+			case yaml.SequenceNode:
+				command = joinCommands(nodeStrings(&node))
+			case yaml.MappingNode:
+				var opts struct {
+					Root  string      `yaml:"root"`
+					Panes []yaml.Node `yaml:"panes"`
+				}
+				if err := node.Decode(&opts); err != nil {
+					return nil, fmt.Errorf("window %q: %w", name, err)
+				}
+				if opts.Root != "" {
+					path = opts.Root
+				}
+				if len(opts.Panes) > 0 {
+					command = tmuxinatorPaneCommand(&opts.Panes[0])
+				}
+			}
+			m.Sessions = append(m.Sessions, ManifestSession{
+				Name:    uniqueImportName(names, name),
+				Path:    path,
+				Command: command,
+			})
+		}
+	}
+	return m, nil
+}
+
+// tmuxinatorPaneCommand returns a pane's command: a string, a list of
+// commands, or a one-key map of pane name to either.
+func tmuxinatorPaneCommand(pane *yaml.Node) string {
+	switch pane.Kind {
+	case yaml.ScalarNode:
+		return scalarValue(pane)
+	case yaml.SequenceNode:
+		return joinCommands(nodeStrings(pane))
+	case yaml.MappingNode:
+		if len(pane.Content) == 2 {
+			return tmuxinatorPaneCommand(pane.Content[1])
+		}
+	}
+	return ""
+}
+
+// parseTmuxpSession maps each window of a tmuxp session (YAML or JSON) to a
+// session in a group named after it, like parseTmuxinatorProject.
+func parseTmuxpSession(data []byte) (*Manifest, error) {
+	var config struct {
+		SessionName    string `yaml:"session_name"`
+		StartDirectory string `yaml:"start_directory"`
+		Windows        []struct {
+			WindowName     string      `yaml:"window_name"`
+			StartDirectory string      `yaml:"start_directory"`
+			Panes          []yaml.Node `yaml:"panes"`
+		} `yaml:"windows"`
+	}
+	// YAML is a superset of JSON, so one decoder reads both
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{Group: config.SessionName}
+	names := make(map[string]int)
+	for i, window := range config.Windows {
+		path := window.StartDirectory
+		if path == "" {
+			path = config.StartDirectory
+		} else if !filepath.IsAbs(expandTilde(path)) && config.StartDirectory != "" {
+			path = filepath.Join(expandTilde(config.StartDirectory), path)
+		}
+		name := window.WindowName
+		if name == "" {
+			name = fmt.Sprintf("window %d", i+1)
+		}
+		command := ""
+		if len(window.Panes) > 0 {
+			command = tmuxpPaneCommand(&window.Panes[0])
+		}
+		m.Sessions = append(m.Sessions, ManifestSession{
+			Name:    uniqueImportName(names, name),
+			Path:    path,
+			Command: command,
+		})
+	}
+	return m, nil
+}
+
+// tmuxpPaneCommand returns a pane's command: a string, or the shell_command
+// (string or list) of a pane map. Blank panes are plain shells.
+func tmuxpPaneCommand(pane *yaml.Node) string {
+	switch pane.Kind {
+	case yaml.ScalarNode:
+		return scalarValue(pane)
+	case yaml.MappingNode:
+		var opts struct {
+			ShellCommand yaml.Node `yaml:"shell_command"`
+		}
+		if pane.Decode(&opts) != nil {
+			return ""
+		}
+		if opts.ShellCommand.Kind == yaml.SequenceNode {
+			return joinCommands(nodeStrings(&opts.ShellCommand))
+		}
+		return scalarValue(&opts.ShellCommand)
+	}
+	return ""
+}
+
+// scalarValue returns a scalar's value, "" for null ("~" or nothing).
+func scalarValue(node *yaml.Node) string {
+	if node.Tag == "!!null" {
+		return ""
+	}
+	return node.Value
+}
+
+// nodeStrings returns the scalar values of a YAML sequence.
+func nodeStrings(node *yaml.Node) []string {
+	var values []string
+	for _, item := range node.Content {
+		if v := scalarValue(item); item.Kind == yaml.ScalarNode && strings.TrimSpace(v) != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// joinCommands chains commands that the other tools type one after another.
+func joinCommands(commands []string) string {
+	return strings.Join(commands, " && ")
+}
+
+// uniqueImportName returns name, or name with a number when it was already
+// used, since manifest session names must be unique.
+func uniqueImportName(seen map[string]int, name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "session"
+	}
+	seen[name]++
+	if n := seen[name]; n > 1 {
+		return fmt.Sprintf("%s %d", name, n)
+	}
+	return name
+}
@@ -0,0 +1,177 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeImportFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestImportManifest_ClaudeSquad(t *testing.T) {
+	dir := t.TempDir()
+	worktree := filepath.Join(dir, "wt")
+	if err := os.Mkdir(worktree, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := writeImportFile(t, dir, "state.json", `{
+  "help_screens_seen": 3,
+  "instances": [
+    {"title": "fix", "path": "/repo", "program": "claude", "worktree": {"worktree_path": "`+worktree+`"}},
+    {"title": "fix", "path": "/repo", "program": "aider", "worktree": {"worktree_path": "/gone"}}
+  ]
+}`)
+
+	m, _, err := ImportManifest(ImportClaudeSquad, path, t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportManifest: %v", err)
+	}
+	want := []ManifestSession{
+		{Name: "fix", Path: worktree, Command: "claude", Group: "claude-squad"},
+		{Name: "fix 2", Path: "/repo", Command: "aider", Group: "claude-squad"},
+	}
+	if len(m.Sessions) != len(want) {
+		t.Fatalf("got %d sessions, want %d: %+v", len(m.Sessions), len(want), m.Sessions)
+	}
+	for i, w := range want {
+		if m.Sessions[i] != w {
+			t.Errorf("session %d = %+v, want %+v", i, m.Sessions[i], w)
+		}
+	}
+}
+
+func TestImportManifest_Tmuxinator(t *testing.T) {
+	dir := t.TempDir()
+	path := writeImportFile(t, dir, "blog.yml", `name: blog
+root: /src/blog
+windows:
+  - editor:
+      root: /src/blog/theme
+      layout: main-vertical
+      panes:
+        - vim
+        - guard
+  - server: bundle exec rails s
+  - setup:
+      - bundle
+      - rake db:migrate
+  - shell:
+  - logs:
+      panes:
+        - tail: tail -f log/development.log
+`)
+
+	m, _, err := ImportManifest(ImportTmuxinator, path, t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportManifest: %v", err)
+	}
+	want := []ManifestSession{
+		{Name: "editor", Path: "/src/blog/theme", Command: "vim", Group: "blog"},
+		{Name: "server", Path: "/src/blog", Command: "bundle exec rails s", Group: "blog"},
+		{Name: "setup", Path: "/src/blog", Command: "bundle && rake db:migrate", Group: "blog"},
+		{Name: "shell", Path: "/src/blog", Group: "blog"},
+		{Name: "logs", Path: "/src/blog", Command: "tail -f log/development.log", Group: "blog"},
+	}
+	if len(m.Sessions) != len(want) {
+		t.Fatalf("got %d sessions, want %d: %+v", len(m.Sessions), len(want), m.Sessions)
+	}
+	for i, w := range want {
+		if m.Sessions[i] != w {
+			t.Errorf("session %d = %+v, want %+v", i, m.Sessions[i], w)
+		}
+	}
+}
+
+func TestImportManifest_Tmuxp(t *testing.T) {
+	dir := t.TempDir()
+	path := writeImportFile(t, dir, "api.yaml", `session_name: api
+start_directory: /src/api
+windows:
+  - window_name: agent
+    panes:
+      - shell_command:
+          - source .venv/bin/activate
+          - claude
+  - window_name: tests
+    start_directory: tests
+    panes:
+      - pytest -f
+  - panes:
+      - null
+`)
+
+	m, _, err := ImportManifest(ImportTmuxp, path, t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportManifest: %v", err)
+	}
+	want := []ManifestSession{
+		{Name: "agent", Path: "/src/api", Command: "source .venv/bin/activate && claude", Group: "api"},
+		{Name: "tests", Path: "/src/api/tests", Command: "pytest -f", Group: "api"},
+		{Name: "window 3", Path: "/src/api", Group: "api"},
+	}
+	if len(m.Sessions) != len(want) {
+		t.Fatalf("got %d sessions, want %d: %+v", len(m.Sessions), len(want), m.Sessions)
+	}
+	for i, w := range want {
+		if m.Sessions[i] != w {
+			t.Errorf("session %d = %+v, want %+v", i, m.Sessions[i], w)
+		}
+	}
+}
+
+func TestImportManifest_RelativeRoots(t *testing.T) {
+	projects := t.TempDir()
+	cwd := t.TempDir()
+	writeImportFile(t, projects, "blog.yml", "name: blog\nwindows:\n  - editor: vim\n  - docs:\n      root: docs\n")
+	writeImportFile(t, projects, "api.yaml", "session_name: api\nstart_directory: src\nwindows:\n  - window_name: agent\n")
+
+	m, _, err := ImportManifest(ImportTmuxinator, filepath.Join(projects, "blog.yml"), cwd)
+	if err != nil {
+		t.Fatalf("ImportManifest: %v", err)
+	}
+	if got := m.Sessions[0].Path; got != cwd {
+		t.Errorf("a project without a root runs in %q, want the current directory %q", got, cwd)
+	}
+	if got, want := m.Sessions[1].Path, filepath.Join(cwd, "docs"); got != want {
+		t.Errorf("relative window root = %q, want %q", got, want)
+	}
+
+	m, _, err = ImportManifest(ImportTmuxp, filepath.Join(projects, "api.yaml"), cwd)
+	if err != nil {
+		t.Fatalf("ImportManifest: %v", err)
+	}
+	if got, want := m.Sessions[0].Path, filepath.Join(cwd, "src"); got != want {
+		t.Errorf("relative start_directory = %q, want %q", got, want)
+	}
+}
+
+func TestImportManifest_ProjectName(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", "")
+	projects := filepath.Join(home, ".tmuxinator")
+	if err := os.MkdirAll(projects, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeImportFile(t, projects, "blog.yml", "name: blog\nroot: /src/blog\nwindows:\n  - editor: vim\n")
+
+	if _, path, err := ImportManifest(ImportTmuxinator, "blog", home); err != nil || path != filepath.Join(projects, "blog.yml") {
+		t.Errorf("ImportManifest(blog) = %q, %v", path, err)
+	}
+	if _, _, err := ImportManifest(ImportTmuxinator, "missing", home); err == nil {
+		t.Error("expected an error for an unknown project")
+	}
+	if _, _, err := ImportManifest(ImportClaudeSquad, "", home); err == nil {
+		t.Error("expected an error without claude-squad state")
+	}
+	if _, _, err := ImportManifest("screen", "x", home); err == nil {
+		t.Error("expected an error for an unknown source")
+	}
+}
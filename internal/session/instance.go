@@ -34,6 +34,10 @@ const (
 	StatusIdle     Status = "idle"
 	StatusError    Status = "error"
 	StatusStarting Status = "starting" // Session is being created (tmux initializing)
+
+	// StatusNeedsInput: the agent is blocked on a permission/approval prompt.
+	// More urgent than StatusWaiting, and not cleared by acknowledging.
+	StatusNeedsInput Status = "needs_input"
 )
 
 const wrapperPlaceholder = "{command}"
@@ -67,10 +71,15 @@ type Instance struct {
 	Status         Status    `json:"status"`
 	CreatedAt      time.Time `json:"created_at"`
 	LastAccessedAt time.Time `json:"last_accessed_at,omitempty"` // When user last attached
+	Notes          string    `json:"notes,omitempty"`            // Free-form task context, shown in the preview header
+	Tags           []string  `json:"tags,omitempty"`             // User labels for filtering (see NormalizeTags)
 
 	// Claude Code integration
-	ClaudeSessionID  string    `json:"claude_session_id,omitempty"`
-	ClaudeDetectedAt time.Time `json:"claude_detected_at,omitempty"`
+	ClaudeSessionID    string    `json:"claude_session_id,omitempty"`
+	ClaudeDetectedAt   time.Time `json:"claude_detected_at,omitempty"`
+	claudeTranscriptID string    // ClaudeSessionID whose transcript was found on disk (forkable for good)
+	claudeRejectedID   string    // Watcher candidate rejected as a zombie at claudeRejectedAt
+	claudeRejectedAt   time.Time
 
 	// Gemini CLI integration
 	GeminiSessionID  string                  `json:"gemini_session_id,omitempty"`
@@ -94,10 +103,19 @@ type Instance struct {
 	LatestPrompt      string    `json:"latest_prompt,omitempty"`
 	lastPromptModTime time.Time // mtime cache for updateGeminiLatestPrompt (not serialized)
 
+	// DetectedModel is the model the agent last ran with, read from its
+	// transcript or, failing that, its pane. Display only
+	DetectedModel string `json:"detected_model,omitempty"`
+
+	// Fork lineage: the session this one was forked from and when
+	ForkedFromID string    `json:"forked_from_id,omitempty"`
+	ForkedAt     time.Time `json:"forked_at,omitempty"`
+
 	// JSONL tail-read cache: skip re-reading if file hasn't grown
 	lastJSONLSize int64
 	lastJSONLPath string
 	cachedPrompt  string
+	cachedModel   string
 
 	// MCP tracking - which MCPs were loaded when session started/restarted
 	// Used to detect pending MCPs (added after session start) and stale MCPs (removed but still running)
@@ -107,6 +125,34 @@ type Instance struct {
 	// JSON structure: {"tool": "claude", "options": {...}}
 	ToolOptionsJSON json.RawMessage `json:"tool_options,omitempty"`
 
+	// Per-session status timing overrides (0 = use [status] config)
+	PollInterval     time.Duration `json:"poll_interval,omitempty"`
+	ActivityCooldown time.Duration `json:"activity_cooldown,omitempty"`
+	lastStatusPoll   time.Time     // Last UpdateStatus that was not throttled by PollInterval
+
+	// NotifyMuted silences desktop notifications for this session
+	NotifyMuted bool `json:"notify_muted,omitempty"`
+
+	// ProjectEnv overrides [shell] project_env for this session: how its
+	// .envrc or .env is loaded before the agent starts ("" = config default).
+	// See ProjectEnvMode
+	ProjectEnv string `json:"project_env,omitempty"`
+
+	// PromptQueue holds prompts sent one at a time as the agent becomes
+	// ready (see TakeQueuedPrompt). Guarded by mu
+	PromptQueue       []string `json:"prompt_queue,omitempty"`
+	queueSentAt       time.Time
+	queueAwaitingBusy bool // Last queued prompt sent; not yet seen running
+
+	// AutoRestartMax restarts the session automatically, with backoff, up to
+	// this many times when its agent process or tmux session dies (0 = off).
+	// See TakeAutoRestart
+	AutoRestartMax  int       `json:"auto_restart_max,omitempty"`
+	autoRestarts    int       // Automatic restarts since the session last ran steadily
+	crashedAt       time.Time // When the current crash was seen; zero while up
+	lastAutoRestart time.Time
+	autoRestarting  bool // A restart is in progress
+
 	tmuxSession *tmux.Session // Internal tmux session
 
 	// Hook-based status detection (set by StatusFileWatcher from Claude Code hooks)
@@ -114,11 +160,24 @@ type Instance struct {
 	hookSessionID  string    // Session ID from hook payload
 	hookLastUpdate time.Time // When hook status was last received
 
-	// mu protects fields written by backgroundStatusUpdate and read by the TUI goroutine.
+	// mu protects fields written by status polling (Monitor) and read by the TUI goroutine.
 	// Use GetStatus()/SetStatus() and GetTool()/SetTool() for thread-safe access.
 	// UpdateStatus() acquires the write lock internally.
 	mu sync.RWMutex
 
+	// errorReason says why the session is in StatusError: the pane line that
+	// matched (rate limit, auth failure, crash) or the agent exiting to its
+	// shell. "" for other errors
+	errorReason string
+
+	// dead is set when the tmux session disappears while the session was
+	// alive and agent-deck didn't stop it: killed from another terminal, or
+	// lost with the tmux server. Cleared once the tmux session exists again
+	dead bool
+
+	// statusHistory remembers recent status changes for the preview pane
+	statusHistory statusHistory
+
 	// lastErrorCheck tracks when we last confirmed the session doesn't exist
 	// Used to skip expensive Exists() checks for ghost sessions (sessions in JSON but not in tmux)
 	// Not serialized - resets on load, but that's fine since we'll recheck on first poll
@@ -140,7 +199,7 @@ type Instance struct {
 }
 
 // GetStatusThreadSafe returns the session status with read-lock protection.
-// Use this when reading Status from a goroutine concurrent with backgroundStatusUpdate.
+// Use this when reading Status from a goroutine concurrent with status polling.
 func (inst *Instance) GetStatusThreadSafe() Status {
 	inst.mu.RLock()
 	s := inst.Status
@@ -148,10 +207,36 @@ func (inst *Instance) GetStatusThreadSafe() Status {
 	return s
 }
 
+// GetErrorReasonThreadSafe returns why the session is in an error status
+// (see errorReason), or "" when the cause is unknown.
+func (inst *Instance) GetErrorReasonThreadSafe() string {
+	inst.mu.RLock()
+	r := inst.errorReason
+	inst.mu.RUnlock()
+	return r
+}
+
+// IsDead reports whether the session's tmux session was killed outside
+// agent-deck, as opposed to never started or stopped from agent-deck.
+func (inst *Instance) IsDead() bool {
+	inst.mu.RLock()
+	defer inst.mu.RUnlock()
+	return inst.dead
+}
+
+// GetDetectedModelThreadSafe returns DetectedModel with read-lock protection.
+func (inst *Instance) GetDetectedModelThreadSafe() string {
+	inst.mu.RLock()
+	m := inst.DetectedModel
+	inst.mu.RUnlock()
+	return m
+}
+
 // SetStatusThreadSafe sets the session status with write-lock protection.
 func (inst *Instance) SetStatusThreadSafe(s Status) {
 	inst.mu.Lock()
 	inst.Status = s
+	inst.recordStatusLocked()
 	inst.mu.Unlock()
 }
 
@@ -170,6 +255,21 @@ func (inst *Instance) SetToolThreadSafe(t string) {
 	inst.mu.Unlock()
 }
 
+// GetNotifyMutedThreadSafe returns NotifyMuted with read-lock protection.
+func (inst *Instance) GetNotifyMutedThreadSafe() bool {
+	inst.mu.RLock()
+	m := inst.NotifyMuted
+	inst.mu.RUnlock()
+	return m
+}
+
+// SetNotifyMutedThreadSafe sets NotifyMuted with write-lock protection.
+func (inst *Instance) SetNotifyMutedThreadSafe(muted bool) {
+	inst.mu.Lock()
+	inst.NotifyMuted = muted
+	inst.mu.Unlock()
+}
+
 // MarkAccessed updates the LastAccessedAt timestamp to now
 func (inst *Instance) MarkAccessed() {
 	inst.LastAccessedAt = time.Now()
@@ -235,6 +335,7 @@ func NewInstance(title, projectPath string) *Instance {
 	tmuxSess := tmux.NewSession(title, projectPath)
 	tmuxSess.InstanceID = id // Pass instance ID for activity hooks
 	tmuxSess.SetInjectStatusLine(GetTmuxSettings().GetInjectStatusLine())
+	tmuxSess.SetActivityCooldown(GetStatusSettings().GetActivityCooldown())
 
 	return &Instance{
 		ID:          id,
@@ -261,6 +362,7 @@ func NewInstanceWithTool(title, projectPath, tool string) *Instance {
 	tmuxSess := tmux.NewSession(title, projectPath)
 	tmuxSess.InstanceID = id // Pass instance ID for activity hooks
 	tmuxSess.SetInjectStatusLine(GetTmuxSettings().GetInjectStatusLine())
+	tmuxSess.SetActivityCooldown(GetStatusSettings().GetActivityCooldown())
 
 	inst := &Instance{
 		ID:          id,
@@ -1259,6 +1361,22 @@ func (i *Instance) applyWrapper(command string) (string, error) {
 	return wrapper, nil
 }
 
+// applyTmuxConfig copies tmux settings from config.toml onto the tmux session
+// before it starts: option overrides from [tmux], the tool and the session's
+// group (e.g. allow-passthrough = "all"), the tool's side panes and pane
+// output logging.
+func (i *Instance) applyTmuxConfig() {
+	def := GetToolDef(i.Tool)
+	if opts := GetTmuxSettings().SessionOptions(def, i.GroupPath); len(opts) > 0 {
+		i.tmuxSession.OptionOverrides = opts
+	}
+	i.tmuxSession.LogOutput = GetLogSettings().PaneOutput
+	if def != nil {
+		i.tmuxSession.SidePanes = def.Panes
+		i.tmuxSession.PaneLayout = def.PaneLayout
+	}
+}
+
 // loadCustomPatternsFromConfig loads detection patterns from built-in defaults + config.toml
 // overrides, and sets them on the tmux session for status detection and tool auto-detection.
 // Works for ALL tools: built-in (claude, gemini, opencode, codex) and custom.
@@ -1325,10 +1443,7 @@ func (i *Instance) Start() error {
 	// Load custom patterns for status detection
 	i.loadCustomPatternsFromConfig()
 
-	// Apply user tmux option overrides from config (e.g. allow-passthrough = "all")
-	if tmuxCfg := GetTmuxSettings(); len(tmuxCfg.Options) > 0 {
-		i.tmuxSession.OptionOverrides = tmuxCfg.Options
-	}
+	i.applyTmuxConfig()
 
 	// Start the tmux session
 	if err := i.tmuxSession.Start(command); err != nil {
@@ -1409,10 +1524,7 @@ func (i *Instance) StartWithMessage(message string) error {
 	// Load custom patterns for status detection
 	i.loadCustomPatternsFromConfig()
 
-	// Apply user tmux option overrides from config (e.g. allow-passthrough = "all")
-	if tmuxCfg := GetTmuxSettings(); len(tmuxCfg.Options) > 0 {
-		i.tmuxSession.OptionOverrides = tmuxCfg.Options
-	}
+	i.applyTmuxConfig()
 
 	// Start the tmux session
 	if err := i.tmuxSession.Start(command); err != nil {
@@ -1536,11 +1648,57 @@ func hookFastPathFreshnessForTool(tool, hookStatus string) time.Duration {
 	}
 }
 
+// EffectivePollInterval returns the session's poll interval override, or the
+// [status] poll interval if none is set.
+func (i *Instance) EffectivePollInterval() time.Duration {
+	if i.PollInterval > 0 {
+		return ClampPollInterval(i.PollInterval)
+	}
+	return GetStatusSettings().GetPollInterval()
+}
+
+// EffectiveActivityCooldown returns the session's activity cooldown override,
+// or the [status] activity cooldown if none is set.
+func (i *Instance) EffectiveActivityCooldown() time.Duration {
+	if i.ActivityCooldown > 0 {
+		return i.ActivityCooldown
+	}
+	return GetStatusSettings().GetActivityCooldown()
+}
+
+// ApplyActivityCooldown pushes the effective activity cooldown to the tmux
+// session. Call after changing ActivityCooldown.
+func (i *Instance) ApplyActivityCooldown() {
+	if i.tmuxSession != nil {
+		i.tmuxSession.SetActivityCooldown(i.EffectiveActivityCooldown())
+	}
+}
+
 // UpdateStatus updates the session status by checking tmux.
 // Thread-safe: acquires write lock to protect Status, Tool, and internal cache fields.
 func (i *Instance) UpdateStatus() error {
+	return i.updateStatus(true)
+}
+
+// updateStatus is UpdateStatus. With throttle false it ignores PollInterval,
+// for the Monitor, which already schedules each session by its interval.
+func (i *Instance) updateStatus(throttle bool) error {
 	i.mu.Lock()
 	defer i.mu.Unlock()
+	defer i.recordStatusLocked()
+
+	// Per-session poll interval: skip polls until the override has elapsed.
+	// The global interval is enforced by the caller's ticker.
+	if i.PollInterval > 0 {
+		if throttle && !i.lastStatusPoll.IsZero() && time.Since(i.lastStatusPoll) < i.PollInterval {
+			return nil
+		}
+		i.lastStatusPoll = time.Now()
+	}
+	i.errorReason = ""
+	if i.Status != StatusError {
+		i.crashedAt = time.Time{}
+	}
 
 	// Short grace period for tmux initialization (not Claude startup)
 	// Use lastStartTime for accuracy on restarts, fallback to CreatedAt
@@ -1562,6 +1720,7 @@ func (i *Instance) UpdateStatus() error {
 	}
 
 	if i.tmuxSession == nil {
+		i.markCrashedLocked()
 		i.Status = StatusError
 		return nil
 	}
@@ -1576,6 +1735,11 @@ func (i *Instance) UpdateStatus() error {
 
 	// Check if tmux session exists
 	if !i.tmuxSession.Exists() {
+		// Alive at the last poll, and Kill would have set StatusError
+		if i.Status != StatusError {
+			i.dead = true
+		}
+		i.markCrashedLocked()
 		i.Status = StatusError
 		i.lastErrorCheck = time.Now() // Record when we confirmed error
 		return nil
@@ -1583,6 +1747,24 @@ func (i *Instance) UpdateStatus() error {
 
 	// Session exists - clear error check timestamp
 	i.lastErrorCheck = time.Time{}
+	i.dead = false
+
+	// The agent process died (tmux pane-died hook): error right away, ahead of
+	// the idle skip and hook fast path, which would keep the old status
+	if i.tmuxSession.Alert() == tmux.AlertPaneDied {
+		i.markCrashedLocked()
+		i.Status = StatusError
+		return nil
+	}
+
+	// The agent exited back to its shell. The pane lives on, so neither check
+	// above sees it, and the hook fast path would keep the last hook status
+	if i.tmuxSession.AgentExited() {
+		i.markCrashedLocked()
+		i.Status = StatusError
+		i.errorReason = i.Tool + " exited"
+		return nil
+	}
 
 	// Tiered polling: skip expensive checks for idle sessions with no new activity
 	if i.Status == StatusIdle {
@@ -1632,6 +1814,8 @@ func (i *Instance) UpdateStatus() error {
 		case "dead":
 			i.Status = StatusError
 		}
+		i.promoteApprovalPromptLocked()
+		i.detectOutputErrorLocked()
 		if i.hookSessionID != "" {
 			switch i.Tool {
 			case "claude":
@@ -1646,6 +1830,12 @@ func (i *Instance) UpdateStatus() error {
 				}
 			}
 		}
+		if i.Status == StatusRunning || i.Status == StatusWaiting || i.Status == StatusNeedsInput {
+			if i.Tool == "claude" {
+				i.refreshClaudeTail()
+			}
+			i.updateDetectedModelLocked()
+		}
 		return nil
 	}
 
@@ -1684,8 +1874,11 @@ func (i *Instance) UpdateStatus() error {
 		i.Tool = detectedTool
 	}
 
+	i.promoteApprovalPromptLocked()
+	i.detectOutputErrorLocked()
+
 	// Update session tracking only for active/waiting sessions (skip idle - nothing changes)
-	if i.Status == StatusRunning || i.Status == StatusWaiting {
+	if i.Status == StatusRunning || i.Status == StatusWaiting || i.Status == StatusNeedsInput {
 		// Update Claude session tracking (non-blocking, best-effort)
 		i.UpdateClaudeSession(nil)
 
@@ -1702,11 +1895,84 @@ func (i *Instance) UpdateStatus() error {
 			}
 			i.UpdateCodexSession(exclude)
 		}
+
+		i.updateDetectedModelLocked()
 	}
 
 	return nil
 }
 
+// updateDetectedModelLocked refreshes DetectedModel from the transcript
+// where agent-deck reads one (Claude, Gemini), else from the pane. A /model
+// switch shows up once the agent has answered with the new model.
+// Caller holds i.mu; it is released around the pane capture.
+func (i *Instance) updateDetectedModelLocked() {
+	var model string
+	switch i.Tool {
+	case "claude":
+		model = i.cachedModel
+	case "gemini":
+		if i.GeminiAnalytics != nil {
+			model = i.GeminiAnalytics.Model
+		}
+		if model == "" {
+			model = i.GeminiModel
+		}
+	}
+	if model == "" && i.tmuxSession != nil {
+		tmuxSess := i.tmuxSession
+		i.mu.Unlock()
+		model = tmuxSess.DetectModel()
+		i.mu.Lock()
+	}
+	if model != "" {
+		i.DetectedModel = model
+	}
+}
+
+// promoteApprovalPromptLocked turns a waiting or idle status into
+// StatusNeedsInput when the pane shows a permission/approval prompt. tmux
+// status detection folds these into "waiting", and acknowledging must not
+// hide them: the agent stays blocked until the user answers.
+// Caller holds i.mu; it is released around the pane capture.
+func (i *Instance) promoteApprovalPromptLocked() {
+	if i.Status != StatusWaiting && i.Status != StatusIdle {
+		return
+	}
+	if i.Tool == "shell" || i.tmuxSession == nil {
+		return
+	}
+	tmuxSess := i.tmuxSession
+	i.mu.Unlock()
+	approval := tmuxSess.HasApprovalPrompt()
+	i.mu.Lock()
+	if approval {
+		i.Status = StatusNeedsInput
+	}
+}
+
+// detectOutputErrorLocked turns a waiting or idle status into StatusError when
+// the bottom of the pane shows one of the tool's error patterns (rate limit,
+// auth failure, crash). Agents usually fall back to their prompt after these,
+// which would otherwise read as an ordinary finished turn.
+// Caller holds i.mu; it is released around the pane capture.
+func (i *Instance) detectOutputErrorLocked() {
+	if i.Status != StatusWaiting && i.Status != StatusIdle {
+		return
+	}
+	if i.tmuxSession == nil {
+		return
+	}
+	tmuxSess := i.tmuxSession
+	i.mu.Unlock()
+	reason := tmuxSess.ErrorOutput()
+	i.mu.Lock()
+	if reason != "" {
+		i.Status = StatusError
+		i.errorReason = reason
+	}
+}
+
 // UpdateClaudeSession updates the Claude session ID from tmux environment.
 // The capture-resume pattern (used in Start/Fork/Restart) sets CLAUDE_SESSION_ID
 // in the tmux environment, making this the single authoritative source.
@@ -1739,14 +2005,69 @@ func (i *Instance) UpdateClaudeSession(excludeIDs map[string]bool) {
 		i.ClaudeDetectedAt = time.Now()
 	}
 
-	// Update latest prompt from JSONL file (tail-read with size caching)
-	if i.ClaudeSessionID != "" {
-		jsonlPath := i.GetJSONLPath()
-		if jsonlPath != "" {
-			if prompt := i.readJSONLTail(jsonlPath); prompt != "" {
-				i.LatestPrompt = prompt
-			}
-		}
+	i.refreshClaudeTail()
+}
+
+// refreshClaudeTail updates the latest prompt and model from the tail of the
+// Claude JSONL file (tail-read with size caching).
+func (i *Instance) refreshClaudeTail() {
+	if i.ClaudeSessionID == "" {
+		return
+	}
+	jsonlPath := i.GetJSONLPath()
+	if jsonlPath == "" {
+		return
+	}
+	i.claudeTranscriptID = i.ClaudeSessionID
+	if prompt := i.readJSONLTail(jsonlPath); prompt != "" {
+		i.LatestPrompt = prompt
+	}
+}
+
+// SyncClaudeSessionFromWatcher keeps ClaudeSessionID current from the
+// transcript writes w has seen. Claude starts a new session file on /clear;
+// hooks report that, so without fresh hook data a newer file written while
+// this session is active is adopted. skip excludes IDs other sessions own.
+// Finding the transcript of the current ID keeps CanFork true.
+func (i *Instance) SyncClaudeSessionFromWatcher(w *ClaudeTranscriptWatcher, skip func(id string) bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.Tool != "claude" {
+		return
+	}
+	w.Watch(i.ProjectPath)
+
+	hooksFresh := i.hookStatus != "" && time.Since(i.hookLastUpdate) < hookFastPathWindow
+	active := i.Status == StatusRunning || i.Status == StatusWaiting || i.Status == StatusNeedsInput
+	if !hooksFresh && active {
+		i.adoptWatchedClaudeSessionLocked(w, skip)
+	}
+	if i.ClaudeSessionID != "" && w.Seen(i.ProjectPath, i.ClaudeSessionID) {
+		i.claudeTranscriptID = i.ClaudeSessionID
+	}
+}
+
+// adoptWatchedClaudeSessionLocked switches to the project's most recently
+// written transcript if it isn't the current one, with the same zombie gate
+// as SyncClaudeSessionFromDisk. Caller holds i.mu.
+func (i *Instance) adoptWatchedClaudeSessionLocked(w *ClaudeTranscriptWatcher, skip func(id string) bool) {
+	latest, at := w.Latest(i.ProjectPath, skip)
+	if latest == "" || latest == i.ClaudeSessionID || time.Since(at) > 5*time.Minute {
+		return
+	}
+	if latest == i.claudeRejectedID && at.Equal(i.claudeRejectedAt) {
+		return // Unchanged since it was rejected
+	}
+	if i.ClaudeSessionID != "" && !sessionHasConversationData(latest, i.ProjectPath) {
+		i.claudeRejectedID, i.claudeRejectedAt = latest, at
+		return
+	}
+
+	sessionLog.Debug("claude_session_update_from_watcher", slog.String("old_id", i.ClaudeSessionID), slog.String("new_id", latest))
+	i.ClaudeSessionID = latest
+	i.ClaudeDetectedAt = time.Now()
+	if i.tmuxSession != nil && i.tmuxSession.Exists() {
+		_ = i.tmuxSession.SetEnvironment("CLAUDE_SESSION_ID", latest)
 	}
 }
 
@@ -1780,15 +2101,21 @@ func (i *Instance) collectOtherClaudeSessionIDs() map[string]bool {
 	return exclude
 }
 
-// syncClaudeSessionFromDisk scans the filesystem for the most recent session file,
+// SyncClaudeSessionFromDisk scans the filesystem for the most recent session file,
 // excluding IDs owned by other agent-deck instances. If a different (newer) session
 // is found, it updates ClaudeSessionID, ClaudeDetectedAt, and the tmux env var.
 // This handles the case where /clear in Claude Code creates a new session UUID
-// that the tmux env var doesn't know about yet.
-func (i *Instance) syncClaudeSessionFromDisk() {
+// that the tmux env var doesn't know about yet. Either way, a transcript on
+// disk for the resulting ID makes it forkable (see CanFork).
+func (i *Instance) SyncClaudeSessionFromDisk() {
 	if i.Tool != "claude" {
 		return
 	}
+	defer func() {
+		if i.GetJSONLPath() != "" {
+			i.claudeTranscriptID = i.ClaudeSessionID
+		}
+	}()
 
 	configDir := GetClaudeConfigDir()
 	exclude := i.collectOtherClaudeSessionIDs()
@@ -2128,7 +2455,8 @@ func (i *Instance) Preview() (string, error) {
 	return strings.Join(lines, "\n"), nil
 }
 
-// PreviewFull returns all terminal output
+// PreviewFull returns all terminal output. While status checks see the pane
+// unchanged, the last capture is reused instead of running capture-pane.
 func (i *Instance) PreviewFull() (string, error) {
 	if i.tmuxSession == nil {
 		return "", fmt.Errorf("tmux session not initialized")
@@ -2137,6 +2465,15 @@ func (i *Instance) PreviewFull() (string, error) {
 	return i.tmuxSession.CaptureFullHistory()
 }
 
+// PreviewStyled returns all terminal output with its colors kept
+func (i *Instance) PreviewStyled() (string, error) {
+	if i.tmuxSession == nil {
+		return "", fmt.Errorf("tmux session not initialized")
+	}
+
+	return i.tmuxSession.CaptureStyledHistory()
+}
+
 // HasUpdated checks if there's new output since last check
 func (i *Instance) HasUpdated() bool {
 	if i.tmuxSession == nil {
@@ -2373,6 +2710,32 @@ func parseClaudeLastAssistantMessage(data []byte, sessionID string) (*ResponseOu
 	}, nil
 }
 
+// parseClaudeLatestModel returns the model of the last assistant message in
+// Claude JSONL data, or "". Synthetic messages (model "<synthetic>") don't
+// count.
+func parseClaudeLatestModel(data []byte) string {
+	var record struct {
+		Message struct {
+			Role  string `json:"role"`
+			Model string `json:"model"`
+		} `json:"message"`
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	for n := len(lines) - 1; n >= 0; n-- {
+		if !bytes.Contains(lines[n], []byte(`"model"`)) {
+			continue
+		}
+		record.Message.Role, record.Message.Model = "", ""
+		if err := json.Unmarshal(lines[n], &record); err != nil {
+			continue
+		}
+		if record.Message.Role == "assistant" && record.Message.Model != "" && !strings.HasPrefix(record.Message.Model, "<") {
+			return record.Message.Model
+		}
+	}
+	return ""
+}
+
 // parseClaudeLatestUserPrompt parses a Claude JSONL file to extract the last user message
 func parseClaudeLatestUserPrompt(data []byte) (string, error) {
 	// JSONL record structure
@@ -2451,6 +2814,7 @@ func parseClaudeLatestUserPrompt(data []byte) (string, error) {
 }
 
 // readJSONLTail reads the last user prompt from a JSONL file using tail-read with size caching.
+// The last assistant model in the tail is kept in cachedModel.
 // Instead of reading the entire file (can be 100-800MB), it:
 // 1. Stats the file to get current size (cheap syscall)
 // 2. Skips reading entirely if size hasn't changed since last check
@@ -2497,6 +2861,10 @@ func (i *Instance) readJSONLTail(path string) string {
 		}
 	}
 
+	if model := parseClaudeLatestModel(data); model != "" {
+		i.cachedModel = model
+	}
+
 	prompt, err := parseClaudeLatestUserPrompt(data)
 	if err != nil || prompt == "" {
 		// Update cache even on empty result to avoid re-reading
@@ -2679,9 +3047,8 @@ func parseGeminiOutput(content string) (*ResponseOutput, error) {
 
 	// Clean up the response
 	response := strings.TrimSpace(strings.Join(responseLines, "\n"))
-	// Remove ANSI codes
-	ansiRegex := regexp.MustCompile(`\x1b\[[0-9;]*m`)
-	response = ansiRegex.ReplaceAllString(response, "")
+	// Remove escape sequences
+	response = tmux.StripANSI(response)
 
 	return &ResponseOutput{
 		Tool:    "gemini",
@@ -2738,8 +3105,7 @@ func parseGenericOutput(content, tool string) (*ResponseOutput, error) {
 
 	// Clean up
 	response := strings.TrimSpace(strings.Join(responseLines, "\n"))
-	ansiRegex := regexp.MustCompile(`\x1b\[[0-9;]*m`)
-	response = ansiRegex.ReplaceAllString(response, "")
+	response = tmux.StripANSI(response)
 
 	return &ResponseOutput{
 		Tool:    tool,
@@ -2757,7 +3123,10 @@ func (i *Instance) Kill() error {
 	if err := i.tmuxSession.Kill(); err != nil {
 		return fmt.Errorf("failed to kill tmux session: %w", err)
 	}
+	i.mu.Lock()
 	i.Status = StatusError
+	i.dead = false
+	i.mu.Unlock()
 	return nil
 }
 
@@ -2784,7 +3153,7 @@ func (i *Instance) Restart() error {
 
 	// Sync Claude session from disk before restart to pick up /clear session changes
 	if i.Tool == "claude" {
-		i.syncClaudeSessionFromDisk()
+		i.SyncClaudeSessionFromDisk()
 	}
 
 	// If Claude session with known ID AND tmux session exists, use respawn-pane
@@ -2976,6 +3345,7 @@ func (i *Instance) Restart() error {
 	i.tmuxSession = tmux.NewSession(i.Title, i.ProjectPath)
 	i.tmuxSession.InstanceID = i.ID // Pass instance ID for activity hooks
 	i.tmuxSession.SetInjectStatusLine(GetTmuxSettings().GetInjectStatusLine())
+	i.ApplyActivityCooldown()
 
 	var command string
 	if i.Tool == "claude" && i.ClaudeSessionID != "" {
@@ -3020,10 +3390,7 @@ func (i *Instance) Restart() error {
 	// Load custom patterns for status detection (for custom tools)
 	i.loadCustomPatternsFromConfig()
 
-	// Apply user tmux option overrides from config (e.g. allow-passthrough = "all")
-	if tmuxCfg := GetTmuxSettings(); len(tmuxCfg.Options) > 0 {
-		i.tmuxSession.OptionOverrides = tmuxCfg.Options
-	}
+	i.applyTmuxConfig()
 
 	mcpLog.Debug("restart_starting_new_session", slog.String("command", command))
 
@@ -3194,11 +3561,12 @@ func (i *Instance) CanFork() bool {
 		return i.CanForkOpenCode()
 	}
 
-	// Claude sessions can fork if session ID is recent
+	// Claude sessions can fork once their transcript is found on disk, or
+	// while the session ID is freshly detected
 	if i.ClaudeSessionID == "" {
 		return false
 	}
-	return time.Since(i.ClaudeDetectedAt) < 5*time.Minute
+	return i.claudeTranscriptID == i.ClaudeSessionID || time.Since(i.ClaudeDetectedAt) < 5*time.Minute
 }
 
 // CanForkOpenCode returns true if this OpenCode session can be forked
@@ -3219,7 +3587,7 @@ func (i *Instance) Fork(newTitle, newGroupPath string) (string, error) {
 // stores in tmux environment, then resumes interactively
 func (i *Instance) ForkWithOptions(newTitle, newGroupPath string, opts *ClaudeOptions) (string, error) {
 	// Sync session from disk to pick up /clear session changes before forking
-	i.syncClaudeSessionFromDisk()
+	i.SyncClaudeSessionFromDisk()
 
 	if !i.CanFork() {
 		return "", fmt.Errorf("cannot fork: no active Claude session")
@@ -3303,6 +3671,8 @@ func (i *Instance) CreateForkedInstanceWithOptions(newTitle, newGroupPath string
 	}
 	forked.Command = cmd
 	forked.Tool = "claude"
+	forked.ForkedFromID = i.ID
+	forked.ForkedAt = time.Now()
 
 	// Store options in the new instance for persistence
 	if opts != nil {
@@ -3433,6 +3803,8 @@ func (i *Instance) CreateForkedOpenCodeInstanceWithOptions(newTitle, newGroupPat
 	}
 	forked.Command = cmd
 	forked.Tool = "opencode"
+	forked.ForkedFromID = i.ID
+	forked.ForkedAt = time.Now()
 
 	// Store options in the new instance for persistence
 	if opts != nil {
@@ -3452,6 +3824,28 @@ func (i *Instance) Exists() bool {
 	return i.tmuxSession.Exists()
 }
 
+// Resurrect recreates the tmux session of a stored session whose tmux
+// session is gone, e.g. after a reboot. It starts in the same project path and
+// resumes the agent's conversation when its ID is known (Claude: --resume
+// with ClaudeSessionID), like Restart does for dead sessions.
+func (i *Instance) Resurrect() error {
+	if i.Exists() {
+		return fmt.Errorf("session %q is still running", i.Title)
+	}
+	return i.Restart()
+}
+
+// GoneSessions returns the sessions whose tmux session no longer exists.
+func GoneSessions(instances []*Instance) []*Instance {
+	var gone []*Instance
+	for _, inst := range instances {
+		if !inst.Exists() {
+			gone = append(gone, inst)
+		}
+	}
+	return gone
+}
+
 // GetTmuxSession returns the tmux session object
 func (i *Instance) GetTmuxSession() *tmux.Session {
 	return i.tmuxSession
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 )
 
 // TestNewSessionStatusFlicker tests for green flicker on new session creation
@@ -657,7 +659,7 @@ func TestInstance_UpdateClaudeSession_PreservesExistingID(t *testing.T) {
 }
 
 // TestSyncClaudeSessionFromDisk_PicksUpNewerSession verifies that when a newer
-// session file appears on disk (e.g., after /clear), syncClaudeSessionFromDisk
+// session file appears on disk (e.g., after /clear), SyncClaudeSessionFromDisk
 // updates the instance's ClaudeSessionID.
 func TestSyncClaudeSessionFromDisk_PicksUpNewerSession(t *testing.T) {
 	configDir := t.TempDir()
@@ -702,7 +704,7 @@ func TestSyncClaudeSessionFromDisk_PicksUpNewerSession(t *testing.T) {
 	inst.ClaudeSessionID = oldSessionID
 	inst.ClaudeDetectedAt = time.Now().Add(-1 * time.Minute)
 
-	inst.syncClaudeSessionFromDisk()
+	inst.SyncClaudeSessionFromDisk()
 
 	if inst.ClaudeSessionID != newSessionID {
 		t.Errorf("ClaudeSessionID = %q, want %q (newer session from disk)", inst.ClaudeSessionID, newSessionID)
@@ -743,7 +745,7 @@ func TestSyncClaudeSessionFromDisk_NoChangeWhenCurrent(t *testing.T) {
 	inst.ClaudeSessionID = currentID
 	inst.ClaudeDetectedAt = originalDetectedAt
 
-	inst.syncClaudeSessionFromDisk()
+	inst.SyncClaudeSessionFromDisk()
 
 	if inst.ClaudeSessionID != currentID {
 		t.Errorf("ClaudeSessionID changed to %q, should remain %q", inst.ClaudeSessionID, currentID)
@@ -794,7 +796,7 @@ func TestSyncClaudeSessionFromDisk_IgnoresAgentFiles(t *testing.T) {
 	inst.ClaudeSessionID = realSession
 	inst.ClaudeDetectedAt = time.Now().Add(-1 * time.Minute)
 
-	inst.syncClaudeSessionFromDisk()
+	inst.SyncClaudeSessionFromDisk()
 
 	if inst.ClaudeSessionID != realSession {
 		t.Errorf("ClaudeSessionID = %q, want %q (agent files should be ignored)", inst.ClaudeSessionID, realSession)
@@ -805,9 +807,9 @@ func TestSyncClaudeSessionFromDisk_IgnoresAgentFiles(t *testing.T) {
 func TestSyncClaudeSessionFromDisk_SkipsNonClaude(t *testing.T) {
 	inst := NewInstanceWithTool("shell-test", "/tmp", "shell")
 	inst.ClaudeSessionID = "should-not-change"
-	inst.syncClaudeSessionFromDisk()
+	inst.SyncClaudeSessionFromDisk()
 	if inst.ClaudeSessionID != "should-not-change" {
-		t.Error("syncClaudeSessionFromDisk should be a no-op for non-claude tools")
+		t.Error("SyncClaudeSessionFromDisk should be a no-op for non-claude tools")
 	}
 }
 
@@ -855,7 +857,7 @@ func TestSyncClaudeSessionFromDisk_RejectsZombie(t *testing.T) {
 	inst.ClaudeSessionID = realID
 	inst.ClaudeDetectedAt = time.Now().Add(-1 * time.Minute)
 
-	inst.syncClaudeSessionFromDisk()
+	inst.SyncClaudeSessionFromDisk()
 
 	if inst.ClaudeSessionID != realID {
 		t.Errorf("ClaudeSessionID = %q, want %q (real session should NOT be replaced by zombie)", inst.ClaudeSessionID, realID)
@@ -906,7 +908,7 @@ func TestSyncClaudeSessionFromDisk_AcceptsRealOverZombie(t *testing.T) {
 	inst.ClaudeSessionID = zombieID
 	inst.ClaudeDetectedAt = time.Now().Add(-1 * time.Minute)
 
-	inst.syncClaudeSessionFromDisk()
+	inst.SyncClaudeSessionFromDisk()
 
 	if inst.ClaudeSessionID != realID {
 		t.Errorf("ClaudeSessionID = %q, want %q (zombie should be upgraded to real session)", inst.ClaudeSessionID, realID)
@@ -956,7 +958,7 @@ func TestSyncClaudeSessionFromDisk_RejectsBothZombies(t *testing.T) {
 	inst.ClaudeSessionID = zombieA
 	inst.ClaudeDetectedAt = time.Now().Add(-1 * time.Minute)
 
-	inst.syncClaudeSessionFromDisk()
+	inst.SyncClaudeSessionFromDisk()
 
 	if inst.ClaudeSessionID != zombieA {
 		t.Errorf("ClaudeSessionID = %q, want %q (should not swap between zombies)", inst.ClaudeSessionID, zombieA)
@@ -2310,3 +2312,69 @@ func TestInstance_SetAcknowledgedFromShared_WaitingApplied(t *testing.T) {
 		t.Fatal("waiting session should apply shared acknowledged=true")
 	}
 }
+
+func TestInstance_Resurrect(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	inst := NewInstance("resurrect-test", t.TempDir())
+	inst.Command = "echo back"
+	running := NewInstance("resurrect-running", t.TempDir())
+	if err := running.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer func() { _ = running.Kill() }()
+
+	// Never started, as after a reboot: only inst is gone
+	gone := GoneSessions([]*Instance{inst, running})
+	if len(gone) != 1 || gone[0] != inst {
+		t.Fatalf("GoneSessions = %v, want only %q", gone, inst.Title)
+	}
+
+	if err := inst.Resurrect(); err != nil {
+		t.Fatalf("Resurrect: %v", err)
+	}
+	defer func() { _ = inst.Kill() }()
+	if !inst.Exists() {
+		t.Fatal("resurrected session should exist")
+	}
+	if err := inst.Resurrect(); err == nil {
+		t.Error("Resurrect of a running session should fail")
+	}
+}
+
+func TestInstance_DeadWhenKilledOutside(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	inst := NewInstance("test-dead", "/tmp")
+	inst.Command = "sleep 60"
+	if err := inst.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer func() { _ = inst.Kill() }()
+	inst.lastStartTime = time.Now().Add(-time.Minute) // Past the startup grace period
+	_ = inst.UpdateStatus()
+
+	name := inst.GetTmuxSession().Name
+	if err := tmux.Command("kill-session", "-t", name).Run(); err != nil {
+		t.Fatalf("kill-session: %v", err)
+	}
+	_ = inst.UpdateStatus()
+	if inst.GetStatusThreadSafe() != StatusError || !inst.IsDead() {
+		t.Errorf("a session killed outside agent-deck should be dead, status %s", inst.GetStatusThreadSafe())
+	}
+
+	// Stopping it from agent-deck isn't a death
+	if err := inst.Start(); err != nil {
+		t.Fatalf("restart failed: %v", err)
+	}
+	inst.lastStartTime = time.Now().Add(-time.Minute)
+	_ = inst.UpdateStatus()
+	if inst.IsDead() {
+		t.Error("a running session should not be dead")
+	}
+	_ = inst.Kill()
+	_ = inst.UpdateStatus()
+	if inst.IsDead() {
+		t.Error("a session stopped with Kill should not be dead")
+	}
+}
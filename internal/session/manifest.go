@@ -0,0 +1,113 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileNames are the file names `agent-deck up` looks for, in order,
+// when no manifest path is given.
+var ManifestFileNames = []string{
+	"agent-deck.yaml",
+	"agent-deck.yml",
+	"agent-deck.json",
+	".agent-deck.yaml",
+	".agent-deck.yml",
+	".agent-deck.json",
+}
+
+// Manifest describes a set of sessions that can be checked into a repository
+// and created idempotently with `agent-deck up`.
+type Manifest struct {
+	// Group is the default group for sessions that don't set their own
+	Group    string            `yaml:"group,omitempty" json:"group,omitempty"`
+	Sessions []ManifestSession `yaml:"sessions" json:"sessions"`
+}
+
+// ManifestSession is a single session entry in a Manifest
+type ManifestSession struct {
+	Name    string `yaml:"name" json:"name"`
+	Path    string `yaml:"path,omitempty" json:"path,omitempty"` // Relative paths resolve against the manifest directory
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+	Group   string `yaml:"group,omitempty" json:"group,omitempty"`
+	Wrapper string `yaml:"wrapper,omitempty" json:"wrapper,omitempty"`
+}
+
+// FindManifest returns the first manifest file found in dir, or "" if none exists.
+func FindManifest(dir string) string {
+	for _, name := range ManifestFileNames {
+		path := filepath.Join(dir, name)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+	}
+	return ""
+}
+
+// LoadManifest reads and validates a manifest. JSON is used for .json files,
+// YAML otherwise. Session paths are resolved to absolute paths relative to the
+// manifest's directory, and the default group is applied.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &m)
+	} else {
+		err = yaml.Unmarshal(data, &m)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+
+	baseDir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve manifest directory: %w", err)
+	}
+	if err := m.normalize(baseDir); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// normalize validates entries, resolves paths against baseDir, and fills in
+// the default group.
+func (m *Manifest) normalize(baseDir string) error {
+	if len(m.Sessions) == 0 {
+		return fmt.Errorf("no sessions defined")
+	}
+
+	seen := make(map[string]bool, len(m.Sessions))
+	for i := range m.Sessions {
+		s := &m.Sessions[i]
+		s.Name = strings.TrimSpace(s.Name)
+		if s.Name == "" {
+			return fmt.Errorf("session %d has no name", i+1)
+		}
+		if seen[s.Name] {
+			return fmt.Errorf("duplicate session name %q", s.Name)
+		}
+		seen[s.Name] = true
+
+		path := expandTilde(s.Path)
+		if path == "" {
+			path = baseDir
+		} else if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, path)
+		}
+		s.Path = filepath.Clean(path)
+
+		if s.Group == "" {
+			s.Group = m.Group
+		}
+	}
+	return nil
+}
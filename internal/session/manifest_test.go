@@ -0,0 +1,97 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifest_YAML(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agent-deck.yaml")
+	content := `group: team
+sessions:
+  - name: api
+    path: services/api
+    command: claude
+  - name: docs
+    path: /tmp/docs
+    group: writing
+  - name: root
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if len(m.Sessions) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(m.Sessions))
+	}
+
+	api := m.Sessions[0]
+	if api.Path != filepath.Join(dir, "services", "api") {
+		t.Errorf("relative path not resolved: %q", api.Path)
+	}
+	if api.Group != "team" || api.Command != "claude" {
+		t.Errorf("unexpected api entry: %+v", api)
+	}
+	if m.Sessions[1].Group != "writing" || m.Sessions[1].Path != "/tmp/docs" {
+		t.Errorf("unexpected docs entry: %+v", m.Sessions[1])
+	}
+	if m.Sessions[2].Path != dir {
+		t.Errorf("empty path should default to manifest dir, got %q", m.Sessions[2].Path)
+	}
+}
+
+func TestLoadManifest_JSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "agent-deck.json")
+	content := `{"sessions": [{"name": "api", "path": ".", "command": "gemini"}]}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest: %v", err)
+	}
+	if m.Sessions[0].Command != "gemini" || m.Sessions[0].Path != dir {
+		t.Errorf("unexpected entry: %+v", m.Sessions[0])
+	}
+}
+
+func TestLoadManifest_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "sessions: []\n",
+		"missing name":   "sessions:\n  - path: /tmp\n",
+		"duplicate name": "sessions:\n  - name: a\n  - name: a\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "agent-deck.yaml")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadManifest(path); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestFindManifest(t *testing.T) {
+	dir := t.TempDir()
+	if got := FindManifest(dir); got != "" {
+		t.Errorf("expected no manifest, got %q", got)
+	}
+	path := filepath.Join(dir, ".agent-deck.yml")
+	if err := os.WriteFile(path, []byte("sessions: []\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if got := FindManifest(dir); got != path {
+		t.Errorf("FindManifest() = %q, want %q", got, path)
+	}
+}
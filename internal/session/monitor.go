@@ -0,0 +1,365 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+var monitorLog = logging.ForComponent(logging.CompStatus)
+
+// monitorPoolSize bounds concurrent UpdateStatus calls. The tmux server
+// serializes commands, so more workers don't help.
+const monitorPoolSize = 10
+
+// monitorIdleSkip is how long a session with a live control pipe must be
+// quiet before full polls skip it: no output means no status change.
+const monitorIdleSkip = 5 * time.Second
+
+// StatusChange is one session's status moving from Old to New.
+type StatusChange struct {
+	Instance *Instance
+	Old      Status
+	New      Status
+}
+
+// ShouldNotify reports whether a status change is worth a notification: a
+// finished turn, or a new approval prompt.
+func ShouldNotify(oldStatus, newStatus Status) bool {
+	switch newStatus {
+	case StatusWaiting:
+		return oldStatus == StatusRunning
+	case StatusNeedsInput:
+		return oldStatus != StatusNeedsInput
+	}
+	return false
+}
+
+// StatusDiff is what one poll found.
+type StatusDiff struct {
+	Changes   []StatusChange
+	Instances []*Instance // Every session known at the time of the poll
+	Full      bool        // The poll every base interval, not a faster tick or a Poll request
+}
+
+// Monitor polls session statuses on its own goroutines, independent of any
+// UI, and pushes what changed to Diffs. It keeps the latest status of every
+// session it has polled, so readers don't have to lock instances.
+//
+// Polling is adaptive: the monitor ticks at the active rate, but each tick
+// only polls the sessions that are due. Running, starting and waiting
+// sessions are due every active interval (the base interval while the
+// terminal is unfocused), idle and errored ones every idle interval. Ticks
+// with nothing due spawn no tmux commands at all. A full poll, the one
+// consumers do periodic work on, happens every base interval.
+//
+// Poll asks for an early update of a few sessions (e.g. the ones on
+// screen). Consumers must keep reading Diffs: the monitor waits for them
+// rather than dropping changes.
+type Monitor struct {
+	source   func() []*Instance
+	interval time.Duration // Base interval: full polls, and active sessions while unfocused
+	active   time.Duration // Active sessions while focused; the tick rate
+	idle     time.Duration // Idle (acknowledged) and errored sessions
+
+	focused  atomic.Bool
+	lastFull time.Time
+
+	// prepare runs before every scheduled poll, e.g. to feed hook statuses
+	// so UpdateStatus can take its fast path
+	prepare func([]*Instance)
+
+	mu       sync.RWMutex
+	statuses map[string]Status    // instance ID -> status at the last poll
+	polledAt map[string]time.Time // instance ID -> time of the last poll
+
+	requests chan []*Instance
+	diffs    chan StatusDiff
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewMonitor creates a monitor that polls the sessions source returns at
+// the rates in settings. prepare may be nil. Call Start() to begin polling.
+func NewMonitor(source func() []*Instance, settings StatusSettings, prepare func([]*Instance)) *Monitor {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &Monitor{
+		source:   source,
+		interval: settings.GetPollInterval(),
+		active:   settings.GetActivePollInterval(),
+		idle:     settings.GetIdlePollInterval(),
+		prepare:  prepare,
+		statuses: make(map[string]Status),
+		polledAt: make(map[string]time.Time),
+		requests: make(chan []*Instance, 1),
+		diffs:    make(chan StatusDiff, 16),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	m.focused.Store(true)
+	return m
+}
+
+// Start polls until Stop is called. Must be called in a goroutine.
+func (m *Monitor) Start() {
+	ticker := time.NewTicker(m.active)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.safePoll(nil, true)
+		case instances := <-m.requests:
+			m.safePoll(instances, false)
+		}
+	}
+}
+
+// SetFocused tells the monitor whether the user is looking at the
+// terminal. Unfocused, active sessions are polled at the base interval.
+func (m *Monitor) SetFocused(focused bool) {
+	m.focused.Store(focused)
+}
+
+// Stop ends polling.
+func (m *Monitor) Stop() {
+	m.cancel()
+}
+
+// Diffs delivers the result of every full poll, and of every other poll
+// that changed a status.
+func (m *Monitor) Diffs() <-chan StatusDiff {
+	return m.diffs
+}
+
+// Poll asks for an early update of instances. It never blocks: when a
+// request is already pending this one is dropped, and the next scheduled
+// poll catches up.
+func (m *Monitor) Poll(instances []*Instance) {
+	if len(instances) == 0 {
+		return
+	}
+	select {
+	case m.requests <- instances:
+	default:
+	}
+}
+
+// Status returns the status of a session at the last poll that covered it.
+func (m *Monitor) Status(id string) (Status, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	status, ok := m.statuses[id]
+	return status, ok
+}
+
+// safePoll runs one poll, so a panic in one doesn't end polling.
+func (m *Monitor) safePoll(instances []*Instance, scheduled bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			monitorLog.Error("monitor_poll_panic", slog.Any("panic", r))
+		}
+	}()
+	m.poll(instances, scheduled)
+}
+
+// pollEvery returns how often inst should be polled: its own poll interval
+// when it has one, else the rate for its status.
+func (m *Monitor) pollEvery(inst *Instance) time.Duration {
+	inst.mu.RLock()
+	status, override := inst.Status, inst.PollInterval
+	inst.mu.RUnlock()
+	if override > 0 {
+		return ClampPollInterval(override)
+	}
+	switch status {
+	case StatusIdle, StatusError:
+		return m.idle
+	}
+	if m.focused.Load() {
+		return m.active
+	}
+	return m.interval
+}
+
+// due reports whether a poll interval has elapsed at now. Ticks jitter, so
+// anything within half a tick counts.
+func (m *Monitor) due(last time.Time, every time.Duration, now time.Time) bool {
+	return now.Sub(last) >= every-m.active/2
+}
+
+// dueSessions returns the sessions whose poll interval has elapsed at now.
+func (m *Monitor) dueSessions(instances []*Instance, now time.Time) []*Instance {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var due []*Instance
+	for _, inst := range instances {
+		if m.due(m.polledAt[inst.ID], m.pollEvery(inst), now) {
+			due = append(due, inst)
+		}
+	}
+	return due
+}
+
+func (m *Monitor) poll(requested []*Instance, scheduled bool) {
+	start := time.Now()
+	all := m.source()
+	targets := requested
+	full := false
+	if scheduled {
+		if len(all) == 0 {
+			return
+		}
+		full = m.due(m.lastFull, m.interval, start)
+		if full {
+			m.lastFull = start
+			configureNext(all)
+			if m.prepare != nil {
+				m.prepare(all)
+			}
+		}
+		targets = skipQuietSessions(m.dueSessions(all, start))
+		if !full && len(targets) == 0 {
+			return
+		}
+	}
+
+	// Refresh tmux session cache
+	refreshStart := time.Now()
+	tmux.RefreshExistingSessions()
+	if dur := time.Since(refreshStart); dur > 100*time.Millisecond {
+		monitorLog.Warn("slow_refresh", slog.Duration("duration", dur))
+	}
+
+	changes := m.update(targets)
+	if full {
+		m.prune(all)
+	}
+	if !full && len(changes) == 0 {
+		return
+	}
+
+	select {
+	case m.diffs <- StatusDiff{Changes: changes, Instances: all, Full: full}:
+	case <-m.ctx.Done():
+	}
+}
+
+// update refreshes instances on a bounded worker pool, records their new
+// statuses and returns the ones that changed.
+func (m *Monitor) update(instances []*Instance) []StatusChange {
+	var (
+		mu      sync.Mutex
+		changes []StatusChange
+		slow    []string
+	)
+	start := time.Now()
+	g := new(errgroup.Group)
+	g.SetLimit(monitorPoolSize)
+	for _, inst := range instances {
+		g.Go(func() error {
+			defer func() {
+				if r := recover(); r != nil {
+					monitorLog.Error("status_update_panic", slog.String("title", inst.Title), slog.Any("panic", r))
+				}
+			}()
+			oldStatus := inst.GetStatusThreadSafe()
+			instStart := time.Now()
+			_ = inst.updateStatus(false) // Errors surface as StatusError
+			instDur := time.Since(instStart)
+			newStatus := inst.GetStatusThreadSafe()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if instDur > 50*time.Millisecond {
+				slow = append(slow, fmt.Sprintf("%s=%v", inst.Title, instDur.Round(time.Millisecond)))
+			}
+			if newStatus != oldStatus {
+				changes = append(changes, StatusChange{Instance: inst, Old: oldStatus, New: newStatus})
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if dur := time.Since(start); dur > 500*time.Millisecond {
+		monitorLog.Info("slow_status_loop", slog.Duration("duration", dur), slog.Int("sessions", len(instances)))
+		if len(slow) > 0 {
+			monitorLog.Info("slow_sessions", slog.String("details", strings.Join(slow, ", ")))
+		}
+	}
+
+	m.mu.Lock()
+	for _, inst := range instances {
+		m.statuses[inst.ID] = inst.GetStatusThreadSafe()
+		m.polledAt[inst.ID] = start
+	}
+	m.mu.Unlock()
+	return changes
+}
+
+// prune forgets the statuses of sessions that no longer exist.
+func (m *Monitor) prune(instances []*Instance) {
+	live := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		live[inst.ID] = true
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id := range m.statuses {
+		if !live[id] {
+			delete(m.statuses, id)
+			delete(m.polledAt, id)
+		}
+	}
+}
+
+// configureNext configures one unconfigured tmux session per poll, so every
+// session gets configured within about a minute without blocking the poll.
+func configureNext(instances []*Instance) {
+	for _, inst := range instances {
+		if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
+			if !tmuxSess.IsConfigured() && tmuxSess.Exists() {
+				tmuxSess.EnsureConfigured()
+				inst.SyncSessionIDsToTmux()
+				return
+			}
+		}
+	}
+}
+
+// skipQuietSessions drops sessions whose control pipe has seen no output
+// for monitorIdleSkip. Sessions without a live pipe are kept, since only
+// UpdateStatus notices them dying.
+func skipQuietSessions(instances []*Instance) []*Instance {
+	pm := tmux.GetPipeManager()
+	if pm == nil {
+		return instances
+	}
+	kept := make([]*Instance, 0, len(instances))
+	for _, inst := range instances {
+		if ts := inst.GetTmuxSession(); ts != nil && pm.IsConnected(ts.Name) {
+			lastOut := pm.LastOutputTime(ts.Name)
+			if !lastOut.IsZero() && time.Since(lastOut) > monitorIdleSkip {
+				continue
+			}
+		}
+		kept = append(kept, inst)
+	}
+	if skipped := len(instances) - len(kept); skipped > 0 {
+		monitorLog.Debug("idle_sessions_skipped", slog.Int("skipped", skipped), slog.Int("checked", len(kept)))
+	}
+	return kept
+}
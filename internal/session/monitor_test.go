@@ -0,0 +1,146 @@
+package session
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// crashedInstances returns sessions past their start grace period with no
+// tmux session, which UpdateStatus marks as errored.
+func crashedInstances(n int) []*Instance {
+	instances := make([]*Instance, n)
+	for i := range instances {
+		instances[i] = &Instance{
+			ID:        fmt.Sprintf("s%d", i),
+			Title:     fmt.Sprintf("session-%d", i),
+			Status:    StatusRunning,
+			CreatedAt: time.Now().Add(-time.Minute),
+		}
+	}
+	return instances
+}
+
+func TestMonitorPollRequest(t *testing.T) {
+	instances := crashedInstances(3)
+	hourly := StatusSettings{PollIntervalMs: 3_600_000, ActivePollIntervalMs: 3_600_000}
+	m := NewMonitor(func() []*Instance { return instances }, hourly, nil)
+	go m.Start()
+	defer m.Stop()
+
+	m.Poll(instances[:2])
+	select {
+	case diff := <-m.Diffs():
+		if diff.Full || len(diff.Changes) != 2 {
+			t.Fatalf("want the two requested sessions changed, got %+v", diff)
+		}
+		for _, c := range diff.Changes {
+			if c.Old != StatusRunning || c.New != StatusError {
+				t.Errorf("%s: %s -> %s, want running -> error", c.Instance.Title, c.Old, c.New)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("a poll that changed statuses should send a diff")
+	}
+
+	if status, ok := m.Status("s0"); !ok || status != StatusError {
+		t.Errorf("Status(s0) = %s, %v; want the polled status cached", status, ok)
+	}
+	if _, ok := m.Status("s2"); ok {
+		t.Error("an unpolled session should have no cached status")
+	}
+}
+
+func TestMonitorFullPoll(t *testing.T) {
+	instances := crashedInstances(2)
+	var prepared atomic.Int32
+	m := NewMonitor(func() []*Instance { return instances }, StatusSettings{}, func(all []*Instance) {
+		prepared.Store(int32(len(all)))
+	})
+	go m.Start()
+	defer m.Stop()
+
+	var diff StatusDiff
+	select {
+	case diff = <-m.Diffs():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the scheduled poll should send a diff")
+	}
+	if !diff.Full || len(diff.Changes) != 2 || len(diff.Instances) != 2 || prepared.Load() != 2 {
+		t.Errorf("want a full poll of both sessions after prepare, got %+v (prepared %d)", diff, prepared.Load())
+	}
+
+	// Later full polls report even when nothing changed
+	select {
+	case diff = <-m.Diffs():
+		if !diff.Full || len(diff.Changes) != 0 {
+			t.Errorf("want an unchanged full poll, got %+v", diff)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("every scheduled poll should send a diff")
+	}
+}
+
+func TestMonitorAdaptiveRates(t *testing.T) {
+	m := NewMonitor(nil, StatusSettings{}, nil)
+	running := &Instance{ID: "running", Status: StatusRunning}
+	idle := &Instance{ID: "idle", Status: StatusIdle}
+	instances := []*Instance{running, idle}
+
+	dueIDs := func(polledAgo time.Duration) []string {
+		now := time.Now()
+		for _, inst := range instances {
+			m.polledAt[inst.ID] = now.Add(-polledAgo)
+		}
+		var ids []string
+		for _, inst := range m.dueSessions(instances, now) {
+			ids = append(ids, inst.ID)
+		}
+		return ids
+	}
+
+	if got := dueIDs(time.Second); len(got) != 1 || got[0] != "running" {
+		t.Errorf("after 1s only the running session should be due, got %v", got)
+	}
+	m.SetFocused(false)
+	if got := dueIDs(time.Second); len(got) != 0 {
+		t.Errorf("unfocused, active sessions should back off to the base interval, got %v", got)
+	}
+	if got := dueIDs(2 * time.Second); len(got) != 1 || got[0] != "running" {
+		t.Errorf("unfocused, the running session should be due after 2s, got %v", got)
+	}
+	if got := dueIDs(5 * time.Second); len(got) != 2 {
+		t.Errorf("after 5s the idle session should be due too, got %v", got)
+	}
+
+	// A session's own poll interval replaces the rate for its status
+	m.SetFocused(true)
+	running.PollInterval = 10 * time.Second
+	idle.PollInterval = time.Second
+	if got := dueIDs(time.Second); len(got) != 1 || got[0] != "idle" {
+		t.Errorf("after 1s only the idle session with a 1s interval should be due, got %v", got)
+	}
+	if got := dueIDs(10 * time.Second); len(got) != 2 {
+		t.Errorf("after 10s the running session with a 10s interval should be due too, got %v", got)
+	}
+}
+
+func TestShouldNotify(t *testing.T) {
+	tests := []struct {
+		old, new Status
+		want     bool
+	}{
+		{StatusRunning, StatusWaiting, true},
+		{StatusIdle, StatusWaiting, false}, // marked unread
+		{StatusRunning, StatusNeedsInput, true},
+		{StatusWaiting, StatusNeedsInput, true},
+		{StatusNeedsInput, StatusNeedsInput, false},
+		{StatusRunning, StatusError, false},
+	}
+	for _, tt := range tests {
+		if got := ShouldNotify(tt.old, tt.new); got != tt.want {
+			t.Errorf("ShouldNotify(%s, %s) = %v, want %v", tt.old, tt.new, got, tt.want)
+		}
+	}
+}
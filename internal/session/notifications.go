@@ -182,6 +182,8 @@ func statusIcon(status Status) string {
 		return "●"
 	case StatusWaiting:
 		return "◐"
+	case StatusNeedsInput:
+		return "◆"
 	case StatusIdle:
 		return "○"
 	case StatusError:
@@ -208,10 +210,11 @@ func (nm *NotificationManager) SyncFromInstances(instances []*Instance, currentS
 			}
 		}
 	} else {
-		// Show only waiting sessions (backward compatible)
+		// Show only sessions waiting on the user (backward compatible)
 		sessionSet = make(map[string]*Instance)
 		for _, inst := range instances {
-			if inst.GetStatusThreadSafe() == StatusWaiting && inst.ID != currentSessionID {
+			status := inst.GetStatusThreadSafe()
+			if (status == StatusWaiting || status == StatusNeedsInput) && inst.ID != currentSessionID {
 				sessionSet[inst.ID] = inst
 			}
 		}
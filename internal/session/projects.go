@@ -0,0 +1,82 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultProjectRoots are searched for projects when [projects] roots is
+// unset. Missing ones are skipped.
+var DefaultProjectRoots = []string{"~/code", "~/src", "~/projects"}
+
+// DefaultProjectMaxDepth is how deep below a root projects are searched for
+// when [projects] max_depth is unset.
+const DefaultProjectMaxDepth = 3
+
+// projectSkipDirs are never searched: they hold dependencies, not projects.
+var projectSkipDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"target":       true,
+	"venv":         true,
+}
+
+// Project is a git repository found by DiscoverProjects.
+type Project struct {
+	Name string // Directory name
+	Path string // Absolute path
+}
+
+// DiscoverProjects returns the git repositories under roots, searching at
+// most maxDepth directories down, sorted by name. It doesn't look inside a
+// repository for nested ones, nor into hidden and dependency directories.
+// Unreadable directories are skipped.
+func DiscoverProjects(roots []string, maxDepth int) []Project {
+	seen := make(map[string]bool)
+	var projects []Project
+
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		if seen[dir] {
+			return
+		}
+		seen[dir] = true
+		// .git is a directory in a clone and a file in a worktree
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			projects = append(projects, Project{Name: filepath.Base(dir), Path: dir})
+			return
+		}
+		if depth >= maxDepth {
+			return
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() || strings.HasPrefix(name, ".") || projectSkipDirs[name] {
+				continue
+			}
+			walk(filepath.Join(dir, name), depth+1)
+		}
+	}
+
+	for _, root := range roots {
+		root, err := filepath.Abs(expandHomePath(root))
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			continue
+		}
+		walk(root, 0)
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		return strings.ToLower(projects[i].Name) < strings.ToLower(projects[j].Name)
+	})
+	return projects
+}
@@ -0,0 +1,48 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscoverProjects(t *testing.T) {
+	root := t.TempDir()
+	mkdir := func(parts ...string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(append([]string{root}, parts...)...), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkdir("api", ".git")
+	mkdir("api", "nested", ".git")          // Inside a repo: not searched
+	mkdir("work", "Web", ".git")            // One level down
+	mkdir("work", "deep", "a", "b", ".git") // Below max depth
+	mkdir("notes")                          // Not a repo
+	mkdir(".cache", "repo", ".git")         // Hidden
+	mkdir("node_modules", "pkg", ".git")
+	// A worktree's .git is a file
+	mkdir("wt")
+	if err := os.WriteFile(filepath.Join(root, "wt", ".git"), []byte("gitdir: /elsewhere\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := DiscoverProjects([]string{root, filepath.Join(root, "missing"), root}, 3)
+	want := []Project{
+		{Name: "api", Path: filepath.Join(root, "api")},
+		{Name: "Web", Path: filepath.Join(root, "work", "Web")},
+		{Name: "wt", Path: filepath.Join(root, "wt")},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("DiscoverProjects = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("project %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if got := DiscoverProjects([]string{root}, 4); len(got) != 4 {
+		t.Errorf("with max depth 4 got %d projects, want 4: %+v", len(got), got)
+	}
+}
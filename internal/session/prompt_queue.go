@@ -0,0 +1,79 @@
+package session
+
+import "time"
+
+// queueBusyTimeout is how long TakeQueuedPrompt waits to see the agent start
+// working on the previous queued prompt before it stops waiting. Covers
+// prompts answered between two status polls and sends that were lost.
+const queueBusyTimeout = 30 * time.Second
+
+// QueuePrompt appends a prompt to the session's queue.
+func (i *Instance) QueuePrompt(prompt string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.PromptQueue = append(i.PromptQueue, prompt)
+}
+
+// QueuedPrompts returns a copy of the pending prompts, oldest first.
+func (i *Instance) QueuedPrompts() []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if len(i.PromptQueue) == 0 {
+		return nil
+	}
+	return append([]string(nil), i.PromptQueue...)
+}
+
+// QueuedPromptCount returns the number of pending prompts.
+func (i *Instance) QueuedPromptCount() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return len(i.PromptQueue)
+}
+
+// ClearPromptQueue drops all pending prompts.
+func (i *Instance) ClearPromptQueue() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.PromptQueue = nil
+	i.queueAwaitingBusy = false
+}
+
+// TakeQueuedPrompt pops the next prompt if the agent is ready for one: it is
+// waiting or idle (not blocked on an approval prompt) and has started working
+// on the previously taken prompt. The caller sends it; on failure it should
+// be put back with RequeuePrompt.
+func (i *Instance) TakeQueuedPrompt() (string, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if len(i.PromptQueue) == 0 {
+		i.queueAwaitingBusy = false
+		return "", false
+	}
+	if i.queueAwaitingBusy {
+		if i.Status != StatusRunning && time.Since(i.queueSentAt) < queueBusyTimeout {
+			return "", false
+		}
+		i.queueAwaitingBusy = false
+		if i.Status == StatusRunning {
+			return "", false
+		}
+	}
+	if i.Status != StatusWaiting && i.Status != StatusIdle {
+		return "", false
+	}
+	prompt := i.PromptQueue[0]
+	i.PromptQueue = i.PromptQueue[1:]
+	i.queueSentAt = time.Now()
+	i.queueAwaitingBusy = true
+	return prompt, true
+}
+
+// RequeuePrompt puts a prompt back at the front of the queue after a failed
+// send.
+func (i *Instance) RequeuePrompt(prompt string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.PromptQueue = append([]string{prompt}, i.PromptQueue...)
+	i.queueAwaitingBusy = false
+}
@@ -0,0 +1,71 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTakeQueuedPrompt(t *testing.T) {
+	inst := &Instance{Status: StatusRunning}
+	inst.QueuePrompt("first")
+	inst.QueuePrompt("second")
+
+	_, ok := inst.TakeQueuedPrompt()
+	assert.False(t, ok, "a running agent gets nothing")
+
+	inst.Status = StatusNeedsInput
+	_, ok = inst.TakeQueuedPrompt()
+	assert.False(t, ok, "an approval prompt must not receive queued text")
+
+	inst.Status = StatusWaiting
+	p, ok := inst.TakeQueuedPrompt()
+	assert.True(t, ok)
+	assert.Equal(t, "first", p)
+
+	// Still "waiting" until the next poll sees it working
+	_, ok = inst.TakeQueuedPrompt()
+	assert.False(t, ok, "the next prompt waits for the agent to pick up the last one")
+
+	inst.Status = StatusRunning
+	_, ok = inst.TakeQueuedPrompt()
+	assert.False(t, ok)
+	inst.Status = StatusIdle
+	p, ok = inst.TakeQueuedPrompt()
+	assert.True(t, ok)
+	assert.Equal(t, "second", p)
+	assert.Zero(t, inst.QueuedPromptCount())
+}
+
+func TestTakeQueuedPrompt_BusyTimeoutAndRequeue(t *testing.T) {
+	inst := &Instance{Status: StatusWaiting}
+	inst.QueuePrompt("a")
+	inst.QueuePrompt("b")
+
+	p, _ := inst.TakeQueuedPrompt()
+	inst.RequeuePrompt(p)
+	assert.Equal(t, []string{"a", "b"}, inst.QueuedPrompts(), "a failed send goes back to the front")
+
+	p, _ = inst.TakeQueuedPrompt()
+	assert.Equal(t, "a", p)
+	// The agent answered between two polls and never showed as running
+	inst.queueSentAt = time.Now().Add(-queueBusyTimeout - time.Second)
+	p, ok := inst.TakeQueuedPrompt()
+	assert.True(t, ok)
+	assert.Equal(t, "b", p)
+}
+
+func TestPromptQueuePersists(t *testing.T) {
+	s := newTestStorage(t)
+	inst := &Instance{ID: "q", Title: "q", Tool: "claude", CreatedAt: time.Now()}
+	inst.QueuePrompt("run the tests")
+	if err := s.SaveWithGroups([]*Instance{inst}, nil); err != nil {
+		t.Fatal(err)
+	}
+	loaded, _, err := s.LoadLite()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"run the tests"}, loaded[0].PromptQueue)
+}
@@ -0,0 +1,22 @@
+package session
+
+import "sort"
+
+// RecentlyAttached returns up to n sessions that have been attached, most
+// recently attached first. LastAccessedAt is set on every attach and
+// persisted, so the list survives restarts.
+func RecentlyAttached(instances []*Instance, n int) []*Instance {
+	var recent []*Instance
+	for _, inst := range instances {
+		if !inst.LastAccessedAt.IsZero() {
+			recent = append(recent, inst)
+		}
+	}
+	sort.SliceStable(recent, func(a, b int) bool {
+		return recent[a].LastAccessedAt.After(recent[b].LastAccessedAt)
+	})
+	if len(recent) > n {
+		recent = recent[:n]
+	}
+	return recent
+}
@@ -0,0 +1,21 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecentlyAttached(t *testing.T) {
+	now := time.Now()
+	never := &Instance{ID: "never"}
+	old := &Instance{ID: "old", LastAccessedAt: now.Add(-time.Hour)}
+	newest := &Instance{ID: "newest", LastAccessedAt: now}
+	mid := &Instance{ID: "mid", LastAccessedAt: now.Add(-time.Minute)}
+	instances := []*Instance{never, old, newest, mid}
+
+	assert.Equal(t, []*Instance{newest, mid, old}, RecentlyAttached(instances, 10))
+	assert.Equal(t, []*Instance{newest, mid}, RecentlyAttached(instances, 2))
+	assert.Empty(t, RecentlyAttached([]*Instance{never}, 10))
+}
@@ -0,0 +1,88 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrSessionNotFound  = errors.New("session not found")
+	ErrSessionAmbiguous = errors.New("session reference is ambiguous")
+)
+
+// resolveError keeps a user-facing message while still matching one of the
+// ErrSession* sentinels with errors.Is.
+type resolveError struct {
+	kind error
+	msg  string
+}
+
+func (e *resolveError) Error() string { return e.msg }
+func (e *resolveError) Unwrap() error { return e.kind }
+
+// ResolveInstance finds the session identifier refers to: by exact title,
+// by an ID prefix of at least six characters, or by project path. The CLI
+// and the daemon both resolve through here so a reference means the same
+// session either way. Errors wrap ErrSessionNotFound or ErrSessionAmbiguous.
+func ResolveInstance(identifier string, instances []*Instance) (*Instance, error) {
+	if identifier == "" {
+		return nil, &resolveError{ErrSessionNotFound, "session identifier is required"}
+	}
+
+	// Try exact title match first
+	for _, inst := range instances {
+		if inst.Title == identifier {
+			return inst, nil
+		}
+	}
+
+	// Try ID prefix match (minimum 6 chars for prefix to avoid too many matches)
+	var matches []*Instance
+	if len(identifier) >= 6 {
+		for _, inst := range instances {
+			if strings.HasPrefix(inst.ID, identifier) {
+				matches = append(matches, inst)
+			}
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	if len(matches) > 1 {
+		return nil, &resolveError{ErrSessionAmbiguous, fmt.Sprintf(
+			"'%s' matches multiple sessions:\n  - %s\nUse full ID or more specific title.",
+			identifier, describeMatches(matches))}
+	}
+
+	// Try path match - collect all sessions at this path
+	var pathMatches []*Instance
+	for _, inst := range instances {
+		if inst.ProjectPath == identifier {
+			pathMatches = append(pathMatches, inst)
+		}
+	}
+	if len(pathMatches) == 1 {
+		return pathMatches[0], nil
+	}
+	if len(pathMatches) > 1 {
+		return nil, &resolveError{ErrSessionAmbiguous, fmt.Sprintf(
+			"path '%s' has multiple sessions:\n  - %s\nUse title or ID to specify.",
+			identifier, describeMatches(pathMatches))}
+	}
+
+	return nil, &resolveError{ErrSessionNotFound, fmt.Sprintf("session '%s' not found", identifier)}
+}
+
+// describeMatches lists sessions as "title (id prefix)", one per line.
+func describeMatches(instances []*Instance) string {
+	names := make([]string, 0, len(instances))
+	for _, m := range instances {
+		id := m.ID
+		if len(id) > 12 {
+			id = id[:12]
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", m.Title, id))
+	}
+	return strings.Join(names, "\n  - ")
+}
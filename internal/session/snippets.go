@@ -0,0 +1,59 @@
+package session
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/git"
+)
+
+// Snippet is a named prompt from the [snippets] config table.
+type Snippet struct {
+	Name string
+	Text string
+}
+
+// SnippetList returns the configured snippets sorted by name.
+func (c *UserConfig) SnippetList() []Snippet {
+	if c == nil {
+		return nil
+	}
+	snippets := make([]Snippet, 0, len(c.Snippets))
+	for name, text := range c.Snippets {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		snippets = append(snippets, Snippet{Name: name, Text: text})
+	}
+	sort.Slice(snippets, func(a, b int) bool { return snippets[a].Name < snippets[b].Name })
+	return snippets
+}
+
+// ExpandSnippet fills the placeholders in a snippet for inst: {{branch}},
+// {{path}}, {{title}}, {{group}} and {{tool}}. Unknown placeholders are left
+// as written; {{branch}} is empty outside a git repository.
+func ExpandSnippet(text string, inst *Instance) string {
+	if inst == nil || !strings.Contains(text, "{{") {
+		return text
+	}
+	var branch string
+	if strings.Contains(text, "{{branch}}") {
+		branch, _ = git.GetCurrentBranch(inst.ProjectPath)
+	}
+	return strings.NewReplacer(
+		"{{branch}}", branch,
+		"{{path}}", inst.ProjectPath,
+		"{{title}}", inst.Title,
+		"{{group}}", inst.GroupPath,
+		"{{tool}}", inst.Tool,
+	).Replace(text)
+}
+
+// ExpandSnippets expands every snippet for inst.
+func ExpandSnippets(snippets []Snippet, inst *Instance) []Snippet {
+	expanded := make([]Snippet, len(snippets))
+	for i, s := range snippets {
+		expanded[i] = Snippet{Name: s.Name, Text: ExpandSnippet(s.Text, inst)}
+	}
+	return expanded
+}
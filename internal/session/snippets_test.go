@@ -0,0 +1,33 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnippetList_SortedAndSkipsEmpty(t *testing.T) {
+	cfg := &UserConfig{Snippets: map[string]string{
+		"tests":  "run the tests",
+		"review": "review {{branch}}",
+		"empty":  "  ",
+	}}
+	assert.Equal(t, []Snippet{
+		{Name: "review", Text: "review {{branch}}"},
+		{Name: "tests", Text: "run the tests"},
+	}, cfg.SnippetList())
+
+	var nilCfg *UserConfig
+	assert.Empty(t, nilCfg.SnippetList())
+}
+
+func TestExpandSnippet(t *testing.T) {
+	dir := t.TempDir() // Not a git repository
+	inst := &Instance{Title: "api", ProjectPath: dir, GroupPath: "work/backend", Tool: "claude"}
+
+	got := ExpandSnippet("{{title}} ({{tool}}) in {{group}} at {{path}} on '{{branch}}' {{unknown}}", inst)
+	assert.Equal(t, "api (claude) in work/backend at "+dir+" on '' {{unknown}}", got)
+
+	assert.Equal(t, "no placeholders", ExpandSnippet("no placeholders", inst))
+	assert.Equal(t, "{{title}}", ExpandSnippet("{{title}}", nil))
+}
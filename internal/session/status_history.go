@@ -0,0 +1,152 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// statusHistorySize is how many status changes each session remembers.
+const statusHistorySize = 16
+
+// StatusTransition is one status change of a session.
+type StatusTransition struct {
+	Status Status
+	At     time.Time
+}
+
+// statusHistory is a fixed-size ring buffer of status changes. Its first
+// entry is the status agent-deck first saw, not necessarily when the session
+// entered it.
+type statusHistory struct {
+	entries [statusHistorySize]StatusTransition
+	next    int // slot the next entry goes to
+	n       int // entries in use
+}
+
+func (h *statusHistory) add(t StatusTransition) {
+	h.entries[h.next] = t
+	h.next = (h.next + 1) % statusHistorySize
+	if h.n < statusHistorySize {
+		h.n++
+	}
+}
+
+// last returns the newest entry, if any.
+func (h *statusHistory) last() (StatusTransition, bool) {
+	if h.n == 0 {
+		return StatusTransition{}, false
+	}
+	return h.entries[(h.next-1+statusHistorySize)%statusHistorySize], true
+}
+
+// list returns the entries oldest first.
+func (h *statusHistory) list() []StatusTransition {
+	out := make([]StatusTransition, 0, h.n)
+	start := (h.next - h.n + statusHistorySize) % statusHistorySize
+	for k := 0; k < h.n; k++ {
+		out = append(out, h.entries[(start+k)%statusHistorySize])
+	}
+	return out
+}
+
+// recordStatusLocked adds the current status to the history when it differs
+// from the last recorded one, and appends it to the history log when
+// [status] history_log is on. Caller holds i.mu.
+func (i *Instance) recordStatusLocked() {
+	prev, ok := i.statusHistory.last()
+	if ok && prev.Status == i.Status {
+		return
+	}
+	now := time.Now()
+	i.statusHistory.add(StatusTransition{Status: i.Status, At: now})
+
+	if !GetStatusSettings().HistoryLog {
+		return
+	}
+	event := StatusEvent{
+		InstanceID: i.ID,
+		Title:      i.Title,
+		Tool:       i.Tool,
+		Status:     string(i.Status),
+		PrevStatus: string(prev.Status),
+		Timestamp:  now.Unix(),
+	}
+	if err := appendStatusHistoryLog(event); err != nil {
+		sessionLog.Debug("status_history_log_failed", slog.String("error", err.Error()))
+	}
+}
+
+// GetStatusHistory returns the session's recent status changes, oldest first.
+func (inst *Instance) GetStatusHistory() []StatusTransition {
+	inst.mu.RLock()
+	defer inst.mu.RUnlock()
+	return inst.statusHistory.list()
+}
+
+// StatusHistoryLogPath returns the JSON Lines file status changes are
+// appended to when [status] history_log is on.
+func StatusHistoryLogPath() string {
+	return agentDeckSubdir("logs", "status-history.jsonl")
+}
+
+func appendStatusHistoryLog(event StatusEvent) error {
+	path := StatusHistoryLogPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create log dir: %w", err)
+	}
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open history log: %w", err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// FormatStatusHistory summarizes the last n transitions as e.g.
+// "running 12m → waiting 3m ago": each status with how long it lasted, and
+// how long ago the current one began.
+func FormatStatusHistory(history []StatusTransition, n int, now time.Time) string {
+	if len(history) == 0 {
+		return ""
+	}
+	if len(history) > n {
+		history = history[len(history)-n:]
+	}
+	parts := make([]string, 0, len(history))
+	for k, t := range history {
+		if k == len(history)-1 {
+			parts = append(parts, fmt.Sprintf("%s %s ago", statusHistoryLabel(t.Status), formatHistoryDuration(now.Sub(t.At))))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", statusHistoryLabel(t.Status), formatHistoryDuration(history[k+1].At.Sub(t.At))))
+	}
+	return strings.Join(parts, " → ")
+}
+
+func statusHistoryLabel(s Status) string {
+	return strings.ReplaceAll(string(s), "_", " ")
+}
+
+// formatHistoryDuration renders d in its largest whole unit: 40s, 12m, 3h, 2d.
+func formatHistoryDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
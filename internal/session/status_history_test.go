@@ -0,0 +1,113 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatusHistory_RingBuffer(t *testing.T) {
+	var h statusHistory
+	if _, ok := h.last(); ok {
+		t.Fatal("empty history should have no last entry")
+	}
+
+	base := time.Now()
+	for k := 0; k < statusHistorySize+3; k++ {
+		h.add(StatusTransition{Status: StatusRunning, At: base.Add(time.Duration(k) * time.Second)})
+	}
+	got := h.list()
+	if len(got) != statusHistorySize {
+		t.Fatalf("len = %d, want %d", len(got), statusHistorySize)
+	}
+	// The three oldest entries were overwritten; the rest stay in order
+	if !got[0].At.Equal(base.Add(3 * time.Second)) {
+		t.Errorf("oldest = %v, want base+3s", got[0].At.Sub(base))
+	}
+	if last, _ := h.last(); !last.At.Equal(got[len(got)-1].At) {
+		t.Error("last() should be the newest entry")
+	}
+}
+
+func TestRecordStatusLocked_OnlyChanges(t *testing.T) {
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	inst := &Instance{ID: "hist-1", Status: StatusRunning}
+	inst.recordStatusLocked()
+	inst.recordStatusLocked()
+	inst.SetStatusThreadSafe(StatusWaiting)
+	inst.SetStatusThreadSafe(StatusWaiting)
+
+	got := inst.GetStatusHistory()
+	if len(got) != 2 || got[0].Status != StatusRunning || got[1].Status != StatusWaiting {
+		t.Errorf("history = %+v, want running then waiting", got)
+	}
+}
+
+func TestRecordStatusLocked_HistoryLog(t *testing.T) {
+	dataDir := t.TempDir()
+	t.Setenv("AGENTDECK_HOME", dataDir)
+	userConfigCacheMu.Lock()
+	origCache := userConfigCache
+	userConfigCache = &UserConfig{Status: StatusSettings{HistoryLog: true}}
+	userConfigCacheMu.Unlock()
+	defer func() {
+		userConfigCacheMu.Lock()
+		userConfigCache = origCache
+		userConfigCacheMu.Unlock()
+	}()
+
+	inst := &Instance{ID: "hist-2", Title: "api", Tool: "claude", Status: StatusRunning}
+	inst.SetStatusThreadSafe(StatusRunning)
+	inst.SetStatusThreadSafe(StatusNeedsInput)
+
+	// The log follows the data directory (--storage, AGENTDECK_HOME, XDG)
+	if want := filepath.Join(dataDir, "logs", "status-history.jsonl"); StatusHistoryLogPath() != want {
+		t.Errorf("StatusHistoryLogPath = %q, want %q", StatusHistoryLogPath(), want)
+	}
+	data, err := os.ReadFile(StatusHistoryLogPath())
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("log has %d lines, want 2:\n%s", len(lines), data)
+	}
+	var event StatusEvent
+	if err := json.Unmarshal([]byte(lines[1]), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.InstanceID != "hist-2" || event.Status != "needs_input" || event.PrevStatus != "running" {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestFormatStatusHistory(t *testing.T) {
+	now := time.Now()
+	history := []StatusTransition{
+		{Status: StatusStarting, At: now.Add(-40 * time.Minute)},
+		{Status: StatusRunning, At: now.Add(-15 * time.Minute)},
+		{Status: StatusNeedsInput, At: now.Add(-3 * time.Minute)},
+	}
+
+	if got, want := FormatStatusHistory(history, 2, now), "running 12m → needs input 3m ago"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := FormatStatusHistory(history[:1], 3, now); got != "starting 40m ago" {
+		t.Errorf("got %q", got)
+	}
+	if got := FormatStatusHistory(nil, 3, now); got != "" {
+		t.Errorf("empty history should format as empty, got %q", got)
+	}
+}
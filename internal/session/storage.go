@@ -70,6 +70,8 @@ type InstanceData struct {
 	CreatedAt       time.Time `json:"created_at"`
 	LastAccessedAt  time.Time `json:"last_accessed_at,omitempty"`
 	TmuxSession     string    `json:"tmux_session"`
+	Notes           string    `json:"notes,omitempty"`
+	Tags            []string  `json:"tags,omitempty"`
 
 	// Worktree support
 	WorktreePath     string `json:"worktree_path,omitempty"`
@@ -102,15 +104,40 @@ type InstanceData struct {
 
 	// MCP tracking (persisted for sync status display)
 	LoadedMCPNames []string `json:"loaded_mcp_names,omitempty"`
+
+	// Per-session status timing overrides (0 = use [status] config)
+	PollInterval     time.Duration `json:"poll_interval,omitempty"`
+	ActivityCooldown time.Duration `json:"activity_cooldown,omitempty"`
+
+	// NotifyMuted silences desktop notifications for this session
+	NotifyMuted bool `json:"notify_muted,omitempty"`
+
+	// DetectedModel is the model last seen in the transcript or pane
+	DetectedModel string `json:"detected_model,omitempty"`
+
+	// Fork lineage
+	ForkedFromID string    `json:"forked_from_id,omitempty"`
+	ForkedAt     time.Time `json:"forked_at,omitempty"`
+
+	// Prompts waiting to be sent when the agent is ready
+	PromptQueue []string `json:"prompt_queue,omitempty"`
+
+	// Automatic restarts allowed after a crash (0 = off)
+	AutoRestartMax int `json:"auto_restart_max,omitempty"`
+
+	// How the project's .envrc/.env is loaded ("" = [shell] project_env)
+	ProjectEnv string `json:"project_env,omitempty"`
 }
 
 // GroupData represents serializable group data
 type GroupData struct {
-	Name        string `json:"name"`
-	Path        string `json:"path"`
-	Expanded    bool   `json:"expanded"`
-	Order       int    `json:"order"`
-	DefaultPath string `json:"default_path,omitempty"`
+	Name        string        `json:"name"`
+	Path        string        `json:"path"`
+	Expanded    bool          `json:"expanded"`
+	Order       int           `json:"order"`
+	DefaultPath string        `json:"default_path,omitempty"`
+	Muted       bool          `json:"muted,omitempty"`
+	SortMode    GroupSortMode `json:"sort_mode,omitempty"`
 }
 
 // Storage handles persistence of session data via SQLite.
@@ -121,6 +148,14 @@ type Storage struct {
 	dbPath  string     // Path to state.db (for change detection)
 	profile string     // The profile this storage is for
 	mu      sync.Mutex // Protects operations during transition
+
+	// knownIDs are the instance IDs this storage last loaded or saved. Saves
+	// only delete rows from this set, so sessions added by another process
+	// (TUI vs CLI) since our last load aren't clobbered. Nil until first load.
+	knownIDs map[string]bool
+
+	// lastBackup is when the last automatic backup was taken (see backup.go)
+	lastBackup time.Time
 }
 
 // NewStorageWithProfile creates a storage instance for a specific profile.
@@ -237,6 +272,8 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 		return fmt.Errorf("storage database not initialized")
 	}
 
+	s.backupIfDueLocked()
+
 	// Convert instances to database rows
 	rows := make([]*statedb.InstanceRow, len(instances))
 	for i, inst := range instances {
@@ -253,6 +290,11 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 			inst.CodexSessionID, inst.CodexDetectedAt,
 			inst.LatestPrompt, inst.LoadedMCPNames,
 			inst.ToolOptionsJSON,
+			inst.PollInterval, inst.ActivityCooldown,
+			inst.NotifyMuted, inst.DetectedModel,
+			inst.ForkedFromID, inst.ForkedAt,
+			inst.QueuedPrompts(), inst.AutoRestartMax,
+			inst.ProjectEnv,
 		)
 
 		rows[i] = &statedb.InstanceRow{
@@ -273,11 +315,23 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 			WorktreeRepo:    inst.WorktreeRepoRoot,
 			WorktreeBranch:  inst.WorktreeBranch,
 			ToolData:        toolData,
+			Notes:           inst.Notes,
+			Tags:            inst.Tags,
 		}
 	}
 
-	if err := s.db.SaveInstances(rows); err != nil {
-		return fmt.Errorf("failed to save instances: %w", err)
+	if s.knownIDs == nil {
+		// Never loaded: nothing to diff against, so the list is authoritative
+		if err := s.db.SaveInstances(rows); err != nil {
+			return fmt.Errorf("failed to save instances: %w", err)
+		}
+		s.setKnownIDs(rowIDs(rows))
+	} else {
+		saved, err := s.db.SyncInstances(rows, s.knownIDs)
+		if err != nil {
+			return fmt.Errorf("failed to save instances: %w", err)
+		}
+		s.setKnownIDs(saved)
 	}
 
 	// Save groups (including empty ones)
@@ -290,6 +344,8 @@ func (s *Storage) SaveWithGroups(instances []*Instance, groupTree *GroupTree) er
 				Expanded:    g.Expanded,
 				Order:       g.Order,
 				DefaultPath: g.DefaultPath,
+				Muted:       g.Muted,
+				SortMode:    string(g.SortMode),
 			})
 		}
 		if err := s.db.SaveGroups(groupRows); err != nil {
@@ -316,6 +372,7 @@ func (s *Storage) DeleteInstance(id string) error {
 	if err := s.db.DeleteInstance(id); err != nil {
 		return fmt.Errorf("failed to delete instance %s: %w", id, err)
 	}
+	delete(s.knownIDs, id)
 
 	_ = s.db.Touch()
 	return nil
@@ -344,6 +401,8 @@ func (s *Storage) SaveGroupsOnly(groupTree *GroupTree) error {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Muted:       g.Muted,
+			SortMode:    string(g.SortMode),
 		})
 	}
 
@@ -392,7 +451,12 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 			opencodeSID, opencodeAt,
 			codexSID, codexAt,
 			latestPrompt, loadedMCPs,
-			toolOpts := statedb.UnmarshalToolData(r.ToolData)
+			toolOpts,
+			pollInterval, activityCooldown,
+			notifyMuted, detectedModel,
+			forkedFromID, forkedAt,
+			promptQueue, autoRestartMax,
+			projectEnv := statedb.UnmarshalToolData(r.ToolData)
 
 		instances[i] = &InstanceData{
 			ID:                 r.ID,
@@ -408,6 +472,8 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 			CreatedAt:          r.CreatedAt,
 			LastAccessedAt:     r.LastAccessed,
 			TmuxSession:        r.TmuxSession,
+			Notes:              r.Notes,
+			Tags:               r.Tags,
 			WorktreePath:       r.WorktreePath,
 			WorktreeRepoRoot:   r.WorktreeRepo,
 			WorktreeBranch:     r.WorktreeBranch,
@@ -424,6 +490,15 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 			LatestPrompt:       latestPrompt,
 			ToolOptionsJSON:    toolOpts,
 			LoadedMCPNames:     loadedMCPs,
+			PollInterval:       pollInterval,
+			ActivityCooldown:   activityCooldown,
+			NotifyMuted:        notifyMuted,
+			DetectedModel:      detectedModel,
+			ForkedFromID:       forkedFromID,
+			ForkedAt:           forkedAt,
+			PromptQueue:        promptQueue,
+			AutoRestartMax:     autoRestartMax,
+			ProjectEnv:         projectEnv,
 		}
 	}
 
@@ -436,6 +511,8 @@ func (s *Storage) LoadLite() ([]*InstanceData, []*GroupData, error) {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Muted:       g.Muted,
+			SortMode:    GroupSortMode(g.SortMode),
 		}
 	}
 
@@ -462,6 +539,7 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to load groups: %w", err)
 	}
+	s.setKnownIDs(rowIDs(dbRows))
 
 	// Convert to InstanceData for the existing convertToInstances pipeline
 	data := &StorageData{
@@ -474,7 +552,12 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 			opencodeSID, opencodeAt,
 			codexSID, codexAt,
 			latestPrompt, loadedMCPs,
-			toolOpts := statedb.UnmarshalToolData(r.ToolData)
+			toolOpts,
+			pollInterval, activityCooldown,
+			notifyMuted, detectedModel,
+			forkedFromID, forkedAt,
+			promptQueue, autoRestartMax,
+			projectEnv := statedb.UnmarshalToolData(r.ToolData)
 
 		data.Instances[i] = &InstanceData{
 			ID:                 r.ID,
@@ -490,6 +573,8 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 			CreatedAt:          r.CreatedAt,
 			LastAccessedAt:     r.LastAccessed,
 			TmuxSession:        r.TmuxSession,
+			Notes:              r.Notes,
+			Tags:               r.Tags,
 			WorktreePath:       r.WorktreePath,
 			WorktreeRepoRoot:   r.WorktreeRepo,
 			WorktreeBranch:     r.WorktreeBranch,
@@ -506,6 +591,15 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 			LatestPrompt:       latestPrompt,
 			ToolOptionsJSON:    toolOpts,
 			LoadedMCPNames:     loadedMCPs,
+			PollInterval:       pollInterval,
+			ActivityCooldown:   activityCooldown,
+			NotifyMuted:        notifyMuted,
+			DetectedModel:      detectedModel,
+			ForkedFromID:       forkedFromID,
+			ForkedAt:           forkedAt,
+			PromptQueue:        promptQueue,
+			AutoRestartMax:     autoRestartMax,
+			ProjectEnv:         projectEnv,
 		}
 	}
 
@@ -518,12 +612,31 @@ func (s *Storage) LoadWithGroups() ([]*Instance, []*GroupData, error) {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Muted:       g.Muted,
+			SortMode:    GroupSortMode(g.SortMode),
 		}
 	}
 
 	return s.convertToInstances(data)
 }
 
+// setKnownIDs replaces the set of instance IDs this storage has synced.
+// MUST be called with mu held.
+func (s *Storage) setKnownIDs(ids []string) {
+	s.knownIDs = make(map[string]bool, len(ids))
+	for _, id := range ids {
+		s.knownIDs[id] = true
+	}
+}
+
+func rowIDs(rows []*statedb.InstanceRow) []string {
+	ids := make([]string, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+	return ids
+}
+
 // GetDBPathForProfile returns the path to the state.db file for a specific profile.
 func GetDBPathForProfile(profile string) (string, error) {
 	if profile == "" {
@@ -645,6 +758,8 @@ func (s *Storage) convertToInstances(data *StorageData) ([]*Instance, []*GroupDa
 			Status:             instData.Status,
 			CreatedAt:          instData.CreatedAt,
 			LastAccessedAt:     instData.LastAccessedAt,
+			Notes:              instData.Notes,
+			Tags:               instData.Tags,
 			WorktreePath:       instData.WorktreePath,
 			WorktreeRepoRoot:   instData.WorktreeRepoRoot,
 			WorktreeBranch:     instData.WorktreeBranch,
@@ -661,8 +776,18 @@ func (s *Storage) convertToInstances(data *StorageData) ([]*Instance, []*GroupDa
 			ToolOptionsJSON:    instData.ToolOptionsJSON,
 			LatestPrompt:       instData.LatestPrompt,
 			LoadedMCPNames:     instData.LoadedMCPNames,
+			PollInterval:       instData.PollInterval,
+			ActivityCooldown:   instData.ActivityCooldown,
+			NotifyMuted:        instData.NotifyMuted,
+			DetectedModel:      instData.DetectedModel,
+			ForkedFromID:       instData.ForkedFromID,
+			ForkedAt:           instData.ForkedAt,
+			PromptQueue:        instData.PromptQueue,
+			AutoRestartMax:     instData.AutoRestartMax,
+			ProjectEnv:         instData.ProjectEnv,
 			tmuxSession:        tmuxSess,
 		}
+		inst.ApplyActivityCooldown()
 
 		// PERFORMANCE: Skip UpdateStatus at load time - use cached status from SQLite
 		// The background worker will update status on first tick.
@@ -683,7 +808,7 @@ func statusToString(s Status) string {
 	switch s {
 	case StatusRunning:
 		return "active"
-	case StatusWaiting:
+	case StatusWaiting, StatusNeedsInput:
 		return "waiting"
 	case StatusIdle:
 		return "idle"
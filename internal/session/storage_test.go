@@ -184,3 +184,56 @@ func TestLoadLiteEmptyDB(t *testing.T) {
 		t.Errorf("Expected empty groups, got %d", len(groupData))
 	}
 }
+
+// TestSaveDoesNotClobberConcurrentAdd verifies that a save from a stale
+// process (e.g. the TUI) keeps a session another process (e.g. the CLI)
+// added after the stale process last loaded.
+func TestSaveDoesNotClobberConcurrentAdd(t *testing.T) {
+	tui := newTestStorage(t)
+	cli := &Storage{db: tui.db, dbPath: tui.dbPath, profile: "_test"}
+
+	first := &Instance{ID: "first", Title: "First", ProjectPath: "/tmp/a", Tool: "shell", CreatedAt: time.Now()}
+	if err := tui.SaveWithGroups([]*Instance{first}, nil); err != nil {
+		t.Fatalf("tui save: %v", err)
+	}
+
+	// CLI loads, adds a session, saves
+	cliInstances, _, err := cli.LoadWithGroups()
+	if err != nil {
+		t.Fatalf("cli load: %v", err)
+	}
+	added := &Instance{ID: "added", Title: "Added", ProjectPath: "/tmp/b", Tool: "shell", CreatedAt: time.Now()}
+	if err := cli.SaveWithGroups(append(cliInstances, added), nil); err != nil {
+		t.Fatalf("cli save: %v", err)
+	}
+
+	// TUI saves its stale list
+	first.Title = "First Renamed"
+	if err := tui.SaveWithGroups([]*Instance{first}, nil); err != nil {
+		t.Fatalf("tui save: %v", err)
+	}
+
+	data, _, err := tui.LoadLite()
+	if err != nil {
+		t.Fatalf("LoadLite: %v", err)
+	}
+	titles := make(map[string]string)
+	for _, d := range data {
+		titles[d.ID] = d.Title
+	}
+	if titles["added"] != "Added" {
+		t.Errorf("concurrently added session was clobbered: %v", titles)
+	}
+	if titles["first"] != "First Renamed" {
+		t.Errorf("stale process update lost: %v", titles)
+	}
+
+	// A session the TUI itself removes is still deleted
+	if err := tui.SaveWithGroups(nil, nil); err != nil {
+		t.Fatalf("tui save: %v", err)
+	}
+	data, _, _ = tui.LoadLite()
+	if len(data) != 1 || data[0].ID != "added" {
+		t.Errorf("expected only the unknown session to remain, got %d rows", len(data))
+	}
+}
@@ -0,0 +1,52 @@
+package session
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ParseTags splits user input like "bug, exp client-x" into normalized tags.
+// Commas and whitespace both separate tags.
+func ParseTags(s string) []string {
+	return NormalizeTags(strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	}))
+}
+
+// NormalizeTags lowercases, strips a leading "#", drops empty and duplicate
+// tags, and sorts the result. Returns nil when no tags remain.
+func NormalizeTags(tags []string) []string {
+	var result []string
+	for _, t := range tags {
+		t = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(t), "#"))
+		// Commas are the storage separator; whitespace would make tags ambiguous to type
+		t = strings.Map(func(r rune) rune {
+			if r == ',' || unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, t)
+		if t != "" && !slices.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// HasTag reports whether the session carries tag (case-insensitive).
+func (i *Instance) HasTag(tag string) bool {
+	return slices.Contains(i.Tags, strings.ToLower(strings.TrimPrefix(tag, "#")))
+}
+
+// CollectTags returns every tag used by the given sessions with its count.
+func CollectTags(instances []*Instance) map[string]int {
+	counts := make(map[string]int)
+	for _, inst := range instances {
+		for _, t := range inst.Tags {
+			counts[t]++
+		}
+	}
+	return counts
+}
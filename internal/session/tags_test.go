@@ -0,0 +1,36 @@
+package session
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseTags(t *testing.T) {
+	tests := map[string][]string{
+		"":                      nil,
+		"bug":                   {"bug"},
+		"Bug, exp  client-x":    {"bug", "client-x", "exp"},
+		"#bug,#BUG,, bug":       {"bug"},
+		" exp,\tperf/api:slow ": {"exp", "perf/api:slow"},
+	}
+	for input, want := range tests {
+		if got := ParseTags(input); !slices.Equal(got, want) {
+			t.Errorf("ParseTags(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
+
+func TestInstanceHasTag(t *testing.T) {
+	inst := &Instance{Tags: NormalizeTags([]string{"Client-X", "bug"})}
+	if !inst.HasTag("client-x") || !inst.HasTag("#BUG") {
+		t.Errorf("HasTag should match case-insensitively, tags = %v", inst.Tags)
+	}
+	if inst.HasTag("exp") {
+		t.Error("HasTag(exp) should be false")
+	}
+
+	counts := CollectTags([]*Instance{inst, {Tags: []string{"bug"}}, {}})
+	if counts["bug"] != 2 || counts["client-x"] != 1 || len(counts) != 2 {
+		t.Errorf("CollectTags = %v", counts)
+	}
+}
@@ -24,6 +24,10 @@ func TestMain(m *testing.M) {
 	// Force test profile to prevent production data corruption
 	// See CLAUDE.md: "2025-12-11 Incident: Tests with AGENTDECK_PROFILE=work overwrote ALL 36 production sessions"
 	os.Setenv("AGENTDECK_PROFILE", "_test")
+	// Tests isolate storage by overriding HOME; XDG dirs from the
+	// environment would send it to the real data and config dirs instead.
+	os.Unsetenv("XDG_DATA_HOME")
+	os.Unsetenv("XDG_CONFIG_HOME")
 
 	// Run tests
 	code := m.Run()
@@ -0,0 +1,199 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Transcript message roles
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+	RoleTool      = "tool"   // Tool calls and their results
+	RoleSystem    = "system" // Session boundaries
+)
+
+// TranscriptMessage is one message of an agent conversation.
+type TranscriptMessage struct {
+	Role string
+	Text string
+	Time time.Time // Zero when the transcript has no timestamps
+}
+
+// aiderHistoryFile is where aider appends the chat, relative to the project.
+const aiderHistoryFile = ".aider.chat.history.md"
+
+// maxToolResultLen caps the display width of tool output in the transcript;
+// results can be whole files.
+const maxToolResultLen = 500
+
+// ReadTranscript parses the conversation from the agent's transcript file:
+// Claude's JSONL session or aider's chat history.
+func (i *Instance) ReadTranscript() ([]TranscriptMessage, error) {
+	switch i.Tool {
+	case "claude":
+		path := i.GetJSONLPath()
+		if path == "" {
+			return nil, fmt.Errorf("no Claude transcript found for this session yet")
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transcript: %w", err)
+		}
+		return parseClaudeTranscript(data), nil
+	case "aider":
+		data, err := os.ReadFile(filepath.Join(i.ProjectPath, aiderHistoryFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, fmt.Errorf("no %s in %s", aiderHistoryFile, i.ProjectPath)
+			}
+			return nil, fmt.Errorf("failed to read transcript: %w", err)
+		}
+		return parseAiderHistory(data), nil
+	default:
+		return nil, fmt.Errorf("transcripts are only available for claude and aider sessions")
+	}
+}
+
+// parseClaudeTranscript turns a Claude JSONL session into messages. Text
+// blocks become user/assistant messages; tool_use and tool_result blocks
+// become tool messages. Injected meta records are skipped.
+func parseClaudeTranscript(data []byte) []TranscriptMessage {
+	type record struct {
+		Type      string          `json:"type"`
+		Message   json.RawMessage `json:"message"`
+		Timestamp string          `json:"timestamp"`
+		IsMeta    bool            `json:"isMeta"`
+	}
+	type block struct {
+		Type    string          `json:"type"`
+		Text    string          `json:"text"`
+		Name    string          `json:"name"`
+		Input   json.RawMessage `json:"input"`
+		Content json.RawMessage `json:"content"`
+	}
+
+	var msgs []TranscriptMessage
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 10*1024*1024)
+	for scanner.Scan() {
+		var rec record
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			continue
+		}
+		if rec.IsMeta || (rec.Type != "user" && rec.Type != "assistant") || len(rec.Message) == 0 {
+			continue
+		}
+		var msg claudeMessage
+		if err := json.Unmarshal(rec.Message, &msg); err != nil {
+			continue
+		}
+		at, _ := time.Parse(time.RFC3339, rec.Timestamp)
+		add := func(role, text string) {
+			if text = strings.TrimSpace(text); text != "" {
+				msgs = append(msgs, TranscriptMessage{Role: role, Text: text, Time: at})
+			}
+		}
+
+		var text string
+		if err := json.Unmarshal(msg.Content, &text); err == nil {
+			add(msg.Role, text)
+			continue
+		}
+		var blocks []block
+		if err := json.Unmarshal(msg.Content, &blocks); err != nil {
+			continue
+		}
+		for _, b := range blocks {
+			switch b.Type {
+			case "text":
+				add(msg.Role, b.Text)
+			case "tool_use":
+				add(RoleTool, b.Name+" "+summarizeToolInput(b.Input))
+			case "tool_result":
+				result := extractContentText(b.Content)
+				result = runewidth.Truncate(result, maxToolResultLen, "…")
+				add(RoleTool, result)
+			}
+		}
+	}
+	return msgs
+}
+
+// summarizeToolInput renders a tool call's input on one line: the command,
+// path or pattern when there is one, else the compact JSON.
+func summarizeToolInput(input json.RawMessage) string {
+	var fields map[string]any
+	if err := json.Unmarshal(input, &fields); err != nil {
+		return ""
+	}
+	for _, key := range []string{"command", "file_path", "path", "pattern", "url", "description"} {
+		if v, ok := fields[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// parseAiderHistory turns aider's markdown chat history into messages.
+// "#### " lines are user input, "> " lines are aider's tool output (edits,
+// commits, warnings) and everything else is the model's reply.
+func parseAiderHistory(data []byte) []TranscriptMessage {
+	var msgs []TranscriptMessage
+	var role string
+	var text []string
+	flush := func() {
+		if body := strings.TrimSpace(strings.Join(text, "\n")); body != "" {
+			msgs = append(msgs, TranscriptMessage{Role: role, Text: body})
+		}
+		text = nil
+	}
+	switchTo := func(r string) {
+		if r != role {
+			flush()
+			role = r
+		}
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, "# aider chat started at"):
+			switchTo(RoleSystem)
+			flush()
+			text = append(text, strings.TrimPrefix(line, "# "))
+			flush()
+		case strings.HasPrefix(line, "#### "):
+			switchTo(RoleUser)
+			text = append(text, strings.TrimPrefix(line, "#### "))
+		case strings.HasPrefix(line, ">"):
+			switchTo(RoleTool)
+			text = append(text, strings.TrimSpace(strings.TrimPrefix(line, ">")))
+		case strings.TrimSpace(line) == "":
+			text = append(text, "")
+		default:
+			switchTo(RoleAssistant)
+			text = append(text, line)
+		}
+	}
+	flush()
+	return msgs
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseClaudeTranscript(t *testing.T) {
+	data := []byte(`{"type":"user","isMeta":true,"message":{"role":"user","content":"<local-command-caveat>"}}
+{"type":"user","timestamp":"2025-06-01T10:00:00.000Z","message":{"role":"user","content":"list the files"}}
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Sure."},{"type":"tool_use","name":"Bash","input":{"command":"ls -la"}}]}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"a.go\nb.go"}]}}
+{"type":"summary","summary":"Listing files"}
+not json
+{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"Two files."}]}}
+`)
+	msgs := parseClaudeTranscript(data)
+	require.Len(t, msgs, 5)
+	assert.Equal(t, TranscriptMessage{Role: RoleUser, Text: "list the files", Time: msgs[0].Time}, msgs[0])
+	assert.False(t, msgs[0].Time.IsZero(), "timestamps are parsed")
+	assert.Equal(t, RoleAssistant, msgs[1].Role)
+	assert.Equal(t, "Bash ls -la", msgs[2].Text)
+	assert.Equal(t, RoleTool, msgs[3].Role)
+	assert.Equal(t, "a.go\nb.go", msgs[3].Text)
+	assert.Equal(t, "Two files.", msgs[4].Text)
+}
+
+func TestParseClaudeTranscriptLongToolResult(t *testing.T) {
+	// Two-byte runes, so a byte cut at maxToolResultLen would split one
+	long := "x" + strings.Repeat("é", maxToolResultLen)
+	data := []byte(`{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"` + long + `"}]}}`)
+	msgs := parseClaudeTranscript(data)
+	require.Len(t, msgs, 1)
+	assert.True(t, utf8.ValidString(msgs[0].Text), "truncation keeps whole runes")
+	assert.True(t, strings.HasSuffix(msgs[0].Text, "é…"))
+}
+
+func TestParseAiderHistory(t *testing.T) {
+	data := []byte(`
+# aider chat started at 2025-06-01 10:00:00
+
+> Aider v0.80.0
+> Main model: sonnet
+
+#### add a test
+#### for the parser
+
+Here is the test:
+
+` + "```go\nfunc TestX(t *testing.T) {}\n```" + `
+
+> Applied edit to x_test.go
+`)
+	msgs := parseAiderHistory(data)
+	require.Len(t, msgs, 5)
+	assert.Equal(t, RoleSystem, msgs[0].Role)
+	assert.Equal(t, "aider chat started at 2025-06-01 10:00:00", msgs[0].Text)
+	assert.Equal(t, RoleTool, msgs[1].Role)
+	assert.Equal(t, "Aider v0.80.0\nMain model: sonnet", msgs[1].Text)
+	assert.Equal(t, TranscriptMessage{Role: RoleUser, Text: "add a test\nfor the parser"}, msgs[2])
+	assert.Equal(t, RoleAssistant, msgs[3].Role)
+	assert.Contains(t, msgs[3].Text, "func TestX")
+	assert.Equal(t, "Applied edit to x_test.go", msgs[4].Text)
+}
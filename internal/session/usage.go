@@ -0,0 +1,87 @@
+package session
+
+import "time"
+
+// Usage is a session's token usage and estimated cost, read from the tool's
+// own transcript.
+type Usage struct {
+	InputTokens      int     `json:"input_tokens"`
+	OutputTokens     int     `json:"output_tokens"`
+	CacheReadTokens  int     `json:"cache_read_tokens,omitempty"`
+	CacheWriteTokens int     `json:"cache_write_tokens,omitempty"`
+	Turns            int     `json:"turns"`
+	Cost             float64 `json:"estimated_cost"`
+	Model            string  `json:"model,omitempty"`
+}
+
+// TotalTokens returns the sum of all token types.
+func (u Usage) TotalTokens() int {
+	return u.InputTokens + u.OutputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// Add returns u plus other, for totals across sessions. The model is kept
+// only when both agree.
+func (u Usage) Add(other Usage) Usage {
+	model := u.Model
+	if model != other.Model {
+		model = ""
+	}
+	return Usage{
+		InputTokens:      u.InputTokens + other.InputTokens,
+		OutputTokens:     u.OutputTokens + other.OutputTokens,
+		CacheReadTokens:  u.CacheReadTokens + other.CacheReadTokens,
+		CacheWriteTokens: u.CacheWriteTokens + other.CacheWriteTokens,
+		Turns:            u.Turns + other.Turns,
+		Cost:             u.Cost + other.Cost,
+		Model:            model,
+	}
+}
+
+// ReadUsage reads the session's token usage from its transcript. ok is false
+// when there is nothing to read: tools other than Claude and Gemini, or no
+// transcript yet. Reads the whole transcript, so keep it out of hot paths.
+func (i *Instance) ReadUsage() (Usage, bool) {
+	return i.ReadUsageSince(time.Time{})
+}
+
+// ReadUsageSince is ReadUsage counting only the turns at or after since,
+// by each turn's own timestamp.
+func (i *Instance) ReadUsageSince(since time.Time) (Usage, bool) {
+	switch i.Tool {
+	case "claude":
+		path := i.GetJSONLPath()
+		if path == "" {
+			return Usage{}, false
+		}
+		a, err := ParseSessionJSONLSince(path, since)
+		if err != nil {
+			return Usage{}, false
+		}
+		return Usage{
+			InputTokens:      a.InputTokens,
+			OutputTokens:     a.OutputTokens,
+			CacheReadTokens:  a.CacheReadTokens,
+			CacheWriteTokens: a.CacheWriteTokens,
+			Turns:            a.TotalTurns,
+			Cost:             a.EstimatedCost,
+			Model:            a.Model,
+		}, true
+
+	case "gemini":
+		if i.GeminiSessionID == "" {
+			return Usage{}, false
+		}
+		a := &GeminiSessionAnalytics{}
+		if err := UpdateGeminiAnalyticsFromDiskSince(i.ProjectPath, i.GeminiSessionID, since, a); err != nil {
+			return Usage{}, false
+		}
+		return Usage{
+			InputTokens:  a.InputTokens,
+			OutputTokens: a.OutputTokens,
+			Turns:        a.TotalTurns,
+			Cost:         a.CalculateCost(a.Model),
+			Model:        a.Model,
+		}, true
+	}
+	return Usage{}, false
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/BurntSushi/toml"
 
@@ -27,9 +29,37 @@ type UserConfig struct {
 	// If empty or invalid, defaults to "shell" (no pre-selection)
 	DefaultTool string `toml:"default_tool"`
 
-	// Theme sets the color scheme: "dark" (default), "light", or "system"
+	// Presets sets which commands the New Session picker offers, and in what order.
+	// Entries are built-in tool names or [tools.*] names; shell is always first.
+	// If empty, the built-in tools are shown followed by all custom tools.
+	Presets []string `toml:"presets"`
+
+	// Theme sets the color scheme: "dark" (default), "light", "solarized",
+	// "solarized-light", "high-contrast", "system", or a [themes.*] name
 	Theme string `toml:"theme"`
 
+	// Themes defines custom color palettes, selectable via theme = "<name>"
+	Themes map[string]ThemePalette `toml:"themes"`
+
+	// Multiplexer hosts the sessions: "tmux", "zellij" or "screen". Empty
+	// uses tmux, falling back to screen when only screen is installed.
+	// Status bar, side panes and event-driven status detection are tmux-only.
+	Multiplexer string `toml:"multiplexer"`
+
+	// ConfirmDelete asks before "d" deletes a session (default: true). When
+	// false the session is deleted right away; Ctrl+Z still restores it
+	ConfirmDelete *bool `toml:"confirm_delete"`
+
+	// NumberKeys picks what the 1-9 keys jump to: "groups" (default, the Nth
+	// root group) or "sessions" (the Nth session on screen; pressing the
+	// same digit again attaches)
+	NumberKeys string `toml:"number_keys"`
+
+	// ToolIcons sets how the session list shows each session's tool: "auto"
+	// (default: Nerd Font glyphs when a Nerd Font is detected, else the
+	// tool name), "nerd" (always glyphs) or "text" (always the name)
+	ToolIcons string `toml:"tool_icons"`
+
 	// Tools defines custom AI tool configurations
 	Tools map[string]ToolDef `toml:"tools"`
 
@@ -95,11 +125,76 @@ type UserConfig struct {
 	// Status defines session status detection settings
 	Status StatusSettings `toml:"status"`
 
+	// Backups defines automatic session state backups
+	Backups BackupSettings `toml:"backups"`
+
+	// Cleanup defines the idle-session cleanup policy
+	Cleanup CleanupSettings `toml:"cleanup"`
+
+	// Projects defines where the New Session dialog looks for projects
+	Projects ProjectsSettings `toml:"projects"`
+
 	// Conductor defines conductor (meta-agent orchestration) settings
 	Conductor ConductorSettings `toml:"conductor"`
 
 	// Tmux defines tmux option overrides applied to every session
 	Tmux TmuxSettings `toml:"tmux"`
+
+	// Keys remaps main-screen keybindings. Keys are action names, values are a
+	// single key or a list of keys, e.g. delete = "D" or up = ["up", "o"].
+	Keys map[string]KeyList `toml:"keys"`
+
+	// Snippets are named prompts for the prompt bar's picker (ctrl+o). Text
+	// may use {{branch}}, {{path}}, {{title}}, {{group}} and {{tool}}.
+	Snippets map[string]string `toml:"snippets"`
+}
+
+// KeyList is a list of key names, written in TOML as a string or an array.
+type KeyList []string
+
+// UnmarshalTOML accepts either a single key string or an array of key strings.
+func (k *KeyList) UnmarshalTOML(v interface{}) error {
+	switch val := v.(type) {
+	case string:
+		*k = KeyList{val}
+	case []interface{}:
+		keys := make(KeyList, 0, len(val))
+		for _, item := range val {
+			key, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("key list must contain strings, got %T", item)
+			}
+			keys = append(keys, key)
+		}
+		*k = keys
+	default:
+		return fmt.Errorf("key binding must be a string or array of strings, got %T", v)
+	}
+	return nil
+}
+
+// BuiltinThemes are the theme names with built-in palettes
+var BuiltinThemes = []string{"dark", "light", "solarized", "solarized-light", "high-contrast"}
+
+// ThemePalette defines a custom color theme. Colors are hex ("#1a1b26") or
+// ANSI color numbers ("4"); unset colors come from the base theme.
+type ThemePalette struct {
+	// Base is the built-in theme to start from (default: "dark")
+	Base string `toml:"base"`
+
+	Bg      string `toml:"bg"`
+	Surface string `toml:"surface"`
+	Border  string `toml:"border"`
+	Text    string `toml:"text"`
+	TextDim string `toml:"text_dim"`
+	Accent  string `toml:"accent"`
+	Purple  string `toml:"purple"`
+	Cyan    string `toml:"cyan"`
+	Green   string `toml:"green"`
+	Yellow  string `toml:"yellow"`
+	Orange  string `toml:"orange"`
+	Red     string `toml:"red"`
+	Comment string `toml:"comment"`
 }
 
 // ProfileSettings defines per-profile configuration overrides.
@@ -169,6 +264,12 @@ type LogSettings struct {
 	// Default: true
 	RemoveOrphans bool `toml:"remove_orphans"`
 
+	// PaneOutput appends each new session's pane output to
+	// ~/.agent-deck/logs/<session>.log (tmux pipe-pane). The TUI watches
+	// these files to update status as soon as output arrives.
+	// Default: false
+	PaneOutput bool `toml:"pane_output"`
+
 	// DebugLevel sets the minimum log level: "debug", "info", "warn", "error"
 	// Default: "info"
 	DebugLevel string `toml:"debug_level"`
@@ -272,7 +373,8 @@ type ExperimentsSettings struct {
 	DefaultTool string `toml:"default_tool"`
 }
 
-// NotificationsConfig configures the waiting session notification bar
+// NotificationsConfig configures the waiting session notification bar,
+// desktop, terminal and phone push notifications, and webhooks
 type NotificationsConfig struct {
 	// Enabled shows notification bar in tmux status (default: true)
 	Enabled bool `toml:"enabled"`
@@ -282,6 +384,58 @@ type NotificationsConfig struct {
 
 	// ShowAll displays all sessions (with status icons) instead of only waiting sessions (default: false)
 	ShowAll bool `toml:"show_all"`
+
+	// Desktop sends a native desktop notification (terminal-notifier or
+	// osascript on macOS, notify-send on Linux) when a session starts waiting
+	// or needs approval while the TUI is unfocused or you are attached to
+	// another session. Mute single sessions with "agent-deck session set
+	// <id> mute on" or the b key (default: false)
+	Desktop bool `toml:"desktop"`
+
+	// Terminal writes a notification escape sequence to the terminal on the
+	// same transitions, for SSH where desktop notifications can't reach the
+	// user: "bell" (BEL), "osc9" (iTerm2, WezTerm, kitty) or "osc777"
+	// (foot, Ghostty, VTE). Empty or invalid disables it (default: "")
+	Terminal string `toml:"terminal"`
+
+	// Webhooks are URLs that get a JSON POST on every session status change.
+	// The body carries "text" and "content" summaries, so Slack and Discord
+	// incoming webhooks work without a relay. Muted sessions are skipped
+	Webhooks []string `toml:"webhooks"`
+
+	// WebhookStatuses limits webhooks to changes into these statuses, e.g.
+	// ["waiting", "error"]. Empty sends every change (default: [])
+	WebhookStatuses []string `toml:"webhook_statuses"`
+
+	// Ntfy publishes phone pushes to an ntfy topic on the same transitions
+	// as Desktop
+	Ntfy NtfySettings `toml:"ntfy"`
+
+	// Pushover sends phone pushes through Pushover on the same transitions
+	// as Desktop
+	Pushover PushoverSettings `toml:"pushover"`
+}
+
+// NtfySettings configures [notifications.ntfy]
+type NtfySettings struct {
+	// Topic to publish to; empty disables ntfy
+	Topic string `toml:"topic"`
+
+	// Server is the ntfy server (default: "https://ntfy.sh")
+	Server string `toml:"server"`
+
+	// Token is an access token for protected topics (default: "")
+	Token string `toml:"token"`
+}
+
+// PushoverSettings configures [notifications.pushover]. Both keys are
+// required
+type PushoverSettings struct {
+	// Token is the Pushover application API token
+	Token string `toml:"token"`
+
+	// User is the user or group key to notify
+	User string `toml:"user"`
 }
 
 // InstanceSettings configures multiple agent-deck instance behavior
@@ -316,6 +470,12 @@ type ShellSettings struct {
 	// IgnoreMissingEnvFiles silently ignores missing .env files (default: true)
 	// When false, sessions will error if an env_file doesn't exist
 	IgnoreMissingEnvFiles *bool `toml:"ignore_missing_env_files"`
+
+	// ProjectEnv loads each session's project environment before the agent
+	// starts: "direnv" (the allowed .envrc), "dotenv" (.env), "auto" (direnv
+	// if there is an .envrc, else .env) or "off" (default). Sessions can
+	// override it with `session set <id> env <mode>`
+	ProjectEnv string `toml:"project_env"`
 }
 
 // GetIgnoreMissingEnvFiles returns whether to ignore missing env files, defaulting to true
@@ -387,6 +547,21 @@ func (a *AnalyticsDisplaySettings) GetShowCost() bool {
 	return *a.ShowCost
 }
 
+// NumberKeysSessions reports whether 1-9 jump to the sessions on screen
+// rather than to root groups.
+func (c *UserConfig) NumberKeysSessions() bool {
+	return strings.EqualFold(strings.TrimSpace(c.NumberKeys), "sessions")
+}
+
+// GetConfirmDelete returns whether deleting a session asks first,
+// defaulting to true
+func (c *UserConfig) GetConfirmDelete() bool {
+	if c.ConfirmDelete == nil {
+		return true
+	}
+	return *c.ConfirmDelete
+}
+
 // GetShowOutput returns whether to show terminal output in preview
 func (c *UserConfig) GetShowOutput() bool {
 	return c.Preview.GetShowOutput()
@@ -560,12 +735,23 @@ type ToolDef struct {
 	// Icon is the emoji/symbol to display
 	Icon string `toml:"icon"`
 
+	// NerdIcon is the Nerd Font glyph shown in the session list when tool
+	// icons are on (see tool_icons). Without it the tool name is shown.
+	NerdIcon string `toml:"nerd_icon"`
+
+	// Label is the name shown in the New Session command picker (default: tool name)
+	Label string `toml:"label"`
+
 	// BusyPatterns are strings that indicate the tool is busy
 	BusyPatterns []string `toml:"busy_patterns"`
 
 	// PromptPatterns are strings that indicate the tool is waiting for input
 	PromptPatterns []string `toml:"prompt_patterns"`
 
+	// ErrorPatterns are output lines that put the session into the error
+	// status (rate limits, auth failures, crashes). Replaces the defaults.
+	ErrorPatterns []string `toml:"error_patterns"`
+
 	// DetectPatterns are regex patterns to auto-detect this tool from terminal content
 	DetectPatterns []string `toml:"detect_patterns"`
 
@@ -605,11 +791,26 @@ type ToolDef struct {
 	// PromptPatternsExtra appends additional prompt patterns to the built-in defaults
 	PromptPatternsExtra []string `toml:"prompt_patterns_extra"`
 
+	// ErrorPatternsExtra appends additional error patterns to the built-in defaults
+	ErrorPatternsExtra []string `toml:"error_patterns_extra"`
+
 	// SpinnerChars replaces the default spinner characters entirely (use with caution)
 	SpinnerChars []string `toml:"spinner_chars"`
 
 	// SpinnerCharsExtra appends additional spinner characters to the built-in defaults
 	SpinnerCharsExtra []string `toml:"spinner_chars_extra"`
+
+	// Panes are commands opened in side panes next to the agent when a session
+	// starts, e.g. panes = ["git status", "npm test -- --watch"]
+	Panes []string `toml:"panes"`
+
+	// PaneLayout is the tmux layout for sessions with panes (default "main-vertical").
+	// Any tmux layout name works: main-horizontal, even-horizontal, tiled, ...
+	PaneLayout string `toml:"pane_layout"`
+
+	// TmuxOptions are tmux options for this tool's sessions, applied on top of
+	// [tmux] options, e.g. tmux_options = { "history-limit" = "100000" }
+	TmuxOptions map[string]string `toml:"tmux_options"`
 }
 
 // HTTPServerConfig defines how to auto-start an HTTP MCP server
@@ -700,7 +901,11 @@ func (m *MCPDef) HasAutoStartServer() bool {
 //
 //	[tmux]
 //	inject_status_line = false
+//	socket = "agentdeck"
 //	options = { "allow-passthrough" = "all", "history-limit" = "50000" }
+//
+//	[tmux.group_options.work]
+//	aggressive-resize = "on"
 type TmuxSettings struct {
 	// InjectStatusLine controls whether agent-deck injects a custom status line
 	// into new tmux sessions. When false, the tmux status bar is not modified,
@@ -708,9 +913,44 @@ type TmuxSettings struct {
 	// Default: true (nil = use default true)
 	InjectStatusLine *bool `toml:"inject_status_line"`
 
+	// Socket runs agent-deck's sessions on a dedicated tmux server
+	// (tmux -L <socket>) instead of the default one, so agent-deck and a
+	// personal tmux setup never see each other's sessions.
+	// Default: "" (default server)
+	Socket string `toml:"socket"`
+
 	// Options is a map of tmux option names to values.
 	// These are passed to `tmux set-option -t <session>` after defaults.
 	Options map[string]string `toml:"options"`
+
+	// GroupOptions maps a group path to tmux options for sessions in that
+	// group or its subgroups. They take precedence over Options and the
+	// tool's tmux_options; a subgroup's options win over its parent's.
+	GroupOptions map[string]map[string]string `toml:"group_options"`
+}
+
+// SessionOptions returns the tmux options for a session of the given tool in
+// the given group: [tmux] options, then the tool's tmux_options, then group
+// options from the outermost group inward. Later layers override earlier ones.
+func (t TmuxSettings) SessionOptions(toolDef *ToolDef, groupPath string) map[string]string {
+	opts := make(map[string]string)
+	for k, v := range t.Options {
+		opts[k] = v
+	}
+	if toolDef != nil {
+		for k, v := range toolDef.TmuxOptions {
+			opts[k] = v
+		}
+	}
+	if groupPath != "" {
+		parts := strings.Split(groupPath, "/")
+		for n := 1; n <= len(parts); n++ {
+			for k, v := range t.GroupOptions[strings.Join(parts[:n], "/")] {
+				opts[k] = v
+			}
+		}
+	}
+	return opts
 }
 
 // GetInjectStatusLine returns whether to inject status line, defaulting to true
@@ -721,9 +961,99 @@ func (t TmuxSettings) GetInjectStatusLine() bool {
 	return *t.InjectStatusLine
 }
 
+// StatusSettings controls how often session status is polled, how long a
+// session stays "running" after its busy indicator disappears, and whether
+// status changes are logged.
+// Control mode pipes are always enabled (no longer configurable).
+//
+// Polling is adaptive: active sessions are polled every
+// active_poll_interval_ms, idle and errored ones every
+// idle_poll_interval_ms, and while the terminal is unfocused active ones
+// drop back to poll_interval_ms.
+//
+// Example config.toml:
+//
+//	[status]
+//	poll_interval_ms = 5000         # slower polling on battery
+//	active_poll_interval_ms = 2000
+//	activity_cooldown_ms = 3000     # settle fast agents to waiting sooner
+//	history_log = true              # keep every status change on disk
 type StatusSettings struct {
-	// Reserved for future status detection settings.
-	// Control mode pipes are always enabled (no longer configurable).
+	// PollIntervalMs is the base interval between status polls in the TUI,
+	// and how often active sessions are polled while the terminal is
+	// unfocused. Default: 2000. Values below 250 are raised to 250.
+	PollIntervalMs int `toml:"poll_interval_ms"`
+
+	// ActivePollIntervalMs is how often running, starting and waiting
+	// sessions are polled while the terminal is focused. Default: 500.
+	// Never slower than PollIntervalMs.
+	ActivePollIntervalMs int `toml:"active_poll_interval_ms"`
+
+	// IdlePollIntervalMs is how often idle (acknowledged) and errored
+	// sessions are polled: they only change once the user acts on them.
+	// Default: 5000. Never faster than PollIntervalMs.
+	IdlePollIntervalMs int `toml:"idle_poll_interval_ms"`
+
+	// ActivityCooldownMs is how long a session keeps showing as running after
+	// its spinner/busy indicator was last seen. Covers the gap between tool
+	// calls. Default: 6000.
+	ActivityCooldownMs int `toml:"activity_cooldown_ms"`
+
+	// HistoryLog appends every session status change to
+	// logs/status-history.jsonl in the data directory. Default: false.
+	HistoryLog bool `toml:"history_log"`
+}
+
+const (
+	defaultPollInterval       = 2 * time.Second
+	defaultActivePollInterval = 500 * time.Millisecond
+	defaultIdlePollInterval   = 5 * time.Second
+	minPollInterval           = 250 * time.Millisecond
+	defaultActivityCooldown   = 6 * time.Second
+)
+
+// GetPollInterval returns the status poll interval, defaulting to 2s
+func (s StatusSettings) GetPollInterval() time.Duration {
+	return ClampPollInterval(time.Duration(s.PollIntervalMs) * time.Millisecond)
+}
+
+// GetActivePollInterval returns how often active sessions are polled,
+// defaulting to 500ms and capped at the poll interval
+func (s StatusSettings) GetActivePollInterval() time.Duration {
+	active := defaultActivePollInterval
+	if s.ActivePollIntervalMs > 0 {
+		active = ClampPollInterval(time.Duration(s.ActivePollIntervalMs) * time.Millisecond)
+	}
+	return min(active, s.GetPollInterval())
+}
+
+// GetIdlePollInterval returns how often idle and errored sessions are
+// polled, defaulting to 5s and at least the poll interval
+func (s StatusSettings) GetIdlePollInterval() time.Duration {
+	idle := defaultIdlePollInterval
+	if s.IdlePollIntervalMs > 0 {
+		idle = time.Duration(s.IdlePollIntervalMs) * time.Millisecond
+	}
+	return max(idle, s.GetPollInterval())
+}
+
+// GetActivityCooldown returns the activity cooldown, defaulting to 6s
+func (s StatusSettings) GetActivityCooldown() time.Duration {
+	if s.ActivityCooldownMs <= 0 {
+		return defaultActivityCooldown
+	}
+	return time.Duration(s.ActivityCooldownMs) * time.Millisecond
+}
+
+// ClampPollInterval applies the default (for d <= 0) and minimum poll interval
+func ClampPollInterval(d time.Duration) time.Duration {
+	if d <= 0 {
+		return defaultPollInterval
+	}
+	if d < minPollInterval {
+		return minPollInterval
+	}
+	return d
 }
 
 // MaintenanceSettings controls the automatic maintenance worker
@@ -733,6 +1063,103 @@ type MaintenanceSettings struct {
 	Enabled bool `toml:"enabled"`
 }
 
+// BackupSettings controls automatic backups of session state (state.db).
+// Backups are taken before a save, at most once per MinIntervalMinutes, and
+// stored in ~/.agent-deck/backups/<profile>/. Restore with `agent-deck restore`.
+type BackupSettings struct {
+	// Enabled turns automatic backups on or off (default: true)
+	Enabled *bool `toml:"enabled"`
+
+	// Keep is the number of backups to keep per profile (default: 10)
+	Keep int `toml:"keep"`
+
+	// MinIntervalMinutes is the minimum time between backups (default: 10).
+	// The TUI saves on every change, so backing up each save would rotate
+	// out useful history within seconds.
+	MinIntervalMinutes int `toml:"min_interval_minutes"`
+}
+
+// CleanupSettings is the idle-session cleanup policy. Sessions idle for
+// IdleHours are offered for cleanup in the TUI ("C" key); nothing is removed
+// until the list is reviewed and confirmed.
+type CleanupSettings struct {
+	// IdleHours is how long a session must be idle (acknowledged, with no
+	// new output) before it is offered for cleanup (default: 0 = off)
+	IdleHours int `toml:"idle_hours"`
+
+	// Action is what cleanup does to idle sessions: "archive" (default)
+	// saves them to the archive and deletes them, "kill" stops their tmux
+	// sessions and keeps them in the list
+	Action string `toml:"action"`
+}
+
+// ProjectsSettings configures project discovery for the New Session
+// dialog's project picker (ctrl+o): git repositories under Roots.
+type ProjectsSettings struct {
+	// Roots are the directories searched for git repositories
+	// (default: ~/code, ~/src and ~/projects, where they exist)
+	Roots []string `toml:"roots"`
+
+	// MaxDepth is how many directories below a root to search (default: 3)
+	MaxDepth int `toml:"max_depth"`
+}
+
+// GetRoots returns the roots to search, defaulting to DefaultProjectRoots.
+func (p ProjectsSettings) GetRoots() []string {
+	if len(p.Roots) == 0 {
+		return DefaultProjectRoots
+	}
+	return p.Roots
+}
+
+// GetMaxDepth returns the search depth, defaulting to DefaultProjectMaxDepth.
+func (p ProjectsSettings) GetMaxDepth() int {
+	if p.MaxDepth <= 0 {
+		return DefaultProjectMaxDepth
+	}
+	return p.MaxDepth
+}
+
+// IdleAfter returns the idle threshold, or 0 when the policy is off.
+func (c CleanupSettings) IdleAfter() time.Duration {
+	return time.Duration(max(c.IdleHours, 0)) * time.Hour
+}
+
+// GetAction returns the cleanup action, defaulting to "archive".
+func (c CleanupSettings) GetAction() string {
+	if strings.EqualFold(strings.TrimSpace(c.Action), "kill") {
+		return "kill"
+	}
+	return "archive"
+}
+
+// GetEnabled returns whether automatic backups are enabled, defaulting to true
+func (b BackupSettings) GetEnabled() bool {
+	if b.Enabled == nil {
+		return true
+	}
+	return *b.Enabled
+}
+
+// GetKeep returns the number of backups to keep, defaulting to 10
+func (b BackupSettings) GetKeep() int {
+	if b.Keep <= 0 {
+		return 10
+	}
+	return b.Keep
+}
+
+// GetMinInterval returns the minimum time between backups, defaulting to 10 minutes
+func (b BackupSettings) GetMinInterval() time.Duration {
+	if b.MinIntervalMinutes < 0 {
+		return 0
+	}
+	if b.MinIntervalMinutes == 0 {
+		return 10 * time.Minute
+	}
+	return time.Duration(b.MinIntervalMinutes) * time.Minute
+}
+
 // Default user config (empty maps)
 var defaultUserConfig = UserConfig{
 	Tools: make(map[string]ToolDef),
@@ -745,9 +1172,9 @@ var (
 	userConfigCacheMu sync.RWMutex
 )
 
-// GetUserConfigPath returns the path to the user config file
+// GetUserConfigPath returns the path to the user config file (see GetConfigDir)
 func GetUserConfigPath() (string, error) {
-	dir, err := GetAgentDeckDir()
+	dir, err := GetConfigDir()
 	if err != nil {
 		return "", err
 	}
@@ -938,6 +1365,50 @@ func GetCustomToolNames() []string {
 	return names
 }
 
+// builtinPresetTools are the built-in tools offered by the New Session picker
+var builtinPresetTools = []string{"claude", "gemini", "opencode", "codex"}
+
+// GetPresetCommands returns the New Session picker entries in display order.
+// The first entry is always "" (plain shell). When presets is set in
+// config.toml, only the listed built-in or custom tools are included; unknown
+// names are skipped. Otherwise built-in tools come first, then custom tools.
+func GetPresetCommands() []string {
+	presets := []string{""}
+
+	config, _ := LoadUserConfig()
+	if config == nil || len(config.Presets) == 0 {
+		presets = append(presets, builtinPresetTools...)
+		return append(presets, GetCustomToolNames()...)
+	}
+
+	seen := map[string]bool{"": true, "shell": true}
+	for _, name := range config.Presets {
+		name = strings.TrimSpace(name)
+		if seen[name] {
+			continue
+		}
+		_, isCustom := config.Tools[name]
+		if !isCustom && !slices.Contains(builtinPresetTools, name) {
+			continue
+		}
+		seen[name] = true
+		presets = append(presets, name)
+	}
+	return presets
+}
+
+// GetToolLabel returns the picker label for a tool: the configured label if
+// set, otherwise the tool name ("shell" for the empty command).
+func GetToolLabel(toolName string) string {
+	if def := GetToolDef(toolName); def != nil && def.Label != "" {
+		return def.Label
+	}
+	if toolName == "" {
+		return "shell"
+	}
+	return toolName
+}
+
 // GetToolIcon returns the icon for a tool (custom or built-in)
 func GetToolIcon(toolName string) string {
 	// Check custom tools first
@@ -990,12 +1461,14 @@ func MergeToolPatterns(toolName string) *tmux.RawPatterns {
 		return nil
 	}
 
-	// Build overrides from ToolDef's replace fields (BusyPatterns, PromptPatterns, SpinnerChars)
+	// Build overrides from ToolDef's replace fields (BusyPatterns, PromptPatterns, ErrorPatterns, SpinnerChars)
 	var overrides *tmux.RawPatterns
-	if toolDef != nil && (toolDef.BusyPatterns != nil || toolDef.PromptPatterns != nil || toolDef.SpinnerChars != nil) {
+	if toolDef != nil && (toolDef.BusyPatterns != nil || toolDef.PromptPatterns != nil ||
+		toolDef.ErrorPatterns != nil || toolDef.SpinnerChars != nil) {
 		overrides = &tmux.RawPatterns{
 			BusyPatterns:   toolDef.BusyPatterns,
 			PromptPatterns: toolDef.PromptPatterns,
+			ErrorPatterns:  toolDef.ErrorPatterns,
 			SpinnerChars:   toolDef.SpinnerChars,
 		}
 	}
@@ -1003,10 +1476,12 @@ func MergeToolPatterns(toolName string) *tmux.RawPatterns {
 	// Build extras from ToolDef's *Extra fields
 	var extras *tmux.RawPatterns
 	if toolDef != nil &&
-		(len(toolDef.BusyPatternsExtra) > 0 || len(toolDef.PromptPatternsExtra) > 0 || len(toolDef.SpinnerCharsExtra) > 0) {
+		(len(toolDef.BusyPatternsExtra) > 0 || len(toolDef.PromptPatternsExtra) > 0 ||
+			len(toolDef.ErrorPatternsExtra) > 0 || len(toolDef.SpinnerCharsExtra) > 0) {
 		extras = &tmux.RawPatterns{
 			BusyPatterns:   toolDef.BusyPatternsExtra,
 			PromptPatterns: toolDef.PromptPatternsExtra,
+			ErrorPatterns:  toolDef.ErrorPatternsExtra,
 			SpinnerChars:   toolDef.SpinnerCharsExtra,
 		}
 	}
@@ -1030,12 +1505,43 @@ func GetTheme() string {
 	if err != nil || config == nil {
 		return "dark"
 	}
-	switch config.Theme {
-	case "dark", "light", "system":
+	if config.Theme == "system" || slices.Contains(BuiltinThemes, config.Theme) {
+		return config.Theme
+	}
+	if _, ok := config.Themes[config.Theme]; ok && config.Theme != "" {
 		return config.Theme
-	default:
-		return "dark"
 	}
+	return "dark"
+}
+
+// GetThemePalette returns a user-defined palette from [themes.*], or nil if
+// name is not defined there.
+func GetThemePalette(name string) *ThemePalette {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	if palette, ok := config.Themes[name]; ok {
+		return &palette
+	}
+	return nil
+}
+
+// GetCustomThemeNames returns sorted [themes.*] names, excluding names that
+// shadow built-in themes or "system".
+func GetCustomThemeNames() []string {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return nil
+	}
+	var names []string
+	for name := range config.Themes {
+		if name != "system" && !slices.Contains(BuiltinThemes, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
 }
 
 // ResolveTheme resolves the configured theme to "dark" or "light".
@@ -1227,6 +1733,33 @@ func GetMaintenanceSettings() MaintenanceSettings {
 	return config.Maintenance
 }
 
+// GetBackupSettings returns session state backup settings
+func GetBackupSettings() BackupSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return BackupSettings{} // Defaults applied via getters
+	}
+	return config.Backups
+}
+
+// GetCleanupSettings returns the idle-session cleanup policy
+func GetCleanupSettings() CleanupSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return CleanupSettings{}
+	}
+	return config.Cleanup
+}
+
+// GetProjectsSettings returns project discovery settings
+func GetProjectsSettings() ProjectsSettings {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return ProjectsSettings{} // Defaults applied via getters
+	}
+	return config.Projects
+}
+
 // GetStatusSettings returns status detection settings with defaults applied.
 func GetStatusSettings() StatusSettings {
 	config, err := LoadUserConfig()
@@ -1245,6 +1778,24 @@ func GetTmuxSettings() TmuxSettings {
 	return config.Tmux
 }
 
+// GetMultiplexer returns the configured session multiplexer, "" for tmux.
+func GetMultiplexer() string {
+	config, err := LoadUserConfig()
+	if err != nil || config == nil {
+		return ""
+	}
+	return config.Multiplexer
+}
+
+// GetTmuxSocket returns the dedicated tmux socket name, or "" for the default
+// server. AGENTDECK_TMUX_SOCKET (set by --tmux-socket) overrides [tmux] socket.
+func GetTmuxSocket() string {
+	if name := strings.TrimSpace(os.Getenv("AGENTDECK_TMUX_SOCKET")); name != "" {
+		return name
+	}
+	return strings.TrimSpace(GetTmuxSettings().Socket)
+}
+
 // GetInstanceSettings returns instance behavior settings
 func GetInstanceSettings() InstanceSettings {
 	config, err := LoadUserConfig()
@@ -1327,6 +1878,34 @@ func CreateExampleConfig() error {
 # Leave commented out or empty to default to shell (no pre-selection)
 # default_tool = "claude"
 
+# Color theme: "dark" (default), "light", "solarized", "solarized-light",
+# "high-contrast", "system" (follow OS dark mode), or a [themes.*] name
+# theme = "dark"
+
+# Commands offered in the New Session picker, in order (shell is always first)
+# Entries are built-in tools or [tools.*] names. Default: all built-ins, then custom tools
+# presets = ["claude", "claude-yolo", "codex"]
+
+# Terminal multiplexer hosting the sessions: "tmux", "zellij" (0.41+) or "screen"
+# Default: tmux, or GNU screen when tmux isn't installed but screen is
+# Other backends cover create/attach/send/capture; the status bar, side panes
+# and event-driven status detection are tmux-only
+# multiplexer = "zellij"
+
+# Ask before d deletes a session (default: true). With false, sessions are
+# deleted right away and Ctrl+Z brings them back
+# confirm_delete = false
+
+# What 1-9 jump to: "groups" (default, the Nth root group) or "sessions"
+# (the Nth session on screen, numbered in the list; the same digit again attaches)
+# number_keys = "sessions"
+
+# How the session list shows each session's tool: "auto" (default: Nerd Font
+# glyphs when NERD_FONT is set or the terminal is WezTerm or Ghostty),
+# "nerd" (always glyphs) or "text" (the tool name). Custom tools set their
+# glyph with nerd_icon under [tools.<name>]
+# tool_icons = "nerd"
+
 # Claude Code integration
 # [claude]
 # Custom config directory (for dual account setups)
@@ -1365,6 +1944,8 @@ max_size_mb = 10
 max_lines = 10000
 # Remove log files for sessions that no longer exist (default: true)
 remove_orphans = true
+# Log each new session's pane output, for faster status updates (default: false)
+# pane_output = true
 
 # Update settings
 # Controls automatic update checking and installation
@@ -1412,8 +1993,94 @@ auto_cleanup = true
 # When false, your existing tmux status line configuration is preserved
 # Default: true (agent-deck injects its own status bar with session info)
 # inject_status_line = false
+# Run sessions on a dedicated tmux server (tmux -L agentdeck) so they stay out
+# of your personal tmux server. Attach manually with: tmux -L agentdeck attach
+# Changing this does not move running sessions; restart them afterwards.
+# socket = "agentdeck"
 # Override tmux options applied to every session (applied after defaults)
 # options = { "allow-passthrough" = "all", "history-limit" = "50000" }
+# Options for sessions in one group (and its subgroups) override the above
+# [tmux.group_options.work]
+# aggressive-resize = "on"
+# status-left = " #(agent-deck status -q) waiting "
+
+# Status polling
+# [status]
+# How often the TUI polls session status, in milliseconds (default: 2000)
+# Raise it on battery to save CPU; values below 250 are raised to 250.
+# Active sessions use this rate while the terminal is unfocused.
+# poll_interval_ms = 5000
+# Running, starting and waiting sessions are polled faster while the
+# terminal is focused (default: 500), idle and errored ones slower
+# (default: 5000)
+# active_poll_interval_ms = 2000
+# idle_poll_interval_ms = 10000
+# How long a session keeps showing as running after its spinner disappears,
+# in milliseconds (default: 6000). Lower it for fast agents.
+# activity_cooldown_ms = 3000
+# Append every status change to ~/.agent-deck/logs/status-history.jsonl
+# (default: false). Recent changes always show in the preview pane.
+# history_log = true
+# Per-session overrides: agent-deck session set <id> poll-interval 10s
+#                        agent-deck session set <id> cooldown 2s
+
+# Notifications
+# [notifications]
+# Desktop notification when a session starts waiting or needs approval while
+# the TUI is unfocused or you are attached elsewhere (default: false).
+# Uses terminal-notifier or osascript on macOS, notify-send on Linux.
+# Mute one session: agent-deck session set <id> mute on (or b in the TUI),
+# a group: agent-deck group update <name> --mute on (or b on the group),
+# everything: B in the TUI (do-not-disturb)
+# desktop = true
+# Same, as a terminal escape sequence that also works over SSH:
+# "bell", "osc9" (iTerm2, WezTerm, kitty) or "osc777" (foot, Ghostty, VTE).
+# Inside tmux, OSC needs: set -g allow-passthrough on
+# terminal = "osc9"
+# POST a JSON event on every status change (Slack and Discord URLs work as is)
+# webhooks = ["https://hooks.slack.com/services/..."]
+# Only send changes into these statuses (default: all)
+# webhook_statuses = ["waiting", "needs_input", "error"]
+# Phone push on the same transitions as desktop notifications
+# [notifications.ntfy]
+# topic = "my-agent-deck-1234"
+# server = "https://ntfy.sh"
+# [notifications.pushover]
+# token = "<application token>"
+# user = "<user key>"
+
+# Session state backups (restore with: agent-deck restore)
+# Stored in ~/.agent-deck/backups/<profile>/, taken before saves
+# [backups]
+# enabled = true
+# Number of backups to keep per profile (default: 10)
+# keep = 10
+# Minimum minutes between backups (default: 10, -1 = every save)
+# min_interval_minutes = 10
+
+# Session shell environment, loaded before the agent starts
+# [shell]
+# .env files sourced for every session
+# env_files = ["~/.agent-deck.env"]
+# Load each project's environment: "direnv" (the allowed .envrc), "dotenv"
+# (.env), "auto" (direnv if there is an .envrc, else .env) or "off" (default).
+# Override per session with: agent-deck session set <id> env <mode>
+# project_env = "auto"
+
+# Idle-session cleanup. Sessions idle (acknowledged, no new output) for
+# idle_hours are listed for review with C; nothing happens until you confirm.
+# [cleanup]
+# idle_hours = 72
+# "archive" (default) archives and deletes them, "kill" stops their tmux
+# sessions but keeps them in the list
+# action = "archive"
+
+# Projects offered by the New Session dialog's picker (ctrl+o): git
+# repositories under these directories
+# [projects]
+# roots = ["~/code", "~/src", "~/projects"]
+# How many directories below a root to search (default: 3)
+# max_depth = 3
 
 # ============================================================================
 # MCP Server Definitions
@@ -1529,6 +2196,56 @@ auto_cleanup = true
 # dangerous_flag = "--dangerously-skip-permissions"
 # env = { ANTHROPIC_BASE_URL = "https://api.example.com/v4", API_KEY = "your-key" }
 
+# Example: Preset with arguments and a custom picker label
+# [tools.claude-yolo]
+# command = "claude --dangerously-skip-permissions"
+# label = "claude (yolo)"
+
+# Example: Side panes next to the agent (works for built-in tools too).
+# The agent keeps the main pane; pane_layout is any tmux layout name.
+# [tools.claude]
+# panes = ["git status", "npm test -- --watch"]
+# tmux_options = { "history-limit" = "100000" }
+# pane_layout = "main-vertical"
+
+# Example: Custom color theme (select with theme = "my-theme")
+# Colors not set are taken from the base theme
+# [themes.my-theme]
+# base = "light"
+# text = "#1c1c1c"
+# accent = "#005f87"
+
+# ============================================================================
+# Keybindings
+# ============================================================================
+# Remap main-screen keys. Each action takes one key or a list of keys; setting
+# an action replaces its defaults. If two actions claim the same key, a warning
+# is shown and the first action keeps it.
+# Actions: quit, up, down, half_page_up, half_page_down, page_up, page_down,
+# attach, expand, collapse, move_up, move_down, new, quick_new, group, rename,
+# move, delete, undo, search, global_search, help, settings, import, restart,
+# fork, fork_dialog, mcp, skills, copy, send_output, mark_unread, preview_mode,
+# yolo, gemini_model, worktree_finish, refresh, profiles, archive, notes,
+# tags, tag_filter, mute, do_not_disturb, resume, fork_tree, transcript,
+# prompt, diff, cleanup, narrow_list, widen_list, toggle_preview, scrollback,
+# plain_preview, group_sort, recent, bottom, dashboard, info, notifications,
+# errors, density
+# [keys]
+# delete = "D"
+# up = ["up", "o"]
+# down = ["down", "n"]
+# new = "a"
+
+# ============================================================================
+# Prompt Snippets
+# ============================================================================
+# Named prompts for the prompt bar (p, then ctrl+o to pick one). Placeholders
+# are filled in for the selected session: {{branch}}, {{path}}, {{title}},
+# {{group}} and {{tool}}.
+# [snippets]
+# review = "Review the changes on {{branch}} and list anything risky"
+# tests = "Run the test suite in {{path}} and fix any failures"
+
 # ============================================================================
 # Status Detection Pattern Overrides (Advanced)
 # ============================================================================
@@ -1543,6 +2260,11 @@ auto_cleanup = true
 # prompt_patterns_extra = ["Custom>"]
 # spinner_chars_extra = ["@"]
 #
+# error_patterns mark the session as errored (red) when they show up at the
+# bottom of the pane: rate limits, auth failures, crashes. Built-in tools
+# already know their own; add more for wrappers or custom tools:
+# error_patterns_extra = ["Proxy authentication required", "re:^FATAL "]
+#
 # Replace all defaults (use with caution):
 # [tools.claude]
 # busy_patterns = ["only-this-pattern"]
@@ -3,7 +3,9 @@ package session
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
 )
@@ -733,6 +735,69 @@ enabled = true
 	}
 }
 
+func TestStatusSettings_Defaults(t *testing.T) {
+	var s StatusSettings
+	if got := s.GetPollInterval(); got != 2*time.Second {
+		t.Errorf("GetPollInterval() = %v, want 2s", got)
+	}
+	if got := s.GetActivePollInterval(); got != 500*time.Millisecond {
+		t.Errorf("GetActivePollInterval() = %v, want 500ms", got)
+	}
+	if got := s.GetIdlePollInterval(); got != 5*time.Second {
+		t.Errorf("GetIdlePollInterval() = %v, want 5s", got)
+	}
+
+	// Active is never slower, idle never faster, than the base interval
+	s = StatusSettings{PollIntervalMs: 8000, ActivePollIntervalMs: 10000}
+	if got := s.GetActivePollInterval(); got != 8*time.Second {
+		t.Errorf("GetActivePollInterval() = %v, want capped at 8s", got)
+	}
+	if got := s.GetIdlePollInterval(); got != 8*time.Second {
+		t.Errorf("GetIdlePollInterval() = %v, want raised to 8s", got)
+	}
+	if got := s.GetActivityCooldown(); got != 6*time.Second {
+		t.Errorf("GetActivityCooldown() = %v, want 6s", got)
+	}
+}
+
+func TestGetStatusSettings_FromConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHome)
+
+	agentDeckDir := filepath.Join(tempDir, ".agent-deck")
+	_ = os.MkdirAll(agentDeckDir, 0700)
+
+	configPath := filepath.Join(agentDeckDir, "config.toml")
+	configContent := `
+[status]
+poll_interval_ms = 100
+activity_cooldown_ms = 1500
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	ClearUserConfigCache()
+	defer ClearUserConfigCache()
+
+	settings := GetStatusSettings()
+	if got := settings.GetPollInterval(); got != 250*time.Millisecond {
+		t.Errorf("GetPollInterval() = %v, want clamped 250ms", got)
+	}
+	if got := settings.GetActivityCooldown(); got != 1500*time.Millisecond {
+		t.Errorf("GetActivityCooldown() = %v, want 1.5s", got)
+	}
+
+	inst := &Instance{ActivityCooldown: 500 * time.Millisecond, PollInterval: 10 * time.Second}
+	if got := inst.EffectiveActivityCooldown(); got != 500*time.Millisecond {
+		t.Errorf("EffectiveActivityCooldown() = %v, want per-session 500ms", got)
+	}
+	if got := inst.EffectivePollInterval(); got != 10*time.Second {
+		t.Errorf("EffectivePollInterval() = %v, want per-session 10s", got)
+	}
+}
+
 func TestGetTmuxSettings_InjectStatusLine_Default(t *testing.T) {
 	// Default (no config) should return true
 	tempDir := t.TempDir()
@@ -808,3 +873,159 @@ inject_status_line = true
 		t.Error("GetInjectStatusLine should be true when set to true")
 	}
 }
+
+func TestTmuxSettings_SessionOptions(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHome)
+	defer ClearUserConfigCache()
+
+	agentDeckDir := filepath.Join(tempDir, ".agent-deck")
+	_ = os.MkdirAll(agentDeckDir, 0700)
+
+	configContent := `
+[tmux]
+options = { "history-limit" = "20000", "mouse" = "off" }
+
+[tmux.group_options.work]
+history-limit = "40000"
+aggressive-resize = "on"
+
+[tmux.group_options."work/api"]
+history-limit = "80000"
+
+[tools.claude]
+tmux_options = { "history-limit" = "30000", "status-left" = "claude" }
+`
+	if err := os.WriteFile(filepath.Join(agentDeckDir, "config.toml"), []byte(configContent), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	ClearUserConfigCache()
+
+	settings := GetTmuxSettings()
+	claude := GetToolDef("claude")
+	if claude == nil {
+		t.Fatal("expected [tools.claude] to be loaded")
+	}
+
+	tests := []struct {
+		name  string
+		tool  *ToolDef
+		group string
+		want  map[string]string
+	}{
+		{"global only", nil, "", map[string]string{"history-limit": "20000", "mouse": "off"}},
+		{"tool overrides global", claude, "", map[string]string{"history-limit": "30000", "mouse": "off", "status-left": "claude"}},
+		{"group overrides tool", claude, "work", map[string]string{"history-limit": "40000", "mouse": "off", "status-left": "claude", "aggressive-resize": "on"}},
+		{"subgroup inherits and overrides parent", nil, "work/api", map[string]string{"history-limit": "80000", "mouse": "off", "aggressive-resize": "on"}},
+		{"unrelated group with shared prefix", nil, "workshop", map[string]string{"history-limit": "20000", "mouse": "off"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := settings.SessionOptions(tt.tool, tt.group)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SessionOptions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetPresetCommands(t *testing.T) {
+	tempDir := t.TempDir()
+	originalHome := os.Getenv("HOME")
+	os.Setenv("HOME", tempDir)
+	defer os.Setenv("HOME", originalHome)
+	defer ClearUserConfigCache()
+
+	agentDeckDir := filepath.Join(tempDir, ".agent-deck")
+	_ = os.MkdirAll(agentDeckDir, 0700)
+	configPath := filepath.Join(agentDeckDir, "config.toml")
+
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(configPath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		ClearUserConfigCache()
+	}
+
+	t.Run("defaults append custom tools", func(t *testing.T) {
+		writeConfig(`
+[tools.claude-yolo]
+command = "claude --dangerously-skip-permissions"
+label = "claude (yolo)"
+`)
+		got := GetPresetCommands()
+		want := []string{"", "claude", "gemini", "opencode", "codex", "claude-yolo"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetPresetCommands() = %q, want %q", got, want)
+		}
+		if label := GetToolLabel("claude-yolo"); label != "claude (yolo)" {
+			t.Errorf("GetToolLabel(claude-yolo) = %q, want %q", label, "claude (yolo)")
+		}
+		if label := GetToolLabel(""); label != "shell" {
+			t.Errorf("GetToolLabel(\"\") = %q, want %q", label, "shell")
+		}
+		if label := GetToolLabel("codex"); label != "codex" {
+			t.Errorf("GetToolLabel(codex) = %q, want %q", label, "codex")
+		}
+	})
+
+	t.Run("presets order and filter", func(t *testing.T) {
+		writeConfig(`
+presets = ["claude-yolo", "shell", "codex", "unknown", "codex", "claude"]
+
+[tools.claude-yolo]
+command = "claude --dangerously-skip-permissions"
+
+[tools.other]
+command = "other-ai"
+`)
+		got := GetPresetCommands()
+		want := []string{"", "claude-yolo", "codex", "claude"}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetPresetCommands() = %q, want %q", got, want)
+		}
+	})
+}
+
+func TestKeyListUnmarshalTOML(t *testing.T) {
+	var cfg UserConfig
+	if _, err := toml.Decode(`
+[keys]
+delete = "D"
+up = ["up", "e"]
+`, &cfg); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := cfg.Keys["delete"]; len(got) != 1 || got[0] != "D" {
+		t.Errorf("delete = %v, want [D]", got)
+	}
+	if got := cfg.Keys["up"]; len(got) != 2 || got[1] != "e" {
+		t.Errorf("up = %v, want [up e]", got)
+	}
+}
+
+func TestConfirmDeleteDefault(t *testing.T) {
+	cfg := &UserConfig{}
+	if !cfg.GetConfirmDelete() {
+		t.Error("GetConfirmDelete should default to true")
+	}
+	off := false
+	cfg.ConfirmDelete = &off
+	if cfg.GetConfirmDelete() {
+		t.Error("confirm_delete = false should skip the prompt")
+	}
+}
+
+func TestNumberKeysSessions(t *testing.T) {
+	cfg := &UserConfig{}
+	if cfg.NumberKeysSessions() {
+		t.Error("1-9 should jump to groups by default")
+	}
+	cfg.NumberKeys = "Sessions"
+	if !cfg.NumberKeysSessions() {
+		t.Error(`number_keys = "sessions" should number the sessions`)
+	}
+}
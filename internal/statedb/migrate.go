@@ -60,6 +60,8 @@ type jsonGroupData struct {
 	Expanded    bool   `json:"expanded"`
 	Order       int    `json:"order"`
 	DefaultPath string `json:"default_path,omitempty"`
+	Muted       bool   `json:"muted,omitempty"`
+	SortMode    string `json:"sort_mode,omitempty"`
 }
 
 // toolDataBlob is the JSON structure stored in the tool_data column.
@@ -77,6 +79,15 @@ type toolDataBlob struct {
 	LatestPrompt       string          `json:"latest_prompt,omitempty"`
 	LoadedMCPNames     []string        `json:"loaded_mcp_names,omitempty"`
 	ToolOptions        json.RawMessage `json:"tool_options,omitempty"`
+	PollIntervalMs     int64           `json:"poll_interval_ms,omitempty"`
+	ActivityCooldownMs int64           `json:"activity_cooldown_ms,omitempty"`
+	NotifyMuted        bool            `json:"notify_muted,omitempty"`
+	DetectedModel      string          `json:"detected_model,omitempty"`
+	ForkedFromID       string          `json:"forked_from_id,omitempty"`
+	ForkedAt           int64           `json:"forked_at,omitempty"`
+	PromptQueue        []string        `json:"prompt_queue,omitempty"`
+	AutoRestartMax     int             `json:"auto_restart_max,omitempty"`
+	ProjectEnv         string          `json:"project_env,omitempty"`
 }
 
 // MigrateFromJSON reads a sessions.json file and inserts all data into the StateDB.
@@ -158,6 +169,8 @@ func MigrateFromJSON(jsonPath string, db *StateDB) (int, int, error) {
 			Expanded:    g.Expanded,
 			Order:       g.Order,
 			DefaultPath: g.DefaultPath,
+			Muted:       g.Muted,
+			SortMode:    g.SortMode,
 		})
 	}
 
@@ -180,17 +193,33 @@ func MarshalToolData(
 	codexSessionID string, codexDetectedAt time.Time,
 	latestPrompt string, loadedMCPNames []string,
 	toolOptionsJSON json.RawMessage,
+	pollInterval, activityCooldown time.Duration,
+	notifyMuted bool, detectedModel string,
+	forkedFromID string, forkedAt time.Time,
+	promptQueue []string, autoRestartMax int,
+	projectEnv string,
 ) json.RawMessage {
 	td := toolDataBlob{
-		ClaudeSessionID:   claudeSessionID,
-		GeminiSessionID:   geminiSessionID,
-		GeminiYoloMode:    geminiYoloMode,
-		GeminiModel:       geminiModel,
-		OpenCodeSessionID: openCodeSessionID,
-		CodexSessionID:    codexSessionID,
-		LatestPrompt:      latestPrompt,
-		LoadedMCPNames:    loadedMCPNames,
-		ToolOptions:       toolOptionsJSON,
+		ClaudeSessionID:    claudeSessionID,
+		GeminiSessionID:    geminiSessionID,
+		GeminiYoloMode:     geminiYoloMode,
+		GeminiModel:        geminiModel,
+		OpenCodeSessionID:  openCodeSessionID,
+		CodexSessionID:     codexSessionID,
+		LatestPrompt:       latestPrompt,
+		LoadedMCPNames:     loadedMCPNames,
+		ToolOptions:        toolOptionsJSON,
+		PollIntervalMs:     pollInterval.Milliseconds(),
+		ActivityCooldownMs: activityCooldown.Milliseconds(),
+		NotifyMuted:        notifyMuted,
+		DetectedModel:      detectedModel,
+		ForkedFromID:       forkedFromID,
+		PromptQueue:        promptQueue,
+		AutoRestartMax:     autoRestartMax,
+		ProjectEnv:         projectEnv,
+	}
+	if !forkedAt.IsZero() {
+		td.ForkedAt = forkedAt.Unix()
 	}
 	if !claudeDetectedAt.IsZero() {
 		td.ClaudeDetectedAt = claudeDetectedAt.Unix()
@@ -218,6 +247,11 @@ func UnmarshalToolData(data json.RawMessage) (
 	codexSessionID string, codexDetectedAt time.Time,
 	latestPrompt string, loadedMCPNames []string,
 	toolOptionsJSON json.RawMessage,
+	pollInterval, activityCooldown time.Duration,
+	notifyMuted bool, detectedModel string,
+	forkedFromID string, forkedAt time.Time,
+	promptQueue []string, autoRestartMax int,
+	projectEnv string,
 ) {
 	if len(data) == 0 {
 		return
@@ -247,5 +281,16 @@ func UnmarshalToolData(data json.RawMessage) (
 	latestPrompt = td.LatestPrompt
 	loadedMCPNames = td.LoadedMCPNames
 	toolOptionsJSON = td.ToolOptions
+	pollInterval = time.Duration(td.PollIntervalMs) * time.Millisecond
+	activityCooldown = time.Duration(td.ActivityCooldownMs) * time.Millisecond
+	notifyMuted = td.NotifyMuted
+	detectedModel = td.DetectedModel
+	forkedFromID = td.ForkedFromID
+	promptQueue = td.PromptQueue
+	autoRestartMax = td.AutoRestartMax
+	projectEnv = td.ProjectEnv
+	if td.ForkedAt > 0 {
+		forkedAt = time.Unix(td.ForkedAt, 0)
+	}
 	return
 }
@@ -3,9 +3,11 @@ package statedb
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -15,7 +17,76 @@ import (
 
 // SchemaVersion tracks the current database schema version.
 // Bump this when adding migrations.
-const SchemaVersion = 1
+const SchemaVersion = 12
+
+// ErrSchemaTooNew is returned by Migrate when the database was written by a
+// newer agent-deck. Opening it anyway could drop columns or tool_data fields
+// this version doesn't know about.
+var ErrSchemaTooNew = errors.New("statedb: database schema is newer than this version supports")
+
+// migration upgrades the schema from version-1 to version.
+type migration struct {
+	version int
+	name    string
+	apply   func(tx *sql.Tx) error
+}
+
+// toolDataField is the apply of a migration that only adds a tool_data field:
+// the bump alone makes older binaries refuse the database.
+func toolDataField(*sql.Tx) error { return nil }
+
+// migrations lists schema upgrades in order. The CREATE TABLE statements in
+// Migrate describe version 1; every later change is an entry here:
+//
+//	{version: 5, name: "add instances.color", apply: func(tx *sql.Tx) error {
+//		_, err := tx.Exec(`ALTER TABLE instances ADD COLUMN color TEXT NOT NULL DEFAULT ''`)
+//		return err
+//	}},
+//
+// Append the migration and bump SchemaVersion to its version. New fields in
+// the tool_data JSON blob need a version bump too (apply: toolDataField), so
+// older binaries refuse the database instead of re-saving it without them.
+var migrations = []migration{
+	{version: 2, name: "add archived_instances", apply: func(tx *sql.Tx) error {
+		_, err := tx.Exec(`
+			CREATE TABLE IF NOT EXISTS archived_instances (
+				id           TEXT PRIMARY KEY,
+				title        TEXT NOT NULL,
+				project_path TEXT NOT NULL,
+				group_path   TEXT NOT NULL DEFAULT '',
+				tool         TEXT NOT NULL DEFAULT 'shell',
+				archived_at  INTEGER NOT NULL,
+				data         TEXT NOT NULL DEFAULT '{}',
+				transcript   TEXT NOT NULL DEFAULT ''
+			)
+		`)
+		return err
+	}},
+	{version: 3, name: "add instances.notes", apply: func(tx *sql.Tx) error {
+		_, err := tx.Exec(`ALTER TABLE instances ADD COLUMN notes TEXT NOT NULL DEFAULT ''`)
+		return err
+	}},
+	{version: 4, name: "add instances.tags", apply: func(tx *sql.Tx) error {
+		_, err := tx.Exec(`ALTER TABLE instances ADD COLUMN tags TEXT NOT NULL DEFAULT ''`)
+		return err
+	}},
+	// Also covers the poll_interval_ms and activity_cooldown_ms tool_data
+	// fields, which were added without a bump of their own
+	{version: 5, name: "add groups.muted", apply: func(tx *sql.Tx) error {
+		_, err := tx.Exec(`ALTER TABLE groups ADD COLUMN muted INTEGER NOT NULL DEFAULT 0`)
+		return err
+	}},
+	{version: 6, name: "add groups.sort_mode", apply: func(tx *sql.Tx) error {
+		_, err := tx.Exec(`ALTER TABLE groups ADD COLUMN sort_mode TEXT NOT NULL DEFAULT ''`)
+		return err
+	}},
+	{version: 7, name: "add tool_data.notify_muted", apply: toolDataField},
+	{version: 8, name: "add tool_data.detected_model", apply: toolDataField},
+	{version: 9, name: "add tool_data.forked_from_id and forked_at", apply: toolDataField},
+	{version: 10, name: "add tool_data.prompt_queue", apply: toolDataField},
+	{version: 11, name: "add tool_data.auto_restart_max", apply: toolDataField},
+	{version: 12, name: "add tool_data.project_env", apply: toolDataField},
+}
 
 // StateDB wraps a SQLite database for session/group persistence.
 // Thread-safe for concurrent use from multiple goroutines within one process.
@@ -44,6 +115,8 @@ type InstanceRow struct {
 	WorktreeRepo    string
 	WorktreeBranch  string
 	ToolData        json.RawMessage // JSON blob for tool-specific data
+	Notes           string          // Free-form user notes
+	Tags            []string        // User labels; stored comma-separated, so tags must not contain commas
 }
 
 // GroupRow represents a group row in the database.
@@ -53,6 +126,21 @@ type GroupRow struct {
 	Expanded    bool
 	Order       int
 	DefaultPath string
+	Muted       bool   // Notifications off for the group's sessions
+	SortMode    string // How the group orders its sessions; "" is manual
+}
+
+// ArchivedRow represents an archived (deleted but restorable) session.
+// Data holds the session's JSON-encoded fields; Transcript its final output.
+type ArchivedRow struct {
+	ID          string
+	Title       string
+	ProjectPath string
+	GroupPath   string
+	Tool        string
+	ArchivedAt  time.Time
+	Data        json.RawMessage
+	Transcript  string
 }
 
 // StatusRow holds status + acknowledgment for a session.
@@ -89,7 +177,15 @@ func Open(dbPath string) (*StateDB, error) {
 		return nil, fmt.Errorf("statedb: mkdir: %w", err)
 	}
 
-	db, err := sql.Open("sqlite", dbPath)
+	// Connection pragmas go in the DSN so they apply to every pooled
+	// connection, not just the first one:
+	//   - busy_timeout: wait up to 5s if another process holds a lock
+	//   - foreign_keys: for future use
+	//   - _txlock=immediate: transactions take the write lock at BEGIN, so a
+	//     read-then-write transaction can't fail with SQLITE_BUSY halfway
+	//     through when another process (TUI vs CLI) commits in between
+	dsn := dbPath + "?_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)&_txlock=immediate"
+	db, err := sql.Open("sqlite", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("statedb: open: %w", err)
 	}
@@ -100,19 +196,16 @@ func Open(dbPath string) (*StateDB, error) {
 		return nil, fmt.Errorf("statedb: wal mode: %w", err)
 	}
 
-	// Busy timeout: wait up to 5s if another process holds a lock
-	if _, err := db.Exec("PRAGMA busy_timeout=5000"); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("statedb: busy timeout: %w", err)
-	}
+	return &StateDB{db: db, pid: os.Getpid()}, nil
+}
 
-	// Foreign keys (for future use)
-	if _, err := db.Exec("PRAGMA foreign_keys=ON"); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("statedb: foreign keys: %w", err)
+// BackupTo writes a consistent snapshot of the database to path, which must
+// not exist. Safe to call while other processes are reading and writing.
+func (s *StateDB) BackupTo(path string) error {
+	if _, err := s.db.Exec("VACUUM INTO ?", path); err != nil {
+		return fmt.Errorf("statedb: backup: %w", err)
 	}
-
-	return &StateDB{db: db, pid: os.Getpid()}, nil
+	return nil
 }
 
 // Close checkpoints WAL and closes the database.
@@ -128,7 +221,12 @@ func (s *StateDB) DB() *sql.DB {
 }
 
 // Migrate creates tables if they don't exist and runs any pending migrations.
+// Returns ErrSchemaTooNew if the database was written by a newer version.
 func (s *StateDB) Migrate() error {
+	return s.migrateTo(SchemaVersion, migrations)
+}
+
+func (s *StateDB) migrateTo(target int, steps []migration) error {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("statedb: begin migrate: %w", err)
@@ -196,16 +294,52 @@ func (s *StateDB) Migrate() error {
 		return fmt.Errorf("statedb: create heartbeats: %w", err)
 	}
 
+	// A database without a version was just created (or predates versioning)
+	// and has the version 1 tables above.
+	current := 1
+	var stored string
+	err = tx.QueryRow("SELECT value FROM metadata WHERE key = 'schema_version'").Scan(&stored)
+	switch {
+	case err == nil:
+		if current, err = strconv.Atoi(stored); err != nil {
+			return fmt.Errorf("statedb: invalid schema version %q", stored)
+		}
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("statedb: read schema version: %w", err)
+	}
+
+	if current > target {
+		return fmt.Errorf("%w (database: v%d, supported: v%d); upgrade agent-deck", ErrSchemaTooNew, current, target)
+	}
+
+	for _, m := range steps {
+		if m.version <= current || m.version > target {
+			continue
+		}
+		if err := m.apply(tx); err != nil {
+			return fmt.Errorf("statedb: migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+
 	// Set schema version
 	if _, err := tx.Exec(`
 		INSERT OR REPLACE INTO metadata (key, value) VALUES ('schema_version', ?)
-	`, fmt.Sprintf("%d", SchemaVersion)); err != nil {
+	`, fmt.Sprintf("%d", target)); err != nil {
 		return fmt.Errorf("statedb: set schema version: %w", err)
 	}
 
 	return tx.Commit()
 }
 
+// StoredSchemaVersion returns the schema version recorded in the database.
+func (s *StateDB) StoredSchemaVersion() (int, error) {
+	var stored string
+	if err := s.db.QueryRow("SELECT value FROM metadata WHERE key = 'schema_version'").Scan(&stored); err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(stored)
+}
+
 // IsEmpty returns true if the instances table has no rows.
 func (s *StateDB) IsEmpty() (bool, error) {
 	var count int
@@ -231,14 +365,14 @@ func (s *StateDB) SaveInstance(inst *InstanceRow) error {
 			command, wrapper, tool, status, tmux_session,
 			created_at, last_accessed,
 			parent_session_id, worktree_path, worktree_repo, worktree_branch,
-			tool_data
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			tool_data, notes, tags
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		inst.ID, inst.Title, inst.ProjectPath, inst.GroupPath, inst.Order,
 		inst.Command, inst.Wrapper, inst.Tool, inst.Status, inst.TmuxSession,
 		inst.CreatedAt.Unix(), inst.LastAccessed.Unix(),
 		inst.ParentSessionID, inst.WorktreePath, inst.WorktreeRepo, inst.WorktreeBranch,
-		string(toolData),
+		string(toolData), inst.Notes, strings.Join(inst.Tags, ","),
 	)
 	return err
 }
@@ -253,6 +387,31 @@ func (s *StateDB) SaveInstances(insts []*InstanceRow) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := replaceInstancesTx(tx, insts); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReplaceState replaces all instances and groups in a single transaction.
+// Used to restore a backup.
+func (s *StateDB) ReplaceState(insts []*InstanceRow, groups []*GroupRow) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if err := replaceInstancesTx(tx, insts); err != nil {
+		return err
+	}
+	if err := replaceGroupsTx(tx, groups); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replaceInstancesTx(tx *sql.Tx, insts []*InstanceRow) error {
 	// Delete rows not in the new list to prevent deleted sessions from reappearing.
 	if len(insts) == 0 {
 		if _, err := tx.Exec("DELETE FROM instances"); err != nil {
@@ -277,8 +436,8 @@ func (s *StateDB) SaveInstances(insts []*InstanceRow) error {
 			command, wrapper, tool, status, tmux_session,
 			created_at, last_accessed,
 			parent_session_id, worktree_path, worktree_repo, worktree_branch,
-			tool_data
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			tool_data, notes, tags
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -295,13 +454,98 @@ func (s *StateDB) SaveInstances(insts []*InstanceRow) error {
 			inst.Command, inst.Wrapper, inst.Tool, inst.Status, inst.TmuxSession,
 			inst.CreatedAt.Unix(), inst.LastAccessed.Unix(),
 			inst.ParentSessionID, inst.WorktreePath, inst.WorktreeRepo, inst.WorktreeBranch,
-			string(toolData),
+			string(toolData), inst.Notes, strings.Join(inst.Tags, ","),
 		); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
-	return tx.Commit()
+// SyncInstances saves instances without clobbering changes made by other
+// processes since the caller last read or wrote the table. known holds the
+// IDs the caller has seen in the database:
+//   - rows in known but missing from insts were removed by the caller and are deleted
+//   - rows not in known were added elsewhere and are left alone
+//   - instances in known whose row is gone were deleted elsewhere and are not re-created
+//
+// Returns the IDs that are in the database for the caller after the save.
+func (s *StateDB) SyncInstances(insts []*InstanceRow, known map[string]bool) ([]string, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	existing := make(map[string]bool)
+	rows, err := tx.Query("SELECT id FROM instances")
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		existing[id] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	keep := make(map[string]bool, len(insts))
+	for _, inst := range insts {
+		keep[inst.ID] = true
+	}
+	for id := range existing {
+		if known[id] && !keep[id] {
+			if _, err := tx.Exec("DELETE FROM instances WHERE id = ?", id); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT OR REPLACE INTO instances (
+			id, title, project_path, group_path, sort_order,
+			command, wrapper, tool, status, tmux_session,
+			created_at, last_accessed,
+			parent_session_id, worktree_path, worktree_repo, worktree_branch,
+			tool_data, notes, tags
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer stmt.Close()
+
+	saved := make([]string, 0, len(insts))
+	for _, inst := range insts {
+		if known[inst.ID] && !existing[inst.ID] {
+			continue // deleted by another process; don't resurrect
+		}
+		toolData := inst.ToolData
+		if len(toolData) == 0 {
+			toolData = json.RawMessage("{}")
+		}
+		if _, err := stmt.Exec(
+			inst.ID, inst.Title, inst.ProjectPath, inst.GroupPath, inst.Order,
+			inst.Command, inst.Wrapper, inst.Tool, inst.Status, inst.TmuxSession,
+			inst.CreatedAt.Unix(), inst.LastAccessed.Unix(),
+			inst.ParentSessionID, inst.WorktreePath, inst.WorktreeRepo, inst.WorktreeBranch,
+			string(toolData), inst.Notes, strings.Join(inst.Tags, ","),
+		); err != nil {
+			return nil, err
+		}
+		saved = append(saved, inst.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return saved, nil
 }
 
 // LoadInstances returns all instances ordered by sort_order.
@@ -311,7 +555,7 @@ func (s *StateDB) LoadInstances() ([]*InstanceRow, error) {
 			command, wrapper, tool, status, tmux_session,
 			created_at, last_accessed,
 			parent_session_id, worktree_path, worktree_repo, worktree_branch,
-			tool_data
+			tool_data, notes, tags
 		FROM instances ORDER BY sort_order
 	`)
 	if err != nil {
@@ -323,13 +567,13 @@ func (s *StateDB) LoadInstances() ([]*InstanceRow, error) {
 	for rows.Next() {
 		r := &InstanceRow{}
 		var createdUnix, accessedUnix int64
-		var toolDataStr string
+		var toolDataStr, tagsStr string
 		if err := rows.Scan(
 			&r.ID, &r.Title, &r.ProjectPath, &r.GroupPath, &r.Order,
 			&r.Command, &r.Wrapper, &r.Tool, &r.Status, &r.TmuxSession,
 			&createdUnix, &accessedUnix,
 			&r.ParentSessionID, &r.WorktreePath, &r.WorktreeRepo, &r.WorktreeBranch,
-			&toolDataStr,
+			&toolDataStr, &r.Notes, &tagsStr,
 		); err != nil {
 			return nil, err
 		}
@@ -338,6 +582,9 @@ func (s *StateDB) LoadInstances() ([]*InstanceRow, error) {
 			r.LastAccessed = time.Unix(accessedUnix, 0)
 		}
 		r.ToolData = json.RawMessage(toolDataStr)
+		if tagsStr != "" {
+			r.Tags = strings.Split(tagsStr, ",")
+		}
 		result = append(result, r)
 	}
 	return result, rows.Err()
@@ -357,6 +604,76 @@ func (s *StateDB) UpdateInstanceField(id, field string, value any) error {
 	return err
 }
 
+// --- Archive ---
+
+// SaveArchived inserts or replaces an archived session.
+func (s *StateDB) SaveArchived(a *ArchivedRow) error {
+	data := a.Data
+	if len(data) == 0 {
+		data = json.RawMessage("{}")
+	}
+	_, err := s.db.Exec(`
+		INSERT OR REPLACE INTO archived_instances (
+			id, title, project_path, group_path, tool, archived_at, data, transcript
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		a.ID, a.Title, a.ProjectPath, a.GroupPath, a.Tool,
+		a.ArchivedAt.Unix(), string(data), a.Transcript,
+	)
+	return err
+}
+
+// LoadArchived returns all archived sessions, most recently archived first.
+// Transcripts are not loaded; use GetArchived for a single full entry.
+func (s *StateDB) LoadArchived() ([]*ArchivedRow, error) {
+	rows, err := s.db.Query(`
+		SELECT id, title, project_path, group_path, tool, archived_at, data
+		FROM archived_instances ORDER BY archived_at DESC, id
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*ArchivedRow
+	for rows.Next() {
+		a := &ArchivedRow{}
+		var archivedUnix int64
+		var dataStr string
+		if err := rows.Scan(&a.ID, &a.Title, &a.ProjectPath, &a.GroupPath, &a.Tool, &archivedUnix, &dataStr); err != nil {
+			return nil, err
+		}
+		a.ArchivedAt = time.Unix(archivedUnix, 0)
+		a.Data = json.RawMessage(dataStr)
+		result = append(result, a)
+	}
+	return result, rows.Err()
+}
+
+// GetArchived returns one archived session including its transcript.
+// Returns sql.ErrNoRows if there is no such entry.
+func (s *StateDB) GetArchived(id string) (*ArchivedRow, error) {
+	a := &ArchivedRow{}
+	var archivedUnix int64
+	var dataStr string
+	err := s.db.QueryRow(`
+		SELECT id, title, project_path, group_path, tool, archived_at, data, transcript
+		FROM archived_instances WHERE id = ?
+	`, id).Scan(&a.ID, &a.Title, &a.ProjectPath, &a.GroupPath, &a.Tool, &archivedUnix, &dataStr, &a.Transcript)
+	if err != nil {
+		return nil, err
+	}
+	a.ArchivedAt = time.Unix(archivedUnix, 0)
+	a.Data = json.RawMessage(dataStr)
+	return a, nil
+}
+
+// DeleteArchived permanently removes an archived session.
+func (s *StateDB) DeleteArchived(id string) error {
+	_, err := s.db.Exec("DELETE FROM archived_instances WHERE id = ?", id)
+	return err
+}
+
 // --- Group CRUD ---
 
 // SaveGroups replaces all groups in a single transaction.
@@ -367,14 +684,21 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 	}
 	defer func() { _ = tx.Rollback() }()
 
+	if err := replaceGroupsTx(tx, groups); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func replaceGroupsTx(tx *sql.Tx, groups []*GroupRow) error {
 	// Clear existing groups and re-insert (simpler than diff)
 	if _, err := tx.Exec("DELETE FROM groups"); err != nil {
 		return err
 	}
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO groups (path, name, expanded, sort_order, default_path)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO groups (path, name, expanded, sort_order, default_path, muted, sort_mode)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -382,22 +706,24 @@ func (s *StateDB) SaveGroups(groups []*GroupRow) error {
 	defer stmt.Close()
 
 	for _, g := range groups {
-		expanded := 0
+		expanded, muted := 0, 0
 		if g.Expanded {
 			expanded = 1
 		}
-		if _, err := stmt.Exec(g.Path, g.Name, expanded, g.Order, g.DefaultPath); err != nil {
+		if g.Muted {
+			muted = 1
+		}
+		if _, err := stmt.Exec(g.Path, g.Name, expanded, g.Order, g.DefaultPath, muted, g.SortMode); err != nil {
 			return err
 		}
 	}
-
-	return tx.Commit()
+	return nil
 }
 
 // LoadGroups returns all groups ordered by sort_order.
 func (s *StateDB) LoadGroups() ([]*GroupRow, error) {
 	rows, err := s.db.Query(`
-		SELECT path, name, expanded, sort_order, default_path
+		SELECT path, name, expanded, sort_order, default_path, muted, sort_mode
 		FROM groups ORDER BY sort_order
 	`)
 	if err != nil {
@@ -408,11 +734,12 @@ func (s *StateDB) LoadGroups() ([]*GroupRow, error) {
 	var result []*GroupRow
 	for rows.Next() {
 		g := &GroupRow{}
-		var expanded int
-		if err := rows.Scan(&g.Path, &g.Name, &expanded, &g.Order, &g.DefaultPath); err != nil {
+		var expanded, muted int
+		if err := rows.Scan(&g.Path, &g.Name, &expanded, &g.Order, &g.DefaultPath, &muted, &g.SortMode); err != nil {
 			return nil, err
 		}
 		g.Expanded = expanded != 0
+		g.Muted = muted != 0
 		result = append(result, g)
 	}
 	return result, rows.Err()
@@ -1,8 +1,12 @@
 package statedb
 
 import (
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"path/filepath"
+	"slices"
 	"sync"
 	"testing"
 	"time"
@@ -74,7 +78,7 @@ func TestSaveLoadInstances(t *testing.T) {
 
 	now := time.Now()
 	instances := []*InstanceRow{
-		{ID: "a", Title: "Alpha", ProjectPath: "/a", GroupPath: "grp", Order: 0, Tool: "claude", Status: "idle", CreatedAt: now, ToolData: json.RawMessage(`{"claude_session_id":"abc"}`)},
+		{ID: "a", Title: "Alpha", ProjectPath: "/a", GroupPath: "grp", Order: 0, Tool: "claude", Status: "idle", CreatedAt: now, Notes: "waiting on review", Tags: []string{"bug", "client-x"}, ToolData: json.RawMessage(`{"claude_session_id":"abc"}`)},
 		{ID: "b", Title: "Beta", ProjectPath: "/b", GroupPath: "grp", Order: 1, Tool: "gemini", Status: "running", CreatedAt: now, ToolData: json.RawMessage("{}")},
 	}
 
@@ -100,6 +104,12 @@ func TestSaveLoadInstances(t *testing.T) {
 	if string(loaded[0].ToolData) != `{"claude_session_id":"abc"}` {
 		t.Errorf("ToolData mismatch: %s", loaded[0].ToolData)
 	}
+	if loaded[0].Notes != "waiting on review" || loaded[1].Notes != "" {
+		t.Errorf("Notes mismatch: %q, %q", loaded[0].Notes, loaded[1].Notes)
+	}
+	if !slices.Equal(loaded[0].Tags, []string{"bug", "client-x"}) || loaded[1].Tags != nil {
+		t.Errorf("Tags mismatch: %v, %v", loaded[0].Tags, loaded[1].Tags)
+	}
 }
 
 func TestSaveLoadGroups(t *testing.T) {
@@ -107,7 +117,7 @@ func TestSaveLoadGroups(t *testing.T) {
 
 	groups := []*GroupRow{
 		{Path: "projects", Name: "Projects", Expanded: true, Order: 0},
-		{Path: "personal", Name: "Personal", Expanded: false, Order: 1, DefaultPath: "/home"},
+		{Path: "personal", Name: "Personal", Expanded: false, Order: 1, DefaultPath: "/home", Muted: true, SortMode: "activity"},
 	}
 
 	if err := db.SaveGroups(groups); err != nil {
@@ -127,6 +137,52 @@ func TestSaveLoadGroups(t *testing.T) {
 	if loaded[1].DefaultPath != "/home" {
 		t.Errorf("DefaultPath: %q", loaded[1].DefaultPath)
 	}
+	if loaded[0].Muted || !loaded[1].Muted {
+		t.Errorf("Muted mismatch: %v, %v", loaded[0].Muted, loaded[1].Muted)
+	}
+	if loaded[0].SortMode != "" || loaded[1].SortMode != "activity" {
+		t.Errorf("SortMode mismatch: %q, %q", loaded[0].SortMode, loaded[1].SortMode)
+	}
+}
+
+func TestMigrate_SchemaVersion(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "state.db")
+	db, err := Open(dbPath)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer db.Close()
+	if err := db.Migrate(); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	if v, err := db.StoredSchemaVersion(); err != nil || v != SchemaVersion {
+		t.Fatalf("StoredSchemaVersion = %d, %v; want %d", v, err, SchemaVersion)
+	}
+
+	// Upgrade: pending migrations run once, in order
+	var applied []int
+	step := func(v int) migration {
+		return migration{version: v, name: fmt.Sprintf("step %d", v), apply: func(tx *sql.Tx) error {
+			applied = append(applied, v)
+			_, err := tx.Exec(fmt.Sprintf("ALTER TABLE instances ADD COLUMN extra%d TEXT NOT NULL DEFAULT ''", v))
+			return err
+		}}
+	}
+	steps := []migration{step(SchemaVersion + 1), step(SchemaVersion + 2)}
+	if err := db.migrateTo(SchemaVersion+2, steps); err != nil {
+		t.Fatalf("migrateTo: %v", err)
+	}
+	if err := db.migrateTo(SchemaVersion+2, steps); err != nil {
+		t.Fatalf("migrateTo (again): %v", err)
+	}
+	if len(applied) != 2 || applied[0] != SchemaVersion+1 || applied[1] != SchemaVersion+2 {
+		t.Errorf("applied = %v, want each migration once in order", applied)
+	}
+
+	// Downgrade: an older binary must refuse the newer database
+	if err := db.Migrate(); !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("Migrate on newer schema: err = %v, want ErrSchemaTooNew", err)
+	}
 }
 
 func TestDeleteInstance(t *testing.T) {
@@ -149,6 +205,89 @@ func TestDeleteInstance(t *testing.T) {
 	}
 }
 
+func TestArchivedInstances(t *testing.T) {
+	db := newTestDB(t)
+
+	older := &ArchivedRow{
+		ID: "old", Title: "Old", ProjectPath: "/tmp/old", GroupPath: "grp", Tool: "shell",
+		ArchivedAt: time.Now().Add(-time.Hour), Data: json.RawMessage(`{"id":"old"}`), Transcript: "bye",
+	}
+	newer := &ArchivedRow{
+		ID: "new", Title: "New", ProjectPath: "/tmp/new", Tool: "claude", ArchivedAt: time.Now(),
+	}
+	for _, a := range []*ArchivedRow{older, newer} {
+		if err := db.SaveArchived(a); err != nil {
+			t.Fatalf("SaveArchived: %v", err)
+		}
+	}
+
+	rows, err := db.LoadArchived()
+	if err != nil {
+		t.Fatalf("LoadArchived: %v", err)
+	}
+	if len(rows) != 2 || rows[0].ID != "new" || rows[1].ID != "old" {
+		t.Fatalf("LoadArchived should list newest first, got %+v", rows)
+	}
+	if rows[1].Transcript != "" {
+		t.Error("LoadArchived should not load transcripts")
+	}
+
+	got, err := db.GetArchived("old")
+	if err != nil {
+		t.Fatalf("GetArchived: %v", err)
+	}
+	if got.Transcript != "bye" || string(got.Data) != `{"id":"old"}` || got.GroupPath != "grp" {
+		t.Errorf("GetArchived = %+v", got)
+	}
+
+	if err := db.DeleteArchived("old"); err != nil {
+		t.Fatalf("DeleteArchived: %v", err)
+	}
+	if _, err := db.GetArchived("old"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("GetArchived after delete: err = %v, want sql.ErrNoRows", err)
+	}
+}
+
+func TestSyncInstances(t *testing.T) {
+	db := newTestDB(t)
+	row := func(id string) *InstanceRow {
+		return &InstanceRow{
+			ID: id, Title: id, ProjectPath: "/tmp", GroupPath: "grp",
+			Tool: "shell", Status: "idle", CreatedAt: time.Now(),
+		}
+	}
+
+	// Caller loaded a, b, c. Since then another process added "other" and
+	// deleted "c"; the caller removed "b" and created "d".
+	if err := db.SaveInstances([]*InstanceRow{row("a"), row("b"), row("other")}); err != nil {
+		t.Fatalf("SaveInstances: %v", err)
+	}
+	known := map[string]bool{"a": true, "b": true, "c": true}
+
+	saved, err := db.SyncInstances([]*InstanceRow{row("a"), row("c"), row("d")}, known)
+	if err != nil {
+		t.Fatalf("SyncInstances: %v", err)
+	}
+	if len(saved) != 2 || saved[0] != "a" || saved[1] != "d" {
+		t.Errorf("saved = %v, want [a d]", saved)
+	}
+
+	rows, _ := db.LoadInstances()
+	got := make(map[string]bool)
+	for _, r := range rows {
+		got[r.ID] = true
+	}
+	want := map[string]bool{"a": true, "d": true, "other": true}
+	if len(got) != len(want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	for id := range want {
+		if !got[id] {
+			t.Errorf("missing row %q (rows = %v)", id, got)
+		}
+	}
+}
+
 func TestStatusReadWrite(t *testing.T) {
 	db := newTestDB(t)
 
@@ -0,0 +1,170 @@
+// Package termesc parses the terminal escape sequences found in captured
+// pane output, so callers can drop them or keep just the colors.
+package termesc
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Kind classifies an escape sequence.
+type Kind int
+
+const (
+	KindNone   Kind = iota // Not an escape sequence
+	KindSGR                // CSI ... m: colors and text attributes
+	KindCSI                // Other control sequences: cursor movement, erasing, modes
+	KindOSC                // Operating system commands: window titles, hyperlinks, clipboard
+	KindString             // DCS, SOS, PM and APC strings
+	KindEsc                // Short escapes: charset selection, cursor save/restore, keypad modes
+)
+
+const esc = '\x1b'
+
+// c1Introducers maps the 8-bit C1 introducers to the byte that follows ESC
+// in their 7-bit form.
+var c1Introducers = map[rune]byte{
+	'\u009b': '[',
+	'\u009d': ']',
+	'\u0090': 'P',
+	'\u0098': 'X',
+	'\u009e': '^',
+	'\u009f': '_',
+}
+
+// Next reports the escape sequence at the start of s: its kind and length
+// in bytes, or KindNone and 0 when s doesn't start with one. A sequence cut
+// off by the end of s runs to the end; one broken by an unexpected byte
+// ends before that byte.
+func Next(s string) (Kind, int) {
+	intro, n := introducer(s)
+	switch intro {
+	case 0:
+		return KindNone, 0
+	case '[':
+		return csi(s, n)
+	case ']':
+		return KindOSC, stringEnd(s, n)
+	case 'P', 'X', '^', '_':
+		return KindString, stringEnd(s, n)
+	default:
+		return KindEsc, shortEscEnd(s)
+	}
+}
+
+// introducer returns the 7-bit introducer byte of the sequence at the start
+// of s ('[' for CSI, ']' for OSC, ...) and the length of the introducer. For
+// short escapes it returns ESC itself.
+func introducer(s string) (byte, int) {
+	if s == "" {
+		return 0, 0
+	}
+	if s[0] == esc {
+		if len(s) > 1 && strings.IndexByte("[]PX^_", s[1]) >= 0 {
+			return s[1], 2
+		}
+		return esc, 1
+	}
+	if s[0] < 0x80 {
+		return 0, 0
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError && size == 1 {
+		// A raw 8-bit CSI, outside any UTF-8 character
+		if s[0] == 0x9b {
+			return '[', 1
+		}
+		return 0, 0
+	}
+	if b, ok := c1Introducers[r]; ok {
+		return b, size
+	}
+	return 0, 0
+}
+
+// csi measures a control sequence whose parameters start at s[i]: parameter
+// bytes, then intermediate bytes, then one final byte.
+func csi(s string, i int) (Kind, int) {
+	start := i
+	for i < len(s) && s[i] >= 0x30 && s[i] <= 0x3f {
+		i++
+	}
+	params := s[start:i]
+	intermediates := i
+	for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+		i++
+	}
+	if i >= len(s) || s[i] < 0x40 || s[i] > 0x7e {
+		return KindCSI, i
+	}
+	if s[i] == 'm' && i == intermediates && strings.Trim(params, "0123456789;:") == "" {
+		return KindSGR, i + 1
+	}
+	return KindCSI, i + 1
+}
+
+// stringEnd returns where the string that starts at s[i] ends: past its BEL
+// or string terminator (ESC \ or the 8-bit ST), or at the end of s.
+func stringEnd(s string, i int) int {
+	for ; i < len(s); i++ {
+		switch {
+		case s[i] == '\a':
+			return i + 1
+		case s[i] == esc && i+1 < len(s) && s[i+1] == '\\':
+			return i + 2
+		case strings.HasPrefix(s[i:], "\u009c"):
+			return i + len("\u009c")
+		}
+	}
+	return len(s)
+}
+
+// shortEscEnd measures an escape that isn't CSI or a string: ESC, any
+// intermediate bytes, then one final byte.
+func shortEscEnd(s string) int {
+	i := 1
+	for i < len(s) && s[i] >= 0x20 && s[i] <= 0x2f {
+		i++
+	}
+	if i < len(s) && s[i] >= 0x30 && s[i] <= 0x7e {
+		i++
+	}
+	return i
+}
+
+// Strip removes every escape sequence from s.
+func Strip(s string) string {
+	return filter(s, false)
+}
+
+// KeepSGR removes every escape sequence from s except SGR, so colors and
+// text attributes survive but titles, hyperlinks and cursor movement don't.
+func KeepSGR(s string) string {
+	return filter(s, true)
+}
+
+func filter(s string, keepSGR bool) string {
+	// Fast path: no ESC, raw CSI or UTF-8 encoded C1 control
+	if strings.IndexByte(s, esc) < 0 && strings.IndexByte(s, 0x9b) < 0 && strings.IndexByte(s, 0xc2) < 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		kind, n := Next(s[i:])
+		if kind == KindNone {
+			// Copy whole characters, so a continuation byte is never read
+			// as an 8-bit CSI
+			_, size := utf8.DecodeRuneInString(s[i:])
+			b.WriteString(s[i : i+size])
+			i += size
+			continue
+		}
+		if keepSGR && kind == KindSGR {
+			b.WriteString(s[i : i+n])
+		}
+		i += n
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package termesc
+
+import "testing"
+
+func TestNext(t *testing.T) {
+	tests := []struct {
+		in   string
+		kind Kind
+		n    int
+	}{
+		{"plain", KindNone, 0},
+		{"\x1b[1;38;5;140mx", KindSGR, 13},
+		{"\x1b[mx", KindSGR, 3},
+		{"\x1b[?25lx", KindCSI, 6},
+		{"\x1b[>4;1mx", KindCSI, 7}, // modifyOtherKeys, not a color
+		{"\x1b[2 qx", KindCSI, 5},   // Cursor shape has an intermediate byte
+		{"\x1b[12\nx", KindCSI, 4},  // Broken by a newline
+		{"\x1b]0;title\x07x", KindOSC, 10},
+		{"\x1b]8;;https://example.com\x1b\\x", KindOSC, 26},
+		{"\u009d0;title\u009cx", KindOSC, 11},
+		{"\x1bPq#0\x1b\\x", KindString, 7},
+		{"\x1b(Bx", KindEsc, 3},
+		{"\x1b7x", KindEsc, 2},
+		{"\x1b", KindEsc, 1},
+		{"\x9bmx", KindSGR, 2},
+		{"\u009b31mx", KindSGR, 5},
+		{"Ûx", KindNone, 0},
+	}
+	for _, tt := range tests {
+		kind, n := Next(tt.in)
+		if kind != tt.kind || n != tt.n {
+			t.Errorf("Next(%q) = %d, %d; want %d, %d", tt.in, kind, n, tt.kind, tt.n)
+		}
+	}
+}
+
+func TestKeepSGR(t *testing.T) {
+	in := "\x1b]2;vim\x07\x1b[H\x1b[2J\x1b[32mok\x1b[0m \x1b]8;;file:///x\x1b\\link\x1b]8;;\x1b\\ Û"
+	if got, want := KeepSGR(in), "\x1b[32mok\x1b[0m link Û"; got != want {
+		t.Errorf("KeepSGR = %q, want %q", got, want)
+	}
+	if got, want := Strip(in), "ok link Û"; got != want {
+		t.Errorf("Strip = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@ package tmux
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -16,6 +17,10 @@ import (
 
 var pipeLog = logging.ForComponent("pipe")
 
+// errCommandFailed wraps errors tmux itself reported for a piped command, as
+// opposed to the pipe failing to deliver it.
+var errCommandFailed = errors.New("tmux error")
+
 // ControlPipe wraps a persistent `tmux -C attach-session -t <name>` process.
 // It provides event-driven output detection via %output events and
 // zero-subprocess command execution through the stdin/stdout pipe.
@@ -41,6 +46,8 @@ type ControlPipe struct {
 	mu         sync.RWMutex
 	alive      bool
 	lastOutput time.Time
+	alert      string // latest value of the session's alertOption
+	subscribed bool   // tmux accepted the alert subscription
 
 	// Lifecycle
 	done      chan struct{}
@@ -56,7 +63,7 @@ type commandResponse struct {
 // Blocks until the initial handshake completes (or 2s timeout), so the pipe is
 // ready for SendCommand immediately after return.
 func NewControlPipe(sessionName string) (*ControlPipe, error) {
-	cmd := exec.Command("tmux", "-C", "attach-session", "-t", sessionName)
+	cmd := Command("-C", "attach-session", "-t", sessionName)
 	// Put in own process group so we can kill the entire group on shutdown
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 
@@ -107,10 +114,43 @@ func NewControlPipe(sessionName string) (*ControlPipe, error) {
 		return nil, fmt.Errorf("session %s: %w", sessionName, cp.handshakeErr)
 	}
 
+	// Have tmux report changes to the session's hook alert (tmux 3.2+; older
+	// versions reject the command and alerts are read from BatchStatus instead)
+	if _, err := cp.SendCommand(`refresh-client -B '` + alertSubscription + `::#{` + alertOption + `}'`); err != nil {
+		pipeLog.Debug("pipe_alert_subscribe_failed", slog.String("session", sessionName), slog.String("error", err.Error()))
+	} else {
+		cp.mu.Lock()
+		cp.subscribed = true
+		cp.mu.Unlock()
+	}
+
 	pipeLog.Debug("pipe_connected", slog.String("session", sessionName))
 	return cp, nil
 }
 
+// alertSubscription names the control mode subscription to alertOption.
+const alertSubscription = "agentdeck-alert"
+
+// parseAlertSubscription extracts the value from a %subscription-changed line
+// for alertSubscription:
+//
+//	%subscription-changed agentdeck-alert $1 - - - : pane-died
+func parseAlertSubscription(raw string) (string, bool) {
+	header, value, ok := strings.Cut(raw, " : ")
+	if !ok {
+		// An empty value leaves no trailing space after the colon
+		header, ok = strings.CutSuffix(raw, " :")
+		if !ok {
+			return "", false
+		}
+	}
+	fields := strings.Fields(header)
+	if len(fields) < 2 || fields[1] != alertSubscription {
+		return "", false
+	}
+	return value, true
+}
+
 // reader is the goroutine that parses tmux control mode protocol events.
 // It handles %output, %begin/%end/%error for command responses, and
 // silently skips all other %-prefixed control lines.
@@ -148,6 +188,18 @@ func (cp *ControlPipe) reader() {
 				case cp.outputEvents <- struct{}{}:
 				default:
 				}
+			} else if strings.HasPrefix(raw, "%subscription-changed ") {
+				if alert, ok := parseAlertSubscription(raw); ok {
+					cp.mu.Lock()
+					cp.alert = alert
+					cp.mu.Unlock()
+
+					// Status needs rechecking, same as for new output
+					select {
+					case cp.outputEvents <- struct{}{}:
+					default:
+					}
+				}
 			} else if strings.HasPrefix(raw, "%begin ") {
 				inCapture = true
 				lines = lines[:0]
@@ -187,7 +239,7 @@ func (cp *ControlPipe) reader() {
 					errMsg = strings.Join(parts[3:], " ")
 				}
 				select {
-				case cp.responseCh <- commandResponse{err: fmt.Errorf("tmux error: %s", errMsg)}:
+				case cp.responseCh <- commandResponse{err: fmt.Errorf("%w: %s", errCommandFailed, errMsg)}:
 				default:
 				}
 			}
@@ -251,7 +303,19 @@ func (cp *ControlPipe) SendCommand(command string) (string, error) {
 // CapturePaneVia sends capture-pane through the control mode pipe.
 // Returns the pane content without spawning any subprocess.
 func (cp *ControlPipe) CapturePaneVia() (string, error) {
-	return cp.SendCommand(fmt.Sprintf("capture-pane -t %s -p -J", cp.sessionName))
+	// Quoted: braces in the target would otherwise parse as a command block
+	return cp.SendCommand(fmt.Sprintf("capture-pane -t '%s' -p -J", agentPaneTarget(cp.sessionName)))
+}
+
+// CaptureHistoryVia is CapturePaneVia plus up to lines of scrollback.
+func (cp *ControlPipe) CaptureHistoryVia(lines int) (string, error) {
+	return cp.SendCommand(fmt.Sprintf("capture-pane -t '%s' -p -J -S -%d", agentPaneTarget(cp.sessionName), lines))
+}
+
+// CaptureStyledHistoryVia is CaptureHistoryVia with colors and attributes
+// kept as escape sequences (-e).
+func (cp *ControlPipe) CaptureStyledHistoryVia(lines int) (string, error) {
+	return cp.SendCommand(fmt.Sprintf("capture-pane -t '%s' -p -J -e -S -%d", agentPaneTarget(cp.sessionName), lines))
 }
 
 // OutputEvents returns a channel that fires when the session produces output.
@@ -267,6 +331,14 @@ func (cp *ControlPipe) LastOutputTime() time.Time {
 	return cp.lastOutput
 }
 
+// Alert returns the session's latest hook alert (see Session.Alert). ok is
+// false when tmux is too old to report alerts over the pipe.
+func (cp *ControlPipe) Alert() (alert string, ok bool) {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+	return cp.alert, cp.subscribed
+}
+
 // IsAlive returns true if the control mode process is still running.
 func (cp *ControlPipe) IsAlive() bool {
 	cp.mu.RLock()
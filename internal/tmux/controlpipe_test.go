@@ -165,6 +165,36 @@ func TestPipeManager_CapturePane(t *testing.T) {
 	assert.Contains(t, content, "pm-capture-test")
 }
 
+func TestPipeManager_CaptureHistoryAndEnvironment(t *testing.T) {
+	name := createTestSession(t, "pm-history")
+
+	_ = exec.Command("tmux", "send-keys", "-t", name, "seq 1 100", "Enter").Run()
+	_ = exec.Command("tmux", "set-environment", "-t", name, "AGENTDECK_PIPE_TEST", "42").Run()
+	require.Eventually(t, func() bool {
+		out, _ := exec.Command("tmux", "capture-pane", "-t", name, "-p").Output()
+		return strings.Contains(string(out), "\n100\n")
+	}, 5*time.Second, 100*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	pm := NewPipeManager(ctx, nil)
+	defer pm.Close()
+	require.NoError(t, pm.Connect(name))
+
+	// Scrollback beyond the visible screen comes back through the pipe
+	content, err := pm.CaptureHistory(name, 2000)
+	require.NoError(t, err)
+	assert.Contains(t, content, "\n1\n2\n3\n")
+
+	env, err := pm.GetEnvironment(name, "AGENTDECK_PIPE_TEST")
+	require.NoError(t, err)
+	assert.Equal(t, "AGENTDECK_PIPE_TEST=42", strings.TrimSpace(env))
+
+	_, err = pm.GetEnvironment(name, "AGENTDECK_PIPE_MISSING")
+	assert.ErrorIs(t, err, errCommandFailed, "unknown variables are a tmux answer, not a pipe failure")
+}
+
 func TestPipeManager_CapturePaneFallback(t *testing.T) {
 	skipIfNoTmuxServer(t)
 
@@ -224,7 +254,7 @@ func TestPipeManager_OutputCallback(t *testing.T) {
 	}
 }
 
-func TestPipeManager_RefreshAllActivities(t *testing.T) {
+func TestPipeManager_BatchStatus(t *testing.T) {
 	name := createTestSession(t, "pm-refresh")
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -234,14 +264,18 @@ func TestPipeManager_RefreshAllActivities(t *testing.T) {
 	defer pm.Close()
 
 	require.NoError(t, pm.Connect(name))
+	SetPipeManager(pm)
+	defer SetPipeManager(nil)
 
-	activities, err := pm.RefreshAllActivities()
+	panes, err := BatchStatus()
 	require.NoError(t, err)
-	assert.NotEmpty(t, activities, "should return at least one session's activity")
 
-	// Our test session should be in the results
-	_, found := activities[name]
-	assert.True(t, found, "test session %s should appear in activities", name)
+	// Our test session should be in the results, with its agent pane's details
+	info, found := panes[name]
+	require.True(t, found, "test session %s should appear in batch status", name)
+	assert.NotZero(t, info.Activity)
+	assert.NotZero(t, info.Width)
+	assert.NotEmpty(t, info.CurrentCommand)
 }
 
 func TestPipeManager_ConnectIdempotent(t *testing.T) {
@@ -288,3 +322,36 @@ func drainEvents(ch <-chan struct{}, duration time.Duration) {
 		}
 	}
 }
+
+func TestParseAlertSubscription(t *testing.T) {
+	alert, ok := parseAlertSubscription("%subscription-changed agentdeck-alert $4 - - - : pane-died")
+	assert.True(t, ok)
+	assert.Equal(t, AlertPaneDied, alert)
+
+	alert, ok = parseAlertSubscription("%subscription-changed agentdeck-alert $4 - - - :")
+	assert.True(t, ok)
+	assert.Empty(t, alert)
+
+	_, ok = parseAlertSubscription("%subscription-changed other $4 - - - : x")
+	assert.False(t, ok)
+}
+
+func TestControlPipe_AlertSubscription(t *testing.T) {
+	name := createTestSession(t, "alert")
+
+	pipe, err := NewControlPipe(name)
+	require.NoError(t, err)
+	defer pipe.Close()
+
+	if _, ok := pipe.Alert(); !ok {
+		t.Skip("tmux too old for control mode subscriptions")
+	}
+
+	require.NoError(t, exec.Command("tmux", "set-option", "-t", name, alertOption, AlertPaneDied).Run())
+
+	// tmux checks subscriptions once a second
+	require.Eventually(t, func() bool {
+		alert, _ := pipe.Alert()
+		return alert == AlertPaneDied
+	}, 5*time.Second, 100*time.Millisecond)
+}
@@ -1,7 +1,10 @@
 package tmux
 
 import (
+	"regexp"
 	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/termesc"
 )
 
 // SessionState represents the detected state of a session
@@ -17,67 +20,122 @@ const (
 // Prompt Detector - Detects tool-specific prompts (from Claude Squad source)
 // =============================================================================
 
-// PromptDetector checks for tool-specific prompts in terminal content
-// Based on Claude Squad's exact implementation:
-// https://github.com/smtg-ai/claude-squad/blob/main/session/tmux/tmux.go
+// PromptDetector checks for tool-specific prompts in terminal content.
+// It is a thin wrapper over the tool's registered ToolDetector.
 type PromptDetector struct {
-	tool string
+	detector ToolDetector
 }
 
 // NewPromptDetector creates a detector for the specified tool
 func NewPromptDetector(tool string) *PromptDetector {
-	return &PromptDetector{tool: strings.ToLower(tool)}
+	return &PromptDetector{detector: detectorFor(tool)}
 }
 
 // HasPrompt checks if the terminal content contains a prompt waiting for input
-// These patterns are derived from Claude Squad + additional research for edge cases
 func (d *PromptDetector) HasPrompt(content string) bool {
-	switch d.tool {
-	case "claude":
-		return d.hasClaudePrompt(content)
-
-	case "opencode":
-		// OpenCode TUI - the UI is always visible (input box, mode tabs, logo),
-		// so we MUST check busy indicators first. If opencode is actively working,
-		// return false to let the busy detector handle status correctly.
-		//
-		// Busy indicators (from opencode source: internal/tui/components/chat/list.go):
-		//   - Help bar shows "esc" when busy (to cancel), vs "enter" when idle (to send)
-		//   - Pulse spinner: █ ▓ ▒ ░ (spinner.Pulse, 125ms cycle)
-		//   - Task strings: "Thinking...", "Generating...", "Building tool call...",
-		//     "Waiting for tool response...", "Loading..."
-		if d.hasOpencodeBusyIndicator(content) {
-			return false
-		}
-		// Idle: check for opencode-specific prompt patterns
-		// "press enter to send" only appears when idle (help bar text)
-		// "Ask anything" is the input placeholder
-		return strings.Contains(content, "press enter to send") ||
-			strings.Contains(content, "Ask anything") ||
-			strings.Contains(content, "open code") ||
-			d.hasLineEndingWith(content, ">")
-
-	case "gemini":
-		return d.hasGeminiPrompt(content)
-
-	case "codex":
-		// Codex/OpenAI CLI patterns.
-		// Busy indicators take priority over prompt markers.
-		lower := strings.ToLower(content)
-		if strings.Contains(lower, "esc to interrupt") ||
-			strings.Contains(lower, "ctrl+c to interrupt") {
-			return false
-		}
-		return strings.Contains(content, "codex>") ||
-			strings.Contains(content, "Continue?")
+	return d.detector.HasPrompt(content)
+}
 
-	default:
-		// Generic shell - check for common prompts
-		return d.hasShellPrompt(content)
+// HasPrompt for OpenCode. The TUI is always visible (input box, mode tabs,
+// logo), so busy indicators are checked first: while opencode works, return
+// false to let the busy detector handle status.
+//
+// Busy indicators (from opencode source: internal/tui/components/chat/list.go):
+//   - Help bar shows "esc" when busy (to cancel), vs "enter" when idle (to send)
+//   - Pulse spinner: █ ▓ ▒ ░ (spinner.Pulse, 125ms cycle)
+//   - Task strings: "Thinking...", "Generating...", "Building tool call...",
+//     "Waiting for tool response...", "Loading..."
+func (opencodeDetector) HasPrompt(content string) bool {
+	if hasOpencodeBusyIndicator(content) {
+		return false
 	}
+	// Idle: check for opencode-specific prompt patterns
+	// "press enter to send" only appears when idle (help bar text)
+	// "Ask anything" is the input placeholder
+	return strings.Contains(content, "press enter to send") ||
+		strings.Contains(content, "Ask anything") ||
+		strings.Contains(content, "open code") ||
+		hasLineEndingWith(content, ">")
 }
 
-// hasClaudePrompt detects if Claude Code is waiting for input
+// HasPrompt for Codex/OpenAI CLI. Busy indicators take priority over prompt
+// markers.
+func (codexDetector) HasPrompt(content string) bool {
+	lower := strings.ToLower(content)
+	if strings.Contains(lower, "esc to interrupt") ||
+		strings.Contains(lower, "ctrl+c to interrupt") {
+		return false
+	}
+	return strings.Contains(content, "codex>") ||
+		strings.Contains(content, "Continue?")
+}
+
+// aiderPromptPattern matches aider's input line: "> " or "<mode>> " (e.g.
+// "architect> "), optionally followed by text the user is typing.
+var aiderPromptPattern = regexp.MustCompile(`^[a-z-]*>( |$)`)
+
+// HasApprovalPrompt for aider: a (Y)es/(N)o confirmation (add file, run
+// command, create file, ...) is pending.
+func (aiderDetector) HasApprovalPrompt(content string) bool {
+	lines := lastNLines(StripANSI(content), 10)
+	recent := strings.Join(lines, "\n")
+	return !strings.Contains(recent, "Waiting for ") && strings.Contains(recent, "(Y)es/(N)o")
+}
+
+// HasPrompt for aider: a pending confirmation, or the input prompt as the
+// last non-empty line while no request is in flight.
+func (aiderDetector) HasPrompt(content string) bool {
+	lines := lastNLines(StripANSI(content), 10)
+	recent := strings.Join(lines, "\n")
+	if strings.Contains(recent, "Waiting for ") {
+		return false
+	}
+	if strings.Contains(recent, "(Y)es/(N)o") {
+		return true
+	}
+	if len(lines) == 0 {
+		return false
+	}
+	return aiderPromptPattern.MatchString(strings.TrimSpace(lines[len(lines)-1]))
+}
+
+// claudePermissionPrompts are the markers of Claude Code's permission and
+// trust dialogs: Claude is blocked until the user approves or denies.
+var claudePermissionPrompts = []string{
+	// From Claude Squad (most reliable indicator)
+	"No, and tell Claude what to do differently",
+	// Permission dialog options
+	"Do you want to allow",
+	"Yes, allow once",
+	"Yes, allow always",
+	"Allow once",
+	"Allow always",
+	// Box-drawing permission dialogs
+	"│ Do you want",
+	"│ Would you like",
+	"│ Allow",
+	// Selection indicators
+	"❯ Yes",
+	"❯ No",
+	"❯ Allow",
+	// Trust prompt on startup
+	"Do you trust the files in this folder?",
+	// MCP permission prompts
+	"Allow this MCP server",
+	// Tool permission prompts
+	"Run this command?",
+	"Execute this?",
+	"Action Required",
+	"Waiting for user confirmation",
+	"Allow execution of",
+}
+
+// HasApprovalPrompt for Claude Code: a permission or trust dialog is open.
+func (claudeDetector) HasApprovalPrompt(content string) bool {
+	return recentContains(content, claudePermissionPrompts...)
+}
+
+// HasPrompt detects if Claude Code is waiting for input
 // Handles BOTH normal mode AND --dangerously-skip-permissions mode
 //
 // Claude Code UI States (from research):
@@ -91,7 +149,7 @@ func (d *PromptDetector) HasPrompt(content string) bool {
 // - Claude Squad: github.com/smtg-ai/claude-squad
 // - CCManager state detection
 // - cli-spinners: github.com/sindresorhus/cli-spinners (dots spinner)
-func (d *PromptDetector) hasClaudePrompt(content string) bool {
+func (claudeDetector) HasPrompt(content string) bool {
 	// Get last 15 lines for analysis (increased from 10 for better context)
 	lines := strings.Split(content, "\n")
 	var lastLines []string
@@ -162,40 +220,15 @@ func (d *PromptDetector) hasClaudePrompt(content string) bool {
 	}
 
 	// ═══════════════════════════════════════════════════════════════════════
-	// WAITING indicators - Permission prompts (normal mode)
+	// WAITING indicators - Permission prompts (normal mode) and the
+	// AskUserQuestion / interactive question UI
 	// ═══════════════════════════════════════════════════════════════════════
-	permissionPrompts := []string{
-		// From Claude Squad (most reliable indicator)
-		"No, and tell Claude what to do differently",
-		// Permission dialog options
-		"Yes, allow once",
-		"Yes, allow always",
-		"Allow once",
-		"Allow always",
-		// Box-drawing permission dialogs
-		"│ Do you want",
-		"│ Would you like",
-		"│ Allow",
-		// Selection indicators
-		"❯ Yes",
-		"❯ No",
-		"❯ Allow",
-		// Trust prompt on startup
-		"Do you trust the files in this folder?",
-		// MCP permission prompts
-		"Allow this MCP server",
-		// Tool permission prompts
-		"Run this command?",
-		"Execute this?",
-		"Action Required",
-		"Waiting for user confirmation",
-		"Allow execution of",
-		// AskUserQuestion / interactive question UI
-		// Claude Code renders selection options with these indicators
-		"Use arrow keys to navigate",
-		"Press Enter to select",
-	}
-	for _, prompt := range permissionPrompts {
+	for _, prompt := range claudePermissionPrompts {
+		if strings.Contains(content, prompt) {
+			return true
+		}
+	}
+	for _, prompt := range []string{"Use arrow keys to navigate", "Press Enter to select"} {
 		if strings.Contains(content, prompt) {
 			return true
 		}
@@ -322,9 +355,9 @@ func (d *PromptDetector) hasClaudePrompt(content string) bool {
 	return false
 }
 
-// hasGeminiPrompt detects if Gemini CLI is waiting for input.
+// HasPrompt detects if Gemini CLI is waiting for input.
 // Checks last 10 non-blank lines for known Gemini prompt patterns.
-func (d *PromptDetector) hasGeminiPrompt(content string) bool {
+func (geminiDetector) HasPrompt(content string) bool {
 	lines := strings.Split(content, "\n")
 	var lastLines []string
 	for i := len(lines) - 1; i >= 0 && len(lastLines) < 10; i-- {
@@ -355,7 +388,7 @@ func (d *PromptDetector) hasGeminiPrompt(content string) bool {
 }
 
 // hasLineEndingWith checks if any recent line ends with the given suffix
-func (d *PromptDetector) hasLineEndingWith(content string, suffix string) bool {
+func hasLineEndingWith(content string, suffix string) bool {
 	lines := strings.Split(content, "\n")
 	// Check last 5 lines
 	start := len(lines) - 5
@@ -371,8 +404,8 @@ func (d *PromptDetector) hasLineEndingWith(content string, suffix string) bool {
 	return false
 }
 
-// hasShellPrompt checks for generic shell prompts
-func (d *PromptDetector) hasShellPrompt(content string) bool {
+// HasPrompt checks for generic shell prompts
+func (shellDetector) HasPrompt(content string) bool {
 	// Check last few lines for shell prompt patterns
 	lines := strings.Split(content, "\n")
 	if len(lines) == 0 {
@@ -423,7 +456,7 @@ func (d *PromptDetector) hasShellPrompt(content string) bool {
 //   - Help bar: "esc" to cancel (only during processing)
 //   - Pulse spinner: █ ▓ ▒ ░ (cycles at 125ms)
 //   - Task text: "Thinking...", "Generating...", etc.
-func (d *PromptDetector) hasOpencodeBusyIndicator(content string) bool {
+func hasOpencodeBusyIndicator(content string) bool {
 	// "esc interrupt" or "esc to exit" in help bar = processing
 	if strings.Contains(content, "esc interrupt") || strings.Contains(content, "esc to exit") {
 		return true
@@ -455,84 +488,13 @@ func (d *PromptDetector) hasOpencodeBusyIndicator(content string) bool {
 // ANSI Stripping Utility
 // =============================================================================
 
-// StripANSI removes ANSI escape codes from content using O(n) single-pass algorithm.
-// This is important because terminal output contains color codes.
-//
-// PERFORMANCE: Uses strings.Builder with pre-allocation for O(n) time complexity.
-// Previous implementation used string concatenation in loops which was O(n²)
-// and caused 2-11 second UI freezes on large terminal output (Issue #39).
+// StripANSI removes terminal escape sequences from content: colors, cursor
+// movement, window titles, hyperlinks and the rest (see termesc.Strip).
 //
-// NOTE: We intentionally avoid regex here because complex ANSI regex patterns
-// can cause catastrophic backtracking on malformed escape sequences.
+// PERFORMANCE: Single pass with no regex. A previous implementation built
+// strings in loops (O(n²)) and froze the UI for seconds on large output
+// (Issue #39); complex ANSI regexes can also backtrack badly on malformed
+// sequences.
 func StripANSI(content string) string {
-	// Fast path: if no escape chars, return as-is
-	// Note: Using IndexByte instead of ContainsAny to avoid UTF-8 validation issues
-	// \x1b is ESC, \x9B is CSI (C1 control character)
-	if strings.IndexByte(content, '\x1b') < 0 && strings.IndexByte(content, '\x9B') < 0 {
-		return content
-	}
-
-	var b strings.Builder
-	b.Grow(len(content)) // Pre-allocate to avoid reallocations
-
-	i := 0
-	for i < len(content) {
-		// Check for ESC character
-		if content[i] == '\x1b' {
-			// CSI sequence: ESC [ ... letter
-			if i+1 < len(content) && content[i+1] == '[' {
-				j := i + 2
-				// Skip until we find the terminating letter
-				for j < len(content) {
-					c := content[j]
-					if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
-						j++
-						break
-					}
-					j++
-				}
-				i = j
-				continue
-			}
-			// OSC sequence: ESC ] ... BEL
-			if i+1 < len(content) && content[i+1] == ']' {
-				// Find BEL terminator
-				bellPos := strings.Index(content[i:], "\x07")
-				if bellPos != -1 {
-					i += bellPos + 1
-					continue
-				}
-				// No BEL found - find ST (ESC \) as alternative terminator
-				stPos := strings.Index(content[i:], "\x1b\\")
-				if stPos != -1 {
-					i += stPos + 2
-					continue
-				}
-			}
-			// Other escape sequence: ESC followed by single char
-			if i+1 < len(content) {
-				i += 2
-				continue
-			}
-		}
-		// Check for CSI without ESC (8-bit CSI: 0x9B)
-		if content[i] == '\x9B' {
-			j := i + 1
-			for j < len(content) {
-				c := content[j]
-				if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') {
-					j++
-					break
-				}
-				j++
-			}
-			i = j
-			continue
-		}
-		// Regular character - copy to output
-		b.WriteByte(content[i])
-		i++
-	}
-
-	return b.String()
+	return termesc.Strip(content)
 }
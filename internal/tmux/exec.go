@@ -0,0 +1,97 @@
+package tmux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// commandTimeout bounds each one-shot command run against the multiplexer or
+// the process table, so a hung tmux server or a slow SSH transport can't
+// stall the status loop or the attach flow. Long-running commands (attach,
+// control mode, pipe-pane) don't go through here.
+var commandTimeout = 3 * time.Second
+
+// queryAttempts is how many times a read-only command is tried before its
+// timeout is reported. Commands that change state are tried once: one that
+// timed out may still have taken effect.
+const queryAttempts = 2
+
+// retryPause is the wait before each further attempt of a query.
+var retryPause = 100 * time.Millisecond
+
+// ErrCommandTimeout is returned when a command exceeds commandTimeout.
+var ErrCommandTimeout = errors.New("command timed out")
+
+// runBounded runs the command build returns under commandTimeout and
+// returns what collect returns. Queries (query true) that time out are
+// retried up to queryAttempts times in total.
+func runBounded(query bool, build func(ctx context.Context) *exec.Cmd, collect func(*exec.Cmd) ([]byte, error)) ([]byte, error) {
+	attempts := 1
+	if query {
+		attempts = queryAttempts
+	}
+	var err error
+	for attempt := range attempts {
+		if attempt > 0 {
+			time.Sleep(retryPause * time.Duration(attempt))
+		}
+		var out []byte
+		out, err = runOnce(build, collect)
+		if !errors.Is(err, ErrCommandTimeout) {
+			return out, err
+		}
+	}
+	return nil, err
+}
+
+func runOnce(build func(ctx context.Context) *exec.Cmd, collect func(*exec.Cmd) ([]byte, error)) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+	defer cancel()
+	cmd := build(ctx)
+	// Don't wait on output pipes held open by a killed command's children
+	cmd.WaitDelay = time.Second
+	out, err := collect(cmd)
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		return out, fmt.Errorf("%s: %w after %s", cmd.Args[0], ErrCommandTimeout, commandTimeout)
+	}
+	return out, err
+}
+
+// runCmd adapts (*exec.Cmd).Run to runBounded's collect.
+func runCmd(cmd *exec.Cmd) ([]byte, error) {
+	return nil, cmd.Run()
+}
+
+// tmuxCmd builds a tmux command for runBounded.
+func tmuxCmd(args ...string) func(ctx context.Context) *exec.Cmd {
+	return func(ctx context.Context) *exec.Cmd { return CommandContext(ctx, args...) }
+}
+
+// tmuxRun runs a one-shot tmux command that changes state.
+func tmuxRun(args ...string) error {
+	_, err := runBounded(false, tmuxCmd(args...), runCmd)
+	return err
+}
+
+// tmuxCombinedOutput runs a one-shot tmux command that changes state and
+// returns its stdout and stderr.
+func tmuxCombinedOutput(args ...string) ([]byte, error) {
+	return runBounded(false, tmuxCmd(args...), (*exec.Cmd).CombinedOutput)
+}
+
+// tmuxQuery runs a read-only tmux command and returns its stdout, retrying
+// when it times out.
+func tmuxQuery(args ...string) ([]byte, error) {
+	return runBounded(true, tmuxCmd(args...), (*exec.Cmd).Output)
+}
+
+// execQuery runs a read-only command such as ps, pgrep or screen -ls and
+// returns its stdout, retrying when it times out.
+func execQuery(name string, args ...string) ([]byte, error) {
+	return runBounded(true, func(ctx context.Context) *exec.Cmd {
+		return exec.CommandContext(ctx, name, args...)
+	}, (*exec.Cmd).Output)
+}
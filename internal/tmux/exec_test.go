@@ -0,0 +1,55 @@
+package tmux
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shortTimeouts makes runBounded give up quickly for the test's duration.
+func shortTimeouts(t *testing.T) {
+	t.Helper()
+	oldTimeout, oldPause := commandTimeout, retryPause
+	commandTimeout, retryPause = 100*time.Millisecond, time.Millisecond
+	t.Cleanup(func() { commandTimeout, retryPause = oldTimeout, oldPause })
+}
+
+func TestRunBoundedTimesOut(t *testing.T) {
+	shortTimeouts(t)
+	attempts := 0
+	hang := func(ctx context.Context) *exec.Cmd {
+		attempts++
+		return exec.CommandContext(ctx, "sleep", "10")
+	}
+
+	start := time.Now()
+	_, err := runBounded(false, hang, runCmd)
+	require.ErrorIs(t, err, ErrCommandTimeout)
+	assert.Less(t, time.Since(start), 2*time.Second, "a hung command should be killed")
+	assert.Equal(t, 1, attempts, "a command that changes state is never repeated")
+
+	attempts = 0
+	_, err = runBounded(true, hang, (*exec.Cmd).Output)
+	require.ErrorIs(t, err, ErrCommandTimeout)
+	assert.Equal(t, queryAttempts, attempts, "a query is retried")
+}
+
+func TestRunBoundedPassesResults(t *testing.T) {
+	shortTimeouts(t)
+	attempts := 0
+	out, err := runBounded(true, func(ctx context.Context) *exec.Cmd {
+		attempts++
+		return exec.CommandContext(ctx, "echo", "hi")
+	}, (*exec.Cmd).Output)
+	require.NoError(t, err)
+	assert.Equal(t, "hi\n", string(out))
+	assert.Equal(t, 1, attempts)
+
+	_, err = execQuery("false")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrCommandTimeout, "only timeouts are retried and reported as such")
+}
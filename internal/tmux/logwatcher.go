@@ -0,0 +1,109 @@
+package tmux
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// logEventInterval is the minimum gap between two notifications for the same
+// session. Agents write many small chunks; one status check per burst is enough.
+const logEventInterval = 250 * time.Millisecond
+
+// LogWatcher watches the session log directory (see LogDir) and reports which
+// session produced output. Logs are written by tmux pipe-pane when output
+// logging is enabled (Session.EnableOutputLog), so status checks can run as
+// output arrives instead of waiting for the next poll.
+type LogWatcher struct {
+	logDir   string
+	watcher  *fsnotify.Watcher
+	onOutput func(sessionName string)
+
+	mu         sync.Mutex
+	lastNotify map[string]time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewLogWatcher creates a watcher for the log directory, creating it if
+// needed. onOutput receives the tmux session name of every log that grows.
+// Call Start() to begin watching; cancelling ctx stops it.
+func NewLogWatcher(ctx context.Context, onOutput func(sessionName string)) (*LogWatcher, error) {
+	logDir := LogDir()
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(logDir); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	childCtx, cancel := context.WithCancel(ctx)
+	return &LogWatcher{
+		logDir:     logDir,
+		watcher:    watcher,
+		onOutput:   onOutput,
+		lastNotify: make(map[string]time.Time),
+		ctx:        childCtx,
+		cancel:     cancel,
+	}, nil
+}
+
+// Start processes file events until the watcher is closed. Must be called in
+// a goroutine.
+func (w *LogWatcher) Start() {
+	defer func() { _ = w.watcher.Close() }()
+
+	for {
+		select {
+		case <-w.ctx.Done():
+			return
+
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&fsnotify.Write == 0 || filepath.Ext(event.Name) != ".log" {
+				continue
+			}
+			name := strings.TrimSuffix(filepath.Base(event.Name), ".log")
+			if w.shouldNotify(name, time.Now()) {
+				w.onOutput(name)
+			}
+
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			statusLog.Warn("log_watcher_error", slog.String("dir", w.logDir), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// Close stops the watcher.
+func (w *LogWatcher) Close() {
+	w.cancel()
+}
+
+// shouldNotify rate-limits notifications per session to one per logEventInterval.
+func (w *LogWatcher) shouldNotify(name string, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if now.Sub(w.lastNotify[name]) < logEventInterval {
+		return false
+	}
+	w.lastNotify[name] = now
+	return true
+}
@@ -0,0 +1,62 @@
+package tmux
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogWatcher_ReportsSessionOnWrite(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	got := make(chan string, 10)
+	lw, err := NewLogWatcher(ctx, func(name string) { got <- name })
+	require.NoError(t, err)
+	go lw.Start()
+	defer lw.Close()
+
+	// Non-log files are ignored
+	require.NoError(t, os.WriteFile(filepath.Join(LogDir(), "notes.txt"), []byte("x"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(LogDir(), "agentdeck_api_1234abcd.log"), []byte("output\n"), 0644))
+
+	select {
+	case name := <-got:
+		assert.Equal(t, "agentdeck_api_1234abcd", name)
+	case <-time.After(2 * time.Second):
+		t.Fatal("no output event for log write")
+	}
+}
+
+func TestLogWatcher_ShouldNotifyRateLimits(t *testing.T) {
+	w := &LogWatcher{lastNotify: make(map[string]time.Time)}
+	now := time.Now()
+
+	assert.True(t, w.shouldNotify("a", now))
+	assert.False(t, w.shouldNotify("a", now.Add(logEventInterval/2)), "burst is coalesced")
+	assert.True(t, w.shouldNotify("b", now), "sessions are limited independently")
+	assert.True(t, w.shouldNotify("a", now.Add(logEventInterval)))
+}
+
+func TestEnableOutputLog(t *testing.T) {
+	name := createTestSession(t, "outlog")
+	t.Setenv("HOME", t.TempDir())
+
+	s := &Session{Name: name}
+	require.NoError(t, s.EnableOutputLog())
+	require.NoError(t, s.EnableOutputLog(), "second call is a no-op")
+
+	require.NoError(t, s.SendKeysAndEnter("echo pane-log-marker"))
+	require.Eventually(t, func() bool {
+		data, _ := os.ReadFile(s.LogFile())
+		return strings.Contains(string(data), "pane-log-marker")
+	}, 5*time.Second, 100*time.Millisecond)
+}
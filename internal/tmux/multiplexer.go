@@ -0,0 +1,156 @@
+package tmux
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Multiplexer is the terminal multiplexer that hosts agent sessions. Session
+// routes its core operations through the active backend.
+//
+// tmux is the default and the only backend with the extras built on top of it:
+// control-mode pipes, the status bar, side panes, respawn and window-activity
+// polling. Other backends get the core operations, and status detection falls
+// back to comparing captured content.
+type Multiplexer interface {
+	// Name identifies the backend in config ("tmux", "zellij", "screen").
+	Name() string
+	// Create starts a detached session running the user's shell in workDir.
+	Create(name, workDir string) error
+	// Kill terminates the session and everything running in it.
+	Kill(name string) error
+	// Exists reports whether a live session with this name exists.
+	Exists(name string) bool
+	// Capture returns the visible content of the agent pane, plus up to
+	// historyLines of scrollback when historyLines > 0.
+	Capture(ctx context.Context, name string, historyLines int) (string, error)
+	// SendKeys types keys into the agent pane literally.
+	SendKeys(name, keys string) error
+	// SendEnter presses Enter in the agent pane.
+	SendEnter(name string) error
+	// AttachCommand returns the command that attaches the calling terminal.
+	AttachCommand(ctx context.Context, name string) *exec.Cmd
+}
+
+// Supported backend names.
+const (
+	BackendTmux   = "tmux"
+	BackendZellij = "zellij"
+	BackendScreen = "screen"
+)
+
+// backend is the active multiplexer. Set once at startup, before any session
+// is created.
+var backend Multiplexer = tmuxBackend{}
+
+// NewMultiplexer returns the backend with the given name. An empty name
+// selects tmux, or GNU screen when tmux isn't installed but screen is.
+func NewMultiplexer(name string) (Multiplexer, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return autoMultiplexer(exec.LookPath), nil
+	case BackendTmux:
+		return tmuxBackend{}, nil
+	case BackendZellij:
+		return zellijBackend{}, nil
+	case BackendScreen:
+		return screenBackend{}, nil
+	default:
+		return nil, fmt.Errorf("unknown multiplexer %q (supported: %s, %s, %s)",
+			name, BackendTmux, BackendZellij, BackendScreen)
+	}
+}
+
+// autoMultiplexer picks tmux unless only screen is installed. Missing both
+// still returns tmux so the "tmux not found" error names the usual fix.
+func autoMultiplexer(lookPath func(string) (string, error)) Multiplexer {
+	if _, err := lookPath(BackendTmux); err != nil {
+		if _, err := lookPath(BackendScreen); err == nil {
+			return screenBackend{}
+		}
+	}
+	return tmuxBackend{}
+}
+
+// SetMultiplexer selects the backend for all sessions. nil restores tmux.
+func SetMultiplexer(m Multiplexer) {
+	if m == nil {
+		m = tmuxBackend{}
+	}
+	backend = m
+}
+
+// ActiveMultiplexer returns the backend set by SetMultiplexer.
+func ActiveMultiplexer() Multiplexer {
+	return backend
+}
+
+// isTmux reports whether tmux is the active backend, which gates the
+// tmux-only features.
+func isTmux() bool {
+	_, ok := backend.(tmuxBackend)
+	return ok
+}
+
+// lastLines returns the last n lines of content, or all of it when n <= 0.
+func lastLines(content string, n int) string {
+	if n <= 0 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	if len(lines) <= n {
+		return content
+	}
+	return strings.Join(lines[len(lines)-n:], "\n")
+}
+
+// tmuxBackend is the default Multiplexer.
+type tmuxBackend struct{}
+
+func (tmuxBackend) Name() string { return BackendTmux }
+
+func (tmuxBackend) Create(name, workDir string) error {
+	output, err := tmuxCombinedOutput("new-session", "-d", "-s", name, "-c", workDir)
+	if err != nil {
+		return fmt.Errorf("failed to create tmux session: %w (output: %s)", err, string(output))
+	}
+	return nil
+}
+
+func (tmuxBackend) Kill(name string) error {
+	return tmuxRun("kill-session", "-t", name)
+}
+
+func (tmuxBackend) Exists(name string) bool {
+	_, err := tmuxQuery("has-session", "-t", name)
+	return err == nil
+}
+
+func (tmuxBackend) Capture(ctx context.Context, name string, historyLines int) (string, error) {
+	// -J joins wrapped lines and trims trailing spaces so hashes don't change on resize
+	args := []string{"capture-pane", "-t", agentPaneTarget(name), "-p", "-J"}
+	if historyLines > 0 {
+		args = append(args, "-S", "-"+strconv.Itoa(historyLines))
+	}
+	output, err := CommandContext(ctx, args...).Output()
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+func (tmuxBackend) SendKeys(name, keys string) error {
+	// -l sends keys literally; "--" stops content starting with "-" being read as a flag
+	return tmuxRun("send-keys", "-l", "-t", agentPaneTarget(name), "--", keys)
+}
+
+func (tmuxBackend) SendEnter(name string) error {
+	return tmuxRun("send-keys", "-t", agentPaneTarget(name), "Enter")
+}
+
+func (tmuxBackend) AttachCommand(ctx context.Context, name string) *exec.Cmd {
+	return CommandContext(ctx, "attach-session", "-t", name)
+}
@@ -0,0 +1,161 @@
+package tmux
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMux records calls and keeps an in-memory screen per session.
+type fakeMux struct {
+	screens map[string]string
+	calls   []string
+}
+
+func (f *fakeMux) Name() string { return "fake" }
+
+func (f *fakeMux) Create(name, workDir string) error {
+	f.calls = append(f.calls, "create "+name+" "+workDir)
+	f.screens[name] = "$ "
+	return nil
+}
+
+func (f *fakeMux) Kill(name string) error {
+	f.calls = append(f.calls, "kill "+name)
+	delete(f.screens, name)
+	return nil
+}
+
+func (f *fakeMux) Exists(name string) bool {
+	_, ok := f.screens[name]
+	return ok
+}
+
+func (f *fakeMux) Capture(_ context.Context, name string, _ int) (string, error) {
+	return f.screens[name], nil
+}
+
+func (f *fakeMux) SendKeys(name, keys string) error {
+	f.screens[name] += keys
+	return nil
+}
+
+func (f *fakeMux) SendEnter(name string) error {
+	f.screens[name] += "\n"
+	return nil
+}
+
+func (f *fakeMux) AttachCommand(ctx context.Context, name string) *exec.Cmd {
+	return exec.CommandContext(ctx, "true")
+}
+
+func TestSessionUsesActiveMultiplexer(t *testing.T) {
+	fake := &fakeMux{screens: map[string]string{}}
+	SetMultiplexer(fake)
+	defer SetMultiplexer(nil)
+
+	sess := NewSession("mux-test", "/work")
+	sess.SidePanes = []string{"htop"} // tmux-only, must be ignored
+	require.NoError(t, sess.Start("echo hi"))
+	assert.Equal(t, []string{"create " + sess.Name + " /work"}, fake.calls)
+	assert.True(t, sess.Exists())
+
+	content, err := sess.CapturePane()
+	require.NoError(t, err)
+	assert.Equal(t, "$ echo hi\n", content)
+
+	_, err = sess.GetWindowActivity()
+	assert.Error(t, err, "window activity is tmux-only")
+	assert.Equal(t, "/work", sess.GetWorkDir())
+
+	require.NoError(t, sess.Kill())
+	assert.False(t, sess.Exists())
+}
+
+func TestNewMultiplexer(t *testing.T) {
+	for name, want := range map[string]string{"tmux": BackendTmux, " Zellij ": BackendZellij, "screen": BackendScreen} {
+		m, err := NewMultiplexer(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, m.Name())
+	}
+	_, err := NewMultiplexer("byobu")
+	assert.Error(t, err)
+}
+
+func TestZellijSessionListed(t *testing.T) {
+	output := strings.Join([]string{
+		"agentdeck_api_1a2b3c4d [Created 2m 3s ago]",
+		"agentdeck_old_99999999 [Created 1day ago] (EXITED - attach to resurrect)",
+		"personal [Created 5m ago] (current)",
+	}, "\n")
+	assert.True(t, zellijSessionListed(output, "agentdeck_api_1a2b3c4d"))
+	assert.False(t, zellijSessionListed(output, "agentdeck_old_99999999"), "exited sessions are gone")
+	assert.False(t, zellijSessionListed(output, "agentdeck_api"), "prefix must not match")
+	assert.False(t, zellijSessionListed("", "personal"))
+}
+
+func TestEnvironWithout(t *testing.T) {
+	env := []string{"HOME=/h", "ZELLIJ=0", "ZELLIJ_SESSION_NAME=x", "ZELLIJX=keep"}
+	assert.Equal(t, []string{"HOME=/h", "ZELLIJX=keep"}, environWithout(env, "ZELLIJ", "ZELLIJ_SESSION_NAME"))
+}
+
+func TestAutoMultiplexer(t *testing.T) {
+	installed := func(bins ...string) func(string) (string, error) {
+		return func(name string) (string, error) {
+			for _, b := range bins {
+				if b == name {
+					return "/usr/bin/" + name, nil
+				}
+			}
+			return "", exec.ErrNotFound
+		}
+	}
+	assert.Equal(t, BackendTmux, autoMultiplexer(installed("tmux", "screen")).Name())
+	assert.Equal(t, BackendScreen, autoMultiplexer(installed("screen")).Name())
+	assert.Equal(t, BackendTmux, autoMultiplexer(installed()).Name(), "missing both reports tmux")
+}
+
+func TestScreenSessionListed(t *testing.T) {
+	output := "There are screens on:\n" +
+		"\t4242.agentdeck_api_1a2b3c4d\t(10/15/2026 05:31:40 AM)\t(Detached)\n" +
+		"\t4343.agentdeck_old_99999999\t(Dead ???)\n" +
+		"2 Sockets in /run/screen/S-me.\n"
+	assert.True(t, screenSessionListed(output, "agentdeck_api_1a2b3c4d"))
+	assert.False(t, screenSessionListed(output, "agentdeck_old_99999999"), "dead sessions are gone")
+	assert.False(t, screenSessionListed(output, "agentdeck_api"))
+	assert.False(t, screenSessionListed("No Sockets found in /run/screen/S-me.\n", "agentdeck_api_1a2b3c4d"))
+}
+
+func TestScreenStuffEscaper(t *testing.T) {
+	assert.Equal(t, `echo \$HOME \^C C:\\dir`, screenStuffEscaper.Replace(`echo $HOME ^C C:\dir`))
+}
+
+func TestReadHardcopy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hardcopy.txt")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = os.WriteFile(path, []byte("$ claude\n"), 0o600)
+	}()
+	data, err := readHardcopy(context.Background(), path, 2*time.Second, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, "$ claude\n", string(data))
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	require.NoError(t, os.WriteFile(empty, nil, 0o600))
+	_, err = readHardcopy(context.Background(), empty, 50*time.Millisecond, 10*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestLastLines(t *testing.T) {
+	assert.Equal(t, "b\nc", lastLines("a\nb\nc", 2))
+	assert.Equal(t, "a\nb", lastLines("a\nb", 5))
+	assert.Equal(t, "a\nb", lastLines("a\nb", 0))
+}
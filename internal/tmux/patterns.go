@@ -16,6 +16,7 @@ var patternLog = logging.ForComponent(logging.CompStatus)
 type RawPatterns struct {
 	BusyPatterns   []string // plain strings + "re:" prefixed regex
 	PromptPatterns []string
+	ErrorPatterns  []string // output that means the agent is stuck (rate limit, auth, crash)
 	SpinnerChars   []string
 	WhimsicalWords []string
 }
@@ -26,6 +27,8 @@ type ResolvedPatterns struct {
 	BusyRegexps   []*regexp.Regexp
 	PromptStrings []string
 	PromptRegexps []*regexp.Regexp
+	ErrorStrings  []string
+	ErrorRegexps  []*regexp.Regexp
 	SpinnerChars  []string
 
 	// Pre-built combo patterns (from WhimsicalWords + SpinnerChars)
@@ -34,45 +37,25 @@ type ResolvedPatterns struct {
 	SpinnerActivePattern    *regexp.Regexp
 }
 
-// DefaultRawPatterns returns the built-in detection patterns for a known tool.
+// DefaultRawPatterns returns the built-in detection patterns for a known tool,
+// as provided by its registered ToolDetector.
 // Returns nil for unknown tools (they have no defaults).
 func DefaultRawPatterns(toolName string) *RawPatterns {
-	switch strings.ToLower(toolName) {
-	case "claude":
-		return &RawPatterns{
-			BusyPatterns: []string{
-				`re:(?m)^[✳✽✶✻✢·]\s*.+…`, // PRIMARY: spinner + ellipsis (Claude 2.1.25+) — anchored to line start to prevent mid-line · in welcome banner from false-positiving
-				"ctrl+c to interrupt",    // SECONDARY: explicit busy text (current Claude)
-				"esc to interrupt",       // FALLBACK: older Claude Code versions
-			},
-			SpinnerChars:   defaultSpinnerChars(),
-			WhimsicalWords: defaultWhimsicalWords(),
-		}
-	case "gemini":
-		return &RawPatterns{
-			BusyPatterns:   []string{"esc to cancel"},
-			PromptPatterns: []string{"gemini>", "Type your message"},
-		}
-	case "opencode":
-		return &RawPatterns{
-			BusyPatterns:   []string{"esc interrupt"},
-			PromptPatterns: []string{"Ask anything"},
-		}
-	case "codex":
-		return &RawPatterns{
-			BusyPatterns: []string{
-				"ctrl+c to interrupt",
-				"esc to interrupt",
-				"press esc to interrupt",
-			},
-			PromptPatterns: []string{"How can I help", "codex>", "Continue?"},
-		}
-	case "shell":
-		return &RawPatterns{
-			PromptPatterns: []string{"$ ", "# ", "% "},
-		}
-	default:
-		return nil
+	if d, ok := lookupToolDetector(toolName); ok {
+		return d.Patterns()
+	}
+	return nil
+}
+
+// defaultErrorPatterns returns output that means an agent CLI is stuck rather
+// than done: crashes and exit banners. Anchored to the line start so the same
+// words inside the agent's own (indented) tool output don't match.
+func defaultErrorPatterns() []string {
+	return []string{
+		`re:(?m)^panic: `,
+		`re:(?m)^Traceback \(most recent call last\):`,
+		`re:(?m)^(Uncaught|Unhandled) .*(Error|Exception)`,
+		`re:(?im)^\[?process exited with (code|status) [1-9]`,
 	}
 }
 
@@ -163,6 +146,22 @@ func CompilePatterns(raw *RawPatterns) (*ResolvedPatterns, error) {
 		}
 	}
 
+	// Split error patterns into strings vs regex
+	for _, p := range raw.ErrorPatterns {
+		if strings.HasPrefix(p, "re:") {
+			re, err := regexp.Compile(p[3:])
+			if err != nil {
+				patternLog.Warn("invalid_error_regex",
+					slog.String("pattern", p),
+					slog.String("error", err.Error()))
+				continue
+			}
+			resolved.ErrorRegexps = append(resolved.ErrorRegexps, re)
+		} else {
+			resolved.ErrorStrings = append(resolved.ErrorStrings, p)
+		}
+	}
+
 	// Copy spinner chars
 	resolved.SpinnerChars = make([]string, len(raw.SpinnerChars))
 	copy(resolved.SpinnerChars, raw.SpinnerChars)
@@ -223,6 +222,7 @@ func MergeRawPatterns(defaults, overrides, extras *RawPatterns) *RawPatterns {
 	if defaults != nil {
 		result.BusyPatterns = copySlice(defaults.BusyPatterns)
 		result.PromptPatterns = copySlice(defaults.PromptPatterns)
+		result.ErrorPatterns = copySlice(defaults.ErrorPatterns)
 		result.SpinnerChars = copySlice(defaults.SpinnerChars)
 		result.WhimsicalWords = copySlice(defaults.WhimsicalWords)
 	}
@@ -235,6 +235,9 @@ func MergeRawPatterns(defaults, overrides, extras *RawPatterns) *RawPatterns {
 		if overrides.PromptPatterns != nil {
 			result.PromptPatterns = copySlice(overrides.PromptPatterns)
 		}
+		if overrides.ErrorPatterns != nil {
+			result.ErrorPatterns = copySlice(overrides.ErrorPatterns)
+		}
 		if overrides.SpinnerChars != nil {
 			result.SpinnerChars = copySlice(overrides.SpinnerChars)
 		}
@@ -247,6 +250,7 @@ func MergeRawPatterns(defaults, overrides, extras *RawPatterns) *RawPatterns {
 	if extras != nil {
 		result.BusyPatterns = append(result.BusyPatterns, extras.BusyPatterns...)
 		result.PromptPatterns = append(result.PromptPatterns, extras.PromptPatterns...)
+		result.ErrorPatterns = append(result.ErrorPatterns, extras.ErrorPatterns...)
 		result.SpinnerChars = append(result.SpinnerChars, extras.SpinnerChars...)
 		result.WhimsicalWords = append(result.WhimsicalWords, extras.WhimsicalWords...)
 	}
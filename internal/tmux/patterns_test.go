@@ -190,11 +190,13 @@ func TestMergeRawPatterns_ExtendMode(t *testing.T) {
 	defaults := &RawPatterns{
 		BusyPatterns:   []string{"default1"},
 		PromptPatterns: []string{"prompt1"},
+		ErrorPatterns:  []string{"error1"},
 		SpinnerChars:   []string{"⠋"},
 	}
 	extras := &RawPatterns{
 		BusyPatterns:   []string{"extra1"},
 		PromptPatterns: []string{"prompt2"},
+		ErrorPatterns:  []string{"error2"},
 		SpinnerChars:   []string{"@"},
 	}
 
@@ -209,6 +211,9 @@ func TestMergeRawPatterns_ExtendMode(t *testing.T) {
 	if len(result.PromptPatterns) != 2 {
 		t.Errorf("expected 2 prompt patterns, got %d", len(result.PromptPatterns))
 	}
+	if len(result.ErrorPatterns) != 2 {
+		t.Errorf("expected 2 error patterns, got %d", len(result.ErrorPatterns))
+	}
 	if len(result.SpinnerChars) != 2 {
 		t.Errorf("expected 2 spinner chars, got %d", len(result.SpinnerChars))
 	}
@@ -388,3 +393,43 @@ func TestSpinnerRuneSet(t *testing.T) {
 		t.Error("missing ✻ from normalization set")
 	}
 }
+
+func TestMatchErrorLine(t *testing.T) {
+	resolved, err := CompilePatterns(DefaultRawPatterns("claude"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"usage limit", "> fix the tests\n  ⎿  Claude AI usage limit reached|1760000000\n\n❯ ", "⎿  Claude AI usage limit reached|1760000000"},
+		{"auth", "  ⎿  API Error: 401 {\"type\":\"error\"}\n❯ ", "⎿  API Error: 401 {\"type\":\"error\"}"},
+		{"crash", "panic: runtime error: index out of range\n\ngoroutine 1 [running]:\n$ ", "panic: runtime error: index out of range"},
+		{"tool output is not an error", "● Bash(go test ./...)\n  ⎿  Error: Exit code 1\n     panic: boom\n❯ ", ""},
+		{"talking about limits", "I added a rate limiter for API errors.\n❯ ", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchErrorLine(resolved, lastNLines(tt.content, 15)); got != tt.want {
+				t.Errorf("matchErrorLine = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompilePatterns_ErrorPatterns(t *testing.T) {
+	resolved, err := CompilePatterns(&RawPatterns{ErrorPatterns: []string{"quota", "re:^FATAL", "re:[bad"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resolved.ErrorStrings) != 1 || len(resolved.ErrorRegexps) != 1 {
+		t.Errorf("got %d strings and %d regexps, want 1 and 1 (invalid regex skipped)",
+			len(resolved.ErrorStrings), len(resolved.ErrorRegexps))
+	}
+	if DefaultRawPatterns("shell").ErrorPatterns != nil {
+		t.Error("shells should have no default error patterns")
+	}
+}
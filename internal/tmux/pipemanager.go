@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log/slog"
 	"maps"
-	"os/exec"
 	"strings"
 	"sync"
 	"time"
@@ -47,6 +46,11 @@ func NewPipeManager(ctx context.Context, onOutput func(sessionName string)) *Pip
 // If a pipe already exists and is alive, this is a no-op.
 // Uses reconnecting map to prevent concurrent pipe creation for the same session.
 func (pm *PipeManager) Connect(sessionName string) error {
+	// Control mode is a tmux feature
+	if !isTmux() {
+		return fmt.Errorf("control mode not supported by %s", backend.Name())
+	}
+
 	pm.mu.Lock()
 
 	// Already connected and alive?
@@ -138,6 +142,45 @@ func (pm *PipeManager) CapturePane(sessionName string) (string, error) {
 	return pipe.CapturePaneVia()
 }
 
+// CaptureHistory routes a scrollback capture through the control mode pipe.
+func (pm *PipeManager) CaptureHistory(sessionName string, lines int) (string, error) {
+	pm.mu.RLock()
+	pipe := pm.pipes[sessionName]
+	pm.mu.RUnlock()
+
+	if pipe == nil || !pipe.IsAlive() {
+		return "", fmt.Errorf("no pipe for session %s", sessionName)
+	}
+
+	return pipe.CaptureHistoryVia(lines)
+}
+
+// CaptureStyledHistory is CaptureHistory with colors kept.
+func (pm *PipeManager) CaptureStyledHistory(sessionName string, lines int) (string, error) {
+	pm.mu.RLock()
+	pipe := pm.pipes[sessionName]
+	pm.mu.RUnlock()
+
+	if pipe == nil || !pipe.IsAlive() {
+		return "", fmt.Errorf("no pipe for session %s", sessionName)
+	}
+
+	return pipe.CaptureStyledHistoryVia(lines)
+}
+
+// GetEnvironment reads a session environment variable through the pipe.
+func (pm *PipeManager) GetEnvironment(sessionName, key string) (string, error) {
+	pm.mu.RLock()
+	pipe := pm.pipes[sessionName]
+	pm.mu.RUnlock()
+
+	if pipe == nil || !pipe.IsAlive() {
+		return "", fmt.Errorf("no pipe for session %s", sessionName)
+	}
+
+	return pipe.SendCommand(fmt.Sprintf("show-environment -t %s %s", sessionName, key))
+}
+
 // GetWindowActivity sends a display-message command through the pipe to get
 // the window_activity timestamp. Falls back to error if pipe unavailable.
 func (pm *PipeManager) GetWindowActivity(sessionName string) (int64, error) {
@@ -162,12 +205,10 @@ func (pm *PipeManager) GetWindowActivity(sessionName string) (int64, error) {
 	return ts, nil
 }
 
-// RefreshAllActivities sends a single list-windows command through any available
-// pipe to get activity timestamps for ALL sessions. This replaces the subprocess
-// call in RefreshSessionCache.
-func (pm *PipeManager) RefreshAllActivities() (map[string]int64, error) {
+// SendCommand sends a server-wide command (one that doesn't depend on the
+// session the pipe is attached to) through any alive pipe.
+func (pm *PipeManager) SendCommand(command string) (string, error) {
 	pm.mu.RLock()
-	// Find any alive pipe to send the command through
 	var pipe *ControlPipe
 	for _, p := range pm.pipes {
 		if p.IsAlive() {
@@ -178,87 +219,35 @@ func (pm *PipeManager) RefreshAllActivities() (map[string]int64, error) {
 	pm.mu.RUnlock()
 
 	if pipe == nil {
-		return nil, fmt.Errorf("no alive pipes available")
-	}
-
-	// tmux control mode requires double-quoted format strings containing special chars
-	output, err := pipe.SendCommand(`list-windows -a -F "#{session_name}\t#{window_activity}"`)
-	if err != nil {
-		return nil, fmt.Errorf("list-windows via pipe: %w", err)
+		return "", fmt.Errorf("no alive pipes available")
 	}
-
-	result := make(map[string]int64)
-	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) != 2 {
-			continue
-		}
-		name := parts[0]
-		var activity int64
-		_, _ = fmt.Sscanf(parts[1], "%d", &activity)
-		// Keep maximum activity if session has multiple windows
-		if existing, ok := result[name]; !ok || activity > existing {
-			result[name] = activity
-		}
-	}
-	return result, nil
+	return pipe.SendCommand(command)
 }
 
-// RefreshAllPaneInfo sends a single list-panes command through any available
-// pipe to get pane titles and current commands for ALL sessions. This provides
-// the data needed for title-based state detection without subprocess spawns.
-func (pm *PipeManager) RefreshAllPaneInfo() (map[string]PaneInfo, error) {
+// LastOutputTime returns the last output time for a session from its pipe.
+// Returns zero time if no pipe or no output recorded.
+func (pm *PipeManager) LastOutputTime(sessionName string) time.Time {
 	pm.mu.RLock()
-	var pipe *ControlPipe
-	for _, p := range pm.pipes {
-		if p.IsAlive() {
-			pipe = p
-			break
-		}
-	}
+	pipe := pm.pipes[sessionName]
 	pm.mu.RUnlock()
 
 	if pipe == nil {
-		return nil, fmt.Errorf("no alive pipes available")
-	}
-
-	output, err := pipe.SendCommand(`list-panes -a -F "#{session_name}\t#{pane_title}\t#{pane_current_command}"`)
-	if err != nil {
-		return nil, fmt.Errorf("list-panes via pipe: %w", err)
-	}
-
-	result := make(map[string]PaneInfo)
-	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 3)
-		if len(parts) != 3 {
-			continue
-		}
-		// Keep last pane per session (most sessions have one pane)
-		result[parts[0]] = PaneInfo{
-			Title:          parts[1],
-			CurrentCommand: parts[2],
-		}
+		return time.Time{}
 	}
-	return result, nil
+	return pipe.LastOutputTime()
 }
 
-// LastOutputTime returns the last output time for a session from its pipe.
-// Returns zero time if no pipe or no output recorded.
-func (pm *PipeManager) LastOutputTime(sessionName string) time.Time {
+// Alert returns the latest hook alert reported by a session's pipe. ok is
+// false when the session has no alive pipe or its pipe can't report alerts.
+func (pm *PipeManager) Alert(sessionName string) (alert string, ok bool) {
 	pm.mu.RLock()
 	pipe := pm.pipes[sessionName]
 	pm.mu.RUnlock()
 
-	if pipe == nil {
-		return time.Time{}
+	if pipe == nil || !pipe.IsAlive() {
+		return "", false
 	}
-	return pipe.LastOutputTime()
+	return pipe.Alert()
 }
 
 // IsConnected returns true if a session has an alive pipe.
@@ -393,8 +382,8 @@ func (pm *PipeManager) watchPipe(sessionName string, pipe *ControlPipe) {
 
 // tmuxSessionExists checks if a tmux session exists (lightweight subprocess).
 func tmuxSessionExists(name string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", name)
-	return cmd.Run() == nil
+	_, err := tmuxQuery("has-session", "-t", name)
+	return err == nil
 }
 
 // --- Global singleton ---
@@ -0,0 +1,129 @@
+package tmux
+
+import (
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Process-based exit detection. Content heuristics can't tell an agent
+// waiting at its prompt from the shell prompt left behind once the agent
+// exits: both sit still with a prompt at the bottom of the pane. The agent
+// pane's foreground command and process tree can.
+
+// agentExitRecheck bounds how often a session reads the process table.
+const agentExitRecheck = 2 * time.Second
+
+// shellCommands are pane_current_command values that mean a shell has the
+// foreground.
+var shellCommands = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "fish": true, "dash": true,
+	"ksh": true, "mksh": true, "tcsh": true, "csh": true, "nu": true,
+}
+
+// isShellCommand reports whether a pane_current_command value is a shell.
+// Login shells may be reported as "-zsh".
+func isShellCommand(command string) bool {
+	name := strings.TrimPrefix(filepath.Base(strings.TrimSpace(command)), "-")
+	return shellCommands[name]
+}
+
+// AgentExited reports whether the session's agent has exited and left its
+// shell running in the agent pane. The foreground command alone isn't
+// enough: agents run tools through bash, so a shell in the foreground only
+// counts once no process under the pane runs the agent any more.
+//
+// Returns false for shell and custom-tool sessions, during startup (the
+// shell runs before the agent does), and whenever the state is unknown.
+func (s *Session) AgentExited() bool {
+	s.mu.Lock()
+	tool := inferToolFromSessionFields(s.detectedTool, s.customToolName, s.Command)
+	starting := s.inStartupWindowLocked()
+	if !s.agentExitCheckedAt.IsZero() && time.Since(s.agentExitCheckedAt) < agentExitRecheck {
+		exited := s.agentExited
+		s.mu.Unlock()
+		return exited
+	}
+	s.mu.Unlock()
+
+	exited := false
+	if d, ok := lookupToolDetector(tool); ok && tool != "shell" && !starting && isTmux() {
+		if info, ok := GetCachedPaneInfo(s.Name); ok && info.PID > 0 && isShellCommand(info.CurrentCommand) {
+			if procs, err := listProcesses(); err == nil {
+				exited = !treeRunsTool(procs, info.PID, d)
+				if exited {
+					statusLog.Debug("agent_exited_to_shell",
+						slog.String("session", s.Name),
+						slog.String("tool", tool),
+						slog.String("shell", info.CurrentCommand))
+				}
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.agentExited = exited
+	s.agentExitCheckedAt = time.Now()
+	s.mu.Unlock()
+	return exited
+}
+
+// processEntry is one row of the process table.
+type processEntry struct {
+	pid, ppid int
+	args      string
+}
+
+// listProcesses reads the process table in one ps call.
+func listProcesses() ([]processEntry, error) {
+	out, err := execQuery("ps", "-A", "-o", "pid=,ppid=,args=")
+	if err != nil {
+		return nil, err
+	}
+	return parseProcessList(string(out)), nil
+}
+
+// parseProcessList parses `ps -o pid=,ppid=,args=` output.
+func parseProcessList(output string) []processEntry {
+	var procs []processEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		procs = append(procs, processEntry{pid: pid, ppid: ppid, args: strings.Join(fields[2:], " ")})
+	}
+	return procs
+}
+
+// treeRunsTool reports whether root or any of its descendants runs the tool
+// d detects. root is the pane's process: usually a shell, but the agent
+// itself when it was started as the pane's command, as restarts do.
+func treeRunsTool(procs []processEntry, root int, d ToolDetector) bool {
+	children := make(map[int][]processEntry)
+	for _, p := range procs {
+		if p.pid == root && d.MatchesCommand(strings.ToLower(p.args)) {
+			return true
+		}
+		children[p.ppid] = append(children[p.ppid], p)
+	}
+	queue := []int{root}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, child := range children[parent] {
+			if d.MatchesCommand(strings.ToLower(child.args)) {
+				return true
+			}
+			queue = append(queue, child.pid)
+		}
+	}
+	return false
+}
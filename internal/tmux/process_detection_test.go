@@ -0,0 +1,49 @@
+package tmux
+
+import "testing"
+
+func TestIsShellCommand(t *testing.T) {
+	tests := map[string]bool{
+		"zsh":           true,
+		"-zsh":          true,
+		"bash":          true,
+		"/usr/bin/fish": true,
+		"claude":        false,
+		"node":          false,
+		"":              false,
+	}
+	for cmd, want := range tests {
+		if got := isShellCommand(cmd); got != want {
+			t.Errorf("isShellCommand(%q) = %v, want %v", cmd, got, want)
+		}
+	}
+}
+
+func TestTreeRunsTool(t *testing.T) {
+	procs := parseProcessList(`
+    1     0 /sbin/init
+  100     1 -zsh
+  101   100 bash -c session_id=$(uuidgen); claude --session-id "$session_id"
+  102   101 node /usr/local/bin/Claude --session-id 42
+  200     1 -zsh
+  201   200 bash -c npm test
+  300     1 claude --resume other
+  301   300 -zsh
+  400     1 /bin/zsh -ic claude --resume 42
+  401   400 bash -c npm test
+`)
+	claude := detectorFor("claude")
+
+	if !treeRunsTool(procs, 100, claude) {
+		t.Error("claude under a bash -c wrapper should count as running")
+	}
+	if treeRunsTool(procs, 200, claude) {
+		t.Error("a shell running only a test command has no agent")
+	}
+	if treeRunsTool(procs, 301, claude) {
+		t.Error("an agent above the pane's shell is not the pane's agent")
+	}
+	if !treeRunsTool(procs, 400, claude) {
+		t.Error("an agent that is the pane's own process should count as running")
+	}
+}
@@ -29,8 +29,8 @@ func (s *Session) Attach(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
-	// Start tmux attach command with PTY
-	cmd := exec.CommandContext(ctx, "tmux", "attach-session", "-t", s.Name)
+	// Start attach command with PTY
+	cmd := backend.AttachCommand(ctx, s.Name)
 
 	// Start command with PTY
 	ptmx, err := pty.Start(cmd)
@@ -183,9 +183,11 @@ func (s *Session) Attach(ctx context.Context) error {
 
 // Resize changes the terminal size of the tmux session
 func (s *Session) Resize(cols, rows int) error {
+	if !isTmux() {
+		return nil
+	}
 	// Resize the tmux window
-	cmd := exec.Command("tmux", "resize-window", "-t", s.Name, "-x", fmt.Sprintf("%d", cols), "-y", fmt.Sprintf("%d", rows))
-	if err := cmd.Run(); err != nil {
+	if err := tmuxRun("resize-window", "-t", s.Name, "-x", fmt.Sprintf("%d", cols), "-y", fmt.Sprintf("%d", rows)); err != nil {
 		return fmt.Errorf("failed to resize window: %w", err)
 	}
 	return nil
@@ -193,6 +195,9 @@ func (s *Session) Resize(cols, rows int) error {
 
 // AttachReadOnly attaches to the session in read-only mode
 func (s *Session) AttachReadOnly(ctx context.Context) error {
+	if !isTmux() {
+		return fmt.Errorf("read-only attach not supported by %s", backend.Name())
+	}
 	if !s.Exists() {
 		return fmt.Errorf("session %s does not exist", s.Name)
 	}
@@ -205,7 +210,7 @@ func (s *Session) AttachReadOnly(ctx context.Context) error {
 	defer func() { _ = term.Restore(int(os.Stdin.Fd()), oldState) }()
 
 	// Start tmux attach command in read-only mode
-	cmd := exec.CommandContext(ctx, "tmux", "attach-session", "-r", "-t", s.Name)
+	cmd := CommandContext(ctx, "attach-session", "-r", "-t", s.Name)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -231,12 +236,15 @@ func (s *Session) AttachReadOnly(ctx context.Context) error {
 
 // StreamOutput streams the session output to the provided writer
 func (s *Session) StreamOutput(ctx context.Context, w io.Writer) error {
+	if !isTmux() {
+		return fmt.Errorf("output streaming not supported by %s", backend.Name())
+	}
 	if !s.Exists() {
 		return fmt.Errorf("session %s does not exist", s.Name)
 	}
 
 	// Use tmux pipe-pane to stream output
-	cmd := exec.CommandContext(ctx, "tmux", "pipe-pane", "-t", s.Name, "-o", "cat")
+	cmd := CommandContext(ctx, "pipe-pane", "-t", agentPaneTarget(s.Name), "-o", "cat")
 	cmd.Stdout = w
 	cmd.Stderr = os.Stderr
 
@@ -258,8 +266,7 @@ func (s *Session) StreamOutput(ctx context.Context, w io.Writer) error {
 	case <-ctx.Done():
 		// Stop pipe-pane - error is intentionally ignored since we're
 		// already returning ctx.Err() and cleanup failure is non-fatal
-		stopCmd := exec.Command("tmux", "pipe-pane", "-t", s.Name)
-		_ = stopCmd.Run()
+		_ = tmuxRun("pipe-pane", "-t", agentPaneTarget(s.Name))
 		// Wait for the goroutine to complete before returning
 		wg.Wait()
 		return ctx.Err()
@@ -0,0 +1,135 @@
+package tmux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// screenBackend runs sessions in GNU screen, for hosts where tmux can't be
+// installed. Commands go to window 0 of the named session via -X.
+type screenBackend struct{}
+
+// screenHardcopyTimeout bounds how long Capture waits for the hardcopy file:
+// -X only queues the command, and the screen server writes it later.
+const screenHardcopyTimeout = 2 * time.Second
+
+// screenStuffEscaper protects text sent with "stuff", whose argument screen
+// parses for ^X control notation, backslash escapes and $VAR expansion.
+var screenStuffEscaper = strings.NewReplacer(`\`, `\\`, `^`, `\^`, `$`, `\$`)
+
+func screenCommand(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "screen", append([]string{"-S", name, "-p", "0", "-X"}, args...)...)
+}
+
+// screenCmd builds a screenCommand for runBounded.
+func screenCmd(name string, args ...string) func(ctx context.Context) *exec.Cmd {
+	return func(ctx context.Context) *exec.Cmd { return screenCommand(ctx, name, args...) }
+}
+
+func (screenBackend) Name() string { return BackendScreen }
+
+func (screenBackend) Create(name, workDir string) error {
+	output, err := runBounded(false, func(ctx context.Context) *exec.Cmd {
+		cmd := exec.CommandContext(ctx, "screen", "-dmS", name)
+		cmd.Dir = workDir
+		// Inside screen, STY would make the new session a window of the current one
+		cmd.Env = environWithout(os.Environ(), "STY", "WINDOW")
+		return cmd
+	}, (*exec.Cmd).CombinedOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create screen session: %w (output: %s)", err, string(output))
+	}
+	return nil
+}
+
+func (screenBackend) Kill(name string) error {
+	_, err := runBounded(false, screenCmd(name, "quit"), runCmd)
+	return err
+}
+
+func (screenBackend) Exists(name string) bool {
+	// screen -ls exits non-zero even when it lists sessions, so only the output counts
+	output, _ := execQuery("screen", "-ls", name)
+	return screenSessionListed(string(output), name)
+}
+
+// screenSessionListed reports whether screen -ls output contains a live
+// session called name. Entries look like "<pid>.<name>\t(...)\t(Detached)".
+func screenSessionListed(output, name string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.Contains(line, "(Dead") {
+			continue
+		}
+		if _, session, ok := strings.Cut(fields[0], "."); ok && session == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (screenBackend) Capture(ctx context.Context, name string, historyLines int) (string, error) {
+	f, err := os.CreateTemp("", "agentdeck-screen-*.txt")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	args := []string{"hardcopy"}
+	if historyLines > 0 {
+		args = append(args, "-h")
+	}
+	if output, err := screenCommand(ctx, name, append(args, path)...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	data, err := readHardcopy(ctx, path, screenHardcopyTimeout, 20*time.Millisecond)
+	if err != nil {
+		return "", err
+	}
+	return lastLines(string(data), historyLines), nil
+}
+
+// readHardcopy reads the file at path once it has content and its size
+// held steady for one poll interval, failing after timeout.
+func readHardcopy(ctx context.Context, path string, timeout, interval time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastSize := int64(-1)
+	for {
+		if info, err := os.Stat(path); err == nil && info.Size() > 0 {
+			if info.Size() == lastSize {
+				return os.ReadFile(path)
+			}
+			lastSize = info.Size()
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("screen didn't write the hardcopy: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func (screenBackend) SendKeys(name, keys string) error {
+	_, err := runBounded(false, screenCmd(name, "stuff", screenStuffEscaper.Replace(keys)), runCmd)
+	return err
+}
+
+func (screenBackend) SendEnter(name string) error {
+	_, err := runBounded(false, screenCmd(name, "stuff", "^M"), runCmd)
+	return err
+}
+
+func (screenBackend) AttachCommand(ctx context.Context, name string) *exec.Cmd {
+	// -x attaches even when another terminal is already attached
+	return exec.CommandContext(ctx, "screen", "-x", name)
+}
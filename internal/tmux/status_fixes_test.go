@@ -1148,6 +1148,28 @@ func TestSpinnerActivityTracker_GracePeriodExpiry(t *testing.T) {
 	}
 }
 
+func TestSession_SetActivityCooldown(t *testing.T) {
+	s := NewSession("cooldown-test", "/tmp")
+	s.SetActivityCooldown(2 * time.Second)
+
+	s.mu.Lock()
+	s.ensureStateTrackerLocked()
+	sat := s.stateTracker.spinnerTracker
+	s.mu.Unlock()
+
+	sat.MarkBusy()
+	sat.lastBusyTime = time.Now().Add(-3 * time.Second)
+	if sat.InGracePeriod() {
+		t.Error("Expected InGracePeriod=false after a 2s cooldown expired")
+	}
+
+	// Changing the cooldown updates an existing tracker; zero restores the default
+	s.SetActivityCooldown(0)
+	if !sat.InGracePeriod() {
+		t.Error("Expected InGracePeriod=true with the default 6s cooldown")
+	}
+}
+
 func TestSpinnerActivityTracker_GracePeriod_BetweenToolCalls(t *testing.T) {
 	sat := NewSpinnerActivityTracker()
 
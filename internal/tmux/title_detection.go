@@ -1,8 +1,6 @@
 package tmux
 
 import (
-	"os/exec"
-	"strings"
 	"sync"
 	"time"
 )
@@ -19,69 +17,28 @@ const (
 	TitleStateDone                      // Done marker detected, fall through to prompt detection
 )
 
-// PaneInfo holds pane title and current command for a tmux session.
+// PaneInfo holds the agent pane's state for a tmux session, as returned by
+// BatchStatus.
 type PaneInfo struct {
 	Title          string
 	CurrentCommand string
+	// PID is the pane's process, usually the shell the agent was started from
+	PID           int
+	Width, Height int
+	// Activity is the most recent window_activity across the session's windows
+	Activity int64
+	// Alert is the last alert recorded by the session's hooks (see AlertPaneDied)
+	Alert string
 }
 
-// Pane info cache - one list-panes call per tick instead of per-session queries.
-// Mirrors the sessionCacheData pattern (tmux.go:38-42).
+// Pane info cache - filled by RefreshSessionCache from the same BatchStatus
+// query as the session cache, instead of per-session queries.
 var (
 	paneCacheMu   sync.RWMutex
 	paneCacheData map[string]PaneInfo
 	paneCacheTime time.Time
 )
 
-// RefreshPaneInfoCache updates the cache of pane titles and commands for all sessions.
-// Call this ONCE per tick (from backgroundStatusUpdate), then use GetCachedPaneInfo()
-// to read cached values. Tries PipeManager first, falls back to subprocess.
-func RefreshPaneInfoCache() {
-	if pm := GetPipeManager(); pm != nil {
-		if info, err := pm.RefreshAllPaneInfo(); err == nil && len(info) > 0 {
-			paneCacheMu.Lock()
-			paneCacheData = info
-			paneCacheTime = time.Now()
-			paneCacheMu.Unlock()
-			return
-		}
-		statusLog.Debug("pane_cache_subprocess_fallback")
-	}
-
-	// Subprocess fallback: list-panes -a
-	cmd := exec.Command("tmux", "list-panes", "-a", "-F", "#{session_name}\t#{pane_title}\t#{pane_current_command}")
-	output, err := cmd.Output()
-	if err != nil {
-		paneCacheMu.Lock()
-		paneCacheData = nil
-		paneCacheTime = time.Time{}
-		paneCacheMu.Unlock()
-		return
-	}
-
-	newCache := make(map[string]PaneInfo)
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 3)
-		if len(parts) != 3 {
-			continue
-		}
-		name := parts[0]
-		// Keep last pane info per session (most sessions have one pane)
-		newCache[name] = PaneInfo{
-			Title:          parts[1],
-			CurrentCommand: parts[2],
-		}
-	}
-
-	paneCacheMu.Lock()
-	paneCacheData = newCache
-	paneCacheTime = time.Now()
-	paneCacheMu.Unlock()
-}
-
 // GetCachedPaneInfo returns cached pane info for a session.
 // Returns (info, true) if found and cache is fresh, (zero, false) otherwise.
 func GetCachedPaneInfo(sessionName string) (PaneInfo, bool) {
@@ -96,6 +53,14 @@ func GetCachedPaneInfo(sessionName string) (PaneInfo, bool) {
 	return info, ok
 }
 
+// forgetCachedPaneInfo drops a session's cached pane info, e.g. after its
+// agent pane was respawned, until the next refresh.
+func forgetCachedPaneInfo(sessionName string) {
+	paneCacheMu.Lock()
+	defer paneCacheMu.Unlock()
+	delete(paneCacheData, sessionName)
+}
+
 // AnalyzePaneTitle determines session state from the pane title.
 // Priority: Braille spinner > Done marker > Unknown.
 //
@@ -103,8 +68,8 @@ func GetCachedPaneInfo(sessionName string) (PaneInfo, bool) {
 // Claude Code frequently spawns bash subprocesses for tool execution, and tmux
 // reports that child process as pane_current_command. This means a waiting Claude
 // session often shows "bash" as the command, making it indistinguishable from
-// "Claude exited and shell is showing". The existing Exists() check handles
-// truly dead sessions reliably.
+// "Claude exited and shell is showing". AgentExited tells the two apart from
+// the pane's process tree.
 func AnalyzePaneTitle(title, _ string) TitleState {
 	if title == "" {
 		return TitleStateUnknown
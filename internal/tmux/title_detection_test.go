@@ -2,6 +2,7 @@ package tmux
 
 import (
 	"os/exec"
+	"strings"
 	"testing"
 	"time"
 )
@@ -108,7 +109,7 @@ func TestAnalyzePaneTitle(t *testing.T) {
 	}
 }
 
-func TestRefreshPaneInfoCache(t *testing.T) {
+func TestRefreshSessionCache_PaneInfo(t *testing.T) {
 	skipIfNoTmuxServer(t)
 
 	// Create a test session to ensure there's at least one pane
@@ -122,7 +123,7 @@ func TestRefreshPaneInfoCache(t *testing.T) {
 	}()
 
 	// Refresh the cache
-	RefreshPaneInfoCache()
+	RefreshSessionCache()
 
 	// Verify cache was populated
 	info, ok := GetCachedPaneInfo(sessName)
@@ -134,6 +135,9 @@ func TestRefreshPaneInfoCache(t *testing.T) {
 	if info.CurrentCommand == "" {
 		t.Error("Expected non-empty CurrentCommand for test session")
 	}
+	if info.Width == 0 || info.Height == 0 || info.Activity == 0 {
+		t.Errorf("Expected size and activity for test session, got %+v", info)
+	}
 
 	// Verify a non-existent session is not in cache
 	_, ok = GetCachedPaneInfo("nonexistent_session_xyz")
@@ -142,6 +146,35 @@ func TestRefreshPaneInfoCache(t *testing.T) {
 	}
 }
 
+func TestParseBatchStatus(t *testing.T) {
+	output := strings.Join([]string{
+		// Agent pane, then a side pane with newer activity in a second window
+		"agentdeck_api_1\t100\t11\t120\t40\tclaude\t4242\t0\t\t⠋ Working\tmore",
+		"agentdeck_api_1\t100\t01\t60\t40\tzsh\t4243\t0\t\tside",
+		"agentdeck_api_1\t250\t11\t80\t20\tvim\t4244\t0\t\teditor",
+		// Pre-3.0 tmux leaves pane_at_* empty
+		"old\t7\t\t80\t24\tbash\t99\t1\tpane-died\t",
+		// Dead pane whose pane-died hook never ran
+		"missed\t5\t11\t80\t24\tclaude\t77\t1\t\t",
+		"garbage line",
+	}, "\n")
+
+	got := parseBatchStatus(output)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 sessions, got %v", got)
+	}
+	want := PaneInfo{Title: "⠋ Working\tmore", CurrentCommand: "claude", PID: 4242, Width: 120, Height: 40, Activity: 250}
+	if got["agentdeck_api_1"] != want {
+		t.Errorf("agentdeck_api_1 = %+v, want %+v", got["agentdeck_api_1"], want)
+	}
+	if info := got["old"]; info.CurrentCommand != "bash" || info.Activity != 7 || info.Alert != AlertPaneDied {
+		t.Errorf("old = %+v", info)
+	}
+	if info := got["missed"]; info.Alert != AlertPaneDied {
+		t.Errorf("missed = %+v, want the dead pane reported as %s", info, AlertPaneDied)
+	}
+}
+
 func TestGetCachedPaneInfo_StaleCache(t *testing.T) {
 	// Set cache data with a time far in the past (stale)
 	paneCacheMu.Lock()
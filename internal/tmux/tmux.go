@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -32,6 +33,99 @@ var ErrCaptureTimeout = errors.New("capture-pane timed out")
 
 const SessionPrefix = "agentdeck_"
 
+// sessionNamespace isolates tmux session names per profile. Set once at
+// startup, before any session is created.
+var sessionNamespace string
+
+// SetSessionNamespace makes new sessions use "agentdeck_<ns>_" as their name
+// prefix so sessions from different profiles are distinguishable in tmux.
+// An empty namespace keeps the plain "agentdeck_" prefix.
+func SetSessionNamespace(ns string) {
+	if ns != "" {
+		ns = sanitizeName(ns)
+	}
+	sessionNamespace = ns
+}
+
+// SessionNamespace returns the namespace set by SetSessionNamespace.
+func SessionNamespace() string {
+	return sessionNamespace
+}
+
+// socketName selects a dedicated tmux server (tmux -L). Set once at startup,
+// before any tmux command runs.
+var socketName string
+
+var invalidSocketChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// SetSocketName runs every agent-deck tmux command against the named socket
+// (tmux -L <name>), keeping agent-deck sessions on their own server apart from
+// the user's personal one. An empty name uses the default server. Characters
+// other than letters, digits, ".", "_" and "-" are replaced with "-".
+func SetSocketName(name string) {
+	socketName = invalidSocketChars.ReplaceAllString(strings.TrimSpace(name), "-")
+}
+
+// SocketName returns the socket set by SetSocketName ("" for the default server).
+func SocketName() string {
+	return socketName
+}
+
+// Command returns a tmux command that talks to agent-deck's tmux server.
+// It has no timeout, so it suits long-running commands like attach; one-shot
+// commands inside this package go through tmuxRun and tmuxQuery instead.
+func Command(args ...string) *exec.Cmd {
+	return exec.Command("tmux", socketArgs(args)...)
+}
+
+// CommandContext is Command with a context.
+func CommandContext(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, "tmux", socketArgs(args)...)
+}
+
+// ShellCommand returns the tmux invocation for use in shell strings, such as
+// run-shell key bindings.
+func ShellCommand() string {
+	if socketName == "" {
+		return "tmux"
+	}
+	return "tmux -L " + socketName
+}
+
+func socketArgs(args []string) []string {
+	if socketName == "" {
+		return args
+	}
+	return append([]string{"-L", socketName}, args...)
+}
+
+// ParseSessionName splits an agent-deck tmux session name into its profile
+// namespace ("" for the default profile) and sanitized title. ok is false for
+// names that don't start with SessionPrefix. Sanitized titles and namespaces
+// never contain "_", so the parts are unambiguous.
+func ParseSessionName(name string) (namespace, title string, ok bool) {
+	rest, ok := strings.CutPrefix(name, SessionPrefix)
+	if !ok {
+		return "", "", false
+	}
+	// <title>_<id> or <namespace>_<title>_<id>
+	parts := strings.Split(rest, "_")
+	if len(parts) <= 2 {
+		return "", parts[0], true
+	}
+	return parts[0], strings.Join(parts[1:len(parts)-1], "_"), true
+}
+
+// newSessionName builds a unique tmux session name for a display name.
+func newSessionName(displayName string) string {
+	prefix := SessionPrefix
+	if sessionNamespace != "" {
+		prefix += sessionNamespace + "_"
+	}
+	// Unique suffix prevents name collisions
+	return prefix + sanitizeName(displayName) + "_" + generateShortID()
+}
+
 // Session cache - reduces subprocess spawns from O(n) to O(1) per tick
 // Instead of calling `tmux has-session` and `tmux display-message` for each session,
 // we call `tmux list-sessions` ONCE and cache both existence and activity timestamps
@@ -41,62 +135,109 @@ var (
 	sessionCacheTime time.Time
 )
 
-// RefreshSessionCache updates the cache of existing tmux sessions and their activity
-// Call this ONCE per tick, then use Session.Exists() and Session.GetWindowActivity()
-// which read from cache. This reduces 30+ subprocess spawns to just 1 per tick cycle.
-//
-// Tries PipeManager first (zero subprocess), falls back to subprocess.
+// RefreshSessionCache updates the caches of existing tmux sessions, their
+// activity and their agent pane info from a single BatchStatus query.
+// Call this ONCE per tick, then use Session.Exists(), Session.GetWindowActivity()
+// and GetCachedPaneInfo() which read from cache. This reduces 30+ subprocess
+// spawns to at most 1 per tick cycle.
 //
 // NOTE: We use window_activity (not session_activity) because window_activity updates
 // when there's actual terminal output, while session_activity only updates on
 // session-level events. This is critical for detecting when Claude is actively working.
 func RefreshSessionCache() {
-	// Try control mode pipe first (zero subprocess)
+	// Only tmux is cached; other backends answer Exists() directly
+	if !isTmux() {
+		return
+	}
+
+	panes, err := BatchStatus()
+	var activities map[string]int64
+	if err == nil {
+		activities = make(map[string]int64, len(panes))
+		for name, info := range panes {
+			activities[name] = info.Activity
+		}
+	}
+
+	now := time.Now()
+	if err != nil {
+		now = time.Time{}
+	}
+	sessionCacheMu.Lock()
+	sessionCacheData = activities
+	sessionCacheTime = now
+	sessionCacheMu.Unlock()
+
+	paneCacheMu.Lock()
+	paneCacheData = panes
+	paneCacheTime = now
+	paneCacheMu.Unlock()
+}
+
+// batchStatusFormat lists, per pane: session, window activity, whether it is
+// the top-left (agent) pane, size, current command, pid, whether the process
+// has exited, the session's hook alert and title. The title is last because it is the only field that can contain
+// tabs.
+const batchStatusFormat = "#{session_name}\t#{window_activity}\t#{pane_at_top}#{pane_at_left}\t" +
+	"#{pane_width}\t#{pane_height}\t#{pane_current_command}\t#{pane_pid}\t#{pane_dead}\t#{" + alertOption + "}\t#{pane_title}"
+
+// BatchStatus returns the state of every tmux session on agent-deck's server
+// from one `list-panes -a` query, sent through a control mode pipe when one is
+// connected (zero subprocess). Keyed by session name; see PaneInfo.
+func BatchStatus() (map[string]PaneInfo, error) {
 	if pm := GetPipeManager(); pm != nil {
-		if activities, err := pm.RefreshAllActivities(); err == nil && len(activities) > 0 {
-			sessionCacheMu.Lock()
-			sessionCacheData = activities
-			sessionCacheTime = time.Now()
-			sessionCacheMu.Unlock()
-			return
+		// tmux control mode requires double-quoted format strings containing special chars
+		output, err := pm.SendCommand(`list-panes -a -F "` + batchStatusFormat + `"`)
+		if err == nil {
+			return parseBatchStatus(output), nil
 		}
 		// Pipe failed: log it so we can verify zero subprocess usage
-		statusLog.Debug("refresh_cache_subprocess_fallback")
+		statusLog.Debug("batch_status_subprocess_fallback", slog.String("error", err.Error()))
 	}
 
-	// Subprocess fallback: list-windows -a
-	cmd := exec.Command("tmux", "list-windows", "-a", "-F", "#{session_name}\t#{window_activity}")
-	output, err := cmd.Output()
+	output, err := tmuxQuery("list-panes", "-a", "-F", batchStatusFormat)
 	if err != nil {
-		sessionCacheMu.Lock()
-		sessionCacheData = nil
-		sessionCacheTime = time.Time{}
-		sessionCacheMu.Unlock()
-		return
+		return nil, err
 	}
+	return parseBatchStatus(string(output)), nil
+}
 
-	newCache := make(map[string]int64)
-	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) != 2 {
+// parseBatchStatus parses list-panes output in batchStatusFormat.
+func parseBatchStatus(output string) map[string]PaneInfo {
+	result := make(map[string]PaneInfo)
+	seenAgentPane := make(map[string]bool)
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		parts := strings.SplitN(line, "\t", 10)
+		if len(parts) != 10 {
 			continue
 		}
 		name := parts[0]
+		info := result[name]
+
 		var activity int64
 		_, _ = fmt.Sscanf(parts[1], "%d", &activity) // ignore error, 0 is valid default
 		// Keep maximum activity (most recent) if session has multiple windows
-		if existing, ok := newCache[name]; !ok || activity > existing {
-			newCache[name] = activity
+		info.Activity = max(info.Activity, activity)
+
+		// First top-left pane, i.e. the agent pane of the first window. tmux
+		// before 3.0 leaves pane_at_* empty; take the first pane then.
+		if atTopLeft := parts[2] == "11" || parts[2] == ""; atTopLeft && !seenAgentPane[name] {
+			seenAgentPane[name] = true
+			info.Width, _ = strconv.Atoi(parts[3])
+			info.Height, _ = strconv.Atoi(parts[4])
+			info.CurrentCommand = parts[5]
+			info.PID, _ = strconv.Atoi(parts[6])
+			info.Alert = parts[8]
+			// tmux 3.3 sometimes skips pane-died for a process that exits
+			// right after starting; the dead pane itself is authoritative
+			if parts[7] == "1" && info.Alert == "" {
+				info.Alert = AlertPaneDied
+			}
+			info.Title = parts[9]
 		}
+		result[name] = info
 	}
-
-	sessionCacheMu.Lock()
-	sessionCacheData = newCache
-	sessionCacheTime = time.Now()
-	sessionCacheMu.Unlock()
+	return result
 }
 
 // RefreshExistingSessions is an alias for RefreshSessionCache for backwards compatibility
@@ -153,11 +294,17 @@ func sessionActivityFromCache(name string) (int64, bool) {
 	return activity, true
 }
 
-// IsTmuxAvailable checks if tmux is installed and accessible
-// Returns nil if tmux is available, otherwise returns an error with details
+// IsTmuxAvailable checks if the active multiplexer (tmux unless configured
+// otherwise) is installed and accessible
+// Returns nil if it is available, otherwise returns an error with details
 func IsTmuxAvailable() error {
-	cmd := exec.Command("tmux", "-V")
-	output, err := cmd.CombinedOutput()
+	if !isTmux() {
+		if _, err := exec.LookPath(backend.Name()); err != nil {
+			return fmt.Errorf("%s not found: %w", backend.Name(), err)
+		}
+		return nil
+	}
+	output, err := tmuxCombinedOutput("-V")
 	if err != nil {
 		return fmt.Errorf("tmux not found or not working: %w (output: %s)", err, string(output))
 	}
@@ -319,26 +466,6 @@ func SupportsHyperlinks() bool {
 	return GetTerminalInfo().SupportsOSC8
 }
 
-// Tool detection patterns (used by DetectTool for initial tool identification)
-var toolDetectionPatterns = map[string][]*regexp.Regexp{
-	"claude": {
-		regexp.MustCompile(`(?i)claude`),
-		regexp.MustCompile(`(?i)anthropic`),
-	},
-	"gemini": {
-		regexp.MustCompile(`(?i)gemini`),
-		regexp.MustCompile(`(?i)google ai`),
-	},
-	"opencode": {
-		regexp.MustCompile(`(?i)opencode`),
-		regexp.MustCompile(`(?i)open code`),
-	},
-	"codex": {
-		regexp.MustCompile(`(?i)codex`),
-		regexp.MustCompile(`(?i)openai`),
-	},
-}
-
 // StateTracker tracks content changes for notification-style status detection
 //
 // StateTracker implements a simple 3-state model:
@@ -374,10 +501,13 @@ type SpinnerActivityTracker struct {
 	gracePeriod  time.Duration // how long to stay busy after spinner disappears (default: 6s)
 }
 
+// DefaultActivityCooldown is the default spinner grace period.
+const DefaultActivityCooldown = 6 * time.Second // cover 3 polls (2s each) of spinner absence
+
 // NewSpinnerActivityTracker creates a tracker with default grace period.
 func NewSpinnerActivityTracker() *SpinnerActivityTracker {
 	return &SpinnerActivityTracker{
-		gracePeriod: 6 * time.Second, // cover 3 polls (2s each) of spinner absence
+		gracePeriod: DefaultActivityCooldown,
 	}
 }
 
@@ -458,6 +588,16 @@ type Session struct {
 	cacheTime    time.Time
 	captureSf    singleflight.Group // Deduplicates concurrent CapturePane subprocess calls
 
+	// Scrollback captures for the preview, reused while status checks see
+	// the pane unchanged (see cachedHistory). Protected by cacheMu.
+	historyCache historyCapture
+	styledCache  historyCapture
+
+	// Pane content hash from the latest GetStatus that computed one, and
+	// when. Protected by mu.
+	contentHash   string
+	contentHashAt time.Time
+
 	// Content tracking for HasUpdated (separate from StateTracker)
 	lastHash    string
 	lastContent string
@@ -479,6 +619,19 @@ type Session struct {
 	// Example: {"allow-passthrough": "all", "history-limit": "50000"}
 	OptionOverrides map[string]string
 
+	// SidePanes are commands opened in panes next to the agent when the
+	// session starts (e.g. a test watcher). Each pane gets a shell first, so
+	// one-shot commands like "git status" leave the pane open.
+	SidePanes []string
+
+	// PaneLayout is the tmux layout applied when SidePanes is set
+	// (default "main-vertical": agent on the left, side panes stacked right).
+	PaneLayout string
+
+	// LogOutput appends the agent pane's output to LogFile() via tmux
+	// pipe-pane when the session starts, for a LogWatcher to pick up.
+	LogOutput bool
+
 	// Custom patterns for generic tool support
 	customToolName       string
 	customBusyPatterns   []string
@@ -489,10 +642,6 @@ type Session struct {
 	// When non-nil, hasBusyIndicator and normalizeContent use these instead of hardcoded values
 	resolvedPatterns *ResolvedPatterns
 
-	// Cached PromptDetector (avoids allocating a new one on every hasPromptIndicator call)
-	cachedPromptDetector     *PromptDetector
-	cachedPromptDetectorTool string
-
 	// Environment variable cache (reduces tmux show-environment subprocess spawns)
 	envCache   map[string]envCacheEntry
 	envCacheMu sync.RWMutex
@@ -501,6 +650,14 @@ type Session struct {
 	// When false, the status bar configuration is skipped entirely.
 	// Default: true (set via SetInjectStatusLine from user config)
 	injectStatusLine bool
+
+	// activityCooldown overrides the spinner grace period (0 = default 6s).
+	// Set via SetActivityCooldown from user config or per-session override.
+	activityCooldown time.Duration
+
+	// Last AgentExited result, reused for agentExitRecheck
+	agentExited        bool
+	agentExitCheckedAt time.Time
 }
 
 type envCacheEntry struct {
@@ -520,6 +677,8 @@ func (s *Session) invalidateCache() {
 	defer s.cacheMu.Unlock()
 	s.cacheContent = ""
 	s.cacheTime = time.Time{}
+	s.historyCache = historyCapture{}
+	s.styledCache = historyCapture{}
 }
 
 // ensureStateTrackerLocked lazily allocates the tracker so callers can safely
@@ -531,12 +690,12 @@ func (s *Session) ensureStateTrackerLocked() {
 			lastHash:       "",
 			lastChangeTime: time.Now(),
 			acknowledged:   false,
-			spinnerTracker: NewSpinnerActivityTracker(),
+			spinnerTracker: s.newSpinnerTrackerLocked(),
 		}
 	}
 	// Ensure spinnerTracker exists even for older StateTrackers
 	if s.stateTracker.spinnerTracker == nil {
-		s.stateTracker.spinnerTracker = NewSpinnerActivityTracker()
+		s.stateTracker.spinnerTracker = s.newSpinnerTrackerLocked()
 	}
 }
 
@@ -597,6 +756,32 @@ func (s *Session) SetDetectPatterns(toolName string, detectPatterns []string) {
 	s.customDetectPatterns = detectPatterns
 }
 
+// SetActivityCooldown sets how long the session stays busy after its spinner
+// disappears (the spinner grace period). Zero restores the default.
+func (s *Session) SetActivityCooldown(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activityCooldown = d
+	if s.stateTracker != nil && s.stateTracker.spinnerTracker != nil {
+		s.stateTracker.spinnerTracker.gracePeriod = s.activityCooldownLocked()
+	}
+}
+
+// activityCooldownLocked returns the effective spinner grace period.
+// MUST be called with mu held.
+func (s *Session) activityCooldownLocked() time.Duration {
+	if s.activityCooldown > 0 {
+		return s.activityCooldown
+	}
+	return DefaultActivityCooldown
+}
+
+// newSpinnerTrackerLocked creates a spinner tracker using this session's cooldown.
+// MUST be called with mu held.
+func (s *Session) newSpinnerTrackerLocked() *SpinnerActivityTracker {
+	return &SpinnerActivityTracker{gracePeriod: s.activityCooldownLocked()}
+}
+
 // SetInjectStatusLine controls whether ConfigureStatusBar modifies tmux settings.
 // When set to false, the status bar is left unchanged, preserving user's tmux config.
 func (s *Session) SetInjectStatusLine(inject bool) {
@@ -605,33 +790,43 @@ func (s *Session) SetInjectStatusLine(inject bool) {
 	s.injectStatusLine = inject
 }
 
-// LogFile returns the path to this session's log file
-// Logs are stored in ~/.agent-deck/logs/<session-name>.log
-func (s *Session) LogFile() string {
+// dataDirOverride is agent-deck's data directory. Set once at startup,
+// before any session runs.
+var dataDirOverride string
+
+// SetDataDir sets agent-deck's data directory, which holds session logs
+// and the ack signal (default: ~/.agent-deck).
+func SetDataDir(dir string) {
+	dataDirOverride = dir
+}
+
+// dataDir returns the directory set by SetDataDir, or ~/.agent-deck.
+func dataDir() string {
+	if dataDirOverride != "" {
+		return dataDirOverride
+	}
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
 		homeDir = "/tmp"
 	}
-	logDir := filepath.Join(homeDir, ".agent-deck", "logs")
-	return filepath.Join(logDir, s.Name+".log")
+	return filepath.Join(homeDir, ".agent-deck")
+}
+
+// LogFile returns the path to this session's log file
+// Logs are stored in <data dir>/logs/<session-name>.log
+func (s *Session) LogFile() string {
+	return filepath.Join(LogDir(), s.Name+".log")
 }
 
 // LogDir returns the directory containing all session logs
 func LogDir() string {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		homeDir = "/tmp"
-	}
-	return filepath.Join(homeDir, ".agent-deck", "logs")
+	return filepath.Join(dataDir(), "logs")
 }
 
 // NewSession creates a new Session instance with a unique name
 func NewSession(name, workDir string) *Session {
-	sanitized := sanitizeName(name)
-	// Add unique suffix to prevent name collisions
-	uniqueSuffix := generateShortID()
 	return &Session{
-		Name:             SessionPrefix + sanitized + "_" + uniqueSuffix,
+		Name:             newSessionName(name),
 		DisplayName:      name,
 		WorkDir:          workDir,
 		Created:          time.Now(),
@@ -765,8 +960,8 @@ func (s *Session) EnsureConfigured() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Already configured or session doesn't exist - nothing to do
-	if s.configured || !s.Exists() {
+	// Already configured, not tmux, or session doesn't exist - nothing to do
+	if s.configured || !isTmux() || !s.Exists() {
 		return
 	}
 
@@ -798,8 +993,10 @@ func generateShortID() string {
 
 // SetEnvironment sets an environment variable for this tmux session
 func (s *Session) SetEnvironment(key, value string) error {
-	cmd := exec.Command("tmux", "set-environment", "-t", s.Name, key, value)
-	err := cmd.Run()
+	if !isTmux() {
+		return fmt.Errorf("session environment not supported by %s", backend.Name())
+	}
+	err := tmuxRun("set-environment", "-t", s.Name, key, value)
 	if err == nil {
 		// Invalidate cache entry so next GetEnvironment sees the new value
 		s.envCacheMu.Lock()
@@ -825,13 +1022,16 @@ func (s *Session) GetEnvironment(key string) (string, error) {
 	}
 	s.envCacheMu.RUnlock()
 
-	cmd := exec.Command("tmux", "show-environment", "-t", s.Name, key)
-	output, err := cmd.Output()
+	if !isTmux() {
+		return "", fmt.Errorf("session environment not supported by %s", backend.Name())
+	}
+
+	output, err := s.showEnvironment(key)
 	if err != nil {
 		return "", fmt.Errorf("variable not found or session doesn't exist: %s", key)
 	}
 	// Output format: "KEY=value\n"
-	line := strings.TrimSpace(string(output))
+	line := strings.TrimSpace(output)
 	prefix := key + "="
 	if strings.HasPrefix(line, prefix) {
 		value := strings.TrimPrefix(line, prefix)
@@ -847,6 +1047,20 @@ func (s *Session) GetEnvironment(key string) (string, error) {
 	return "", fmt.Errorf("variable not found: %s", key)
 }
 
+// showEnvironment runs show-environment through the control mode pipe when
+// one is connected (zero subprocess), otherwise as a subprocess.
+func (s *Session) showEnvironment(key string) (string, error) {
+	if pm := GetPipeManager(); pm != nil {
+		output, err := pm.GetEnvironment(s.Name, key)
+		// A tmux error (e.g. unknown variable) is the answer; only pipe failures fall back
+		if err == nil || errors.Is(err, errCommandFailed) {
+			return output, err
+		}
+	}
+	output, err := tmuxQuery("show-environment", "-t", s.Name, key)
+	return string(output), err
+}
+
 // InvalidateEnvCache clears the environment variable cache for this session.
 // Should be called after SetEnvironment to ensure fresh reads.
 func (s *Session) InvalidateEnvCache() {
@@ -871,15 +1085,12 @@ func (s *Session) Start(command string) error {
 	s.mu.Lock()
 	s.lastStableStatus = "waiting"
 	s.stateTracker = nil
-	s.cachedPromptDetector = nil
-	s.cachedPromptDetectorTool = ""
 	s.mu.Unlock()
 
 	// Check if session already exists (shouldn't happen with unique IDs, but handle gracefully)
 	if s.Exists() {
 		// Session with this exact name exists - regenerate with new unique suffix
-		sanitized := sanitizeName(s.DisplayName)
-		s.Name = SessionPrefix + sanitized + "_" + generateShortID()
+		s.Name = newSessionName(s.DisplayName)
 	}
 
 	// Ensure working directory exists
@@ -888,17 +1099,60 @@ func (s *Session) Start(command string) error {
 		workDir = os.Getenv("HOME")
 	}
 
-	// Create new tmux session in detached mode
-	cmd := exec.Command("tmux", "new-session", "-d", "-s", s.Name, "-c", workDir)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return fmt.Errorf("failed to create tmux session: %w (output: %s)", err, string(output))
+	// Create new session in detached mode
+	if err := backend.Create(s.Name, workDir); err != nil {
+		return err
 	}
 
 	// Register session in cache immediately to prevent race condition
 	// where Exists() returns false because cache was refreshed before session creation
 	registerSessionInCache(s.Name)
 
+	if isTmux() {
+		s.configureTmuxSession(workDir)
+	}
+
+	// Send the command to the session
+	if command != "" {
+		cmdToSend := command
+		// IMPORTANT: Commands containing bash-specific syntax (like `session_id=$(...)`)
+		// must be wrapped in `bash -c` for fish shell compatibility (#47).
+		// Fish uses different syntax: `set var (...)` instead of `var=$(...)`.
+		if strings.Contains(command, "$(") || strings.Contains(command, "session_id=") {
+			// Escape single quotes in the command for bash -c wrapper
+			escapedCmd := strings.ReplaceAll(command, "'", "'\"'\"'")
+			cmdToSend = fmt.Sprintf("bash -c '%s'", escapedCmd)
+		}
+		if err := s.SendKeysAndEnter(cmdToSend); err != nil {
+			return fmt.Errorf("failed to send command: %w", err)
+		}
+	}
+
+	// Connect control mode pipe for event-driven status detection
+	if pm := GetPipeManager(); pm != nil {
+		if err := pm.Connect(s.Name); err != nil {
+			statusLog.Debug("control_pipe_connect_failed", slog.String("session", s.Name), slog.String("error", err.Error()))
+		}
+	}
+
+	// Note: We tried using tmux hooks for instant GREEN status detection:
+	// - alert-activity: Only fires for background windows (not current window)
+	// - after-send-keys: Fires for ALL send-keys calls (too noisy, catches agent-deck operations)
+	// Neither works reliably for detecting user input. We use polling for GREEN instead.
+	// The Stop hook (via Claude settings) handles instant YELLOW detection.
+
+	return nil
+}
+
+// configureTmuxSession applies agent-deck's tmux options, the user's
+// overrides, the status bar and side panes to a freshly created session.
+func (s *Session) configureTmuxSession(workDir string) {
+	historyLimit := defaultHistoryLimit
+	if limit, ok := s.OptionOverrides["history-limit"]; ok {
+		historyLimit = limit
+	}
+	s.applyHistoryLimit(workDir, historyLimit)
+
 	// PERFORMANCE: Batch all session options into a single subprocess call.
 	// Before: 7 separate exec.Command calls = 7 subprocess spawns (~50-70ms)
 	// After:  1 exec.Command call = 1 subprocess spawn (~7-10ms)
@@ -908,74 +1162,190 @@ func (s *Session) Start(command string) error {
 	// - mouse on: Mouse scrolling, text selection, pane resizing
 	// - allow-passthrough on: OSC 8 hyperlinks, OSC 52 clipboard (tmux 3.2+, -q for older)
 	// - set-clipboard on: Clipboard integration (Warp, iTerm2, kitty, etc.)
-	// - history-limit 10000: Large scrollback for AI agent output
+	// - history-limit 10000: Large scrollback for AI agent output (or the user's override)
 	// - escape-time 10: Fast Vim/editor responsiveness (default 500ms is too slow)
 	// - terminal-features hyperlinks: Track hyperlinks like colors (tmux 3.4+, server-wide)
-	_ = exec.Command("tmux",
-		"set-option", "-t", s.Name, "window-style", "default", ";",
+	_ = tmuxRun("set-option", "-t", s.Name, "window-style", "default", ";",
 		"set-option", "-t", s.Name, "window-active-style", "default", ";",
 		"set-option", "-t", s.Name, "mouse", "on", ";",
 		"set-option", "-t", s.Name, "-q", "allow-passthrough", "on", ";",
 		"set-option", "-t", s.Name, "set-clipboard", "on", ";",
-		"set-option", "-t", s.Name, "history-limit", "10000", ";",
+		"set-option", "-t", s.Name, "history-limit", historyLimit, ";",
 		"set-option", "-t", s.Name, "escape-time", "10", ";",
-		"set", "-asq", "terminal-features", ",*:hyperlinks").Run()
+		"set", "-asq", "terminal-features", ",*:hyperlinks")
+
+	// Configure status bar with session info for easy identification
+	// Shows: session title on left, project folder on right
+	s.ConfigureStatusBar()
 
-	// Apply user-specified tmux option overrides from config (after defaults).
+	// Apply user-specified tmux option overrides from config (after defaults
+	// and the status bar, so status-left/status-right can be overridden too).
 	// These are batched into a single call when multiple overrides are present.
 	if len(s.OptionOverrides) > 0 {
-		args := make([]string, 0, len(s.OptionOverrides)*6)
-		first := true
-		for key, value := range s.OptionOverrides {
-			if !first {
+		keys := make([]string, 0, len(s.OptionOverrides))
+		for key := range s.OptionOverrides {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		args := make([]string, 0, len(keys)*7)
+		for i, key := range keys {
+			if i > 0 {
 				args = append(args, ";")
 			}
-			args = append(args, "set-option", "-t", s.Name, "-q", key, value)
-			first = false
+			args = append(args, "set-option", "-t", s.Name, "-q", key, s.OptionOverrides[key])
 		}
-		_ = exec.Command("tmux", args...).Run()
+		_ = tmuxRun(args...)
 	}
 
-	// Configure status bar with session info for easy identification
-	// Shows: session title on left, project folder on right
-	s.ConfigureStatusBar()
+	s.registerAlertHooks()
+	s.createSidePanes(workDir)
 
-	// Send the command to the session
-	if command != "" {
-		cmdToSend := command
-		// IMPORTANT: Commands containing bash-specific syntax (like `session_id=$(...)`)
-		// must be wrapped in `bash -c` for fish shell compatibility (#47).
-		// Fish uses different syntax: `set var (...)` instead of `var=$(...)`.
-		if strings.Contains(command, "$(") || strings.Contains(command, "session_id=") {
-			// Escape single quotes in the command for bash -c wrapper
-			escapedCmd := strings.ReplaceAll(command, "'", "'\"'\"'")
-			cmdToSend = fmt.Sprintf("bash -c '%s'", escapedCmd)
-		}
-		if err := s.SendKeysAndEnter(cmdToSend); err != nil {
-			return fmt.Errorf("failed to send command: %w", err)
+	if s.LogOutput {
+		if err := s.EnableOutputLog(); err != nil {
+			statusLog.Warn("output_log_enable_failed", slog.String("session", s.Name), slog.String("error", err.Error()))
 		}
 	}
+}
 
-	// Connect control mode pipe for event-driven status detection
+// EnableOutputLog pipes everything the agent pane prints into LogFile().
+// The pipe lives as long as the pane, so it survives agent-deck restarts, and
+// the call is a no-op while a pipe is already open. The file is kept in check
+// by the regular log maintenance (TruncateLargeLogFiles); cat appends, so it
+// carries on at the new end of a truncated file.
+func (s *Session) EnableOutputLog() error {
+	if !isTmux() {
+		return fmt.Errorf("output logging not supported by %s", backend.Name())
+	}
+	logFile := s.LogFile()
+	if err := os.MkdirAll(filepath.Dir(logFile), 0755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+	// pipe-pane replaces an open pipe (and -o would toggle it off), so check first
+	target := agentPaneTarget(s.Name)
+	if piped, err := tmuxQuery("display-message", "-p", "-t", target, "#{pane_pipe}"); err != nil {
+		return fmt.Errorf("failed to query pane: %w", err)
+	} else if strings.TrimSpace(string(piped)) == "1" {
+		return nil
+	}
+	quoted := "'" + strings.ReplaceAll(logFile, "'", "'\\''") + "'"
+	if output, err := tmuxCombinedOutput("pipe-pane", "-t", target, "cat >> "+quoted); err != nil {
+		return fmt.Errorf("pipe-pane failed: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// AlertPaneDied is recorded when the agent pane's process exits.
+const AlertPaneDied = "pane-died"
+
+// alertOption is the session user option the alert hooks write to. Status
+// checks read it through BatchStatus, and control pipes subscribe to it.
+const alertOption = "@agentdeck_alert"
+
+// registerAlertHooks keeps the agent pane open when its process exits and
+// records the exit in alertOption via a pane-died hook, so the session reports
+// an error as soon as the agent dies instead of when the whole session is gone.
+// Side panes close on exit as usual.
+//
+// alert-activity and alert-silence are not used: tmux only raises them for
+// background windows, and the agent's window is the current one.
+func (s *Session) registerAlertHooks() {
+	// -p (pane options) needs tmux 3.0+; -q keeps older versions quiet
+	_ = tmuxRun("set-option", "-p", "-q", "-t", agentPaneTarget(s.Name), "remain-on-exit", "on", ";",
+		"set-hook", "-t", s.Name, "pane-died", "set-option "+alertOption+" "+AlertPaneDied)
+}
+
+// Alert returns the last alert recorded by the session's hooks (e.g.
+// AlertPaneDied), or "" if there is none. A connected control pipe reports
+// alerts as they happen. Otherwise, or when the pipe has none, this reads the
+// BatchStatus cache, which also flags a dead pane whose hook never ran.
+func (s *Session) Alert() string {
 	if pm := GetPipeManager(); pm != nil {
-		if err := pm.Connect(s.Name); err != nil {
-			statusLog.Debug("control_pipe_connect_failed", slog.String("session", s.Name), slog.String("error", err.Error()))
+		if alert, ok := pm.Alert(s.Name); ok && alert != "" {
+			return alert
 		}
 	}
+	if info, ok := GetCachedPaneInfo(s.Name); ok {
+		return info.Alert
+	}
+	return ""
+}
 
-	// Note: We tried using tmux hooks for instant GREEN status detection:
-	// - alert-activity: Only fires for background windows (not current window)
-	// - after-send-keys: Fires for ALL send-keys calls (too noisy, catches agent-deck operations)
-	// Neither works reliably for detecting user input. We use polling for GREEN instead.
-	// The Stop hook (via Claude settings) handles instant YELLOW detection.
+// defaultHistoryLimit is the scrollback of agent panes unless the user's
+// options set history-limit.
+const defaultHistoryLimit = "10000"
 
-	return nil
+// applyHistoryLimit makes history-limit reach the agent pane. tmux sizes a
+// pane's scrollback when the pane is created, so setting the option only
+// affects panes opened afterwards. While the first window is still an idle
+// shell, it is replaced by a fresh one that picks the limit up. Runs before
+// any window options are set, since those don't carry over.
+func (s *Session) applyHistoryLimit(workDir, limit string) {
+	out, err := tmuxQuery("display-message", "-p", "-t", s.Name, "#{history_limit} #{window_id}")
+	if err != nil {
+		return
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 || fields[0] == limit {
+		return
+	}
+	_ = tmuxRun("set-option", "-t", s.Name, "history-limit", limit, ";",
+		"new-window", "-d", "-t", s.Name+":", "-c", workDir, ";",
+		"kill-window", "-t", fields[1])
+}
+
+// DefaultPaneLayout is used for sessions with side panes when no layout is configured.
+const DefaultPaneLayout = "main-vertical"
+
+// agentPaneTarget addresses the pane running the agent. Side panes are split
+// off with -d and laid out right of or below it, so the agent stays top-left
+// even while the user has another pane focused. In single-pane sessions it is
+// simply the only pane.
+func agentPaneTarget(sessionName string) string {
+	return sessionName + ":.{top-left}"
+}
+
+// createSidePanes opens s.SidePanes next to the agent pane and applies the
+// layout. Failures are logged, not returned: the agent pane is usable anyway.
+func (s *Session) createSidePanes(workDir string) {
+	if len(s.SidePanes) == 0 {
+		return
+	}
+	for _, command := range s.SidePanes {
+		// -d keeps the agent pane active; -P prints the new pane's ID
+		// Creates a pane, so it isn't a query even though it reads the new pane's ID
+		out, err := runBounded(false, tmuxCmd("split-window", "-d", "-P", "-F", "#{pane_id}",
+			"-t", agentPaneTarget(s.Name), "-c", workDir), (*exec.Cmd).Output)
+		if err != nil {
+			statusLog.Warn("side_pane_create_failed", slog.String("session", s.Name), slog.String("error", err.Error()))
+			return
+		}
+		if command = strings.TrimSpace(command); command != "" {
+			paneID := strings.TrimSpace(string(out))
+			_ = tmuxRun("send-keys", "-l", "-t", paneID, "--", command, ";",
+				"send-keys", "-t", paneID, "Enter")
+		}
+	}
+	layout := s.PaneLayout
+	if layout == "" {
+		layout = DefaultPaneLayout
+	}
+	if out, err := tmuxCombinedOutput("select-layout", "-t", s.Name, layout); err != nil {
+		statusLog.Warn("side_pane_layout_failed",
+			slog.String("session", s.Name),
+			slog.String("layout", layout),
+			slog.String("error", strings.TrimSpace(string(out))))
+	}
 }
 
 // Exists checks if the tmux session exists
 // Uses cached session list when available (refreshed by RefreshExistingSessions)
 // Falls back to direct tmux call if cache is stale
 func (s *Session) Exists() bool {
+	// The session cache and control pipes only track tmux
+	if !isTmux() {
+		return backend.Exists(s.Name)
+	}
+
 	// Try cache first (O(1) map lookup, no subprocess)
 	if exists, cacheValid := sessionExistsFromCache(s.Name); cacheValid {
 		return exists
@@ -989,8 +1359,7 @@ func (s *Session) Exists() bool {
 	}
 
 	// Cache is stale and no live pipe: fall back to direct tmux check.
-	cmd := exec.Command("tmux", "has-session", "-t", s.Name)
-	return cmd.Run() == nil
+	return backend.Exists(s.Name)
 }
 
 // ConfigureStatusBar sets up the tmux status bar with session info
@@ -1017,13 +1386,11 @@ func (s *Session) ConfigureStatusBar() {
 	// Uses tmux command chaining with \; separator (73% reduction in subprocess calls)
 	// Before: 5 separate exec.Command calls = 5 subprocess spawns
 	// After: 1 exec.Command call = 1 subprocess spawn
-	cmd := exec.Command("tmux",
-		"set-option", "-t", s.Name, "status", "on", ";",
+	_ = tmuxRun("set-option", "-t", s.Name, "status", "on", ";",
 		"set-option", "-t", s.Name, "status-style", "bg=#1a1b26,fg=#a9b1d6", ";",
 		"set-option", "-t", s.Name, "status-left-length", "120", ";",
 		"set-option", "-t", s.Name, "status-right", rightStatus, ";",
 		"set-option", "-t", s.Name, "status-right-length", "80")
-	_ = cmd.Run()
 }
 
 // EnableMouseMode enables mouse scrolling, clipboard integration, and optimal settings
@@ -1046,8 +1413,7 @@ func (s *Session) ConfigureStatusBar() {
 func (s *Session) EnableMouseMode() error {
 	// CRITICAL: Mouse mode must succeed - keep as separate call for error handling
 	// This is the only essential feature; all others are enhancements
-	mouseCmd := exec.Command("tmux", "set-option", "-t", s.Name, "mouse", "on")
-	if err := mouseCmd.Run(); err != nil {
+	if err := tmuxRun("set-option", "-t", s.Name, "mouse", "on"); err != nil {
 		return err
 	}
 
@@ -1064,15 +1430,13 @@ func (s *Session) EnableMouseMode() error {
 	// - escape-time 10: Fast Vim/editor responsiveness (default 500ms is too slow)
 	//
 	// Uses -q flag where supported to silently ignore on older tmux versions
-	enhanceCmd := exec.Command("tmux",
-		"set-option", "-t", s.Name, "set-clipboard", "on", ";",
+	// Ignore errors - all these are non-fatal enhancements
+	// Older tmux versions may not support some options
+	_ = tmuxRun("set-option", "-t", s.Name, "set-clipboard", "on", ";",
 		"set-option", "-t", s.Name, "-q", "allow-passthrough", "on", ";",
-		"set-option", "-t", s.Name, "history-limit", "10000", ";",
+		"set-option", "-t", s.Name, "history-limit", defaultHistoryLimit, ";",
 		"set-option", "-t", s.Name, "escape-time", "10", ";",
 		"set", "-asq", "terminal-features", ",*:hyperlinks")
-	// Ignore errors - all these are non-fatal enhancements
-	// Older tmux versions may not support some options
-	_ = enhanceCmd.Run()
 
 	return nil
 }
@@ -1091,6 +1455,10 @@ func (s *Session) Kill() error {
 	logFile := s.LogFile()
 	os.Remove(logFile) // Ignore errors
 
+	if !isTmux() {
+		return backend.Kill(s.Name)
+	}
+
 	// Capture process tree BEFORE killing so we can verify they die
 	_, oldPIDs := s.getPaneProcessTree()
 	if len(oldPIDs) > 0 {
@@ -1098,8 +1466,7 @@ func (s *Session) Kill() error {
 	}
 
 	// Kill the tmux session
-	cmd := exec.Command("tmux", "kill-session", "-t", s.Name)
-	err := cmd.Run()
+	err := backend.Kill(s.Name)
 
 	// Verify old processes are dead; escalate to SIGKILL if needed
 	if len(oldPIDs) > 0 {
@@ -1112,17 +1479,11 @@ func (s *Session) Kill() error {
 // getPaneProcessTree returns the pane's direct PID and all descendant PIDs.
 // Used before respawn to track processes that must die.
 func (s *Session) getPaneProcessTree() (panePID int, allPIDs []int) {
-	target := s.Name + ":"
-	out, err := exec.Command("tmux", "list-panes", "-t", target, "-F", "#{pane_pid}").Output()
+	out, err := tmuxQuery("display-message", "-p", "-t", agentPaneTarget(s.Name), "#{pane_pid}")
 	if err != nil {
 		return 0, nil
 	}
-	// Take only the first line (handles multi-pane sessions safely)
-	pidStr := strings.TrimSpace(string(out))
-	if idx := strings.IndexByte(pidStr, '\n'); idx >= 0 {
-		pidStr = pidStr[:idx]
-	}
-	panePID, err = strconv.Atoi(pidStr)
+	panePID, err = strconv.Atoi(strings.TrimSpace(string(out)))
 	if err != nil {
 		return 0, nil
 	}
@@ -1133,7 +1494,7 @@ func (s *Session) getPaneProcessTree() (panePID int, allPIDs []int) {
 	for len(queue) > 0 {
 		parent := queue[0]
 		queue = queue[1:]
-		pgrepOut, err := exec.Command("pgrep", "-P", strconv.Itoa(parent)).Output()
+		pgrepOut, err := execQuery("pgrep", "-P", strconv.Itoa(parent))
 		if err != nil {
 			continue
 		}
@@ -1151,7 +1512,7 @@ func (s *Session) getPaneProcessTree() (panePID int, allPIDs []int) {
 // (claude, node, zsh, bash, sh) rather than an unrelated process that
 // reused the PID. This prevents accidentally killing random processes.
 func isOurProcess(pid int) bool {
-	out, err := exec.Command("ps", "-p", strconv.Itoa(pid), "-o", "comm=").Output()
+	out, err := execQuery("ps", "-p", strconv.Itoa(pid), "-o", "comm=")
 	if err != nil {
 		return false // Process doesn't exist
 	}
@@ -1253,6 +1614,14 @@ func (s *Session) RespawnPane(command string) error {
 	}
 	s.invalidateCache()
 
+	// Without respawn-pane, restart the session under the same name
+	if !isTmux() {
+		if err := backend.Kill(s.Name); err != nil {
+			return fmt.Errorf("failed to stop session: %w", err)
+		}
+		return s.Start(command)
+	}
+
 	// Capture the current process tree BEFORE respawn so we can verify they die
 	_, oldPIDs := s.getPaneProcessTree()
 	if len(oldPIDs) > 0 {
@@ -1261,9 +1630,8 @@ func (s *Session) RespawnPane(command string) error {
 
 	// Clear scrollback buffer BEFORE respawn to prevent stale content
 	// from previous conversation appearing when user attaches (#138).
-	clearTarget := s.Name + ":"
-	clearCmd := exec.Command("tmux", "clear-history", "-t", clearTarget)
-	if clearOut, clearErr := clearCmd.CombinedOutput(); clearErr != nil {
+	clearTarget := agentPaneTarget(s.Name)
+	if clearOut, clearErr := tmuxCombinedOutput("clear-history", "-t", clearTarget); clearErr != nil {
 		respawnLog.Debug("clear_history_failed", slog.String("error", clearErr.Error()), slog.String("output", string(clearOut)))
 	} else {
 		respawnLog.Info("cleared_scrollback", slog.String("session", s.Name))
@@ -1273,7 +1641,7 @@ func (s *Session) RespawnPane(command string) error {
 	// -k: Kill current process
 	// -t: Target pane (session:window.pane format, use session: for active pane)
 	// command: New command to run
-	target := s.Name + ":" // Append colon to target the active pane
+	target := agentPaneTarget(s.Name)
 	args := []string{"respawn-pane", "-k", "-t", target}
 	if command != "" {
 		// Wrap command in interactive shell to ensure aliases and shell configs are available
@@ -1296,15 +1664,17 @@ func (s *Session) RespawnPane(command string) error {
 		wrappedCmd := fmt.Sprintf("%s -ic %q", shell, command)
 		args = append(args, wrappedCmd)
 	}
+	// The fresh process is alive again: clear any pane-died alert
+	args = append(args, ";", "set-option", "-u", "-t", s.Name, alertOption)
 
 	mcpLog.Debug("respawn_pane_executing", slog.Any("args", args))
-	cmd := exec.Command("tmux", args...)
-	output, err := cmd.CombinedOutput()
+	output, err := tmuxCombinedOutput(args...)
 	if err != nil {
 		mcpLog.Debug("respawn_pane_error", slog.String("error", err.Error()), slog.String("output", string(output)))
 		return fmt.Errorf("failed to respawn pane: %w (output: %s)", err, string(output))
 	}
 	mcpLog.Debug("respawn_pane_output", slog.String("output", string(output)))
+	forgetCachedPaneInfo(s.Name)
 
 	// Get the NEW pane PID so we don't accidentally kill the fresh process
 	newPanePID, _ := s.getPaneProcessTree()
@@ -1328,8 +1698,6 @@ func (s *Session) RespawnPane(command string) error {
 	s.startupAt = time.Now()
 	s.lastStableStatus = "waiting"
 	s.stateTracker = nil
-	s.cachedPromptDetector = nil
-	s.cachedPromptDetectorTool = ""
 	s.mu.Unlock()
 
 	return nil
@@ -1339,6 +1707,10 @@ func (s *Session) RespawnPane(command string) error {
 // Uses cached data when available (refreshed by RefreshSessionCache)
 // Falls back to direct tmux call if cache is stale
 func (s *Session) GetWindowActivity() (int64, error) {
+	if !isTmux() {
+		return 0, fmt.Errorf("window activity not supported by %s", backend.Name())
+	}
+
 	// Try cache first (O(1) map lookup, no subprocess)
 	if activity, cacheValid := sessionActivityFromCache(s.Name); cacheValid {
 		return activity, nil
@@ -1352,7 +1724,7 @@ func (s *Session) GetWindowActivity() (int64, error) {
 	// No PipeManager: fall back to direct check (spawns subprocess)
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
-	cmd := exec.CommandContext(ctx, "tmux", "display-message", "-t", s.Name, "-p", "#{window_activity}")
+	cmd := CommandContext(ctx, "display-message", "-t", s.Name, "-p", "#{window_activity}")
 	output, err := cmd.Output()
 	if err != nil {
 		return 0, fmt.Errorf("failed to get window activity: %w", err)
@@ -1401,7 +1773,7 @@ func (s *Session) CapturePane() (string, error) {
 		s.cacheMu.RUnlock()
 
 		// Try control mode pipe first (zero subprocess)
-		if pm := GetPipeManager(); pm != nil {
+		if pm := GetPipeManager(); pm != nil && isTmux() {
 			if content, pipeErr := pm.CapturePane(s.Name); pipeErr == nil {
 				s.cacheMu.Lock()
 				s.cacheContent = content
@@ -1413,11 +1785,10 @@ func (s *Session) CapturePane() (string, error) {
 			statusLog.Debug("capture_pane_subprocess_fallback", slog.String("session", s.Name))
 		}
 
-		// Subprocess fallback: -J joins wrapped lines, 3s timeout
+		// Subprocess fallback with a 3s timeout
 		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 		defer cancel()
-		cmd := exec.CommandContext(ctx, "tmux", "capture-pane", "-t", s.Name, "-p", "-J")
-		output, err := cmd.Output()
+		content, err := backend.Capture(ctx, s.Name, 0)
 		if err != nil {
 			if ctx.Err() == context.DeadlineExceeded {
 				return "", ErrCaptureTimeout
@@ -1425,8 +1796,6 @@ func (s *Session) CapturePane() (string, error) {
 			return "", fmt.Errorf("failed to capture pane: %w", err)
 		}
 
-		content := string(output)
-
 		s.cacheMu.Lock()
 		s.cacheContent = content
 		s.cacheTime = time.Now()
@@ -1440,16 +1809,110 @@ func (s *Session) CapturePane() (string, error) {
 	return v.(string), nil
 }
 
-// CaptureFullHistory captures the scrollback history (limited to last 2000 lines for performance)
+// historyCaptureLines limits scrollback captures to balance content availability
+// with memory usage. AI agent conversations can be long - 2000 lines captures
+// ~40-80 screens of content.
+const historyCaptureLines = 2000
+
+// historyCapture is a cached scrollback capture and the pane content hash
+// that was current when it was taken.
+type historyCapture struct {
+	hash       string
+	capturedAt time.Time
+	content    string
+}
+
+// noteContentHashLocked records the pane content hash GetStatus just
+// computed. Caller must hold s.mu.
+func (s *Session) noteContentHashLocked(hash string) {
+	s.contentHash = hash
+	s.contentHashAt = time.Now()
+}
+
+// clearContentHashLocked forgets the content hash when GetStatus saw the
+// pane change, or couldn't tell, without hashing it: busy and starting
+// sessions return early. Caller must hold s.mu.
+func (s *Session) clearContentHashLocked() {
+	s.contentHash = ""
+	s.contentHashAt = time.Now()
+}
+
+// cachedHistory returns c's content when a status check made after it was
+// captured hashed the pane to the same content, so capturing again would
+// return the same scrollback. A status check that saw activity without
+// hashing clears the hash, so busy sessions always capture fresh.
+func (s *Session) cachedHistory(c *historyCapture) (string, bool) {
+	s.mu.Lock()
+	hash, hashAt := s.contentHash, s.contentHashAt
+	s.mu.Unlock()
+
+	s.cacheMu.RLock()
+	defer s.cacheMu.RUnlock()
+	if c.content == "" || hash == "" || hash != c.hash || !hashAt.After(c.capturedAt) {
+		return "", false
+	}
+	return c.content, true
+}
+
+// storeHistory caches a scrollback capture under the current content hash.
+func (s *Session) storeHistory(c *historyCapture, content string) {
+	s.mu.Lock()
+	hash := s.contentHash
+	s.mu.Unlock()
+
+	s.cacheMu.Lock()
+	defer s.cacheMu.Unlock()
+	*c = historyCapture{hash: hash, capturedAt: time.Now(), content: content}
+}
+
+// CaptureFullHistory captures the scrollback history (limited to last 2000 lines for performance).
+// Reuses the last capture while the pane is unchanged (see cachedHistory).
 func (s *Session) CaptureFullHistory() (string, error) {
-	// Limit to last 2000 lines to balance content availability with memory usage
-	// AI agent conversations can be long - 2000 lines captures ~40-80 screens of content
-	// -J joins wrapped lines and trims trailing spaces so hashes don't change on resize
-	cmd := exec.Command("tmux", "capture-pane", "-t", s.Name, "-p", "-J", "-S", "-2000")
-	output, err := cmd.Output()
+	if content, ok := s.cachedHistory(&s.historyCache); ok {
+		return content, nil
+	}
+
+	// Try control mode pipe first (zero subprocess)
+	if pm := GetPipeManager(); pm != nil && isTmux() {
+		if content, pipeErr := pm.CaptureHistory(s.Name, historyCaptureLines); pipeErr == nil {
+			s.storeHistory(&s.historyCache, content)
+			return content, nil
+		}
+		statusLog.Debug("capture_history_subprocess_fallback", slog.String("session", s.Name))
+	}
+
+	content, err := backend.Capture(context.Background(), s.Name, historyCaptureLines)
 	if err != nil {
 		return "", fmt.Errorf("failed to capture history: %w", err)
 	}
+	s.storeHistory(&s.historyCache, content)
+	return content, nil
+}
+
+// CaptureStyledHistory is CaptureFullHistory with colors and text attributes
+// kept as SGR escape sequences, for display. Only tmux can capture them;
+// other multiplexers return plain text.
+func (s *Session) CaptureStyledHistory() (string, error) {
+	if !isTmux() {
+		return s.CaptureFullHistory()
+	}
+	if content, ok := s.cachedHistory(&s.styledCache); ok {
+		return content, nil
+	}
+	if pm := GetPipeManager(); pm != nil {
+		if content, pipeErr := pm.CaptureStyledHistory(s.Name, historyCaptureLines); pipeErr == nil {
+			s.storeHistory(&s.styledCache, content)
+			return content, nil
+		}
+		statusLog.Debug("capture_history_subprocess_fallback", slog.String("session", s.Name))
+	}
+
+	output, err := tmuxQuery("capture-pane", "-t", agentPaneTarget(s.Name), "-p", "-J", "-e",
+		"-S", "-"+strconv.Itoa(historyCaptureLines))
+	if err != nil {
+		return "", fmt.Errorf("failed to capture history: %w", err)
+	}
+	s.storeHistory(&s.styledCache, string(output))
 	return string(output), nil
 }
 
@@ -1511,18 +1974,7 @@ func (s *Session) DetectTool() string {
 
 	// Detect tool from command first (most reliable)
 	if s.Command != "" {
-		cmdLower := strings.ToLower(s.Command)
-		var tool string
-		if strings.Contains(cmdLower, "claude") {
-			tool = "claude"
-		} else if strings.Contains(cmdLower, "gemini") {
-			tool = "gemini"
-		} else if strings.Contains(cmdLower, "opencode") || strings.Contains(cmdLower, "open code") {
-			tool = "opencode"
-		} else if strings.Contains(cmdLower, "codex") {
-			tool = "codex"
-		}
-		if tool != "" {
+		if tool := toolFromCommand(s.Command); tool != "" {
 			s.mu.Lock()
 			s.detectedTool = tool
 			s.toolDetectedAt = time.Now()
@@ -1544,18 +1996,10 @@ func (s *Session) DetectTool() string {
 	// Strip ANSI codes for accurate matching
 	cleanContent := StripANSI(content)
 
-	// Check using pre-compiled patterns
-	detectedTool := "shell"
-	for tool, patterns := range toolDetectionPatterns {
-		for _, pattern := range patterns {
-			if pattern.MatchString(cleanContent) {
-				detectedTool = tool
-				break
-			}
-		}
-		if detectedTool != "shell" {
-			break
-		}
+	// Ask the registered tool detectors
+	detectedTool := toolFromContent(cleanContent)
+	if detectedTool == "" {
+		detectedTool = "shell"
 	}
 
 	s.mu.Lock()
@@ -1635,11 +2079,31 @@ func (s *Session) GetStatus() (string, error) {
 	if !s.Exists() {
 		s.mu.Lock()
 		s.lastStableStatus = "inactive"
+		s.clearContentHashLocked()
 		s.mu.Unlock()
 		statusLog.Debug("session_inactive", slog.String("session", shortName))
 		return "inactive", nil
 	}
 
+	// The agent exited and remain-on-exit kept its dead pane around
+	if s.Alert() == AlertPaneDied {
+		s.mu.Lock()
+		s.lastStableStatus = "inactive"
+		s.clearContentHashLocked()
+		s.mu.Unlock()
+		statusLog.Debug("agent_pane_dead", slog.String("session", shortName))
+		return "inactive", nil
+	}
+
+	// The agent exited back to its shell, whose prompt would read as waiting
+	if s.AgentExited() {
+		s.mu.Lock()
+		s.lastStableStatus = "inactive"
+		s.clearContentHashLocked()
+		s.mu.Unlock()
+		return "inactive", nil
+	}
+
 	// FAST PATH: Title-based state detection for Claude Code sessions.
 	// Claude Code sets pane titles via OSC sequences: Braille spinner while working,
 	// ✳ markers when done. One character check replaces full CapturePane + content scan.
@@ -1656,6 +2120,7 @@ func (s *Session) GetStatus() (string, error) {
 			s.stateTracker.spinnerTracker.MarkBusy()
 			s.lastStableStatus = "active"
 			s.startupAt = time.Time{}
+			s.clearContentHashLocked()
 			s.mu.Unlock()
 			statusLog.Debug("title_working", slog.String("session", shortName), slog.String("title", paneInfo.Title))
 			return "active", nil
@@ -1699,6 +2164,10 @@ func (s *Session) GetStatus() (string, error) {
 	}
 
 	if needsBusyCheck {
+		// The pane changed: the hash is stale until the content is hashed
+		// below, which busy and timed-out checks never get to
+		s.clearContentHashLocked()
+
 		// Release lock for slow CapturePane operation
 		s.mu.Unlock()
 		content, err := s.CapturePane()
@@ -1752,6 +2221,7 @@ func (s *Session) GetStatus() (string, error) {
 			// so we store the normalized result once and reuse it via cachedNormContent.
 			cleanContent := s.normalizeContent(content)
 			currentHash := s.hashContent(cleanContent)
+			s.noteContentHashLocked(currentHash)
 			if currentHash != "" {
 				// Keep the content hash for diagnostics/fallback logic only.
 				// Do NOT clear acknowledgment on hash changes: dynamic footer text
@@ -1809,7 +2279,7 @@ func (s *Session) GetStatus() (string, error) {
 			acknowledged:          false, // Start unacknowledged so stopped sessions show YELLOW
 			lastActivityTimestamp: currentTS,
 			waitingSince:          now, // Track when session became waiting
-			spinnerTracker:        NewSpinnerActivityTracker(),
+			spinnerTracker:        s.newSpinnerTrackerLocked(),
 		}
 		if s.inStartupWindowLocked() {
 			s.lastStableStatus = "starting"
@@ -1891,6 +2361,7 @@ func (s *Session) GetStatus() (string, error) {
 					// Not busy - update hash for tracking (deferred past the early return above)
 					cleanContent := s.normalizeContent(content)
 					currentHash := s.hashContent(cleanContent)
+					s.noteContentHashLocked(currentHash)
 					if currentHash != "" {
 						// Hash changes alone are not enough to clear acknowledgment.
 						s.stateTracker.lastHash = currentHash
@@ -2039,6 +2510,11 @@ func (s *Session) getStatusFallback() (string, error) {
 		shortName = shortName[:12]
 	}
 
+	// Only the idle path at the end hashes the content
+	s.mu.Lock()
+	s.clearContentHashLocked()
+	s.mu.Unlock()
+
 	content, err := s.CapturePane()
 	if err != nil {
 		if errors.Is(err, ErrCaptureTimeout) {
@@ -2110,6 +2586,7 @@ func (s *Session) getStatusFallback() (string, error) {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.noteContentHashLocked(currentHash)
 
 	if s.stateTracker == nil {
 		now := time.Now()
@@ -2222,7 +2699,7 @@ func (s *Session) ApplySharedAcknowledged(ack bool) {
 		}
 		s.ensureStateTrackerLocked()
 	} else if s.stateTracker.spinnerTracker == nil {
-		s.stateTracker.spinnerTracker = NewSpinnerActivityTracker()
+		s.stateTracker.spinnerTracker = s.newSpinnerTrackerLocked()
 	}
 
 	s.stateTracker.acknowledged = ack
@@ -2284,19 +2761,7 @@ func inferToolFromSessionFields(detected, custom, command string) string {
 	if custom != "" {
 		return strings.ToLower(custom)
 	}
-	cmd := strings.ToLower(command)
-	switch {
-	case strings.Contains(cmd, "claude"):
-		return "claude"
-	case strings.Contains(cmd, "gemini"):
-		return "gemini"
-	case strings.Contains(cmd, "opencode"), strings.Contains(cmd, "open code"):
-		return "opencode"
-	case strings.Contains(cmd, "codex"):
-		return "codex"
-	default:
-		return ""
-	}
+	return toolFromCommand(command)
 }
 
 func defaultResolvedPatternsForTool(tool string) *ResolvedPatterns {
@@ -2424,15 +2889,10 @@ func (s *Session) hasBusyIndicatorResolved(content string) bool {
 			return true
 		}
 	}
-	isClaude := strings.EqualFold(tool, "claude")
-
 	if found {
-		// For Claude, braille spinner frames are authoritative.
-		// Asterisk-style frames can appear in non-active contexts, so require context.
-		lineClean := StripANSI(spinnerLine)
-		lineLower := strings.ToLower(lineClean)
-		hasActiveContext := strings.Contains(lineClean, "…") || strings.Contains(lineLower, "interrupt")
-		if !isClaude || isBrailleSpinnerChar(char) || hasActiveContext {
+		// The tool decides whether its spinner frame means work (Claude
+		// requires active-line context for asterisk-style frames).
+		if detectorFor(tool).SpinnerMeansBusy(char, spinnerLine) {
 			tracker.MarkBusy()
 			statusLog.Debug("busy_spinner_found", slog.String("session", shortName), slog.String("char", char))
 			return true
@@ -2455,7 +2915,7 @@ func (s *Session) hasBusyIndicatorResolved(content string) bool {
 }
 
 // hasPromptIndicator checks if the terminal shows a prompt waiting for user input.
-// Uses the PromptDetector which understands tool-specific prompt patterns (permission
+// Uses the tool's ToolDetector, which understands tool-specific prompt patterns (permission
 // dialogs, AskUserQuestion UI, input prompts, etc.). Prompt detection takes priority
 // over busy indicators because tools can show status text alongside interactive prompts.
 //
@@ -2488,12 +2948,96 @@ func (s *Session) hasPromptIndicator(content string) bool {
 	if tool == "" {
 		return false
 	}
-	// Reuse cached detector if tool hasn't changed (avoids allocation per call)
-	if s.cachedPromptDetector == nil || s.cachedPromptDetectorTool != tool {
-		s.cachedPromptDetector = NewPromptDetector(tool)
-		s.cachedPromptDetectorTool = tool
+	return detectorFor(tool).HasPrompt(content)
+}
+
+// HasApprovalPrompt reports whether the agent is blocked on a permission or
+// confirmation prompt (e.g. Claude's "Do you want to allow…", aider's
+// (Y)es/(N)o). GetStatus reports these as plain "waiting"; callers use this to
+// single them out. Uses the cached pane capture.
+func (s *Session) HasApprovalPrompt() bool {
+	s.mu.Lock()
+	tool := inferToolFromSessionFields(s.detectedTool, s.customToolName, s.Command)
+	s.mu.Unlock()
+	if tool == "" {
+		return false
+	}
+	content, err := s.CapturePane()
+	if err != nil {
+		return false
+	}
+	return detectorFor(tool).HasApprovalPrompt(content)
+}
+
+// ErrorOutput returns the recent pane line matching one of the tool's error
+// patterns (rate limits, auth failures, crashes), or "" if there is none.
+// Only the bottom of the pane is checked: an error the agent has moved past
+// no longer counts. Uses the cached pane capture.
+func (s *Session) ErrorOutput() string {
+	s.mu.Lock()
+	tool := inferToolFromSessionFields(s.detectedTool, s.customToolName, s.Command)
+	patterns := s.resolvedPatterns
+	s.mu.Unlock()
+	if patterns == nil {
+		patterns = defaultResolvedPatternsForTool(tool)
+	}
+	if patterns == nil || (len(patterns.ErrorStrings) == 0 && len(patterns.ErrorRegexps) == 0) {
+		return ""
+	}
+	content, err := s.CapturePane()
+	if err != nil {
+		return ""
+	}
+	return matchErrorLine(patterns, lastNLines(StripANSI(content), 15))
+}
+
+// DetectModel returns the model the pane shows the tool running, or "" if
+// none is visible or the tool's detector has no ModelDetector. Uses the
+// cached pane capture.
+func (s *Session) DetectModel() string {
+	s.mu.Lock()
+	tool := inferToolFromSessionFields(s.detectedTool, s.customToolName, s.Command)
+	s.mu.Unlock()
+	md, ok := detectorFor(tool).(ModelDetector)
+	if !ok {
+		return ""
+	}
+	content, err := s.CapturePane()
+	if err != nil {
+		return ""
 	}
-	return s.cachedPromptDetector.HasPrompt(content)
+	return md.ModelFromContent(StripANSI(content))
+}
+
+// matchErrorLine returns the last of lines matching an error pattern,
+// trimmed for display.
+func matchErrorLine(patterns *ResolvedPatterns, lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if !isErrorLine(patterns, line) {
+			continue
+		}
+		line = strings.TrimSpace(line)
+		if r := []rune(line); len(r) > 120 {
+			line = string(r[:117]) + "..."
+		}
+		return line
+	}
+	return ""
+}
+
+func isErrorLine(patterns *ResolvedPatterns, line string) bool {
+	for _, re := range patterns.ErrorRegexps {
+		if re.MatchString(line) {
+			return true
+		}
+	}
+	for _, str := range patterns.ErrorStrings {
+		if strings.Contains(line, str) {
+			return true
+		}
+	}
+	return false
 }
 
 // lastNLines splits content into lines, trims trailing blank lines, and returns
@@ -2711,18 +3255,51 @@ func (s *Session) hashContent(content string) string {
 // Uses -l flag to treat keys as literal text, preventing tmux special key interpretation
 func (s *Session) SendKeys(keys string) error {
 	s.invalidateCache()
-	// The -l flag makes tmux treat the string as literal text, not key names
+	// Backends send the string as literal text, not key names (tmux uses -l).
 	// This prevents issues like "Enter" being interpreted as the Enter key
 	// and provides a layer of safety against tmux special sequences
-	cmd := exec.Command("tmux", "send-keys", "-l", "-t", s.Name, "--", keys)
-	return cmd.Run()
+	return backend.SendKeys(s.Name, keys)
+}
+
+// SendMode selects how SendText delivers text to the agent pane.
+type SendMode int
+
+const (
+	// SendLiteral types the text as-is (send-keys -l). Line feeds reach the
+	// program one by one, which many agent TUIs treat as submit.
+	SendLiteral SendMode = iota
+	// SendBracketedPaste pastes the text as one block, wrapped in
+	// \e[200~...\e[201~ when the program has enabled bracketed paste, so
+	// multi-line prompts and special characters arrive intact.
+	SendBracketedPaste
+)
+
+// SendText sends text to the session in the given mode. Bracketed paste is
+// tmux-only; other backends fall back to literal sending.
+func (s *Session) SendText(text string, mode SendMode) error {
+	if mode != SendBracketedPaste || !isTmux() {
+		return s.SendKeysChunked(text)
+	}
+	s.invalidateCache()
+	// Load into a named buffer and paste it in one tmux call; -d deletes the
+	// buffer afterwards so it never shows up in the user's paste history.
+	buffer := "agentdeck-" + s.Name
+	output, err := runBounded(false, func(ctx context.Context) *exec.Cmd {
+		cmd := CommandContext(ctx, "load-buffer", "-b", buffer, "-", ";",
+			"paste-buffer", "-p", "-d", "-b", buffer, "-t", agentPaneTarget(s.Name))
+		cmd.Stdin = strings.NewReader(text)
+		return cmd
+	}, (*exec.Cmd).CombinedOutput)
+	if err != nil {
+		return fmt.Errorf("paste failed: %w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	return nil
 }
 
 // SendEnter sends an Enter key to the tmux session
 func (s *Session) SendEnter() error {
 	s.invalidateCache()
-	cmd := exec.Command("tmux", "send-keys", "-t", s.Name, "Enter")
-	return cmd.Run()
+	return backend.SendEnter(s.Name)
 }
 
 // SendKeysAndEnter sends literal text followed by Enter as two separate tmux
@@ -2805,15 +3382,19 @@ func splitIntoChunks(content string, maxSize int) []string {
 // SendCtrlC sends Ctrl+C (interrupt signal) to the tmux session
 func (s *Session) SendCtrlC() error {
 	s.invalidateCache()
-	cmd := exec.Command("tmux", "send-keys", "-t", s.Name, "C-c")
-	return cmd.Run()
+	if !isTmux() {
+		return backend.SendKeys(s.Name, "\x03")
+	}
+	return tmuxRun("send-keys", "-t", agentPaneTarget(s.Name), "C-c")
 }
 
 // SendCtrlU sends Ctrl+U (clear line) to the tmux session
 func (s *Session) SendCtrlU() error {
 	s.invalidateCache()
-	cmd := exec.Command("tmux", "send-keys", "-t", s.Name, "C-u")
-	return cmd.Run()
+	if !isTmux() {
+		return backend.SendKeys(s.Name, "\x15")
+	}
+	return tmuxRun("send-keys", "-t", agentPaneTarget(s.Name), "C-u")
 }
 
 // WaitForShellPrompt polls the terminal until a shell prompt is detected
@@ -2983,8 +3564,11 @@ func (s *Session) GetWorkDir() string {
 		return ""
 	}
 
-	cmd := exec.Command("tmux", "display-message", "-t", s.Name, "-p", "#{pane_current_path}")
-	output, err := cmd.Output()
+	if !isTmux() {
+		return s.WorkDir
+	}
+
+	output, err := tmuxQuery("display-message", "-t", agentPaneTarget(s.Name), "-p", "#{pane_current_path}")
 	if err != nil {
 		return ""
 	}
@@ -2993,8 +3577,10 @@ func (s *Session) GetWorkDir() string {
 
 // ListAllSessions returns all Agent Deck tmux sessions
 func ListAllSessions() ([]*Session, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-	output, err := cmd.Output()
+	if !isTmux() {
+		return nil, nil
+	}
+	output, err := tmuxQuery("list-sessions", "-F", "#{session_name}")
 	if err != nil {
 		// No sessions exist
 		if strings.Contains(err.Error(), "no server running") ||
@@ -3016,8 +3602,7 @@ func ListAllSessions() ([]*Session, error) {
 				DisplayName: displayName,
 			}
 			// Try to get working directory
-			workDirCmd := exec.Command("tmux", "display-message", "-t", line, "-p", "#{pane_current_path}")
-			if workDirOutput, err := workDirCmd.Output(); err == nil {
+			if workDirOutput, err := tmuxQuery("display-message", "-t", line, "-p", "#{pane_current_path}"); err == nil {
 				sess.WorkDir = strings.TrimSpace(string(workDirOutput))
 			}
 			sessions = append(sessions, sess)
@@ -3200,8 +3785,7 @@ func RunLogMaintenance(maxSizeMB int, maxLines int, removeOrphans bool) {
 // those in the current profile. This ensures consistent notification bars
 // when users switch between sessions.
 func ListAgentDeckSessions() ([]string, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-	output, err := cmd.Output()
+	output, err := tmuxQuery("list-sessions", "-F", "#{session_name}")
 	if err != nil {
 		// No sessions exist
 		if strings.Contains(err.Error(), "no server running") ||
@@ -3228,16 +3812,14 @@ func ListAgentDeckSessions() ([]string, error) {
 func SetStatusLeft(sessionName, text string) error {
 	// Escape single quotes for tmux by replacing ' with '\''
 	escaped := strings.ReplaceAll(text, "'", "'\\''")
-	cmd := exec.Command("tmux", "set-option", "-t", sessionName, "status-left", escaped)
-	return cmd.Run()
+	return tmuxRun("set-option", "-t", sessionName, "status-left", escaped)
 }
 
 // ClearStatusLeft resets status-left to default for a session.
 // Called when notifications are cleared or acknowledged.
 func ClearStatusLeft(sessionName string) error {
 	// -u flag unsets the option, reverting to tmux default
-	cmd := exec.Command("tmux", "set-option", "-t", sessionName, "-u", "status-left")
-	return cmd.Run()
+	return tmuxRun("set-option", "-t", sessionName, "-u", "status-left")
 }
 
 // SetStatusLeftGlobal sets the left side of tmux status bar globally.
@@ -3245,14 +3827,12 @@ func ClearStatusLeft(sessionName string) error {
 // All agentdeck sessions inherit this global setting.
 func SetStatusLeftGlobal(text string) error {
 	escaped := strings.ReplaceAll(text, "'", "'\\''")
-	cmd := exec.Command("tmux", "set-option", "-g", "status-left", escaped)
-	return cmd.Run()
+	return tmuxRun("set-option", "-g", "status-left", escaped)
 }
 
 // ClearStatusLeftGlobal resets status-left to default globally.
 func ClearStatusLeftGlobal() error {
-	cmd := exec.Command("tmux", "set-option", "-gu", "status-left")
-	return cmd.Run()
+	return tmuxRun("set-option", "-gu", "status-left")
 }
 
 // InitializeStatusBarOptions sets optimal status bar options for agent-deck.
@@ -3261,7 +3841,7 @@ func ClearStatusLeftGlobal() error {
 func InitializeStatusBarOptions() error {
 	// Set adequate status-left-length globally (default is only 10 chars!)
 	// This ensures the notification bar content is not truncated
-	return exec.Command("tmux", "set-option", "-g", "status-left-length", "120").Run()
+	return tmuxRun("set-option", "-g", "status-left-length", "120")
 }
 
 // RefreshStatusBarImmediate forces an immediate status bar redraw for ALL connected clients.
@@ -3270,8 +3850,7 @@ func InitializeStatusBarOptions() error {
 // Filters out control mode clients (from PipeManager) which don't have a visible status bar.
 func RefreshStatusBarImmediate() error {
 	// Get all connected clients, filtering out control mode clients
-	cmd := exec.Command("tmux", "list-clients", "-F", "#{client_name}\t#{client_control_mode}")
-	output, err := cmd.Output()
+	output, err := tmuxQuery("list-clients", "-F", "#{client_name}\t#{client_control_mode}")
 	if err != nil {
 		return nil
 	}
@@ -3285,7 +3864,7 @@ func RefreshStatusBarImmediate() error {
 		if parts[1] == "1" {
 			continue
 		}
-		_ = exec.Command("tmux", "refresh-client", "-S", "-t", parts[0]).Run()
+		_ = tmuxRun("refresh-client", "-S", "-t", parts[0])
 	}
 	return nil
 }
@@ -3294,8 +3873,7 @@ func RefreshStatusBarImmediate() error {
 // Used to detect which session the user is currently viewing.
 // Filters out control mode clients (from PipeManager) which are not real user sessions.
 func GetAttachedSessions() ([]string, error) {
-	cmd := exec.Command("tmux", "list-clients", "-F", "#{session_name}\t#{client_control_mode}")
-	output, err := cmd.Output()
+	output, err := tmuxQuery("list-clients", "-F", "#{session_name}\t#{client_control_mode}")
 	if err != nil {
 		return nil, err
 	}
@@ -3320,8 +3898,7 @@ func GetAttachedSessions() ([]string, error) {
 // The key should be a single character like "1", "2", etc.
 // Deprecated: Use BindSwitchKeyWithAck for notification bar integration.
 func BindSwitchKey(key, targetSession string) error {
-	cmd := exec.Command("tmux", "bind-key", key, "switch-client", "-t", targetSession)
-	return cmd.Run()
+	return tmuxRun("bind-key", key, "switch-client", "-t", targetSession)
 }
 
 // BindSwitchKeyWithAck binds a number key to switch to target session AND
@@ -3338,19 +3915,23 @@ func BindSwitchKeyWithAck(key, targetSession, sessionID string) error {
 	// Create a compound command that:
 	// 1. Writes the session ID to a signal file (for agent-deck to acknowledge)
 	// 2. Switches to the target session
-	script := fmt.Sprintf("echo '%s' > '%s' && tmux switch-client -t '%s'",
-		sessionID, signalFile, targetSession)
-	cmd := exec.Command("tmux", "bind-key", key, "run-shell", script)
-	return cmd.Run()
+	script := fmt.Sprintf("echo '%s' > '%s' && %s switch-client -t '%s'",
+		sessionID, signalFile, ShellCommand(), targetSession)
+	return tmuxRun("bind-key", key, "run-shell", script)
+}
+
+// SwitchClient switches the current tmux client (the one running this
+// process, e.g. from a display-popup) to the target session.
+func SwitchClient(targetSession string) error {
+	if out, err := tmuxCombinedOutput("switch-client", "-t", targetSession); err != nil {
+		return fmt.Errorf("switch-client failed: %w (output: %s)", err, strings.TrimSpace(string(out)))
+	}
+	return nil
 }
 
 // GetAckSignalPath returns the path to the acknowledgment signal file
 func GetAckSignalPath() (string, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(homeDir, ".agent-deck", "ack-signal"), nil
+	return filepath.Join(dataDir(), "ack-signal"), nil
 }
 
 // ReadAndClearAckSignal reads the session ID from the signal file and deletes it.
@@ -3378,19 +3959,18 @@ func ReadAndClearAckSignal() string {
 // without windows (e.g., CI) and agent-deck rebinds keys every 2s anyway.
 func UnbindKey(key string) error {
 	// First unbind our custom binding
-	_ = exec.Command("tmux", "unbind-key", key).Run()
+	_ = tmuxRun("unbind-key", key)
 
 	// Best-effort restore default: number keys select windows
 	// bind-key 1 select-window -t :1
-	_ = exec.Command("tmux", "bind-key", key, "select-window", "-t", ":"+key).Run()
+	_ = tmuxRun("bind-key", key, "select-window", "-t", ":"+key)
 	return nil
 }
 
 // GetActiveSession returns the session name the user is currently attached to.
 // Returns empty string and error if not attached to any session.
 func GetActiveSession() (string, error) {
-	cmd := exec.Command("tmux", "display-message", "-p", "#{client_session}")
-	out, err := cmd.Output()
+	out, err := tmuxQuery("display-message", "-p", "#{client_session}")
 	if err != nil {
 		return "", err
 	}
@@ -3401,8 +3981,10 @@ func GetActiveSession() (string, error) {
 
 // DiscoverAllTmuxSessions returns all tmux sessions (including non-Agent Deck ones)
 func DiscoverAllTmuxSessions() ([]*Session, error) {
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}:#{pane_current_path}")
-	output, err := cmd.Output()
+	if !isTmux() {
+		return nil, nil
+	}
+	output, err := tmuxQuery("list-sessions", "-F", "#{session_name}:#{pane_current_path}")
 	if err != nil {
 		// No sessions exist
 		if strings.Contains(err.Error(), "no server running") ||
@@ -98,6 +98,31 @@ func TestSession_InjectStatusLine_ReconnectSession(t *testing.T) {
 	sess.ConfigureStatusBar() // Should be no-op, no error
 }
 
+func TestSessionNamespace(t *testing.T) {
+	t.Cleanup(func() { SetSessionNamespace("") })
+
+	SetSessionNamespace("client_a")
+	sess := NewSession("my app", "/tmp")
+	if !strings.HasPrefix(sess.Name, SessionPrefix+"client-a_my-app_") {
+		t.Errorf("Name = %s, want prefix %sclient-a_my-app_", sess.Name, SessionPrefix)
+	}
+	ns, title, ok := ParseSessionName(sess.Name)
+	if !ok || ns != "client-a" || title != "my-app" {
+		t.Errorf("ParseSessionName(%s) = %q, %q, %v", sess.Name, ns, title, ok)
+	}
+
+	SetSessionNamespace("")
+	sess = NewSession("my app", "/tmp")
+	ns, title, ok = ParseSessionName(sess.Name)
+	if !ok || ns != "" || title != "my-app" {
+		t.Errorf("ParseSessionName(%s) = %q, %q, %v", sess.Name, ns, title, ok)
+	}
+
+	if _, _, ok := ParseSessionName("main"); ok {
+		t.Error("non agent-deck names should not parse")
+	}
+}
+
 func TestSessionPrefix(t *testing.T) {
 	if SessionPrefix != "agentdeck_" {
 		t.Errorf("SessionPrefix = %s, want agentdeck_", SessionPrefix)
@@ -305,15 +330,20 @@ func TestStripANSI(t *testing.T) {
 		{"mixed content", "Hello \x1b[1mworld\x1b[0m!", "Hello world!"},
 		{"nested codes", "\x1b[1m\x1b[31m\x1b[4mtext\x1b[0m", "text"},
 		{"8-bit csi", "\x9Bmtest\x9Bm", "test"}, // 8-bit CSI (0x9B)
+		{"osc st terminator", "\x1b]2;Title\x1b\\Content", "Content"},
+		{"hyperlink", "\x1b]8;;https://example.com\x07link\x1b]8;;\x07", "link"},
+		{"private mode", "\x1b[?25lhidden\x1b[?25h", "hidden"},
+		{"charset", "\x1b(Btext", "text"},
+		{"utf-8 continuation not csi", "Ûmlaut", "Ûmlaut"}, // Û is C3 9B
 		// Edge cases - malformed sequences (rare in real terminal output)
-		// These match behavior of the original O(n²) implementation
-		{"esc at end", "hello\x1b", "hello\x1b"},              // trailing ESC kept (same as old)
-		{"osc without terminator", "\x1b]0;Title", "0;Title"}, // ESC] stripped, rest kept
-		{"csi without letter", "\x1b[123", ""},                // CSI params stripped
-		{"just esc", "\x1b", "\x1b"},                          // lone ESC kept (same as old)
-		{"esc followed by char", "\x1bXtext", "text"},         // ESC+char stripped
-		{"8-bit csi at end", "test\x9B", "test"},              // 8-bit CSI stripped
-		{"csi at end no params", "test\x1b[", "test"},         // CSI stripped
+		{"esc at end", "hello\x1b", "hello"},                 // incomplete escape dropped
+		{"osc without terminator", "\x1b]0;Title", ""},       // runs to the end, like a terminal
+		{"csi without letter", "\x1b[123", ""},               // CSI params stripped
+		{"csi broken by newline", "\x1b[12\nnext", "\nnext"}, // ends before the bad byte
+		{"just esc", "\x1b", ""},                             // incomplete escape dropped
+		{"esc followed by char", "\x1b7text", "text"},        // ESC+char stripped
+		{"8-bit csi at end", "test\x9B", "test"},             // 8-bit CSI stripped
+		{"csi at end no params", "test\x1b[", "test"},        // CSI stripped
 	}
 
 	for _, tt := range tests {
@@ -2360,3 +2390,302 @@ func TestSplitIntoChunks_SplitsAtNewlineBoundary(t *testing.T) {
 	assert.Equal(t, line+line, chunks[0])
 	assert.Equal(t, line, chunks[1])
 }
+
+// TestStartWithSidePanes verifies side panes are created without stealing
+// the agent pane, which capture and send-keys keep targeting.
+func TestStartWithSidePanes(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("panes-test", t.TempDir())
+	sess.SidePanes = []string{"echo side-one", ""}
+	err := sess.Start("")
+	assert.NoError(t, err)
+	defer func() { _ = sess.Kill() }()
+
+	out, err := exec.Command("tmux", "list-panes", "-t", sess.Name, "-F", "#{pane_active}").Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "1\n0\n0", strings.TrimSpace(string(out)), "agent pane should stay active")
+
+	// Focus a side pane, as a user might while attached
+	assert.NoError(t, exec.Command("tmux", "select-pane", "-t", sess.Name+":.{bottom-right}").Run())
+	assert.NoError(t, sess.SendKeysAndEnter("echo agent-marker"))
+	time.Sleep(300 * time.Millisecond)
+	content, err := sess.CapturePane()
+	assert.NoError(t, err)
+	assert.Contains(t, content, "agent-marker")
+	assert.NotContains(t, content, "side-one")
+}
+
+// TestStartAppliesOptionOverrides verifies overrides reach the agent pane,
+// including history-limit (fixed when a pane is created) and status-right
+// (also set by the injected status bar).
+func TestStartAppliesOptionOverrides(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("options-test", t.TempDir())
+	sess.OptionOverrides = map[string]string{
+		"history-limit":     "54321",
+		"status-right":      "custom-right",
+		"aggressive-resize": "on",
+	}
+	require.NoError(t, sess.Start(""))
+	defer func() { _ = sess.Kill() }()
+
+	out, err := exec.Command("tmux", "display-message", "-p", "-t", agentPaneTarget(sess.Name),
+		"#{history_limit}|#{status-right}|#{aggressive-resize}").Output()
+	require.NoError(t, err)
+	assert.Equal(t, "54321|custom-right|1", strings.TrimSpace(string(out)))
+
+	windows, err := exec.Command("tmux", "list-windows", "-t", sess.Name).Output()
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(strings.TrimSpace(string(windows)), "\n"), 1, "replaced window is gone")
+}
+
+func TestDedicatedSocket(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not available")
+	}
+
+	SetSocketName("agentdeck test/socket")
+	defer SetSocketName("")
+	assert.Equal(t, "agentdeck-test-socket", SocketName())
+	assert.Equal(t, "tmux -L agentdeck-test-socket", ShellCommand())
+	defer func() { _ = exec.Command("tmux", "-L", SocketName(), "kill-server").Run() }()
+
+	sess := NewSession("socket-test", t.TempDir())
+	assert.NoError(t, sess.Start(""))
+	defer func() { _ = sess.Kill() }()
+	assert.True(t, sess.Exists())
+
+	sessions, err := ListAllSessions()
+	assert.NoError(t, err)
+	names := make([]string, 0, len(sessions))
+	for _, s := range sessions {
+		names = append(names, s.Name)
+	}
+	assert.Contains(t, names, sess.Name)
+
+	// The default server never sees it
+	assert.Error(t, exec.Command("tmux", "has-session", "-t", sess.Name).Run())
+}
+
+// TestPaneDiedAlert verifies the pane-died hook keeps the dead agent pane and
+// reports the session as inactive until it is respawned
+func TestPaneDiedAlert(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("pane-died-test", t.TempDir())
+	require.NoError(t, sess.Start(""))
+	defer func() { _ = sess.Kill() }()
+
+	// Restart the agent the way restarts do, with one that exits at once
+	t.Setenv("SHELL", "/bin/sh")
+	require.NoError(t, sess.RespawnPane("exit 3"))
+
+	require.Eventually(t, func() bool {
+		RefreshSessionCache()
+		return sess.Alert() == AlertPaneDied
+	}, 5*time.Second, 100*time.Millisecond)
+	assert.True(t, sess.Exists(), "remain-on-exit keeps the session")
+
+	status, err := sess.GetStatus()
+	require.NoError(t, err)
+	assert.Equal(t, "inactive", status)
+
+	require.NoError(t, sess.RespawnPane("sleep 30"))
+	RefreshSessionCache()
+	assert.Empty(t, sess.Alert(), "respawn clears the alert")
+}
+
+func TestHasApprovalPromptFromPane(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("approval-test", t.TempDir())
+	// The command names Claude, so the session is treated as a Claude one
+	require.NoError(t, sess.Start(`printf '%s\n' 'Do you want to allow Claude to run make test?' '> 1. Yes' '  2. No'; sleep 30`))
+	defer func() { _ = sess.Kill() }()
+
+	require.Eventually(t, sess.HasApprovalPrompt, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestErrorOutputFromPane(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("error-output-test", t.TempDir())
+	// The command names Claude, so Claude's error patterns apply
+	require.NoError(t, sess.Start(`printf '%s\n' 'Claude AI usage limit reached' '> '; sleep 30`))
+	defer func() { _ = sess.Kill() }()
+
+	require.Eventually(t, func() bool {
+		return sess.ErrorOutput() == "Claude AI usage limit reached"
+	}, 5*time.Second, 100*time.Millisecond)
+}
+
+func TestSendTextBracketedPaste(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("paste-test", t.TempDir())
+	require.NoError(t, sess.Start(""))
+	defer func() { _ = sess.Kill() }()
+
+	// A program that enables bracketed paste and echoes what it receives
+	require.NoError(t, sess.RespawnPane(`printf '\033[?2004h'; exec cat -v`))
+	require.Eventually(t, func() bool {
+		out, _ := exec.Command("tmux", "display-message", "-p", "-t", agentPaneTarget(sess.Name), "#{pane_current_command}").Output()
+		return strings.TrimSpace(string(out)) == "cat"
+	}, 5*time.Second, 100*time.Millisecond)
+
+	require.NoError(t, sess.SendText("first line\n-$HOME 'quoted'", SendBracketedPaste))
+	require.NoError(t, sess.SendEnter())
+
+	require.Eventually(t, func() bool {
+		content, _ := sess.CapturePane()
+		return strings.Contains(content, "^[[200~first line") &&
+			strings.Contains(content, "-$HOME 'quoted'^[[201~")
+	}, 5*time.Second, 100*time.Millisecond)
+
+	out, err := exec.Command("tmux", "list-buffers", "-F", "#{buffer_name}").Output()
+	require.NoError(t, err)
+	assert.NotContains(t, string(out), "agentdeck-"+sess.Name, "paste buffer is deleted")
+}
+
+func TestAgentExitedToShell(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	exitedSess := NewSession("agent-exit-test", t.TempDir())
+	// The command names Claude but exits at once, leaving the shell
+	require.NoError(t, exitedSess.Start("echo claude"))
+	defer func() { _ = exitedSess.Kill() }()
+
+	runningSess := NewSession("agent-running-test", t.TempDir())
+	// A wrapped launch keeps a shell in the foreground with the agent below
+	// it. The marker shows the wrapper has started.
+	require.NoError(t, runningSess.Start("bash -c 'echo agent-up; sleep 30; : claude'"))
+	defer func() { _ = runningSess.Kill() }()
+
+	for _, sess := range []*Session{exitedSess, runningSess} {
+		sess.mu.Lock()
+		sess.startupAt = time.Time{}
+		sess.mu.Unlock()
+	}
+
+	// Wait until the pane shows a command's output with the shell back in the
+	// foreground (or, for the wrapper, still running) before checking, so the
+	// assertions don't race the command starting or exiting.
+	waitForOutput := func(sess *Session, line string, shellForeground bool) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			RefreshSessionCache()
+			info, ok := GetCachedPaneInfo(sess.Name)
+			if !ok || isShellCommand(info.CurrentCommand) != shellForeground {
+				return false
+			}
+			content, err := sess.CapturePane()
+			if err != nil {
+				return false
+			}
+			for _, l := range strings.Split(content, "\n") {
+				if strings.TrimSpace(l) == line {
+					return true
+				}
+			}
+			return false
+		}, 15*time.Second, 100*time.Millisecond)
+	}
+
+	waitForOutput(exitedSess, "claude", true)
+	assert.True(t, exitedSess.AgentExited())
+
+	waitForOutput(runningSess, "agent-up", true)
+	assert.False(t, runningSess.AgentExited())
+	status, err := exitedSess.GetStatus()
+	require.NoError(t, err)
+	assert.Equal(t, "inactive", status)
+}
+
+func TestHistoryCacheRefetchedWhenBusy(t *testing.T) {
+	skipIfNoTmuxServer(t)
+
+	sess := NewSession("history-busy", t.TempDir())
+	require.NoError(t, sess.Start(""))
+	defer func() { _ = sess.Kill() }()
+	sess.mu.Lock()
+	sess.Command = "claude" // For Claude's busy indicators
+	sess.startupAt = time.Time{}
+	sess.mu.Unlock()
+
+	// The agent's own output: typed straight into tmux, since SendKeys
+	// would drop the cached capture by itself
+	show := func(args, want string) {
+		t.Helper()
+		require.NoError(t, exec.Command("tmux", "send-keys", "-t", sess.Name, "clear && printf "+args, "Enter").Run())
+		require.Eventually(t, func() bool {
+			out, _ := exec.Command("tmux", "capture-pane", "-p", "-t", sess.Name).Output()
+			return strings.Contains(string(out), want)
+		}, 5*time.Second, 50*time.Millisecond)
+		time.Sleep(600 * time.Millisecond) // Past CapturePane's cache
+	}
+	// poll runs a status check that looks at the pane content
+	poll := func() string {
+		sess.mu.Lock()
+		if sess.stateTracker != nil {
+			sess.stateTracker.lastActivityTimestamp = -1
+		}
+		sess.mu.Unlock()
+		status, err := sess.GetStatus()
+		require.NoError(t, err)
+		return status
+	}
+
+	show(`'%s-%s\n' idle screen`, "idle-screen")
+	poll()
+	_, err := sess.CaptureFullHistory()
+	require.NoError(t, err)
+	time.Sleep(600 * time.Millisecond)
+	poll()
+	_, hit := sess.cachedHistory(&sess.historyCache)
+	require.True(t, hit, "an unchanged pane should reuse the capture")
+
+	show(`'%s to %s\n' ctrl+c interrupt`, "ctrl+c to interrupt")
+	require.Equal(t, "active", poll())
+	_, hit = sess.cachedHistory(&sess.historyCache)
+	assert.False(t, hit, "a busy pane should be captured again")
+	history, err := sess.CaptureFullHistory()
+	require.NoError(t, err)
+	assert.Contains(t, history, "ctrl+c to interrupt")
+}
+
+func TestHistoryCacheFollowsContentHash(t *testing.T) {
+	s := NewSession("history-cache", "/tmp")
+	note := func(hash string) {
+		s.mu.Lock()
+		s.noteContentHashLocked(hash)
+		s.mu.Unlock()
+	}
+
+	note("a")
+	s.storeHistory(&s.historyCache, "scrollback")
+	if _, ok := s.cachedHistory(&s.historyCache); ok {
+		t.Error("no status check has seen the pane since the capture, so it may have changed")
+	}
+
+	time.Sleep(time.Millisecond)
+	note("a")
+	if got, ok := s.cachedHistory(&s.historyCache); !ok || got != "scrollback" {
+		t.Errorf("an unchanged pane should reuse the capture, got %q, %v", got, ok)
+	}
+	if _, ok := s.cachedHistory(&s.styledCache); ok {
+		t.Error("plain and styled captures are cached separately")
+	}
+
+	note("b")
+	if _, ok := s.cachedHistory(&s.historyCache); ok {
+		t.Error("a changed pane should be captured again")
+	}
+
+	note("a")
+	s.invalidateCache()
+	if _, ok := s.cachedHistory(&s.historyCache); ok {
+		t.Error("sending keys should drop the cached capture")
+	}
+}
@@ -0,0 +1,348 @@
+package tmux
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ToolDetector holds one tool's status rules. Busy detection runs its
+// Patterns (plus any config.toml overrides) and spinner check; waiting
+// detection runs HasPrompt. Implementations are registered by tool name with
+// RegisterToolDetector, so a new tool needs no changes to the status core.
+type ToolDetector interface {
+	// Patterns returns the tool's built-in busy/prompt patterns, or nil.
+	Patterns() *RawPatterns
+
+	// HasPrompt reports whether content shows the tool waiting for input.
+	HasPrompt(content string) bool
+
+	// HasApprovalPrompt reports whether content shows the tool blocked on a
+	// permission or confirmation prompt, a stronger form of HasPrompt.
+	HasApprovalPrompt(content string) bool
+
+	// SpinnerMeansBusy reports whether a spinner frame char found on line
+	// means the tool is working. Some tools draw spinner-like glyphs in
+	// static UI, so they can ask for more context.
+	SpinnerMeansBusy(char, line string) bool
+
+	// MatchesCommand reports whether the (lowercased) launch command runs
+	// this tool.
+	MatchesCommand(command string) bool
+
+	// MatchesContent reports whether ANSI-stripped pane content shows this
+	// tool, for sessions whose command doesn't identify it.
+	MatchesContent(content string) bool
+}
+
+// ModelDetector is implemented by tool detectors that can read the active
+// model off the pane: a startup banner, status line or header box.
+type ModelDetector interface {
+	// ModelFromContent returns the model shown in ANSI-stripped content, or
+	// "" if none is visible.
+	ModelFromContent(content string) string
+}
+
+var (
+	toolDetectorsMu sync.RWMutex
+	toolDetectors   = map[string]ToolDetector{}
+	toolDetectorIDs []string // registration order; earlier tools win identification
+)
+
+// RegisterToolDetector adds or replaces the detector for a tool name.
+func RegisterToolDetector(tool string, d ToolDetector) {
+	tool = strings.ToLower(strings.TrimSpace(tool))
+	toolDetectorsMu.Lock()
+	defer toolDetectorsMu.Unlock()
+	if _, exists := toolDetectors[tool]; !exists {
+		toolDetectorIDs = append(toolDetectorIDs, tool)
+	}
+	toolDetectors[tool] = d
+}
+
+// lookupToolDetector returns the detector registered for tool, if any.
+func lookupToolDetector(tool string) (ToolDetector, bool) {
+	toolDetectorsMu.RLock()
+	defer toolDetectorsMu.RUnlock()
+	d, ok := toolDetectors[strings.ToLower(strings.TrimSpace(tool))]
+	return d, ok
+}
+
+// detectorFor returns the detector for tool, falling back to the shell rules
+// for custom tools and unknown names.
+func detectorFor(tool string) ToolDetector {
+	if d, ok := lookupToolDetector(tool); ok {
+		return d
+	}
+	return shellDetector{}
+}
+
+// toolFromCommand identifies the registered tool a launch command runs, or "".
+func toolFromCommand(command string) string {
+	cmd := strings.ToLower(command)
+	return firstMatchingTool(func(d ToolDetector) bool { return d.MatchesCommand(cmd) })
+}
+
+// toolFromContent identifies the registered tool shown in pane content, or "".
+func toolFromContent(content string) string {
+	return firstMatchingTool(func(d ToolDetector) bool { return d.MatchesContent(content) })
+}
+
+func firstMatchingTool(match func(ToolDetector) bool) string {
+	toolDetectorsMu.RLock()
+	defer toolDetectorsMu.RUnlock()
+	for _, tool := range toolDetectorIDs {
+		if match(toolDetectors[tool]) {
+			return tool
+		}
+	}
+	return ""
+}
+
+func init() {
+	RegisterToolDetector("claude", claudeDetector{claudeMatcher})
+	RegisterToolDetector("gemini", geminiDetector{geminiMatcher})
+	RegisterToolDetector("opencode", opencodeDetector{opencodeMatcher})
+	RegisterToolDetector("codex", codexDetector{codexMatcher})
+	RegisterToolDetector("aider", aiderDetector{aiderMatcher})
+	RegisterToolDetector("shell", shellDetector{})
+}
+
+// toolMatcher implements the identification half of ToolDetector from
+// command substrings and content regexes.
+type toolMatcher struct {
+	commands []string
+	content  []*regexp.Regexp
+}
+
+func (m toolMatcher) MatchesCommand(command string) bool {
+	for _, c := range m.commands {
+		if strings.Contains(command, c) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m toolMatcher) MatchesContent(content string) bool {
+	for _, re := range m.content {
+		if re.MatchString(content) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	claudeMatcher = toolMatcher{
+		commands: []string{"claude"},
+		content:  []*regexp.Regexp{regexp.MustCompile(`(?i)claude`), regexp.MustCompile(`(?i)anthropic`)},
+	}
+	geminiMatcher = toolMatcher{
+		commands: []string{"gemini"},
+		content:  []*regexp.Regexp{regexp.MustCompile(`(?i)gemini`), regexp.MustCompile(`(?i)google ai`)},
+	}
+	opencodeMatcher = toolMatcher{
+		commands: []string{"opencode", "open code"},
+		content:  []*regexp.Regexp{regexp.MustCompile(`(?i)opencode`), regexp.MustCompile(`(?i)open code`)},
+	}
+	codexMatcher = toolMatcher{
+		commands: []string{"codex"},
+		content:  []*regexp.Regexp{regexp.MustCompile(`(?i)codex`), regexp.MustCompile(`(?i)openai`)},
+	}
+	aiderMatcher = toolMatcher{
+		commands: []string{"aider"},
+		content:  []*regexp.Regexp{regexp.MustCompile(`(?i)\baider v\d`)},
+	}
+)
+
+// claudeDetector: Claude Code. Asterisk spinner frames also show up in
+// finished-turn summaries, so they only count with active-line context.
+type claudeDetector struct{ toolMatcher }
+
+func (claudeDetector) Patterns() *RawPatterns {
+	return &RawPatterns{
+		BusyPatterns: []string{
+			`re:(?m)^[✳✽✶✻✢·]\s*.+…`, // PRIMARY: spinner + ellipsis (Claude 2.1.25+) — anchored to line start to prevent mid-line · in welcome banner from false-positiving
+			"ctrl+c to interrupt",    // SECONDARY: explicit busy text (current Claude)
+			"esc to interrupt",       // FALLBACK: older Claude Code versions
+		},
+		ErrorPatterns: append(defaultErrorPatterns(),
+			"usage limit reached",
+			`re:\d+-hour limit reached`,
+			`re:API Error: (401|403|429)`,
+			"Invalid API key",
+			"OAuth token has expired",
+			"Please run /login",
+		),
+		SpinnerChars:   defaultSpinnerChars(),
+		WhimsicalWords: defaultWhimsicalWords(),
+	}
+}
+
+// claudeModelPatterns match the welcome banner ("Opus 4.1 · Claude Max") and
+// the /model confirmation ("Set model to Sonnet 4.5 (claude-sonnet-4-5…)").
+var claudeModelPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`((?:Opus|Sonnet|Haiku) \d+(?:\.\d+)?) · `),
+	regexp.MustCompile(`Set model to ((?:Opus|Sonnet|Haiku)(?: \d+(?:\.\d+)?)?)`),
+}
+
+func (claudeDetector) ModelFromContent(content string) string {
+	return lastModelMatch(content, claudeModelPatterns...)
+}
+
+func (claudeDetector) SpinnerMeansBusy(char, line string) bool {
+	// Braille spinner frames are authoritative; asterisk-style frames need an
+	// ellipsis or interrupt hint on the same line.
+	lineClean := StripANSI(line)
+	return isBrailleSpinnerChar(char) ||
+		strings.Contains(lineClean, "…") ||
+		strings.Contains(strings.ToLower(lineClean), "interrupt")
+}
+
+// geminiDetector: Gemini CLI.
+type geminiDetector struct{ toolMatcher }
+
+func (geminiDetector) Patterns() *RawPatterns {
+	return &RawPatterns{
+		BusyPatterns:   []string{"esc to cancel"},
+		PromptPatterns: []string{"gemini>", "Type your message"},
+		ErrorPatterns: append(defaultErrorPatterns(),
+			"RESOURCE_EXHAUSTED",
+			"Quota exceeded",
+			"API key not valid",
+		),
+	}
+}
+
+func (geminiDetector) SpinnerMeansBusy(string, string) bool { return true }
+
+// geminiModelPattern matches the footer: "gemini-2.5-pro (98% context left)".
+var geminiModelPattern = regexp.MustCompile(`\b(gemini-[\w.-]+) \(\d+% context left\)`)
+
+func (geminiDetector) ModelFromContent(content string) string {
+	return lastModelMatch(content, geminiModelPattern)
+}
+
+func (geminiDetector) HasApprovalPrompt(content string) bool {
+	return recentContains(content, "Allow execution", "Apply this change?")
+}
+
+// opencodeDetector: OpenCode TUI.
+type opencodeDetector struct{ toolMatcher }
+
+func (opencodeDetector) Patterns() *RawPatterns {
+	return &RawPatterns{
+		BusyPatterns:   []string{"esc interrupt"},
+		PromptPatterns: []string{"Ask anything"},
+		ErrorPatterns:  defaultErrorPatterns(),
+	}
+}
+
+func (opencodeDetector) SpinnerMeansBusy(string, string) bool { return true }
+
+func (opencodeDetector) HasApprovalPrompt(string) bool { return false }
+
+// codexDetector: OpenAI Codex CLI.
+type codexDetector struct{ toolMatcher }
+
+func (codexDetector) Patterns() *RawPatterns {
+	return &RawPatterns{
+		BusyPatterns: []string{
+			"ctrl+c to interrupt",
+			"esc to interrupt",
+			"press esc to interrupt",
+		},
+		PromptPatterns: []string{"How can I help", "codex>", "Continue?"},
+		ErrorPatterns: append(defaultErrorPatterns(),
+			"You've hit your usage limit",
+			"exceeded retry limit",
+			"stream disconnected before completion",
+		),
+	}
+}
+
+func (codexDetector) SpinnerMeansBusy(string, string) bool { return true }
+
+// codexModelPattern matches the header box: "│ model: gpt-5-codex high │".
+var codexModelPattern = regexp.MustCompile(`│\s*model:\s+([\w.-]+)`)
+
+func (codexDetector) ModelFromContent(content string) string {
+	return lastModelMatch(content, codexModelPattern)
+}
+
+func (codexDetector) HasApprovalPrompt(content string) bool {
+	return recentContains(content,
+		"Would you like to run the following command?",
+		"Would you like to make the following edits?",
+		"Allow command?",
+	)
+}
+
+// aiderDetector: aider. It shows "Waiting for <model>" while a request is in
+// flight and a "> " (or "<mode>> ") input prompt when done.
+type aiderDetector struct{ toolMatcher }
+
+func (aiderDetector) Patterns() *RawPatterns {
+	return &RawPatterns{
+		BusyPatterns:   []string{"Waiting for "},
+		PromptPatterns: []string{"(Y)es/(N)o"},
+		ErrorPatterns: append(defaultErrorPatterns(),
+			"litellm.RateLimitError",
+			"litellm.AuthenticationError",
+			"litellm.APIConnectionError",
+		),
+	}
+}
+
+func (aiderDetector) SpinnerMeansBusy(string, string) bool { return true }
+
+// aiderModelPattern matches the startup summary: "Main model: gpt-4o with
+// diff edit format" (older versions print "Model: ...").
+var aiderModelPattern = regexp.MustCompile(`(?m)^(?:Main model|Model): (\S+)`)
+
+func (aiderDetector) ModelFromContent(content string) string {
+	return lastModelMatch(content, aiderModelPattern)
+}
+
+// shellDetector: plain shells, and the fallback for custom tools.
+type shellDetector struct{ toolMatcher }
+
+func (shellDetector) Patterns() *RawPatterns {
+	return &RawPatterns{
+		PromptPatterns: []string{"$ ", "# ", "% "},
+	}
+}
+
+func (shellDetector) SpinnerMeansBusy(string, string) bool { return true }
+
+// HasApprovalPrompt is always false for shells: a [Y/n] there is ordinary
+// waiting, not an agent blocked on approval.
+func (shellDetector) HasApprovalPrompt(string) bool { return false }
+
+// recentContains reports whether the last lines of content contain any of
+// markers. Approval dialogs sit at the bottom of the pane; older ones in
+// scrollback have already been answered.
+func recentContains(content string, markers ...string) bool {
+	recent := strings.Join(lastNLines(StripANSI(content), 15), "\n")
+	for _, m := range markers {
+		if strings.Contains(recent, m) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastModelMatch returns the first group of the match nearest the end of
+// content across patterns, so a later /model switch beats the banner.
+func lastModelMatch(content string, patterns ...*regexp.Regexp) string {
+	model, at := "", -1
+	for _, re := range patterns {
+		for _, m := range re.FindAllStringSubmatchIndex(content, -1) {
+			if m[0] > at && m[2] >= 0 {
+				model, at = content[m[2]:m[3]], m[0]
+			}
+		}
+	}
+	return model
+}
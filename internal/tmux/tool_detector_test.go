@@ -0,0 +1,153 @@
+package tmux
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToolFromCommand(t *testing.T) {
+	tests := map[string]string{
+		"claude --resume abc":    "claude",
+		"/usr/bin/gemini":        "gemini",
+		"opencode":               "opencode",
+		"codex --full-auto":      "codex",
+		"aider --model sonnet":   "aider",
+		"bash":                   "",
+		"python script.py":       "",
+		"CLAUDE_CONFIG=x claude": "claude",
+	}
+	for cmd, want := range tests {
+		if got := toolFromCommand(cmd); got != want {
+			t.Errorf("toolFromCommand(%q) = %q, want %q", cmd, got, want)
+		}
+	}
+}
+
+func TestToolFromContent_Aider(t *testing.T) {
+	content := "Aider v0.82.1\nMain model: sonnet with diff edit format\n> "
+	if got := toolFromContent(content); got != "aider" {
+		t.Errorf("toolFromContent = %q, want aider", got)
+	}
+}
+
+func TestAiderDetector_HasPrompt(t *testing.T) {
+	d := NewPromptDetector("aider")
+	tests := []struct {
+		content string
+		want    bool
+	}{
+		{"Applied edit to main.go\n\n> ", true},
+		{"Tokens: 4k sent, 120 received.\n\narchitect> ", true},
+		{"Add main.go to the chat? (Y)es/(N)o [Yes]: ", true},
+		{"> fix the tests\n\nWaiting for claude-sonnet", false},
+		{"Editing main.go...", false},
+	}
+	for _, tt := range tests {
+		if got := d.HasPrompt(tt.content); got != tt.want {
+			t.Errorf("aider.HasPrompt(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestHasApprovalPrompt(t *testing.T) {
+	tests := []struct {
+		tool    string
+		content string
+		want    bool
+	}{
+		{"claude", "╭──────╮\n│ Bash command\n│ rm -rf build\n│ Do you want to proceed?\n│ ❯ 1. Yes\n│   2. No, and tell Claude what to do differently (esc)", true},
+		{"claude", "Do you want to allow Claude to fetch this content?\n❯ Yes\n  No", true},
+		{"claude", "Done. All tests pass.\n\n❯ ", false},
+		{"claude", "Which approach?\n❯ 1. Refactor\n  2. Rewrite\nUse arrow keys to navigate", false},
+		{"aider", "Add src/main.go to the chat? (Y)es/(N)o/(D)on't ask again [Yes]: ", true},
+		{"aider", "Applied edit to main.go\n> ", false},
+		{"codex", "Would you like to run the following command?\n$ make test\n▌ Yes, proceed", true},
+		{"gemini", "Allow execution of: 'npm install'?\n● 1. Yes, allow once", true},
+		{"shell", "Proceed? [Y/n] ", false},
+	}
+	for _, tt := range tests {
+		if got := detectorFor(tt.tool).HasApprovalPrompt(tt.content); got != tt.want {
+			t.Errorf("%s.HasApprovalPrompt(%q) = %v, want %v", tt.tool, tt.content, got, tt.want)
+		}
+	}
+
+	// An approval dialog that scrolled away has already been answered
+	old := "Do you want to allow this?\n" + strings.Repeat("output line\n", 30) + "❯ "
+	if detectorFor("claude").HasApprovalPrompt(old) {
+		t.Error("approval prompt in scrollback should not count")
+	}
+}
+
+func TestModelFromContent(t *testing.T) {
+	tests := []struct {
+		tool    string
+		content string
+		want    string
+	}{
+		{"claude", " ▐▛███▜▌   Claude Code v2.0.14\n▝▜█████▛▘  Sonnet 4.5 · Claude Max\n  ▘▘ ▝▝    ~/src/app", "Sonnet 4.5"},
+		{"claude", "Opus 4.1 · API Usage Billing\n> /model\n  ⎿  Set model to Haiku 4.5 (claude-haiku-4-5)", "Haiku 4.5"},
+		{"claude", "Should we use Sonnet 4 or Opus 4 here?", ""},
+		{"gemini", "> Type your message\n~/src/app   no sandbox   gemini-2.5-pro (98% context left)", "gemini-2.5-pro"},
+		{"codex", "│ >_ OpenAI Codex (v0.46.0)            │\n│ model:     gpt-5-codex high   /model │", "gpt-5-codex"},
+		{"aider", "Aider v0.86.1\nMain model: anthropic/claude-sonnet-4-20250514 with diff edit format\n> ", "anthropic/claude-sonnet-4-20250514"},
+	}
+	for _, tt := range tests {
+		md, ok := detectorFor(tt.tool).(ModelDetector)
+		if !ok {
+			t.Fatalf("%s detector should implement ModelDetector", tt.tool)
+		}
+		if got := md.ModelFromContent(tt.content); got != tt.want {
+			t.Errorf("%s.ModelFromContent(%q) = %q, want %q", tt.tool, tt.content, got, tt.want)
+		}
+	}
+	if _, ok := detectorFor("shell").(ModelDetector); ok {
+		t.Error("shells have no model")
+	}
+}
+
+type fakeToolDetector struct{ toolMatcher }
+
+func (fakeToolDetector) Patterns() *RawPatterns {
+	return &RawPatterns{BusyPatterns: []string{"thinking hard"}}
+}
+
+func (fakeToolDetector) HasPrompt(content string) bool {
+	return strings.HasSuffix(strings.TrimSpace(content), "fake>")
+}
+
+func (fakeToolDetector) HasApprovalPrompt(string) bool { return false }
+
+func (fakeToolDetector) SpinnerMeansBusy(string, string) bool { return false }
+
+func TestRegisterToolDetector(t *testing.T) {
+	RegisterToolDetector("fakeagent", fakeToolDetector{toolMatcher{commands: []string{"fakeagent"}}})
+	t.Cleanup(func() {
+		toolDetectorsMu.Lock()
+		defer toolDetectorsMu.Unlock()
+		delete(toolDetectors, "fakeagent")
+		toolDetectorIDs = toolDetectorIDs[:len(toolDetectorIDs)-1]
+	})
+
+	if got := toolFromCommand("fakeagent --yolo"); got != "fakeagent" {
+		t.Errorf("toolFromCommand = %q, want fakeagent", got)
+	}
+	raw := DefaultRawPatterns("FakeAgent")
+	if raw == nil || len(raw.BusyPatterns) != 1 {
+		t.Fatalf("DefaultRawPatterns = %+v, want the registered patterns", raw)
+	}
+	if !NewPromptDetector("fakeagent").HasPrompt("ready\nfake>") {
+		t.Error("registered HasPrompt should be used")
+	}
+	if detectorFor("fakeagent").SpinnerMeansBusy("⠋", "⠋ loading") {
+		t.Error("registered SpinnerMeansBusy should be used")
+	}
+}
+
+func TestDetectorFor_UnknownFallsBackToShell(t *testing.T) {
+	if _, ok := detectorFor("mytool").(shellDetector); !ok {
+		t.Error("unknown tools should use the shell detector")
+	}
+	if DefaultRawPatterns("mytool") != nil {
+		t.Error("unknown tools should have no default patterns")
+	}
+}
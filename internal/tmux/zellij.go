@@ -0,0 +1,123 @@
+package tmux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// zellijBackend runs sessions in Zellij (0.41+), driven through its CLI.
+// Sessions are created in the background and addressed with --session; keys
+// and captures go to the focused pane, which is the agent's only pane.
+type zellijBackend struct{}
+
+func zellijCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "zellij", args...)
+	// Inside a Zellij pane these make the CLI target the enclosing session
+	cmd.Env = environWithout(os.Environ(), "ZELLIJ", "ZELLIJ_SESSION_NAME", "ZELLIJ_PANE_ID")
+	return cmd
+}
+
+func zellijAction(ctx context.Context, name string, args ...string) *exec.Cmd {
+	return zellijCommand(ctx, append([]string{"--session", name, "action"}, args...)...)
+}
+
+// zellijActionCmd builds a zellijAction for runBounded.
+func zellijActionCmd(name string, args ...string) func(ctx context.Context) *exec.Cmd {
+	return func(ctx context.Context) *exec.Cmd { return zellijAction(ctx, name, args...) }
+}
+
+func (zellijBackend) Name() string { return BackendZellij }
+
+func (zellijBackend) Create(name, workDir string) error {
+	output, err := runBounded(false, func(ctx context.Context) *exec.Cmd {
+		cmd := zellijCommand(ctx, "attach", "--create-background", name)
+		cmd.Dir = workDir
+		return cmd
+	}, (*exec.Cmd).CombinedOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create zellij session: %w (output: %s)", err, string(output))
+	}
+	return nil
+}
+
+func (zellijBackend) Kill(name string) error {
+	// --force kills a running session; deleting also stops Zellij offering to resurrect it
+	_, err := runBounded(false, func(ctx context.Context) *exec.Cmd {
+		return zellijCommand(ctx, "delete-session", "--force", name)
+	}, runCmd)
+	return err
+}
+
+func (zellijBackend) Exists(name string) bool {
+	output, err := runBounded(true, func(ctx context.Context) *exec.Cmd {
+		return zellijCommand(ctx, "list-sessions", "--no-formatting")
+	}, (*exec.Cmd).Output)
+	if err != nil {
+		return false
+	}
+	return zellijSessionListed(string(output), name)
+}
+
+// zellijSessionListed reports whether list-sessions output contains a live
+// session called name. Exited sessions are listed as "(EXITED ...)".
+func zellijSessionListed(output, name string) bool {
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 && fields[0] == name && !strings.Contains(line, "EXITED") {
+			return true
+		}
+	}
+	return false
+}
+
+func (zellijBackend) Capture(ctx context.Context, name string, historyLines int) (string, error) {
+	f, err := os.CreateTemp("", "agentdeck-zellij-*.txt")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	f.Close()
+	defer os.Remove(path)
+
+	args := []string{"dump-screen"}
+	if historyLines > 0 {
+		args = append(args, "--full")
+	}
+	if output, err := zellijAction(ctx, name, append(args, path)...).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("%w (output: %s)", err, strings.TrimSpace(string(output)))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return lastLines(string(data), historyLines), nil
+}
+
+func (zellijBackend) SendKeys(name, keys string) error {
+	_, err := runBounded(false, zellijActionCmd(name, "write-chars", "--", keys), runCmd)
+	return err
+}
+
+func (zellijBackend) SendEnter(name string) error {
+	_, err := runBounded(false, zellijActionCmd(name, "write", "13"), runCmd)
+	return err
+}
+
+func (zellijBackend) AttachCommand(ctx context.Context, name string) *exec.Cmd {
+	return zellijCommand(ctx, "attach", name)
+}
+
+// environWithout returns env minus the given variables.
+func environWithout(env []string, keys ...string) []string {
+	filtered := make([]string, 0, len(env))
+	for _, kv := range env {
+		if name, _, _ := strings.Cut(kv, "="); !slices.Contains(keys, name) {
+			filtered = append(filtered, kv)
+		}
+	}
+	return filtered
+}
@@ -530,6 +530,9 @@ func (p *AnalyticsPanel) renderCost() string {
 			dimStyle.Render("Estimated:"),
 			valueStyle.Render(costStr),
 		))
+		if p.analytics.Model != "" {
+			b.WriteString(fmt.Sprintf("  %s %s\n", dimStyle.Render("Model:"), p.analytics.Model))
+		}
 	} else {
 		b.WriteString(dimStyle.Render("  (calculating...)\n"))
 	}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const (
+	archiveTranscriptLines = 20 // Transcript lines shown at once
+	archiveListRows        = 15 // Archived sessions shown at once
+)
+
+// ArchiveDialog lists archived sessions ("A" key). Home loads entries and
+// transcripts and performs restore/delete; the dialog only tracks selection.
+type ArchiveDialog struct {
+	visible       bool
+	width, height int
+	entries       []*session.ArchivedSession
+	cursor        int
+
+	// Transcript view for the selected entry ("v")
+	transcript      *session.ArchivedSession
+	transcriptStart int
+
+	// confirmDelete is set after the first "d" press; a second "d" deletes
+	confirmDelete bool
+}
+
+// NewArchiveDialog creates a new archive dialog.
+func NewArchiveDialog() *ArchiveDialog {
+	return &ArchiveDialog{}
+}
+
+// Show opens the dialog with the given archived sessions.
+func (d *ArchiveDialog) Show(entries []*session.ArchivedSession) {
+	d.visible = true
+	d.entries = entries
+	d.cursor = 0
+	d.transcript = nil
+	d.confirmDelete = false
+}
+
+// Hide closes the dialog and resets state.
+func (d *ArchiveDialog) Hide() {
+	d.visible = false
+	d.entries = nil
+	d.cursor = 0
+	d.transcript = nil
+	d.confirmDelete = false
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *ArchiveDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *ArchiveDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// GetSelected returns the entry at the cursor, or nil.
+func (d *ArchiveDialog) GetSelected() *session.ArchivedSession {
+	if len(d.entries) == 0 || d.cursor >= len(d.entries) {
+		return nil
+	}
+	return d.entries[d.cursor]
+}
+
+// IsViewingTranscript reports whether the transcript view is open.
+func (d *ArchiveDialog) IsViewingTranscript() bool {
+	return d.transcript != nil
+}
+
+// ShowTranscript switches to the transcript view, scrolled to the end.
+func (d *ArchiveDialog) ShowTranscript(a *session.ArchivedSession) {
+	d.transcript = a
+	lines := strings.Split(a.Transcript, "\n")
+	d.transcriptStart = max(0, len(lines)-archiveTranscriptLines)
+}
+
+// ConfirmingDelete reports whether the next "d" deletes the selected entry.
+func (d *ArchiveDialog) ConfirmingDelete() bool {
+	return d.confirmDelete
+}
+
+// SetConfirmDelete arms or disarms delete confirmation.
+func (d *ArchiveDialog) SetConfirmDelete(on bool) {
+	d.confirmDelete = on
+}
+
+// Remove drops an entry from the list (after restore or delete).
+func (d *ArchiveDialog) Remove(id string) {
+	for i, a := range d.entries {
+		if a.ID == id {
+			d.entries = append(d.entries[:i], d.entries[i+1:]...)
+			break
+		}
+	}
+	if d.cursor >= len(d.entries) && d.cursor > 0 {
+		d.cursor = len(d.entries) - 1
+	}
+	d.confirmDelete = false
+}
+
+// Update handles navigation keys. Enter, v, and d are handled by Home.
+func (d *ArchiveDialog) Update(msg tea.KeyMsg) (*ArchiveDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+
+	if d.transcript != nil {
+		total := len(strings.Split(d.transcript.Transcript, "\n"))
+		maxStart := max(0, total-archiveTranscriptLines)
+		switch msg.String() {
+		case "j", "down":
+			d.transcriptStart = min(d.transcriptStart+1, maxStart)
+		case "k", "up":
+			d.transcriptStart = max(d.transcriptStart-1, 0)
+		case "ctrl+d", "pgdown":
+			d.transcriptStart = min(d.transcriptStart+archiveTranscriptLines, maxStart)
+		case "ctrl+u", "pgup":
+			d.transcriptStart = max(d.transcriptStart-archiveTranscriptLines, 0)
+		case "esc", "q", "v":
+			d.transcript = nil
+		}
+		return d, nil
+	}
+
+	d.confirmDelete = false
+	switch msg.String() {
+	case "j", "down":
+		if len(d.entries) > 0 {
+			d.cursor = (d.cursor + 1) % len(d.entries)
+		}
+	case "k", "up":
+		if len(d.entries) > 0 {
+			d.cursor = (d.cursor - 1 + len(d.entries)) % len(d.entries)
+		}
+	case "esc":
+		d.Hide()
+	}
+	return d, nil
+}
+
+// View renders the archive dialog.
+func (d *ArchiveDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent).
+		MarginBottom(1)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 64
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	var lines []string
+	if d.transcript != nil {
+		lines = append(lines, titleStyle.Render(fmt.Sprintf("Archived: %s", d.transcript.Title)))
+		if d.transcript.Transcript == "" {
+			lines = append(lines, dimStyle.Render("(no output was captured)"))
+		} else {
+			all := strings.Split(d.transcript.Transcript, "\n")
+			end := min(d.transcriptStart+archiveTranscriptLines, len(all))
+			for _, line := range all[d.transcriptStart:end] {
+				lines = append(lines, runewidth.Truncate(line, dialogWidth-4, "..."))
+			}
+		}
+		lines = append(lines, "")
+		lines = append(lines, footerStyle.Render("j/k scroll | Esc back"))
+	} else {
+		lines = append(lines, titleStyle.Render("Archived Sessions"))
+		if len(d.entries) == 0 {
+			lines = append(lines, normalStyle.Render("No archived sessions"))
+		}
+		start := max(0, d.cursor-archiveListRows+1)
+		end := min(start+archiveListRows, len(d.entries))
+		for i := start; i < end; i++ {
+			a := d.entries[i]
+			label := a.Title
+			meta := dimStyle.Render(fmt.Sprintf("  %s · %s", a.GroupPath, a.ArchivedAt.Format("2006-01-02 15:04")))
+			if i == d.cursor {
+				lines = append(lines, "> "+selectedStyle.Render(label)+meta)
+			} else {
+				lines = append(lines, "  "+normalStyle.Render(label)+meta)
+			}
+		}
+		if len(d.entries) > archiveListRows {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d", d.cursor+1, len(d.entries))))
+		}
+		lines = append(lines, "")
+		if d.confirmDelete {
+			lines = append(lines, lipgloss.NewStyle().Foreground(ColorRed).Render("Press d again to delete permanently"))
+		}
+		lines = append(lines, footerStyle.Render("Enter restore | v view output | d delete | Esc close"))
+	}
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
@@ -0,0 +1,64 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestArchiveDialog_NavigateAndRemove(t *testing.T) {
+	d := NewArchiveDialog()
+	d.Show([]*session.ArchivedSession{
+		{ID: "a", Title: "alpha", ArchivedAt: time.Now()},
+		{ID: "b", Title: "beta", ArchivedAt: time.Now()},
+	})
+	if got := d.GetSelected(); got == nil || got.ID != "a" {
+		t.Fatalf("initial selection = %+v, want a", got)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	d.SetConfirmDelete(true)
+	d.Remove("b")
+	if got := d.GetSelected(); got == nil || got.ID != "a" {
+		t.Errorf("cursor should move back after removing the last entry, got %+v", got)
+	}
+	if d.ConfirmingDelete() {
+		t.Error("Remove should disarm delete confirmation")
+	}
+
+	// Any navigation disarms a pending delete
+	d.SetConfirmDelete(true)
+	d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if d.ConfirmingDelete() {
+		t.Error("navigation should disarm delete confirmation")
+	}
+}
+
+func TestArchiveDialog_Transcript(t *testing.T) {
+	d := NewArchiveDialog()
+	d.SetSize(120, 50)
+	entry := &session.ArchivedSession{ID: "a", Title: "alpha", ArchivedAt: time.Now()}
+	d.Show([]*session.ArchivedSession{entry})
+
+	var lines []string
+	for i := 1; i <= 30; i++ {
+		lines = append(lines, "line-"+string(rune('A'+i-1)))
+	}
+	full := *entry
+	full.Transcript = strings.Join(lines, "\n")
+	d.ShowTranscript(&full)
+
+	view := d.View()
+	if !strings.Contains(view, "line-"+string(rune('A'+29))) || strings.Contains(view, "line-A") {
+		t.Errorf("transcript view should start scrolled to the end:\n%s", view)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsViewingTranscript() || !d.IsVisible() {
+		t.Error("esc in transcript view should return to the list")
+	}
+}
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const cleanupRows = 15 // Sessions shown at once
+
+// CleanupDialog lists the sessions the idle-cleanup policy would archive or
+// kill ("C" key), all checked to start with. Home runs the action on the
+// checked sessions on Enter; the dialog only tracks selection.
+type CleanupDialog struct {
+	visible       bool
+	width, height int
+	sessions      []*session.Instance
+	checked       []bool
+	action        string // "archive" or "kill"
+	idleFor       time.Duration
+	cursor        int
+}
+
+// NewCleanupDialog creates a new cleanup dialog.
+func NewCleanupDialog() *CleanupDialog {
+	return &CleanupDialog{}
+}
+
+// Show opens the dialog with the idle sessions, all checked.
+func (d *CleanupDialog) Show(sessions []*session.Instance, action string, idleFor time.Duration) {
+	d.visible = true
+	d.sessions = sessions
+	d.checked = make([]bool, len(sessions))
+	for i := range d.checked {
+		d.checked[i] = true
+	}
+	d.action = action
+	d.idleFor = idleFor
+	d.cursor = 0
+}
+
+// Hide closes the dialog and resets state.
+func (d *CleanupDialog) Hide() {
+	d.visible = false
+	d.sessions = nil
+	d.checked = nil
+	d.cursor = 0
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *CleanupDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *CleanupDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// Action returns what cleanup does to the checked sessions.
+func (d *CleanupDialog) Action() string {
+	return d.action
+}
+
+// GetChecked returns the sessions checked for cleanup.
+func (d *CleanupDialog) GetChecked() []*session.Instance {
+	var out []*session.Instance
+	for i, inst := range d.sessions {
+		if d.checked[i] {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// Update handles navigation and checking. Enter is handled by Home.
+func (d *CleanupDialog) Update(msg tea.KeyMsg) (*CleanupDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		if len(d.sessions) > 0 {
+			d.cursor = (d.cursor + 1) % len(d.sessions)
+		}
+	case "k", "up":
+		if len(d.sessions) > 0 {
+			d.cursor = (d.cursor - 1 + len(d.sessions)) % len(d.sessions)
+		}
+	case " ", "x":
+		if d.cursor < len(d.checked) {
+			d.checked[d.cursor] = !d.checked[d.cursor]
+		}
+	case "a":
+		// Check all, or uncheck all when everything is checked
+		all := len(d.GetChecked()) == len(d.sessions)
+		for i := range d.checked {
+			d.checked[i] = !all
+		}
+	case "esc", "q":
+		d.Hide()
+	}
+	return d, nil
+}
+
+// View renders the cleanup dialog.
+func (d *CleanupDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 72
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	verb := "archive"
+	if d.action == "kill" {
+		verb = "kill"
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Clean Up Idle Sessions"))
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("Idle for over %s · %s checked sessions", formatIdleThreshold(d.idleFor), verb)))
+	lines = append(lines, "")
+	if len(d.sessions) == 0 {
+		lines = append(lines, normalStyle.Render("No idle sessions"))
+	}
+
+	start := max(0, d.cursor-cleanupRows+1)
+	end := min(start+cleanupRows, len(d.sessions))
+	for i := start; i < end; i++ {
+		inst := d.sessions[i]
+		box := "[ ] "
+		if d.checked[i] {
+			box = "[x] "
+		}
+		meta := "last active " + formatRelativeTime(inst.IdleSince())
+		if inst.GroupPath != "" {
+			meta = inst.GroupPath + " · " + meta
+		}
+		titleWidth := max(dialogWidth-10-runewidth.StringWidth(meta)-2, 10)
+		title := runewidth.Truncate(inst.Title, titleWidth, "...")
+
+		style, cursor := normalStyle, "  "
+		if i == d.cursor {
+			style, cursor = selectedStyle, "> "
+		}
+		lines = append(lines, cursor+style.Render(box+title)+"  "+dimStyle.Render(meta))
+	}
+	if len(d.sessions) > cleanupRows {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d", d.cursor+1, len(d.sessions))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render(fmt.Sprintf("Space toggle | a all/none | Enter %s %d | Esc cancel", verb, len(d.GetChecked()))))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
+
+// formatIdleThreshold renders the policy's idle threshold in days when it is
+// a whole number of them, else in hours.
+func formatIdleThreshold(d time.Duration) string {
+	n, unit := int(d.Hours()), "hour"
+	if n >= 24 && n%24 == 0 {
+		n, unit = n/24, "day"
+	}
+	if n == 1 {
+		return "1 " + unit
+	}
+	return fmt.Sprintf("%d %ss", n, unit)
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestCleanupDialog_Checking(t *testing.T) {
+	old := time.Now().Add(-96 * time.Hour)
+	idle := []*session.Instance{
+		{ID: "a", Title: "stale-a", GroupPath: "work", CreatedAt: old},
+		{ID: "b", Title: "stale-b", CreatedAt: old},
+	}
+
+	d := NewCleanupDialog()
+	d.SetSize(120, 50)
+	d.Show(idle, "archive", 72*time.Hour)
+	if got := len(d.GetChecked()); got != 2 {
+		t.Fatalf("all sessions should start checked, got %d", got)
+	}
+
+	view := d.View()
+	for _, want := range []string{"3 days", "[x] stale-a", "work · last active", "Enter archive 2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if got := d.GetChecked(); len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("space should uncheck the first session, got %+v", got)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if got := len(d.GetChecked()); got != 2 {
+		t.Errorf("a should check everything when some are unchecked, got %d", got)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	if got := len(d.GetChecked()); got != 0 {
+		t.Errorf("a should uncheck everything when all are checked, got %d", got)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsVisible() || d.GetChecked() != nil {
+		t.Error("Esc should hide and clear the dialog")
+	}
+}
+
+func TestFormatIdleThreshold(t *testing.T) {
+	cases := map[time.Duration]string{
+		time.Hour:       "1 hour",
+		36 * time.Hour:  "36 hours",
+		24 * time.Hour:  "1 day",
+		168 * time.Hour: "7 days",
+	}
+	for d, want := range cases {
+		if got := formatIdleThreshold(d); got != want {
+			t.Errorf("formatIdleThreshold(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -18,6 +18,8 @@ const (
 	ConfirmQuitWithPool
 	ConfirmCreateDirectory
 	ConfirmInstallHooks
+	ConfirmResurrectSessions
+	ConfirmSessionNotRunning
 )
 
 // ConfirmDialog handles confirmation for destructive actions
@@ -30,12 +32,21 @@ type ConfirmDialog struct {
 	height      int
 	mcpCount    int // Number of running MCPs (for quit confirmation)
 
+	// Worktree of the session being deleted, if it has one
+	worktreePath string
+
 	// Pending session creation data (for ConfirmCreateDirectory)
 	pendingSessionName      string
 	pendingSessionPath      string
 	pendingSessionCommand   string
 	pendingSessionGroupPath string
 	pendingToolOptionsJSON  json.RawMessage // Generic tool options (claude, codex, etc.)
+
+	// Sessions to recreate (for ConfirmResurrectSessions)
+	pendingSessionIDs []string
+
+	// The session was killed outside agent-deck (for ConfirmSessionNotRunning)
+	dead bool
 }
 
 // NewConfirmDialog creates a new confirmation dialog
@@ -43,12 +54,14 @@ func NewConfirmDialog() *ConfirmDialog {
 	return &ConfirmDialog{}
 }
 
-// ShowDeleteSession shows confirmation for session deletion
-func (c *ConfirmDialog) ShowDeleteSession(sessionID, sessionName string) {
+// ShowDeleteSession shows confirmation for session deletion. worktreePath is
+// the session's git worktree, or "" if it has none.
+func (c *ConfirmDialog) ShowDeleteSession(sessionID, sessionName, worktreePath string) {
 	c.visible = true
 	c.confirmType = ConfirmDeleteSession
 	c.targetID = sessionID
 	c.targetName = sessionName
+	c.worktreePath = worktreePath
 }
 
 // ShowDeleteGroup shows confirmation for group deletion
@@ -89,6 +102,40 @@ func (c *ConfirmDialog) ShowInstallHooks() {
 	c.targetName = ""
 }
 
+// ShowResurrectSessions shows confirmation for recreating sessions whose tmux
+// sessions are gone, e.g. after a reboot
+func (c *ConfirmDialog) ShowResurrectSessions(ids, titles []string) {
+	c.visible = true
+	c.confirmType = ConfirmResurrectSessions
+	c.targetID = ""
+	c.pendingSessionIDs = ids
+
+	const maxListed = 5
+	listed := titles
+	if len(listed) > maxListed {
+		listed = listed[:maxListed]
+	}
+	c.targetName = "  • " + strings.Join(listed, "\n  • ")
+	if extra := len(titles) - len(listed); extra > 0 {
+		c.targetName += fmt.Sprintf("\n  … and %d more", extra)
+	}
+}
+
+// ShowSessionNotRunning offers to restart or remove a session whose tmux
+// session is gone. dead says it was killed outside agent-deck.
+func (c *ConfirmDialog) ShowSessionNotRunning(sessionID, sessionName string, dead bool) {
+	c.visible = true
+	c.confirmType = ConfirmSessionNotRunning
+	c.targetID = sessionID
+	c.targetName = sessionName
+	c.dead = dead
+}
+
+// GetPendingSessionIDs returns the sessions to recreate
+func (c *ConfirmDialog) GetPendingSessionIDs() []string {
+	return c.pendingSessionIDs
+}
+
 // GetPendingSession returns the pending session creation data
 func (c *ConfirmDialog) GetPendingSession() (name, path, command, groupPath string, toolOptionsJSON json.RawMessage) {
 	return c.pendingSessionName, c.pendingSessionPath, c.pendingSessionCommand, c.pendingSessionGroupPath, c.pendingToolOptionsJSON
@@ -99,6 +146,9 @@ func (c *ConfirmDialog) Hide() {
 	c.visible = false
 	c.targetID = ""
 	c.targetName = ""
+	c.worktreePath = ""
+	c.pendingSessionIDs = nil
+	c.dead = false
 }
 
 // IsVisible returns whether the dialog is visible
@@ -148,7 +198,10 @@ func (c *ConfirmDialog) View() string {
 	case ConfirmDeleteSession:
 		title = "⚠️  Delete Session?"
 		warning = fmt.Sprintf("This will PERMANENTLY KILL the tmux session:\n\n  \"%s\"", c.targetName)
-		details = "• The tmux session will be terminated\n• Any running processes will be killed\n• Terminal history will be lost\n• Press Ctrl+Z after deletion to undo"
+		details = "• The tmux session will be terminated\n• Any running processes will be killed\n• Terminal history will be lost\n• Press Ctrl+Z after deletion to undo\n• Press a to archive instead (keeps the output; A to view)"
+		if c.worktreePath != "" {
+			details += "\n• Its worktree will be removed:\n    " + c.worktreePath + "\n• Press k to delete but keep the worktree"
+		}
 		borderColor = ColorRed
 
 		buttonYes := lipgloss.NewStyle().
@@ -157,6 +210,12 @@ func (c *ConfirmDialog) View() string {
 			Padding(0, 2).
 			Bold(true).
 			Render("y Delete")
+		buttonArchive := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorYellow).
+			Padding(0, 2).
+			Bold(true).
+			Render("a Archive")
 		buttonNo := lipgloss.NewStyle().
 			Foreground(ColorBg).
 			Background(ColorAccent).
@@ -166,7 +225,7 @@ func (c *ConfirmDialog) View() string {
 		escHint := lipgloss.NewStyle().
 			Foreground(ColorTextDim).
 			Render("(Esc to cancel)")
-		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
+		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonArchive, "  ", buttonNo, "  ", escHint)
 
 	case ConfirmDeleteGroup:
 		title = "⚠️  Delete Group?"
@@ -260,6 +319,56 @@ func (c *ConfirmDialog) View() string {
 			Foreground(ColorTextDim).
 			Render("(Esc to skip)")
 		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
+
+	case ConfirmResurrectSessions:
+		title = "Sessions Not Running"
+		warning = fmt.Sprintf("None of your %d sessions are running (tmux restarted?):\n\n%s", len(c.pendingSessionIDs), c.targetName)
+		details = "Recreate them in their directories? Claude sessions resume\ntheir conversation. Later: agent-deck resurrect, or R per session."
+		borderColor = ColorAccent
+
+		buttonYes := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorGreen).
+			Padding(0, 2).
+			Bold(true).
+			Render("y Resurrect")
+		buttonNo := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorAccent).
+			Padding(0, 2).
+			Bold(true).
+			Render("n Skip")
+		escHint := lipgloss.NewStyle().
+			Foreground(ColorTextDim).
+			Render("(Esc to skip)")
+		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonYes, "  ", buttonNo, "  ", escHint)
+
+	case ConfirmSessionNotRunning:
+		title = "Session Not Running"
+		warning = fmt.Sprintf("There is no tmux session to attach to:\n\n  \"%s\"", c.targetName)
+		if c.dead {
+			title = "Session Killed"
+			warning = fmt.Sprintf("Its tmux session was killed outside agent-deck:\n\n  \"%s\"", c.targetName)
+		}
+		details = "Restart it in its directory (resuming the conversation\nwhen possible), or remove it from the list."
+		borderColor = ColorAccent
+
+		buttonRestart := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorGreen).
+			Padding(0, 2).
+			Bold(true).
+			Render("r Restart")
+		buttonRemove := lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorRed).
+			Padding(0, 2).
+			Bold(true).
+			Render("d Remove")
+		escHint := lipgloss.NewStyle().
+			Foreground(ColorTextDim).
+			Render("(Esc to cancel)")
+		buttons = lipgloss.JoinHorizontal(lipgloss.Center, buttonRestart, "  ", buttonRemove, "  ", escHint)
 	}
 
 	// Title style
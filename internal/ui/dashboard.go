@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const (
+	dashboardGroupRows   = 8 // Groups listed before "+N more"
+	dashboardWaitingRows = 5 // Longest-waiting sessions listed
+)
+
+// dashboardStatuses are the statuses the dashboard counts, in display order.
+var dashboardStatuses = []session.Status{
+	session.StatusRunning,
+	session.StatusNeedsInput,
+	session.StatusWaiting,
+	session.StatusIdle,
+	session.StatusError,
+	session.StatusStarting,
+}
+
+// Dashboard summarizes the whole deck on one screen ("w" key): sessions per
+// status, tool and group, today's token usage, and the sessions that have
+// waited longest for a reply. Counts are taken from the live sessions on
+// every render; usage is read from transcripts by Home and arrives later.
+type Dashboard struct {
+	visible       bool
+	width, height int
+	instances     []*session.Instance
+	usage         session.Usage
+	usageSessions int  // Sessions with usage today
+	usageLoaded   bool // False while Home reads transcripts
+}
+
+// dashboardCount is a name with its session count.
+type dashboardCount struct {
+	name  string
+	count int
+}
+
+// NewDashboard creates a new dashboard.
+func NewDashboard() *Dashboard {
+	return &Dashboard{}
+}
+
+// Show opens the dashboard over the given sessions. Today's usage shows as
+// loading until SetUsage.
+func (d *Dashboard) Show(instances []*session.Instance) {
+	d.visible = true
+	d.instances = instances
+	d.usage = session.Usage{}
+	d.usageSessions = 0
+	d.usageLoaded = false
+}
+
+// Hide closes the dashboard.
+func (d *Dashboard) Hide() {
+	d.visible = false
+	d.instances = nil
+}
+
+// IsVisible returns whether the dashboard is currently shown.
+func (d *Dashboard) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dashboard dimensions for centering.
+func (d *Dashboard) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// SetInstances replaces the sessions summarized, e.g. after a reload.
+func (d *Dashboard) SetInstances(instances []*session.Instance) {
+	d.instances = instances
+}
+
+// SetUsage records today's usage totals across sessions.
+func (d *Dashboard) SetUsage(usage session.Usage, sessions int) {
+	d.usage = usage
+	d.usageSessions = sessions
+	d.usageLoaded = true
+}
+
+// Update handles keys; the dashboard only closes.
+func (d *Dashboard) Update(msg tea.KeyMsg) (*Dashboard, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "esc", "q", "w", "enter":
+		d.Hide()
+	}
+	return d, nil
+}
+
+// longestWaiting returns the sessions waiting on the user (waiting or needs
+// input), longest waiting first.
+func longestWaiting(instances []*session.Instance, n int) []*session.Instance {
+	var waiting []*session.Instance
+	for _, inst := range instances {
+		switch inst.GetStatusThreadSafe() {
+		case session.StatusWaiting, session.StatusNeedsInput:
+			waiting = append(waiting, inst)
+		}
+	}
+	since := make(map[*session.Instance]int64, len(waiting))
+	for _, inst := range waiting {
+		since[inst] = inst.IdleSince().UnixNano()
+	}
+	sort.SliceStable(waiting, func(a, b int) bool {
+		return since[waiting[a]] < since[waiting[b]]
+	})
+	if len(waiting) > n {
+		waiting = waiting[:n]
+	}
+	return waiting
+}
+
+// countBy counts sessions by key, most first, ties by name.
+func countBy(instances []*session.Instance, key func(*session.Instance) string) []dashboardCount {
+	counts := make(map[string]int)
+	for _, inst := range instances {
+		counts[key(inst)]++
+	}
+	out := make([]dashboardCount, 0, len(counts))
+	for name, count := range counts {
+		out = append(out, dashboardCount{name, count})
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].count != out[b].count {
+			return out[a].count > out[b].count
+		}
+		return out[a].name < out[b].name
+	})
+	return out
+}
+
+// View renders the dashboard.
+func (d *Dashboard) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	headingStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorText)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 72
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+	textWidth := dialogWidth - 6
+
+	statuses := make(map[session.Status]int)
+	for _, inst := range d.instances {
+		statuses[inst.GetStatusThreadSafe()]++
+	}
+	groups := countBy(d.instances, func(inst *session.Instance) string { return inst.GroupPath })
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Dashboard"))
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("%d sessions in %d groups", len(d.instances), len(groups))))
+	lines = append(lines, "")
+
+	// Status
+	var parts []string
+	for _, status := range dashboardStatuses {
+		if n := statuses[status]; n > 0 {
+			label := strings.ReplaceAll(string(status), "_", " ")
+			parts = append(parts, StatusIndicator(string(status))+normalStyle.Render(fmt.Sprintf(" %d %s", n, label)))
+		}
+	}
+	lines = append(lines, headingStyle.Render("Status"))
+	if len(parts) == 0 {
+		lines = append(lines, dimStyle.Render("  no sessions"))
+	} else {
+		lines = append(lines, "  "+strings.Join(parts, dimStyle.Render(" · ")))
+	}
+	lines = append(lines, "")
+
+	// Today's usage
+	lines = append(lines, headingStyle.Render("Today"))
+	switch {
+	case !d.usageLoaded:
+		lines = append(lines, dimStyle.Render("  reading transcripts..."))
+	case d.usage.TotalTokens() == 0:
+		lines = append(lines, dimStyle.Render("  no token usage yet (Claude and Gemini sessions)"))
+	default:
+		lines = append(lines, normalStyle.Render(fmt.Sprintf("  %s tokens · $%.2f", formatNumber(d.usage.TotalTokens()), d.usage.Cost))+
+			dimStyle.Render(fmt.Sprintf(" · %d sessions", d.usageSessions)))
+	}
+	lines = append(lines, "")
+
+	// Per tool
+	parts = parts[:0]
+	for _, c := range countBy(d.instances, func(inst *session.Instance) string { return inst.Tool }) {
+		name := c.name
+		if name == "" {
+			name = "shell"
+		}
+		parts = append(parts, fmt.Sprintf("%s %d", name, c.count))
+	}
+	if len(parts) > 0 {
+		lines = append(lines, headingStyle.Render("Tools"))
+		lines = append(lines, normalStyle.Render("  "+runewidth.Truncate(strings.Join(parts, " · "), textWidth-2, "...")))
+		lines = append(lines, "")
+	}
+
+	// Per group
+	if len(groups) > 0 {
+		lines = append(lines, headingStyle.Render("Groups"))
+		nameWidth := textWidth - 10
+		for i, c := range groups {
+			if i == dashboardGroupRows {
+				lines = append(lines, dimStyle.Render(fmt.Sprintf("  +%d more", len(groups)-i)))
+				break
+			}
+			name := c.name
+			if name == "" {
+				name = "(ungrouped)"
+			}
+			name = runewidth.FillRight(runewidth.Truncate(name, nameWidth, "..."), nameWidth)
+			lines = append(lines, normalStyle.Render(fmt.Sprintf("  %s %4d", name, c.count)))
+		}
+		lines = append(lines, "")
+	}
+
+	// Longest waiting
+	if waiting := longestWaiting(d.instances, dashboardWaitingRows); len(waiting) > 0 {
+		lines = append(lines, headingStyle.Render("Waiting longest"))
+		for _, inst := range waiting {
+			meta := "since " + formatRelativeTime(inst.IdleSince())
+			if inst.GroupPath != "" {
+				meta = inst.GroupPath + " · " + meta
+			}
+			titleWidth := max(textWidth-4-runewidth.StringWidth(meta)-2, 10)
+			title := runewidth.Truncate(inst.Title, titleWidth, "...")
+			lines = append(lines, "  "+StatusIndicator(string(inst.GetStatusThreadSafe()))+" "+
+				normalStyle.Render(title)+"  "+dimStyle.Render(meta))
+		}
+		lines = append(lines, "")
+	}
+
+	lines = append(lines, footerStyle.Render("Esc close"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestDashboardView(t *testing.T) {
+	now := time.Now()
+	older := &session.Instance{ID: "1", Title: "older", Tool: "claude", GroupPath: "work", Status: session.StatusWaiting, CreatedAt: now.Add(-3 * time.Hour)}
+	newer := &session.Instance{ID: "2", Title: "newer", Tool: "claude", GroupPath: "work", Status: session.StatusNeedsInput, CreatedAt: now.Add(-time.Hour)}
+	idle := &session.Instance{ID: "3", Title: "idle", Tool: "gemini", GroupPath: "home", Status: session.StatusIdle, CreatedAt: now}
+
+	if got := longestWaiting([]*session.Instance{newer, idle, older}, 5); len(got) != 2 || got[0] != older {
+		t.Errorf("longestWaiting = %v, want older then newer", got)
+	}
+
+	d := NewDashboard()
+	d.SetSize(120, 50)
+	d.Show([]*session.Instance{older, newer, idle})
+	view := d.View()
+	for _, want := range []string{"3 sessions in 2 groups", "1 waiting", "1 needs input", "claude 2 · gemini 1", "reading transcripts"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+	if strings.Index(view, "older") > strings.Index(view, "newer") {
+		t.Error("the longest waiting session should be listed first")
+	}
+
+	d.SetUsage(session.Usage{InputTokens: 12000, OutputTokens: 345, Cost: 1.5}, 2)
+	if view := d.View(); !strings.Contains(view, "12,345 tokens · $1.50") {
+		t.Error("view should show today's usage")
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsVisible() {
+		t.Error("esc should close the dashboard")
+	}
+}
+
+func TestHomeDashboardKey(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 40
+	home.initialLoading = false
+	setSessions(home, session.NewInstance("one", "/tmp/one"))
+
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if !home.dashboard.IsVisible() {
+		t.Fatal("w should open the dashboard")
+	}
+	if cmd == nil {
+		t.Fatal("opening the dashboard should load today's usage")
+	}
+	home.Update(dashboardUsageMsg{usage: session.Usage{OutputTokens: 10}, sessions: 1})
+	if !strings.Contains(home.View(), "10 tokens") {
+		t.Error("the loaded usage should be shown")
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}})
+	if home.dashboard.IsVisible() {
+		t.Error("w again should close the dashboard")
+	}
+}
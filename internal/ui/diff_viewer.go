@@ -0,0 +1,197 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// DiffViewer shows the full git diff of a session's working directory ("D"
+// key), with added and removed lines colored.
+type DiffViewer struct {
+	visible       bool
+	width, height int
+	title         string
+	path          string
+	raw           []string // Diff lines, tabs expanded
+	lines         []string // Rendered for the current width
+	files         int      // Files in the diff
+	offset        int      // First visible line
+}
+
+// NewDiffViewer creates a new diff viewer.
+func NewDiffViewer() *DiffViewer {
+	return &DiffViewer{}
+}
+
+// Show opens the viewer at the top of the diff.
+func (v *DiffViewer) Show(title, path, diff string) {
+	v.visible = true
+	v.title = title
+	v.path = path
+	v.raw = strings.Split(strings.TrimRight(strings.ReplaceAll(diff, "\t", "    "), "\n"), "\n")
+	v.files = 0
+	for _, line := range v.raw {
+		if strings.HasPrefix(line, "diff --git ") {
+			v.files++
+		}
+	}
+	v.render()
+	v.offset = 0
+}
+
+// Hide closes the viewer and resets state.
+func (v *DiffViewer) Hide() {
+	v.visible = false
+	v.raw = nil
+	v.lines = nil
+	v.offset = 0
+}
+
+// IsVisible returns whether the viewer is currently shown.
+func (v *DiffViewer) IsVisible() bool {
+	return v.visible
+}
+
+// SetSize updates the viewer dimensions and re-renders the diff.
+func (v *DiffViewer) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+	if v.visible {
+		v.render()
+		v.offset = min(v.offset, v.maxOffset())
+	}
+}
+
+// boxWidth is the width of the dialog box, leaving a margin on each side.
+func (v *DiffViewer) boxWidth() int {
+	if v.width <= 0 {
+		return 100
+	}
+	return max(v.width-8, 30)
+}
+
+// pageSize is the number of diff lines visible at once.
+func (v *DiffViewer) pageSize() int {
+	if v.height <= 0 {
+		return 30
+	}
+	return max(v.height-12, 5) // Title, position, footer and box padding
+}
+
+func (v *DiffViewer) maxOffset() int {
+	return max(0, len(v.lines)-v.pageSize())
+}
+
+// render colors each diff line and cuts it to the box width; long lines are
+// truncated rather than wrapped so hunks keep their shape.
+func (v *DiffViewer) render() {
+	textWidth := v.boxWidth() - 6
+	v.lines = make([]string, len(v.raw))
+	for i, line := range v.raw {
+		v.lines[i] = lipgloss.NewStyle().Foreground(diffLineColor(line)).
+			Render(runewidth.Truncate(line, textWidth, "…"))
+	}
+}
+
+// diffLineColor picks the color of a unified diff line.
+func diffLineColor(line string) lipgloss.Color {
+	switch {
+	case strings.HasPrefix(line, "diff --git "), strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+		return ColorAccent
+	case strings.HasPrefix(line, "@@"):
+		return ColorCyan
+	case strings.HasPrefix(line, "+"):
+		return ColorGreen
+	case strings.HasPrefix(line, "-"):
+		return ColorRed
+	case strings.HasPrefix(line, "index "), strings.HasPrefix(line, "new file"), strings.HasPrefix(line, "deleted file"):
+		return ColorComment
+	default:
+		return ColorText
+	}
+}
+
+// Update handles scrolling keys.
+func (v *DiffViewer) Update(msg tea.KeyMsg) (*DiffViewer, tea.Cmd) {
+	if !v.visible {
+		return v, nil
+	}
+	half := max(v.pageSize()/2, 1)
+	switch msg.String() {
+	case "j", "down":
+		v.offset++
+	case "k", "up":
+		v.offset--
+	case "ctrl+d", "pgdown", " ":
+		v.offset += half
+	case "ctrl+u", "pgup":
+		v.offset -= half
+	case "g", "home":
+		v.offset = 0
+	case "G", "end":
+		v.offset = v.maxOffset()
+	case "n":
+		v.jumpFile(1)
+	case "N":
+		v.jumpFile(-1)
+	case "esc", "q", "D":
+		v.Hide()
+		return v, nil
+	}
+	v.offset = max(0, min(v.offset, v.maxOffset()))
+	return v, nil
+}
+
+// jumpFile scrolls to the next (dir 1) or previous (dir -1) file header.
+func (v *DiffViewer) jumpFile(dir int) {
+	for i := v.offset + dir; i >= 0 && i < len(v.raw); i += dir {
+		if strings.HasPrefix(v.raw[i], "diff --git ") {
+			v.offset = i
+			return
+		}
+	}
+}
+
+// View renders the diff viewer.
+func (v *DiffViewer) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Diff: "+v.title))
+	info := fmt.Sprintf("%s · %d files", truncatePath(v.path, v.boxWidth()-30), v.files)
+	if len(v.lines) > v.pageSize() {
+		end := min(v.offset+v.pageSize(), len(v.lines))
+		info += fmt.Sprintf(" · lines %d-%d of %d", v.offset+1, end, len(v.lines))
+	}
+	lines = append(lines, dimStyle.Render(info))
+	lines = append(lines, "")
+
+	end := min(v.offset+v.pageSize(), len(v.lines))
+	lines = append(lines, v.lines[v.offset:end]...)
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("j/k scroll | ctrl+d/u half page | n/N next/prev file | g/G top/end | Esc close"))
+
+	box := DialogBoxStyle.
+		Width(v.boxWidth()).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, v.width, v.height)
+}
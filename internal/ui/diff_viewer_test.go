@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestDiffViewer_ScrollAndFileJumps(t *testing.T) {
+	var diff strings.Builder
+	for _, name := range []string{"a.go", "b.go"} {
+		fmt.Fprintf(&diff, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n@@ -1,30 +1,30 @@\n", name, name, name, name)
+		for i := 0; i < 30; i++ {
+			fmt.Fprintf(&diff, "+%s line %02d\n", name, i)
+		}
+	}
+
+	v := NewDiffViewer()
+	v.SetSize(100, 30)
+	v.Show("alpha", "/tmp/alpha", diff.String())
+
+	view := v.View()
+	if !strings.Contains(view, "Diff: alpha") || !strings.Contains(view, "2 files") || !strings.Contains(view, "a.go line 00") {
+		t.Errorf("viewer should open at the top of the diff:\n%s", view)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if !strings.HasPrefix(v.raw[v.offset], "diff --git a/b.go") {
+		t.Errorf("n should jump to the next file, at %q", v.raw[v.offset])
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'N'}})
+	if v.offset != 0 {
+		t.Errorf("N should jump back to the first file, offset = %d", v.offset)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'G'}})
+	if !strings.Contains(v.View(), "b.go line 29") {
+		t.Error("G should jump to the end")
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if v.IsVisible() {
+		t.Error("Esc should close the viewer")
+	}
+}
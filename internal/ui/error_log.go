@@ -0,0 +1,246 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+const errorLogSize = 50 // Errors kept; the oldest is overwritten first
+
+// ErrorEntry is one logged error. The same error reported again in a row
+// bumps Count instead of taking another slot.
+type ErrorEntry struct {
+	Text      string
+	At        time.Time // Last time it was reported
+	Count     int
+	Dismissed bool
+}
+
+// ErrorLog is a ring buffer of the errors Home has hit. Errors stay until
+// they are overwritten; dismissing one only takes it out of the count shown
+// in the header badge. Not safe for concurrent use: errors are logged from
+// Update.
+type ErrorLog struct {
+	entries [errorLogSize]ErrorEntry
+	next    int // Slot the next error goes in
+	n       int // Slots in use
+}
+
+// NewErrorLog creates an empty error log.
+func NewErrorLog() *ErrorLog {
+	return &ErrorLog{}
+}
+
+// Add logs an error.
+func (l *ErrorLog) Add(text string, now time.Time) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	if latest := l.entry(0); latest != nil && latest.Text == text {
+		latest.At = now
+		latest.Count++
+		latest.Dismissed = false
+		return
+	}
+	l.entries[l.next] = ErrorEntry{Text: text, At: now, Count: 1}
+	l.next = (l.next + 1) % errorLogSize
+	l.n = min(l.n+1, errorLogSize)
+}
+
+// Len returns the number of errors logged.
+func (l *ErrorLog) Len() int {
+	return l.n
+}
+
+// entry returns the i-th newest error, or nil.
+func (l *ErrorLog) entry(i int) *ErrorEntry {
+	if i < 0 || i >= l.n {
+		return nil
+	}
+	return &l.entries[(l.next-1-i+errorLogSize)%errorLogSize]
+}
+
+// Entries returns the logged errors, newest first.
+func (l *ErrorLog) Entries() []ErrorEntry {
+	out := make([]ErrorEntry, l.n)
+	for i := range out {
+		out[i] = *l.entry(i)
+	}
+	return out
+}
+
+// Dismiss marks the i-th newest error as read.
+func (l *ErrorLog) Dismiss(i int) {
+	if e := l.entry(i); e != nil {
+		e.Dismissed = true
+	}
+}
+
+// DismissAll marks every error as read.
+func (l *ErrorLog) DismissAll() {
+	for i := range l.n {
+		l.entry(i).Dismissed = true
+	}
+}
+
+// Undismissed returns the number of errors not yet dismissed.
+func (l *ErrorLog) Undismissed() int {
+	count := 0
+	for i := range l.n {
+		if !l.entry(i).Dismissed {
+			count++
+		}
+	}
+	return count
+}
+
+// ErrorLogDialog lists the logged errors, newest first ("E" key), and
+// dismisses them one at a time or all at once.
+type ErrorLogDialog struct {
+	visible       bool
+	width, height int
+	log           *ErrorLog
+	cursor        int
+	offset        int // First visible error
+}
+
+// NewErrorLogDialog creates a new error log dialog.
+func NewErrorLogDialog() *ErrorLogDialog {
+	return &ErrorLogDialog{}
+}
+
+// Show opens the dialog on log, with the newest error selected.
+func (d *ErrorLogDialog) Show(log *ErrorLog) {
+	d.visible = true
+	d.log = log
+	d.cursor = 0
+	d.offset = 0
+}
+
+// Hide closes the dialog.
+func (d *ErrorLogDialog) Hide() {
+	d.visible = false
+	d.log = nil
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *ErrorLogDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *ErrorLogDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// pageSize is the number of errors visible at once.
+func (d *ErrorLogDialog) pageSize() int {
+	if d.height <= 0 {
+		return 20
+	}
+	return max(d.height-9, 5) // Title, summary, footer and box padding
+}
+
+// Update handles navigation and dismissing.
+func (d *ErrorLogDialog) Update(msg tea.KeyMsg) (*ErrorLogDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	n := d.log.Len()
+	switch msg.String() {
+	case "j", "down":
+		d.cursor = min(d.cursor+1, n-1)
+	case "k", "up":
+		d.cursor--
+	case "d", "x":
+		d.log.Dismiss(d.cursor)
+		d.cursor = min(d.cursor+1, n-1)
+	case "D":
+		d.log.DismissAll()
+	case "esc", "q", "E", "enter":
+		d.Hide()
+		return d, nil
+	}
+	d.cursor = max(d.cursor, 0)
+	if d.cursor < d.offset {
+		d.offset = d.cursor
+	} else if d.cursor >= d.offset+d.pageSize() {
+		d.offset = d.cursor - d.pageSize() + 1
+	}
+	return d, nil
+}
+
+// View renders the error log.
+func (d *ErrorLogDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorRed).
+		Bold(true)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 80
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 40)
+	}
+	textWidth := dialogWidth - 6 - 2 - 9 // Box padding, cursor and time
+
+	entries := d.log.Entries()
+	var lines []string
+	lines = append(lines, titleStyle.Render("Errors"))
+	if len(entries) == 0 {
+		lines = append(lines, "", dimStyle.Render("No errors"))
+	} else {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("%d logged, %d not dismissed", len(entries), d.log.Undismissed())))
+		lines = append(lines, "")
+	}
+	end := min(d.offset+d.pageSize(), len(entries))
+	for i := d.offset; i < end; i++ {
+		e := entries[i]
+		text := e.Text
+		if e.Count > 1 {
+			text = fmt.Sprintf("%s (×%d)", text, e.Count)
+		}
+		text = runewidth.Truncate(text, textWidth, "...")
+
+		style := ErrorStyle
+		if e.Dismissed {
+			style = dimStyle
+		}
+		cursor := "  "
+		if i == d.cursor {
+			cursor = "> "
+			if !e.Dismissed {
+				style = selectedStyle
+			}
+		}
+		lines = append(lines, cursor+dimStyle.Render(e.At.Local().Format("15:04:05"))+" "+style.Render(text))
+	}
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("j/k move | d dismiss | D dismiss all | Esc close"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestErrorLogRing(t *testing.T) {
+	log := NewErrorLog()
+	now := time.Now()
+	log.Add("failed to save", now)
+	log.Add("failed to save", now.Add(time.Second))
+	if log.Len() != 1 || log.Entries()[0].Count != 2 {
+		t.Fatalf("a repeated error should be counted, got %+v", log.Entries())
+	}
+
+	for i := range errorLogSize {
+		log.Add(fmt.Sprint("error ", i), now)
+	}
+	entries := log.Entries()
+	if len(entries) != errorLogSize || entries[0].Text != fmt.Sprint("error ", errorLogSize-1) ||
+		entries[errorLogSize-1].Text != "error 0" {
+		t.Errorf("the oldest error should be overwritten first: newest %q, oldest %q",
+			entries[0].Text, entries[errorLogSize-1].Text)
+	}
+
+	log.Dismiss(0)
+	if log.Undismissed() != errorLogSize-1 || !log.Entries()[0].Dismissed {
+		t.Error("dismiss should mark the newest error")
+	}
+	log.DismissAll()
+	if log.Undismissed() != 0 || log.Len() != errorLogSize {
+		t.Error("dismissed errors should stay in the log")
+	}
+}
+
+func TestHomeErrorLog(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+	home.initialLoading = false
+
+	home.setError(errors.New("failed to send prompt"))
+	home.setError(errors.New("failed to save"))
+	view := home.View()
+	if !strings.Contains(view, "⚠ 2 errors (E)") {
+		t.Error("the header should count the errors")
+	}
+	if strings.Contains(view, "failed to save") {
+		t.Error("errors should only show as a badge on the main screen")
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'E'}})
+	if !home.errorLogDialog.IsVisible() {
+		t.Fatal("E should open the error log")
+	}
+	if !strings.Contains(home.View(), "failed to save") {
+		t.Error("the error log should list the errors")
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if home.errorLog.Undismissed() != 1 {
+		t.Errorf("d should dismiss one error, %d left", home.errorLog.Undismissed())
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.errorLogDialog.IsVisible() {
+		t.Error("esc should close the error log")
+	}
+	if strings.Contains(home.View(), "error") {
+		t.Error("the badge should go once every error is dismissed")
+	}
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const forkTreeRows = 20 // Rows shown at once
+
+// forkTreeRow is one flattened line of the fork tree.
+type forkTreeRow struct {
+	node   *session.ForkNode
+	prefix string // Tree glyphs ("├─ ", "│  └─ ", ...)
+	root   bool
+}
+
+// ForkTreeDialog shows which sessions were forked from which ("L" key).
+// Home jumps to the selected session on Enter; the dialog only tracks
+// selection.
+type ForkTreeDialog struct {
+	visible       bool
+	width, height int
+	rows          []forkTreeRow
+	currentID     string
+	cursor        int
+}
+
+// NewForkTreeDialog creates a new fork tree dialog.
+func NewForkTreeDialog() *ForkTreeDialog {
+	return &ForkTreeDialog{}
+}
+
+// Show opens the dialog with the given fork trees, starting on currentID if
+// it is part of one.
+func (d *ForkTreeDialog) Show(roots []*session.ForkNode, currentID string) {
+	d.visible = true
+	d.currentID = currentID
+	d.rows = nil
+	for _, root := range roots {
+		d.rows = append(d.rows, forkTreeRow{node: root, root: true})
+		d.appendChildren(root, "")
+	}
+	d.cursor = 0
+	for i, row := range d.rows {
+		if row.node.Instance.ID == currentID {
+			d.cursor = i
+			break
+		}
+	}
+}
+
+func (d *ForkTreeDialog) appendChildren(node *session.ForkNode, indent string) {
+	for i, child := range node.Children {
+		branch, next := "├─ ", "│  "
+		if i == len(node.Children)-1 {
+			branch, next = "└─ ", "   "
+		}
+		d.rows = append(d.rows, forkTreeRow{node: child, prefix: indent + branch})
+		d.appendChildren(child, indent+next)
+	}
+}
+
+// Hide closes the dialog and resets state.
+func (d *ForkTreeDialog) Hide() {
+	d.visible = false
+	d.rows = nil
+	d.cursor = 0
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *ForkTreeDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *ForkTreeDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// GetSelected returns the session at the cursor, or nil.
+func (d *ForkTreeDialog) GetSelected() *session.Instance {
+	if d.cursor >= len(d.rows) {
+		return nil
+	}
+	return d.rows[d.cursor].node.Instance
+}
+
+// Update handles navigation keys. Enter is handled by Home.
+func (d *ForkTreeDialog) Update(msg tea.KeyMsg) (*ForkTreeDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		if len(d.rows) > 0 {
+			d.cursor = (d.cursor + 1) % len(d.rows)
+		}
+	case "k", "up":
+		if len(d.rows) > 0 {
+			d.cursor = (d.cursor - 1 + len(d.rows)) % len(d.rows)
+		}
+	case "esc":
+		d.Hide()
+	}
+	return d, nil
+}
+
+// View renders the fork tree dialog.
+func (d *ForkTreeDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	treeStyle := lipgloss.NewStyle().
+		Foreground(ColorBorder)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 72
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Fork Tree"))
+	lines = append(lines, "")
+	if len(d.rows) == 0 {
+		lines = append(lines, normalStyle.Render("No forked sessions yet (fork with f or F)"))
+	}
+
+	start := max(0, d.cursor-forkTreeRows+1)
+	end := min(start+forkTreeRows, len(d.rows))
+	for i := start; i < end; i++ {
+		row := d.rows[i]
+		inst := row.node.Instance
+
+		var meta string
+		switch {
+		case row.root && row.node.ParentMissing:
+			meta = "forked " + formatRelativeTime(inst.ForkedAt) + " · source deleted"
+		case row.root:
+			meta = inst.Tool + " · created " + formatRelativeTime(inst.CreatedAt)
+		default:
+			meta = "forked " + formatRelativeTime(inst.ForkedAt)
+		}
+		if inst.ID == d.currentID {
+			meta += " · current"
+		}
+
+		titleWidth := max(dialogWidth-6-runewidth.StringWidth(row.prefix)-runewidth.StringWidth(meta)-2, 10)
+		title := runewidth.Truncate(inst.Title, titleWidth, "...")
+
+		style, cursor := normalStyle, "  "
+		if i == d.cursor {
+			style, cursor = selectedStyle, "> "
+		}
+		lines = append(lines, cursor+treeStyle.Render(row.prefix)+style.Render(title)+"  "+dimStyle.Render(meta))
+	}
+	if len(d.rows) > forkTreeRows {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d", d.cursor+1, len(d.rows))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("Enter jump to session | Esc close"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
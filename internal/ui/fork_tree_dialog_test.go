@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestForkTreeDialog_RowsAndSelection(t *testing.T) {
+	now := time.Now()
+	instances := []*session.Instance{
+		{ID: "root", Title: "root", Tool: "claude", CreatedAt: now.Add(-time.Hour)},
+		{ID: "a", Title: "fork-a", ForkedFromID: "root", ForkedAt: now.Add(-30 * time.Minute)},
+		{ID: "b", Title: "fork-b", ForkedFromID: "root", ForkedAt: now},
+		{ID: "a1", Title: "fork-a1", ForkedFromID: "a", ForkedAt: now},
+	}
+
+	d := NewForkTreeDialog()
+	d.SetSize(120, 50)
+	d.Show(session.BuildForkTree(instances), "a")
+	if got := d.GetSelected(); got == nil || got.ID != "a" {
+		t.Fatalf("dialog should open on the current session, got %+v", got)
+	}
+
+	view := d.View()
+	for _, want := range []string{"├─ ", "│  └─ ", "└─ ", "fork-a1", "current"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := d.GetSelected(); got == nil || got.ID != "a1" {
+		t.Errorf("rows should be depth-first, got %+v", got)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsVisible() || d.GetSelected() != nil {
+		t.Error("Esc should hide and clear the dialog")
+	}
+}
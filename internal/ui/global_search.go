@@ -10,6 +10,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 var (
@@ -392,9 +393,7 @@ func (gs *GlobalSearch) View() string {
 			if maxTitleLen < 20 {
 				maxTitleLen = 20
 			}
-			if len(title) > maxTitleLen {
-				title = title[:maxTitleLen] + "..."
-			}
+			title = runewidth.Truncate(title, maxTitleLen+3, "...")
 
 			// Format date
 			dateStr := gs.formatRelativeTime(result.ModTime)
@@ -446,9 +445,7 @@ func (gs *GlobalSearch) View() string {
 		// Show CWD
 		if result.CWD != "" {
 			cwdDisplay := result.CWD
-			if len(cwdDisplay) > rightWidth-5 {
-				cwdDisplay = "..." + cwdDisplay[len(cwdDisplay)-(rightWidth-8):]
-			}
+			cwdDisplay = truncateStart(cwdDisplay, rightWidth-5)
 			rightPane.WriteString(lipgloss.NewStyle().
 				Foreground(ColorComment).
 				Render("📁 "+cwdDisplay) + "\n")
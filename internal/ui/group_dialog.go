@@ -14,23 +14,21 @@ type GroupDialogMode int
 
 const (
 	GroupDialogCreate GroupDialogMode = iota
-	GroupDialogRename
 	GroupDialogMove
-	GroupDialogRenameSession
 )
 
-// GroupDialog handles group creation, renaming, and moving sessions
+// GroupDialog handles group creation and moving sessions. Renames are
+// edited in place in the list (see InlineRename).
 type GroupDialog struct {
 	visible       bool
 	mode          GroupDialogMode
 	nameInput     textinput.Model
 	width         int
 	height        int
-	groupPath     string   // Current group being edited (for rename) or parent path (for create subgroup)
+	groupPath     string   // Parent path (for create subgroup)
 	parentName    string   // Display name of parent group (for subgroup creation)
 	groupNames    []string // Available groups (for move)
 	selected      int      // Selected group index (for move)
-	sessionID     string   // Session ID being renamed (for rename session)
 	validationErr string   // Inline validation error displayed inside the dialog
 
 	// Tab toggle between Root and Subgroup modes (Issue #111)
@@ -136,16 +134,6 @@ func (g *GroupDialog) ToggleRootSubgroup() {
 	g.validationErr = ""
 }
 
-// ShowRename shows the dialog in rename mode
-func (g *GroupDialog) ShowRename(currentPath, currentName string) {
-	g.visible = true
-	g.mode = GroupDialogRename
-	g.groupPath = currentPath
-	g.validationErr = ""
-	g.nameInput.SetValue(currentName)
-	g.nameInput.Focus()
-}
-
 // ShowMove shows the dialog for moving a session to a group
 func (g *GroupDialog) ShowMove(groups []string) {
 	g.visible = true
@@ -155,21 +143,6 @@ func (g *GroupDialog) ShowMove(groups []string) {
 	g.selected = 0
 }
 
-// ShowRenameSession shows the dialog for renaming a session
-func (g *GroupDialog) ShowRenameSession(sessionID, currentName string) {
-	g.visible = true
-	g.mode = GroupDialogRenameSession
-	g.sessionID = sessionID
-	g.validationErr = ""
-	g.nameInput.SetValue(currentName)
-	g.nameInput.Focus()
-}
-
-// GetSessionID returns the session ID being renamed
-func (g *GroupDialog) GetSessionID() string {
-	return g.sessionID
-}
-
 // Hide hides the dialog
 func (g *GroupDialog) Hide() {
 	g.visible = false
@@ -201,9 +174,6 @@ func (g *GroupDialog) Validate() string {
 
 	// Check for empty name
 	if name == "" {
-		if g.mode == GroupDialogRenameSession {
-			return "Session name cannot be empty"
-		}
 		return "Group name cannot be empty"
 	}
 
@@ -213,10 +183,8 @@ func (g *GroupDialog) Validate() string {
 	}
 
 	// Check for "/" in group names (would break path hierarchy)
-	if g.mode == GroupDialogCreate || g.mode == GroupDialogRename {
-		if strings.Contains(name, "/") {
-			return "Group name cannot contain '/' character"
-		}
+	if strings.Contains(name, "/") {
+		return "Group name cannot contain '/' character"
 	}
 
 	return "" // Valid
@@ -232,11 +200,6 @@ func (g *GroupDialog) ClearError() {
 	g.validationErr = ""
 }
 
-// GetGroupPath returns the group path being edited (or parent path for subgroup creation)
-func (g *GroupDialog) GetGroupPath() string {
-	return g.groupPath
-}
-
 // GetParentPath returns the parent path for subgroup creation
 func (g *GroupDialog) GetParentPath() string {
 	return g.groupPath
@@ -327,9 +290,6 @@ func (g *GroupDialog) View() string {
 			}
 			content = tabs + "\n\n" + content
 		}
-	case GroupDialogRename:
-		title = "Rename Group"
-		content = g.nameInput.View()
 	case GroupDialogMove:
 		title = "Move to Group"
 		var items []string
@@ -349,9 +309,6 @@ func (g *GroupDialog) View() string {
 			}
 		}
 		content = strings.Join(items, "\n")
-	case GroupDialogRenameSession:
-		title = "Rename Session"
-		content = g.nameInput.View()
 	}
 
 	// Responsive dialog width
@@ -5,14 +5,175 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
-// HelpOverlay shows keyboard shortcuts in a modal
+// helpEntry is one row of the help overlay. Rows for remappable actions take
+// their keys from the keymap, so remapping never leaves the help stale; keys
+// that are not remappable (dialogs, quick-jump digits) are written out.
+type helpEntry struct {
+	action KeyAction
+	twice  bool   // Action key pressed twice, e.g. gg
+	keys   string // Fixed keys when action is empty
+	desc   string
+}
+
+// helpSection groups the rows that apply in one context.
+type helpSection struct {
+	title   string
+	entries []helpEntry
+}
+
+// helpSections lists every main-screen action by the context it applies in.
+// Some actions appear in more than one context; every action in
+// defaultKeyBindings must appear at least once.
+var helpSections = []helpSection{
+	{
+		title: "SESSION LIST",
+		entries: []helpEntry{
+			{action: ActionDown, desc: "Move down"},
+			{action: ActionUp, desc: "Move up"},
+			{action: ActionHalfPageDown, desc: "Half page down"},
+			{action: ActionHalfPageUp, desc: "Half page up"},
+			{action: ActionPageDown, desc: "Page down"},
+			{action: ActionPageUp, desc: "Page up"},
+			{action: ActionGroup, twice: true, desc: "Jump to top"},
+			{action: ActionBottom, desc: "Jump to bottom"},
+			{keys: "1-9", desc: "Jump to group (or session)"},
+			{action: ActionRecent, desc: "Recent sessions"},
+			{action: ActionNew, desc: "New session"},
+			{action: ActionQuickNew, desc: "Quick create (auto name)"},
+			{action: ActionImport, desc: "Import tmux sessions"},
+			{action: ActionUndo, desc: "Undo delete"},
+			{action: ActionArchive, desc: "Archived sessions"},
+			{action: ActionCleanup, desc: "Clean up idle sessions"},
+			{action: ActionResume, desc: "Resume a Claude conversation"},
+			{action: ActionForkTree, desc: "Fork tree"},
+		},
+	},
+	{
+		title: "SESSION",
+		entries: []helpEntry{
+			{action: ActionAttach, desc: "Attach"},
+			{action: ActionInfo, desc: "Session details"},
+			{action: ActionRename, desc: "Rename in place"},
+			{action: ActionNotes, desc: "Edit notes"},
+			{action: ActionTags, desc: "Edit tags"},
+			{action: ActionMove, desc: "Move to group"},
+			{action: ActionMoveUp, desc: "Reorder up"},
+			{action: ActionMoveDown, desc: "Reorder down"},
+			{action: ActionRestart, desc: "Restart"},
+			{action: ActionDelete, desc: "Delete (or archive)"},
+			{action: ActionFork, desc: "Quick fork (Claude)"},
+			{action: ActionForkDialog, desc: "Fork with options (Claude)"},
+			{action: ActionWorktreeFinish, desc: "Finish worktree (merge)"},
+			{action: ActionPrompt, desc: "Send or queue a prompt"},
+			{action: ActionMCP, desc: "MCP Manager (Claude/Gemini)"},
+			{action: ActionSkills, desc: "Skills Manager (Claude)"},
+			{action: ActionYolo, desc: "Toggle YOLO mode (Gemini)"},
+			{action: ActionGeminiModel, desc: "Pick model (Gemini)"},
+			{action: ActionMarkUnread, desc: "Mark unread"},
+			{action: ActionMute, desc: "Mute notifications"},
+		},
+	},
+	{
+		title: "GROUP",
+		entries: []helpEntry{
+			{action: ActionExpand, desc: "Expand / toggle"},
+			{action: ActionCollapse, desc: "Collapse / parent"},
+			{action: ActionGroup, desc: "New group (subgroup here)"},
+			{action: ActionNew, desc: "New session in group"},
+			{action: ActionRename, desc: "Rename group"},
+			{action: ActionDelete, desc: "Delete group"},
+			{action: ActionMoveUp, desc: "Reorder up"},
+			{action: ActionMoveDown, desc: "Reorder down"},
+			{action: ActionMute, desc: "Mute group"},
+			{action: ActionGroupSort, desc: "Cycle session sort order"},
+		},
+	},
+	{
+		title: "PREVIEW",
+		entries: []helpEntry{
+			{action: ActionPreviewMode, desc: "Cycle output/stats/both/diff"},
+			{action: ActionPlainPreview, desc: "Plain output (no colors)"},
+			{action: ActionTogglePreview, desc: "Hide/show preview"},
+			{action: ActionDensity, desc: "List density (compact/detailed)"},
+			{action: ActionNarrowList, desc: "Narrow session list"},
+			{action: ActionWidenList, desc: "Widen session list"},
+			{action: ActionScrollback, desc: "Scroll output full screen"},
+			{action: ActionDiff, desc: "Full git diff"},
+			{action: ActionTranscript, desc: "Read conversation transcript"},
+			{action: ActionCopy, desc: "Copy output to clipboard"},
+			{action: ActionSendOutput, desc: "Send output to a session"},
+		},
+	},
+	{
+		title: "SEARCH & FILTER",
+		entries: []helpEntry{
+			{action: ActionSearch, desc: "Search"},
+			{action: ActionGlobalSearch, desc: "Search conversations"},
+			{action: ActionTagFilter, desc: "Filter by tag"},
+			{keys: "!", desc: "Running only"},
+			{keys: "@", desc: "Waiting only"},
+			{keys: "#", desc: "Idle only"},
+			{keys: "$", desc: "Errors only"},
+			{keys: "%", desc: "Needs input only"},
+			{keys: "^", desc: "Needs attention (◐ ◆ ✕)"},
+			{keys: "0", desc: "Clear filters"},
+		},
+	},
+	{
+		title: "DIALOGS",
+		entries: []helpEntry{
+			{keys: "Enter", desc: "Confirm"},
+			{keys: "Esc", desc: "Cancel / close"},
+			{keys: "Tab", desc: "Next field"},
+			{keys: "↑/↓ j/k", desc: "Move through lists"},
+			{keys: "Space", desc: "Toggle checkbox"},
+			{keys: "n/N", desc: "Next/prev file (diff)"},
+			{keys: "Ctrl+Q", desc: "Detach from attached session"},
+		},
+	},
+	{
+		title: "OTHER",
+		entries: []helpEntry{
+			{action: ActionDashboard, desc: "Dashboard overview"},
+			{action: ActionNotifications, desc: "Notification log"},
+			{action: ActionErrors, desc: "Error log (dismiss)"},
+			{action: ActionSettings, desc: "Settings"},
+			{action: ActionProfiles, desc: "Switch profile"},
+			{action: ActionDoNotDisturb, desc: "Do not disturb"},
+			{action: ActionRefresh, desc: "Reload from disk"},
+			{action: ActionHelp, desc: "This help"},
+			{action: ActionQuit, desc: "Quit"},
+		},
+	},
+}
+
+// helpKeyLabel formats an action's keys for the help overlay. Shift aliases
+// of a bound capital letter (shift+m next to M) are left out.
+func helpKeyLabel(keys []string) string {
+	bound := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		bound[key] = true
+	}
+	var labels []string
+	for _, key := range keys {
+		if letter, ok := strings.CutPrefix(key, "shift+"); ok && bound[strings.ToUpper(letter)] {
+			continue
+		}
+		labels = append(labels, keyDisplayName(key))
+	}
+	return strings.Join(labels, "/")
+}
+
+// HelpOverlay shows keyboard shortcuts in a full-screen modal
 type HelpOverlay struct {
 	visible      bool
 	width        int
 	height       int
 	scrollOffset int // Current scroll position for small screens
+	keymap       *Keymap
 }
 
 // NewHelpOverlay creates a new help overlay
@@ -20,10 +181,11 @@ func NewHelpOverlay() *HelpOverlay {
 	return &HelpOverlay{}
 }
 
-// Show makes the help overlay visible
-func (h *HelpOverlay) Show() {
+// Show makes the help overlay visible, listing the keys bound in km
+func (h *HelpOverlay) Show(km *Keymap) {
 	h.visible = true
 	h.scrollOffset = 0
+	h.keymap = km
 }
 
 // Hide hides the help overlay
@@ -88,84 +250,6 @@ func (h *HelpOverlay) View() string {
 		return ""
 	}
 
-	// Define help sections
-	sections := []struct {
-		title string
-		items [][2]string // [key, description]
-	}{
-		{
-			title: "NAVIGATION",
-			items: [][2]string{
-				{"j / Down", "Move down"},
-				{"k / Up", "Move up"},
-				{"Ctrl+u/d", "Half page up/down"},
-				{"Ctrl+f/b", "Full page up/down"},
-				{"gg / G", "Jump to top/bottom"},
-				{"h / Left", "Collapse / parent"},
-				{"l / Right", "Expand / toggle"},
-				{"1-9", "Jump to group"},
-				{"Enter", "Attach / toggle"},
-			},
-		},
-		{
-			title: "SESSIONS",
-			items: [][2]string{
-				{"n", "New session"},
-				{"N", "Quick create (auto name, smart defaults)"},
-				{"r", "Rename session"},
-				{"Shift+R", "Restart session"},
-				{"d", "Delete session"},
-				{"Ctrl+Z", "Undo delete"},
-				{"M", "Move to group"},
-				{"m", "MCP Manager (Claude/Gemini)"},
-				{"s", "Skills Manager (Claude)"},
-				{"v", "Toggle preview mode (output/stats/both)"},
-				{"u", "Mark unread"},
-				{"K / J", "Reorder up/down"},
-				{"f", "Quick fork (Claude only)"},
-				{"F", "Fork with options (Claude only)"},
-				{"c", "Copy output to clipboard"},
-				{"x", "Send output to session"},
-			},
-		},
-		{
-			title: "WORKTREES",
-			items: [][2]string{
-				{"W", "Finish worktree (merge + cleanup)"},
-				{"n → w", "Create session in worktree"},
-				{"F → w", "Fork session into worktree"},
-			},
-		},
-		{
-			title: "GROUPS",
-			items: [][2]string{
-				{"g", "New group"},
-				{"r", "Rename group"},
-				{"Tab", "Toggle expand"},
-			},
-		},
-		{
-			title: "SEARCH & FILTER",
-			items: [][2]string{
-				{"/", "Open search"},
-				{"/waiting", "Filter waiting"},
-				{"/running", "Filter running"},
-				{"/idle", "Filter idle"},
-			},
-		},
-		{
-			title: "OTHER",
-			items: [][2]string{
-				{"S", "Settings"},
-				{"Ctrl+R", "Reload from disk"},
-				{"i", "Import tmux sessions"},
-				{"Ctrl+Q", "Detach from session"},
-				{"q", "Quit"},
-				{"?", "This help"},
-			},
-		},
-	}
-
 	// Styles
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -175,14 +259,12 @@ func (h *HelpOverlay) View() string {
 		Foreground(ColorCyan).
 		Bold(true)
 
-	// Responsive dialog width
-	dialogWidth := 48
-	if h.width > 0 && h.width < dialogWidth+10 {
-		dialogWidth = h.width - 10
-		if dialogWidth < 35 {
-			dialogWidth = 35
-		}
+	// Full-screen box, with as many columns of sections as fit
+	dialogWidth := 100
+	if h.width > 0 {
+		dialogWidth = max(h.width-4, 35)
 	}
+	const minColumnWidth = 44
 	keyWidth := 14
 	if dialogWidth < 45 {
 		keyWidth = 10 // Compact key column for small screens
@@ -206,30 +288,45 @@ func (h *HelpOverlay) View() string {
 		Foreground(ColorYellow).
 		Bold(true)
 
-	// Build content as lines for scrolling support
-	var lines []string
+	// Render each section as a block of lines
+	innerWidth := dialogWidth - 4 // Padding
+	numCols := max(1, min(innerWidth/(minColumnWidth+2), len(helpSections)))
+	colWidth := innerWidth/numCols - 2
+	var blocks [][]string
+	for _, section := range helpSections {
+		block := []string{sectionStyle.Render(section.title)}
+		for _, entry := range section.entries {
+			keys := entry.keys
+			if entry.action != "" {
+				keys = helpKeyLabel(h.keymap.Keys(entry.action))
+				if keys == "" {
+					continue // Unbound
+				}
+				if entry.twice {
+					keys = strings.Repeat(keyDisplayName(h.keymap.Keys(entry.action)[0]), 2)
+				}
+			}
+			desc := runewidth.Truncate(entry.desc, max(colWidth-keyWidth-3, 8), "…")
+			block = append(block, "  "+keyStyle.Render(keys)+descStyle.Render(desc))
+		}
+		blocks = append(blocks, block)
+	}
+
+	columns := packHelpColumns(blocks, numCols)
 
+	var lines []string
 	lines = append(lines, titleStyle.Render("KEYBOARD SHORTCUTS"))
 	lines = append(lines, "")
 
-	for i, section := range sections {
-		lines = append(lines, sectionStyle.Render(section.title))
-		for _, item := range section.items {
-			line := "  " + keyStyle.Render(item[0]) + descStyle.Render(item[1])
-			lines = append(lines, line)
-		}
-		if i < len(sections)-1 {
-			lines = append(lines, "")
-		}
+	rendered := make([]string, len(columns))
+	for i, c := range columns {
+		rendered[i] = lipgloss.NewStyle().Width(colWidth + 2).Render(strings.Join(c, "\n"))
 	}
+	lines = append(lines, strings.Split(lipgloss.JoinHorizontal(lipgloss.Top, rendered...), "\n")...)
 
 	// Version info
-	separatorWidth := dialogWidth - 8
-	if separatorWidth < 20 {
-		separatorWidth = 20
-	}
 	lines = append(lines, "")
-	lines = append(lines, separatorStyle.Render(strings.Repeat("─", separatorWidth)))
+	lines = append(lines, separatorStyle.Render(strings.Repeat("─", max(innerWidth, 20))))
 	lines = append(lines, versionStyle.Render("Agent Deck v"+Version))
 
 	totalLines := len(lines)
@@ -318,3 +415,32 @@ func (h *HelpOverlay) View() string {
 
 	return centerInScreen(box, h.width, h.height)
 }
+
+// packHelpColumns splits the section blocks, in order, into at most n
+// columns, keeping the tallest column as short as possible.
+func packHelpColumns(blocks [][]string, n int) [][]string {
+	pack := func(limit int) [][]string {
+		var columns [][]string
+		var col []string
+		for _, block := range blocks {
+			height := len(block)
+			if len(col) > 0 {
+				height++ // Blank line between sections
+			}
+			if len(col) > 0 && len(col)+height > limit {
+				columns = append(columns, col)
+				col, height = nil, len(block)
+			}
+			if len(col) > 0 {
+				col = append(col, "")
+			}
+			col = append(col, block...)
+		}
+		return append(columns, col)
+	}
+	for limit := 1; ; limit++ {
+		if columns := pack(limit); len(columns) <= n {
+			return columns
+		}
+	}
+}
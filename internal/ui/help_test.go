@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestHelpSectionsCoverKeymap(t *testing.T) {
+	listed := make(map[KeyAction]bool)
+	for _, section := range helpSections {
+		for _, entry := range section.entries {
+			listed[entry.action] = true
+		}
+	}
+	for _, b := range defaultKeyBindings {
+		if !listed[b.action] {
+			t.Errorf("action %q is missing from the help overlay", b.action)
+		}
+	}
+}
+
+func TestHelpKeyLabel(t *testing.T) {
+	tests := map[string][]string{
+		"M":       {"M", "shift+m"},
+		"Tab/l/→": {"tab", "l", "right"},
+		"^Z":      {"ctrl+z"},
+	}
+	for want, keys := range tests {
+		if got := helpKeyLabel(keys); got != want {
+			t.Errorf("helpKeyLabel(%v) = %q, want %q", keys, got, want)
+		}
+	}
+}
+
+func TestHelpOverlayShowsRemappedKeys(t *testing.T) {
+	km, _ := NewKeymap(map[string]session.KeyList{"cleanup": {"ctrl+k"}})
+	h := NewHelpOverlay()
+	h.SetSize(200, 80)
+	h.Show(km)
+
+	view := h.View()
+	if !strings.Contains(view, "^K") {
+		t.Error("help should show the remapped cleanup key")
+	}
+	if !strings.Contains(view, "SESSION LIST") || !strings.Contains(view, "PREVIEW") {
+		t.Error("help should list every context")
+	}
+}
@@ -9,23 +9,26 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
-
-	"golang.org/x/sync/errgroup"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/mattn/go-runewidth"
 
 	"github.com/asheshgoplani/agent-deck/internal/clipboard"
 	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/logging"
+	"github.com/asheshgoplani/agent-deck/internal/notify"
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/asheshgoplani/agent-deck/internal/statedb"
+	"github.com/asheshgoplani/agent-deck/internal/termesc"
 	"github.com/asheshgoplani/agent-deck/internal/tmux"
 	"github.com/asheshgoplani/agent-deck/internal/update"
 	"github.com/asheshgoplani/agent-deck/internal/web"
@@ -50,11 +53,6 @@ var (
 )
 
 const (
-	// tickInterval for UI refresh and status updates
-	// Background worker polls at 2s intervals for status detection
-	// At 2s: 2-5 CapturePane() calls/sec = minimal CPU overhead
-	tickInterval = 2 * time.Second
-
 	// logCheckInterval - how often to check for oversized logs (fast check, just file stats)
 	// This catches runaway logs before they cause high CPU
 	logCheckInterval = 10 * time.Second
@@ -66,6 +64,13 @@ const (
 	// analyticsCacheTTL - how long analytics data remains valid before refresh
 	// Analytics don't change frequently, so 5s is a good balance between freshness and performance
 	analyticsCacheTTL = 5 * time.Second
+
+	// maxPreviewNoteLines - notes lines shown in the preview header before truncating
+	maxPreviewNoteLines = 3
+
+	// cleanupCheckInterval - how often to look for sessions the idle-cleanup
+	// policy would clean up, to point the user at the review list
+	cleanupCheckInterval = 5 * time.Minute
 )
 
 // UI spacing constants (2-char grid system)
@@ -89,6 +94,15 @@ const (
 	// At or above 80: dual column (current side-by-side layout)
 )
 
+// Session list width in the side-by-side layout, as a percentage of the
+// terminal width; "<" and ">" move it by listPercentStep.
+const (
+	listPercentDefault = 35
+	listPercentMin     = 20
+	listPercentMax     = 70
+	listPercentStep    = 5
+)
+
 // Layout mode names
 const (
 	LayoutModeSingle  = "single"  // <50 cols: list only
@@ -103,6 +117,7 @@ const (
 	PreviewModeBoth      PreviewMode = iota // Show both analytics and output (default)
 	PreviewModeOutput                       // Show output only (content preview)
 	PreviewModeAnalytics                    // Show analytics only
+	PreviewModeDiff                         // Show uncommitted git changes
 )
 
 // Responsive breakpoints for empty state content tiers
@@ -126,15 +141,13 @@ type Home struct {
 	height int
 
 	// Profile
-	profile string // The profile this Home is displaying
+	profile       string // The profile this Home is displaying
+	switchProfile string // Profile to relaunch into after quitting (set by the profile picker)
 
-	// Data (protected by instancesMu for background worker access)
-	instances    []*session.Instance
-	instanceByID map[string]*session.Instance // O(1) instance lookup by ID
-	instancesMu  sync.RWMutex                 // Protects instances slice for thread-safe background access
-	storage      *session.Storage
-	groupTree    *session.GroupTree
-	flatItems    []session.Item // Flattened view for cursor navigation
+	// Data
+	sessions  *SessionStore // Sessions and their groups, shared with background workers
+	storage   *session.Storage
+	flatItems []session.Item // Flattened view for cursor navigation
 
 	// Components
 	search               *Search
@@ -153,6 +166,24 @@ type Home struct {
 	geminiModelDialog    *GeminiModelDialog    // For selecting Gemini model
 	sessionPickerDialog  *SessionPickerDialog  // For sending output to another session
 	worktreeFinishDialog *WorktreeFinishDialog // For finishing worktree sessions (merge + cleanup)
+	profilePickerDialog  *ProfilePickerDialog  // For switching to another profile
+	archiveDialog        *ArchiveDialog        // For viewing and restoring archived sessions
+	resumeDialog         *ResumeDialog         // For resuming past Claude conversations
+	forkTreeDialog       *ForkTreeDialog       // For browsing fork lineage
+	transcriptViewer     *TranscriptViewer     // For reading a session's conversation
+	diffViewer           *DiffViewer           // For reading a session's full git diff
+	scrollbackViewer     *ScrollbackViewer     // For reading a session's output full screen
+	cleanupDialog        *CleanupDialog        // For reviewing idle sessions before cleanup
+	importDialog         *ImportDialog         // For choosing which tmux sessions to import
+	recentDialog         *RecentDialog         // For jumping back to recently attached sessions
+	dashboard            *Dashboard            // For an overview of the whole deck
+	sessionInfoDialog    *SessionInfoDialog    // For a session's full details
+	inlineRename         *InlineRename         // For renaming the selected row in place
+	toastLogDialog       *ToastLogDialog       // For reading past notifications
+	errorLogDialog       *ErrorLogDialog       // For reading and dismissing errors
+	promptDialog         *PromptDialog         // For sending a prompt without attaching
+	notesDialog          *NotesDialog          // For editing session notes
+	tagDialog            *TagDialog            // For editing session tags and picking the tag filter
 
 	// Analytics cache (async fetching with TTL)
 	currentAnalytics       *session.SessionAnalytics                  // Current analytics for selected session (Claude)
@@ -163,20 +194,33 @@ type Home struct {
 	geminiAnalyticsCache   map[string]*session.GeminiSessionAnalytics // TTL cache: sessionID -> analytics (Gemini)
 	analyticsCacheTime     map[string]time.Time                       // TTL cache: sessionID -> cache timestamp
 
+	// Mouse: clickable list rows and session drags
+	listArea    listArea          // Where the last View drew the session list
+	dragSession *session.Instance // Session being dragged with the mouse
+	dragRow     int               // flatItems index the drag last moved to
+	dragMoved   bool              // The drag changed the session's place
+
 	// State
 	cursor         int            // Selected item index in flatItems
 	viewOffset     int            // First visible item index (for scrolling)
 	isAttaching    atomic.Bool    // Prevents View() output during attach (fixes Bubble Tea Issue #431) - atomic for thread safety
 	statusFilter   session.Status // Filter sessions by status ("" = all, or specific status)
+	filterHits     []filterHit    // Clickable status counts from the last View
+	tagFilter      string         // Filter sessions by tag ("" = all)
 	previewMode    PreviewMode    // What to show in preview pane (both, output-only, analytics-only)
-	err            error
-	errTime        time.Time  // When error occurred (for auto-dismiss)
-	isReloading    bool       // Visual feedback during auto-reload
-	initialLoading bool       // True until first loadSessionsMsg received (shows splash screen)
-	isQuitting     bool       // True when user pressed q, shows quitting splash
-	reloadVersion  uint64     // Incremented on each reload to prevent stale background saves
-	reloadMu       sync.Mutex // Protects reloadVersion, isReloading, and lastLoadMtime for thread-safe access
-	lastLoadMtime  time.Time  // File mtime when we last loaded (for external change detection)
+	listPercent    int            // Session list share of the width side by side (0 = default)
+	previewHidden  bool           // Preview pane hidden; the list takes the full width
+	previewPlain   bool           // Preview output without colors, blank lines collapsed, truncated
+	listDensity    ListDensity    // Session rows: normal, compact or detailed
+	liveSortOrder  string         // Session order of activity- and status-sorted groups at the last rebuild
+	toasts         *Toasts        // Transient notifications and their log
+	errorLog       *ErrorLog      // Errors, counted in the header until dismissed
+	isReloading    bool           // Visual feedback during auto-reload
+	initialLoading bool           // True until first loadSessionsMsg received (shows splash screen)
+	isQuitting     bool           // True when user pressed q, shows quitting splash
+	reloadVersion  uint64         // Incremented on each reload to prevent stale background saves
+	reloadMu       sync.Mutex     // Protects reloadVersion, isReloading, and lastLoadMtime for thread-safe access
+	lastLoadMtime  time.Time      // File mtime when we last loaded (for external change detection)
 
 	// Preview cache (async fetching - View() must be pure, no blocking I/O)
 	previewCache      map[string]string    // sessionID -> cached preview content
@@ -196,13 +240,17 @@ type Home struct {
 
 	// Background status worker (Priority 1C optimization)
 	// Moves status updates to a separate goroutine, completely decoupling from UI
+	monitor          *session.Monitor         // Polls statuses on its own goroutines
 	statusTrigger    chan statusUpdateRequest // Triggers background status update
 	statusWorkerDone chan struct{}            // Signals worker has stopped
 
 	// PERFORMANCE: Worker pool for output-driven status updates (Priority 2)
 	// Caps the number of goroutines spawned for %output events from control pipes
 	logUpdateChan chan *session.Instance // Buffers status update requests from PipeManager
-	logWorkerWg   sync.WaitGroup         // Tracks log worker goroutines for clean shutdown
+	statusChanged chan struct{}          // Signalled by status workers when a status changes
+
+	resurrectChecked bool           // First load checked for sessions lost to a reboot
+	logWorkerWg      sync.WaitGroup // Tracks log worker goroutines for clean shutdown
 
 	// PERFORMANCE: Debounce output activity status updates
 	lastLogActivity map[string]time.Time // sessionID -> last update time
@@ -213,13 +261,31 @@ type Home struct {
 	worktreeDirtyCacheTs map[string]time.Time // sessionID -> cache timestamp
 	worktreeDirtyMu      sync.Mutex           // Protects dirty cache maps
 
+	// Git branch/ahead-behind/dirty state per project path, refreshed lazily
+	// for listed sessions (see refreshGitStatus)
+	gitStatusCache    map[string]gitStatusEntry
+	gitStatusMu       sync.Mutex // Protects gitStatusCache
+	gitStatusFetching bool       // A refresh batch is in flight
+
+	// Diff summary for the diff preview tab (selected session only)
+	diffStat         diffStatEntry
+	diffStatFetching bool
+
 	// Memory management: periodic cache pruning
 	lastCachePrune time.Time
 
+	// Idle-session cleanup ([cleanup] idle_hours): when idle sessions were
+	// last counted, and how many the user was last told about
+	lastCleanupCheck time.Time
+	cleanupNoticed   int
+
 	// Hook-based status detection (Claude Code lifecycle hooks)
 	hookWatcher        *session.StatusFileWatcher
 	pendingHooksPrompt bool // True if user should be prompted to install hooks
 
+	// Claude transcript writes: keeps session IDs current for fork
+	claudeWatcher *session.ClaudeTranscriptWatcher
+
 	// File watcher for external changes (auto-reload)
 	storageWatcher *StorageWatcher
 
@@ -236,6 +302,14 @@ type Home struct {
 	// Watcher warning (shown if fsnotify may not work, e.g., on 9p/NFS)
 	watcherWarning string
 
+	// Main-screen keybindings ([keys] in config.toml) and any config problems
+	keymap        *Keymap
+	keymapWarning string
+
+	// pollInterval drives UI ticks ([status] poll_interval_ms, default 2s).
+	// The session monitor polls at adaptive rates of its own.
+	pollInterval time.Duration
+
 	// Update notification (async check on startup)
 	updateInfo *update.UpdateInfo
 
@@ -264,8 +338,22 @@ type Home struct {
 	// Double ESC to quit (#28) - for non-English keyboard users
 	lastEscTime time.Time // When ESC was last pressed (double-tap within 500ms quits)
 
-	// Vi-style gg to jump to top (#38)
-	lastGTime time.Time // When 'g' was last pressed (double-tap within 500ms jumps to top)
+	// Vi-style gg to jump to top (#38). A g waits up to ggWindow for a second
+	// one before it opens the new group dialog.
+	pendingG    bool
+	pendingGSeq int // Identifies the latest g, so a stale timeout is ignored
+
+	// Number keys: 1-9 jump to root groups, or with number_keys = "sessions"
+	// to the sessions numbered on screen
+	numberSessions bool
+	sessionNumbers []int // flatItems indexes of the sessions numbered 1-9 in the last View
+
+	// Tool shown as a Nerd Font glyph rather than its name (tool_icons)
+	nerdIcons bool
+
+	// Session list rows from the last frame, and this frame's group counts
+	rowCache         rowCache
+	frameGroupCounts map[string]groupCounts
 
 	// Navigation tracking (PERFORMANCE: suspend background updates during rapid navigation)
 	lastNavigationTime time.Time // When user last navigated (up/down/j/k)
@@ -273,9 +361,9 @@ type Home struct {
 
 	// Cached status counts (invalidated on instance changes)
 	cachedStatusCounts struct {
-		running, waiting, idle, errored int
-		valid                           atomic.Bool // THREAD-SAFE: accessed from main and worker goroutines
-		timestamp                       time.Time   // For time-based expiration
+		running, needsInput, waiting, idle, errored int
+		valid                                       atomic.Bool // THREAD-SAFE: accessed from main and worker goroutines
+		timestamp                                   time.Time   // For time-based expiration
 	}
 
 	// Reusable string builder for View() to reduce allocations
@@ -289,6 +377,33 @@ type Home struct {
 	lastBarText          string            // Cache to avoid updating all sessions every tick
 	lastBarTextMu        sync.Mutex        // Protects lastBarText for background worker access
 
+	// Desktop, terminal and push notifications ([notifications] desktop,
+	// terminal, ntfy and pushover) go out while the terminal is unfocused or
+	// another session is attached
+	desktopNotifications bool
+	terminalNotification string // "", "bell", "osc9" or "osc777"
+	pushTargets          notify.PushTargets
+	terminalFocused      atomic.Bool // Cleared by tea.BlurMsg; stays true on terminals without focus reporting
+
+	// Muting: per session (Instance.NotifyMuted), per group, or everything
+	// (do-not-disturb). mutedGroups mirrors the group tree, which only the
+	// main goroutine may read, for the background status worker
+	mutedGroups  atomic.Pointer[map[string]bool]
+	doNotDisturb atomic.Bool
+
+	// Set by the status worker after sending a queued prompt; the next tick
+	// saves so a restart doesn't send it again
+	promptQueueDirty atomic.Bool
+
+	// Set when the status worker restarted a crashed session; the next tick
+	// saves the new tmux session
+	autoRestartDirty atomic.Bool
+
+	// Webhooks ([notifications] webhooks) get every status change, or only
+	// changes into webhookStatuses when set
+	webhooks        []string
+	webhookStatuses map[session.Status]bool
+
 	// Maintenance banner (shown after background maintenance completes)
 	maintenanceMsg     string
 	maintenanceMsgTime time.Time
@@ -308,8 +423,8 @@ type Home struct {
 	pendingTitleChanges map[string]string
 
 	// UI state persistence across restarts
-	pendingCursorRestore *uiState // Consumed on first loadSessionsMsg to restore cursor
-	uiStateSaveTicks     int      // Counter for periodic UI state saves in tick handler
+	pendingCursorRestore *uiState  // Consumed on first loadSessionsMsg to restore cursor
+	lastUIStateSave      time.Time // Last periodic UI state save in tick handler
 }
 
 // reloadState preserves UI state during storage reload
@@ -326,6 +441,12 @@ type uiState struct {
 	CursorGroupPath string `json:"cursor_group_path,omitempty"`
 	PreviewMode     int    `json:"preview_mode"`
 	StatusFilter    string `json:"status_filter,omitempty"`
+	TagFilter       string `json:"tag_filter,omitempty"`
+	DoNotDisturb    bool   `json:"do_not_disturb,omitempty"`
+	ListPercent     int    `json:"list_percent,omitempty"`
+	PreviewHidden   bool   `json:"preview_hidden,omitempty"`
+	PreviewPlain    bool   `json:"preview_plain,omitempty"`
+	ListDensity     int    `json:"list_density,omitempty"`
 }
 
 // deletedSessionEntry holds a deleted session for undo restore
@@ -334,10 +455,11 @@ type deletedSessionEntry struct {
 	deletedAt time.Time
 }
 
-// getLayoutMode returns the current layout mode based on terminal width
+// getLayoutMode returns the current layout mode based on terminal width.
+// Hiding the preview forces the single-column layout at any width.
 func (h *Home) getLayoutMode() string {
 	switch {
-	case h.width < layoutBreakpointSingle:
+	case h.previewHidden || h.width < layoutBreakpointSingle:
 		return LayoutModeSingle
 	case h.width < layoutBreakpointStacked:
 		return LayoutModeStacked
@@ -357,6 +479,13 @@ type loadSessionsMsg struct {
 	loadMtime    time.Time    // File mtime at load time (for external change detection)
 }
 
+// tmuxSessionsDiscoveredMsg carries the tmux sessions an import could adopt,
+// to be offered in the import dialog.
+type tmuxSessionsDiscoveredMsg struct {
+	instances []*session.Instance
+	err       error
+}
+
 type sessionCreatedMsg struct {
 	instance *session.Instance
 	err      error
@@ -372,6 +501,10 @@ type refreshMsg struct{}
 
 type statusUpdateMsg struct{} // Triggers immediate status update without reloading
 
+// sessionStatusChangedMsg signals that a background status update changed a
+// session's status, so the list re-renders without waiting for the next tick
+type sessionStatusChangedMsg struct{}
+
 // storageChangedMsg signals that state.db was modified externally
 type storageChangedMsg struct{}
 
@@ -386,6 +519,14 @@ type updateCheckMsg struct {
 	info *update.UpdateInfo
 }
 
+// ggWindow is how long a g waits for a second g before acting alone
+const ggWindow = 500 * time.Millisecond
+
+// pendingGTimeoutMsg ends the wait for the second g of gg
+type pendingGTimeoutMsg struct {
+	seq int
+}
+
 type tickMsg time.Time
 type quitMsg bool
 
@@ -396,6 +537,32 @@ type previewFetchedMsg struct {
 	err       error
 }
 
+// searchContentMsg carries every session's scrollback for output search
+type searchContentMsg struct {
+	contents map[string]string // Session ID -> scrollback
+}
+
+// dashboardUsageMsg carries today's token usage across sessions
+type dashboardUsageMsg struct {
+	usage    session.Usage
+	sessions int // Sessions with usage today
+}
+
+// sessionInfoUsageMsg carries a session's token usage for the info dialog
+type sessionInfoUsageMsg struct {
+	sessionID string
+	usage     session.Usage
+	ok        bool // The tool has readable usage
+}
+
+// previewUpdateMsg drives the fast preview refresh of the selected session
+type previewUpdateMsg struct{}
+
+// previewFollowInterval is how often the selected session's preview is
+// re-captured when its pane has new output. Other sessions stay on the
+// slower status tick.
+const previewFollowInterval = 250 * time.Millisecond
+
 // previewDebounceMsg signals debounce period elapsed for preview fetch
 // PERFORMANCE: Delays preview fetch during rapid navigation
 type previewDebounceMsg struct {
@@ -430,6 +597,20 @@ type copyResultMsg struct {
 	err          error
 }
 
+// transcriptLoadedMsg is sent when a session's transcript has been parsed
+type transcriptLoadedMsg struct {
+	title    string
+	tool     string
+	messages []session.TranscriptMessage
+	err      error
+}
+
+// promptSentMsg is sent when a prompt from the prompt dialog was delivered
+type promptSentMsg struct {
+	sessionTitle string
+	err          error
+}
+
 // sendOutputResultMsg is sent when async inter-session send completes
 type sendOutputResultMsg struct {
 	sourceTitle string
@@ -443,6 +624,45 @@ type systemThemeMsg struct {
 	dark bool
 }
 
+// gitStatusEntry is the cached git status of a project path. ok is false
+// when the path is not a git repository.
+type gitStatusEntry struct {
+	status    git.Status
+	ok        bool
+	checkedAt time.Time
+}
+
+// gitStatusMsg is sent when a git status refresh batch completes
+type gitStatusMsg struct {
+	statuses map[string]gitStatusEntry // By project path
+}
+
+const (
+	gitStatusTTL   = 30 * time.Second // How long a path's git status is reused
+	gitStatusBatch = 16               // Paths checked per refresh
+	diffStatTTL    = 5 * time.Second  // How long the diff preview is reused
+)
+
+// diffStatEntry is the diff summary of a session's working directory.
+type diffStatEntry struct {
+	sessionID string
+	files     []git.FileChange
+	untracked []string
+	err       error
+	checkedAt time.Time
+}
+
+// diffStatMsg is sent when a diff summary for the diff preview tab is read
+type diffStatMsg diffStatEntry
+
+// diffLoadedMsg is sent when a session's full diff has been read
+type diffLoadedMsg struct {
+	title string
+	path  string
+	diff  string
+	err   error
+}
+
 // worktreeDirtyCheckMsg is sent when an async worktree dirty check completes
 type worktreeDirtyCheckMsg struct {
 	sessionID string
@@ -523,14 +743,33 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		analyticsPanel:       NewAnalyticsPanel(),
 		geminiModelDialog:    NewGeminiModelDialog(),
 		sessionPickerDialog:  NewSessionPickerDialog(),
+		profilePickerDialog:  NewProfilePickerDialog(),
+		archiveDialog:        NewArchiveDialog(),
+		resumeDialog:         NewResumeDialog(),
+		forkTreeDialog:       NewForkTreeDialog(),
+		transcriptViewer:     NewTranscriptViewer(),
+		diffViewer:           NewDiffViewer(),
+		scrollbackViewer:     NewScrollbackViewer(),
+		cleanupDialog:        NewCleanupDialog(),
+		importDialog:         NewImportDialog(),
+		recentDialog:         NewRecentDialog(),
+		dashboard:            NewDashboard(),
+		sessionInfoDialog:    NewSessionInfoDialog(),
+		toasts:               NewToasts(),
+		errorLog:             NewErrorLog(),
+		errorLogDialog:       NewErrorLogDialog(),
+		inlineRename:         NewInlineRename(),
+		toastLogDialog:       NewToastLogDialog(),
+		lastCleanupCheck:     time.Now(), // Give status polling a head start
+		promptDialog:         NewPromptDialog(),
+		notesDialog:          NewNotesDialog(),
+		tagDialog:            NewTagDialog(),
 		worktreeFinishDialog: NewWorktreeFinishDialog(),
 		cursor:               0,
 		initialLoading:       true, // Show splash until sessions load
 		ctx:                  ctx,
 		cancel:               cancel,
-		instances:            []*session.Instance{},
-		instanceByID:         make(map[string]*session.Instance),
-		groupTree:            session.NewGroupTree([]*session.Instance{}),
+		sessions:             NewSessionStore(),
 		flatItems:            []session.Item{},
 		previewCache:         make(map[string]string),
 		previewCacheTime:     make(map[string]time.Time),
@@ -544,9 +783,11 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		lastLogActivity:      make(map[string]time.Time),
 		worktreeDirtyCache:   make(map[string]bool),
 		worktreeDirtyCacheTs: make(map[string]time.Time),
+		gitStatusCache:       make(map[string]gitStatusEntry),
 		statusTrigger:        make(chan statusUpdateRequest, 1), // Buffered to avoid blocking
 		statusWorkerDone:     make(chan struct{}),
 		logUpdateChan:        make(chan *session.Instance, 100), // Buffered to absorb bursts
+		statusChanged:        make(chan struct{}, 1),
 		boundKeys:            make(map[string]string),
 		undoStack:            make([]deletedSessionEntry, 0, 10),
 		pendingTitleChanges:  make(map[string]string),
@@ -562,6 +803,23 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Initialize notification manager if enabled in config
 	// All instances manage the notification bar (they share SQLite state, so produce identical output)
 	notifSettings := session.GetNotificationsSettings()
+	h.desktopNotifications = notifSettings.Desktop
+	h.terminalNotification = notifSettings.Terminal
+	h.pushTargets = notify.PushTargets{
+		NtfyServer:    notifSettings.Ntfy.Server,
+		NtfyTopic:     notifSettings.Ntfy.Topic,
+		NtfyToken:     notifSettings.Ntfy.Token,
+		PushoverToken: notifSettings.Pushover.Token,
+		PushoverUser:  notifSettings.Pushover.User,
+	}
+	h.webhooks = notifSettings.Webhooks
+	if len(notifSettings.WebhookStatuses) > 0 {
+		h.webhookStatuses = make(map[session.Status]bool, len(notifSettings.WebhookStatuses))
+		for _, s := range notifSettings.WebhookStatuses {
+			h.webhookStatuses[session.Status(s)] = true
+		}
+	}
+	h.terminalFocused.Store(true)
 	if notifSettings.Enabled {
 		h.notificationsEnabled = true
 		h.notificationManager = session.NewNotificationManager(notifSettings.MaxShown, notifSettings.ShowAll)
@@ -574,26 +832,26 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Initialize event-driven status detection
 	// Output callback: invoked when PipeManager detects %output from a session
 	outputCallback := func(sessionName string) {
-		h.instancesMu.RLock()
-		for _, inst := range h.instances {
-			if inst.GetTmuxSession() != nil && inst.GetTmuxSession().Name == sessionName {
-				h.logActivityMu.Lock()
-				lastUpdate := h.lastLogActivity[inst.ID]
-				if time.Since(lastUpdate) < 500*time.Millisecond {
+		h.sessions.View(func(instances []*session.Instance, _ *session.GroupTree) {
+			for _, inst := range instances {
+				if inst.GetTmuxSession() != nil && inst.GetTmuxSession().Name == sessionName {
+					h.logActivityMu.Lock()
+					lastUpdate := h.lastLogActivity[inst.ID]
+					if time.Since(lastUpdate) < 500*time.Millisecond {
+						h.logActivityMu.Unlock()
+						break
+					}
+					h.lastLogActivity[inst.ID] = time.Now()
 					h.logActivityMu.Unlock()
-					break
-				}
-				h.lastLogActivity[inst.ID] = time.Now()
-				h.logActivityMu.Unlock()
 
-				select {
-				case h.logUpdateChan <- inst:
-				default:
+					select {
+					case h.logUpdateChan <- inst:
+					default:
+					}
+					break
 				}
-				break
 			}
-		}
-		h.instancesMu.RUnlock()
+		})
 	}
 
 	// Control mode pipes: event-driven, zero-subprocess status detection
@@ -603,10 +861,7 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 	// Connect pipes for all existing running sessions in background
 	go func() {
 		time.Sleep(500 * time.Millisecond) // Let TUI render first
-		h.instancesMu.RLock()
-		instances := make([]*session.Instance, len(h.instances))
-		copy(instances, h.instances)
-		h.instancesMu.RUnlock()
+		instances := h.sessions.All()
 
 		for _, inst := range instances {
 			if ts := inst.GetTmuxSession(); ts != nil && ts.Exists() {
@@ -620,8 +875,14 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		pipeUILog.Debug("startup_pipes_connected", slog.Int("count", pm.ConnectedCount()))
 	}()
 
-	// Start background status worker (Priority 1C)
-	go h.statusWorker()
+	// Pane output logs: the same event-driven updates, from pipe-pane writes
+	if session.GetLogSettings().PaneOutput {
+		if lw, err := tmux.NewLogWatcher(h.ctx, outputCallback); err != nil {
+			uiLog.Warn("log_watcher_init_failed", slog.String("error", err.Error()))
+		} else {
+			go lw.Start()
+		}
+	}
 
 	// Start log worker pool (Priority 2)
 	h.startLogWorkers()
@@ -660,8 +921,32 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		}
 	}
 
-	// Hook-based status detection (Claude Code lifecycle hooks)
 	userConfig, _ := session.LoadUserConfig()
+	h.lastUIStateSave = time.Now()
+
+	// Keybindings: invalid or conflicting entries are reported but never fatal
+	var keyOverrides map[string]session.KeyList
+	var statusSettings session.StatusSettings
+	if userConfig != nil {
+		statusSettings = userConfig.Status
+		keyOverrides = userConfig.Keys
+		h.pollInterval = userConfig.Status.GetPollInterval()
+		h.numberSessions = userConfig.NumberKeysSessions()
+		h.nerdIcons = useNerdIcons(userConfig.ToolIcons)
+	}
+
+	// Start status polling (Priority 1C), now that the poll interval is known
+	h.monitor = session.NewMonitor(h.sessions.All, statusSettings, h.prepareStatusPoll)
+	go h.monitor.Start()
+	go h.statusWorker()
+	var keyWarnings []string
+	h.keymap, keyWarnings = NewKeymap(keyOverrides)
+	if len(keyWarnings) > 0 {
+		uiLog.Warn("keymap_config_problems", slog.String("warnings", strings.Join(keyWarnings, "; ")))
+		h.keymapWarning = "Keybindings: " + strings.Join(keyWarnings, "; ")
+	}
+
+	// Hook-based status detection (Claude Code lifecycle hooks)
 	hooksEnabled := userConfig == nil || userConfig.Claude.GetHooksEnabled()
 	if hooksEnabled {
 		configDir := session.GetClaudeConfigDir()
@@ -706,6 +991,14 @@ func NewHomeWithProfileAndMode(profile string) *Home {
 		}
 	}
 
+	// Claude transcript watcher (works with or without hooks)
+	if claudeWatcher, err := session.NewClaudeTranscriptWatcher(); err != nil {
+		uiLog.Warn("claude_transcript_watcher_init_failed", slog.String("error", err.Error()))
+	} else {
+		h.claudeWatcher = claudeWatcher
+		go claudeWatcher.Start()
+	}
+
 	// Start system theme watcher if configured
 	if session.GetTheme() == "system" {
 		h.themeWatcher = NewThemeWatcher(ctx)
@@ -742,16 +1035,11 @@ func (h *Home) getWebMenuData() *web.MemoryMenuData {
 
 func (h *Home) publishWebMenuSnapshot() {
 	menuData := h.getWebMenuData()
-	if menuData == nil || h.groupTree == nil {
+	if menuData == nil {
 		return
 	}
 
-	h.instancesMu.RLock()
-	instancesCopy := make([]*session.Instance, len(h.instances))
-	copy(instancesCopy, h.instances)
-	h.instancesMu.RUnlock()
-
-	groupTreeCopy := h.groupTree.ShallowCopyForSave()
+	instancesCopy, groupTreeCopy := h.sessions.Snapshot()
 	groupsData := make([]*session.GroupData, 0, len(groupTreeCopy.GroupList))
 	for _, g := range groupTreeCopy.GroupList {
 		if g == nil {
@@ -809,11 +1097,9 @@ func (h *Home) preserveState() reloadState {
 	}
 
 	// Capture expanded groups
-	if h.groupTree != nil {
-		for _, group := range h.groupTree.GroupList {
-			if group.Expanded {
-				state.expandedGroups[group.Path] = true
-			}
+	for _, group := range h.sessions.Tree().GroupList {
+		if group.Expanded {
+			state.expandedGroups[group.Path] = true
 		}
 	}
 
@@ -824,13 +1110,13 @@ func (h *Home) preserveState() reloadState {
 func (h *Home) restoreState(state reloadState) {
 	// Restore expanded groups (only for groups present in the map;
 	// new groups keep their default expanded state from storage)
-	if h.groupTree != nil {
-		for _, group := range h.groupTree.GroupList {
+	h.sessions.Update(func(tree *session.GroupTree) {
+		for _, group := range tree.GroupList {
 			if expanded, exists := state.expandedGroups[group.Path]; exists {
 				group.Expanded = expanded
 			}
 		}
-	}
+	})
 
 	// Rebuild flat items with restored group states
 	h.rebuildFlatItems()
@@ -881,17 +1167,200 @@ func (h *Home) restoreState(state reloadState) {
 	}
 }
 
+// statusFilterAttention is a status filter that matches every session
+// waiting on the user: waiting, needs input, or error.
+const statusFilterAttention session.Status = "attention"
+
+// needsAttention reports whether a session in status s is waiting on the user.
+func needsAttention(s session.Status) bool {
+	return s == session.StatusWaiting || s == session.StatusNeedsInput || s == session.StatusError
+}
+
+// matchesFilters reports whether a session passes the active status and tag filters.
+func (h *Home) matchesFilters(inst *session.Instance) bool {
+	switch h.statusFilter {
+	case "":
+	case statusFilterAttention:
+		if !needsAttention(inst.Status) {
+			return false
+		}
+	default:
+		if inst.Status != h.statusFilter {
+			return false
+		}
+	}
+	return h.tagFilter == "" || inst.HasTag(h.tagFilter)
+}
+
+// toggleStatusFilter shows only sessions matching status, or all sessions
+// again if that filter is already on.
+func (h *Home) toggleStatusFilter(status session.Status) {
+	if h.statusFilter == status {
+		h.statusFilter = "" // Toggle off
+	} else {
+		h.statusFilter = status
+	}
+	h.rebuildFlatItems()
+}
+
+// filterHit is a clickable status count in the header or filter bar.
+type filterHit struct {
+	row, x0, x1 int
+	filter      session.Status // "" clears all filters
+}
+
+// listArea is where View drew the session list: from screen row top, in
+// the columns before width, with the flatItems index drawn on each row
+// (detailed density gives sessions two rows).
+type listArea struct {
+	top, width int
+	items      []int
+}
+
+// itemAt returns the flatItems index drawn at screen cell (x, y), or -1.
+func (a listArea) itemAt(x, y int) int {
+	if x < 0 || x >= a.width || y < a.top || y >= a.top+len(a.items) {
+		return -1
+	}
+	return a.items[y-a.top]
+}
+
+// handleMouse handles left clicks and drags. A click on a status count in
+// the header or filter bar turns on the matching filter; a click in the
+// list selects the item under it, and dragging a session moves it (see
+// dragSessionTo). Hit areas are recorded by View, which clears them while
+// a dialog is shown.
+func (h *Home) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action == tea.MouseActionRelease {
+		// Some terminals don't say which button was released
+		h.endDrag()
+		return h, nil
+	}
+	if msg.Button != tea.MouseButtonLeft {
+		return h, nil
+	}
+	if msg.Action == tea.MouseActionMotion {
+		if h.dragSession != nil {
+			if idx := h.listArea.itemAt(msg.X, msg.Y); idx >= 0 && idx < len(h.flatItems) {
+				h.dragSessionTo(idx)
+			}
+		}
+		return h, nil
+	}
+
+	for _, hit := range h.filterHits {
+		if msg.Y != hit.row || msg.X < hit.x0 || msg.X >= hit.x1 {
+			continue
+		}
+		if hit.filter == "" {
+			h.statusFilter = ""
+			h.tagFilter = ""
+			h.rebuildFlatItems()
+		} else {
+			h.toggleStatusFilter(hit.filter)
+		}
+		return h, nil
+	}
+
+	idx := h.listArea.itemAt(msg.X, msg.Y)
+	if idx < 0 || idx >= len(h.flatItems) {
+		return h, nil
+	}
+	h.cursor = idx
+	h.syncViewport()
+	item := h.flatItems[idx]
+	if item.Type != session.ItemTypeSession || item.Session == nil {
+		return h, nil
+	}
+	h.dragSession = item.Session
+	h.dragRow = idx
+	h.dragMoved = false
+	return h, h.fetchPreviewDebounced(item.Session.ID)
+}
+
+// dragSessionTo moves the dragged session to the list row idx: next to the
+// session there, in that session's group, or to the top of a group whose
+// header it is dragged onto. Dragged down it lands after the item, dragged
+// up before it, so it follows the pointer. Nothing is saved until the drag
+// ends.
+func (h *Home) dragSessionTo(idx int) {
+	if idx == h.dragRow {
+		return
+	}
+	inst := h.dragSession
+	down := idx > h.dragRow
+	h.dragRow = idx
+
+	target := h.flatItems[idx]
+	groupPath, pos := "", 0
+	switch {
+	case target.Type == session.ItemTypeGroup:
+		if target.Path == inst.GroupPath {
+			return
+		}
+		groupPath = target.Path
+		if !down {
+			pos = len(h.sessions.Tree().Groups[groupPath].Sessions)
+		}
+	case target.Session != nil && target.Session != inst:
+		groupPath = target.Session.GroupPath
+		pos = slices.Index(h.sessions.Tree().Groups[groupPath].Sessions, target.Session)
+		if groupPath == inst.GroupPath {
+			if h.sortedReorderBlocked(groupPath) {
+				return
+			}
+		} else if down {
+			pos++
+		}
+	default:
+		return
+	}
+
+	h.sessions.Regroup(func(tree *session.GroupTree) {
+		tree.MoveSessionTo(inst, groupPath, pos)
+	})
+	h.dragMoved = true
+	h.rebuildFlatItems()
+	for i, item := range h.flatItems {
+		if item.Session == inst {
+			h.cursor = i
+			h.dragRow = i
+			h.syncViewport()
+			return
+		}
+	}
+	// Dropped into a collapsed group: select the group and stop dragging
+	for i, item := range h.flatItems {
+		if item.Type == session.ItemTypeGroup && item.Path == groupPath {
+			h.cursor = i
+			h.syncViewport()
+			break
+		}
+	}
+	h.endDrag()
+}
+
+// endDrag finishes a mouse drag, saving the new order if it moved anything.
+func (h *Home) endDrag() {
+	moved := h.dragMoved
+	h.dragSession = nil
+	h.dragMoved = false
+	if moved {
+		h.saveInstances()
+	}
+}
+
 // rebuildFlatItems rebuilds the flattened view from group tree
 func (h *Home) rebuildFlatItems() {
-	allItems := h.groupTree.Flatten()
+	allItems := h.sessions.Tree().Flatten()
 
-	// Apply status filter if active
-	if h.statusFilter != "" {
+	// Apply status and tag filters if active
+	if h.statusFilter != "" || h.tagFilter != "" {
 		// First pass: identify groups that have matching sessions
 		groupsWithMatches := make(map[string]bool)
 		for _, item := range allItems {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
-				if item.Session.Status == h.statusFilter {
+				if h.matchesFilters(item.Session) {
 					// Mark this session's group and all parent groups as having matches
 					groupsWithMatches[item.Path] = true
 					// Also mark parent paths
@@ -913,8 +1382,8 @@ func (h *Home) rebuildFlatItems() {
 					filtered = append(filtered, item)
 				}
 			} else if item.Type == session.ItemTypeSession && item.Session != nil {
-				// Keep session if it matches the filter
-				if item.Session.Status == h.statusFilter {
+				// Keep session if it matches the filters
+				if h.matchesFilters(item.Session) {
 					filtered = append(filtered, item)
 				}
 			}
@@ -1029,15 +1498,14 @@ func (h *Home) syncViewport() {
 	}
 
 	// If cursor is below viewport, scroll down
-	if h.cursor >= h.viewOffset+effectiveMaxVisible {
+	if h.cursor >= h.viewOffset+h.itemsFitting(h.viewOffset, effectiveMaxVisible) {
 		// When scrolling down, we need to account for the "more above" indicator
-		// that will appear once viewOffset > 0
-		if h.viewOffset == 0 {
-			// First scroll down: "more above" will appear, reducing visible by 1
-			h.viewOffset = h.cursor - (maxVisible - 1) + 1
-		} else {
-			// Already scrolled: "more above" already showing
-			h.viewOffset = h.cursor - effectiveMaxVisible + 1
+		// that will appear once viewOffset > 0. Every item takes at least one
+		// row, so start from the nearest offset that could fit and walk down
+		// past taller items (detailed density).
+		h.viewOffset = max(h.viewOffset+1, h.cursor-(maxVisible-1)+1)
+		for h.viewOffset < h.cursor && h.cursor >= h.viewOffset+h.itemsFitting(h.viewOffset, maxVisible-1) {
+			h.viewOffset++
 		}
 	}
 
@@ -1047,9 +1515,12 @@ func (h *Home) syncViewport() {
 	if h.viewOffset > 0 {
 		finalMaxVisible--
 	}
-	maxOffset := len(h.flatItems) - finalMaxVisible
-	if maxOffset < 0 {
-		maxOffset = 0
+	maxOffset := len(h.flatItems)
+	for used := 0; maxOffset > 0; maxOffset-- {
+		used += h.itemHeight(h.flatItems[maxOffset-1])
+		if used > finalMaxVisible {
+			break
+		}
 	}
 	if h.viewOffset > maxOffset {
 		h.viewOffset = maxOffset
@@ -1071,12 +1542,11 @@ func (h *Home) getAttachedSessionID() string {
 		return ""
 	}
 
-	h.instancesMu.RLock()
-	defer h.instancesMu.RUnlock()
+	instances := h.sessions.All()
 
 	// Find the first attached agentdeck session
 	for _, sessName := range attachedSessions {
-		for _, inst := range h.instances {
+		for _, inst := range instances {
 			if ts := inst.GetTmuxSession(); ts != nil && ts.Name == sessName {
 				return inst.ID
 			}
@@ -1142,6 +1612,9 @@ func (h *Home) getVisibleHeight() int {
 	if maxVisible < 1 {
 		maxVisible = 1
 	}
+	if h.listDensity == DensityDetailed {
+		maxVisible = max(maxVisible/2, 1) // Sessions take two rows
+	}
 	return maxVisible
 }
 
@@ -1167,6 +1640,41 @@ func (h *Home) jumpToRootGroup(n int) {
 	// If n exceeds available root groups, do nothing (no-op)
 }
 
+// jumpToNumberedSession selects the session shown with number n in the
+// list, or attaches it if it is already selected.
+func (h *Home) jumpToNumberedSession(n int) tea.Cmd {
+	if n < 1 || n > len(h.sessionNumbers) {
+		return nil
+	}
+	idx := h.sessionNumbers[n-1]
+	if idx >= len(h.flatItems) || h.flatItems[idx].Session == nil {
+		return nil
+	}
+	inst := h.flatItems[idx].Session
+	if h.cursor == idx {
+		return h.attachIfReady(inst)
+	}
+	h.cursor = idx
+	h.syncViewport()
+	return h.fetchPreviewDebounced(inst.ID)
+}
+
+// attachIfReady attaches inst unless it is still starting or not running.
+func (h *Home) attachIfReady(inst *session.Instance) tea.Cmd {
+	if h.hasActiveAnimation(inst.ID) {
+		h.toast(ToastWarning, "session is starting, please wait...")
+		return nil
+	}
+	if !inst.Exists() {
+		// Offer to bring it back instead of failing to attach
+		h.confirmDialog.ShowSessionNotRunning(inst.ID, inst.Title, inst.IsDead())
+		h.confirmDialog.SetSize(h.width, h.height)
+		return nil
+	}
+	h.isAttaching.Store(true) // Prevent View() output during transition (atomic)
+	return h.attachSession(inst)
+}
+
 // Init initializes the model
 func (h *Home) Init() tea.Cmd {
 	// Check for first run (no config.toml exists)
@@ -1180,6 +1688,7 @@ func (h *Home) Init() tea.Cmd {
 		h.loadSessions,
 
 		h.tick(),
+		h.previewUpdateTick(),
 		h.checkForUpdate(),
 	}
 
@@ -1193,6 +1702,9 @@ func (h *Home) Init() tea.Cmd {
 		cmds = append(cmds, listenForThemeChange(h.themeWatcher))
 	}
 
+	// Start listening for event-driven status changes
+	cmds = append(cmds, h.listenForStatusChanges())
+
 	return tea.Batch(cmds...)
 }
 
@@ -1278,11 +1790,26 @@ func (h *Home) loadSessions() tea.Msg {
 // tick returns a command that sends a tick message at regular intervals
 // Status updates use time-based cooldown to prevent flickering
 func (h *Home) tick() tea.Cmd {
-	return tea.Tick(tickInterval, func(t time.Time) tea.Msg {
+	return tea.Tick(session.ClampPollInterval(h.pollInterval), func(t time.Time) tea.Msg {
 		return tickMsg(t)
 	})
 }
 
+// previewUpdateTick returns a command that sends the next previewUpdateMsg.
+func (h *Home) previewUpdateTick() tea.Cmd {
+	return tea.Tick(previewFollowInterval, func(time.Time) tea.Msg {
+		return previewUpdateMsg{}
+	})
+}
+
+// followPreview reports whether the selected session's preview should be
+// re-captured early: its control pipe saw output after the cached capture.
+// Sessions without a pipe wait for the status tick, so following never
+// costs a tmux subprocess.
+func followPreview(connected bool, lastOutput, fetchedAt time.Time) bool {
+	return connected && lastOutput.After(fetchedAt)
+}
+
 // invalidatePreviewCache removes a session's preview from the cache
 // Called when session is deleted, renamed, or moved to ensure stale data is not displayed
 func (h *Home) invalidatePreviewCache(sessionID string) {
@@ -1318,18 +1845,24 @@ func (h *Home) pruneAnalyticsCache() {
 	session.PruneMCPCache(maxAge)
 }
 
-// setError sets an error with timestamp for auto-dismiss
+// setError logs err to the error log, which the header counts until it is
+// dismissed. Nil is ignored.
 func (h *Home) setError(err error) {
-	h.err = err
 	if err != nil {
-		h.errTime = time.Now()
+		uiLog.Warn("ui_error", slog.String("error", err.Error()))
+		h.errorLog.Add(err.Error(), time.Now())
 	}
 }
 
-// clearError clears the current error
+// toast shows a notification of the given level.
+func (h *Home) toast(level ToastLevel, format string, args ...any) {
+	h.toasts.Push(level, fmt.Sprintf(format, args...))
+}
+
+// clearError dismisses the toasts on screen, e.g. a validation error once
+// its dialog closes.
 func (h *Home) clearError() {
-	h.err = nil
-	h.errTime = time.Time{}
+	h.toasts.Dismiss()
 }
 
 // cleanupExpiredAnimations removes expired entries from an animation map
@@ -1337,7 +1870,7 @@ func (h *Home) clearError() {
 func (h *Home) cleanupExpiredAnimations(animMap map[string]time.Time, claudeTimeout, defaultTimeout time.Duration) []string {
 	var toDelete []string
 	for sessionID, startTime := range animMap {
-		inst := h.instanceByID[sessionID]
+		inst := h.sessions.Get(sessionID)
 		if inst == nil {
 			// Session was deleted, clean up
 			toDelete = append(toDelete, sessionID)
@@ -1363,7 +1896,7 @@ func (h *Home) cleanupExpiredAnimations(animMap map[string]time.Time, claudeTime
 // Returns true only if the animation is actually showing (not just tracked in the map)
 // This MUST match the display logic in renderPreviewPane exactly
 func (h *Home) hasActiveAnimation(sessionID string) bool {
-	inst := h.instanceByID[sessionID]
+	inst := h.sessions.Get(sessionID)
 	if inst == nil {
 		return false
 	}
@@ -1410,11 +1943,13 @@ func (h *Home) hasActiveAnimation(sessionID string) bool {
 	// STATUS-BASED CHECK: Session is ready when status is Running or Waiting
 	// - StatusRunning (GREEN): Claude is actively processing
 	// - StatusWaiting (YELLOW): Claude is at prompt, waiting for input
+	// - StatusNeedsInput (ORANGE): Claude is blocked on a permission prompt
 	// - StatusIdle (GRAY): Claude has stopped and user acknowledged
 	animStatus := inst.GetStatusThreadSafe()
 	animTool := inst.GetToolThreadSafe()
 	if animStatus == session.StatusRunning ||
 		animStatus == session.StatusWaiting ||
+		animStatus == session.StatusNeedsInput ||
 		animStatus == session.StatusIdle {
 		// Session is ready - stop animation immediately
 		return false
@@ -1423,7 +1958,7 @@ func (h *Home) hasActiveAnimation(sessionID string) bool {
 	// CONTENT-BASED CHECK: Also check preview content for faster detection
 	// This catches cases where status hasn't updated yet but content is visible
 	h.previewCacheMu.RLock()
-	previewContent := h.previewCache[sessionID]
+	previewContent := tmux.StripANSI(h.previewCache[sessionID])
 	h.previewCacheMu.RUnlock()
 
 	if animTool == "claude" || animTool == "gemini" {
@@ -1465,8 +2000,12 @@ func (h *Home) fetchPreview(inst *session.Instance) tea.Cmd {
 		return nil
 	}
 	sessionID := inst.ID
+	capture := inst.PreviewStyled
+	if h.previewPlain {
+		capture = inst.PreviewFull
+	}
 	return func() tea.Msg {
-		content, err := inst.PreviewFull()
+		content, err := capture()
 		return previewFetchedMsg{
 			sessionID: sessionID,
 			content:   content,
@@ -1475,6 +2014,42 @@ func (h *Home) fetchPreview(inst *session.Instance) tea.Cmd {
 	}
 }
 
+// captureSearchContent returns a command that captures the scrollback of
+// every running session for the search overlay's output search.
+func (h *Home) captureSearchContent() tea.Cmd {
+	instances := h.sessions.All()
+	return func() tea.Msg {
+		contents := make(map[string]string, len(instances))
+		for _, inst := range instances {
+			if ts := inst.GetTmuxSession(); ts == nil || !ts.Exists() {
+				continue
+			}
+			if content, err := inst.PreviewFull(); err == nil {
+				contents[inst.ID] = tmux.StripANSI(content)
+			}
+		}
+		return searchContentMsg{contents: contents}
+	}
+}
+
+// loadDashboardUsage reads today's token usage from every session's
+// transcript in the background.
+func (h *Home) loadDashboardUsage() tea.Cmd {
+	instances := h.sessions.All()
+	return func() tea.Msg {
+		now := time.Now()
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		var msg dashboardUsageMsg
+		for _, inst := range instances {
+			if u, ok := inst.ReadUsageSince(midnight); ok && u.TotalTokens() > 0 {
+				msg.usage = msg.usage.Add(u)
+				msg.sessions++
+			}
+		}
+		return msg
+	}
+}
+
 // fetchPreviewDebounced returns a command that triggers preview fetch after debounce delay
 // PERFORMANCE: Prevents rapid subprocess spawning during keyboard navigation
 // The 150ms delay allows navigation to settle before spawning tmux capture-pane
@@ -1597,12 +2172,6 @@ func (h *Home) getSelectedSession() *session.Instance {
 	return nil
 }
 
-// getInstanceByID returns the instance with the given ID using O(1) map lookup
-// Returns nil if not found. Caller must hold instancesMu if accessing from background goroutine.
-func (h *Home) getInstanceByID(id string) *session.Instance {
-	return h.instanceByID[id]
-}
-
 // pushUndoStack adds a deleted session to the undo stack (LIFO, capped at 10)
 func (h *Home) pushUndoStack(inst *session.Instance) {
 	entry := deletedSessionEntry{
@@ -1615,34 +2184,35 @@ func (h *Home) pushUndoStack(inst *session.Instance) {
 	}
 }
 
+// expandGroupOf expands the group of inst and its parents, so it is visible.
+func (h *Home) expandGroupOf(inst *session.Instance) {
+	if inst.GroupPath == "" {
+		return
+	}
+	h.sessions.Update(func(tree *session.GroupTree) {
+		tree.ExpandGroupWithParents(inst.GroupPath)
+	})
+}
+
 // getDefaultPathForGroup returns the default path for a group
 // Returns empty string if group not found or no default path set
 func (h *Home) getDefaultPathForGroup(groupPath string) string {
-	if h.groupTree == nil {
-		return ""
-	}
-	return h.groupTree.DefaultPathForGroup(groupPath)
+	return h.sessions.Tree().DefaultPathForGroup(groupPath)
 }
 
-// statusWorker runs in a background goroutine with its own ticker
-// This ensures status updates continue even when TUI is paused (tea.Exec)
+// statusWorker applies what the session monitor finds and forwards the
+// TUI's requests for early updates to it. The monitor polls on its own
+// ticker, so status updates continue even when the TUI is paused (tea.Exec).
 func (h *Home) statusWorker() {
 	defer close(h.statusWorkerDone)
 
-	// Internal ticker - independent of Bubble Tea event loop
-	// This is the key insight: when tea.Exec suspends the TUI (user attaches to session),
-	// the Bubble Tea tick messages stop firing, but this goroutine keeps running
-	ticker := time.NewTicker(2 * time.Second)
-	defer ticker.Stop()
-
 	for {
 		select {
 		case <-h.ctx.Done():
 			return
 
-		case <-ticker.C:
-			// Self-triggered update - runs even when TUI is paused
-			h.backgroundStatusUpdate()
+		case diff := <-h.monitor.Diffs():
+			h.applyStatusDiff(diff)
 
 		case req := <-h.statusTrigger:
 			// Explicit trigger from TUI (for immediate updates)
@@ -1669,7 +2239,20 @@ func (h *Home) startLogWorkers() {
 	}
 }
 
-// logWorker processes per-session status updates triggered by PipeManager %output events
+// listenForStatusChanges waits for a log worker to report a status change
+func (h *Home) listenForStatusChanges() tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-h.ctx.Done():
+			return nil
+		case <-h.statusChanged:
+			return sessionStatusChangedMsg{}
+		}
+	}
+}
+
+// logWorker processes per-session status updates triggered by output events
+// (PipeManager %output, LogWatcher writes) and reports status changes to the UI
 func (h *Home) logWorker() {
 	defer h.logWorkerWg.Done()
 	for {
@@ -1687,56 +2270,30 @@ func (h *Home) logWorker() {
 						uiLog.Error("log_worker_panic", slog.Any("panic", r))
 					}
 				}()
+				oldStatus := inst.GetStatusThreadSafe()
 				_ = inst.UpdateStatus()
+				if inst.GetStatusThreadSafe() != oldStatus {
+					h.cachedStatusCounts.valid.Store(false)
+					h.publishWebSessionStates([]*session.Instance{inst})
+					h.signalStatusChanged()
+				}
 			}()
 		}
 	}
 }
 
-// backgroundStatusUpdate runs independently of the TUI
-// Updates session statuses and syncs notification bar directly to tmux
-// This is called by the internal ticker even when TUI is paused (tea.Exec)
-func (h *Home) backgroundStatusUpdate() {
-	defer func() {
-		if r := recover(); r != nil {
-			notifLog.Error("background_update_panic", slog.Any("panic", r))
-		}
-	}()
-
-	totalStart := time.Now()
-
-	// Refresh tmux session cache
-	refreshStart := time.Now()
-	tmux.RefreshExistingSessions()
-	tmux.RefreshPaneInfoCache()
-	refreshDur := time.Since(refreshStart)
-	if refreshDur > 100*time.Millisecond {
-		perfLog.Warn("slow_refresh", slog.Duration("duration", refreshDur))
-	}
-
-	// Get instances snapshot
-	h.instancesMu.RLock()
-	if len(h.instances) == 0 {
-		h.instancesMu.RUnlock()
-		return
-	}
-	instances := make([]*session.Instance, len(h.instances))
-	copy(instances, h.instances)
-	h.instancesMu.RUnlock()
-
-	// PERFORMANCE: Gradually configure unconfigured sessions in background
-	// Configure one session per tick to avoid blocking the status update
-	// This ensures all sessions get configured within ~1 minute even without user interaction
-	for _, inst := range instances {
-		if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
-			if !tmuxSess.IsConfigured() && tmuxSess.Exists() {
-				tmuxSess.EnsureConfigured()
-				inst.SyncSessionIDsToTmux()
-				break // Only one per tick to avoid blocking
-			}
-		}
+// signalStatusChanged tells the UI a session's status changed, so the list
+// re-renders without waiting for the next tick
+func (h *Home) signalStatusChanged() {
+	select {
+	case h.statusChanged <- struct{}{}:
+	default: // A re-render is already pending
 	}
+}
 
+// prepareStatusPoll runs before each of the monitor's full polls, feeding
+// it what the TUI's watchers know.
+func (h *Home) prepareStatusPoll(instances []*session.Instance) {
 	// Feed hook statuses from watcher to instances (enables hook fast path in UpdateStatus)
 	if h.hookWatcher != nil {
 		for _, inst := range instances {
@@ -1748,72 +2305,63 @@ func (h *Home) backgroundStatusUpdate() {
 		}
 	}
 
-	// Update status for all instances in parallel (I/O bound: tmux subprocess calls)
-	// With PipeManager, skip sessions idle for >5s (no %output events = no status change)
-	statusStart := time.Now()
-	var statusChanged atomic.Bool
-	var slowMu sync.Mutex
-	var slowSessions []string
-	pm := tmux.GetPipeManager()
-	var skipped int
-
-	g := new(errgroup.Group)
-	g.SetLimit(10) // Pool of 10 workers (tmux server serializes, more doesn't help)
-
-	for _, inst := range instances {
-		inst := inst // capture loop variable
-
-		// Skip idle sessions when PipeManager knows they haven't produced output.
-		// Only skip if pipe is alive (otherwise we need UpdateStatus for Error detection).
-		if pm != nil {
-			if ts := inst.GetTmuxSession(); ts != nil && pm.IsConnected(ts.Name) {
-				lastOut := pm.LastOutputTime(ts.Name)
-				if !lastOut.IsZero() && time.Since(lastOut) > 5*time.Second {
-					skipped++
-					continue
-				}
+	// Keep Claude session IDs current from transcript writes, skipping IDs
+	// other sessions own
+	if h.claudeWatcher != nil {
+		owners := make(map[string]string)
+		for _, inst := range instances {
+			if inst.Tool == "claude" && inst.ClaudeSessionID != "" {
+				owners[inst.ClaudeSessionID] = inst.ID
 			}
 		}
-
-		g.Go(func() error {
-			oldStatus := inst.GetStatusThreadSafe()
-			instStart := time.Now()
-			_ = inst.UpdateStatus()
-			instDur := time.Since(instStart)
-
-			if instDur > 50*time.Millisecond {
-				slowMu.Lock()
-				slowSessions = append(slowSessions, fmt.Sprintf("%s=%v", inst.Title, instDur.Round(time.Millisecond)))
-				slowMu.Unlock()
-			}
-			newStatus := inst.GetStatusThreadSafe()
-			if newStatus != oldStatus {
-				statusChanged.Store(true)
-				notifLog.Debug("status_changed", slog.String("title", inst.Title), slog.String("old", string(oldStatus)), slog.String("new", string(newStatus)))
+		for _, inst := range instances {
+			if inst.Tool != "claude" {
+				continue
 			}
-			return nil
-		})
+			instID := inst.ID
+			inst.SyncClaudeSessionFromWatcher(h.claudeWatcher, func(id string) bool {
+				owner, ok := owners[id]
+				return ok && owner != instID
+			})
+		}
 	}
-	_ = g.Wait() // Errors are logged within each goroutine
+}
 
-	statusDur := time.Since(statusStart)
-	if skipped > 0 {
-		perfLog.Debug("idle_sessions_skipped", slog.Int("skipped", skipped), slog.Int("checked", len(instances)-skipped))
-	}
-	if statusDur > 500*time.Millisecond {
-		perfLog.Info("slow_status_loop", slog.Duration("duration", statusDur), slog.Int("sessions", len(instances)))
-		slowMu.Lock()
-		if len(slowSessions) > 0 {
-			perfLog.Info("slow_sessions", slog.String("details", strings.Join(slowSessions, ", ")))
+// applyStatusDiff acts on a monitor poll: notifications, webhooks and a
+// re-render for status changes, and after full polls the work that rides on
+// the poll interval (queued prompts, auto-restart, SQLite sync, the tmux
+// notification bar). Runs on the status worker, even while the TUI is paused.
+func (h *Home) applyStatusDiff(diff session.StatusDiff) {
+	defer func() {
+		if r := recover(); r != nil {
+			notifLog.Error("background_update_panic", slog.Any("panic", r))
 		}
-		slowMu.Unlock()
-	}
+	}()
 
-	// Invalidate cache if status changed
-	if statusChanged.Load() {
+	totalStart := time.Now()
+	for _, c := range diff.Changes {
+		notifLog.Debug("status_changed", slog.String("title", c.Instance.Title), slog.String("old", string(c.Old)), slog.String("new", string(c.New)))
+		h.notifyStatusChange(c.Instance, c.Old, c.New)
+		h.postWebhooks(c.Instance, c.Old, c.New)
+		if c.New == session.StatusError && c.Old != session.StatusStarting {
+			h.toasts.Push(ToastWarning, sessionDiedText(c.Instance))
+		}
+	}
+	if len(diff.Changes) > 0 {
 		h.cachedStatusCounts.valid.Store(false)
-		h.publishWebSessionStates(instances)
+		h.publishWebSessionStates(diff.Instances)
+		h.signalStatusChanged()
+	}
+	if !diff.Full {
+		return
 	}
+	instances := diff.Instances
+
+	// Feed queued prompts to sessions that are ready for the next one
+	h.dispatchQueuedPrompts(instances)
+
+	// Bring back crashed sessions that have an auto-restart policy
+	h.autoRestartCrashed(instances)
 
 	// SQLite sync: heartbeat, status writes, ack reads (enables multi-instance coordination)
 	if db := statedb.GetGlobal(); db != nil {
@@ -1839,7 +2387,6 @@ func (h *Home) backgroundStatusUpdate() {
 				}
 			}
 		}
-
 	}
 
 	// Always sync notification bar - must check for signal file (Ctrl+b N acknowledgments)
@@ -1852,13 +2399,191 @@ func (h *Home) backgroundStatusUpdate() {
 	if totalDur > 1*time.Second {
 		perfLog.Warn("background_status_update_slow",
 			slog.Duration("total", totalDur),
-			slog.Duration("status", time.Since(statusStart)),
 			slog.Duration("notif", notifDur),
-			slog.Duration("refresh", refreshDur),
 			slog.Int("sessions", len(instances)))
 	}
 }
 
+// autoRestartCrashed restarts every session that crashed and is due for an
+// automatic restart (see Instance.TakeAutoRestart). Restarts run in their
+// own goroutines so a slow one doesn't hold up status polling. With several
+// TUIs open only the primary one restarts, so a crash isn't restarted twice.
+func (h *Home) autoRestartCrashed(instances []*session.Instance) {
+	if !isPrimaryTUI() {
+		return
+	}
+	for _, inst := range instances {
+		attempt, ok := inst.TakeAutoRestart()
+		if !ok {
+			continue
+		}
+		uiLog.Info("auto_restart", slog.String("title", inst.Title), slog.Int("attempt", attempt), slog.Int("max", inst.AutoRestartMax))
+		go func(inst *session.Instance) {
+			err := inst.Restart()
+			inst.FinishAutoRestart(err)
+			if err != nil {
+				uiLog.Warn("auto_restart_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+				return
+			}
+			inst.CaptureLoadedMCPs()
+			h.autoRestartDirty.Store(true)
+		}(inst)
+	}
+}
+
+// dispatchQueuedPrompts sends the next queued prompt to every session that
+// is ready for one (see Instance.TakeQueuedPrompt). Runs on the status
+// worker so queues keep moving while the TUI is attached elsewhere. With
+// several TUIs open only the primary one sends, so no prompt goes out twice.
+func (h *Home) dispatchQueuedPrompts(instances []*session.Instance) {
+	if !isPrimaryTUI() {
+		return
+	}
+	for _, inst := range instances {
+		prompt, ok := inst.TakeQueuedPrompt()
+		if !ok {
+			continue
+		}
+		tmuxSession := inst.GetTmuxSession()
+		if tmuxSession == nil {
+			inst.RequeuePrompt(prompt)
+			continue
+		}
+		if err := tmuxSession.SendKeysAndEnter(prompt); err != nil {
+			uiLog.Warn("queued_prompt_send_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+			inst.RequeuePrompt(prompt)
+			continue
+		}
+		uiLog.Info("queued_prompt_sent", slog.String("title", inst.Title), slog.Int("remaining", inst.QueuedPromptCount()))
+		h.promptQueueDirty.Store(true)
+	}
+}
+
+// notifyStatusChange sends the configured desktop, terminal and push
+// notifications when inst has just started waiting or needing approval and
+// the user isn't looking at the TUI: the terminal lost focus, or they are
+// attached to a different session. Push notifications go to a phone, so like
+// webhooks only the primary TUI sends them.
+func (h *Home) notifyStatusChange(inst *session.Instance, oldStatus, newStatus session.Status) {
+	if !h.desktopNotifications && h.terminalNotification == "" && !h.pushTargets.Enabled() {
+		return
+	}
+	if h.notificationsMuted(inst) || !session.ShouldNotify(oldStatus, newStatus) {
+		return
+	}
+	if newStatus == session.StatusWaiting && inst.QueuedPromptCount() > 0 {
+		return // The queue carries on by itself
+	}
+	if h.isAttaching.Load() {
+		if h.getAttachedSessionID() == inst.ID {
+			return // they are looking at it
+		}
+	} else if h.terminalFocused.Load() {
+		return
+	}
+
+	title := fmt.Sprintf("Agent Deck: %s (waiting)", inst.Title)
+	body := "Finished and waiting for input"
+	if newStatus == session.StatusNeedsInput {
+		title = fmt.Sprintf("Agent Deck: %s (needs approval)", inst.Title)
+		body = "Blocked on an approval prompt"
+	}
+	if h.terminalNotification != "" {
+		if err := notify.SendTerminal(h.terminalNotification, title, body); err != nil {
+			notifLog.Warn("terminal_notify_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+		}
+	}
+	if h.pushTargets.Enabled() && isPrimaryTUI() {
+		go func() {
+			if err := notify.Push(h.pushTargets, title, body, newStatus == session.StatusNeedsInput); err != nil {
+				notifLog.Warn("push_notify_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+			}
+		}()
+	}
+	if !h.desktopNotifications {
+		return
+	}
+	go func() {
+		method, err := notify.Send(title, body)
+		if err != nil {
+			notifLog.Warn("desktop_notify_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+			return
+		}
+		notifLog.Debug("desktop_notify_sent", slog.String("title", inst.Title), slog.String("method", method))
+	}()
+}
+
+// sessionDiedText describes why a session went into the error status.
+func sessionDiedText(inst *session.Instance) string {
+	reason := inst.GetErrorReasonThreadSafe()
+	if reason == "" && inst.IsDead() {
+		reason = "tmux session was killed"
+	} else if reason == "" {
+		reason = "tmux session died"
+	}
+	return fmt.Sprintf("'%s': %s", inst.Title, reason)
+}
+
+// isPrimaryTUI reports whether this TUI is the primary instance and so owns
+// side effects that must happen once no matter how many TUIs are open.
+// Without a state database (or if the election fails) it assumes it is.
+func isPrimaryTUI() bool {
+	if db := statedb.GetGlobal(); db != nil {
+		if primary, err := db.ElectPrimary(30 * time.Second); err == nil && !primary {
+			return false
+		}
+	}
+	return true
+}
+
+// postWebhooks POSTs a status change to the configured webhooks. With
+// several TUIs open only the primary one posts, so each change is sent once.
+func (h *Home) postWebhooks(inst *session.Instance, oldStatus, newStatus session.Status) {
+	if len(h.webhooks) == 0 {
+		return
+	}
+	if h.webhookStatuses != nil && !h.webhookStatuses[newStatus] {
+		return
+	}
+	if h.notificationsMuted(inst) {
+		return
+	}
+	if !isPrimaryTUI() {
+		return
+	}
+
+	event := notify.NewWebhookEvent(inst.ID, inst.Title, inst.Tool, inst.GroupPath, inst.ProjectPath,
+		string(oldStatus), string(newStatus), time.Now())
+	for _, url := range h.webhooks {
+		go func(url string) {
+			if err := notify.PostWebhook(url, event); err != nil {
+				notifLog.Warn("webhook_failed", slog.String("title", inst.Title), slog.String("error", err.Error()))
+				return
+			}
+			notifLog.Debug("webhook_sent", slog.String("title", inst.Title), slog.String("status", string(newStatus)))
+		}(url)
+	}
+}
+
+// notificationsMuted reports whether inst's notifications are off: muted
+// itself, in a muted group, or do-not-disturb is on. Safe to call from the
+// background worker.
+func (h *Home) notificationsMuted(inst *session.Instance) bool {
+	if h.doNotDisturb.Load() || inst.GetNotifyMutedThreadSafe() {
+		return true
+	}
+	if muted := h.mutedGroups.Load(); muted != nil {
+		return session.IsGroupPathMuted(*muted, inst.GroupPath)
+	}
+	return false
+}
+
+// syncMutedGroups refreshes the background worker's copy of the muted groups.
+func (h *Home) syncMutedGroups() {
+	muted := h.sessions.Tree().MutedGroupPaths()
+	h.mutedGroups.Store(&muted)
+}
+
 // syncNotificationsBackground updates the tmux notification bar directly
 // Called from background worker - does NOT depend on Bubble Tea
 func (h *Home) syncNotificationsBackground() {
@@ -1890,13 +2615,11 @@ func (h *Home) syncNotificationsBackground() {
 	}
 
 	// Get current instances (copy to avoid race with main goroutine)
-	h.instancesMu.RLock()
-	instances := make([]*session.Instance, len(h.instances))
-	copy(instances, h.instances)
+	instances := h.sessions.All()
 
 	// Phase 2: Acknowledge the session if signal was received
 	if sessionToAcknowledgeID != "" {
-		if inst, ok := h.instanceByID[sessionToAcknowledgeID]; ok {
+		if inst := h.sessions.Get(sessionToAcknowledgeID); inst != nil {
 			if ts := inst.GetTmuxSession(); ts != nil {
 				ts.Acknowledge()
 				// Persist ack to SQLite so other instances see it
@@ -1908,7 +2631,6 @@ func (h *Home) syncNotificationsBackground() {
 			}
 		}
 	}
-	h.instancesMu.RUnlock()
 
 	// Detect currently attached session (may be the user's session during tea.Exec)
 	currentSessionID := h.getAttachedSessionID()
@@ -1957,7 +2679,7 @@ func (h *Home) syncNotificationsBackground() {
 func (h *Home) updateKeyBindings() {
 	entries := h.notificationManager.GetEntries()
 
-	// Phase 1: Collect binding info while holding instancesMu (read-only)
+	// Phase 1: Collect binding info
 	type bindingInfo struct {
 		key        string
 		sessionID  string
@@ -1967,13 +2689,12 @@ func (h *Home) updateKeyBindings() {
 	bindings := make([]bindingInfo, 0, len(entries))
 	currentKeys := make(map[string]string) // key -> sessionID
 
-	h.instancesMu.RLock()
 	for _, e := range entries {
 		currentKeys[e.AssignedKey] = e.SessionID
 
 		// Look up CURRENT TmuxName from instance (cached entry may be stale)
 		currentTmuxName := e.TmuxName
-		if inst, ok := h.instanceByID[e.SessionID]; ok {
+		if inst := h.sessions.Get(e.SessionID); inst != nil {
 			if ts := inst.GetTmuxSession(); ts != nil {
 				currentTmuxName = ts.Name
 			}
@@ -1986,7 +2707,6 @@ func (h *Home) updateKeyBindings() {
 			bindingKey: e.SessionID + ":" + currentTmuxName,
 		})
 	}
-	h.instancesMu.RUnlock()
 
 	// Phase 2: Update key bindings while holding boundKeysMu
 	h.boundKeysMu.Lock()
@@ -2048,23 +2768,16 @@ func (h *Home) triggerStatusUpdate() {
 //
 // Performance: With 10 sessions, updating all takes ~1-2s of cumulative time per tick.
 // With batching (3 visible + 2 non-visible per tick), we keep each tick under 100ms.
+// The chosen sessions are handed to the session monitor, which updates them
+// on its worker pool and reports changes like any other poll.
 func (h *Home) processStatusUpdate(req statusUpdateRequest) {
 	const batchSize = 2 // Reduced from 5 to 2 - fewer CapturePane() calls per tick
 
-	// CRITICAL FIX: Refresh session cache in background worker, NOT main goroutine
-	// This prevents UI freezing when subprocess spawning is slow (high system load)
-	// The cache refresh spawns `tmux list-sessions` which can block for 50-200ms
-	tmux.RefreshExistingSessions()
-
-	// Take a snapshot of instances under read lock (thread-safe)
-	h.instancesMu.RLock()
-	if len(h.instances) == 0 {
-		h.instancesMu.RUnlock()
+	// Take a snapshot of instances (thread-safe)
+	instancesCopy := h.sessions.All()
+	if len(instancesCopy) == 0 {
 		return
 	}
-	instancesCopy := make([]*session.Instance, len(h.instances))
-	copy(instancesCopy, h.instances)
-	h.instancesMu.RUnlock()
 
 	// Build set of visible session IDs for quick lookup
 	visibleIDs := make(map[string]bool)
@@ -2074,19 +2787,14 @@ func (h *Home) processStatusUpdate(req statusUpdateRequest) {
 		visibleIDs[req.flatItemIDs[i]] = true
 	}
 
-	// Track which sessions we've updated this tick
+	// Track which sessions we update this tick
 	updated := make(map[string]bool)
-	// Track if any status actually changed (for cache invalidation)
-	statusChanged := false
+	var batch []*session.Instance
 
 	// Step 1: Always update visible sessions (Priority 1B - visible first)
 	for _, inst := range instancesCopy {
 		if visibleIDs[inst.ID] {
-			oldStatus := inst.GetStatusThreadSafe()
-			_ = inst.UpdateStatus() // Ignore errors in background worker
-			if inst.GetStatusThreadSafe() != oldStatus {
-				statusChanged = true
-			}
+			batch = append(batch, inst)
 			updated[inst.ID] = true
 		}
 	}
@@ -2113,21 +2821,12 @@ func (h *Home) processStatusUpdate(req statusUpdateRequest) {
 			continue
 		}
 
-		oldStatus := inst.GetStatusThreadSafe()
-		_ = inst.UpdateStatus() // Ignore errors in background worker
-		if inst.GetStatusThreadSafe() != oldStatus {
-			statusChanged = true
-		}
+		batch = append(batch, inst)
 		remaining--
 		h.statusUpdateIndex.Store(int32((idx + 1) % instanceCount))
 	}
 
-	// Only invalidate status counts cache if status actually changed
-	// This reduces View() overhead by keeping cache valid when no changes occurred
-	if statusChanged {
-		h.cachedStatusCounts.valid.Store(false)
-		h.publishWebSessionStates(instancesCopy)
-	}
+	h.monitor.Poll(batch)
 }
 
 // Update handles messages
@@ -2139,6 +2838,17 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Execute final shutdown logic after splash delay
 		return h, h.performFinalShutdown(bool(msg))
 
+	case tea.FocusMsg:
+		h.terminalFocused.Store(true)
+		h.monitor.SetFocused(true)
+		return h, nil
+
+	case tea.BlurMsg:
+		// Poll active sessions less often while the user is elsewhere
+		h.terminalFocused.Store(false)
+		h.monitor.SetFocused(false)
+		return h, nil
+
 	case tea.WindowSizeMsg:
 		h.width = msg.Width
 		h.height = msg.Height
@@ -2147,6 +2857,16 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.setupWizard.SetSize(msg.Width, msg.Height)
 		h.settingsPanel.SetSize(msg.Width, msg.Height)
 		h.geminiModelDialog.SetSize(msg.Width, msg.Height)
+		h.transcriptViewer.SetSize(msg.Width, msg.Height)
+		h.diffViewer.SetSize(msg.Width, msg.Height)
+		h.scrollbackViewer.SetSize(msg.Width, msg.Height)
+		h.cleanupDialog.SetSize(msg.Width, msg.Height)
+		h.importDialog.SetSize(msg.Width, msg.Height)
+		h.recentDialog.SetSize(msg.Width, msg.Height)
+		h.toastLogDialog.SetSize(msg.Width, msg.Height)
+		h.errorLogDialog.SetSize(msg.Width, msg.Height)
+		h.dashboard.SetSize(msg.Width, msg.Height)
+		h.sessionInfoDialog.SetSize(msg.Width, msg.Height)
 		return h, nil
 
 	case loadSessionsMsg:
@@ -2190,69 +2910,53 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// Re-capture expanded groups (user may have toggled between
 				// storageChangedMsg and now)
-				if h.groupTree != nil {
-					msg.restoreState.expandedGroups = make(map[string]bool)
-					for _, group := range h.groupTree.GroupList {
-						if group.Expanded {
-							msg.restoreState.expandedGroups[group.Path] = true
-						}
+				msg.restoreState.expandedGroups = make(map[string]bool)
+				for _, group := range h.sessions.Tree().GroupList {
+					if group.Expanded {
+						msg.restoreState.expandedGroups[group.Path] = true
 					}
 				}
 			}
 
-			h.instancesMu.Lock()
-			oldCount := len(h.instances)
-			h.instances = msg.instances
+			oldCount := h.sessions.Len()
 			newCount := len(msg.instances)
 			uiLog.Debug("reload_load_sessions", slog.Int("old_count", oldCount), slog.Int("new_count", newCount), slog.String("profile", h.profile))
-			// Rebuild instanceByID map for O(1) lookup
-			h.instanceByID = make(map[string]*session.Instance, len(h.instances))
-			for _, inst := range h.instances {
-				h.instanceByID[inst.ID] = inst
-			}
 			// Deduplicate Claude session IDs on load to fix any existing duplicates
 			// This ensures no two sessions share the same Claude session ID
-			session.UpdateClaudeSessionsWithDedup(h.instances)
+			session.UpdateClaudeSessionsWithDedup(msg.instances)
 			// Collect OpenCode detection commands for restored sessions without IDs
 			// Using tea.Cmd pattern ensures save is triggered after detection completes
 			var detectionCmds []tea.Cmd
-			for _, inst := range h.instances {
+			for _, inst := range msg.instances {
 				if inst.Tool == "opencode" && inst.OpenCodeSessionID == "" {
 					detectionCmds = append(detectionCmds, h.detectOpenCodeSessionCmd(inst))
 				}
 			}
-			h.instancesMu.Unlock()
-			// Invalidate status counts cache
-			h.cachedStatusCounts.valid.Store(false)
-			// Sync group tree with loaded data
-			if h.groupTree.GroupCount() == 0 {
-				// Initial load - use stored groups if available
-				if len(msg.groups) > 0 {
-					h.groupTree = session.NewGroupTreeWithGroups(h.instances, msg.groups)
-				} else {
-					h.groupTree = session.NewGroupTree(h.instances)
-				}
+			// Build the group tree from the loaded sessions AND groups, using
+			// stored groups if available
+			var tree *session.GroupTree
+			if len(msg.groups) > 0 {
+				tree = session.NewGroupTreeWithGroups(msg.instances, msg.groups)
 			} else {
-				// Refresh - update existing tree with loaded sessions AND groups
-				// Preserve expanded state before recreating tree
-				expandedState := make(map[string]bool)
-				for path, group := range h.groupTree.Groups {
-					expandedState[path] = group.Expanded
-				}
-				// Recreate tree with fresh groups from storage
-				if len(msg.groups) > 0 {
-					h.groupTree = session.NewGroupTreeWithGroups(h.instances, msg.groups)
-				} else {
-					h.groupTree = session.NewGroupTree(h.instances)
-				}
-				// Restore expanded state for groups that still exist
-				for path, expanded := range expandedState {
-					if group, exists := h.groupTree.Groups[path]; exists {
-						group.Expanded = expanded
-					}
+				tree = session.NewGroupTree(msg.instances)
+			}
+			// On refresh, keep the expanded state of groups that still exist
+			for path, group := range h.sessions.Tree().Groups {
+				if fresh, exists := tree.Groups[path]; exists {
+					fresh.Expanded = group.Expanded
 				}
 			}
-			h.search.SetItems(h.instances)
+			h.sessions.Replace(msg.instances, tree)
+			// Invalidate status counts cache
+			h.cachedStatusCounts.valid.Store(false)
+			h.syncMutedGroups()
+			h.search.SetItems(h.sessions.All())
+
+			// First load: offer to recreate sessions lost to a reboot
+			if !h.resurrectChecked {
+				h.resurrectChecked = true
+				h.promptResurrectIfAllGone()
+			}
 
 			// Re-apply pending title changes that were lost during reload.
 			// This happens when a rename's save was skipped (isReloading=true)
@@ -2260,7 +2964,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(h.pendingTitleChanges) > 0 {
 				applied := false
 				for id, title := range h.pendingTitleChanges {
-					if inst := h.getInstanceByID(id); inst != nil && inst.Title != title {
+					if inst := h.sessions.Get(id); inst != nil && inst.Title != title {
 						inst.Title = title
 						inst.SyncTmuxDisplayName()
 						applied = true
@@ -2326,6 +3030,19 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case tmuxSessionsDiscoveredMsg:
+		if msg.err != nil {
+			h.setError(msg.err)
+			return h, nil
+		}
+		if len(msg.instances) == 0 {
+			h.toast(ToastInfo, "No tmux sessions to import")
+			return h, nil
+		}
+		h.importDialog.SetSize(h.width, h.height)
+		h.importDialog.Show(msg.instances, h.sessions.Tree().GetGroupPaths())
+		return h, nil
+
 	case sessionCreatedMsg:
 		// Handle reload scenario: session was already started in tmux, we MUST save it to JSON
 		// even during reload, otherwise the session becomes orphaned (exists in tmux but not in storage)
@@ -2336,9 +3053,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// CRITICAL: Save the new session to JSON immediately to prevent orphaning
 			// Skip in-memory state update (reload will handle that), but persist to disk
 			uiLog.Debug("reload_save_session_created", slog.String("id", msg.instance.ID), slog.String("title", msg.instance.Title))
-			h.instancesMu.Lock()
-			h.instances = append(h.instances, msg.instance)
-			h.instancesMu.Unlock()
+			h.sessions.Add(msg.instance)
 			// Force save to persist the session even during reload
 			h.forceSaveInstances()
 			// Trigger another reload to pick up the new session in the UI
@@ -2350,12 +3065,10 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			h.setError(msg.err)
 		} else {
-			h.instancesMu.Lock()
-			h.instances = append(h.instances, msg.instance)
-			h.instanceByID[msg.instance.ID] = msg.instance
+			// Add to the existing group tree instead of rebuilding
+			h.sessions.Add(msg.instance)
 			// Run dedup to ensure the new session doesn't have a duplicate ID
-			session.UpdateClaudeSessionsWithDedup(h.instances)
-			h.instancesMu.Unlock()
+			h.sessions.DedupClaudeSessions()
 			// Invalidate status counts cache
 			h.cachedStatusCounts.valid.Store(false)
 
@@ -2363,14 +3076,9 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			h.launchingSessions[msg.instance.ID] = time.Now()
 
 			// Expand the group so the session is visible
-			if msg.instance.GroupPath != "" {
-				h.groupTree.ExpandGroupWithParents(msg.instance.GroupPath)
-			}
-
-			// Add to existing group tree instead of rebuilding
-			h.groupTree.AddSession(msg.instance)
+			h.expandGroupOf(msg.instance)
 			h.rebuildFlatItems()
-			h.search.SetItems(h.instances)
+			h.search.SetItems(h.sessions.All())
 
 			// Auto-select the new session
 			for i, item := range h.flatItems {
@@ -2384,6 +3092,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Save both instances AND groups (critical fix: was losing groups!)
 			// Use forceSave to bypass mtime check - new session creation MUST persist
 			h.forceSaveInstances()
+			h.toast(ToastSuccess, "Session '%s' created", msg.instance.Title)
 
 			// Start fetching preview for the new session
 			return h, h.fetchPreview(msg.instance)
@@ -2403,9 +3112,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if reloading && msg.err == nil && msg.instance != nil {
 			// CRITICAL: Save the forked session to JSON immediately to prevent orphaning
 			uiLog.Debug("reload_save_session_forked", slog.String("id", msg.instance.ID), slog.String("title", msg.instance.Title))
-			h.instancesMu.Lock()
-			h.instances = append(h.instances, msg.instance)
-			h.instancesMu.Unlock()
+			h.sessions.Add(msg.instance)
 			h.forceSaveInstances()
 			if h.storageWatcher != nil {
 				h.storageWatcher.TriggerReload()
@@ -2416,13 +3123,11 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			h.setError(msg.err)
 		} else {
-			h.instancesMu.Lock()
-			h.instances = append(h.instances, msg.instance)
-			h.instanceByID[msg.instance.ID] = msg.instance
+			// Add to the existing group tree instead of rebuilding
+			h.sessions.Add(msg.instance)
 			// Run dedup to ensure the forked session doesn't have a duplicate ID
 			// This is critical: fork detection may have picked up wrong session
-			session.UpdateClaudeSessionsWithDedup(h.instances)
-			h.instancesMu.Unlock()
+			h.sessions.DedupClaudeSessions()
 			// Invalidate status counts cache
 			h.cachedStatusCounts.valid.Store(false)
 
@@ -2430,14 +3135,9 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			h.launchingSessions[msg.instance.ID] = time.Now()
 
 			// Expand the group so the session is visible
-			if msg.instance.GroupPath != "" {
-				h.groupTree.ExpandGroupWithParents(msg.instance.GroupPath)
-			}
-
-			// Add to existing group tree instead of rebuilding
-			h.groupTree.AddSession(msg.instance)
+			h.expandGroupOf(msg.instance)
 			h.rebuildFlatItems()
-			h.search.SetItems(h.instances)
+			h.search.SetItems(h.sessions.All())
 
 			// Auto-select the forked session
 			for i, item := range h.flatItems {
@@ -2451,6 +3151,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Save both instances AND groups
 			// Use forceSave to bypass mtime check - forked session MUST persist
 			h.forceSaveInstances()
+			h.toast(ToastSuccess, "Forked '%s'", msg.instance.Title)
 
 			// Start fetching preview for the forked session
 			return h, h.fetchPreview(msg.instance)
@@ -2467,26 +3168,24 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return h, nil
 		}
 
+		if msg.archiveErr != nil {
+			h.setError(fmt.Errorf("failed to archive session: %w", msg.archiveErr))
+			return h, nil
+		}
+
 		// Report kill error if any (session may still be running in tmux)
 		if msg.killErr != nil {
-			h.setError(fmt.Errorf("warning: tmux session may still be running: %w", msg.killErr))
+			h.toast(ToastWarning, "tmux session may still be running: %v", msg.killErr)
+		} else if msg.worktreeErr != nil {
+			h.toast(ToastWarning, "worktree was kept: %v", msg.worktreeErr)
 		}
 
-		// Find and remove from list
-		var deletedInstance *session.Instance
-		h.instancesMu.Lock()
-		for i, s := range h.instances {
-			if s.ID == msg.deletedID {
-				deletedInstance = s
-				h.instances = append(h.instances[:i], h.instances[i+1:]...)
-				break
-			}
-		}
-		delete(h.instanceByID, msg.deletedID)
-		h.instancesMu.Unlock()
+		// Remove from the list and the group tree (preserves empty groups)
+		deletedInstance := h.sessions.Remove(msg.deletedID)
 
-		// Push to undo stack before removing from group tree
-		if deletedInstance != nil {
+		// Push to undo stack (archived sessions are restored from the
+		// archive instead)
+		if deletedInstance != nil && !msg.archived {
 			h.pushUndoStack(deletedInstance)
 		}
 
@@ -2501,13 +3200,9 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.logActivityMu.Lock()
 		delete(h.lastLogActivity, msg.deletedID)
 		h.logActivityMu.Unlock()
-		// Remove from group tree (preserves empty groups)
-		if deletedInstance != nil {
-			h.groupTree.RemoveSession(deletedInstance)
-		}
 		h.rebuildFlatItems()
 		// Update search items
-		h.search.SetItems(h.instances)
+		h.search.SetItems(h.sessions.All())
 		// Explicitly delete from database to prevent resurrection on reload
 		if err := h.storage.DeleteInstance(msg.deletedID); err != nil {
 			uiLog.Warn("delete_instance_db_err", slog.String("id", msg.deletedID), slog.String("err", err.Error()))
@@ -2517,8 +3212,22 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.forceSaveInstances()
 
 		// Show undo hint (using setError as a transient message)
-		if deletedInstance != nil {
-			h.setError(fmt.Errorf("deleted '%s'. Ctrl+Z to undo", deletedInstance.Title))
+		if deletedInstance != nil && msg.archived {
+			h.toast(ToastSuccess, "archived '%s'. %s to view archive", deletedInstance.Title, h.keyHint(ActionArchive))
+		} else if deletedInstance != nil {
+			h.toast(ToastSuccess, "deleted '%s'. %s to undo", deletedInstance.Title, h.keyHint(ActionUndo))
+		}
+		return h, nil
+
+	case idleSessionsKilledMsg:
+		if msg.killed > 0 {
+			h.cachedStatusCounts.valid.Store(false)
+			h.saveInstances()
+		}
+		if len(msg.errs) > 0 {
+			h.setError(fmt.Errorf("stopped %d idle sessions, %d failed (first: %w)", msg.killed, len(msg.errs), msg.errs[0]))
+		} else {
+			h.toast(ToastSuccess, "stopped %d idle sessions", msg.killed)
 		}
 		return h, nil
 
@@ -2535,26 +3244,18 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return h, nil
 		}
 
-		// Re-add to instances (mirrors sessionCreatedMsg pattern)
-		h.instancesMu.Lock()
-		h.instances = append(h.instances, msg.instance)
-		h.instanceByID[msg.instance.ID] = msg.instance
-		session.UpdateClaudeSessionsWithDedup(h.instances)
-		h.instancesMu.Unlock()
+		// Re-add to instances and the group tree (mirrors sessionCreatedMsg pattern)
+		h.sessions.Add(msg.instance)
+		h.sessions.DedupClaudeSessions()
 		h.cachedStatusCounts.valid.Store(false)
 
 		// Track as launching for animation
 		h.launchingSessions[msg.instance.ID] = time.Now()
 
 		// Expand the group so the restored session is visible
-		if msg.instance.GroupPath != "" {
-			h.groupTree.ExpandGroupWithParents(msg.instance.GroupPath)
-		}
-
-		// Add to group tree and rebuild
-		h.groupTree.AddSession(msg.instance)
+		h.expandGroupOf(msg.instance)
 		h.rebuildFlatItems()
-		h.search.SetItems(h.instances)
+		h.search.SetItems(h.sessions.All())
 
 		// Move cursor to restored session
 		for i, item := range h.flatItems {
@@ -2567,7 +3268,12 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Use forceSave to bypass mtime check - restore MUST persist
 		h.forceSaveInstances()
-		h.setError(fmt.Errorf("restored '%s'", msg.instance.Title))
+		if msg.archiveID != "" {
+			if err := h.storage.DeleteArchived(msg.archiveID); err != nil {
+				uiLog.Warn("delete_archived_err", slog.String("id", msg.archiveID), slog.String("err", err.Error()))
+			}
+		}
+		h.toast(ToastSuccess, "restored '%s'", msg.instance.Title)
 		return h, h.fetchPreview(msg.instance)
 
 	case openCodeDetectionCompleteMsg:
@@ -2577,7 +3283,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.sessionID != "" {
 			uiLog.Debug("opencode_detection_complete", slog.String("instance_id", msg.instanceID), slog.String("session_id", msg.sessionID))
 			// Update the CURRENT instance (not the original pointer which may be stale)
-			if inst := h.getInstanceByID(msg.instanceID); inst != nil {
+			if inst := h.sessions.Get(msg.instanceID); inst != nil {
 				inst.OpenCodeSessionID = msg.sessionID
 				inst.OpenCodeDetectedAt = time.Now()
 				uiLog.Debug("opencode_instance_updated", slog.String("instance_id", msg.instanceID), slog.String("session_id", msg.sessionID))
@@ -2588,7 +3294,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			uiLog.Debug("opencode_detection_no_session", slog.String("instance_id", msg.instanceID))
 			// Mark detection as completed even when no session found
 			// This allows UI to show "No session found" instead of "Detecting..."
-			if inst := h.getInstanceByID(msg.instanceID); inst != nil {
+			if inst := h.sessions.Get(msg.instanceID); inst != nil {
 				inst.OpenCodeDetectedAt = time.Now()
 				uiLog.Debug("opencode_marked_complete", slog.String("instance_id", msg.instanceID))
 			}
@@ -2606,7 +3312,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			h.setError(fmt.Errorf("failed to restart session: %w", msg.err))
 		} else {
 			// Find the instance and refresh its MCP state (O(1) lookup)
-			if inst := h.getInstanceByID(msg.sessionID); inst != nil {
+			if inst := h.sessions.Get(msg.sessionID); inst != nil {
 				// Refresh the loaded MCPs to match the new config
 				inst.CaptureLoadedMCPs()
 			}
@@ -2639,6 +3345,13 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.updateInfo = msg.info
 		return h, nil
 
+	case pendingGTimeoutMsg:
+		if h.pendingG && msg.seq == h.pendingGSeq {
+			h.pendingG = false
+			h.showNewGroupDialog()
+		}
+		return h, nil
+
 	case MaintenanceCompleteMsg:
 		return h, func() tea.Msg {
 			return maintenanceCompleteMsg{result: msg.Result}
@@ -2671,6 +3384,10 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		h.maintenanceMsg = ""
 		return h, nil
 
+	case projectsDiscoveredMsg:
+		h.newDialog.SetProjects(msg.projects)
+		return h, nil
+
 	case modelsFetchedMsg:
 		if h.geminiModelDialog != nil && h.geminiModelDialog.IsVisible() {
 			h.geminiModelDialog.HandleModelsFetched(msg)
@@ -2679,13 +3396,10 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case modelSelectedMsg:
 		// Find the session and set the model
-		h.instancesMu.RLock()
-		inst := h.instanceByID[msg.instanceID]
-		h.instancesMu.RUnlock()
+		inst := h.sessions.Get(msg.instanceID)
 		if inst != nil {
 			if err := inst.SetGeminiModel(msg.model); err != nil {
-				h.err = fmt.Errorf("failed to set model: %w", err)
-				h.errTime = time.Now()
+				h.setError(fmt.Errorf("failed to set model: %w", err))
 			}
 			// Force save to persist the model change
 			h.forceSaveInstances()
@@ -2706,7 +3420,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return h, tea.Batch(listenForThemeChange(h.themeWatcher), tea.ClearScreen)
 
 	case storageChangedMsg:
-		uiLog.Debug("reload_storage_changed", slog.String("profile", h.profile), slog.Int("instances", len(h.instances)))
+		uiLog.Debug("reload_storage_changed", slog.String("profile", h.profile), slog.Int("instances", h.sessions.Len()))
 
 		// Show reload indicator and increment version to invalidate in-flight background saves
 		h.reloadMu.Lock()
@@ -2735,6 +3449,10 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Continue listening for next change
 		return h, tea.Batch(cmd, listenForReloads(h.storageWatcher))
 
+	case sessionStatusChangedMsg:
+		// Status was already updated by a worker; returning re-renders the list
+		return h, h.listenForStatusChanges()
+
 	case statusUpdateMsg:
 		// Clear attach flag - we've returned from the attached session
 		h.isAttaching.Store(false) // Atomic store for thread safety
@@ -2763,11 +3481,8 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// If session is in a collapsed group, expand it first
 			if !found {
-				h.instancesMu.RLock()
-				inst, ok := h.instanceByID[switchedID]
-				h.instancesMu.RUnlock()
-				if ok && inst.GroupPath != "" && h.groupTree != nil {
-					h.groupTree.ExpandGroupWithParents(inst.GroupPath)
+				if inst := h.sessions.Get(switchedID); inst != nil && inst.GroupPath != "" {
+					h.expandGroupOf(inst)
 					h.rebuildFlatItems()
 					for i, item := range h.flatItems {
 						if item.Type == session.ItemTypeSession && item.Session != nil && item.Session.ID == switchedID {
@@ -2795,6 +3510,24 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		return h, nil
 
+	case previewUpdateMsg:
+		// Live-follow the selected session's output between status ticks
+		var previewCmd tea.Cmd
+		selected := h.getSelectedSession()
+		previewShown := h.getLayoutMode() != LayoutModeSingle || h.scrollbackViewer.IsVisible()
+		if selected != nil && previewShown && !h.isNavigating {
+			if pm, ts := tmux.GetPipeManager(), selected.GetTmuxSession(); pm != nil && ts != nil {
+				h.previewCacheMu.Lock()
+				if h.previewFetchingID != selected.ID &&
+					followPreview(pm.IsConnected(ts.Name), pm.LastOutputTime(ts.Name), h.previewCacheTime[selected.ID]) {
+					h.previewFetchingID = selected.ID
+					previewCmd = h.fetchPreview(selected)
+				}
+				h.previewCacheMu.Unlock()
+			}
+		}
+		return h, tea.Batch(previewCmd, h.previewUpdateTick())
+
 	case previewDebounceMsg:
 		// PERFORMANCE: Debounce period elapsed - check if this fetch is still relevant
 		// If user continued navigating, pendingPreviewID will have changed
@@ -2810,9 +3543,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		// Find session and trigger actual fetch
-		h.instancesMu.RLock()
-		inst := h.instanceByID[msg.sessionID]
-		h.instancesMu.RUnlock()
+		inst := h.sessions.Get(msg.sessionID)
 
 		if inst != nil {
 			var cmds []tea.Cmd
@@ -2903,6 +3634,22 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case searchContentMsg:
+		h.search.SetContents(msg.contents)
+		return h, nil
+
+	case dashboardUsageMsg:
+		if h.dashboard.IsVisible() {
+			h.dashboard.SetUsage(msg.usage, msg.sessions)
+		}
+		return h, nil
+
+	case sessionInfoUsageMsg:
+		if h.sessionInfoDialog.SessionID() == msg.sessionID {
+			h.sessionInfoDialog.SetUsage(msg.usage, msg.ok)
+		}
+		return h, nil
+
 	case previewFetchedMsg:
 		// Async preview content received - update cache with timestamp
 		// Protect both previewFetchingID and previewCache with the same mutex
@@ -2913,6 +3660,9 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			h.previewCacheTime[msg.sessionID] = time.Now()
 		}
 		h.previewCacheMu.Unlock()
+		if msg.err == nil && h.scrollbackViewer.SessionID() == msg.sessionID {
+			h.scrollbackViewer.Refresh(msg.content)
+		}
 		return h, nil
 
 	case analyticsFetchedMsg:
@@ -2951,6 +3701,33 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case diffStatMsg:
+		h.diffStatFetching = false
+		h.diffStat = diffStatEntry(msg)
+		return h, nil
+
+	case diffLoadedMsg:
+		if msg.err != nil {
+			h.setError(msg.err)
+			return h, nil
+		}
+		if strings.TrimSpace(msg.diff) == "" {
+			h.toast(ToastInfo, "No uncommitted changes in %s", msg.path)
+			return h, nil
+		}
+		h.diffViewer.SetSize(h.width, h.height)
+		h.diffViewer.Show(msg.title, msg.path, msg.diff)
+		return h, nil
+
+	case gitStatusMsg:
+		h.gitStatusFetching = false
+		h.gitStatusMu.Lock()
+		for path, entry := range msg.statuses {
+			h.gitStatusCache[path] = entry
+		}
+		h.gitStatusMu.Unlock()
+		return h, nil
+
 	case worktreeDirtyCheckMsg:
 		// Update worktree dirty status cache
 		if msg.err == nil {
@@ -2979,16 +3756,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Success: remove session from instances and clean up
 		h.worktreeFinishDialog.Hide()
 
-		h.instancesMu.Lock()
-		for i, s := range h.instances {
-			if s.ID == msg.sessionID {
-				h.instances = append(h.instances[:i], h.instances[i+1:]...)
-				break
-			}
-		}
-		inst := h.instanceByID[msg.sessionID]
-		delete(h.instanceByID, msg.sessionID)
-		h.instancesMu.Unlock()
+		h.sessions.Remove(msg.sessionID)
 
 		// Invalidate caches
 		h.cachedStatusCounts.valid.Store(false)
@@ -3004,12 +3772,8 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		delete(h.lastLogActivity, msg.sessionID)
 		h.logActivityMu.Unlock()
 
-		// Remove from group tree and rebuild
-		if inst != nil {
-			h.groupTree.RemoveSession(inst)
-		}
 		h.rebuildFlatItems()
-		h.search.SetItems(h.instances)
+		h.search.SetItems(h.sessions.All())
 
 		// Delete from database and save
 		if err := h.storage.DeleteInstance(msg.sessionID); err != nil {
@@ -3022,14 +3786,31 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.merged {
 			successMsg += fmt.Sprintf(", merged into %s", msg.targetBranch)
 		}
-		h.setError(fmt.Errorf("%s", successMsg))
+		h.toast(ToastSuccess, "%s", successMsg)
 		return h, nil
 
 	case copyResultMsg:
 		if msg.err != nil {
 			h.setError(msg.err)
 		} else {
-			h.setError(fmt.Errorf("Copied %d lines to clipboard (%s)", msg.lineCount, msg.sessionTitle))
+			h.toast(ToastSuccess, "Copied %d lines to clipboard (%s)", msg.lineCount, msg.sessionTitle)
+		}
+		return h, nil
+
+	case transcriptLoadedMsg:
+		if msg.err != nil {
+			h.setError(msg.err)
+			return h, nil
+		}
+		h.transcriptViewer.SetSize(h.width, h.height)
+		h.transcriptViewer.Show(msg.title, msg.tool, msg.messages)
+		return h, nil
+
+	case promptSentMsg:
+		if msg.err != nil {
+			h.setError(fmt.Errorf("failed to send prompt to %s: %w", msg.sessionTitle, msg.err))
+		} else {
+			h.toast(ToastSuccess, "Sent prompt to '%s'", msg.sessionTitle)
 		}
 		return h, nil
 
@@ -3037,15 +3818,12 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			h.setError(fmt.Errorf("failed to send to %s: %v", msg.targetTitle, msg.err))
 		} else {
-			h.setError(fmt.Errorf("Sent %d lines from '%s' to '%s'", msg.lineCount, msg.sourceTitle, msg.targetTitle))
+			h.toast(ToastSuccess, "Sent %d lines from '%s' to '%s'", msg.lineCount, msg.sourceTitle, msg.targetTitle)
 		}
 		return h, nil
 
 	case tickMsg:
-		// Auto-dismiss errors after 5 seconds
-		if h.err != nil && !h.errTime.IsZero() && time.Since(h.errTime) > 5*time.Second {
-			h.clearError()
-		}
+		h.toasts.Expire(time.Now())
 
 		// PERFORMANCE: Detect when navigation has settled (300ms since last up/down)
 		// This allows background updates to resume after rapid navigation stops
@@ -3056,7 +3834,9 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// PERFORMANCE: Skip background updates during rapid navigation
 		// This prevents subprocess spawning while user is scrolling through sessions
+		var gitStatusCmd tea.Cmd
 		if !h.isNavigating {
+			gitStatusCmd = h.refreshGitStatus()
 			// PERFORMANCE: Adaptive status updates - only when user is active
 			// If user hasn't interacted for 2+ seconds, skip status updates.
 			// This prevents background polling during idle periods.
@@ -3070,13 +3850,21 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// User idle - no updates needed (cache refresh happens in background worker)
 		}
 
+		queueSent, restarted := h.promptQueueDirty.Swap(false), h.autoRestartDirty.Swap(false)
+		if queueSent || restarted {
+			h.saveInstances()
+		}
+
+		if h.dashboard.IsVisible() {
+			h.dashboard.SetInstances(h.sessions.All())
+		}
+
 		// Update animation frame for launching spinner (8 frames, cycles every tick)
 		h.animationFrame = (h.animationFrame + 1) % 8
 
-		// Periodic UI state save (every 5 ticks = ~10 seconds)
-		h.uiStateSaveTicks++
-		if h.uiStateSaveTicks >= 5 {
-			h.uiStateSaveTicks = 0
+		// Periodic UI state save (every ~10 seconds, independent of poll interval)
+		if time.Since(h.lastUIStateSave) >= 10*time.Second {
+			h.lastUIStateSave = time.Now()
 			h.saveUIState()
 		}
 
@@ -3091,6 +3879,17 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}()
 		}
 
+		// Move sessions in activity- and status-sorted groups as they change
+		if !h.isNavigating {
+			h.resortLiveGroups()
+		}
+
+		// Point the user at idle sessions the cleanup policy would remove
+		if time.Since(h.lastCleanupCheck) >= cleanupCheckInterval {
+			h.lastCleanupCheck = time.Now()
+			h.noticeIdleSessions()
+		}
+
 		// Prune stale caches and limiters every 20 seconds
 		if time.Since(h.lastCachePrune) >= 20*time.Second {
 			h.lastCachePrune = time.Now()
@@ -3098,8 +3897,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// Prune dead pipes and connect new sessions
 			if pm := tmux.GetPipeManager(); pm != nil {
-				h.instancesMu.RLock()
-				for _, inst := range h.instances {
+				for _, inst := range h.sessions.All() {
 					if ts := inst.GetTmuxSession(); ts != nil && ts.Exists() {
 						if !pm.IsConnected(ts.Name) {
 							go func(name string) {
@@ -3108,7 +3906,6 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						}
 					}
 				}
-				h.instancesMu.RUnlock()
 			}
 		}
 
@@ -3128,7 +3925,6 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		const defaultTimeout = 5 * time.Second
 
 		// Use consolidated cleanup helper for all animation maps
-		// Note: cleanupExpiredAnimations accesses instanceByID which is thread-safe on main goroutine
 		h.cleanupExpiredAnimations(h.launchingSessions, claudeTimeout, defaultTimeout)
 		h.cleanupExpiredAnimations(h.resumingSessions, claudeTimeout, defaultTimeout)
 		h.cleanupExpiredAnimations(h.mcpLoadingSessions, claudeTimeout, defaultTimeout)
@@ -3141,9 +3937,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Cache expires after 2 seconds to show live terminal updates without excessive fetching
 		const previewCacheTTL = 2 * time.Second
 		var previewCmd tea.Cmd
-		h.instancesMu.RLock()
 		selected := h.getSelectedSession()
-		h.instancesMu.RUnlock()
 		if selected != nil {
 			h.previewCacheMu.Lock()
 			cachedTime, hasCached := h.previewCacheTime[selected.ID]
@@ -3155,7 +3949,15 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			h.previewCacheMu.Unlock()
 		}
-		return h, tea.Batch(h.tick(), previewCmd)
+
+		// Diff preview tab: re-read the selected session's changes when stale
+		var diffStatCmd tea.Cmd
+		if h.previewMode == PreviewModeDiff && selected != nil && !h.isNavigating && !h.diffStatFetching &&
+			(h.diffStat.sessionID != selected.ID || time.Since(h.diffStat.checkedAt) > diffStatTTL) {
+			h.diffStatFetching = true
+			diffStatCmd = h.fetchDiffStat(selected)
+		}
+		return h, tea.Batch(h.tick(), previewCmd, gitStatusCmd, diffStatCmd)
 
 	case globalSearchDebounceMsg, globalSearchResultsMsg:
 		// Route async global search messages to the global search component
@@ -3166,6 +3968,9 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case tea.MouseMsg:
+		return h.handleMouse(msg)
+
 	case tea.KeyMsg:
 		// Track user activity for adaptive status updates
 		h.lastUserInputTime = time.Now()
@@ -3179,8 +3984,7 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Save config and close wizard
 				config := h.setupWizard.GetConfig()
 				if err := session.SaveUserConfig(config); err != nil {
-					h.err = err
-					h.errTime = time.Now()
+					h.setError(err)
 				}
 				h.setupWizard.Hide()
 				// Reload config cache
@@ -3201,8 +4005,9 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if shouldSave {
 				config := h.settingsPanel.GetConfig()
 				if err := session.SaveUserConfig(config); err != nil {
-					h.err = err
-					h.errTime = time.Now()
+					h.setError(err)
+				} else {
+					h.toast(ToastSuccess, "Settings saved")
 				}
 				_, _ = session.ReloadUserConfig()
 
@@ -3228,6 +4033,11 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return h, cmd
 		}
 
+		// An inline rename takes every key until it is confirmed or cancelled
+		if h.inlineRename.IsActive() {
+			return h.handleInlineRenameKey(msg)
+		}
+
 		// Handle overlays first
 		// Help overlay takes priority (any key closes it)
 		if h.helpOverlay.IsVisible() {
@@ -3269,6 +4079,64 @@ func (h *Home) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if h.worktreeFinishDialog.IsVisible() {
 			return h.handleWorktreeFinishDialogKey(msg)
 		}
+		if h.profilePickerDialog.IsVisible() {
+			return h.handleProfilePickerDialogKey(msg)
+		}
+		if h.archiveDialog.IsVisible() {
+			return h.handleArchiveDialogKey(msg)
+		}
+		if h.resumeDialog.IsVisible() {
+			return h.handleResumeDialogKey(msg)
+		}
+		if h.forkTreeDialog.IsVisible() {
+			return h.handleForkTreeDialogKey(msg)
+		}
+		if h.cleanupDialog.IsVisible() {
+			return h.handleCleanupDialogKey(msg)
+		}
+		if h.importDialog.IsVisible() {
+			return h.handleImportDialogKey(msg)
+		}
+		if h.recentDialog.IsVisible() {
+			return h.handleRecentDialogKey(msg)
+		}
+		if h.dashboard.IsVisible() {
+			h.dashboard.Update(msg)
+			return h, nil
+		}
+		if h.sessionInfoDialog.IsVisible() {
+			h.sessionInfoDialog.Update(msg)
+			return h, nil
+		}
+		if h.toastLogDialog.IsVisible() {
+			h.toastLogDialog.Update(msg)
+			return h, nil
+		}
+		if h.errorLogDialog.IsVisible() {
+			h.errorLogDialog.Update(msg)
+			return h, nil
+		}
+		if h.transcriptViewer.IsVisible() {
+			h.transcriptViewer.Update(msg)
+			return h, nil
+		}
+		if h.diffViewer.IsVisible() {
+			h.diffViewer.Update(msg)
+			return h, nil
+		}
+		if h.scrollbackViewer.IsVisible() {
+			h.scrollbackViewer.Update(msg)
+			return h, nil
+		}
+		if h.notesDialog.IsVisible() {
+			return h.handleNotesDialogKey(msg)
+		}
+		if h.tagDialog.IsVisible() {
+			return h.handleTagDialogKey(msg)
+		}
+		if h.promptDialog.IsVisible() {
+			return h.handlePromptDialogKey(msg)
+		}
 
 		// Main view keys
 		return h.handleMainKey(msg)
@@ -3284,9 +4152,7 @@ func (h *Home) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		selected := h.search.Selected()
 		if selected != nil {
 			// Ensure the session's group AND all parent groups are expanded so it's visible
-			if selected.GroupPath != "" {
-				h.groupTree.ExpandGroupWithParents(selected.GroupPath)
-			}
+			h.expandGroupOf(selected)
 			h.rebuildFlatItems()
 
 			// Find the session in flatItems (not instances) and set cursor
@@ -3313,6 +4179,9 @@ func (h *Home) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		h.globalSearch.SetSize(h.width, h.height)
 		h.globalSearch.Show()
 	}
+	if h.search.WantsContent() {
+		cmd = tea.Batch(cmd, h.captureSearchContent())
+	}
 
 	return h, cmd
 }
@@ -3338,7 +4207,7 @@ func (h *Home) handleGlobalSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Check if user wants to switch to local search
 	if h.globalSearch.WantsSwitchToLocal() {
-		h.search.SetItems(h.instances)
+		h.search.SetItems(h.sessions.All())
 		h.search.Show()
 	}
 
@@ -3348,16 +4217,13 @@ func (h *Home) handleGlobalSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleGlobalSearchSelection handles selection from global search
 func (h *Home) handleGlobalSearchSelection(result *GlobalSearchResult) tea.Cmd {
 	// Check if session already exists in Agent Deck
-	h.instancesMu.RLock()
-	for _, inst := range h.instances {
+	for _, inst := range h.sessions.All() {
 		if inst.ClaudeSessionID == result.SessionID {
-			h.instancesMu.RUnlock()
 			// Jump to existing session
 			h.jumpToSession(inst)
 			return nil
 		}
 	}
-	h.instancesMu.RUnlock()
 
 	// Create new session with this Claude session ID
 	return h.createSessionFromGlobalSearch(result)
@@ -3366,9 +4232,7 @@ func (h *Home) handleGlobalSearchSelection(result *GlobalSearchResult) tea.Cmd {
 // jumpToSession jumps the cursor to the specified session
 func (h *Home) jumpToSession(inst *session.Instance) {
 	// Ensure the session's group is expanded
-	if inst.GroupPath != "" {
-		h.groupTree.ExpandGroupWithParents(inst.GroupPath)
-	}
+	h.expandGroupOf(inst)
 	h.rebuildFlatItems()
 
 	// Find and select the session
@@ -3383,23 +4247,28 @@ func (h *Home) jumpToSession(inst *session.Instance) {
 
 // createSessionFromGlobalSearch creates a new Agent Deck session from global search result
 func (h *Home) createSessionFromGlobalSearch(result *GlobalSearchResult) tea.Cmd {
-	return func() tea.Msg {
-		// Derive title from CWD or session ID
-		title := "Claude Session"
-		projectPath := result.CWD
-		if result.CWD != "" {
-			parts := strings.Split(result.CWD, "/")
-			if len(parts) > 0 {
-				title = parts[len(parts)-1]
-			}
-		}
-		if projectPath == "" {
-			projectPath = "."
+	// Derive title from CWD or session ID
+	title := "Claude Session"
+	projectPath := result.CWD
+	if result.CWD != "" {
+		parts := strings.Split(result.CWD, "/")
+		if len(parts) > 0 {
+			title = parts[len(parts)-1]
 		}
+	}
+	if projectPath == "" {
+		projectPath = "."
+	}
+	return h.resumeClaudeSession(title, projectPath, h.getCurrentGroupPath(), result.SessionID)
+}
 
+// resumeClaudeSession creates and starts a session running
+// `claude --resume <claudeSessionID>` in projectPath.
+func (h *Home) resumeClaudeSession(title, projectPath, groupPath, claudeSessionID string) tea.Cmd {
+	return func() tea.Msg {
 		// Create instance
-		inst := session.NewInstanceWithGroupAndTool(title, projectPath, h.getCurrentGroupPath(), "claude")
-		inst.ClaudeSessionID = result.SessionID
+		inst := session.NewInstanceWithGroupAndTool(title, projectPath, groupPath, "claude")
+		inst.ClaudeSessionID = claudeSessionID
 
 		// Build resume command with config dir and permission flags
 		userConfig, _ := session.LoadUserConfig()
@@ -3415,7 +4284,7 @@ func (h *Home) createSessionFromGlobalSearch(result *GlobalSearchResult) tea.Cmd
 			cmdBuilder.WriteString(fmt.Sprintf("CLAUDE_CONFIG_DIR=%s ", configDir))
 		}
 		cmdBuilder.WriteString("claude --resume ")
-		cmdBuilder.WriteString(result.SessionID)
+		cmdBuilder.WriteString(claudeSessionID)
 		if opts.SkipPermissions {
 			cmdBuilder.WriteString(" --dangerously-skip-permissions")
 		} else if opts.AllowSkipPermissions {
@@ -3451,8 +4320,19 @@ func (h *Home) getCurrentGroupPath() string {
 
 // handleNewDialogKey handles keys when new dialog is visible
 func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The project picker handles its own keys; Enter creates a session from
+	// the picked project
+	if h.newDialog.IsPickingProject() && msg.String() != "enter" {
+		var cmd tea.Cmd
+		h.newDialog, cmd = h.newDialog.Update(msg)
+		return h, cmd
+	}
+
 	switch msg.String() {
 	case "enter":
+		if h.newDialog.IsPickingProject() && !h.newDialog.ApplyPickedProject() {
+			return h, nil
+		}
 		// Validate before creating session
 		if validationErr := h.newDialog.Validate(); validationErr != "" {
 			h.newDialog.SetError(validationErr)
@@ -3462,6 +4342,22 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Get values including worktree settings
 		name, path, command, branchName, worktreeEnabled := h.newDialog.GetValuesWithWorktree()
 		groupPath := h.newDialog.GetSelectedGroup()
+
+		// A mistyped path would otherwise start the session in the home
+		// directory. Worktree mode checks for a git repository below.
+		if !worktreeEnabled {
+			create, pathErr := h.newDialog.CheckPath(path)
+			if pathErr != "" {
+				h.newDialog.SetError(pathErr)
+				return h, nil
+			}
+			if create {
+				if err := os.MkdirAll(path, 0o755); err != nil {
+					h.newDialog.SetError(fmt.Sprintf("Failed to create directory: %v", err))
+					return h, nil
+				}
+			}
+		}
 		claudeOpts := h.newDialog.GetClaudeOptions() // Get Claude options if applicable
 
 		// Handle worktree creation if enabled
@@ -3496,7 +4392,7 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 
 			// Create worktree
-			if err := git.CreateWorktree(repoRoot, worktreePath, branchName); err != nil {
+			if err := git.CreateWorktreeFrom(repoRoot, worktreePath, branchName, h.newDialog.GetWorktreeBase()); err != nil {
 				h.newDialog.SetError(fmt.Sprintf("Failed to create worktree: %v", err))
 				return h, nil
 			}
@@ -3542,8 +4438,17 @@ func (h *Home) handleNewDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 // handleMainKey handles keys in main view
+// Keys are first translated through the keymap, so cases use default keys.
 func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
+	// A g is waiting for the second g of gg. Any other key means it was a
+	// lone g: open the dialog it stands for and let the key go there.
+	if h.pendingG && h.keymap.Resolve(msg.String()) != "g" {
+		h.pendingG = false
+		h.showNewGroupDialog()
+		return h.handleGroupDialogKey(msg)
+	}
+
+	switch h.keymap.Resolve(msg.String()) {
 	case "q", "ctrl+c":
 		return h.tryQuit()
 
@@ -3667,7 +4572,20 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
-	case "G": // Open global search (fall back to local search if index not available)
+	case "G":
+		// Vi-style G to jump to the bottom
+		if len(h.flatItems) > 0 {
+			h.cursor = len(h.flatItems) - 1
+			h.syncViewport()
+			h.lastNavigationTime = time.Now()
+			h.isNavigating = true
+			if selected := h.getSelectedSession(); selected != nil {
+				return h, h.fetchPreviewDebounced(selected.ID)
+			}
+		}
+		return h, nil
+
+	case "alt+g": // Open global search (fall back to local search if index not available)
 		if h.globalSearchIndex != nil {
 			h.globalSearch.SetSize(h.width, h.height)
 			h.globalSearch.Show()
@@ -3682,7 +4600,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				// Block attachment during animations (must match renderPreviewPane display logic)
 				if h.hasActiveAnimation(item.Session.ID) {
-					h.setError(fmt.Errorf("session is starting, please wait..."))
+					h.toast(ToastWarning, "session is starting, please wait...")
 					return h, nil
 				}
 				if item.Session.Exists() {
@@ -3692,7 +4610,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			} else if item.Type == session.ItemTypeGroup {
 				// Toggle group on enter
 				groupPath := item.Path
-				h.groupTree.ToggleGroup(groupPath)
+				h.sessions.Update(func(tree *session.GroupTree) { tree.ToggleGroup(groupPath) })
 				h.rebuildFlatItems()
 				for i, fi := range h.flatItems {
 					if fi.Type == session.ItemTypeGroup && fi.Path == groupPath {
@@ -3711,7 +4629,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeGroup {
 				groupPath := item.Path
-				h.groupTree.ToggleGroup(groupPath)
+				h.sessions.Update(func(tree *session.GroupTree) { tree.ToggleGroup(groupPath) })
 				h.rebuildFlatItems()
 				for i, fi := range h.flatItems {
 					if fi.Type == session.ItemTypeGroup && fi.Path == groupPath {
@@ -3731,7 +4649,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			collapsed := false
 			if item.Type == session.ItemTypeGroup {
 				groupPath := item.Path
-				h.groupTree.CollapseGroup(groupPath)
+				h.sessions.Update(func(tree *session.GroupTree) { tree.CollapseGroup(groupPath) })
 				h.rebuildFlatItems()
 				for i, fi := range h.flatItems {
 					if fi.Type == session.ItemTypeGroup && fi.Path == groupPath {
@@ -3742,7 +4660,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				collapsed = true
 			} else if item.Type == session.ItemTypeSession {
 				// Move cursor to parent group
-				h.groupTree.CollapseGroup(item.Path)
+				h.sessions.Update(func(tree *session.GroupTree) { tree.CollapseGroup(item.Path) })
 				h.rebuildFlatItems()
 				// Find the group in flatItems
 				for i, fi := range h.flatItems {
@@ -3764,9 +4682,12 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeGroup {
-				h.groupTree.MoveGroupUp(item.Path)
+				h.sessions.Update(func(tree *session.GroupTree) { tree.MoveGroupUp(item.Path) })
 			} else if item.Type == session.ItemTypeSession {
-				h.groupTree.MoveSessionUp(item.Session)
+				if h.sortedReorderBlocked(item.Path) {
+					return h, nil
+				}
+				h.sessions.Regroup(func(tree *session.GroupTree) { tree.MoveSessionUp(item.Session) })
 			}
 			h.rebuildFlatItems()
 			if h.cursor > 0 {
@@ -3781,9 +4702,12 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeGroup {
-				h.groupTree.MoveGroupDown(item.Path)
+				h.sessions.Update(func(tree *session.GroupTree) { tree.MoveGroupDown(item.Path) })
 			} else if item.Type == session.ItemTypeSession {
-				h.groupTree.MoveSessionDown(item.Session)
+				if h.sortedReorderBlocked(item.Path) {
+					return h, nil
+				}
+				h.sessions.Regroup(func(tree *session.GroupTree) { tree.MoveSessionDown(item.Session) })
 			}
 			h.rebuildFlatItems()
 			if h.cursor < len(h.flatItems)-1 {
@@ -3815,7 +4739,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				// Block fork during animations to prevent concurrent operations
 				if h.hasActiveAnimation(item.Session.ID) {
-					h.setError(fmt.Errorf("session is starting, please wait..."))
+					h.toast(ToastWarning, "session is starting, please wait...")
 					return h, nil
 				}
 				if item.Session.CanFork() {
@@ -3833,7 +4757,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				// Block fork during animations to prevent concurrent operations
 				if h.hasActiveAnimation(item.Session.ID) {
-					h.setError(fmt.Errorf("session is starting, please wait..."))
+					h.toast(ToastWarning, "session is starting, please wait...")
 					return h, nil
 				}
 				if item.Session.CanFork() {
@@ -3862,7 +4786,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeSession {
-				h.groupDialog.ShowMove(h.groupTree.GetGroupNames())
+				h.groupDialog.ShowMove(h.sessions.Tree().GetGroupNames())
 			}
 		}
 		return h, nil
@@ -3874,7 +4798,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				inst := item.Session
 				if !inst.IsWorktree() {
-					h.setError(fmt.Errorf("session '%s' is not a worktree", inst.Title))
+					h.toast(ToastWarning, "session '%s' is not a worktree", inst.Title)
 					return h, nil
 				}
 				// Determine default target branch
@@ -3896,9 +4820,9 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "g":
-		// Vi-style gg to jump to top (#38) - check for double-tap first
-		if time.Since(h.lastGTime) < 500*time.Millisecond {
-			// Double g - jump to top
+		// Vi-style gg to jump to top (#38)
+		if h.pendingG {
+			h.pendingG = false
 			if len(h.flatItems) > 0 {
 				h.cursor = 0
 				h.syncViewport()
@@ -3910,43 +4834,22 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 			return h, nil
 		}
-		// Record time for potential gg detection
-		h.lastGTime = time.Now()
-
-		// Create new group with context-aware Tab toggle (Issue #111):
-		// - Group header: defaults to subgroup, Tab toggles to root
-		// - Grouped session: defaults to root, Tab toggles to subgroup
-		// - Ungrouped item: root only, no toggle
-		if h.cursor < len(h.flatItems) {
-			item := h.flatItems[h.cursor]
-			if item.Type == session.ItemTypeGroup {
-				// On group header: default to subgroup mode
-				h.groupDialog.ShowCreateWithContext(item.Group.Path, item.Group.Name)
-			} else if item.Type == session.ItemTypeSession && item.Session != nil && item.Session.GroupPath != "" {
-				// On grouped session: default to root, Tab toggles to subgroup
-				gPath := item.Session.GroupPath
-				gName := gPath
-				if idx := strings.LastIndex(gPath, "/"); idx >= 0 {
-					gName = gPath[idx+1:]
-				}
-				h.groupDialog.ShowCreateWithContextDefaultRoot(gPath, gName)
-			} else {
-				// Ungrouped: root only, no toggle
-				h.groupDialog.ShowCreateWithContext("", "")
-			}
-		} else {
-			h.groupDialog.ShowCreateWithContext("", "")
-		}
-		return h, nil
+		// Hold the g until we know it isn't the start of gg
+		h.pendingG = true
+		h.pendingGSeq++
+		seq := h.pendingGSeq
+		return h, tea.Tick(ggWindow, func(time.Time) tea.Msg {
+			return pendingGTimeoutMsg{seq: seq}
+		})
 
 	case "r":
 		// Rename group or session
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeGroup {
-				h.groupDialog.ShowRename(item.Path, item.Group.Name)
+				h.inlineRename.StartGroup(item.Path, item.Group.Name)
 			} else if item.Type == session.ItemTypeSession && item.Session != nil {
-				h.groupDialog.ShowRenameSession(item.Session.ID, item.Session.Title)
+				h.inlineRename.StartSession(item.Session.ID, item.Session.Title)
 			}
 		}
 		return h, nil
@@ -3963,7 +4866,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "?":
 		h.helpOverlay.SetSize(h.width, h.height)
-		h.helpOverlay.Show()
+		h.helpOverlay.Show(h.keymap)
 		return h, nil
 
 	case "S":
@@ -3979,7 +4882,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			lastAccessedAt time.Time
 		}
 		pathMap := make(map[string]*pathInfo)
-		for _, inst := range h.instances {
+		for _, inst := range h.sessions.All() {
 			if inst.ProjectPath == "" {
 				continue
 			}
@@ -4036,7 +4939,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			} else if item.Type == session.ItemTypeSession {
 				// Use the session's group
 				groupPath = item.Path
-				if group, exists := h.groupTree.Groups[groupPath]; exists {
+				if group, exists := h.sessions.Tree().Groups[groupPath]; exists {
 					groupName = group.Name
 				}
 			}
@@ -4054,7 +4957,15 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if h.cursor < len(h.flatItems) {
 			item := h.flatItems[h.cursor]
 			if item.Type == session.ItemTypeSession && item.Session != nil {
-				h.confirmDialog.ShowDeleteSession(item.Session.ID, item.Session.Title)
+				if cfg, _ := session.LoadUserConfig(); cfg != nil && !cfg.GetConfirmDelete() {
+					// No confirmation: keep any worktree, undo restores the rest
+					return h, h.deleteSession(item.Session, false)
+				}
+				var worktreePath string
+				if item.Session.IsWorktree() {
+					worktreePath = item.Session.WorktreePath
+				}
+				h.confirmDialog.ShowDeleteSession(item.Session.ID, item.Session.Title, worktreePath)
 			} else if item.Type == session.ItemTypeGroup && item.Path != session.DefaultGroupPath {
 				h.confirmDialog.ShowDeleteGroup(item.Path, item.Group.Name)
 			}
@@ -4062,7 +4973,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return h, nil
 
 	case "i":
-		return h, h.importSessions
+		return h, h.discoverTmuxSessions
 
 	case "u":
 		// Mark session as unread (idle → waiting)
@@ -4085,9 +4996,66 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case "b":
+		// Toggle notifications for the selected session or group
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			switch {
+			case item.Type == session.ItemTypeSession && item.Session != nil:
+				item.Session.SetNotifyMutedThreadSafe(!item.Session.GetNotifyMutedThreadSafe())
+				h.saveInstances()
+			case item.Type == session.ItemTypeGroup && item.Group != nil:
+				item.Group.Muted = !item.Group.Muted
+				h.saveInstances()
+			}
+		}
+		return h, nil
+
+	case "B":
+		// Toggle do-not-disturb: no notifications from any session
+		h.doNotDisturb.Store(!h.doNotDisturb.Load())
+		h.saveUIState()
+		return h, nil
+
+	case "ctrl+s":
+		h.cycleGroupSort()
+		return h, nil
+
 	case "v":
-		// Toggle preview mode (cycle: both → output-only → analytics-only → both)
-		h.previewMode = (h.previewMode + 1) % 3
+		// Toggle preview mode (cycle: both → output-only → analytics-only → diff → both)
+		h.previewMode = (h.previewMode + 1) % 4
+		return h, nil
+
+	case "<":
+		h.resizeList(-listPercentStep)
+		return h, nil
+
+	case ">":
+		h.resizeList(listPercentStep)
+		return h, nil
+
+	case "O", "shift+o":
+		// Toggle plain preview output; refetch so the capture matches
+		h.previewPlain = !h.previewPlain
+		h.previewCacheMu.Lock()
+		clear(h.previewCacheTime)
+		h.previewCacheMu.Unlock()
+		h.saveUIState()
+		return h, nil
+
+	case "=":
+		// Cycle how much each session row shows
+		h.listDensity = h.listDensity.Next()
+		h.syncViewport()
+		h.toast(ToastInfo, "List density: %s", h.listDensity.Label())
+		h.saveUIState()
+		return h, nil
+
+	case "|":
+		// Hide or show the preview pane; the list takes the full width
+		h.previewHidden = !h.previewHidden
+		h.syncViewport()
+		h.saveUIState()
 		return h, nil
 
 	case "y":
@@ -4112,7 +5080,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				inst.GeminiYoloMode = &newYolo
 				h.saveInstances()
 				// If session is running, it needs restart to apply
-				if inst.GetStatusThreadSafe() == session.StatusRunning || inst.GetStatusThreadSafe() == session.StatusWaiting {
+				if status := inst.GetStatusThreadSafe(); status == session.StatusRunning || status == session.StatusWaiting || status == session.StatusNeedsInput {
 					h.resumingSessions[inst.ID] = time.Now()
 					return h, h.restartSession(inst)
 				}
@@ -4127,7 +5095,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				// Block restart during animations to prevent concurrent restarts
 				if h.hasActiveAnimation(item.Session.ID) {
-					h.setError(fmt.Errorf("session is starting, please wait..."))
+					h.toast(ToastWarning, "session is starting, please wait...")
 					return h, nil
 				}
 				if item.Session.CanRestart() {
@@ -4149,6 +5117,39 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case "D":
+		// Read the full git diff of the session's working directory
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.loadDiff(item.Session)
+			}
+		}
+		return h, nil
+
+	case "z":
+		// Read the session's scrollback full screen; it keeps refreshing
+		// with the preview while open
+		if inst := h.getSelectedSession(); inst != nil {
+			h.previewCacheMu.Lock()
+			content := h.previewCache[inst.ID]
+			h.previewCacheTime[inst.ID] = time.Time{} // Refetch on the next tick
+			h.previewCacheMu.Unlock()
+			h.scrollbackViewer.SetSize(h.width, h.height)
+			h.scrollbackViewer.Show(inst.ID, inst.Title, content)
+		}
+		return h, nil
+
+	case "V":
+		// Read the conversation from the agent's transcript file
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				return h, h.loadTranscript(item.Session)
+			}
+		}
+		return h, nil
+
 	case "x":
 		// Send session output to another session
 		if h.cursor < len(h.flatItems) {
@@ -4156,11 +5157,11 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if item.Type == session.ItemTypeSession && item.Session != nil {
 				others := h.getOtherActiveSessions(item.Session.ID)
 				if len(others) == 0 {
-					h.setError(fmt.Errorf("no other sessions to send to"))
+					h.toast(ToastWarning, "no other sessions to send to")
 					return h, nil
 				}
 				h.sessionPickerDialog.SetSize(h.width, h.height)
-				h.sessionPickerDialog.Show(item.Session, h.instances)
+				h.sessionPickerDialog.Show(item.Session, h.sessions.All())
 			}
 		}
 		return h, nil
@@ -4176,7 +5177,7 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "ctrl+z":
 		// Undo last session delete (Chrome-style: restores in reverse order)
 		if len(h.undoStack) == 0 {
-			h.setError(fmt.Errorf("nothing to undo"))
+			h.toast(ToastInfo, "nothing to undo")
 			return h, nil
 		}
 		entry := h.undoStack[len(h.undoStack)-1]
@@ -4203,56 +5204,204 @@ func (h *Home) handleMainKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 		return h, cmd
 
+	case "e":
+		// Edit notes for the selected session
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				h.notesDialog.SetSize(h.width, h.height)
+				h.notesDialog.Show(item.Session.ID, item.Session.Title, item.Session.Notes)
+			}
+		}
+		return h, nil
+
+	case "p":
+		// Send or queue a one-line prompt for the selected session without attaching
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				// A busy agent gets the prompt queued by default
+				queued := item.Session.QueuedPrompts()
+				busy := item.Session.GetStatusThreadSafe() == session.StatusRunning
+				h.promptDialog.SetSize(h.width, h.height)
+				h.promptDialog.Show(item.Session.ID, item.Session.Title, queued, busy || len(queued) > 0)
+				if cfg, _ := session.LoadUserConfig(); cfg != nil {
+					h.promptDialog.SetSnippets(session.ExpandSnippets(cfg.SnippetList(), item.Session))
+				}
+			}
+		}
+		return h, nil
+
+	case "t":
+		// Edit tags for the selected session
+		if h.cursor < len(h.flatItems) {
+			item := h.flatItems[h.cursor]
+			if item.Type == session.ItemTypeSession && item.Session != nil {
+				h.tagDialog.SetSize(h.width, h.height)
+				h.tagDialog.ShowEdit(item.Session.ID, item.Session.Title, item.Session.Tags)
+			}
+		}
+		return h, nil
+
+	case "T":
+		// Filter the list by tag
+		counts := session.CollectTags(h.sessions.All())
+		h.tagDialog.SetSize(h.width, h.height)
+		h.tagDialog.ShowFilter(counts, h.tagFilter)
+		return h, nil
+
+	case "U":
+		// Resume a past Claude conversation for the selected project
+		projectPath, groupPath := h.selectedProjectPath()
+		if projectPath == "" {
+			h.toast(ToastWarning, "select a session (or a group with a default path) to resume from")
+			return h, nil
+		}
+		convs, err := session.ListClaudeConversations(projectPath)
+		if err != nil {
+			h.setError(err)
+			return h, nil
+		}
+		openIn := make(map[string]string)
+		for _, inst := range h.sessions.All() {
+			if inst.Tool == "claude" && inst.ClaudeSessionID != "" {
+				openIn[inst.ClaudeSessionID] = inst.Title
+			}
+		}
+		h.resumeDialog.SetSize(h.width, h.height)
+		h.resumeDialog.Show(projectPath, groupPath, convs, openIn)
+		return h, nil
+
+	case "L":
+		// Show which sessions were forked from which
+		currentID := ""
+		if h.cursor >= 0 && h.cursor < len(h.flatItems) && h.flatItems[h.cursor].Session != nil {
+			currentID = h.flatItems[h.cursor].Session.ID
+		}
+		roots := session.BuildForkTree(h.sessions.All())
+		h.forkTreeDialog.SetSize(h.width, h.height)
+		h.forkTreeDialog.Show(roots, currentID)
+		return h, nil
+
+	case "C":
+		// Review idle sessions for cleanup
+		settings := session.GetCleanupSettings()
+		if settings.IdleAfter() <= 0 {
+			h.toast(ToastWarning, "no idle cleanup policy (set idle_hours under [cleanup] in config.toml)")
+			return h, nil
+		}
+		idle := session.IdleCleanupCandidates(h.sessions.All(), settings.IdleAfter(), time.Now())
+		h.cleanupDialog.SetSize(h.width, h.height)
+		h.cleanupDialog.Show(idle, settings.GetAction(), settings.IdleAfter())
+		return h, nil
+
+	case "ctrl+o":
+		// Jump list of recently attached sessions
+		recent := session.RecentlyAttached(h.sessions.All(), recentSessionsMax)
+		h.recentDialog.Show(recent)
+		return h, nil
+
+	case "w":
+		// Overview of the whole deck
+		h.dashboard.Show(h.sessions.All())
+		return h, h.loadDashboardUsage()
+
+	case "I":
+		// Full details of the selected session
+		inst := h.getSelectedSession()
+		if inst == nil {
+			return h, nil
+		}
+		h.sessionInfoDialog.Show(inst)
+		return h, func() tea.Msg {
+			usage, ok := inst.ReadUsage()
+			return sessionInfoUsageMsg{sessionID: inst.ID, usage: usage, ok: ok}
+		}
+
+	case "H":
+		// Notifications that have already gone from the screen
+		h.toasts.Dismiss()
+		h.toastLogDialog.Show(h.toasts.Log())
+		return h, nil
+
+	case "E":
+		// Errors logged so far, to read and dismiss
+		h.errorLogDialog.Show(h.errorLog)
+		return h, nil
+
+	case "A":
+		// View archived sessions
+		archived, err := h.storage.ListArchived()
+		if err != nil {
+			h.setError(err)
+			return h, nil
+		}
+		h.archiveDialog.SetSize(h.width, h.height)
+		h.archiveDialog.Show(archived)
+		return h, nil
+
+	case "P":
+		// Switch to another profile
+		profiles, err := session.ListProfiles()
+		if err != nil {
+			h.setError(fmt.Errorf("failed to list profiles: %w", err))
+			return h, nil
+		}
+		if !slices.Contains(profiles, h.profile) {
+			profiles = append([]string{h.profile}, profiles...)
+		}
+		if len(profiles) < 2 {
+			h.toast(ToastWarning, "no other profiles (create one with: agent-deck profile create <name>)")
+			return h, nil
+		}
+		h.profilePickerDialog.SetSize(h.width, h.height)
+		h.profilePickerDialog.Show(profiles, h.profile)
+		return h, nil
+
 	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 		// Quick jump to Nth root group (1-indexed)
 		targetNum := int(msg.String()[0] - '0') // Convert "1" -> 1, "2" -> 2, etc.
+		if h.numberSessions {
+			return h, h.jumpToNumberedSession(targetNum)
+		}
 		h.jumpToRootGroup(targetNum)
 		return h, nil
 
 	case "0":
-		// Clear status filter (show all)
+		// Clear status and tag filters (show all)
 		h.statusFilter = ""
+		h.tagFilter = ""
 		h.rebuildFlatItems()
 		return h, nil
 
 	case "!", "shift+1":
 		// Filter to running sessions only
-		if h.statusFilter == session.StatusRunning {
-			h.statusFilter = "" // Toggle off
-		} else {
-			h.statusFilter = session.StatusRunning
-		}
-		h.rebuildFlatItems()
+		h.toggleStatusFilter(session.StatusRunning)
+		return h, nil
+
+	case "%", "shift+5":
+		// Filter to sessions blocked on an approval prompt
+		h.toggleStatusFilter(session.StatusNeedsInput)
 		return h, nil
 
 	case "@", "shift+2":
 		// Filter to waiting sessions only
-		if h.statusFilter == session.StatusWaiting {
-			h.statusFilter = "" // Toggle off
-		} else {
-			h.statusFilter = session.StatusWaiting
-		}
-		h.rebuildFlatItems()
+		h.toggleStatusFilter(session.StatusWaiting)
 		return h, nil
 
 	case "#", "shift+3":
 		// Filter to idle sessions only
-		if h.statusFilter == session.StatusIdle {
-			h.statusFilter = "" // Toggle off
-		} else {
-			h.statusFilter = session.StatusIdle
-		}
-		h.rebuildFlatItems()
+		h.toggleStatusFilter(session.StatusIdle)
 		return h, nil
 
 	case "$", "shift+4":
 		// Filter to error sessions only
-		if h.statusFilter == session.StatusError {
-			h.statusFilter = "" // Toggle off
-		} else {
-			h.statusFilter = session.StatusError
-		}
-		h.rebuildFlatItems()
+		h.toggleStatusFilter(session.StatusError)
+		return h, nil
+
+	case "^", "shift+6":
+		// Filter to sessions that need attention (waiting, needs input, error)
+		h.toggleStatusFilter(statusFilterAttention)
 		return h, nil
 	}
 
@@ -4295,6 +5444,47 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		return h, nil
 
+	case ConfirmSessionNotRunning:
+		inst := h.sessions.Get(h.confirmDialog.GetTargetID())
+		switch msg.String() {
+		case "r", "R", "enter":
+			h.confirmDialog.Hide()
+			if inst == nil || h.hasActiveAnimation(inst.ID) {
+				return h, nil
+			}
+			h.resumingSessions[inst.ID] = time.Now()
+			return h, h.restartSession(inst)
+		case "d", "D":
+			h.confirmDialog.Hide()
+			if inst == nil {
+				return h, nil
+			}
+			// Nothing runs any more; keep its worktree, which may hold work
+			return h, h.deleteSession(inst, false)
+		case "esc", "n", "N":
+			h.confirmDialog.Hide()
+		}
+		return h, nil
+
+	case ConfirmResurrectSessions:
+		switch msg.String() {
+		case "y", "Y":
+			ids := h.confirmDialog.GetPendingSessionIDs()
+			h.confirmDialog.Hide()
+			var cmds []tea.Cmd
+			for _, id := range ids {
+				if inst := h.sessions.Get(id); inst != nil && !h.hasActiveAnimation(id) {
+					h.resumingSessions[id] = time.Now()
+					cmds = append(cmds, h.restartSession(inst))
+				}
+			}
+			return h, tea.Batch(cmds...)
+		case "n", "N", "esc":
+			h.confirmDialog.Hide()
+			return h, nil
+		}
+		return h, nil
+
 	case ConfirmInstallHooks:
 		switch msg.String() {
 		case "y", "Y":
@@ -4338,22 +5528,39 @@ func (h *Home) handleConfirmDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			switch h.confirmDialog.GetConfirmType() {
 			case ConfirmDeleteSession:
 				sessionID := h.confirmDialog.GetTargetID()
-				if inst := h.getInstanceByID(sessionID); inst != nil {
+				if inst := h.sessions.Get(sessionID); inst != nil {
 					h.confirmDialog.Hide()
-					return h, h.deleteSession(inst)
+					return h, h.deleteSession(inst, true)
 				}
 			case ConfirmDeleteGroup:
 				groupPath := h.confirmDialog.GetTargetID()
-				h.groupTree.DeleteGroup(groupPath)
-				h.instancesMu.Lock()
-				h.instances = h.groupTree.GetAllInstances()
-				h.instancesMu.Unlock()
+				h.sessions.Regroup(func(tree *session.GroupTree) { tree.DeleteGroup(groupPath) })
 				h.rebuildFlatItems()
 				h.saveInstances()
 			}
 			h.confirmDialog.Hide()
 			return h, nil
 
+		case "k", "K":
+			// Delete a worktree session but leave its worktree on disk
+			if h.confirmDialog.GetConfirmType() == ConfirmDeleteSession {
+				if inst := h.sessions.Get(h.confirmDialog.GetTargetID()); inst != nil && inst.IsWorktree() {
+					h.confirmDialog.Hide()
+					return h, h.deleteSession(inst, false)
+				}
+			}
+			return h, nil
+
+		case "a", "A":
+			// Archive instead of delete (sessions only)
+			if h.confirmDialog.GetConfirmType() == ConfirmDeleteSession {
+				if inst := h.sessions.Get(h.confirmDialog.GetTargetID()); inst != nil {
+					h.confirmDialog.Hide()
+					return h, h.archiveSession(inst)
+				}
+			}
+			return h, nil
+
 		case "n", "N", "esc":
 			// User cancelled
 			h.confirmDialog.Hide()
@@ -4393,8 +5600,9 @@ func (h *Home) performQuit(shutdownPool bool) tea.Cmd {
 // This is called via quitMsg after the splash screen has had time to render
 func (h *Home) performFinalShutdown(shutdownPool bool) tea.Cmd {
 	return func() tea.Msg {
-		// Signal background worker to stop
+		// Signal background workers to stop
 		h.cancel()
+		h.monitor.Stop()
 		// Wait for background worker to finish (prevents race on shutdown)
 		if h.statusWorkerDone != nil {
 			select {
@@ -4424,6 +5632,9 @@ func (h *Home) performFinalShutdown(shutdownPool bool) tea.Cmd {
 		if h.hookWatcher != nil {
 			h.hookWatcher.Stop()
 		}
+		if h.claudeWatcher != nil {
+			h.claudeWatcher.Stop()
+		}
 		// Close storage watcher
 		if h.storageWatcher != nil {
 			h.storageWatcher.Close()
@@ -4480,7 +5691,7 @@ func (h *Home) handleMCPDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			mcpUILog.Debug("dialog_looking_for_session", slog.String("session_id", sessionID))
 
 			// O(1) lookup - no lock needed as Update() runs on main goroutine
-			targetInst := h.getInstanceByID(sessionID)
+			targetInst := h.sessions.Get(sessionID)
 			if targetInst != nil {
 				mcpUILog.Debug("dialog_session_found", slog.String("session_id", targetInst.ID), slog.String("title", targetInst.Title))
 			}
@@ -4525,7 +5736,7 @@ func (h *Home) handleSkillDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 
 			sessionID := h.skillDialog.GetSessionID()
-			targetInst := h.getInstanceByID(sessionID)
+			targetInst := h.sessions.Get(sessionID)
 			if targetInst != nil && targetInst.Tool == "claude" {
 				h.skillDialog.Hide()
 				return h, h.restartSession(targetInst)
@@ -4544,6 +5755,34 @@ func (h *Home) handleSkillDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	}
 }
 
+// showNewGroupDialog opens the new group dialog for a lone g, with a
+// context-aware Tab toggle (Issue #111):
+// - Group header: defaults to subgroup, Tab toggles to root
+// - Grouped session: defaults to root, Tab toggles to subgroup
+// - Ungrouped item: root only, no toggle
+func (h *Home) showNewGroupDialog() {
+	if h.cursor < len(h.flatItems) {
+		item := h.flatItems[h.cursor]
+		if item.Type == session.ItemTypeGroup {
+			// On group header: default to subgroup mode
+			h.groupDialog.ShowCreateWithContext(item.Group.Path, item.Group.Name)
+		} else if item.Type == session.ItemTypeSession && item.Session != nil && item.Session.GroupPath != "" {
+			// On grouped session: default to root, Tab toggles to subgroup
+			gPath := item.Session.GroupPath
+			gName := gPath
+			if idx := strings.LastIndex(gPath, "/"); idx >= 0 {
+				gName = gPath[idx+1:]
+			}
+			h.groupDialog.ShowCreateWithContextDefaultRoot(gPath, gName)
+		} else {
+			// Ungrouped: root only, no toggle
+			h.groupDialog.ShowCreateWithContext("", "")
+		}
+	} else {
+		h.groupDialog.ShowCreateWithContext("", "")
+	}
+}
+
 // handleGroupDialogKey handles keys when group dialog is visible
 func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
@@ -4562,36 +5801,23 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if h.groupDialog.HasParent() {
 					// Create subgroup under parent
 					parentPath := h.groupDialog.GetParentPath()
-					h.groupTree.CreateSubgroup(parentPath, name)
+					h.sessions.Update(func(tree *session.GroupTree) { tree.CreateSubgroup(parentPath, name) })
 				} else {
 					// Create root-level group
-					h.groupTree.CreateGroup(name)
+					h.sessions.Update(func(tree *session.GroupTree) { tree.CreateGroup(name) })
 				}
 				h.rebuildFlatItems()
 				h.saveInstances() // Persist the new group
 			}
-		case GroupDialogRename:
-			name := h.groupDialog.GetValue()
-			if name != "" {
-				h.groupTree.RenameGroup(h.groupDialog.GetGroupPath(), name)
-				h.instancesMu.Lock()
-				h.instances = h.groupTree.GetAllInstances()
-				h.instancesMu.Unlock()
-				h.rebuildFlatItems()
-				h.saveInstances()
-			}
 		case GroupDialogMove:
 			groupName := h.groupDialog.GetSelectedGroup()
 			if groupName != "" && h.cursor < len(h.flatItems) {
 				item := h.flatItems[h.cursor]
 				if item.Type == session.ItemTypeSession {
 					// Find the group path from name
-					for _, g := range h.groupTree.GroupList {
+					for _, g := range h.sessions.Tree().GroupList {
 						if g.Name == groupName {
-							h.groupTree.MoveSessionToGroup(item.Session, g.Path)
-							h.instancesMu.Lock()
-							h.instances = h.groupTree.GetAllInstances()
-							h.instancesMu.Unlock()
+							h.sessions.Regroup(func(tree *session.GroupTree) { tree.MoveSessionToGroup(item.Session, g.Path) })
 							h.rebuildFlatItems()
 							h.saveInstances()
 							break
@@ -4599,25 +5825,6 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					}
 				}
 			}
-		case GroupDialogRenameSession:
-			newName := h.groupDialog.GetValue()
-			if newName != "" {
-				sessionID := h.groupDialog.GetSessionID()
-				// Find and rename the session (O(1) lookup)
-				if inst := h.getInstanceByID(sessionID); inst != nil {
-					inst.Title = newName
-					inst.SyncTmuxDisplayName()
-				}
-				// Store pending title change so it survives reload races.
-				// If saveInstances() is skipped (isReloading=true), the reload
-				// replaces h.instances from disk, losing the in-memory rename.
-				// loadSessionsMsg re-applies pending changes after reload.
-				h.pendingTitleChanges[sessionID] = newName
-				// Invalidate preview cache since title changed
-				h.invalidatePreviewCache(sessionID)
-				h.rebuildFlatItems()
-				h.saveInstances()
-			}
 		}
 		h.groupDialog.Hide()
 		return h, nil
@@ -4632,20 +5839,71 @@ func (h *Home) handleGroupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return h, cmd
 }
 
-// handleForkDialogKey handles keyboard input for the fork dialog
-func (h *Home) handleForkDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+// handleInlineRenameKey handles keys while a list row is being renamed in
+// place. An invalid name keeps the edit open with the reason shown.
+func (h *Home) handleInlineRenameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
-		// Validate before proceeding
-		if validationErr := h.forkDialog.Validate(); validationErr != "" {
-			h.forkDialog.SetError(validationErr)
+		if reason := h.inlineRename.Validate(); reason != "" {
+			h.toast(ToastWarning, "%s", reason)
 			return h, nil
 		}
-
-		// Get fork parameters from dialog including worktree settings
-		title, groupPath, branchName, worktreeEnabled := h.forkDialog.GetValuesWithWorktree()
-		opts := h.forkDialog.GetOptions()
-		h.clearError() // Clear any previous error
+		h.clearError()
+		if h.inlineRename.IsSession() {
+			h.renameSession(h.inlineRename.SessionID(), h.inlineRename.Value())
+		} else {
+			h.renameGroup(h.inlineRename.GroupPath(), h.inlineRename.Value())
+		}
+		h.inlineRename.Stop()
+		return h, nil
+	case "esc":
+		h.inlineRename.Stop()
+		h.clearError()
+		return h, nil
+	}
+	return h, h.inlineRename.Update(msg)
+}
+
+// renameSession sets a session's title and saves it.
+func (h *Home) renameSession(sessionID, newName string) {
+	// Find and rename the session (O(1) lookup)
+	if inst := h.sessions.Get(sessionID); inst != nil {
+		inst.Title = newName
+		inst.SyncTmuxDisplayName()
+	}
+	// Store pending title change so it survives reload races.
+	// If saveInstances() is skipped (isReloading=true), the reload
+	// replaces the sessions from disk, losing the in-memory rename.
+	// loadSessionsMsg re-applies pending changes after reload.
+	h.pendingTitleChanges[sessionID] = newName
+	// Invalidate preview cache since title changed
+	h.invalidatePreviewCache(sessionID)
+	h.rebuildFlatItems()
+	h.saveInstances()
+}
+
+// renameGroup renames a group, which changes its path and its sessions'
+// group paths, and saves it.
+func (h *Home) renameGroup(path, newName string) {
+	h.sessions.Regroup(func(tree *session.GroupTree) { tree.RenameGroup(path, newName) })
+	h.rebuildFlatItems()
+	h.saveInstances()
+}
+
+// handleForkDialogKey handles keyboard input for the fork dialog
+func (h *Home) handleForkDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		// Validate before proceeding
+		if validationErr := h.forkDialog.Validate(); validationErr != "" {
+			h.forkDialog.SetError(validationErr)
+			return h, nil
+		}
+
+		// Get fork parameters from dialog including worktree settings
+		title, groupPath, branchName, worktreeEnabled := h.forkDialog.GetValuesWithWorktree()
+		opts := h.forkDialog.GetOptions()
+		h.clearError() // Clear any previous error
 
 		// Find the currently selected session
 		if h.cursor < len(h.flatItems) {
@@ -4723,6 +5981,9 @@ func (h *Home) forceSaveInstances() {
 // saveInstancesWithForce is the internal save implementation.
 // force=true bypasses the isReloading check for critical updates.
 func (h *Home) saveInstancesWithForce(force bool) {
+	// Group edits (mute, rename, delete) all end in a save
+	h.syncMutedGroups()
+
 	// Skip saving during reload to avoid overwriting external changes (CLI)
 	// Unless force=true for critical updates like detection results
 	h.reloadMu.Lock()
@@ -4776,13 +6037,9 @@ func (h *Home) saveInstancesWithForce(force bool) {
 			return
 		}
 
-		// Take snapshot under lock for defensive programming
-		// This ensures consistency even if architecture changes in the future
-		h.instancesMu.RLock()
-		instancesCopy := make([]*session.Instance, len(h.instances))
-		copy(instancesCopy, h.instances)
-		instanceCount := len(h.instances)
-		h.instancesMu.RUnlock()
+		// Take sessions and groups in one snapshot, so they match
+		instancesCopy, groupTreeCopy := h.sessions.Snapshot()
+		instanceCount := len(instancesCopy)
 
 		uiLog.Debug("save_instances", slog.Int("count", instanceCount), slog.String("profile", h.profile), slog.String("path", h.storage.Path()), slog.Bool("force", force))
 
@@ -4796,8 +6053,6 @@ func (h *Home) saveInstancesWithForce(force bool) {
 			}
 		}
 
-		groupTreeCopy := h.groupTree.ShallowCopyForSave()
-
 		// CRITICAL FIX: NotifySave MUST be called immediately before SaveWithGroups
 		// Previously it was called 25 lines earlier, creating a race window where the
 		// 500ms ignore window could expire before the save completed under load
@@ -4830,10 +6085,10 @@ func (h *Home) saveInstancesWithForce(force bool) {
 // saveGroupState saves only group expanded/collapsed state to SQLite.
 // This is lightweight (no Touch, no StorageWatcher trigger) and safe to call after every toggle.
 func (h *Home) saveGroupState() {
-	if h.storage == nil || h.groupTree == nil {
+	if h.storage == nil {
 		return
 	}
-	groupTreeCopy := h.groupTree.ShallowCopyForSave()
+	_, groupTreeCopy := h.sessions.Snapshot()
 	if err := h.storage.SaveGroupsOnly(groupTreeCopy); err != nil {
 		uiLog.Warn("save_group_state_failed", slog.String("error", err.Error()))
 	}
@@ -4850,8 +6105,14 @@ func (h *Home) saveUIState() {
 	}
 
 	state := uiState{
-		PreviewMode:  int(h.previewMode),
-		StatusFilter: string(h.statusFilter),
+		PreviewMode:   int(h.previewMode),
+		StatusFilter:  string(h.statusFilter),
+		TagFilter:     h.tagFilter,
+		DoNotDisturb:  h.doNotDisturb.Load(),
+		ListPercent:   h.listPercent,
+		PreviewHidden: h.previewHidden,
+		PreviewPlain:  h.previewPlain,
+		ListDensity:   int(h.listDensity),
 	}
 
 	// Capture cursor position
@@ -4903,6 +6164,16 @@ func (h *Home) loadUIState() {
 	// Apply preview mode and status filter immediately
 	h.previewMode = PreviewMode(state.PreviewMode)
 	h.statusFilter = session.Status(state.StatusFilter)
+	h.tagFilter = state.TagFilter
+	h.doNotDisturb.Store(state.DoNotDisturb)
+	if state.ListPercent >= listPercentMin && state.ListPercent <= listPercentMax {
+		h.listPercent = state.ListPercent
+	}
+	h.previewHidden = state.PreviewHidden
+	h.previewPlain = state.PreviewPlain
+	if state.ListDensity >= 0 && state.ListDensity <= int(DensityDetailed) {
+		h.listDensity = ListDensity(state.ListDensity)
+	}
 
 	// Defer cursor restoration until flatItems are populated
 	h.pendingCursorRestore = &state
@@ -4911,11 +6182,8 @@ func (h *Home) loadUIState() {
 // getUsedClaudeSessionIDs returns a map of all Claude session IDs currently in use
 // This is used for deduplication when detecting new session IDs
 func (h *Home) getUsedClaudeSessionIDs() map[string]bool {
-	h.instancesMu.RLock()
-	defer h.instancesMu.RUnlock()
-
 	usedIDs := make(map[string]bool)
-	for _, inst := range h.instances {
+	for _, inst := range h.sessions.All() {
 		if inst.ClaudeSessionID != "" {
 			usedIDs[inst.ClaudeSessionID] = true
 		}
@@ -5041,9 +6309,8 @@ func (h *Home) quickCreateSession() tea.Cmd {
 			projectPath = h.mostRecentPathInGroup(groupPath)
 		}
 
-		h.instancesMu.RLock()
 		var mostRecent *session.Instance
-		for _, inst := range h.instances {
+		for _, inst := range h.sessions.All() {
 			if inst.GroupPath == groupPath {
 				if mostRecent == nil || inst.CreatedAt.After(mostRecent.CreatedAt) {
 					mostRecent = inst
@@ -5060,7 +6327,6 @@ func (h *Home) quickCreateSession() tea.Cmd {
 				geminiYoloMode = true
 			}
 		}
-		h.instancesMu.RUnlock()
 	}
 
 	// Fallback for path
@@ -5086,9 +6352,7 @@ func (h *Home) quickCreateSession() tea.Cmd {
 	}
 
 	// Generate unique name
-	h.instancesMu.RLock()
-	name := session.GenerateUniqueSessionName(h.instances, groupPath)
-	h.instancesMu.RUnlock()
+	name := session.GenerateUniqueSessionName(h.sessions.All(), groupPath)
 
 	return h.createSessionInGroupWithWorktreeAndOptions(
 		name, projectPath, command, groupPath,
@@ -5100,11 +6364,8 @@ func (h *Home) quickCreateSession() tea.Cmd {
 // mostRecentPathInGroup returns the project path of the most recently created
 // session in the given group, or empty string if no sessions exist.
 func (h *Home) mostRecentPathInGroup(groupPath string) string {
-	h.instancesMu.RLock()
-	defer h.instancesMu.RUnlock()
-
 	var mostRecent *session.Instance
-	for _, inst := range h.instances {
+	for _, inst := range h.sessions.All() {
 		if inst.GroupPath == groupPath && inst.ProjectPath != "" {
 			if mostRecent == nil || inst.CreatedAt.After(mostRecent.CreatedAt) {
 				mostRecent = inst
@@ -5184,29 +6445,70 @@ func (h *Home) forkSessionCmdWithOptions(source *session.Instance, title, groupP
 
 // sessionDeletedMsg signals that a session was deleted
 type sessionDeletedMsg struct {
-	deletedID string
-	killErr   error // Error from Kill() if any
+	deletedID   string
+	killErr     error // Error from Kill() if any
+	worktreeErr error // The session's worktree could not be removed
+	archived    bool  // Session was archived before deletion (no undo entry)
+	archiveErr  error // Archiving failed; the session was left untouched
 }
 
-// sessionRestoredMsg signals that an undo-delete restore completed
+// sessionRestoredMsg signals that an undo-delete or archive restore completed
 type sessionRestoredMsg struct {
-	instance *session.Instance
-	err      error
+	instance  *session.Instance
+	archiveID string // Set when restored from the archive
+	err       error
 }
 
-// deleteSession deletes a session
-func (h *Home) deleteSession(inst *session.Instance) tea.Cmd {
+// deleteSession deletes a session and, if removeWorktree is set, the git
+// worktree it was created in. A worktree with uncommitted changes is kept.
+func (h *Home) deleteSession(inst *session.Instance, removeWorktree bool) tea.Cmd {
 	id := inst.ID
 	isWorktree := inst.IsWorktree()
 	worktreePath := inst.WorktreePath
 	worktreeRepoRoot := inst.WorktreeRepoRoot
 	return func() tea.Msg {
 		killErr := inst.Kill()
-		if isWorktree {
-			_ = git.RemoveWorktree(worktreeRepoRoot, worktreePath, false)
+		if killErr != nil && !inst.Exists() {
+			killErr = nil // Already gone, e.g. killed outside agent-deck
+		}
+		var worktreeErr error
+		if isWorktree && removeWorktree {
+			worktreeErr = git.RemoveWorktree(worktreeRepoRoot, worktreePath, false)
 			_ = git.PruneWorktrees(worktreeRepoRoot)
 		}
-		return sessionDeletedMsg{deletedID: id, killErr: killErr}
+		return sessionDeletedMsg{deletedID: id, killErr: killErr, worktreeErr: worktreeErr}
+	}
+}
+
+// archiveSession saves a session and its final output to the archive, then
+// deletes it. Worktrees are kept so the session can be restored.
+func (h *Home) archiveSession(inst *session.Instance) tea.Cmd {
+	id := inst.ID
+	return func() tea.Msg {
+		if err := h.storage.ArchiveInstance(inst, inst.CaptureTranscript()); err != nil {
+			return sessionDeletedMsg{deletedID: id, archiveErr: err}
+		}
+		killErr := inst.Kill()
+		return sessionDeletedMsg{deletedID: id, killErr: killErr, archived: true}
+	}
+}
+
+// restoreArchivedSession rebuilds an archived session and restarts it.
+// The archive entry is removed once the restored session is saved.
+func (h *Home) restoreArchivedSession(id string) tea.Cmd {
+	return func() tea.Msg {
+		archived, err := h.storage.GetArchived(id)
+		if err != nil {
+			return sessionRestoredMsg{err: err}
+		}
+		inst, err := archived.Instance()
+		if err != nil {
+			return sessionRestoredMsg{err: err}
+		}
+		if err := inst.Restart(); err != nil {
+			return sessionRestoredMsg{err: err}
+		}
+		return sessionRestoredMsg{instance: inst, archiveID: id}
 	}
 }
 
@@ -5222,6 +6524,31 @@ type mcpRestartedMsg struct {
 	err     error
 }
 
+// promptResurrectIfAllGone offers to recreate the stored sessions when none of
+// their tmux sessions exist, which is what a reboot (or tmux server restart)
+// leaves behind. Partially missing sessions are left to R and
+// `agent-deck resurrect`, since those were usually stopped on purpose.
+func (h *Home) promptResurrectIfAllGone() {
+	instances := h.sessions.All()
+	if h.confirmDialog.IsVisible() || h.setupWizard.IsVisible() || len(instances) == 0 {
+		return
+	}
+	// One list-sessions call instead of a has-session per instance
+	tmux.RefreshExistingSessions()
+	gone := session.GoneSessions(instances)
+	if len(gone) < len(instances) {
+		return
+	}
+	ids := make([]string, len(gone))
+	titles := make([]string, len(gone))
+	for i, inst := range gone {
+		ids[i] = inst.ID
+		titles[i] = inst.Title
+	}
+	h.confirmDialog.ShowResurrectSessions(ids, titles)
+	h.confirmDialog.SetSize(h.width, h.height)
+}
+
 // restartSession restarts a dead/errored session by creating a new tmux session
 func (h *Home) restartSession(inst *session.Instance) tea.Cmd {
 	id := inst.ID
@@ -5231,9 +6558,7 @@ func (h *Home) restartSession(inst *session.Instance) tea.Cmd {
 
 		// Resolve current instance by ID at execution time. During storage reloads,
 		// the pointer captured from the key event can be replaced before this cmd runs.
-		h.instancesMu.RLock()
-		current := h.instanceByID[id]
-		h.instancesMu.RUnlock()
+		current := h.sessions.Get(id)
 		if current == nil {
 			err := fmt.Errorf("session no longer exists")
 			mcpUILog.Debug("restart_session_result", slog.String("id", id), slog.Any("error", err))
@@ -5271,12 +6596,9 @@ func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
 	reloading := h.isReloading
 	h.reloadMu.Unlock()
 	if !reloading && h.storage != nil {
-		// Take snapshot under lock for defensive programming
-		h.instancesMu.RLock()
-		instancesCopy := make([]*session.Instance, len(h.instances))
-		copy(instancesCopy, h.instances)
-		instanceCount := len(h.instances)
-		h.instancesMu.RUnlock()
+		// Take sessions and groups in one snapshot, so they match
+		instancesCopy, groupTreeCopy := h.sessions.Snapshot()
+		instanceCount := len(instancesCopy)
 
 		// DEFENSIVE: Never save empty instances if storage has data
 		if instanceCount == 0 {
@@ -5286,8 +6608,6 @@ func (h *Home) attachSession(inst *session.Instance) tea.Cmd {
 			}
 		}
 
-		groupTreeCopy := h.groupTree.ShallowCopyForSave()
-
 		// CRITICAL FIX: NotifySave MUST be called immediately before SaveWithGroups
 		// Previously it was called 18 lines earlier, creating a race window
 		if h.storageWatcher != nil {
@@ -5353,27 +6673,11 @@ func (a attachCmd) SetStdin(r io.Reader)  {}
 func (a attachCmd) SetStdout(w io.Writer) {}
 func (a attachCmd) SetStderr(w io.Writer) {}
 
-// importSessions imports existing tmux sessions
-func (h *Home) importSessions() tea.Msg {
-	discovered, err := session.DiscoverExistingTmuxSessions(h.instances)
-	if err != nil {
-		return loadSessionsMsg{err: err}
-	}
-
-	h.instancesMu.Lock()
-	h.instances = append(h.instances, discovered...)
-	instancesCopy := make([]*session.Instance, len(h.instances))
-	copy(instancesCopy, h.instances)
-	h.instancesMu.Unlock()
-
-	// Add discovered sessions to group tree before saving
-	for _, inst := range discovered {
-		h.groupTree.AddSession(inst)
-	}
-	// Save both instances AND groups (critical fix: was losing groups!)
-	h.saveInstances()
-	state := h.preserveState()
-	return loadSessionsMsg{instances: instancesCopy, restoreState: &state}
+// discoverTmuxSessions finds existing tmux sessions to import. It runs as a
+// command; the import dialog opens when its message arrives.
+func (h *Home) discoverTmuxSessions() tea.Msg {
+	discovered, err := session.DiscoverExistingTmuxSessions(h.sessions.All())
+	return tmuxSessionsDiscoveredMsg{instances: discovered, err: err}
 }
 
 // countSessionStatuses counts sessions by status for the logo display
@@ -5381,21 +6685,22 @@ func (h *Home) importSessions() tea.Msg {
 // Cache expires after 500ms to balance freshness with performance
 // PERFORMANCE: Increased from 100ms to 500ms - status changes are rare
 // during UI interaction, and longer cache reduces View() overhead
-func (h *Home) countSessionStatuses() (running, waiting, idle, errored int) {
+func (h *Home) countSessionStatuses() (running, needsInput, waiting, idle, errored int) {
 	// Return cached values if valid and not expired
 	const cacheDuration = 500 * time.Millisecond
 	if h.cachedStatusCounts.valid.Load() &&
 		time.Since(h.cachedStatusCounts.timestamp) < cacheDuration {
-		return h.cachedStatusCounts.running, h.cachedStatusCounts.waiting,
+		return h.cachedStatusCounts.running, h.cachedStatusCounts.needsInput, h.cachedStatusCounts.waiting,
 			h.cachedStatusCounts.idle, h.cachedStatusCounts.errored
 	}
 
 	// Compute counts
-	h.instancesMu.RLock()
-	for _, inst := range h.instances {
+	for _, inst := range h.sessions.All() {
 		switch inst.GetStatusThreadSafe() {
 		case session.StatusRunning:
 			running++
+		case session.StatusNeedsInput:
+			needsInput++
 		case session.StatusWaiting:
 			waiting++
 		case session.StatusIdle:
@@ -5404,22 +6709,22 @@ func (h *Home) countSessionStatuses() (running, waiting, idle, errored int) {
 			errored++
 		}
 	}
-	h.instancesMu.RUnlock()
 
 	// Cache results with timestamp
 	h.cachedStatusCounts.running = running
+	h.cachedStatusCounts.needsInput = needsInput
 	h.cachedStatusCounts.waiting = waiting
 	h.cachedStatusCounts.idle = idle
 	h.cachedStatusCounts.errored = errored
 	h.cachedStatusCounts.valid.Store(true)
 	h.cachedStatusCounts.timestamp = time.Now()
-	return running, waiting, idle, errored
+	return running, needsInput, waiting, idle, errored
 }
 
 // renderFilterBar renders the quick filter pills
-// Format: [All] [● Running 2] [◐ Waiting 1] [○ Idle 5] [✕ Error 1]
+// Format: [All] [⚑ 3] [● Running 2] [◆ Needs input 1] [◐ Waiting 1] [○ Idle 5] [✕ Error 1]
 func (h *Home) renderFilterBar() string {
-	running, waiting, idle, errored := h.countSessionStatuses()
+	running, needsInput, waiting, idle, errored := h.countSessionStatuses()
 
 	// Pill styling
 	activePillStyle := lipgloss.NewStyle().
@@ -5438,88 +6743,142 @@ func (h *Home) renderFilterBar() string {
 		Faint(true).
 		Padding(0, 1)
 
-	// Build pills
+	// Build pills, recording where each status pill lands so it can be
+	// clicked (the row starts with one space, pills are one space apart)
 	var pills []string
+	x := 1
+	addPill := func(pill string, filter session.Status) {
+		w := lipgloss.Width(pill)
+		h.filterHits = append(h.filterHits, filterHit{row: 1, x0: x, x1: x + w, filter: filter})
+		pills = append(pills, pill)
+		x += w + 1
+	}
 
 	// "All" pill
 	allLabel := "All"
-	if h.statusFilter == "" {
-		pills = append(pills, activePillStyle.Render(allLabel))
+	if h.statusFilter == "" && h.tagFilter == "" {
+		addPill(activePillStyle.Render(allLabel), "")
 	} else {
-		pills = append(pills, inactivePillStyle.Render(allLabel))
+		addPill(inactivePillStyle.Render(allLabel), "")
+	}
+
+	// Needs-attention pill (waiting, needs input and errors together)
+	if attention := waiting + needsInput + errored; attention > 0 || h.statusFilter == statusFilterAttention {
+		attentionLabel := fmt.Sprintf("⚑ %d", attention)
+		if h.statusFilter == statusFilterAttention {
+			addPill(activePillStyle.Render(attentionLabel), statusFilterAttention)
+		} else {
+			addPill(inactivePillStyle.Render(attentionLabel), statusFilterAttention)
+		}
 	}
 
 	// Running pill (green when active, dim if 0)
 	runningLabel := fmt.Sprintf("● %d", running)
 	if h.statusFilter == session.StatusRunning {
-		pills = append(pills, lipgloss.NewStyle().
+		addPill(lipgloss.NewStyle().
 			Foreground(ColorBg).
 			Background(ColorGreen).
 			Bold(true).
-			Padding(0, 1).Render(runningLabel))
+			Padding(0, 1).Render(runningLabel), session.StatusRunning)
 	} else if running > 0 {
-		pills = append(pills, lipgloss.NewStyle().
+		addPill(lipgloss.NewStyle().
 			Foreground(ColorGreen).
 			Background(ColorSurface).
-			Padding(0, 1).Render(runningLabel))
+			Padding(0, 1).Render(runningLabel), session.StatusRunning)
 	} else {
-		pills = append(pills, dimPillStyle.Render(runningLabel))
+		addPill(dimPillStyle.Render(runningLabel), session.StatusRunning)
+	}
+
+	// Needs-input pill (orange; only shown when there is something to approve)
+	if needsInput > 0 || h.statusFilter == session.StatusNeedsInput {
+		needsInputLabel := fmt.Sprintf("◆ %d", needsInput)
+		if h.statusFilter == session.StatusNeedsInput {
+			addPill(lipgloss.NewStyle().
+				Foreground(ColorBg).
+				Background(ColorOrange).
+				Bold(true).
+				Padding(0, 1).Render(needsInputLabel), session.StatusNeedsInput)
+		} else {
+			addPill(lipgloss.NewStyle().
+				Foreground(ColorOrange).
+				Background(ColorSurface).
+				Bold(true).
+				Padding(0, 1).Render(needsInputLabel), session.StatusNeedsInput)
+		}
 	}
 
 	// Waiting pill (yellow when active)
 	waitingLabel := fmt.Sprintf("◐ %d", waiting)
 	if h.statusFilter == session.StatusWaiting {
-		pills = append(pills, lipgloss.NewStyle().
+		addPill(lipgloss.NewStyle().
 			Foreground(ColorBg).
 			Background(ColorYellow).
 			Bold(true).
-			Padding(0, 1).Render(waitingLabel))
+			Padding(0, 1).Render(waitingLabel), session.StatusWaiting)
 	} else if waiting > 0 {
-		pills = append(pills, lipgloss.NewStyle().
+		addPill(lipgloss.NewStyle().
 			Foreground(ColorYellow).
 			Background(ColorSurface).
-			Padding(0, 1).Render(waitingLabel))
+			Padding(0, 1).Render(waitingLabel), session.StatusWaiting)
 	} else {
-		pills = append(pills, dimPillStyle.Render(waitingLabel))
+		addPill(dimPillStyle.Render(waitingLabel), session.StatusWaiting)
 	}
 
 	// Idle pill (gray when active)
 	idleLabel := fmt.Sprintf("○ %d", idle)
 	if h.statusFilter == session.StatusIdle {
-		pills = append(pills, lipgloss.NewStyle().
+		addPill(lipgloss.NewStyle().
 			Foreground(ColorBg).
 			Background(ColorTextDim).
 			Bold(true).
-			Padding(0, 1).Render(idleLabel))
+			Padding(0, 1).Render(idleLabel), session.StatusIdle)
 	} else if idle > 0 {
-		pills = append(pills, lipgloss.NewStyle().
+		addPill(lipgloss.NewStyle().
 			Foreground(ColorText).
 			Background(ColorSurface).
-			Padding(0, 1).Render(idleLabel))
+			Padding(0, 1).Render(idleLabel), session.StatusIdle)
 	} else {
-		pills = append(pills, dimPillStyle.Render(idleLabel))
+		addPill(dimPillStyle.Render(idleLabel), session.StatusIdle)
 	}
 
 	// Error pill (red when active)
 	if errored > 0 || h.statusFilter == session.StatusError {
 		errorLabel := fmt.Sprintf("✕ %d", errored)
 		if h.statusFilter == session.StatusError {
-			pills = append(pills, lipgloss.NewStyle().
+			addPill(lipgloss.NewStyle().
 				Foreground(ColorBg).
 				Background(ColorRed).
 				Bold(true).
-				Padding(0, 1).Render(errorLabel))
+				Padding(0, 1).Render(errorLabel), session.StatusError)
 		} else if errored > 0 {
-			pills = append(pills, lipgloss.NewStyle().
+			addPill(lipgloss.NewStyle().
 				Foreground(ColorRed).
 				Background(ColorSurface).
-				Padding(0, 1).Render(errorLabel))
+				Padding(0, 1).Render(errorLabel), session.StatusError)
 		}
 	}
 
+	// Tag pill (only while a tag filter is active)
+	if h.tagFilter != "" {
+		pills = append(pills, lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorPurple).
+			Bold(true).
+			Padding(0, 1).Render("tag: "+h.tagFilter))
+	}
+
+	// Do-not-disturb pill
+	if h.doNotDisturb.Load() {
+		pills = append(pills, lipgloss.NewStyle().
+			Foreground(ColorBg).
+			Background(ColorTextDim).
+			Bold(true).
+			Padding(0, 1).Render("🔕 dnd"))
+	}
+
 	// Hint for keyboard shortcuts (shift+number to filter, 0 to clear)
 	hintStyle := lipgloss.NewStyle().Foreground(ColorComment).Faint(true)
-	hint := hintStyle.Render("  !@#$ filter • 0 all")
+	hint := hintStyle.Render("  !@#$%^ filter • T tag • 0 all")
 
 	// Join pills with spaces (leading space replaces Padding)
 	filterRow := " " + strings.Join(pills, " ") + hint
@@ -5541,6 +6900,10 @@ func (h *Home) updateSizes() {
 
 // View renders the UI
 func (h *Home) View() string {
+	// Status counts and list rows are only clickable while the main screen is shown
+	h.filterHits = h.filterHits[:0]
+	h.listArea = listArea{}
+
 	// CRITICAL: Return empty during attach to prevent View() output leakage
 	// (Bubble Tea Issue #431 - View gets printed to stdout during tea.Exec)
 	if h.isAttaching.Load() { // Atomic read for thread safety
@@ -5623,6 +6986,57 @@ func (h *Home) View() string {
 	if h.worktreeFinishDialog.IsVisible() {
 		return h.worktreeFinishDialog.View()
 	}
+	if h.profilePickerDialog.IsVisible() {
+		return h.profilePickerDialog.View()
+	}
+	if h.archiveDialog.IsVisible() {
+		return h.archiveDialog.View()
+	}
+	if h.resumeDialog.IsVisible() {
+		return h.resumeDialog.View()
+	}
+	if h.forkTreeDialog.IsVisible() {
+		return h.forkTreeDialog.View()
+	}
+	if h.cleanupDialog.IsVisible() {
+		return h.cleanupDialog.View()
+	}
+	if h.importDialog.IsVisible() {
+		return h.importDialog.View()
+	}
+	if h.recentDialog.IsVisible() {
+		return h.recentDialog.View()
+	}
+	if h.dashboard.IsVisible() {
+		return h.dashboard.View()
+	}
+	if h.sessionInfoDialog.IsVisible() {
+		return h.sessionInfoDialog.View()
+	}
+	if h.toastLogDialog.IsVisible() {
+		return h.toastLogDialog.View()
+	}
+	if h.errorLogDialog.IsVisible() {
+		return h.errorLogDialog.View()
+	}
+	if h.transcriptViewer.IsVisible() {
+		return h.transcriptViewer.View()
+	}
+	if h.diffViewer.IsVisible() {
+		return h.diffViewer.View()
+	}
+	if h.scrollbackViewer.IsVisible() {
+		return h.scrollbackViewer.View()
+	}
+	if h.notesDialog.IsVisible() {
+		return h.notesDialog.View()
+	}
+	if h.tagDialog.IsVisible() {
+		return h.tagDialog.View()
+	}
+	if h.promptDialog.IsVisible() {
+		return h.promptDialog.View()
+	}
 
 	// Reuse viewBuilder to reduce allocations (reset and pre-allocate)
 	h.viewBuilder.Reset()
@@ -5633,8 +7047,8 @@ func (h *Home) View() string {
 	// HEADER BAR
 	// ═══════════════════════════════════════════════════════════════════
 	// Calculate real session status counts for logo and stats
-	running, waiting, idle, errored := h.countSessionStatuses()
-	logo := RenderLogoCompact(running, waiting, idle)
+	running, needsInput, waiting, idle, errored := h.countSessionStatuses()
+	logo := RenderLogoCompact(running, waiting+needsInput, idle)
 
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -5651,21 +7065,39 @@ func (h *Home) View() string {
 	title := titleStyle.Render(titleText)
 
 	// Status-based stats (more useful than group/session counts)
-	// Format: ● 2 running • ◐ 1 waiting • ○ 3 idle (• ✕ 1 error)
+	// Format: ● 2 running • ◆ 1 needs input • ◐ 1 waiting • ○ 3 idle (• ✕ 1 error)
 	var statsParts []string
+	var statsFilters []session.Status // Filter each part opens when clicked
 	statsSep := lipgloss.NewStyle().Foreground(ColorBorder).Render(" • ")
 
 	if running > 0 {
 		statsParts = append(statsParts, lipgloss.NewStyle().Foreground(ColorGreen).Render(fmt.Sprintf("● %d running", running)))
+		statsFilters = append(statsFilters, session.StatusRunning)
+	}
+	if needsInput > 0 {
+		statsParts = append(statsParts, lipgloss.NewStyle().Foreground(ColorOrange).Bold(true).Render(fmt.Sprintf("◆ %d needs input", needsInput)))
+		statsFilters = append(statsFilters, session.StatusNeedsInput)
 	}
 	if waiting > 0 {
 		statsParts = append(statsParts, lipgloss.NewStyle().Foreground(ColorYellow).Render(fmt.Sprintf("◐ %d waiting", waiting)))
+		statsFilters = append(statsFilters, session.StatusWaiting)
 	}
 	if idle > 0 {
 		statsParts = append(statsParts, lipgloss.NewStyle().Foreground(ColorText).Render(fmt.Sprintf("○ %d idle", idle)))
+		statsFilters = append(statsFilters, session.StatusIdle)
 	}
 	if errored > 0 {
 		statsParts = append(statsParts, lipgloss.NewStyle().Foreground(ColorRed).Render(fmt.Sprintf("✕ %d error", errored)))
+		statsFilters = append(statsFilters, session.StatusError)
+	}
+
+	// Make each count clickable: the bar's left padding, logo and title
+	// come first, two spaces apart
+	x := 1 + lipgloss.Width(logo) + 2 + lipgloss.Width(title) + 2
+	for i, part := range statsParts {
+		w := lipgloss.Width(part)
+		h.filterHits = append(h.filterHits, filterHit{row: 0, x0: x, x1: x + w, filter: statsFilters[i]})
+		x += w + lipgloss.Width(statsSep)
 	}
 
 	// Fallback if no sessions
@@ -5682,6 +7114,18 @@ func (h *Home) View() string {
 		Faint(true)
 	versionBadge := versionStyle.Render("v" + Version)
 
+	// Errors not yet dismissed, instead of a message that takes a row
+	if n := h.errorLog.Undismissed(); n > 0 {
+		label := fmt.Sprintf("⚠ %d error", n)
+		if n > 1 {
+			label += "s"
+		}
+		if hint := h.keyHint(ActionErrors); hint != "" {
+			label += " (" + hint + ")"
+		}
+		versionBadge = lipgloss.NewStyle().Foreground(ColorRed).Bold(true).Render(label) + "  " + versionBadge
+	}
+
 	// Fill remaining header space
 	headerLeft := lipgloss.JoinHorizontal(lipgloss.Left, logo, "  ", title, "  ", stats)
 	headerPadding := h.width - lipgloss.Width(headerLeft) - lipgloss.Width(versionBadge) - 2
@@ -5751,6 +7195,14 @@ func (h *Home) View() string {
 	// Route to appropriate layout based on terminal width
 	layoutMode := h.getLayoutMode()
 
+	// The list starts below the panel title; renderSessionList fills in
+	// which items it shows
+	h.listArea.top = 1 + filterBarHeight + updateBannerHeight + maintenanceBannerHeight + 2
+	h.listArea.width = h.width
+	if layoutMode == LayoutModeDual {
+		h.listArea.width = h.dualListWidth()
+	}
+
 	var mainContent string
 	switch layoutMode {
 	case LayoutModeSingle:
@@ -5763,6 +7215,8 @@ func (h *Home) View() string {
 
 	// Ensure mainContent has exact height
 	mainContent = ensureExactHeight(mainContent, contentHeight)
+	// Toasts float over the bottom-right corner instead of taking a row
+	mainContent = overlayBottomRight(mainContent, h.toasts.Lines(h.width-2), h.width)
 	b.WriteString(mainContent)
 	b.WriteString("\n")
 
@@ -5772,19 +7226,7 @@ func (h *Home) View() string {
 	helpBar := h.renderHelpBar()
 	b.WriteString(helpBar)
 
-	// Error and warning messages are displayed but may be truncated by final height constraint
-	if h.err != nil {
-		remaining := 5*time.Second - time.Since(h.errTime)
-		if remaining < 0 {
-			remaining = 0
-		}
-		dismissHint := lipgloss.NewStyle().Foreground(ColorText).Render(
-			fmt.Sprintf(" (auto-dismiss in %ds)", int(remaining.Seconds())+1))
-		errMsg := ErrorStyle.Render("⚠ "+h.err.Error()) + dismissHint
-		b.WriteString("\n")
-		b.WriteString(errMsg)
-	}
-
+	// Warnings are displayed but may be truncated by final height constraint
 	if h.storageWarning != "" {
 		warnStyle := lipgloss.NewStyle().Foreground(ColorYellow)
 		b.WriteString("\n")
@@ -5797,6 +7239,12 @@ func (h *Home) View() string {
 		b.WriteString(warnStyle.Render("⚠ " + h.watcherWarning))
 	}
 
+	if h.keymapWarning != "" {
+		warnStyle := lipgloss.NewStyle().Foreground(ColorYellow)
+		b.WriteString("\n")
+		b.WriteString(warnStyle.Render("⚠ " + h.keymapWarning))
+	}
+
 	// CRITICAL: Use ensureExactHeight for robust, consistent output across all platforms
 	// This is the single source of truth for output height - guarantees exactly h.height lines
 	// regardless of component content, ANSI codes, or terminal differences
@@ -5818,12 +7266,10 @@ func (h *Home) View() string {
 // renderPanelTitle creates a styled section title with underline
 func (h *Home) renderPanelTitle(title string, width int) string {
 	// Truncate title if it exceeds width
-	if len(title) > width {
-		if width > 3 {
-			title = title[:width-3] + "..."
-		} else {
-			title = title[:width]
-		}
+	if width > 3 {
+		title = runewidth.Truncate(title, width, "...")
+	} else {
+		title = runewidth.Truncate(title, max(width, 0), "")
 	}
 
 	titleStyle := lipgloss.NewStyle().
@@ -6057,8 +7503,8 @@ func renderEmptyStateResponsive(config EmptyStateConfig, width, height int) stri
 		// Truncate subtitle if width is tight
 		subtitle := config.Subtitle
 		maxSubtitleWidth := width - hPad*2 - 4 // Account for padding and margins
-		if maxSubtitleWidth > 0 && len(subtitle) > maxSubtitleWidth {
-			subtitle = subtitle[:maxSubtitleWidth-3] + "..."
+		if maxSubtitleWidth > 3 {
+			subtitle = runewidth.Truncate(subtitle, maxSubtitleWidth, "...")
 		}
 		content.WriteString(subtitleStyle.Render(subtitle))
 	}
@@ -6091,8 +7537,8 @@ func renderEmptyStateResponsive(config EmptyStateConfig, width, height int) stri
 			// Truncate hint if width is tight
 			displayHint := hint
 			maxHintWidth := width - hPad*2 - 6 // Account for "• " prefix and margins
-			if maxHintWidth > 0 && len(displayHint) > maxHintWidth {
-				displayHint = displayHint[:maxHintWidth-3] + "..."
+			if maxHintWidth > 3 {
+				displayHint = runewidth.Truncate(displayHint, maxHintWidth, "...")
 			}
 			content.WriteString(hintStyle.Render("• " + displayHint))
 			if i < len(hintsToShow)-1 {
@@ -6194,12 +7640,32 @@ func ensureExactWidth(content string, width int) string {
 	return strings.Join(result, "\n")
 }
 
+// dualListWidth returns the session list width in the side-by-side layout.
+func (h *Home) dualListWidth() int {
+	pct := h.listPercent
+	if pct == 0 {
+		pct = listPercentDefault
+	}
+	return int(float64(h.width) * float64(pct) / 100)
+}
+
+// resizeList moves the side-by-side split by delta percent, within
+// listPercentMin..listPercentMax, and remembers it.
+func (h *Home) resizeList(delta int) {
+	pct := h.listPercent
+	if pct == 0 {
+		pct = listPercentDefault
+	}
+	h.listPercent = max(listPercentMin, min(pct+delta, listPercentMax))
+	h.saveUIState()
+}
+
 // renderDualColumnLayout renders side-by-side panels for wide terminals (80+ cols)
 func (h *Home) renderDualColumnLayout(contentHeight int) string {
 	var b strings.Builder
 
-	// Calculate panel widths (35% left, 65% right for more preview space)
-	leftWidth := int(float64(h.width) * 0.35)
+	// Calculate panel widths (35% left, 65% right by default; "<" and ">" adjust)
+	leftWidth := h.dualListWidth()
 	rightWidth := h.width - leftWidth - 3 // -3 for separator
 
 	// Panel title is exactly 2 lines (title + underline)
@@ -6495,7 +7961,7 @@ func (h *Home) renderHelpBarTiny() string {
 	border := borderStyle.Render(strings.Repeat("─", max(0, h.width)))
 
 	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
-	hint := hintStyle.Render("? for help")
+	hint := hintStyle.Render(h.keyHint(ActionHelp) + " for help")
 
 	// Center the hint
 	padding := (h.width - lipgloss.Width(hint)) / 2
@@ -6521,31 +7987,38 @@ func (h *Home) renderHelpBarMinimal() string {
 	sep := sepStyle.Render(" │ ")
 
 	// Context-specific keys (left side)
-	var contextKeys string
+	var actions []KeyAction
 	if len(h.flatItems) == 0 {
-		contextKeys = keyStyle.Render("n") + " " + keyStyle.Render("N") + " " + keyStyle.Render("i") + " " + keyStyle.Render("g")
+		actions = []KeyAction{ActionNew, ActionQuickNew, ActionImport, ActionGroup}
 	} else if h.cursor < len(h.flatItems) {
 		item := h.flatItems[h.cursor]
 		if item.Type == session.ItemTypeGroup {
-			contextKeys = keyStyle.Render("⏎") + " " + keyStyle.Render("n") + " " + keyStyle.Render("N") + " " + keyStyle.Render("g")
+			actions = []KeyAction{ActionAttach, ActionNew, ActionQuickNew, ActionGroup}
 		} else {
-			contextKeys = keyStyle.Render("⏎") + " " + keyStyle.Render("n") + " " + keyStyle.Render("N") + " " + keyStyle.Render("R")
+			actions = []KeyAction{ActionAttach, ActionNew, ActionQuickNew, ActionRestart}
 			if item.Session != nil && item.Session.CanFork() {
-				contextKeys += " " + keyStyle.Render("f")
+				actions = append(actions, ActionFork)
 			}
 			if item.Session != nil && (item.Session.Tool == "claude" || item.Session.Tool == "gemini") {
-				contextKeys += " " + keyStyle.Render("m")
+				actions = append(actions, ActionMCP)
 			}
 			if item.Session != nil && item.Session.Tool == "claude" {
-				contextKeys += " " + keyStyle.Render("s")
+				actions = append(actions, ActionSkills)
 			}
 		}
 	}
+	var keyLabels []string
+	for _, action := range actions {
+		if label := h.keyHint(action); label != "" {
+			keyLabels = append(keyLabels, keyStyle.Render(shortKeyLabel(label)))
+		}
+	}
+	contextKeys := strings.Join(keyLabels, " ")
 
 	// Global keys (right side)
 	globalStyle := lipgloss.NewStyle().Foreground(ColorComment)
-	globalKeys := globalStyle.Render("↑↓") + " " + globalStyle.Render("/") + " " +
-		globalStyle.Render("?") + " " + globalStyle.Render("q")
+	globalKeys := globalStyle.Render(h.keyHint(ActionUp)+h.keyHint(ActionDown)) + " " + globalStyle.Render(h.keyHint(ActionSearch)) + " " +
+		globalStyle.Render(h.keyHint(ActionHelp)) + " " + globalStyle.Render(h.keyHint(ActionQuit))
 
 	// Calculate padding
 	leftPart := contextKeys
@@ -6575,50 +8048,50 @@ func (h *Home) renderHelpBarCompact() string {
 	var contextHints []string
 	if len(h.flatItems) == 0 {
 		contextHints = []string{
-			h.helpKeyShort("n/N", "New"),
-			h.helpKeyShort("i", "Import"),
+			h.helpKeyShort(h.keyHint(ActionNew, ActionQuickNew), "New"),
+			h.helpKeyShort(h.keyHint(ActionImport), "Import"),
 		}
 	} else if h.cursor < len(h.flatItems) {
 		item := h.flatItems[h.cursor]
 		if item.Type == session.ItemTypeGroup {
 			contextHints = []string{
-				h.helpKeyShort("⏎", "Toggle"),
-				h.helpKeyShort("n/N", "New"),
+				h.helpKeyShort(shortKeyLabel(h.keyHint(ActionAttach)), "Toggle"),
+				h.helpKeyShort(h.keyHint(ActionNew, ActionQuickNew), "New"),
 			}
 		} else {
 			contextHints = []string{
-				h.helpKeyShort("⏎", "Attach"),
-				h.helpKeyShort("n/N", "New"),
-				h.helpKeyShort("R", "Restart"),
+				h.helpKeyShort(shortKeyLabel(h.keyHint(ActionAttach)), "Attach"),
+				h.helpKeyShort(h.keyHint(ActionNew, ActionQuickNew), "New"),
+				h.helpKeyShort(h.keyHint(ActionRestart), "Restart"),
 			}
 			if item.Session != nil && item.Session.CanFork() {
-				contextHints = append(contextHints, h.helpKeyShort("f", "Fork"))
+				contextHints = append(contextHints, h.helpKeyShort(h.keyHint(ActionFork), "Fork"))
 			}
 			if item.Session != nil && (item.Session.Tool == "claude" || item.Session.Tool == "gemini") {
-				contextHints = append(contextHints, h.helpKeyShort("m", "MCP"))
-				contextHints = append(contextHints, h.helpKeyShort("v", h.previewModeShort()))
+				contextHints = append(contextHints, h.helpKeyShort(h.keyHint(ActionMCP), "MCP"))
+				contextHints = append(contextHints, h.helpKeyShort(h.keyHint(ActionPreviewMode), h.previewModeShort()))
 			}
 			if item.Session != nil && item.Session.Tool == "claude" {
-				contextHints = append(contextHints, h.helpKeyShort("s", "Skills"))
+				contextHints = append(contextHints, h.helpKeyShort(h.keyHint(ActionSkills), "Skills"))
 			}
-			contextHints = append(contextHints, h.helpKeyShort("c", "Copy"))
-			contextHints = append(contextHints, h.helpKeyShort("x", "Send"))
+			contextHints = append(contextHints, h.helpKeyShort(h.keyHint(ActionCopy), "Copy"))
+			contextHints = append(contextHints, h.helpKeyShort(h.keyHint(ActionSendOutput), "Send"))
 		}
 	}
 
 	// Show undo hint when undo stack is non-empty
 	if len(h.undoStack) > 0 {
-		contextHints = append(contextHints, h.helpKeyShort("^Z", "Undo"))
+		contextHints = append(contextHints, h.helpKeyShort(h.keyHint(ActionUndo), "Undo"))
 	}
 
 	// Global hints (abbreviated)
 	globalStyle := lipgloss.NewStyle().Foreground(ColorComment)
-	globalHints := globalStyle.Render("↑↓ Nav") + " " +
-		globalStyle.Render("/") + " " +
-		globalStyle.Render("?") + " " +
-		globalStyle.Render("q")
+	globalHints := globalStyle.Render(h.keyHint(ActionUp)+h.keyHint(ActionDown)+" Nav") + " " +
+		globalStyle.Render(h.keyHint(ActionSearch)) + " " +
+		globalStyle.Render(h.keyHint(ActionHelp)) + " " +
+		globalStyle.Render(h.keyHint(ActionQuit))
 
-	leftPart := strings.Join(contextHints, " ")
+	leftPart := joinHints(contextHints)
 	rightPart := globalHints
 	padding := h.width - lipgloss.Width(leftPart) - lipgloss.Width(rightPart) - 4
 	if padding < 2 {
@@ -6633,8 +8106,12 @@ func (h *Home) renderHelpBarCompact() string {
 	return lipgloss.NewStyle().MaxWidth(h.width).Render(raw)
 }
 
-// helpKeyShort formats a compact keyboard shortcut (no padding)
+// helpKeyShort formats a compact keyboard shortcut (no padding).
+// Returns "" when key is empty (action unbound in the keymap).
 func (h *Home) helpKeyShort(key, desc string) string {
+	if key == "" {
+		return ""
+	}
 	keyStyle := lipgloss.NewStyle().
 		Foreground(ColorBg).
 		Background(ColorAccent).
@@ -6650,6 +8127,8 @@ func (h *Home) previewModeShort() string {
 		return "Out"
 	case PreviewModeAnalytics:
 		return "Stats"
+	case PreviewModeDiff:
+		return "Diff"
 	default:
 		return "Both"
 	}
@@ -6669,56 +8148,56 @@ func (h *Home) renderHelpBarFull() string {
 	if len(h.flatItems) == 0 {
 		contextTitle = "Empty"
 		primaryHints = []string{
-			h.helpKey("n/N", "New/Quick"),
-			h.helpKey("i", "Import"),
-			h.helpKey("g", "Group"),
+			h.helpKey(h.keyHint(ActionNew, ActionQuickNew), "New/Quick"),
+			h.helpKey(h.keyHint(ActionImport), "Import"),
+			h.helpKey(h.keyHint(ActionGroup), "Group"),
 		}
 	} else if h.cursor < len(h.flatItems) {
 		item := h.flatItems[h.cursor]
 		if item.Type == session.ItemTypeGroup {
 			contextTitle = "Group"
 			primaryHints = []string{
-				h.helpKey("Tab", "Toggle"),
-				h.helpKey("n/N", "New/Quick"),
-				h.helpKey("g", "Group"),
+				h.helpKey(h.keyHint(ActionExpand), "Toggle"),
+				h.helpKey(h.keyHint(ActionNew, ActionQuickNew), "New/Quick"),
+				h.helpKey(h.keyHint(ActionGroup), "Group"),
 			}
 			secondaryHints = []string{
-				h.helpKey("r", "Rename"),
-				h.helpKey("d", "Delete"),
+				h.helpKey(h.keyHint(ActionRename), "Rename"),
+				h.helpKey(h.keyHint(ActionDelete), "Delete"),
 			}
 		} else {
 			contextTitle = "Session"
 			primaryHints = []string{
-				h.helpKey("Enter", "Attach"),
-				h.helpKey("n/N", "New/Quick"),
-				h.helpKey("g", "Group"),
-				h.helpKey("R", "Restart"),
+				h.helpKey(h.keyHint(ActionAttach), "Attach"),
+				h.helpKey(h.keyHint(ActionNew, ActionQuickNew), "New/Quick"),
+				h.helpKey(h.keyHint(ActionGroup), "Group"),
+				h.helpKey(h.keyHint(ActionRestart), "Restart"),
 			}
 			// Only show fork hints if session has a valid Claude session ID
 			if item.Session != nil && item.Session.CanFork() {
-				primaryHints = append(primaryHints, h.helpKey("f/F", "Fork"))
+				primaryHints = append(primaryHints, h.helpKey(h.keyHint(ActionFork, ActionForkDialog), "Fork"))
 			}
 			// Show MCP Manager and preview mode toggle for Claude and Gemini sessions
 			if item.Session != nil && (item.Session.Tool == "claude" || item.Session.Tool == "gemini") {
-				primaryHints = append(primaryHints, h.helpKey("m", "MCP"))
-				primaryHints = append(primaryHints, h.helpKey("v", h.previewModeShort()))
+				primaryHints = append(primaryHints, h.helpKey(h.keyHint(ActionMCP), "MCP"))
+				primaryHints = append(primaryHints, h.helpKey(h.keyHint(ActionPreviewMode), h.previewModeShort()))
 			}
 			if item.Session != nil && item.Session.Tool == "claude" {
-				primaryHints = append(primaryHints, h.helpKey("s", "Skills"))
+				primaryHints = append(primaryHints, h.helpKey(h.keyHint(ActionSkills), "Skills"))
 			}
-			primaryHints = append(primaryHints, h.helpKey("c", "Copy"))
-			primaryHints = append(primaryHints, h.helpKey("x", "Send"))
+			primaryHints = append(primaryHints, h.helpKey(h.keyHint(ActionCopy), "Copy"))
+			primaryHints = append(primaryHints, h.helpKey(h.keyHint(ActionSendOutput), "Send"))
 			secondaryHints = []string{
-				h.helpKey("r", "Rename"),
-				h.helpKey("M", "Move"),
-				h.helpKey("d", "Delete"),
+				h.helpKey(h.keyHint(ActionRename), "Rename"),
+				h.helpKey(h.keyHint(ActionMove), "Move"),
+				h.helpKey(h.keyHint(ActionDelete), "Delete"),
 			}
 		}
 	}
 
 	// Show undo hint when undo stack is non-empty
 	if len(h.undoStack) > 0 {
-		secondaryHints = append(secondaryHints, h.helpKey("^Z", "Undo"))
+		secondaryHints = append(secondaryHints, h.helpKey(h.keyHint(ActionUndo), "Undo"))
 	}
 
 	// Top border
@@ -6733,9 +8212,9 @@ func (h *Home) renderHelpBarFull() string {
 
 	// Build shortcuts line with visual grouping
 	var shortcutsLine string
-	shortcutsLine = strings.Join(primaryHints, " ")
-	if len(secondaryHints) > 0 {
-		shortcutsLine += sep + strings.Join(secondaryHints, " ")
+	shortcutsLine = joinHints(primaryHints)
+	if secondary := joinHints(secondaryHints); secondary != "" {
+		shortcutsLine += sep + secondary
 	}
 
 	// Reload indicator
@@ -6752,9 +8231,9 @@ func (h *Home) renderHelpBarFull() string {
 
 	// Global shortcuts (right side) - more compact with separators
 	globalStyle := lipgloss.NewStyle().Foreground(ColorComment)
-	globalHints := globalStyle.Render("↑↓ Nav") + sep +
-		globalStyle.Render("/ Search  G Global") + sep +
-		globalStyle.Render("? Help  q Quit")
+	globalHints := globalStyle.Render(h.keyHint(ActionUp)+h.keyHint(ActionDown)+" Nav") + sep +
+		globalStyle.Render(h.keyHint(ActionSearch)+" Search  "+h.keyHint(ActionGlobalSearch)+" Global") + sep +
+		globalStyle.Render(h.keyHint(ActionHelp)+" Help  "+h.keyHint(ActionQuit)+" Quit")
 
 	// Calculate spacing between left (context) and right (global) portions
 	leftPart := contextLabel + " " + shortcutsLine
@@ -6775,8 +8254,12 @@ func (h *Home) renderHelpBarFull() string {
 	return lipgloss.NewStyle().MaxWidth(h.width).Render(raw)
 }
 
-// helpKey formats a keyboard shortcut for the help bar
+// helpKey formats a keyboard shortcut for the help bar.
+// Returns "" when key is empty (action unbound in the keymap).
 func (h *Home) helpKey(key, desc string) string {
+	if key == "" {
+		return ""
+	}
 	keyStyle := lipgloss.NewStyle().
 		Foreground(ColorBg).
 		Background(ColorAccent).
@@ -6786,6 +8269,34 @@ func (h *Home) helpKey(key, desc string) string {
 	return keyStyle.Render(key) + " " + descStyle.Render(desc)
 }
 
+// keyHint returns the key labels bound to the given actions joined with "/",
+// e.g. "n/N". Unbound actions are skipped.
+func (h *Home) keyHint(actions ...KeyAction) string {
+	var labels []string
+	for _, action := range actions {
+		if label := h.keymap.Label(action); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return strings.Join(labels, "/")
+}
+
+// shortKeyLabel abbreviates key labels for narrow help bars.
+func shortKeyLabel(label string) string {
+	return strings.ReplaceAll(label, "Enter", "⏎")
+}
+
+// joinHints joins rendered hints with spaces, skipping empty ones.
+func joinHints(hints []string) string {
+	var parts []string
+	for _, hint := range hints {
+		if hint != "" {
+			parts = append(parts, hint)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
 // renderSessionList renders the left panel with hierarchical session list
 func (h *Home) renderSessionList(width, height int) string {
 	var b strings.Builder
@@ -6807,9 +8318,9 @@ func (h *Home) renderSessionList(width, height int) string {
 			Title:    "No Sessions Yet",
 			Subtitle: "Get started by creating your first session",
 			Hints: []string{
-				"Press n to create a new session",
-				"Press i to import existing tmux sessions",
-				"Press g to create a group",
+				"Press " + h.keyHint(ActionNew) + " to create a new session",
+				"Press " + h.keyHint(ActionImport) + " to import existing tmux sessions",
+				"Press " + h.keyHint(ActionGroup) + " to create a group",
 			},
 		}, contentWidth, contentHeight)
 
@@ -6831,12 +8342,24 @@ func (h *Home) renderSessionList(width, height int) string {
 		b.WriteString(DimStyle.Render(fmt.Sprintf("  ⋮ +%d above", h.viewOffset)))
 		b.WriteString("\n")
 		maxVisible-- // Account for the indicator line
+		h.listArea.top++
 	}
 
-	for i := h.viewOffset; i < len(h.flatItems) && visibleCount < maxVisible; i++ {
+	h.sessionNumbers = h.sessionNumbers[:0]
+	h.listArea.items = h.listArea.items[:0]
+	h.rowCache.startFrame()
+	h.frameGroupCounts = nil
+	for i := h.viewOffset; i < len(h.flatItems); i++ {
 		item := h.flatItems[i]
+		rows := h.itemHeight(item)
+		if len(h.listArea.items)+rows > maxVisible && visibleCount > 0 {
+			break
+		}
 		h.renderItem(&b, item, i == h.cursor, i)
 		visibleCount++
+		for range rows {
+			h.listArea.items = append(h.listArea.items, i)
+		}
 	}
 
 	// Show "more below" indicator if there are more items
@@ -6853,30 +8376,53 @@ func (h *Home) renderSessionList(width, height int) string {
 func (h *Home) renderItem(b *strings.Builder, item session.Item, selected bool, itemIndex int) {
 	if item.Type == session.ItemTypeGroup {
 		h.renderGroupItem(b, item, selected, itemIndex)
-	} else {
-		h.renderSessionItem(b, item, selected)
+		return
+	}
+	num := 0
+	if h.numberSessions && len(h.sessionNumbers) < 9 {
+		h.sessionNumbers = append(h.sessionNumbers, itemIndex)
+		num = len(h.sessionNumbers)
 	}
+	h.renderSessionItem(b, item, selected, num)
 }
 
 // renderGroupItem renders a group header
-// PERFORMANCE: Uses cached styles from styles.go to avoid allocations
+// PERFORMANCE: Uses cached styles from styles.go to avoid allocations, and
+// reuses the header from the last frame when nothing in it changed
 func (h *Home) renderGroupItem(b *strings.Builder, item session.Item, selected bool, itemIndex int) {
-	group := item.Group
+	row := h.groupRowFor(item, selected)
+	if h.inlineRename.Targets(item) {
+		h.drawGroupRow(b, item, row)
+		return
+	}
+	if cached, ok := h.rowCache.get(row); ok {
+		b.WriteString(cached)
+		return
+	}
+	var rb strings.Builder
+	h.drawGroupRow(&rb, item, row)
+	h.rowCache.put(row, rb.String())
+	b.WriteString(rb.String())
+}
+
+// drawGroupRow renders the group header row snapshots.
+func (h *Home) drawGroupRow(b *strings.Builder, item session.Item, row groupRow) {
+	selected := row.selected
 
 	// Calculate indentation based on nesting level (no tree lines, just spaces)
 	// Uses spacingNormal (2 chars) per level for consistent hierarchy visualization
-	indent := strings.Repeat(strings.Repeat(" ", spacingNormal), max(0, item.Level))
+	indent := strings.Repeat(strings.Repeat(" ", spacingNormal), max(0, row.level))
 
 	// Expand/collapse indicator with filled triangles (using cached styles)
 	var expandIcon string
 	if selected {
-		if group.Expanded {
+		if row.expanded {
 			expandIcon = GroupExpandSelStyle.Render("▾")
 		} else {
 			expandIcon = GroupExpandSelStyle.Render("▸")
 		}
 	} else {
-		if group.Expanded {
+		if row.expanded {
 			expandIcon = GroupExpandStyle.Render("▾") // Filled triangle for expanded
 		} else {
 			expandIcon = GroupExpandStyle.Render("▸") // Filled triangle for collapsed
@@ -6886,9 +8432,9 @@ func (h *Home) renderGroupItem(b *strings.Builder, item session.Item, selected b
 	// Hotkey indicator (subtle, only for root groups, hidden when selected)
 	// Uses pre-computed RootGroupNum from rebuildFlatItems() - O(1) lookup instead of O(n) loop
 	hotkeyStr := ""
-	if item.Level == 0 && !selected {
-		if item.RootGroupNum >= 1 && item.RootGroupNum <= 9 {
-			hotkeyStr = GroupHotkeyStyle.Render(fmt.Sprintf("%d·", item.RootGroupNum))
+	if row.level == 0 && !selected && !row.numbered {
+		if row.rootNum >= 1 && row.rootNum <= 9 {
+			hotkeyStr = GroupHotkeyStyle.Render(fmt.Sprintf("%d·", row.rootNum))
 		}
 	}
 
@@ -6900,38 +8446,35 @@ func (h *Home) renderGroupItem(b *strings.Builder, item session.Item, selected b
 		countStyle = GroupCountSelStyle
 	}
 
-	// Use recursive count to include sessions in subgroups (Issue #48)
-	sessionCount := h.groupTree.SessionCountForGroup(group.Path)
-	countStr := countStyle.Render(fmt.Sprintf(" (%d)", sessionCount))
+	// Counts include sessions in subgroups (Issue #48)
+	countStr := countStyle.Render(fmt.Sprintf(" (%d)", row.counts.total))
 
 	// Status indicators (compact, on same line) using cached styles
-	// Also count recursively for subgroups
-	running := 0
-	waiting := 0
-	for path, g := range h.groupTree.Groups {
-		if path == group.Path || strings.HasPrefix(path, group.Path+"/") {
-			for _, sess := range g.Sessions {
-				switch sess.Status {
-				case session.StatusRunning:
-					running++
-				case session.StatusWaiting:
-					waiting++
-				}
-			}
-		}
-	}
-
 	statusStr := ""
-	if running > 0 {
-		statusStr += " " + GroupStatusRunning.Render(fmt.Sprintf("● %d", running))
+	if row.counts.running > 0 {
+		statusStr += " " + GroupStatusRunning.Render(fmt.Sprintf("● %d", row.counts.running))
 	}
-	if waiting > 0 {
-		statusStr += " " + GroupStatusWaiting.Render(fmt.Sprintf("◐ %d", waiting))
+	if row.counts.needsInput > 0 {
+		statusStr += " " + GroupStatusNeedsInput.Render(fmt.Sprintf("◆ %d", row.counts.needsInput))
+	}
+	if row.counts.waiting > 0 {
+		statusStr += " " + GroupStatusWaiting.Render(fmt.Sprintf("◐ %d", row.counts.waiting))
+	}
+	if row.muted {
+		statusStr += " 🔕"
+	}
+	if row.sortMode != session.GroupSortManual {
+		statusStr += " " + countStyle.Render("⇅ "+row.sortMode.Label())
+	}
+
+	name := nameStyle.Render(row.name)
+	if h.inlineRename.Targets(item) {
+		name = h.inlineRename.View()
 	}
 
 	// Build the row: [indent][hotkey][expand] [name](count) [status]
-	row := fmt.Sprintf("%s%s%s %s%s%s", indent, hotkeyStr, expandIcon, nameStyle.Render(group.Name), countStr, statusStr)
-	b.WriteString(row)
+	line := fmt.Sprintf("%s%s%s %s%s%s", indent, hotkeyStr, expandIcon, name, countStr, statusStr)
+	b.WriteString(line)
 	b.WriteString("\n")
 }
 
@@ -6947,13 +8490,31 @@ const (
 )
 
 // renderSessionItem renders a single session item for the left panel
-// PERFORMANCE: Uses cached styles from styles.go to avoid allocations
-func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected bool) {
-	inst := item.Session
+// PERFORMANCE: Uses cached styles from styles.go to avoid allocations, and
+// reuses the row from the last frame when nothing in it changed
+func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected bool, num int) {
+	row := h.sessionRowFor(item, selected, num)
+	if h.inlineRename.Targets(item) {
+		h.drawSessionRow(b, item, row)
+		return
+	}
+	if cached, ok := h.rowCache.get(row); ok {
+		b.WriteString(cached)
+		return
+	}
+	var rb strings.Builder
+	h.drawSessionRow(&rb, item, row)
+	h.rowCache.put(row, rb.String())
+	b.WriteString(rb.String())
+}
+
+// deadIcon marks a session whose tmux session was killed outside
+// agent-deck, in place of the error status icon.
+const deadIcon = "⊘"
 
-	// Snapshot status and tool under read lock to avoid races with background worker
-	instStatus := inst.GetStatusThreadSafe()
-	instTool := inst.GetToolThreadSafe()
+// drawSessionRow renders the session row snapshots.
+func (h *Home) drawSessionRow(b *strings.Builder, item session.Item, row sessionRow) {
+	selected := row.selected
 
 	// Tree style for connectors - Use ColorText for clear visibility of box-drawing characters
 	treeStyle := TreeConnectorStyle
@@ -6961,52 +8522,58 @@ func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected
 	// Calculate base indentation for parent levels
 	// Level 1 means direct child of root group, Level 2 means child of nested group, etc.
 	baseIndent := ""
-	if item.Level > 1 {
+	if row.level > 1 {
 		// For deeply nested items, add spacing for parent levels
 		// Sub-sessions get extra indentation (they're at Level = groupLevel + 2)
-		if item.IsSubSession {
+		if row.subSession {
 			// Sub-session: indent for group level, then continuation line for parent
 			// Add leading space so │ aligns with ├ in regular items (both at position 1)
-			groupIndent := strings.Repeat(treeEmpty, item.Level-2)
-			if item.ParentIsLastInGroup {
+			groupIndent := strings.Repeat(treeEmpty, row.level-2)
+			if row.parentLast {
 				baseIndent = groupIndent + "  " // 2 spaces - parent is last, no continuation needed
 			} else {
 				// Style the │ character - leading space aligns │ with ├ above
 				baseIndent = groupIndent + " " + treeStyle.Render("│")
 			}
 		} else {
-			baseIndent = strings.Repeat(treeEmpty, item.Level-1)
+			baseIndent = strings.Repeat(treeEmpty, row.level-1)
 		}
 	}
 
 	// Tree connector: └─ for last item, ├─ for others
 	treeConnector := treeBranch
-	if item.IsSubSession {
+	if row.subSession {
 		// Sub-session uses its own last-in-group logic
-		if item.IsLastSubSession {
+		if row.lastSub {
 			treeConnector = subLast
 		} else {
 			treeConnector = subBranch
 		}
-	} else if item.IsLastInGroup {
+	} else if row.lastInGroup {
 		treeConnector = treeLast
 	}
 
 	// Status indicator with consistent sizing
 	var statusIcon string
 	var statusStyle lipgloss.Style
-	switch instStatus {
+	switch row.status {
 	case session.StatusRunning:
 		statusIcon = "●"
 		statusStyle = SessionStatusRunning
 	case session.StatusWaiting:
 		statusIcon = "◐"
 		statusStyle = SessionStatusWaiting
+	case session.StatusNeedsInput:
+		statusIcon = "◆"
+		statusStyle = SessionStatusNeedsInput
 	case session.StatusIdle:
 		statusIcon = "○"
 		statusStyle = SessionStatusIdle
 	case session.StatusError:
 		statusIcon = "✕"
+		if row.dead {
+			statusIcon = deadIcon
+		}
 		statusStyle = SessionStatusError
 	default:
 		statusIcon = "○"
@@ -7017,8 +8584,8 @@ func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected
 
 	// Title styling - add bold/underline for accessibility (colorblind users)
 	var titleStyle lipgloss.Style
-	switch instStatus {
-	case session.StatusRunning, session.StatusWaiting:
+	switch row.status {
+	case session.StatusRunning, session.StatusWaiting, session.StatusNeedsInput:
 		// Bold for active states (distinguishable without color)
 		titleStyle = SessionTitleActive
 	case session.StatusError:
@@ -7030,7 +8597,17 @@ func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected
 
 	// Tool badge with brand-specific color
 	// Claude=orange, Gemini=purple, Codex=cyan, Aider=red
-	toolStyle := GetToolStyle(instTool)
+	toolStyle := GetToolStyle(row.tool)
+
+	// Badge styles; all of them take the selection style on the selected row
+	modelStyle := SessionModelStyle
+	yoloStyle := SessionYoloStyle
+	queueStyle := SessionQueueStyle
+	restartStyle := SessionRestartStyle
+	if row.restartsExhausted {
+		restartStyle = SessionRestartExhaustedStyle
+	}
+	gitStyle := SessionGitStyle
 
 	// Selection indicator
 	selectionPrefix := " "
@@ -7040,48 +8617,93 @@ func (h *Home) renderSessionItem(b *strings.Builder, item session.Item, selected
 		toolStyle = SessionStatusSelStyle
 		statusStyle = SessionStatusSelStyle
 		status = statusStyle.Render(statusIcon)
+		modelStyle = SessionStatusSelStyle
+		yoloStyle = SessionStatusSelStyle
+		queueStyle = SessionStatusSelStyle
+		restartStyle = SessionStatusSelStyle
+		gitStyle = SessionStatusSelStyle
 		// Tree connector also gets selection styling
 		treeStyle = TreeConnectorSelStyle
 		// Rebuild baseIndent with selection styling for sub-sessions
-		if item.IsSubSession && !item.ParentIsLastInGroup {
-			groupIndent := strings.Repeat(treeEmpty, max(0, item.Level-2))
+		if row.subSession && !row.parentLast {
+			groupIndent := strings.Repeat(treeEmpty, max(0, row.level-2))
 			baseIndent = groupIndent + " " + treeStyle.Render("│")
 		}
 	}
 
-	title := titleStyle.Render(inst.Title)
-	tool := toolStyle.Render(" " + instTool)
+	title := titleStyle.Render(row.title)
+	if h.inlineRename.Targets(item) {
+		title = h.inlineRename.View()
+	}
+
+	// Quick-jump number (number_keys = "sessions"), padded so rows line up
+	numStr := ""
+	if row.numbered {
+		numStr = "  "
+		if row.num > 0 {
+			numStr = " " + GroupHotkeyStyle.Render(fmt.Sprintf("%d", row.num))
+		}
+	}
+
+	// Compact density: status and title only, for long lists
+	if row.density == DensityCompact {
+		line := fmt.Sprintf("%s%s%s%s %s %s", baseIndent, selectionPrefix, treeStyle.Render(treeConnector), numStr, status, title)
+		b.WriteString(line)
+		b.WriteString("\n")
+		return
+	}
+
+	tool := toolStyle.Render(" " + toolLabel(row.tool, row.nerd))
+
+	// Model badge next to the tool, e.g. "claude·opus-4.5"
+	modelBadge := ""
+	if model := session.ShortModelName(row.model); model != "" {
+		modelBadge = modelStyle.Render("·" + runewidth.Truncate(model, 16, "…"))
+	}
 
 	// YOLO badge for Gemini sessions with YOLO mode enabled
 	yoloBadge := ""
-	if instTool == "gemini" && inst.GeminiYoloMode != nil && *inst.GeminiYoloMode {
-		yoloStyle := lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
-		if selected {
-			yoloStyle = SessionStatusSelStyle
-		}
+	if row.yolo {
 		yoloBadge = yoloStyle.Render(" [YOLO]")
 	}
 
-	// Worktree branch badge for sessions running in git worktrees
-	worktreeBadge := ""
-	if inst.IsWorktree() && inst.WorktreeBranch != "" {
-		branch := inst.WorktreeBranch
-		if len(branch) > 15 {
-			branch = branch[:12] + "..."
-		}
-		wtStyle := lipgloss.NewStyle().Foreground(ColorCyan)
-		if selected {
-			wtStyle = SessionStatusSelStyle
+	// Pending prompt queue badge
+	queueBadge := ""
+	if row.queued > 0 {
+		queueBadge = queueStyle.Render(fmt.Sprintf(" [%d queued]", row.queued))
+	}
+
+	// Auto-restart badge: restarts since the session last ran steadily, red
+	// once they are used up and the session is still down
+	restartBadge := ""
+	if row.restarts > 0 {
+		restartBadge = restartStyle.Render(fmt.Sprintf(" [↻%d]", row.restarts))
+	}
+
+	// Git branch badge, e.g. "[main ↑2↓1 *]": live status of the project path
+	// when known, else the branch a worktree session was created on
+	gitBadge := ""
+	branch, marks := row.branch, row.marks
+	if !row.gitKnown {
+		branch = row.worktreeBranch
+	}
+	if branch != "" {
+		branch = runewidth.Truncate(branch, 15, "...")
+		if marks != "" {
+			branch += " " + marks
 		}
-		worktreeBadge = wtStyle.Render(" [" + branch + "]")
+		gitBadge = gitStyle.Render(" [" + branch + "]")
 	}
 
-	// Build row: [baseIndent][selection][tree][status] [title] [tool] [yolo] [worktree]
+	// Build row: [baseIndent][selection][tree][num] [status] [title] [tool][model] [yolo] [queue] [restarts] [git]
 	// Format: " ├─ ● session-name tool" or "▶└─ ● session-name tool"
 	// Sub-sessions get extra indent: "   ├─◐ sub-session tool"
-	row := fmt.Sprintf("%s%s%s %s %s%s%s%s", baseIndent, selectionPrefix, treeStyle.Render(treeConnector), status, title, tool, yoloBadge, worktreeBadge)
-	b.WriteString(row)
+	line := fmt.Sprintf("%s%s%s%s %s %s%s%s%s%s%s%s", baseIndent, selectionPrefix, treeStyle.Render(treeConnector), numStr, status, title, tool, modelBadge, yoloBadge, queueBadge, restartBadge, gitBadge)
+	b.WriteString(line)
 	b.WriteString("\n")
+	if row.density == DensityDetailed {
+		h.renderSessionDetail(b, row, baseIndent)
+	}
 }
 
 // renderLaunchingState renders the animated launching/resuming indicator for sessions
@@ -7343,6 +8965,8 @@ func (h *Home) renderSessionInfoCard(inst *session.Instance, width, height int)
 		statusColor = ColorGreen
 	case session.StatusWaiting:
 		statusColor = ColorYellow
+	case session.StatusNeedsInput:
+		statusColor = ColorOrange
 	case session.StatusError:
 		statusColor = ColorRed
 	default:
@@ -7421,25 +9045,62 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	case session.StatusWaiting:
 		statusIcon = "◐"
 		statusColor = ColorYellow
+	case session.StatusNeedsInput:
+		statusIcon = "◆"
+		statusColor = ColorOrange
 	case session.StatusError:
 		statusIcon = "✕"
 		statusColor = ColorRed
 	}
+	statusLabel := string(selected.Status)
+	dead := selected.Status == session.StatusError && selected.IsDead()
+	if dead {
+		statusIcon, statusLabel = deadIcon, "dead"
+	}
 
 	// Header with session name and status
-	statusBadge := lipgloss.NewStyle().Foreground(statusColor).Render(statusIcon + " " + string(selected.Status))
+	statusBadge := lipgloss.NewStyle().Foreground(statusColor).Render(statusIcon + " " + statusLabel)
 	nameStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
 	b.WriteString(nameStyle.Render(selected.Title))
 	b.WriteString("  ")
 	b.WriteString(statusBadge)
 	b.WriteString("\n")
 
+	// Error with a known cause (agent output, agent exited): say what it was
+	errorReason := selected.GetErrorReasonThreadSafe()
+	if selected.Status == session.StatusError && errorReason != "" {
+		reasonStyle := lipgloss.NewStyle().Foreground(ColorRed)
+		b.WriteString(reasonStyle.Render(runewidth.Truncate(errorReason, width-4, "...")))
+		b.WriteString("\n")
+	}
+
 	// Info lines: path and activity time
 	infoStyle := lipgloss.NewStyle().Foreground(ColorText)
 	pathStr := truncatePath(selected.ProjectPath, width-4)
 	b.WriteString(infoStyle.Render("📁 " + pathStr))
 	b.WriteString("\n")
 
+	// Git branch, upstream distance and uncommitted changes (lazily cached)
+	if st, ok := h.cachedGitStatus(selected.ProjectPath); ok {
+		branch := st.Branch
+		if branch == "" {
+			branch = "detached HEAD"
+		}
+		gitLine := "⎇ " + branch
+		if marks := formatGitMarks(st, " "); marks != "" {
+			gitLine += " " + marks
+		}
+		gitStyle := lipgloss.NewStyle().Foreground(ColorText)
+		if st.Dirty() {
+			gitLine += fmt.Sprintf(" · %d uncommitted", st.Changed)
+			gitStyle = lipgloss.NewStyle().Foreground(ColorYellow)
+		} else {
+			gitLine += " · clean"
+		}
+		b.WriteString(gitStyle.Render(runewidth.Truncate(gitLine, width-4, "...")))
+		b.WriteString("\n")
+	}
+
 	// Activity time - shows when session was last active
 	activityTime := selected.GetLastActivityTime()
 	activityStr := formatRelativeTime(activityTime)
@@ -7449,11 +9110,33 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	b.WriteString(infoStyle.Render("⏱ " + activityStr))
 	b.WriteString("\n")
 
+	// Recent status changes, e.g. "running 12m → waiting 3m ago"
+	if history := session.FormatStatusHistory(selected.GetStatusHistory(), 3, time.Now()); history != "" {
+		b.WriteString(infoStyle.Render(runewidth.Truncate("⇄ "+history, width-4, "...")))
+		b.WriteString("\n")
+	}
+
+	// Auto-restart policy and how much of it the current crash streak used
+	if selected.AutoRestartMax > 0 {
+		restartLine := fmt.Sprintf("↻ auto-restart: %d/%d used", selected.AutoRestartCount(), selected.AutoRestartMax)
+		restartStyle := infoStyle
+		if selected.AutoRestartExhausted() {
+			restartLine += " · gave up"
+			restartStyle = lipgloss.NewStyle().Foreground(ColorRed)
+		}
+		b.WriteString(restartStyle.Render(restartLine))
+		b.WriteString("\n")
+	}
+
+	toolLabel := selected.Tool
+	if model := session.ShortModelName(selected.GetDetectedModelThreadSafe()); model != "" {
+		toolLabel += " · " + model
+	}
 	toolBadge := lipgloss.NewStyle().
 		Foreground(ColorBg).
 		Background(ColorPurple).
 		Padding(0, 1).
-		Render(selected.Tool)
+		Render(toolLabel)
 	groupBadge := lipgloss.NewStyle().
 		Foreground(ColorBg).
 		Background(ColorCyan).
@@ -7462,8 +9145,37 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	b.WriteString(toolBadge)
 	b.WriteString(" ")
 	b.WriteString(groupBadge)
+	tagStyle := lipgloss.NewStyle().Foreground(ColorPurple)
+	for _, tag := range selected.Tags {
+		b.WriteString(" ")
+		b.WriteString(tagStyle.Render("#" + tag))
+	}
+	if selected.NotifyMuted {
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorText).Render("🔕 muted"))
+	} else if h.sessions.Tree().IsMuted(selected.GroupPath) {
+		b.WriteString(" ")
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorText).Render("🔕 group muted"))
+	}
 	b.WriteString("\n")
 
+	// User notes (set with "e" or `session set <id> notes`)
+	if selected.Notes != "" {
+		notesStyle := lipgloss.NewStyle().Foreground(ColorYellow).Italic(true)
+		noteLines := strings.Split(selected.Notes, "\n")
+		if len(noteLines) > maxPreviewNoteLines {
+			noteLines = append(noteLines[:maxPreviewNoteLines-1], "...")
+		}
+		for i, line := range noteLines {
+			prefix := "📝 "
+			if i > 0 {
+				prefix = "   "
+			}
+			b.WriteString(notesStyle.Render(prefix + runewidth.Truncate(line, max(width-7, 10), "...")))
+			b.WriteString("\n")
+		}
+	}
+
 	// Worktree info section (for sessions running in git worktrees)
 	if selected.IsWorktree() {
 		wtHeader := renderSectionDivider("Worktree", width-4)
@@ -7858,9 +9570,14 @@ func (h *Home) renderPreviewPane(width, height int) string {
 
 	b.WriteString("\n")
 
-	// Special handling for error state - show guidance instead of output
-	if selected.Status == session.StatusError {
-		errorHeader := renderSectionDivider("Session Inactive", width-4)
+	// Special handling for error state - show guidance instead of output.
+	// Errors with a known cause keep the output visible.
+	if selected.Status == session.StatusError && errorReason == "" {
+		header, warning := "Session Inactive", "⚠ No tmux session running"
+		if dead {
+			header, warning = "Session Killed", "⚠ tmux session was killed outside agent-deck"
+		}
+		errorHeader := renderSectionDivider(header, width-4)
 		b.WriteString(errorHeader)
 		b.WriteString("\n\n")
 
@@ -7869,19 +9586,25 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		dimStyle := lipgloss.NewStyle().Foreground(ColorText)
 		keyStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
 
-		b.WriteString(warnStyle.Render("⚠ No tmux session running"))
-		b.WriteString("\n\n")
-		b.WriteString(dimStyle.Render("This can happen if:"))
-		b.WriteString("\n")
-		b.WriteString(dimStyle.Render("  • Session was added but not yet started"))
-		b.WriteString("\n")
-		b.WriteString(dimStyle.Render("  • tmux server was restarted"))
-		b.WriteString("\n")
-		b.WriteString(dimStyle.Render("  • Terminal was closed or system rebooted"))
+		b.WriteString(warnStyle.Render(warning))
 		b.WriteString("\n\n")
-		b.WriteString(dimStyle.Render("Actions:"))
-		b.WriteString("\n")
-		b.WriteString("  ")
+		if dead {
+			b.WriteString(dimStyle.Render("It was running until tmux kill-session, a crash"))
+			b.WriteString("\n")
+			b.WriteString(dimStyle.Render("or the tmux server going away ended it."))
+		} else {
+			b.WriteString(dimStyle.Render("This can happen if:"))
+			b.WriteString("\n")
+			b.WriteString(dimStyle.Render("  • Session was added but not yet started"))
+			b.WriteString("\n")
+			b.WriteString(dimStyle.Render("  • tmux server was restarted"))
+			b.WriteString("\n")
+			b.WriteString(dimStyle.Render("  • Terminal was closed or system rebooted"))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(dimStyle.Render("Actions:"))
+		b.WriteString("\n")
+		b.WriteString("  ")
 		b.WriteString(keyStyle.Render("R"))
 		b.WriteString(dimStyle.Render(" Start   - create and start tmux session"))
 		b.WriteString("\n")
@@ -7891,7 +9614,7 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		b.WriteString("\n")
 		b.WriteString("  ")
 		b.WriteString(keyStyle.Render("Enter"))
-		b.WriteString(dimStyle.Render(" - attach (will auto-start)"))
+		b.WriteString(dimStyle.Render(" - restart or remove"))
 		b.WriteString("\n")
 
 		// Pad output to exact height to prevent layout shifts
@@ -7925,6 +9648,9 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	case PreviewModeAnalytics:
 		// showAnalytics keeps its default value (only available for Claude/Gemini)
 		showOutput = false
+	case PreviewModeDiff:
+		showAnalytics = false
+		showOutput = false
 		// PreviewModeBoth: use config settings (default)
 	}
 
@@ -7964,7 +9690,10 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	// (We want to show the launch animation even if output is normally disabled)
 	if !showOutput && !isStartingUp {
 		// If analytics was also not shown, display session info card as fallback
-		if !showAnalytics {
+		if h.previewMode == PreviewModeDiff {
+			used := strings.Count(b.String(), "\n")
+			b.WriteString(h.renderDiffSection(selected, width, height-used-1))
+		} else if !showAnalytics {
 			infoCard := h.renderSessionInfoCard(selected, width, height)
 			b.WriteString("\n")
 			b.WriteString(infoCard)
@@ -8027,12 +9756,13 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			// STATUS-BASED CHECK: Session ready when Running/Waiting/Idle
 			sessionReady := selected.Status == session.StatusRunning ||
 				selected.Status == session.StatusWaiting ||
+				selected.Status == session.StatusNeedsInput ||
 				selected.Status == session.StatusIdle
 
 			if !sessionReady {
 				// Also check content for faster detection
 				h.previewCacheMu.RLock()
-				previewContent := h.previewCache[selected.ID]
+				previewContent := tmux.StripANSI(h.previewCache[selected.ID])
 				h.previewCacheMu.RUnlock()
 
 				if selected.Tool == "claude" || selected.Tool == "gemini" {
@@ -8113,7 +9843,7 @@ func (h *Home) renderPreviewPane(width, height int) string {
 		// Strip trailing empty lines BEFORE truncation
 		// This ensures we show actual content, not empty trailing lines when space is limited
 		// (Terminal output often ends with empty lines at cursor position)
-		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		for len(lines) > 0 && strings.TrimSpace(tmux.StripANSI(lines[len(lines)-1])) == "" {
 			lines = lines[:len(lines)-1]
 		}
 
@@ -8132,68 +9862,73 @@ func (h *Home) renderPreviewPane(width, height int) string {
 			maxLines = 1
 		}
 
-		// Track if we're truncating from the top (for indicator)
-		truncatedFromTop := len(lines) > maxLines
-		truncatedCount := 0
-		if truncatedFromTop {
-			// Reserve one line for the truncation indicator
-			maxLines--
-			if maxLines < 1 {
-				maxLines = 1
+		if !h.previewPlain {
+			// Keep the agent's colors and blank lines, wrapping long lines
+			b.WriteString(renderStyledPreview(lines, maxLines+1, max(width-4, 10)))
+		} else {
+			// Track if we're truncating from the top (for indicator)
+			truncatedFromTop := len(lines) > maxLines
+			truncatedCount := 0
+			if truncatedFromTop {
+				// Reserve one line for the truncation indicator
+				maxLines--
+				if maxLines < 1 {
+					maxLines = 1
+				}
+				truncatedCount = len(lines) - maxLines
+				lines = lines[len(lines)-maxLines:]
 			}
-			truncatedCount = len(lines) - maxLines
-			lines = lines[len(lines)-maxLines:]
-		}
 
-		previewStyle := lipgloss.NewStyle().Foreground(ColorText)
-		maxWidth := width - 4
-		if maxWidth < 10 {
-			maxWidth = 10
-		}
+			previewStyle := lipgloss.NewStyle().Foreground(ColorText)
+			maxWidth := width - 4
+			if maxWidth < 10 {
+				maxWidth = 10
+			}
 
-		// Show truncation indicator if content was cut from top
-		if truncatedFromTop {
-			truncIndicator := lipgloss.NewStyle().
-				Foreground(ColorText).
-				Italic(true).
-				Render(fmt.Sprintf("⋮ %d more lines above", truncatedCount))
-			b.WriteString(truncIndicator)
-			b.WriteString("\n")
-		}
+			// Show truncation indicator if content was cut from top
+			if truncatedFromTop {
+				truncIndicator := lipgloss.NewStyle().
+					Foreground(ColorText).
+					Italic(true).
+					Render(fmt.Sprintf("⋮ %d more lines above", truncatedCount))
+				b.WriteString(truncIndicator)
+				b.WriteString("\n")
+			}
 
-		// Track consecutive empty lines to preserve some spacing
-		consecutiveEmpty := 0
-		const maxConsecutiveEmpty = 2 // Allow up to 2 consecutive empty lines
+			// Track consecutive empty lines to preserve some spacing
+			consecutiveEmpty := 0
+			const maxConsecutiveEmpty = 2 // Allow up to 2 consecutive empty lines
 
-		for _, line := range lines {
-			// Strip ANSI codes for accurate width measurement
-			cleanLine := tmux.StripANSI(line)
+			for _, line := range lines {
+				// Strip ANSI codes for accurate width measurement
+				cleanLine := tmux.StripANSI(line)
 
-			// Strip control characters (\r, \b, etc.) that can corrupt terminal
-			// rendering. tmux capture-pane output may contain carriage returns
-			// which, inside JoinHorizontal, move the cursor to column 0 and
-			// overwrite the left panel content on that line.
-			cleanLine = stripControlChars(cleanLine)
+				// Strip control characters (\r, \b, etc.) that can corrupt terminal
+				// rendering. tmux capture-pane output may contain carriage returns
+				// which, inside JoinHorizontal, move the cursor to column 0 and
+				// overwrite the left panel content on that line.
+				cleanLine = stripControlChars(cleanLine)
 
-			// Handle empty lines - preserve some for readability
-			trimmed := strings.TrimSpace(cleanLine)
-			if trimmed == "" {
-				consecutiveEmpty++
-				if consecutiveEmpty <= maxConsecutiveEmpty {
-					b.WriteString("\n") // Preserve empty line
+				// Handle empty lines - preserve some for readability
+				trimmed := strings.TrimSpace(cleanLine)
+				if trimmed == "" {
+					consecutiveEmpty++
+					if consecutiveEmpty <= maxConsecutiveEmpty {
+						b.WriteString("\n") // Preserve empty line
+					}
+					continue
 				}
-				continue
-			}
-			consecutiveEmpty = 0 // Reset counter on non-empty line
+				consecutiveEmpty = 0 // Reset counter on non-empty line
 
-			// Truncate based on display width (handles CJK, emoji correctly)
-			displayWidth := runewidth.StringWidth(cleanLine)
-			if displayWidth > maxWidth {
-				cleanLine = runewidth.Truncate(cleanLine, maxWidth-3, "...")
-			}
+				// Truncate based on display width (handles CJK, emoji correctly)
+				displayWidth := runewidth.StringWidth(cleanLine)
+				if displayWidth > maxWidth {
+					cleanLine = runewidth.Truncate(cleanLine, maxWidth-3, "...")
+				}
 
-			b.WriteString(previewStyle.Render(cleanLine))
-			b.WriteString("\n")
+				b.WriteString(previewStyle.Render(cleanLine))
+				b.WriteString("\n")
+			}
 		}
 	}
 
@@ -8225,6 +9960,71 @@ func (h *Home) renderPreviewPane(width, height int) string {
 	return strings.Join(truncatedLines, "\n")
 }
 
+// renderStyledPreview renders captured pane lines with their colors, wrapping
+// lines wider than width and keeping blank lines. Only the last maxLines rows
+// are shown, the first of them replaced by a count of the rows above when
+// output is cut.
+func renderStyledPreview(lines []string, maxLines, width int) string {
+	var rows []string
+	for _, line := range lines {
+		// Keep colors but drop titles, hyperlinks and cursor movement, which
+		// would otherwise act on the real terminal
+		line = stripControlCharsKeepEscapes(termesc.KeepSGR(strings.ReplaceAll(line, "\t", "    ")))
+		// Carry colors onto wrapped rows, and end every row reset so colors
+		// never bleed into the next panel
+		carry := ""
+		for _, row := range strings.Split(ansi.Hardwrap(line, width, true), "\n") {
+			rows = append(rows, carry+row+"\x1b[0m")
+			carry = activeSGR(carry + row)
+		}
+	}
+
+	if len(rows) > maxLines {
+		cut := len(rows) - max(maxLines-1, 1)
+		rows = rows[cut:]
+		indicator := lipgloss.NewStyle().
+			Foreground(ColorText).
+			Italic(true).
+			Render(fmt.Sprintf("⋮ %d more lines above", cut))
+		rows = append([]string{indicator}, rows...)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// activeSGR returns the SGR sequences (colors, bold, ...) still in effect at
+// the end of s, since its last reset.
+func activeSGR(s string) string {
+	var active strings.Builder
+	for i := 0; i < len(s); {
+		kind, n := termesc.Next(s[i:])
+		if kind == termesc.KindNone {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			continue
+		}
+		if kind == termesc.KindSGR {
+			if seq := s[i : i+n]; seq == "\x1b[m" || seq == "\x1b[0m" {
+				active.Reset()
+			} else {
+				active.WriteString(seq)
+			}
+		}
+		i += n
+	}
+	return active.String()
+}
+
+// stripControlCharsKeepEscapes is stripControlChars that keeps ESC, so SGR
+// color sequences survive.
+func stripControlCharsKeepEscapes(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\n' && r != '\t' && r != 0x1b {
+			return -1
+		}
+		return r
+	}, s)
+}
+
 // stripControlChars removes C0 control characters (except \n and \t) from a string.
 // tmux capture-pane output may include \r, \b, and other control characters that
 // corrupt terminal rendering when embedded inside styled TUI output (e.g. \r moves
@@ -8247,16 +10047,27 @@ func truncatePath(path string, maxLen int) string {
 	if maxLen < 10 {
 		maxLen = 10
 	}
-	// Show beginning and end: /Users/.../project
-	// Use rune-based slicing for proper Unicode handling
-	runes := []rune(path)
+	if pathWidth <= maxLen {
+		return path
+	}
+	// Show beginning and end: /Users/.../project, measured in columns so
+	// wide characters neither overflow nor get split
 	startLen := maxLen / 3
 	endLen := maxLen*2/3 - 3
-	if startLen+endLen+3 > len(runes) {
-		// Path is short in runes but wide in display - use simple truncation
-		return runewidth.Truncate(path, maxLen-3, "...")
+	return runewidth.Truncate(path, startLen, "") + "..." + runewidth.TruncateLeft(path, pathWidth-endLen, "")
+}
+
+// truncateStart cuts s from the front to fit width columns, marking the cut
+// with "...". For paths and other text whose end matters most.
+func truncateStart(s string, width int) string {
+	sw := runewidth.StringWidth(s)
+	if sw <= width {
+		return s
+	}
+	if width <= 3 {
+		return runewidth.Truncate("...", max(width, 0), "")
 	}
-	return string(runes[:startLen]) + "..." + string(runes[len(runes)-endLen:])
+	return runewidth.TruncateLeft(s, sw-(width-3), "...")
 }
 
 // formatRelativeTime formats a time as a human-readable relative string
@@ -8311,11 +10122,13 @@ func (h *Home) renderGroupPreview(group *session.Group, width, height int) strin
 	b.WriteString("\n\n")
 
 	// Status breakdown with inline badges
-	running, waiting, idle, errored := 0, 0, 0, 0
+	running, needsInput, waiting, idle, errored := 0, 0, 0, 0, 0
 	for _, sess := range group.Sessions {
 		switch sess.Status {
 		case session.StatusRunning:
 			running++
+		case session.StatusNeedsInput:
+			needsInput++
 		case session.StatusWaiting:
 			waiting++
 		case session.StatusIdle:
@@ -8330,6 +10143,9 @@ func (h *Home) renderGroupPreview(group *session.Group, width, height int) strin
 	if running > 0 {
 		statuses = append(statuses, lipgloss.NewStyle().Foreground(ColorGreen).Render(fmt.Sprintf("● %d running", running)))
 	}
+	if needsInput > 0 {
+		statuses = append(statuses, lipgloss.NewStyle().Foreground(ColorOrange).Render(fmt.Sprintf("◆ %d needs input", needsInput)))
+	}
 	if waiting > 0 {
 		statuses = append(statuses, lipgloss.NewStyle().Foreground(ColorYellow).Render(fmt.Sprintf("◐ %d waiting", waiting)))
 	}
@@ -8408,6 +10224,8 @@ func (h *Home) renderGroupPreview(group *session.Group, width, height int) strin
 				statusIcon, statusColor = "●", ColorGreen
 			case session.StatusWaiting:
 				statusIcon, statusColor = "◐", ColorYellow
+			case session.StatusNeedsInput:
+				statusIcon, statusColor = "◆", ColorOrange
 			case session.StatusError:
 				statusIcon, statusColor = "✕", ColorRed
 			}
@@ -8460,6 +10278,205 @@ type groupWorktreeInfo struct {
 	branches []groupWorktreeBranch
 }
 
+// refreshGitStatus returns a tea.Cmd that re-reads the git status of listed
+// sessions' project paths whose cached status is older than gitStatusTTL.
+// Returns nil when nothing is stale or a refresh is already running.
+func (h *Home) refreshGitStatus() tea.Cmd {
+	if h.gitStatusFetching {
+		return nil
+	}
+
+	var paths []string
+	seen := make(map[string]bool)
+	h.gitStatusMu.Lock()
+	for _, item := range h.flatItems {
+		if item.Type != session.ItemTypeSession || item.Session == nil {
+			continue
+		}
+		path := item.Session.ProjectPath
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		if entry, ok := h.gitStatusCache[path]; ok && time.Since(entry.checkedAt) < gitStatusTTL {
+			continue
+		}
+		paths = append(paths, path)
+		if len(paths) == gitStatusBatch {
+			break
+		}
+	}
+	h.gitStatusMu.Unlock()
+	if len(paths) == 0 {
+		return nil
+	}
+
+	h.gitStatusFetching = true
+	return func() tea.Msg {
+		statuses := make(map[string]gitStatusEntry, len(paths))
+		for _, path := range paths {
+			st, err := git.GetStatus(path)
+			statuses[path] = gitStatusEntry{status: st, ok: err == nil, checkedAt: time.Now()}
+		}
+		return gitStatusMsg{statuses: statuses}
+	}
+}
+
+// fetchDiffStat returns a tea.Cmd that reads the diff summary of the
+// session's working directory for the diff preview tab.
+func (h *Home) fetchDiffStat(inst *session.Instance) tea.Cmd {
+	id, path := inst.ID, inst.ProjectPath
+	return func() tea.Msg {
+		entry := diffStatEntry{sessionID: id, checkedAt: time.Now()}
+		if !git.IsGitRepo(path) {
+			entry.err = fmt.Errorf("not a git repository")
+			return diffStatMsg(entry)
+		}
+		entry.files, entry.err = git.DiffStat(path)
+		if entry.err == nil {
+			entry.untracked, _ = git.UntrackedFiles(path)
+		}
+		return diffStatMsg(entry)
+	}
+}
+
+// loadDiff returns a tea.Cmd that reads the full diff of the session's
+// working directory, including a list of untracked files, for the diff
+// viewer.
+func (h *Home) loadDiff(inst *session.Instance) tea.Cmd {
+	title, path := inst.Title, inst.ProjectPath
+	return func() tea.Msg {
+		if !git.IsGitRepo(path) {
+			return diffLoadedMsg{err: fmt.Errorf("%s is not a git repository", path)}
+		}
+		diff, err := git.Diff(path)
+		if err != nil {
+			return diffLoadedMsg{err: err}
+		}
+		if untracked, err := git.UntrackedFiles(path); err == nil && len(untracked) > 0 {
+			diff += "\nUntracked files:\n  " + strings.Join(untracked, "\n  ") + "\n"
+		}
+		return diffLoadedMsg{title: title, path: path, diff: diff}
+	}
+}
+
+// renderDiffSection renders the diff preview tab: each changed file with a
+// +/- bar scaled to the largest change, then untracked files, in at most
+// maxLines lines.
+func (h *Home) renderDiffSection(selected *session.Instance, width, maxLines int) string {
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	textStyle := lipgloss.NewStyle().Foreground(ColorText)
+	addStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+	delStyle := lipgloss.NewStyle().Foreground(ColorRed)
+	hintStyle := lipgloss.NewStyle().Foreground(ColorText).Italic(true)
+	keyStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+
+	var lines []string
+	lines = append(lines, renderSectionDivider("Diff", width-4))
+
+	entry := h.diffStat
+	switch {
+	case entry.sessionID != selected.ID:
+		lines = append(lines, dimStyle.Italic(true).Render("Loading diff..."))
+	case entry.err != nil:
+		msg := strings.SplitN(entry.err.Error(), "\n", 2)[0]
+		lines = append(lines, dimStyle.Render(runewidth.Truncate(msg, width-4, "...")))
+	case len(entry.files) == 0 && len(entry.untracked) == 0:
+		lines = append(lines, dimStyle.Render("No uncommitted changes"))
+	default:
+		var added, deleted, maxTotal, countWidth int
+		for _, f := range entry.files {
+			added += f.Added
+			deleted += f.Deleted
+			maxTotal = max(maxTotal, f.Added+f.Deleted)
+		}
+		countWidth = len(fmt.Sprint(maxTotal))
+		barWidth := min(20, max((width-4)/4, 5))
+		pathWidth := max(width-4-countWidth-barWidth-4, 10)
+
+		var body []string
+		for _, f := range entry.files {
+			row := textStyle.Render(runewidth.FillRight(truncatePath(f.Path, pathWidth), pathWidth)) + " "
+			if f.Binary {
+				body = append(body, row+dimStyle.Render("binary"))
+				continue
+			}
+			plus, minus := f.Added, f.Deleted
+			if maxTotal > barWidth {
+				plus = scaleDiffBar(f.Added, maxTotal, barWidth)
+				minus = scaleDiffBar(f.Deleted, maxTotal, barWidth)
+			}
+			row += textStyle.Render(fmt.Sprintf("%*d ", countWidth, f.Added+f.Deleted))
+			row += addStyle.Render(strings.Repeat("+", plus)) + delStyle.Render(strings.Repeat("-", minus))
+			body = append(body, row)
+		}
+		for _, path := range entry.untracked {
+			body = append(body, addStyle.Render(runewidth.FillRight(truncatePath(path, pathWidth), pathWidth))+" "+dimStyle.Render("new"))
+		}
+
+		summary := fmt.Sprintf("%d files changed, ", len(entry.files))
+		if len(entry.files) == 1 {
+			summary = "1 file changed, "
+		}
+		summary = textStyle.Render(summary) + addStyle.Render(fmt.Sprintf("+%d", added)) + " " + delStyle.Render(fmt.Sprintf("-%d", deleted))
+		if n := len(entry.untracked); n > 0 {
+			summary += textStyle.Render(fmt.Sprintf(", %d untracked", n))
+		}
+
+		// Divider, summary, blank and hint lines are always shown
+		room := max(maxLines-4, 1)
+		if len(body) > room {
+			extra := len(body) - room + 1
+			body = append(body[:room-1], dimStyle.Render(fmt.Sprintf("… %d more", extra)))
+		}
+		lines = append(lines, body...)
+		lines = append(lines, summary)
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, hintStyle.Render("Full diff: ")+keyStyle.Render(h.keyHint(ActionDiff)))
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// scaleDiffBar scales a line count to a bar of at most width characters,
+// keeping at least one character for any change.
+func scaleDiffBar(n, maxTotal, width int) int {
+	if n == 0 {
+		return 0
+	}
+	return max(n*width/maxTotal, 1)
+}
+
+// cachedGitStatus returns the last git status read for path, if path is a
+// git repository.
+func (h *Home) cachedGitStatus(path string) (git.Status, bool) {
+	h.gitStatusMu.Lock()
+	defer h.gitStatusMu.Unlock()
+	entry, ok := h.gitStatusCache[path]
+	return entry.status, ok && entry.ok
+}
+
+// formatGitMarks renders the upstream distance and dirty marker, e.g.
+// "↑2↓1 *" (sep "") or "↑2 ↓1 *" (sep " "). Empty for a clean tree that is
+// in sync.
+func formatGitMarks(st git.Status, sep string) string {
+	var parts []string
+	if st.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", st.Ahead))
+	}
+	if st.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", st.Behind))
+	}
+	marks := strings.Join(parts, sep)
+	if st.Dirty() {
+		if marks != "" {
+			marks += " "
+		}
+		marks += "*"
+	}
+	return marks
+}
+
 // getGroupWorktreeInfo returns worktree summary if all sessions in the group
 // share the same repo root and at least one is a worktree. Returns nil otherwise.
 func (h *Home) getGroupWorktreeInfo(group *session.Group) *groupWorktreeInfo {
@@ -8526,6 +10543,17 @@ func (h *Home) copySessionOutput(inst *session.Instance) tea.Cmd {
 	}
 }
 
+// loadTranscript returns a tea.Cmd that parses the session's transcript
+// file for the transcript viewer. Claude transcripts can be large, so this
+// runs off the UI goroutine.
+func (h *Home) loadTranscript(inst *session.Instance) tea.Cmd {
+	title, tool := inst.Title, inst.GetToolThreadSafe()
+	return func() tea.Msg {
+		messages, err := inst.ReadTranscript()
+		return transcriptLoadedMsg{title: title, tool: tool, messages: messages, err: err}
+	}
+}
+
 // sendOutputToSession returns a tea.Cmd that sends the source session's output to the target.
 func (h *Home) sendOutputToSession(source, target *session.Instance) tea.Cmd {
 	return func() tea.Msg {
@@ -8554,7 +10582,8 @@ func (h *Home) sendOutputToSession(source, target *session.Instance) tea.Cmd {
 			}
 		}
 
-		if err := tmuxSession.SendKeysChunked(wrapped); err != nil {
+		// Paste as one block so the agent doesn't see each line as a submit
+		if err := tmuxSession.SendText(wrapped, tmux.SendBracketedPaste); err != nil {
 			return sendOutputResultMsg{
 				targetTitle: target.Title,
 				err:         fmt.Errorf("send failed: %w", err),
@@ -8590,6 +10619,476 @@ func (h *Home) handleSessionPickerDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 	}
 }
 
+// handleProfilePickerDialogKey handles key events when the profile picker is visible.
+// Choosing another profile quits the TUI; main relaunches it on that profile.
+func (h *Home) handleProfilePickerDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		selected := h.profilePickerDialog.GetSelected()
+		h.profilePickerDialog.Hide()
+		if selected == "" || selected == h.profile {
+			return h, nil
+		}
+		h.switchProfile = selected
+		// Leave the MCP pool running: the relaunched TUI reconnects to it
+		return h, h.performQuit(false)
+	case "esc":
+		h.profilePickerDialog.Hide()
+		return h, nil
+	default:
+		h.profilePickerDialog.Update(msg)
+		return h, nil
+	}
+}
+
+// handleArchiveDialogKey handles key events when the archive dialog is visible.
+func (h *Home) handleArchiveDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if h.archiveDialog.IsViewingTranscript() {
+		h.archiveDialog.Update(msg)
+		return h, nil
+	}
+
+	selected := h.archiveDialog.GetSelected()
+	switch msg.String() {
+	case "enter":
+		if selected == nil {
+			return h, nil
+		}
+		if h.sessions.Get(selected.ID) != nil {
+			h.toast(ToastWarning, "session '%s' already exists", selected.Title)
+			return h, nil
+		}
+		h.archiveDialog.Hide()
+		return h, h.restoreArchivedSession(selected.ID)
+	case "v":
+		if selected == nil {
+			return h, nil
+		}
+		full, err := h.storage.GetArchived(selected.ID)
+		if err != nil {
+			h.setError(err)
+			return h, nil
+		}
+		h.archiveDialog.ShowTranscript(full)
+		return h, nil
+	case "d":
+		if selected == nil {
+			return h, nil
+		}
+		if !h.archiveDialog.ConfirmingDelete() {
+			h.archiveDialog.SetConfirmDelete(true)
+			return h, nil
+		}
+		if err := h.storage.DeleteArchived(selected.ID); err != nil {
+			h.setError(err)
+			return h, nil
+		}
+		h.archiveDialog.Remove(selected.ID)
+		return h, nil
+	default:
+		h.archiveDialog.Update(msg)
+		return h, nil
+	}
+}
+
+// handleResumeDialogKey handles key events when the resume dialog is visible.
+func (h *Home) handleResumeDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() != "enter" {
+		h.resumeDialog.Update(msg)
+		return h, nil
+	}
+	selected := h.resumeDialog.GetSelected()
+	if selected == nil {
+		return h, nil
+	}
+	projectPath, groupPath := h.resumeDialog.ProjectPath(), h.resumeDialog.GroupPath()
+	h.resumeDialog.Hide()
+
+	// Two Claude processes on one transcript would interleave it: jump to
+	// the session that already has the conversation open instead
+	for _, inst := range h.sessions.All() {
+		if inst.Tool == "claude" && inst.ClaudeSessionID == selected.SessionID {
+			h.jumpToSession(inst)
+			return h, nil
+		}
+	}
+
+	title := runewidth.Truncate(selected.Summary, 32, "…")
+	if title == "" {
+		title = filepath.Base(projectPath)
+	}
+	return h, h.resumeClaudeSession(title, projectPath, groupPath, selected.SessionID)
+}
+
+// handleForkTreeDialogKey handles key events when the fork tree dialog is
+// visible.
+func (h *Home) handleForkTreeDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() != "enter" {
+		h.forkTreeDialog.Update(msg)
+		return h, nil
+	}
+	selected := h.forkTreeDialog.GetSelected()
+	h.forkTreeDialog.Hide()
+	if selected != nil {
+		h.jumpToSession(selected)
+	}
+	return h, nil
+}
+
+// handleCleanupDialogKey handles key events when the cleanup dialog is
+// visible. Enter archives or kills the checked sessions.
+func (h *Home) handleCleanupDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() != "enter" {
+		h.cleanupDialog.Update(msg)
+		return h, nil
+	}
+	checked, action := h.cleanupDialog.GetChecked(), h.cleanupDialog.Action()
+	h.cleanupDialog.Hide()
+	if len(checked) == 0 {
+		return h, nil
+	}
+	h.cleanupNoticed = 0
+	if action == "kill" {
+		return h, h.killIdleSessions(checked)
+	}
+	cmds := make([]tea.Cmd, len(checked))
+	for i, inst := range checked {
+		cmds[i] = h.archiveSession(inst)
+	}
+	return h, tea.Batch(cmds...)
+}
+
+// handleImportDialogKey handles key events when the import dialog is
+// visible. Enter adopts the checked sessions into the chosen group, skipping
+// any imported since the dialog opened.
+func (h *Home) handleImportDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() != "enter" {
+		h.importDialog.Update(msg)
+		return h, nil
+	}
+	checked, group := h.importDialog.GetChecked(), h.importDialog.TargetGroup()
+	h.importDialog.Hide()
+
+	tracked := make(map[string]bool)
+	for _, inst := range h.sessions.All() {
+		if tmuxSess := inst.GetTmuxSession(); tmuxSess != nil {
+			tracked[tmuxSess.Name] = true
+		}
+	}
+	var adopted []*session.Instance
+	for _, inst := range checked {
+		tmuxSess := inst.GetTmuxSession()
+		if tmuxSess == nil || tracked[tmuxSess.Name] {
+			continue
+		}
+		tracked[tmuxSess.Name] = true
+		if group != "" {
+			inst.GroupPath = group
+		}
+		adopted = append(adopted, inst)
+	}
+	if len(adopted) == 0 {
+		return h, nil
+	}
+
+	h.sessions.Add(adopted...)
+	h.cachedStatusCounts.valid.Store(false)
+	h.rebuildFlatItems()
+	h.search.SetItems(h.sessions.All())
+	// Save both instances AND groups (critical fix: was losing groups!)
+	h.saveInstances()
+	h.toast(ToastSuccess, "Imported %d tmux sessions", len(adopted))
+
+	return h, func() tea.Msg {
+		// Enable mouse mode for proper scrolling in imported sessions
+		// Ignore errors - non-fatal, older tmux versions may not support all options
+		for _, inst := range adopted {
+			_ = inst.GetTmuxSession().EnableMouseMode()
+		}
+		return nil
+	}
+}
+
+// handleRecentDialogKey handles key events when the recent sessions dialog
+// is visible. Enter attaches the selected session; space only selects it in
+// the list.
+func (h *Home) handleRecentDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key != "enter" && key != " " {
+		h.recentDialog.Update(msg)
+		return h, nil
+	}
+	selected := h.recentDialog.Selected()
+	h.recentDialog.Hide()
+	if selected == nil {
+		return h, nil
+	}
+	h.jumpToSession(selected)
+	if key == " " {
+		return h, nil
+	}
+	return h, h.attachIfReady(selected)
+}
+
+// idleSessionsKilledMsg reports the result of the "kill" cleanup action
+type idleSessionsKilledMsg struct {
+	killed int
+	errs   []error
+}
+
+// killIdleSessions stops the tmux sessions of idle sessions, keeping them in
+// the list so they can be restarted later.
+func (h *Home) killIdleSessions(instances []*session.Instance) tea.Cmd {
+	return func() tea.Msg {
+		var msg idleSessionsKilledMsg
+		for _, inst := range instances {
+			if err := inst.Kill(); err != nil {
+				msg.errs = append(msg.errs, fmt.Errorf("%s: %w", inst.Title, err))
+				continue
+			}
+			msg.killed++
+		}
+		return msg
+	}
+}
+
+// noticeIdleSessions tells the user when more sessions have become idle
+// enough for the cleanup policy than they were last told about.
+func (h *Home) noticeIdleSessions() {
+	idleFor := session.GetCleanupSettings().IdleAfter()
+	n := len(session.IdleCleanupCandidates(h.sessions.All(), idleFor, time.Now()))
+	if n > h.cleanupNoticed {
+		h.toast(ToastInfo, "%d sessions idle for over %s. %s to review cleanup", n, formatIdleThreshold(idleFor), h.keyHint(ActionCleanup))
+	}
+	h.cleanupNoticed = n
+}
+
+// cycleGroupSort switches the selected group, or the selected session's
+// group, to its next sort mode and keeps the cursor on the same item.
+func (h *Home) cycleGroupSort() {
+	if h.cursor >= len(h.flatItems) {
+		return
+	}
+	group, ok := h.sessions.Tree().Groups[h.flatItems[h.cursor].Path]
+	if !ok {
+		return
+	}
+	group.SortMode = group.SortMode.Next()
+	h.rebuildFlatItemsKeepingSelection()
+	h.liveSortOrder = h.liveSortSignature()
+	h.saveGroupState()
+	h.toast(ToastInfo, "%s: sorted by %s", group.Name, group.SortMode.Label())
+}
+
+// sortedReorderBlocked reports whether groupPath sorts its sessions
+// automatically, where moving one by hand would be undone, and says so.
+func (h *Home) sortedReorderBlocked(groupPath string) bool {
+	group, ok := h.sessions.Tree().Groups[groupPath]
+	if !ok || group.SortMode == session.GroupSortManual {
+		return false
+	}
+	h.toast(ToastWarning, "%s is sorted by %s. %s to switch to manual order", group.Name, group.SortMode.Label(), h.keyHint(ActionGroupSort))
+	return true
+}
+
+// liveSortSignature lists the session order of expanded groups sorted by
+// activity or status, whose order changes without any user action.
+func (h *Home) liveSortSignature() string {
+	var b strings.Builder
+	for _, group := range h.sessions.Tree().GroupList {
+		if !group.Expanded || (group.SortMode != session.GroupSortActivity && group.SortMode != session.GroupSortStatus) {
+			continue
+		}
+		b.WriteString(group.Path)
+		for _, inst := range group.SortedSessions() {
+			b.WriteByte(' ')
+			b.WriteString(inst.ID)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// resortLiveGroups rebuilds the list when an activity- or status-sorted
+// group's order has changed since the last rebuild.
+func (h *Home) resortLiveGroups() {
+	order := h.liveSortSignature()
+	if order == h.liveSortOrder {
+		return
+	}
+	h.liveSortOrder = order
+	h.rebuildFlatItemsKeepingSelection()
+}
+
+// rebuildFlatItemsKeepingSelection rebuilds the list with the cursor on the
+// session or group it was on, wherever that moved to.
+func (h *Home) rebuildFlatItemsKeepingSelection() {
+	var selected session.Item
+	if h.cursor >= 0 && h.cursor < len(h.flatItems) {
+		selected = h.flatItems[h.cursor]
+	}
+	h.rebuildFlatItems()
+	for i, item := range h.flatItems {
+		if item.Type != selected.Type {
+			continue
+		}
+		if (item.Type == session.ItemTypeSession && item.Session == selected.Session) ||
+			(item.Type == session.ItemTypeGroup && item.Path == selected.Path) {
+			h.cursor = i
+			h.syncViewport()
+			return
+		}
+	}
+}
+
+// selectedProjectPath returns the project path and group of the selected
+// session, or the default path of the selected group.
+func (h *Home) selectedProjectPath() (projectPath, groupPath string) {
+	if h.cursor < 0 || h.cursor >= len(h.flatItems) {
+		return "", ""
+	}
+	item := h.flatItems[h.cursor]
+	switch {
+	case item.Type == session.ItemTypeSession && item.Session != nil:
+		return item.Session.ProjectPath, item.Session.GroupPath
+	case item.Type == session.ItemTypeGroup && item.Group != nil:
+		return h.sessions.Tree().DefaultPathForGroup(item.Group.Path), item.Group.Path
+	}
+	return "", ""
+}
+
+// handleNotesDialogKey handles key events when the notes dialog is visible.
+func (h *Home) handleNotesDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if inst := h.sessions.Get(h.notesDialog.SessionID()); inst != nil {
+			inst.Notes = h.notesDialog.GetValue()
+			h.invalidatePreviewCache(inst.ID)
+			// Force: a user edit must not be dropped by a concurrent reload
+			h.forceSaveInstances()
+		}
+		h.notesDialog.Hide()
+		return h, nil
+	case "esc":
+		h.notesDialog.Hide()
+		return h, nil
+	}
+
+	var cmd tea.Cmd
+	h.notesDialog, cmd = h.notesDialog.Update(msg)
+	return h, cmd
+}
+
+// handleTagDialogKey handles key events when the tag dialog is visible.
+func (h *Home) handleTagDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		switch h.tagDialog.Mode() {
+		case TagDialogEdit:
+			if inst := h.sessions.Get(h.tagDialog.SessionID()); inst != nil {
+				inst.Tags = h.tagDialog.GetTags()
+				h.invalidatePreviewCache(inst.ID)
+				// Force: a user edit must not be dropped by a concurrent reload
+				h.forceSaveInstances()
+				// The session may no longer match an active tag filter
+				h.rebuildFlatItems()
+			}
+		case TagDialogFilter:
+			if tag := h.tagDialog.GetSelectedTag(); tag != "" {
+				h.tagFilter = tag
+				h.rebuildFlatItems()
+			}
+		}
+		h.tagDialog.Hide()
+		return h, nil
+	case "0":
+		if h.tagDialog.Mode() == TagDialogFilter {
+			h.tagFilter = ""
+			h.rebuildFlatItems()
+			h.tagDialog.Hide()
+			return h, nil
+		}
+	case "esc":
+		h.tagDialog.Hide()
+		return h, nil
+	}
+
+	var cmd tea.Cmd
+	h.tagDialog, cmd = h.tagDialog.Update(msg)
+	return h, cmd
+}
+
+// handlePromptDialogKey handles key events when the prompt dialog is visible.
+func (h *Home) handlePromptDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if h.promptDialog.PickingSnippet() {
+		// "s" sends the picked snippet as-is; other keys drive the picker
+		if snippet, ok := h.promptDialog.SelectedSnippet(); ok && msg.String() == "s" {
+			return h, h.submitPrompt(strings.TrimSpace(snippet.Text))
+		}
+		h.promptDialog.Update(msg)
+		return h, nil
+	}
+
+	switch msg.String() {
+	case "enter":
+		return h, h.submitPrompt(h.promptDialog.GetPrompt())
+	case "ctrl+x":
+		if inst := h.sessions.Get(h.promptDialog.SessionID()); inst != nil && h.promptDialog.QueueMode() {
+			inst.ClearPromptQueue()
+			h.saveInstances()
+			h.promptDialog.SetQueued(nil)
+		}
+		return h, nil
+	case "esc":
+		h.promptDialog.Hide()
+		return h, nil
+	}
+
+	var cmd tea.Cmd
+	h.promptDialog, cmd = h.promptDialog.Update(msg)
+	return h, cmd
+}
+
+// submitPrompt closes the prompt dialog and sends or queues prompt for its
+// session, depending on the dialog's mode.
+func (h *Home) submitPrompt(prompt string) tea.Cmd {
+	inst := h.sessions.Get(h.promptDialog.SessionID())
+	queue := h.promptDialog.QueueMode()
+	h.promptDialog.Hide()
+	if prompt == "" || inst == nil {
+		return nil
+	}
+	if queue {
+		inst.QueuePrompt(prompt)
+		h.saveInstances()
+		h.toast(ToastSuccess, "Queued prompt for '%s' (%d pending)", inst.Title, inst.QueuedPromptCount())
+		return nil
+	}
+	return h.sendPrompt(inst, prompt)
+}
+
+// sendPrompt returns a tea.Cmd that types prompt into the session's pane and
+// presses Enter.
+func (h *Home) sendPrompt(inst *session.Instance, prompt string) tea.Cmd {
+	title := inst.Title
+	return func() tea.Msg {
+		tmuxSession := inst.GetTmuxSession()
+		if tmuxSession == nil || !inst.Exists() {
+			return promptSentMsg{sessionTitle: title, err: fmt.Errorf("session is not running")}
+		}
+		if err := tmuxSession.SendKeysAndEnter(prompt); err != nil {
+			return promptSentMsg{sessionTitle: title, err: err}
+		}
+		return promptSentMsg{sessionTitle: title}
+	}
+}
+
+// SwitchProfile returns the profile the user chose to switch to before the
+// TUI quit, or "" for a normal quit.
+func (h *Home) SwitchProfile() string {
+	return h.switchProfile
+}
+
 // handleWorktreeFinishDialogKey processes key events for the worktree finish dialog
 func (h *Home) handleWorktreeFinishDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	action := h.worktreeFinishDialog.HandleKey(msg.String())
@@ -8610,9 +11109,7 @@ func (h *Home) handleWorktreeFinishDialogKey(msg tea.KeyMsg) (tea.Model, tea.Cmd
 		wtPath := h.worktreeFinishDialog.worktreePath
 
 		// Find the instance for kill/remove
-		h.instancesMu.RLock()
-		inst := h.instanceByID[sid]
-		h.instancesMu.RUnlock()
+		inst := h.sessions.Get(sid)
 
 		return h, h.finishWorktree(inst, sid, sTitle, branch, repoRoot, wtPath, mergeEnabled, targetBranch, keepBranch)
 
@@ -8685,7 +11182,7 @@ func (h *Home) finishWorktree(inst *session.Instance, sessionID, sessionTitle, b
 // getOtherActiveSessions returns sessions excluding the given ID and error-status sessions.
 func (h *Home) getOtherActiveSessions(excludeID string) []*session.Instance {
 	var result []*session.Instance
-	for _, inst := range h.instances {
+	for _, inst := range h.sessions.All() {
 		if inst.ID == excludeID {
 			continue
 		}
@@ -2,13 +2,19 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
 
+	"github.com/asheshgoplani/agent-deck/internal/git"
 	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/statedb"
 )
 
 func TestNewHome(t *testing.T) {
@@ -145,8 +151,7 @@ func TestHomeRenameGroupWithR(t *testing.T) {
 	home.height = 30
 
 	// Create a group tree with a group
-	home.groupTree = session.NewGroupTree([]*session.Instance{})
-	home.groupTree.CreateGroup("test-group")
+	home.sessions.Update(func(tree *session.GroupTree) { tree.CreateGroup("test-group") })
 	home.rebuildFlatItems()
 
 	// Position cursor on the group
@@ -158,7 +163,7 @@ func TestHomeRenameGroupWithR(t *testing.T) {
 		t.Fatal("First item should be a group")
 	}
 
-	// Press r to open rename dialog
+	// Press r to rename the group in place
 	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
 	model, _ := home.Update(msg)
 
@@ -166,11 +171,25 @@ func TestHomeRenameGroupWithR(t *testing.T) {
 	if !ok {
 		t.Fatal("Update should return *Home")
 	}
-	if !h.groupDialog.IsVisible() {
-		t.Error("Group dialog should be visible after pressing r on a group")
+	if h.groupDialog.IsVisible() {
+		t.Error("Group dialog should not open for a rename")
+	}
+	if !h.inlineRename.IsActive() || h.inlineRename.IsSession() {
+		t.Fatal("r on a group should start renaming it in place")
+	}
+	if h.inlineRename.GroupPath() != h.flatItems[0].Path || h.inlineRename.Value() != "test-group" {
+		t.Errorf("renaming %q with %q", h.inlineRename.GroupPath(), h.inlineRename.Value())
+	}
+
+	// A "/" is rejected and the edit stays open
+	h.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	h.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, shown := h.toasts.Last(); !h.inlineRename.IsActive() || !shown {
+		t.Error("an invalid name should keep the rename open with an error")
 	}
-	if h.groupDialog.Mode() != GroupDialogRename {
-		t.Errorf("Dialog mode = %v, want GroupDialogRename", h.groupDialog.Mode())
+	h.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if h.inlineRename.IsActive() || h.sessions.Tree().GroupList[0].Name != "test-group" {
+		t.Error("esc should cancel the rename")
 	}
 }
 
@@ -181,10 +200,7 @@ func TestHomeRenameSessionWithR(t *testing.T) {
 
 	// Create a test session
 	inst := session.NewInstance("test-session", "/tmp/project")
-	home.instancesMu.Lock()
-	home.instances = []*session.Instance{inst}
-	home.instancesMu.Unlock()
-	home.groupTree = session.NewGroupTree(home.instances)
+	setSessions(home, inst)
 	home.rebuildFlatItems()
 
 	// Find and position cursor on the session (skip the group)
@@ -200,7 +216,7 @@ func TestHomeRenameSessionWithR(t *testing.T) {
 	}
 	home.cursor = sessionIdx
 
-	// Press r to open rename dialog
+	// Press r to rename the session in place
 	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
 	model, _ := home.Update(msg)
 
@@ -208,14 +224,14 @@ func TestHomeRenameSessionWithR(t *testing.T) {
 	if !ok {
 		t.Fatal("Update should return *Home")
 	}
-	if !h.groupDialog.IsVisible() {
-		t.Error("Group dialog should be visible after pressing r on a session")
+	if !h.inlineRename.IsActive() || !h.inlineRename.IsSession() {
+		t.Fatal("r on a session should start renaming it in place")
 	}
-	if h.groupDialog.Mode() != GroupDialogRenameSession {
-		t.Errorf("Dialog mode = %v, want GroupDialogRenameSession", h.groupDialog.Mode())
+	if h.inlineRename.SessionID() != inst.ID {
+		t.Errorf("Session ID = %s, want %s", h.inlineRename.SessionID(), inst.ID)
 	}
-	if h.groupDialog.GetSessionID() != inst.ID {
-		t.Errorf("Session ID = %s, want %s", h.groupDialog.GetSessionID(), inst.ID)
+	if !h.inlineRename.Targets(h.flatItems[sessionIdx]) {
+		t.Error("the selected row should be the one edited")
 	}
 }
 
@@ -226,11 +242,7 @@ func TestHomeRenameSessionComplete(t *testing.T) {
 
 	// Create a test session
 	inst := session.NewInstance("original-name", "/tmp/project")
-	home.instancesMu.Lock()
-	home.instances = []*session.Instance{inst}
-	home.instanceByID[inst.ID] = inst // Also populate the O(1) lookup map
-	home.instancesMu.Unlock()
-	home.groupTree = session.NewGroupTree(home.instances)
+	setSessions(home, inst)
 	home.rebuildFlatItems()
 
 	// Find and position cursor on the session
@@ -243,12 +255,18 @@ func TestHomeRenameSessionComplete(t *testing.T) {
 	}
 	home.cursor = sessionIdx
 
-	// Press r to open rename dialog
+	home.initialLoading = false
+
+	// Press r to rename in place
 	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}}
 	home.Update(msg)
 
-	// Simulate typing a new name
-	home.groupDialog.nameInput.SetValue("new-name")
+	// Type over the old name: the keys edit the title, they don't run actions
+	home.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("new-name")})
+	if !strings.Contains(home.View(), "new-name") {
+		t.Error("the edited title should be shown in the list")
+	}
 
 	// Press Enter to confirm
 	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
@@ -258,11 +276,11 @@ func TestHomeRenameSessionComplete(t *testing.T) {
 	if !ok {
 		t.Fatal("Update should return *Home")
 	}
-	if h.groupDialog.IsVisible() {
-		t.Error("Dialog should be hidden after pressing Enter")
+	if h.inlineRename.IsActive() {
+		t.Error("Rename should end after pressing Enter")
 	}
-	if h.instances[0].Title != "new-name" {
-		t.Errorf("Session title = %s, want new-name", h.instances[0].Title)
+	if h.sessions.All()[0].Title != "new-name" {
+		t.Errorf("Session title = %s, want new-name", h.sessions.All()[0].Title)
 	}
 }
 
@@ -273,11 +291,7 @@ func TestHomeRenamePendingChangesSurviveReload(t *testing.T) {
 
 	// Create a test session
 	inst := session.NewInstance("original-name", "/tmp/project")
-	home.instancesMu.Lock()
-	home.instances = []*session.Instance{inst}
-	home.instanceByID[inst.ID] = inst
-	home.instancesMu.Unlock()
-	home.groupTree = session.NewGroupTree(home.instances)
+	setSessions(home, inst)
 	home.rebuildFlatItems()
 
 	// Simulate a rename that stores a pending title change
@@ -297,8 +311,8 @@ func TestHomeRenamePendingChangesSurviveReload(t *testing.T) {
 	h := model.(*Home)
 
 	// The pending rename should have been re-applied after reload
-	if h.instances[0].Title != "renamed-title" {
-		t.Errorf("Session title = %s, want renamed-title (pending rename should survive reload)", h.instances[0].Title)
+	if h.sessions.All()[0].Title != "renamed-title" {
+		t.Errorf("Session title = %s, want renamed-title (pending rename should survive reload)", h.sessions.All()[0].Title)
 	}
 	// Pending changes should be cleared after re-application
 	if len(h.pendingTitleChanges) != 0 {
@@ -313,11 +327,7 @@ func TestHomeRenamePendingChangesNoop(t *testing.T) {
 
 	// Create a test session
 	inst := session.NewInstance("desired-name", "/tmp/project")
-	home.instancesMu.Lock()
-	home.instances = []*session.Instance{inst}
-	home.instanceByID[inst.ID] = inst
-	home.instancesMu.Unlock()
-	home.groupTree = session.NewGroupTree(home.instances)
+	setSessions(home, inst)
 	home.rebuildFlatItems()
 
 	// Store a pending change that matches the current title (normal save succeeded)
@@ -337,8 +347,8 @@ func TestHomeRenamePendingChangesNoop(t *testing.T) {
 	h := model.(*Home)
 
 	// Title should still be correct
-	if h.instances[0].Title != "desired-name" {
-		t.Errorf("Session title = %s, want desired-name", h.instances[0].Title)
+	if h.sessions.All()[0].Title != "desired-name" {
+		t.Errorf("Session title = %s, want desired-name", h.sessions.All()[0].Title)
 	}
 	// Pending changes should be cleared (no re-application needed)
 	if len(h.pendingTitleChanges) != 0 {
@@ -485,6 +495,37 @@ func TestGetLayoutMode(t *testing.T) {
 	}
 }
 
+func TestListSplitKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // The split is saved with the UI state
+	home := NewHome()
+	home.width = 100
+
+	if got := home.dualListWidth(); got != 35 {
+		t.Errorf("default list width = %d, want 35", got)
+	}
+	for i := 0; i < 3; i++ {
+		home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'>'}})
+	}
+	if got := home.dualListWidth(); got != 50 {
+		t.Errorf("list width after >>> = %d, want 50", got)
+	}
+	for i := 0; i < 10; i++ {
+		home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'<'}})
+	}
+	if home.listPercent != listPercentMin {
+		t.Errorf("listPercent = %d, want clamped to %d", home.listPercent, listPercentMin)
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'|'}})
+	if got := home.getLayoutMode(); got != LayoutModeSingle {
+		t.Errorf("layout with preview hidden = %q, want single", got)
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'|'}})
+	if got := home.getLayoutMode(); got != LayoutModeDual {
+		t.Errorf("layout with preview shown = %q, want dual", got)
+	}
+}
+
 func TestRenderHelpBarTiny(t *testing.T) {
 	home := NewHome()
 	home.width = 45 // Tiny mode (<50 cols)
@@ -672,10 +713,7 @@ func TestHomeViewStackedLayout(t *testing.T) {
 
 	// Add a test session so we have content
 	inst := &session.Instance{ID: "test1", Title: "Test Session", Tool: "claude", Status: session.StatusIdle}
-	home.instancesMu.Lock()
-	home.instances = []*session.Instance{inst}
-	home.instancesMu.Unlock()
-	home.groupTree = session.NewGroupTree(home.instances)
+	setSessions(home, inst)
 	home.rebuildFlatItems()
 
 	view := home.View()
@@ -698,10 +736,7 @@ func TestHomeViewSingleColumnLayout(t *testing.T) {
 
 	// Add a test session
 	inst := &session.Instance{ID: "test1", Title: "Test Session", Tool: "claude", Status: session.StatusIdle}
-	home.instancesMu.Lock()
-	home.instances = []*session.Instance{inst}
-	home.instancesMu.Unlock()
-	home.groupTree = session.NewGroupTree(home.instances)
+	setSessions(home, inst)
 	home.rebuildFlatItems()
 
 	view := home.View()
@@ -774,11 +809,11 @@ func TestCtrlZEmptyStack(t *testing.T) {
 		t.Fatal("Update should return *Home")
 	}
 
-	// Should show "nothing to undo" error
-	if h.err == nil {
-		t.Error("Expected error message for empty undo stack")
-	} else if !strings.Contains(h.err.Error(), "nothing to undo") {
-		t.Errorf("Error = %q, want 'nothing to undo'", h.err.Error())
+	// Should show "nothing to undo"
+	if toast, ok := h.toasts.Last(); !ok {
+		t.Error("Expected a notice for empty undo stack")
+	} else if !strings.Contains(toast.Text, "nothing to undo") {
+		t.Errorf("Toast = %q, want 'nothing to undo'", toast.Text)
 	}
 
 	// Should not return a command
@@ -854,10 +889,7 @@ func TestSessionRestartedMsgErrorClearsResumingAnimation(t *testing.T) {
 	home := NewHome()
 	inst := session.NewInstance("restart-test", "/tmp/project")
 
-	home.instancesMu.Lock()
-	home.instances = []*session.Instance{inst}
-	home.instanceByID[inst.ID] = inst
-	home.instancesMu.Unlock()
+	setSessions(home, inst)
 
 	home.resumingSessions[inst.ID] = time.Now()
 
@@ -870,11 +902,12 @@ func TestSessionRestartedMsgErrorClearsResumingAnimation(t *testing.T) {
 	if _, ok := h.resumingSessions[inst.ID]; ok {
 		t.Fatal("resuming animation should be cleared after restart error")
 	}
-	if h.err == nil {
-		t.Fatal("expected restart error to be set")
+	errs := h.errorLog.Entries()
+	if len(errs) != 1 || h.errorLog.Undismissed() != 1 {
+		t.Fatal("expected restart error to be logged")
 	}
-	if !strings.Contains(h.err.Error(), "failed to restart session") {
-		t.Fatalf("unexpected error: %v", h.err)
+	if !strings.Contains(errs[0].Text, "failed to restart session") {
+		t.Fatalf("unexpected error: %v", errs[0].Text)
 	}
 }
 
@@ -884,9 +917,7 @@ func TestRestartSessionCmdSessionMissingReturnsError(t *testing.T) {
 
 	// Build command with a valid instance, then simulate reload/delete before cmd runs.
 	cmd := home.restartSession(inst)
-	home.instancesMu.Lock()
-	delete(home.instanceByID, inst.ID)
-	home.instancesMu.Unlock()
+	home.sessions.Remove(inst.ID)
 
 	msg := cmd()
 	restarted, ok := msg.(sessionRestartedMsg)
@@ -900,3 +931,765 @@ func TestRestartSessionCmdSessionMissingReturnsError(t *testing.T) {
 		t.Fatalf("unexpected error: %v", restarted.err)
 	}
 }
+
+func TestHomeTagFilter(t *testing.T) {
+	home := NewHome()
+	home.width = 100
+	home.height = 30
+
+	bug := session.NewInstance("bug-session", "/tmp/bug")
+	bug.Tags = []string{"bug"}
+	other := session.NewInstance("other-session", "/tmp/other")
+	setSessions(home, bug, other)
+	home.rebuildFlatItems()
+
+	countSessions := func() int {
+		n := 0
+		for _, item := range home.flatItems {
+			if item.Type == session.ItemTypeSession {
+				n++
+			}
+		}
+		return n
+	}
+	if got := countSessions(); got != 2 {
+		t.Fatalf("unfiltered sessions = %d, want 2", got)
+	}
+
+	// T opens the picker; Enter applies the selected tag
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'T'}})
+	if !home.tagDialog.IsVisible() || home.tagDialog.Mode() != TagDialogFilter {
+		t.Fatal("T should open the tag filter picker")
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if home.tagFilter != "bug" {
+		t.Fatalf("tagFilter = %q, want bug", home.tagFilter)
+	}
+	if got := countSessions(); got != 1 {
+		t.Errorf("filtered sessions = %d, want 1", got)
+	}
+
+	// 0 clears all filters
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'0'}})
+	if home.tagFilter != "" || countSessions() != 2 {
+		t.Errorf("after 0: tagFilter = %q, sessions = %d", home.tagFilter, countSessions())
+	}
+}
+
+func TestHomeAttentionFilterAndClicks(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+	home.initialLoading = false
+
+	waiting := session.NewInstance("waiting-session", "/tmp/w")
+	waiting.Status = session.StatusWaiting
+	broken := session.NewInstance("broken-session", "/tmp/b")
+	broken.Status = session.StatusError
+	idle := session.NewInstance("idle-session", "/tmp/i")
+	idle.Status = session.StatusIdle
+	setSessions(home, waiting, broken, idle)
+	home.rebuildFlatItems()
+
+	countSessions := func() int {
+		n := 0
+		for _, item := range home.flatItems {
+			if item.Type == session.ItemTypeSession {
+				n++
+			}
+		}
+		return n
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'^'}})
+	if got := countSessions(); got != 2 {
+		t.Errorf("^ should show waiting and error sessions, got %d", got)
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'^'}})
+	if home.statusFilter != "" {
+		t.Errorf("^ again should clear the filter, got %q", home.statusFilter)
+	}
+
+	// Clicking a count in the header or a filter pill opens its view
+	click := func(row int, filter session.Status) {
+		t.Helper()
+		home.View()
+		for _, hit := range home.filterHits {
+			if hit.row == row && hit.filter == filter {
+				home.Update(tea.MouseMsg{X: hit.x0, Y: hit.row, Action: tea.MouseActionPress, Button: tea.MouseButtonLeft})
+				return
+			}
+		}
+		t.Fatalf("no clickable %q on row %d", filter, row)
+	}
+	click(0, session.StatusIdle)
+	if home.statusFilter != session.StatusIdle || countSessions() != 1 {
+		t.Errorf("header click: filter = %q, sessions = %d", home.statusFilter, countSessions())
+	}
+	click(1, statusFilterAttention)
+	if home.statusFilter != statusFilterAttention {
+		t.Errorf("pill click: filter = %q", home.statusFilter)
+	}
+	click(1, "")
+	if home.statusFilter != "" || countSessions() != 3 {
+		t.Errorf("All pill: filter = %q, sessions = %d", home.statusFilter, countSessions())
+	}
+}
+
+func TestMouseDragMovesSessions(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+	home.initialLoading = false
+
+	s1 := session.NewInstance("s1", "/tmp/s1")
+	s2 := session.NewInstance("s2", "/tmp/s2")
+	s3 := session.NewInstance("s3", "/tmp/s3")
+	t1 := session.NewInstance("t1", "/tmp/t1")
+	for _, inst := range []*session.Instance{s1, s2, s3} {
+		inst.GroupPath = "a"
+	}
+	t1.GroupPath = "b"
+	setSessions(home, s1, s2, s3, t1)
+	home.rebuildFlatItems()
+
+	rowOf := func(inst *session.Instance) int {
+		t.Helper()
+		home.View()
+		for i, item := range home.flatItems {
+			if row := slices.Index(home.listArea.items, i); item.Session == inst && row >= 0 {
+				return home.listArea.top + row
+			}
+		}
+		t.Fatalf("%s is not in the list", inst.Title)
+		return 0
+	}
+	mouse := func(y int, action tea.MouseAction) {
+		home.Update(tea.MouseMsg{X: 2, Y: y, Action: action, Button: tea.MouseButtonLeft})
+	}
+	order := func(path string) string {
+		var titles []string
+		for _, inst := range home.sessions.Tree().Groups[path].Sessions {
+			titles = append(titles, inst.Title)
+		}
+		return strings.Join(titles, ",")
+	}
+
+	// Clicking selects
+	mouse(rowOf(s2), tea.MouseActionPress)
+	mouse(rowOf(s2), tea.MouseActionRelease)
+	if home.getSelectedSession() != s2 {
+		t.Fatal("a click should select the session under it")
+	}
+
+	// Dragging down within the group
+	y := rowOf(s1)
+	mouse(y, tea.MouseActionPress)
+	mouse(y+1, tea.MouseActionMotion)
+	mouse(y+2, tea.MouseActionMotion)
+	mouse(y+2, tea.MouseActionRelease)
+	if got := order("a"); got != "s2,s3,s1" {
+		t.Errorf("order after drag = %s, want s2,s3,s1", got)
+	}
+	if home.getSelectedSession() != s1 {
+		t.Error("the dragged session should stay selected")
+	}
+
+	// Dragging onto the next group's header moves it to the top of that group
+	y = rowOf(s1)
+	mouse(y, tea.MouseActionPress)
+	mouse(y+1, tea.MouseActionMotion)
+	mouse(y+1, tea.MouseActionRelease)
+	if s1.GroupPath != "b" || order("b") != "s1,t1" {
+		t.Errorf("group = %s, order = %s; want s1 first in b", s1.GroupPath, order("b"))
+	}
+	if home.dragSession != nil {
+		t.Error("release should end the drag")
+	}
+}
+
+func TestStatusChangeSignalReRendersAndRelistens(t *testing.T) {
+	home := NewHome()
+
+	// A signal from a log worker becomes a sessionStatusChangedMsg
+	home.statusChanged <- struct{}{}
+	msg := home.listenForStatusChanges()()
+	if _, ok := msg.(sessionStatusChangedMsg); !ok {
+		t.Fatalf("listener returned %T, want sessionStatusChangedMsg", msg)
+	}
+
+	// Handling it re-arms the listener
+	_, cmd := home.Update(msg)
+	if cmd == nil {
+		t.Fatal("sessionStatusChangedMsg should return the next listener")
+	}
+
+	// Cancelling the context ends the listener
+	home.cancel()
+	if msg := cmd(); msg != nil {
+		t.Errorf("listener after shutdown returned %T, want nil", msg)
+	}
+}
+
+func TestPromptResurrectIfAllGone(t *testing.T) {
+	home := NewHome()
+	setSessions(home, session.NewInstance("one", "/tmp/one"), session.NewInstance("two", "/tmp/two"))
+
+	home.promptResurrectIfAllGone()
+	if !home.confirmDialog.IsVisible() || home.confirmDialog.GetConfirmType() != ConfirmResurrectSessions {
+		t.Fatal("expected the resurrect prompt when no session is running")
+	}
+	if ids := home.confirmDialog.GetPendingSessionIDs(); len(ids) != 2 {
+		t.Errorf("pending ids = %v, want both sessions", ids)
+	}
+	if view := home.confirmDialog.View(); !strings.Contains(view, "one") || !strings.Contains(view, "two") {
+		t.Errorf("prompt should list the sessions, got:\n%s", view)
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}})
+	if home.confirmDialog.IsVisible() {
+		t.Error("n should dismiss the prompt")
+	}
+}
+
+func TestAttachOffersRestartOrRemove(t *testing.T) {
+	home := NewHome()
+	inst := session.NewInstance("gone", "/tmp/gone")
+	setSessions(home, inst)
+
+	if cmd := home.attachIfReady(inst); cmd != nil {
+		t.Error("a session that isn't running shouldn't attach")
+	}
+	if !home.confirmDialog.IsVisible() || home.confirmDialog.GetConfirmType() != ConfirmSessionNotRunning {
+		t.Fatal("expected the restart/remove prompt")
+	}
+	if view := home.confirmDialog.View(); !strings.Contains(view, "r Restart") || !strings.Contains(view, "d Remove") {
+		t.Errorf("prompt should offer restart and remove, got:\n%s", view)
+	}
+
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'d'}})
+	if home.confirmDialog.IsVisible() || cmd == nil {
+		t.Fatal("d should close the prompt and delete the session")
+	}
+	msg, ok := cmd().(sessionDeletedMsg)
+	if !ok || msg.deletedID != inst.ID {
+		t.Fatalf("d returned %T, want sessionDeletedMsg for %s", msg, inst.ID)
+	}
+	if msg.killErr != nil {
+		t.Errorf("removing a gone session shouldn't report a kill error: %v", msg.killErr)
+	}
+}
+
+func TestConfirmDialogResurrectListsAtMostFive(t *testing.T) {
+	d := NewConfirmDialog()
+	titles := []string{"a", "b", "c", "d", "e", "f", "g"}
+	d.ShowResurrectSessions(make([]string, len(titles)), titles)
+	if !strings.Contains(d.targetName, "and 2 more") || strings.Contains(d.targetName, "• f") {
+		t.Errorf("targetName = %q", d.targetName)
+	}
+}
+
+func TestFocusMessagesTrackTerminalFocus(t *testing.T) {
+	home := NewHome()
+	if !home.terminalFocused.Load() {
+		t.Fatal("terminal should count as focused until told otherwise")
+	}
+	home.Update(tea.BlurMsg{})
+	if home.terminalFocused.Load() {
+		t.Error("BlurMsg should clear focus")
+	}
+	home.Update(tea.FocusMsg{})
+	if !home.terminalFocused.Load() {
+		t.Error("FocusMsg should restore focus")
+	}
+}
+
+func TestMuteKeys(t *testing.T) {
+	home := NewHome()
+	home.width = 100
+	home.height = 30
+
+	inst := session.NewInstance("api", "/tmp/api")
+	inst.GroupPath = "work"
+	setSessions(home, inst)
+	home.rebuildFlatItems()
+
+	cursorOn := func(itemType session.ItemType) {
+		for i, item := range home.flatItems {
+			if item.Type == itemType {
+				home.cursor = i
+				return
+			}
+		}
+		t.Fatalf("no item of type %v", itemType)
+	}
+	press := func(r rune) { home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}) }
+
+	if home.notificationsMuted(inst) {
+		t.Fatal("nothing is muted yet")
+	}
+
+	cursorOn(session.ItemTypeSession)
+	press('b')
+	if !inst.NotifyMuted || !home.notificationsMuted(inst) {
+		t.Fatal("b on a session should mute it")
+	}
+	press('b')
+
+	cursorOn(session.ItemTypeGroup)
+	press('b')
+	if inst.NotifyMuted || !home.notificationsMuted(inst) {
+		t.Fatal("b on a group should mute its sessions, not flag them")
+	}
+	press('b')
+	if home.notificationsMuted(inst) {
+		t.Fatal("second b should unmute the group")
+	}
+
+	press('B')
+	if !home.notificationsMuted(inst) {
+		t.Error("B should turn on do-not-disturb")
+	}
+	press('B')
+	if home.notificationsMuted(inst) {
+		t.Error("second B should turn do-not-disturb off")
+	}
+}
+
+func TestGitStatusRefreshAndBadge(t *testing.T) {
+	home := NewHome()
+	dir := t.TempDir() // Not a git repository
+	a := session.NewInstance("a", dir)
+	b := session.NewInstance("b", dir)
+	setSessions(home, a, b)
+	home.rebuildFlatItems()
+
+	cmd := home.refreshGitStatus()
+	if cmd == nil {
+		t.Fatal("expected a refresh for an unchecked path")
+	}
+	if home.refreshGitStatus() != nil {
+		t.Error("no second refresh while one is in flight")
+	}
+	msg, ok := cmd().(gitStatusMsg)
+	if !ok || len(msg.statuses) != 1 {
+		t.Fatalf("refresh should check the shared path once, got %+v", msg)
+	}
+	home.Update(msg)
+	if home.refreshGitStatus() != nil {
+		t.Error("fresh statuses should not be re-read")
+	}
+	if _, ok := home.cachedGitStatus(dir); ok {
+		t.Error("a non-git path should have no git status")
+	}
+
+	home.gitStatusCache[dir] = gitStatusEntry{
+		status:    git.Status{Branch: "main", HasUpstream: true, Ahead: 2, Behind: 1, Changed: 3},
+		ok:        true,
+		checkedAt: time.Now(),
+	}
+	var row strings.Builder
+	home.renderSessionItem(&row, session.Item{Type: session.ItemTypeSession, Session: a}, false, 0)
+	if !strings.Contains(row.String(), "[main ↑2↓1 *]") {
+		t.Errorf("row should show the git badge: %q", row.String())
+	}
+}
+
+func TestFormatGitMarks(t *testing.T) {
+	tests := []struct {
+		st   git.Status
+		sep  string
+		want string
+	}{
+		{git.Status{Branch: "main"}, "", ""},
+		{git.Status{Ahead: 2, Behind: 1}, "", "↑2↓1"},
+		{git.Status{Ahead: 2, Behind: 1, Changed: 1}, " ", "↑2 ↓1 *"},
+		{git.Status{Changed: 4}, "", "*"},
+	}
+	for _, tt := range tests {
+		if got := formatGitMarks(tt.st, tt.sep); got != tt.want {
+			t.Errorf("formatGitMarks(%+v, %q) = %q, want %q", tt.st, tt.sep, got, tt.want)
+		}
+	}
+}
+
+func TestRenderDiffSection(t *testing.T) {
+	home := NewHome()
+	inst := session.NewInstance("alpha", t.TempDir())
+
+	if got := home.renderDiffSection(inst, 80, 20); !strings.Contains(got, "Loading diff...") {
+		t.Errorf("diff for another session should show loading:\n%s", got)
+	}
+
+	home.Update(diffStatMsg{
+		sessionID: inst.ID,
+		files: []git.FileChange{
+			{Path: "internal/ui/home.go", Added: 120, Deleted: 30},
+			{Path: "logo.png", Binary: true},
+		},
+		untracked: []string{"notes.txt"},
+		checkedAt: time.Now(),
+	})
+	got := home.renderDiffSection(inst, 80, 20)
+	for _, want := range []string{"internal/ui/home.go", "150 ", "binary", "notes.txt", "2 files changed", "+120", "-30", "1 untracked", "Full diff: D"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("diff section should contain %q:\n%s", want, got)
+		}
+	}
+	if n := strings.Count(got, "+"); n > 25 {
+		t.Errorf("the +/- bar should be scaled down, found %d '+'", n)
+	}
+
+	// Too many files for the pane collapse into a "more" line
+	if got := home.renderDiffSection(inst, 80, 6); !strings.Contains(got, "… 2 more") {
+		t.Errorf("short pane should truncate the file list:\n%s", got)
+	}
+}
+
+func TestRenderStyledPreview(t *testing.T) {
+	red := "\x1b[31m"
+	lines := []string{red + "error: 0123456789", "", "\x1b]0;title\x07\x1b[0mplain\x1b[2K\r"}
+
+	out := renderStyledPreview(lines, 10, 10)
+	rows := strings.Split(out, "\n")
+	if len(rows) != 4 {
+		t.Fatalf("want the long line wrapped and the blank line kept, got %q", rows)
+	}
+	if !strings.HasPrefix(rows[1], red) {
+		t.Errorf("wrapped row should keep the color, got %q", rows[1])
+	}
+	for _, row := range rows {
+		if !strings.HasSuffix(row, "\x1b[0m") {
+			t.Errorf("row %q should end reset", row)
+		}
+		if strings.Contains(row, "\r") {
+			t.Errorf("row %q should have control characters stripped", row)
+		}
+		if strings.Contains(row, "title") || strings.Contains(row, "\x1b[2K") {
+			t.Errorf("row %q should keep only color sequences", row)
+		}
+	}
+
+	out = renderStyledPreview(lines, 2, 10)
+	if rows := strings.Split(out, "\n"); len(rows) != 2 || !strings.Contains(rows[0], "3 more lines above") {
+		t.Errorf("cut output should start with a count, got %q", rows)
+	}
+}
+
+func TestActiveSGR(t *testing.T) {
+	tests := map[string]string{
+		"plain":                          "",
+		"\x1b[1mbold\x1b[31mred":         "\x1b[1m\x1b[31m",
+		"\x1b[31mred\x1b[0mplain":        "",
+		"\x1b[31mred\x1b[mplain\x1b[32m": "\x1b[32m",
+	}
+	for in, want := range tests {
+		if got := activeSGR(in); got != want {
+			t.Errorf("activeSGR(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFollowPreview(t *testing.T) {
+	fetched := time.Now()
+	if !followPreview(true, fetched.Add(time.Millisecond), fetched) {
+		t.Error("output after the last capture should refresh the preview")
+	}
+	if followPreview(true, fetched.Add(-time.Second), fetched) {
+		t.Error("no new output: keep the cached preview")
+	}
+	if followPreview(false, fetched.Add(time.Second), fetched) {
+		t.Error("sessions without a control pipe wait for the status tick")
+	}
+}
+
+func TestGroupSortKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir()) // The sort mode is saved with the groups
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+	home.initialLoading = false
+
+	zeta := session.NewInstance("zeta", "/tmp/z")
+	alpha := session.NewInstance("alpha", "/tmp/a")
+	setSessions(home, zeta, alpha)
+	home.rebuildFlatItems()
+
+	home.cursor = 1 // zeta
+	ctrlS := tea.KeyMsg{Type: tea.KeyCtrlS}
+	for home.sessions.Tree().Groups[zeta.GroupPath].SortMode != session.GroupSortName {
+		home.Update(ctrlS)
+	}
+	if home.flatItems[1].Session != alpha || home.flatItems[2].Session != zeta {
+		t.Fatalf("name sort should put alpha first")
+	}
+	if home.flatItems[home.cursor].Session != zeta {
+		t.Errorf("cursor should stay on zeta, is on %d", home.cursor)
+	}
+
+	// Moving by hand is refused while the group is sorted
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'K'}})
+	if _, shown := home.toasts.Last(); home.flatItems[2].Session != zeta || !shown {
+		t.Errorf("K in a sorted group should leave the order and explain why")
+	}
+}
+
+func TestQuickJumpKeys(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+	home.initialLoading = false
+
+	var insts []*session.Instance
+	for _, name := range []string{"a", "b", "c", "d"} {
+		insts = append(insts, session.NewInstance(name, "/tmp/"+name))
+	}
+	setSessions(home, insts...)
+	home.rebuildFlatItems()
+	key := func(r rune) { home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}) }
+
+	key('G')
+	if home.cursor != len(home.flatItems)-1 {
+		t.Errorf("G should jump to the bottom, cursor = %d", home.cursor)
+	}
+	// The first g waits for the second instead of opening the group dialog
+	key('g')
+	if home.groupDialog.IsVisible() {
+		t.Error("a pending g shouldn't open the group dialog yet")
+	}
+	key('g')
+	if home.cursor != 0 || home.groupDialog.IsVisible() {
+		t.Errorf("gg should jump to the top, cursor = %d, dialog = %v", home.cursor, home.groupDialog.IsVisible())
+	}
+
+	// A lone g opens the dialog once it times out, or when another key
+	// follows, which then goes to the dialog
+	key('g')
+	home.Update(pendingGTimeoutMsg{seq: home.pendingGSeq})
+	if !home.groupDialog.IsVisible() {
+		t.Error("a lone g should open the group dialog after the timeout")
+	}
+	home.groupDialog.Hide()
+	key('g')
+	key('x')
+	if !home.groupDialog.IsVisible() || home.groupDialog.GetValue() != "x" {
+		t.Errorf("g then x should open the dialog with x typed, value = %q", home.groupDialog.GetValue())
+	}
+	home.groupDialog.Hide()
+
+	home.numberSessions = true
+	view := home.View()
+	if len(home.sessionNumbers) != 4 {
+		t.Fatalf("all four sessions should be numbered, got %v", home.sessionNumbers)
+	}
+	if !strings.Contains(view, "3") {
+		t.Error("numbers should be shown in the list")
+	}
+	key('3')
+	if sel := home.getSelectedSession(); sel == nil || sel.Title != home.flatItems[home.sessionNumbers[2]].Session.Title {
+		t.Errorf("3 should select the third session, got %v", sel)
+	}
+	// Pressing it again would attach; the session isn't running, so it
+	// offers to restart or remove it
+	key('3')
+	if home.confirmDialog.GetConfirmType() != ConfirmSessionNotRunning || !home.confirmDialog.IsVisible() {
+		t.Error("attaching a stopped session should offer to restart or remove it")
+	}
+}
+
+func TestHomeListDensity(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	home := NewHome()
+	home.width = 120
+	home.height = 20
+	home.initialLoading = false
+
+	var instances []*session.Instance
+	for i := range 12 {
+		inst := session.NewInstance(fmt.Sprintf("session-%02d", i), "/tmp/project-"+fmt.Sprint(i))
+		inst.Tool = "claude"
+		instances = append(instances, inst)
+	}
+	setSessions(home, instances...)
+	home.rebuildFlatItems()
+
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'='}}
+	home.Update(key)
+	if home.listDensity != DensityCompact {
+		t.Fatalf("density = %s, want compact", home.listDensity.Label())
+	}
+	if list := home.renderSessionList(50, 16); !strings.Contains(list, "session-00") || strings.Contains(list, "claude") {
+		t.Error("compact rows should show the title without the tool")
+	}
+
+	home.Update(key)
+	view := home.View()
+	if home.listDensity != DensityDetailed || !strings.Contains(view, "/tmp/project-0 · ") {
+		t.Error("detailed rows should show the path on a second line")
+	}
+	// Every session takes two rows, and the cursor stays on screen
+	for range len(home.flatItems) - 1 {
+		home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	}
+	view = home.View()
+	if !strings.Contains(view, "session-11") || !strings.Contains(view, "/tmp/project-11") {
+		t.Error("the last session and its detail line should be visible")
+	}
+	last := home.listArea.items[len(home.listArea.items)-1]
+	if last != len(home.flatItems)-1 || home.listArea.items[len(home.listArea.items)-2] != last {
+		t.Errorf("the last two list rows should both map to the last session: %v", home.listArea.items)
+	}
+
+	home.Update(key)
+	if home.listDensity != DensityNormal {
+		t.Error("= should cycle back to normal")
+	}
+}
+
+func TestTruncateWideCharacters(t *testing.T) {
+	paths := []string{
+		"/home/user/projects/プロジェクト/サービス/バックエンド",
+		"/home/user/📦📦📦📦📦📦📦📦/🚀🚀🚀🚀🚀🚀🚀🚀/app",
+		"/Users/someone/work/some-very-long-directory-name/project",
+	}
+	for _, p := range paths {
+		got := truncatePath(p, 30)
+		if w := runewidth.StringWidth(got); w > 30 {
+			t.Errorf("truncatePath(%q) = %q is %d columns, want at most 30", p, got, w)
+		}
+		if !strings.Contains(got, "...") || !strings.HasPrefix(p, strings.Split(got, "...")[0]) {
+			t.Errorf("truncatePath(%q) = %q should keep the start and mark the cut", p, got)
+		}
+	}
+
+	got := truncateStart("/home/user/プロジェクト/app", 12)
+	if w := runewidth.StringWidth(got); w != 12 || !strings.HasSuffix(got, "/app") {
+		t.Errorf("truncateStart = %q (%d columns), want 12 columns ending in /app", got, w)
+	}
+
+	home := NewHome()
+	title := home.renderPanelTitle("セッション一覧とプレビュー", 10)
+	if w := runewidth.StringWidth(strings.Split(title, "\n")[0]); w > 10 {
+		t.Errorf("panel title is %d columns, want at most 10", w)
+	}
+}
+
+func TestProcessStatusUpdateSignalsUI(t *testing.T) {
+	home := NewHome()
+	var instances []*session.Instance
+	var ids []string
+	for i := range 12 {
+		inst := &session.Instance{
+			ID:        fmt.Sprintf("s%d", i),
+			Title:     fmt.Sprintf("session-%d", i),
+			Status:    session.StatusWaiting,
+			CreatedAt: time.Now().Add(-time.Minute),
+		}
+		instances = append(instances, inst)
+		ids = append(ids, inst.ID)
+	}
+	setSessions(home, instances...)
+
+	home.processStatusUpdate(statusUpdateRequest{visibleHeight: 10, flatItemIDs: ids})
+	select {
+	case <-home.statusChanged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a status change should wake the UI")
+	}
+	// Ten visible sessions plus a round-robin batch of two covers all of them
+	for _, inst := range instances {
+		if got := inst.GetStatusThreadSafe(); got != session.StatusError {
+			t.Errorf("%s = %s, want it updated to error (no tmux session)", inst.Title, got)
+		}
+	}
+}
+
+func TestImportAdoptsCheckedSessions(t *testing.T) {
+	home := NewHome()
+	existing := session.NewInstanceWithGroup("existing", "/tmp/existing", "work")
+	setSessions(home, existing)
+
+	keep := session.NewInstance("keep", "/tmp/keep")
+	skip := session.NewInstance("skip", "/tmp/skip")
+	tracked := session.NewInstance("tracked", "/tmp/tracked")
+
+	home.Update(tmuxSessionsDiscoveredMsg{instances: []*session.Instance{keep, skip, tracked}})
+	if !home.importDialog.IsVisible() {
+		t.Fatal("discovered sessions should open the import dialog")
+	}
+	// Imported some other way while the dialog was open
+	home.sessions.Add(tracked)
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'j'}},
+		{Type: tea.KeySpace, Runes: []rune{' '}},
+	} {
+		home.Update(key)
+	}
+	for home.importDialog.TargetGroup() != "work" {
+		home.Update(tea.KeyMsg{Type: tea.KeyTab})
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if home.importDialog.IsVisible() {
+		t.Error("Enter should close the import dialog")
+	}
+	if home.sessions.Get(keep.ID) == nil || keep.GroupPath != "work" {
+		t.Errorf("checked session should be imported into work, group %q", keep.GroupPath)
+	}
+	if home.sessions.Get(skip.ID) != nil {
+		t.Error("unchecked session shouldn't be imported")
+	}
+	if got := len(home.sessions.All()); got != 3 {
+		t.Errorf("a tmux session that is already tracked shouldn't be imported again, have %d sessions", got)
+	}
+}
+
+func TestImportWithNothingToAdopt(t *testing.T) {
+	home := NewHome()
+	home.Update(tmuxSessionsDiscoveredMsg{})
+	if home.importDialog.IsVisible() {
+		t.Error("the import dialog shouldn't open with nothing to import")
+	}
+}
+
+func TestDispatchQueuedPromptsOnlyOnPrimary(t *testing.T) {
+	db := newTestDB(t)
+	prev := statedb.GetGlobal()
+	statedb.SetGlobal(db)
+	t.Cleanup(func() { statedb.SetGlobal(prev) })
+
+	// Another TUI is the primary
+	now := time.Now().Unix()
+	_, err := db.DB().Exec("INSERT INTO instance_heartbeats (pid, started, heartbeat, is_primary) VALUES (?, ?, ?, 1)",
+		os.Getpid()+1, now, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RegisterInstance(false); err != nil {
+		t.Fatal(err)
+	}
+
+	inst := session.NewInstance("queue-primary", t.TempDir())
+	name := inst.GetTmuxSession().Name
+	if err := exec.Command("tmux", "new-session", "-d", "-s", name, "cat").Run(); err != nil {
+		t.Skipf("tmux not available: %v", err)
+	}
+	t.Cleanup(func() { _ = exec.Command("tmux", "kill-session", "-t", name).Run() })
+	inst.Status = session.StatusWaiting
+	inst.QueuePrompt("hello")
+
+	home := NewHome()
+	home.dispatchQueuedPrompts([]*session.Instance{inst})
+	if got := inst.QueuedPromptCount(); got != 1 {
+		t.Fatalf("a non-primary TUI shouldn't send queued prompts, %d left", got)
+	}
+
+	if _, err := db.DB().Exec("DELETE FROM instance_heartbeats WHERE pid = ?", os.Getpid()+1); err != nil {
+		t.Fatal(err)
+	}
+	home.dispatchQueuedPrompts([]*session.Instance{inst})
+	if got := inst.QueuedPromptCount(); got != 0 {
+		t.Errorf("the primary TUI should send the queued prompt, %d left", got)
+	}
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const importRows = 15 // Sessions shown at once
+
+// ImportDialog lists the tmux sessions agent-deck doesn't manage yet ("i"
+// key), all checked to start with, and the group to adopt them into. Home
+// imports the checked sessions on Enter; the dialog only tracks selection.
+type ImportDialog struct {
+	visible       bool
+	width, height int
+	sessions      []*session.Instance
+	checked       []bool
+	groups        []string // Target groups; "" keeps each session's discovered group
+	group         int
+	cursor        int
+}
+
+// NewImportDialog creates a new import dialog.
+func NewImportDialog() *ImportDialog {
+	return &ImportDialog{}
+}
+
+// Show opens the dialog with the discovered sessions, all checked, and the
+// existing group paths to choose from.
+func (d *ImportDialog) Show(sessions []*session.Instance, groupPaths []string) {
+	d.visible = true
+	d.sessions = sessions
+	d.checked = make([]bool, len(sessions))
+	for i := range d.checked {
+		d.checked[i] = true
+	}
+	d.groups = append([]string{""}, groupPaths...)
+	d.group = 0
+	d.cursor = 0
+}
+
+// Hide closes the dialog and resets state.
+func (d *ImportDialog) Hide() {
+	d.visible = false
+	d.sessions = nil
+	d.checked = nil
+	d.groups = nil
+	d.group = 0
+	d.cursor = 0
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *ImportDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *ImportDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// TargetGroup returns the group to import into, or "" to keep the group
+// each session was discovered with.
+func (d *ImportDialog) TargetGroup() string {
+	if d.group < len(d.groups) {
+		return d.groups[d.group]
+	}
+	return ""
+}
+
+// GetChecked returns the sessions checked for import.
+func (d *ImportDialog) GetChecked() []*session.Instance {
+	var out []*session.Instance
+	for i, inst := range d.sessions {
+		if d.checked[i] {
+			out = append(out, inst)
+		}
+	}
+	return out
+}
+
+// Update handles navigation, checking and choosing the group. Enter is
+// handled by Home.
+func (d *ImportDialog) Update(msg tea.KeyMsg) (*ImportDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		if len(d.sessions) > 0 {
+			d.cursor = (d.cursor + 1) % len(d.sessions)
+		}
+	case "k", "up":
+		if len(d.sessions) > 0 {
+			d.cursor = (d.cursor - 1 + len(d.sessions)) % len(d.sessions)
+		}
+	case " ", "x":
+		if d.cursor < len(d.checked) {
+			d.checked[d.cursor] = !d.checked[d.cursor]
+		}
+	case "a":
+		// Check all, or uncheck all when everything is checked
+		all := len(d.GetChecked()) == len(d.sessions)
+		for i := range d.checked {
+			d.checked[i] = !all
+		}
+	case "tab", "l", "right":
+		if len(d.groups) > 0 {
+			d.group = (d.group + 1) % len(d.groups)
+		}
+	case "shift+tab", "h", "left":
+		if len(d.groups) > 0 {
+			d.group = (d.group - 1 + len(d.groups)) % len(d.groups)
+		}
+	case "esc", "q":
+		d.Hide()
+	}
+	return d, nil
+}
+
+// View renders the import dialog.
+func (d *ImportDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 72
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	group := d.TargetGroup()
+	if group == "" {
+		group = "as discovered"
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Import tmux Sessions"))
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("%d not managed by agent-deck · sessions already imported are hidden", len(d.sessions))))
+	lines = append(lines, "")
+
+	start := max(0, d.cursor-importRows+1)
+	end := min(start+importRows, len(d.sessions))
+	for i := start; i < end; i++ {
+		inst := d.sessions[i]
+		box := "[ ] "
+		if d.checked[i] {
+			box = "[x] "
+		}
+		meta := tildePath(inst.ProjectPath)
+		if inst.GroupPath != "" {
+			meta = inst.GroupPath + " · " + meta
+		}
+		meta = runewidth.Truncate(meta, dialogWidth/2, "...")
+		titleWidth := max(dialogWidth-10-runewidth.StringWidth(meta)-2, 10)
+		title := runewidth.Truncate(inst.Title, titleWidth, "...")
+
+		style, cursor := normalStyle, "  "
+		if i == d.cursor {
+			style, cursor = selectedStyle, "> "
+		}
+		lines = append(lines, cursor+style.Render(box+title)+"  "+dimStyle.Render(meta))
+	}
+	if len(d.sessions) > importRows {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d", d.cursor+1, len(d.sessions))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, normalStyle.Render("Group: ")+selectedStyle.Render("‹ "+group+" ›"))
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render(fmt.Sprintf("Space toggle | a all/none | Tab group | Enter import %d | Esc cancel", len(d.GetChecked()))))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestImportDialog_CheckingAndGroup(t *testing.T) {
+	found := []*session.Instance{
+		{ID: "a", Title: "scratch", ProjectPath: "/srv/scratch"},
+		{ID: "b", Title: "old-agent", ProjectPath: "/srv/app", GroupPath: "recovered"},
+	}
+
+	d := NewImportDialog()
+	d.SetSize(120, 50)
+	d.Show(found, []string{"work", "personal"})
+	if got := len(d.GetChecked()); got != 2 {
+		t.Fatalf("all sessions should start checked, got %d", got)
+	}
+	if d.TargetGroup() != "" {
+		t.Errorf("sessions should keep their discovered group by default, got %q", d.TargetGroup())
+	}
+
+	view := d.View()
+	for _, want := range []string{"[x] scratch", "recovered · /srv/app", "as discovered", "Enter import 2"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q:\n%s", want, view)
+		}
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	if got := d.GetChecked(); len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("space should uncheck the first session, got %+v", got)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.TargetGroup() != "work" {
+		t.Errorf("Tab should pick the first group, got %q", d.TargetGroup())
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	d.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if d.TargetGroup() != "personal" {
+		t.Errorf("Shift+Tab should wrap to the last group, got %q", d.TargetGroup())
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsVisible() || d.GetChecked() != nil {
+		t.Error("Esc should hide and clear the dialog")
+	}
+}
@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// InlineRename edits the selected row's title in place ("r" key): the
+// session or group name in the list turns into a text input. Home applies
+// the new name on Enter; Esc cancels.
+type InlineRename struct {
+	active    bool
+	input     textinput.Model
+	sessionID string // Session being renamed, or "" for a group
+	groupPath string // Group being renamed
+}
+
+// NewInlineRename creates an inactive inline rename.
+func NewInlineRename() *InlineRename {
+	ti := textinput.New()
+	ti.Prompt = ""
+	return &InlineRename{input: ti}
+}
+
+// StartSession starts renaming a session, editing its current title.
+func (r *InlineRename) StartSession(sessionID, title string) {
+	r.start(title)
+	r.sessionID = sessionID
+}
+
+// StartGroup starts renaming a group.
+func (r *InlineRename) StartGroup(path, name string) {
+	r.start(name)
+	r.groupPath = path
+}
+
+func (r *InlineRename) start(value string) {
+	r.active = true
+	r.sessionID = ""
+	r.groupPath = ""
+	r.input.SetValue(value)
+	r.input.CursorEnd()
+	r.input.Focus()
+}
+
+// Stop ends the rename without applying it.
+func (r *InlineRename) Stop() {
+	r.active = false
+	r.input.Blur()
+}
+
+// IsActive returns whether a rename is in progress.
+func (r *InlineRename) IsActive() bool {
+	return r.active
+}
+
+// IsSession returns whether a session (rather than a group) is being renamed.
+func (r *InlineRename) IsSession() bool {
+	return r.sessionID != ""
+}
+
+// SessionID returns the session being renamed.
+func (r *InlineRename) SessionID() string {
+	return r.sessionID
+}
+
+// GroupPath returns the group being renamed.
+func (r *InlineRename) GroupPath() string {
+	return r.groupPath
+}
+
+// Targets reports whether item is the row being renamed.
+func (r *InlineRename) Targets(item session.Item) bool {
+	if !r.active {
+		return false
+	}
+	if r.sessionID != "" {
+		return item.Type == session.ItemTypeSession && item.Session != nil && item.Session.ID == r.sessionID
+	}
+	return item.Type == session.ItemTypeGroup && item.Path == r.groupPath
+}
+
+// Value returns the new name, trimmed.
+func (r *InlineRename) Value() string {
+	return strings.TrimSpace(r.input.Value())
+}
+
+// Validate returns why the new name can't be used, or "".
+func (r *InlineRename) Validate() string {
+	name := r.Value()
+	switch {
+	case name == "" && r.IsSession():
+		return "Session name cannot be empty"
+	case name == "":
+		return "Group name cannot be empty"
+	case len(name) > MaxNameLength:
+		return fmt.Sprintf("Name too long (max %d characters)", MaxNameLength)
+	case !r.IsSession() && strings.Contains(name, "/"):
+		// A "/" would break the group path hierarchy
+		return "Group name cannot contain '/' character"
+	}
+	return ""
+}
+
+// Update passes editing keys to the input. Enter and Esc are handled by Home.
+func (r *InlineRename) Update(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	r.input, cmd = r.input.Update(msg)
+	return cmd
+}
+
+// View renders the input, shown in place of the title.
+func (r *InlineRename) View() string {
+	return r.input.View()
+}
@@ -0,0 +1,343 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// KeyAction names a remappable main-screen action. The string value is the
+// name used in the [keys] section of config.toml.
+type KeyAction string
+
+const (
+	ActionQuit           KeyAction = "quit"
+	ActionUp             KeyAction = "up"
+	ActionDown           KeyAction = "down"
+	ActionHalfPageUp     KeyAction = "half_page_up"
+	ActionHalfPageDown   KeyAction = "half_page_down"
+	ActionPageUp         KeyAction = "page_up"
+	ActionPageDown       KeyAction = "page_down"
+	ActionAttach         KeyAction = "attach"
+	ActionExpand         KeyAction = "expand"
+	ActionCollapse       KeyAction = "collapse"
+	ActionMoveUp         KeyAction = "move_up"
+	ActionMoveDown       KeyAction = "move_down"
+	ActionNew            KeyAction = "new"
+	ActionQuickNew       KeyAction = "quick_new"
+	ActionGroup          KeyAction = "group"
+	ActionRename         KeyAction = "rename"
+	ActionMove           KeyAction = "move"
+	ActionDelete         KeyAction = "delete"
+	ActionUndo           KeyAction = "undo"
+	ActionSearch         KeyAction = "search"
+	ActionGlobalSearch   KeyAction = "global_search"
+	ActionHelp           KeyAction = "help"
+	ActionSettings       KeyAction = "settings"
+	ActionImport         KeyAction = "import"
+	ActionRestart        KeyAction = "restart"
+	ActionFork           KeyAction = "fork"
+	ActionForkDialog     KeyAction = "fork_dialog"
+	ActionMCP            KeyAction = "mcp"
+	ActionSkills         KeyAction = "skills"
+	ActionCopy           KeyAction = "copy"
+	ActionSendOutput     KeyAction = "send_output"
+	ActionMarkUnread     KeyAction = "mark_unread"
+	ActionPreviewMode    KeyAction = "preview_mode"
+	ActionYolo           KeyAction = "yolo"
+	ActionGeminiModel    KeyAction = "gemini_model"
+	ActionWorktreeFinish KeyAction = "worktree_finish"
+	ActionRefresh        KeyAction = "refresh"
+	ActionProfiles       KeyAction = "profiles"
+	ActionArchive        KeyAction = "archive"
+	ActionNotes          KeyAction = "notes"
+	ActionTags           KeyAction = "tags"
+	ActionTagFilter      KeyAction = "tag_filter"
+	ActionMute           KeyAction = "mute"
+	ActionDoNotDisturb   KeyAction = "do_not_disturb"
+	ActionResume         KeyAction = "resume"
+	ActionForkTree       KeyAction = "fork_tree"
+	ActionTranscript     KeyAction = "transcript"
+	ActionPrompt         KeyAction = "prompt"
+	ActionDiff           KeyAction = "diff"
+	ActionCleanup        KeyAction = "cleanup"
+	ActionNarrowList     KeyAction = "narrow_list"
+	ActionWidenList      KeyAction = "widen_list"
+	ActionTogglePreview  KeyAction = "toggle_preview"
+	ActionScrollback     KeyAction = "scrollback"
+	ActionPlainPreview   KeyAction = "plain_preview"
+	ActionGroupSort      KeyAction = "group_sort"
+	ActionRecent         KeyAction = "recent"
+	ActionBottom         KeyAction = "bottom"
+	ActionDashboard      KeyAction = "dashboard"
+	ActionInfo           KeyAction = "info"
+	ActionNotifications  KeyAction = "notifications"
+	ActionErrors         KeyAction = "errors"
+	ActionDensity        KeyAction = "density"
+)
+
+// keyBinding is an action with its default keys. The first key is the
+// canonical key that handleMainKey switches on.
+type keyBinding struct {
+	action KeyAction
+	keys   []string
+}
+
+// defaultKeyBindings lists every remappable action in precedence order:
+// when two configured actions claim the same key, the earlier one wins.
+var defaultKeyBindings = []keyBinding{
+	{ActionQuit, []string{"q", "ctrl+c"}},
+	{ActionUp, []string{"up", "k"}},
+	{ActionDown, []string{"down", "j"}},
+	{ActionHalfPageUp, []string{"ctrl+u"}},
+	{ActionHalfPageDown, []string{"ctrl+d"}},
+	{ActionPageUp, []string{"ctrl+b"}},
+	{ActionPageDown, []string{"ctrl+f"}},
+	{ActionAttach, []string{"enter"}},
+	{ActionExpand, []string{"tab", "l", "right"}},
+	{ActionCollapse, []string{"h", "left"}},
+	{ActionMoveUp, []string{"shift+up", "K"}},
+	{ActionMoveDown, []string{"shift+down", "J"}},
+	{ActionNew, []string{"n"}},
+	{ActionQuickNew, []string{"N"}},
+	{ActionGroup, []string{"g"}},
+	{ActionRename, []string{"r"}},
+	{ActionMove, []string{"M", "shift+m"}},
+	{ActionDelete, []string{"d"}},
+	{ActionUndo, []string{"ctrl+z"}},
+	{ActionSearch, []string{"/"}},
+	{ActionGlobalSearch, []string{"alt+g"}},
+	{ActionHelp, []string{"?"}},
+	{ActionSettings, []string{"S"}},
+	{ActionImport, []string{"i"}},
+	{ActionRestart, []string{"R"}},
+	{ActionFork, []string{"f"}},
+	{ActionForkDialog, []string{"F", "shift+f"}},
+	{ActionMCP, []string{"m"}},
+	{ActionSkills, []string{"s"}},
+	{ActionCopy, []string{"c"}},
+	{ActionSendOutput, []string{"x"}},
+	{ActionMarkUnread, []string{"u"}},
+	{ActionPreviewMode, []string{"v"}},
+	{ActionYolo, []string{"y"}},
+	{ActionGeminiModel, []string{"ctrl+g"}},
+	{ActionWorktreeFinish, []string{"W", "shift+w"}},
+	{ActionRefresh, []string{"ctrl+r"}},
+	{ActionProfiles, []string{"P", "shift+p"}},
+	{ActionArchive, []string{"A", "shift+a"}},
+	{ActionNotes, []string{"e"}},
+	{ActionTags, []string{"t"}},
+	{ActionTagFilter, []string{"T", "shift+t"}},
+	{ActionMute, []string{"b"}},
+	{ActionDoNotDisturb, []string{"B", "shift+b"}},
+	{ActionResume, []string{"U", "shift+u"}},
+	{ActionForkTree, []string{"L", "shift+l"}},
+	{ActionTranscript, []string{"V", "shift+v"}},
+	{ActionPrompt, []string{"p"}},
+	{ActionDiff, []string{"D", "shift+d"}},
+	{ActionCleanup, []string{"C", "shift+c"}},
+	{ActionNarrowList, []string{"<"}},
+	{ActionWidenList, []string{">"}},
+	{ActionTogglePreview, []string{"|"}},
+	{ActionScrollback, []string{"z"}},
+	{ActionPlainPreview, []string{"O", "shift+o"}},
+	{ActionGroupSort, []string{"ctrl+s"}},
+	{ActionRecent, []string{"ctrl+o"}},
+	{ActionBottom, []string{"G", "end"}},
+	{ActionDashboard, []string{"w"}},
+	{ActionInfo, []string{"I", "shift+i"}},
+	{ActionNotifications, []string{"H", "shift+h"}},
+	{ActionErrors, []string{"E", "shift+e"}},
+	{ActionDensity, []string{"="}},
+}
+
+// reservedKeys are handled by handleMainKey but not remappable (escape,
+// group quick-jump digits, and status filters).
+var reservedKeys = map[string]bool{
+	"esc": true,
+	"0":   true, "1": true, "2": true, "3": true, "4": true,
+	"5": true, "6": true, "7": true, "8": true, "9": true,
+	"!": true, "@": true, "#": true, "$": true, "%": true, "^": true,
+	"shift+1": true, "shift+2": true, "shift+3": true, "shift+4": true, "shift+5": true, "shift+6": true,
+}
+
+// Keymap translates pressed keys into the canonical keys handleMainKey
+// understands, so remapping needs no changes to the key switch itself.
+type Keymap struct {
+	byKey       map[string]KeyAction
+	keys        map[KeyAction][]string
+	defaultKeys map[string]bool
+}
+
+// DefaultKeymap returns the keymap with no user overrides.
+func DefaultKeymap() *Keymap {
+	km, _ := NewKeymap(nil)
+	return km
+}
+
+// NewKeymap builds a keymap from [keys] overrides. An overridden action loses
+// its default keys, and defaults of other actions that collide with an
+// override are dropped. Problems (unknown actions, reserved keys, conflicts
+// between overrides, actions left without a key) are returned as warnings;
+// the keymap is always usable.
+func NewKeymap(overrides map[string]session.KeyList) (*Keymap, []string) {
+	km := &Keymap{
+		byKey:       make(map[string]KeyAction),
+		keys:        make(map[KeyAction][]string),
+		defaultKeys: make(map[string]bool),
+	}
+	var warnings []string
+
+	known := make(map[string]bool, len(defaultKeyBindings))
+	for _, b := range defaultKeyBindings {
+		known[string(b.action)] = true
+		for _, key := range b.keys {
+			km.defaultKeys[key] = true
+		}
+	}
+
+	var unknown []string
+	for name := range overrides {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		warnings = append(warnings, fmt.Sprintf("unknown action %q", name))
+	}
+
+	// Explicit overrides claim their keys first
+	for _, b := range defaultKeyBindings {
+		keys, ok := overrides[string(b.action)]
+		if !ok {
+			continue
+		}
+		for _, key := range keys {
+			key = normalizeKeyName(key)
+			switch {
+			case key == "":
+				continue
+			case reservedKeys[key]:
+				warnings = append(warnings, fmt.Sprintf("%s: key %q is reserved", b.action, key))
+				continue
+			}
+			if other, taken := km.byKey[key]; taken {
+				warnings = append(warnings, fmt.Sprintf("key %q is bound to both %s and %s; keeping %s", key, other, b.action, other))
+				continue
+			}
+			km.bind(b.action, key)
+		}
+	}
+
+	// Remaining actions keep whichever default keys are still free
+	for _, b := range defaultKeyBindings {
+		if _, ok := overrides[string(b.action)]; ok {
+			continue
+		}
+		for _, key := range b.keys {
+			if _, taken := km.byKey[key]; !taken {
+				km.bind(b.action, key)
+			}
+		}
+		if len(km.keys[b.action]) == 0 {
+			warnings = append(warnings, fmt.Sprintf("%s has no key left (%s was rebound)", b.action, strings.Join(b.keys, ", ")))
+		}
+	}
+
+	return km, warnings
+}
+
+func (km *Keymap) bind(action KeyAction, key string) {
+	km.byKey[key] = action
+	km.keys[action] = append(km.keys[action], key)
+}
+
+// Resolve maps a pressed key to the canonical key for its action. Keys that
+// are not part of any binding pass through unchanged; default keys whose
+// action was remapped elsewhere resolve to "" so they do nothing.
+func (km *Keymap) Resolve(key string) string {
+	if km == nil {
+		return key
+	}
+	if action, ok := km.byKey[key]; ok {
+		return canonicalKey(action)
+	}
+	if km.defaultKeys[key] {
+		return ""
+	}
+	return key
+}
+
+// Keys returns the keys bound to an action.
+func (km *Keymap) Keys(action KeyAction) []string {
+	if km == nil {
+		for _, b := range defaultKeyBindings {
+			if b.action == action {
+				return b.keys
+			}
+		}
+		return nil
+	}
+	return km.keys[action]
+}
+
+// Label returns the display label of an action's primary key, or "" if the
+// action is unbound.
+func (km *Keymap) Label(action KeyAction) string {
+	keys := km.Keys(action)
+	if len(keys) == 0 {
+		return ""
+	}
+	return keyDisplayName(keys[0])
+}
+
+// canonicalKey returns the default key handleMainKey uses for an action.
+func canonicalKey(action KeyAction) string {
+	for _, b := range defaultKeyBindings {
+		if b.action == action {
+			return b.keys[0]
+		}
+	}
+	return ""
+}
+
+// normalizeKeyName converts config spellings to Bubble Tea key strings.
+func normalizeKeyName(key string) string {
+	key = strings.TrimSpace(key)
+	switch strings.ToLower(key) {
+	case "space":
+		return " "
+	case "return":
+		return "enter"
+	case "escape":
+		return "esc"
+	}
+	return key
+}
+
+// keyDisplayName formats a key for the help bar.
+func keyDisplayName(key string) string {
+	switch key {
+	case "enter":
+		return "Enter"
+	case "tab":
+		return "Tab"
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	if rest, ok := strings.CutPrefix(key, "ctrl+"); ok {
+		return "^" + strings.ToUpper(rest)
+	}
+	return key
+}
@@ -0,0 +1,126 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestDefaultKeymapResolve(t *testing.T) {
+	km := DefaultKeymap()
+
+	tests := map[string]string{
+		"k":      "up",
+		"up":     "up",
+		"l":      "tab",
+		"K":      "shift+up",
+		"d":      "d",
+		"esc":    "esc",
+		"3":      "3",
+		"!":      "!",
+		"ctrl+z": "ctrl+z",
+		"z":      "z", // unbound keys pass through
+	}
+	for key, want := range tests {
+		if got := km.Resolve(key); got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestKeymapOverrides(t *testing.T) {
+	km, warnings := NewKeymap(map[string]session.KeyList{
+		"delete": {"D"},
+		"up":     {"up", "o"},
+		"new":    {"a"},
+	})
+	if len(warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", warnings)
+	}
+
+	tests := map[string]string{
+		"D": "d",  // new delete key
+		"d": "",   // old delete key no longer does anything
+		"o": "up", // extra up key
+		"k": "",   // up defaults replaced
+		"a": "n",
+		"n": "",
+		"j": "down", // untouched actions keep defaults
+	}
+	for key, want := range tests {
+		if got := km.Resolve(key); got != want {
+			t.Errorf("Resolve(%q) = %q, want %q", key, got, want)
+		}
+	}
+
+	if got := km.Label(ActionDelete); got != "D" {
+		t.Errorf("Label(delete) = %q, want %q", got, "D")
+	}
+	if got := km.Label(ActionUp); got != "↑" {
+		t.Errorf("Label(up) = %q, want %q", got, "↑")
+	}
+}
+
+func TestKeymapOverrideTakesDefaultKey(t *testing.T) {
+	// Binding attach to "d" displaces delete's only default key
+	km, warnings := NewKeymap(map[string]session.KeyList{
+		"attach": {"d"},
+	})
+	if got := km.Resolve("d"); got != "enter" {
+		t.Errorf("Resolve(d) = %q, want %q", got, "enter")
+	}
+	if len(km.Keys(ActionDelete)) != 0 {
+		t.Errorf("delete should be unbound, got %v", km.Keys(ActionDelete))
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "delete has no key left") {
+		t.Errorf("warnings = %v, want one 'delete has no key left'", warnings)
+	}
+}
+
+func TestKeymapConflictsAndInvalid(t *testing.T) {
+	km, warnings := NewKeymap(map[string]session.KeyList{
+		"rename": {"x"},
+		"delete": {"x", "X"},
+		"bogus":  {"b"},
+		"copy":   {"esc"},
+	})
+
+	// rename comes first in the table, so it keeps "x"
+	if got := km.Resolve("x"); got != "r" {
+		t.Errorf("Resolve(x) = %q, want %q", got, "r")
+	}
+	if got := km.Resolve("X"); got != "d" {
+		t.Errorf("Resolve(X) = %q, want %q", got, "d")
+	}
+	if got := km.Resolve("esc"); got != "esc" {
+		t.Errorf("Resolve(esc) = %q, want reserved key to pass through", got)
+	}
+
+	joined := strings.Join(warnings, "\n")
+	for _, want := range []string{
+		`unknown action "bogus"`,
+		`key "x" is bound to both rename and delete`,
+		`copy: key "esc" is reserved`,
+		"send_output has no key left",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("warnings missing %q:\n%s", want, joined)
+		}
+	}
+}
+
+func TestKeyDisplayName(t *testing.T) {
+	tests := map[string]string{
+		"enter":  "Enter",
+		"ctrl+z": "^Z",
+		" ":      "Space",
+		"down":   "↓",
+		"D":      "D",
+	}
+	for key, want := range tests {
+		if got := keyDisplayName(key); got != want {
+			t.Errorf("keyDisplayName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// ListDensity is how much the session list shows per session.
+type ListDensity int
+
+const (
+	DensityNormal   ListDensity = iota // One line: status, title, tool and badges
+	DensityCompact                     // One short line: status and title only
+	DensityDetailed                    // A second line with path, branch and last activity
+)
+
+// Next returns the density after d, wrapping around.
+func (d ListDensity) Next() ListDensity {
+	return (d + 1) % 3
+}
+
+// Label returns the density's display name.
+func (d ListDensity) Label() string {
+	switch d {
+	case DensityCompact:
+		return "compact"
+	case DensityDetailed:
+		return "detailed"
+	default:
+		return "normal"
+	}
+}
+
+// itemHeight returns the number of list rows an item takes.
+func (h *Home) itemHeight(item session.Item) int {
+	if h.listDensity == DensityDetailed && item.Type == session.ItemTypeSession && item.Session != nil {
+		return 2
+	}
+	return 1
+}
+
+// itemsFitting returns how many flatItems from index from fit in lines rows.
+// At least one, so the cursor row is always shown.
+func (h *Home) itemsFitting(from, lines int) int {
+	n, used := 0, 0
+	for i := from; i < len(h.flatItems); i++ {
+		used += h.itemHeight(h.flatItems[i])
+		if used > lines && n > 0 {
+			break
+		}
+		n++
+	}
+	return n
+}
+
+// tildePath shortens a path under the home directory to start with "~".
+func tildePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if path == home {
+		return "~"
+	}
+	if rel, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return "~" + string(filepath.Separator) + rel
+	}
+	return path
+}
+
+// renderSessionDetail writes the detailed density's second line for a
+// session: its path, git branch and last activity, under the title.
+func (h *Home) renderSessionDetail(b *strings.Builder, row sessionRow, baseIndent string) {
+	// Continue the tree line past this row unless nothing follows it
+	last := row.lastInGroup
+	if row.subSession {
+		last = row.lastSub
+	}
+	treeStyle := TreeConnectorStyle
+	if row.selected {
+		treeStyle = TreeConnectorSelStyle
+	}
+	connector := treeEmpty
+	if !last {
+		connector = treeStyle.Render(treeLine)
+	}
+
+	parts := []string{truncatePath(row.path, 40)}
+	if row.branch != "" {
+		parts = append(parts, row.branch)
+	} else if row.worktreeBranch != "" {
+		parts = append(parts, row.worktreeBranch)
+	}
+	parts = append(parts, row.lastActive)
+
+	// Line up under the title: past the number column and status icon
+	pad := "   "
+	if row.numbered {
+		pad += "  "
+	}
+	b.WriteString(baseIndent + " " + connector + pad + SessionDetailStyle.Render(strings.Join(parts, " · ")))
+	b.WriteString("\n")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 var mcpDialogLog = logging.ForComponent(logging.CompMCP)
@@ -928,9 +929,7 @@ func (m *MCPDialog) renderColumn(title string, items []MCPItem, selectedIdx int,
 			if item.IsOrphan {
 				name = name + " ⚠"
 			}
-			if len(name) > 24 {
-				name = name[:21] + "..."
-			}
+			name = runewidth.Truncate(name, 24, "...")
 
 			var line string
 			if i == selectedIdx && focused {
@@ -36,20 +36,21 @@ type NewDialog struct {
 	// Worktree support
 	worktreeEnabled bool
 	branchInput     textinput.Model
-	branchAutoSet   bool // true if branch was auto-derived from session name
+	baseInput       textinput.Model // Starting point for a new branch (blank = HEAD)
+	branchAutoSet   bool            // true if branch was auto-derived from session name
 	// Inline validation error displayed inside the dialog
 	validationErr string
 	pathCycler    session.CompletionCycler // Path autocomplete state
+	projectPicker *ProjectPicker           // "Pick a project" mode (ctrl+o)
+	createPath    string                   // Missing path offered for creation; Enter again confirms
+	checkedPath   string                   // Path last checked by updatePathMissing
+	pathMissing   bool                     // checkedPath doesn't exist
 }
 
 // buildPresetCommands returns the list of commands for the picker,
-// including any custom tools from config.toml.
+// including any custom tools and the presets order from config.toml.
 func buildPresetCommands() []string {
-	presets := []string{"", "claude", "gemini", "opencode", "codex"}
-	if customTools := session.GetCustomToolNames(); len(customTools) > 0 {
-		presets = append(presets, customTools...)
-	}
-	return presets
+	return session.GetPresetCommands()
 }
 
 // NewNewDialog creates a new NewDialog instance
@@ -86,11 +87,18 @@ func NewNewDialog() *NewDialog {
 	branchInput.CharLimit = 100
 	branchInput.Width = 40
 
+	// Create base input for new worktree branches
+	baseInput := textinput.New()
+	baseInput.Placeholder = "current HEAD"
+	baseInput.CharLimit = 100
+	baseInput.Width = 40
+
 	dlg := &NewDialog{
 		nameInput:       nameInput,
 		pathInput:       pathInput,
 		commandInput:    commandInput,
 		branchInput:     branchInput,
+		baseInput:       baseInput,
 		claudeOptions:   NewClaudeOptionsPanel(),
 		geminiOptions:   NewYoloOptionsPanel("Gemini", "YOLO mode - auto-approve all"),
 		codexOptions:    NewYoloOptionsPanel("Codex", "YOLO mode - bypass approvals and sandbox"),
@@ -101,6 +109,7 @@ func NewNewDialog() *NewDialog {
 		parentGroupPath: "default",
 		parentGroupName: "default",
 		worktreeEnabled: false,
+		projectPicker:   NewProjectPicker(),
 	}
 	dlg.updateToolOptions()
 	return dlg
@@ -117,11 +126,13 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string) {
 	d.visible = true
 	d.focusIndex = 0
 	d.validationErr = ""
+	d.createPath = ""
 	d.nameInput.SetValue("")
 	d.nameInput.Focus()
 	d.suggestionNavigated = false // reset on show
 	d.pathSuggestionCursor = 0    // reset cursor too
 	d.pathCycler.Reset()          // clear stale autocomplete matches from previous show
+	d.projectPicker.Hide()
 	d.pathInput.Blur()
 	d.claudeOptions.Blur()
 	d.geminiOptions.Blur()
@@ -131,6 +142,7 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string) {
 	// Reset worktree fields
 	d.worktreeEnabled = false
 	d.branchInput.SetValue("")
+	d.baseInput.SetValue("")
 	d.branchAutoSet = false
 	// Set path input to group's default path if provided, otherwise use current working directory
 	if defaultPath != "" {
@@ -141,6 +153,7 @@ func (d *NewDialog) ShowInGroup(groupPath, groupName, defaultPath string) {
 			d.pathInput.SetValue(cwd)
 		}
 	}
+	d.updatePathMissing()
 	// Initialize tool options from global config
 	d.geminiOptions.SetDefaults(false)
 	d.codexOptions.SetDefaults(false)
@@ -191,6 +204,44 @@ func (d *NewDialog) SetPathSuggestions(paths []string) {
 	d.pathInput.SetSuggestions(paths)
 }
 
+// IsPickingProject returns whether the project picker is open
+func (d *NewDialog) IsPickingProject() bool {
+	return d.projectPicker.IsVisible()
+}
+
+// SetProjects fills the project picker with discovered projects
+func (d *NewDialog) SetProjects(projects []session.Project) {
+	if d.projectPicker.IsVisible() {
+		d.projectPicker.SetProjects(projects)
+	}
+}
+
+// ApplyPickedProject closes the project picker, filling in the path and, if
+// it's empty, the name from the highlighted project. It returns false, leaving
+// the picker open, when no project is highlighted.
+func (d *NewDialog) ApplyPickedProject() bool {
+	proj := d.projectPicker.Selected()
+	if proj == nil {
+		return false
+	}
+	d.projectPicker.Hide()
+	d.pathInput.SetValue(proj.Path)
+	d.pathInput.SetCursor(len(proj.Path))
+	d.pathCycler.Reset()
+	d.suggestionNavigated = false
+	if strings.TrimSpace(d.nameInput.Value()) == "" {
+		d.nameInput.SetValue(proj.Name)
+		d.nameInput.SetCursor(len(proj.Name))
+		if d.worktreeEnabled && d.branchAutoSet {
+			d.autoBranchFromName()
+		}
+	}
+	d.validationErr = ""
+	d.updatePathMissing()
+	d.updateFocus()
+	return true
+}
+
 // Show makes the dialog visible (uses default group)
 func (d *NewDialog) Show() {
 	d.ShowInGroup("default", "default", "")
@@ -277,6 +328,12 @@ func (d *NewDialog) GetValuesWithWorktree() (name, path, command, branch string,
 	return
 }
 
+// GetWorktreeBase returns the branch or commit a new worktree branch starts
+// from, or "" for HEAD.
+func (d *NewDialog) GetWorktreeBase() string {
+	return strings.TrimSpace(d.baseInput.Value())
+}
+
 // IsGeminiYoloMode returns whether YOLO mode is enabled for Gemini
 func (d *NewDialog) IsGeminiYoloMode() bool {
 	return d.geminiOptions.GetYoloMode()
@@ -343,6 +400,40 @@ func (d *NewDialog) Validate() string {
 	return "" // Valid
 }
 
+// CheckPath checks that path, as returned by GetValues, is a directory:
+// tmux would start a session in a missing one in the home directory
+// instead. For a missing path it offers to create it, and returns create
+// once the user confirmed by pressing Enter again on the same path.
+func (d *NewDialog) CheckPath(path string) (create bool, errMsg string) {
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		return false, ""
+	case err == nil:
+		return false, "Not a directory: " + tildePath(path)
+	case !os.IsNotExist(err):
+		return false, err.Error()
+	case d.createPath == path:
+		return true, ""
+	default:
+		d.createPath = path
+		return false, tildePath(path) + " doesn't exist. Press Enter again to create it"
+	}
+}
+
+// updatePathMissing rechecks whether the path names a missing directory,
+// for View's hint. It only stats the path when it changed, so View itself
+// does no I/O.
+func (d *NewDialog) updatePathMissing() {
+	_, path, _ := d.GetValues()
+	if path == d.checkedPath {
+		return
+	}
+	d.checkedPath = path
+	_, err := os.Stat(path)
+	d.pathMissing = path != "" && os.IsNotExist(err)
+}
+
 // SetError sets an inline validation error displayed inside the dialog
 func (d *NewDialog) SetError(msg string) {
 	d.validationErr = msg
@@ -356,7 +447,7 @@ func (d *NewDialog) ClearError() {
 // optionsStartIndex returns the focus index where tool options begin.
 func (d *NewDialog) optionsStartIndex() int {
 	if d.worktreeEnabled {
-		return 4 // 0=name, 1=path, 2=command, 3=branch, 4=options
+		return 5 // 0=name, 1=path, 2=command, 3=branch, 4=from, 5=options
 	}
 	return 3 // 0=name, 1=path, 2=command, 3=options
 }
@@ -380,6 +471,7 @@ func (d *NewDialog) updateFocus() {
 	d.pathInput.Blur()
 	d.commandInput.Blur()
 	d.branchInput.Blur()
+	d.baseInput.Blur()
 	d.claudeOptions.Blur()
 	d.geminiOptions.Blur()
 	d.codexOptions.Blur()
@@ -399,6 +491,12 @@ func (d *NewDialog) updateFocus() {
 		} else if d.toolOptions != nil {
 			d.toolOptions.Focus()
 		}
+	case 4:
+		if d.worktreeEnabled {
+			d.baseInput.Focus()
+		} else if d.toolOptions != nil {
+			d.toolOptions.Focus()
+		}
 	default:
 		if d.toolOptions != nil {
 			d.toolOptions.Focus()
@@ -409,9 +507,12 @@ func (d *NewDialog) updateFocus() {
 // getMaxFocusIndex returns the maximum focus index based on current state
 func (d *NewDialog) getMaxFocusIndex() int {
 	if d.worktreeEnabled && d.toolOptions != nil {
+		return 5
+	}
+	if d.worktreeEnabled {
 		return 4
 	}
-	if d.worktreeEnabled || d.toolOptions != nil {
+	if d.toolOptions != nil {
 		return 3
 	}
 	return 2
@@ -419,6 +520,12 @@ func (d *NewDialog) getMaxFocusIndex() int {
 
 // Update handles key messages
 func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
+	d, cmd := d.update(msg)
+	d.updatePathMissing()
+	return d, cmd
+}
+
+func (d *NewDialog) update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 	if !d.visible {
 		return d, nil
 	}
@@ -428,7 +535,31 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if d.projectPicker.IsVisible() {
+			switch msg.String() {
+			case "tab":
+				d.ApplyPickedProject()
+				return d, nil
+			case "esc":
+				d.projectPicker.Hide()
+				d.updateFocus()
+				return d, nil
+			case "enter":
+				// Let parent handle enter (create session from the project)
+				return d, nil
+			}
+			return d, d.projectPicker.Update(msg)
+		}
+
 		switch msg.String() {
+		case "ctrl+o":
+			d.nameInput.Blur()
+			d.pathInput.Blur()
+			d.commandInput.Blur()
+			d.branchInput.Blur()
+			d.baseInput.Blur()
+			return d, d.projectPicker.Show()
+
 		case "tab":
 			// On path field: trigger autocomplete or cycle through matches
 			if d.focusIndex == 1 {
@@ -619,6 +750,12 @@ func (d *NewDialog) Update(msg tea.Msg) (*NewDialog, tea.Cmd) {
 		} else if d.toolOptions != nil {
 			cmd = d.toolOptions.Update(msg)
 		}
+	case 4:
+		if d.worktreeEnabled {
+			d.baseInput, cmd = d.baseInput.Update(msg)
+		} else if d.toolOptions != nil {
+			cmd = d.toolOptions.Update(msg)
+		}
 	default:
 		if d.toolOptions != nil && d.focusIndex >= d.optionsStartIndex() {
 			cmd = d.toolOptions.Update(msg)
@@ -674,6 +811,16 @@ func (d *NewDialog) View() string {
 	content.WriteString(groupInfoStyle.Render("  in group: " + d.parentGroupName))
 	content.WriteString("\n\n")
 
+	// Project picker replaces the fields while open
+	if d.projectPicker.IsVisible() {
+		content.WriteString(d.projectPicker.View())
+		content.WriteString("\n")
+		content.WriteString(lipgloss.NewStyle().Foreground(ColorComment).MarginTop(1).
+			Render("↑↓ select │ Enter create │ Tab use & edit │ Esc back"))
+		return lipgloss.Place(d.width, d.height, lipgloss.Center, lipgloss.Center,
+			dialogStyle.Render(content.String()))
+	}
+
 	// Name input
 	if d.focusIndex == 0 {
 		content.WriteString(activeLabelStyle.Render("▶ Name:"))
@@ -696,6 +843,12 @@ func (d *NewDialog) View() string {
 	content.WriteString(d.pathInput.View())
 	content.WriteString("\n")
 
+	// Flag a missing directory while it's being typed
+	if d.focusIndex == 1 && !d.worktreeEnabled && d.pathMissing {
+		content.WriteString(lipgloss.NewStyle().Foreground(ColorYellow).Render("  ✕ no such directory (Enter to create)"))
+		content.WriteString("\n")
+	}
+
 	// Show path suggestions dropdown when path field is focused
 	if d.focusIndex == 1 && len(d.pathSuggestions) > 0 {
 		suggestionStyle := lipgloss.NewStyle().
@@ -764,10 +917,7 @@ func (d *NewDialog) View() string {
 	// Render command options as consistent pill buttons
 	var cmdButtons []string
 	for i, cmd := range d.presetCommands {
-		displayName := cmd
-		if displayName == "" {
-			displayName = "shell"
-		}
+		displayName := session.GetToolLabel(cmd)
 		// Prepend icon for custom tools
 		if icon := session.GetToolIcon(cmd); cmd != "" && icon != "" {
 			// Only prepend for custom tools (not built-ins which are recognizable by name)
@@ -829,6 +979,16 @@ func (d *NewDialog) View() string {
 		content.WriteString("  ")
 		content.WriteString(d.branchInput.View())
 		content.WriteString("\n")
+
+		if d.focusIndex == 4 {
+			content.WriteString(activeLabelStyle.Render("▶ From:"))
+		} else {
+			content.WriteString(labelStyle.Render("  From:"))
+		}
+		content.WriteString("\n")
+		content.WriteString("  ")
+		content.WriteString(d.baseInput.View())
+		content.WriteString("\n")
 	}
 
 	// Tool options panel
@@ -850,7 +1010,7 @@ func (d *NewDialog) View() string {
 	helpStyle := lipgloss.NewStyle().
 		Foreground(ColorComment). // Use consistent theme color
 		MarginTop(1)
-	helpText := "Tab next/accept │ ↑↓ navigate │ Enter create │ Esc cancel"
+	helpText := "Tab next │ ↑↓ navigate │ ^O pick project │ Enter create │ Esc cancel"
 	if d.focusIndex == 1 {
 		helpText = "Tab autocomplete │ ^N/^P recent │ ↑↓ navigate │ Enter create │ Esc cancel"
 	} else if d.focusIndex == 2 {
@@ -860,6 +1020,8 @@ func (d *NewDialog) View() string {
 		} else {
 			helpText = "←→ command │ w worktree │ Tab next │ Enter create │ Esc cancel"
 		}
+	} else if d.worktreeEnabled && d.focusIndex == 4 {
+		helpText = "Base for a new branch (blank = HEAD) │ Tab next │ Enter create │ Esc cancel"
 	} else if d.toolOptions != nil && d.focusIndex >= d.optionsStartIndex() {
 		helpText = "Space/y toggle │ ↑↓ navigate │ Enter create │ Esc cancel"
 	}
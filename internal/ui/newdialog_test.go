@@ -2,9 +2,11 @@ package ui
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/asheshgoplani/agent-deck/internal/session"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -347,6 +349,121 @@ func TestNewDialog_TypingResetsSuggestionNavigation(t *testing.T) {
 	}
 }
 
+func TestNewDialog_ProjectPicker(t *testing.T) {
+	d := NewNewDialog()
+	d.Show()
+
+	d, cmd := d.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if cmd == nil || !d.IsPickingProject() {
+		t.Fatal("ctrl+o should open the project picker and start discovery")
+	}
+	d.SetProjects([]session.Project{
+		{Name: "api", Path: "/code/api"},
+		{Name: "web", Path: "/code/web"},
+		{Name: "docs", Path: "/code/web-docs"},
+	})
+
+	// Names match before paths
+	for _, r := range "web" {
+		d, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := len(d.projectPicker.matches); got != 2 {
+		t.Fatalf("filter \"web\" matched %d projects, want 2", got)
+	}
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := d.projectPicker.Selected(); got == nil || got.Name != "docs" {
+		t.Fatalf("selected %+v, want docs", got)
+	}
+	if !strings.Contains(d.View(), "/code/web-docs") {
+		t.Error("View should list the matching projects")
+	}
+
+	// Tab fills the fields and returns to the form
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.IsPickingProject() {
+		t.Error("Tab should close the picker")
+	}
+	name, path, _ := d.GetValues()
+	if name != "docs" || path != "/code/web-docs" {
+		t.Errorf("GetValues = %q, %q; want docs, /code/web-docs", name, path)
+	}
+
+	// A typed name is kept; Esc only closes the picker
+	d.nameInput.SetValue("mine")
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	d.SetProjects([]session.Project{{Name: "api", Path: "/code/api"}})
+	if !d.ApplyPickedProject() {
+		t.Fatal("ApplyPickedProject should succeed with a highlighted project")
+	}
+	if name, path, _ := d.GetValues(); name != "mine" || path != "/code/api" {
+		t.Errorf("GetValues = %q, %q; want mine, /code/api", name, path)
+	}
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	d, _ = d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsPickingProject() || !d.IsVisible() {
+		t.Error("Esc should close the picker but not the dialog")
+	}
+}
+
+func TestNewDialog_CheckPath(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "new", "project")
+
+	d := NewNewDialog()
+	d.Show()
+	if create, msg := d.CheckPath(dir); create || msg != "" {
+		t.Errorf("CheckPath(dir) = %v, %q; want false, \"\"", create, msg)
+	}
+	if _, msg := d.CheckPath(file); !strings.Contains(msg, "Not a directory") {
+		t.Errorf("CheckPath(file) message = %q", msg)
+	}
+
+	// A missing path is offered for creation, and confirmed by a second check
+	if create, msg := d.CheckPath(missing); create || !strings.Contains(msg, "doesn't exist") {
+		t.Errorf("first CheckPath(missing) = %v, %q; want an offer to create it", create, msg)
+	}
+	if create, msg := d.CheckPath(missing); !create || msg != "" {
+		t.Errorf("second CheckPath(missing) = %v, %q; want true, \"\"", create, msg)
+	}
+
+	// Editing the path or reopening the dialog asks again
+	if create, _ := d.CheckPath(missing + "2"); create {
+		t.Error("a different missing path should need its own confirmation")
+	}
+	d.Show()
+	if create, _ := d.CheckPath(missing + "2"); create {
+		t.Error("reopening the dialog should reset the confirmation")
+	}
+}
+
+func TestNewDialog_View_FlagsMissingPath(t *testing.T) {
+	d := NewNewDialog()
+	d.SetSize(100, 50)
+	d.Show()
+	d.focusIndex = 1
+	d.updateFocus()
+
+	// The path is checked as it's typed, not in View
+	typeKey := func(r rune) {
+		d.pathInput.CursorEnd()
+		d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	d.pathInput.SetValue(t.TempDir())
+	typeKey('/')
+	if strings.Contains(d.View(), "no such directory") {
+		t.Error("an existing directory should not be flagged")
+	}
+	d.pathInput.SetValue(filepath.Join(t.TempDir(), "typ"))
+	typeKey('o')
+	if !strings.Contains(d.View(), "no such directory") {
+		t.Error("a missing directory should be flagged")
+	}
+}
+
 // ===== Worktree Support Tests =====
 
 func TestNewDialog_WorktreeToggle(t *testing.T) {
@@ -556,7 +673,7 @@ func TestNewDialog_TabNavigationWithWorktree(t *testing.T) {
 	dialog.focusIndex = 0
 	dialog.worktreeEnabled = true
 
-	// Tab through all fields: 0 -> 1 -> 2 -> 3 -> 0
+	// Tab through all fields: 0 -> 1 -> 2 -> 3 -> 4 -> 0
 	dialog, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyTab})
 	if dialog.focusIndex != 1 {
 		t.Errorf("After first Tab, focusIndex = %d, want 1", dialog.focusIndex)
@@ -572,9 +689,36 @@ func TestNewDialog_TabNavigationWithWorktree(t *testing.T) {
 		t.Errorf("After third Tab, focusIndex = %d, want 3 (branch field)", dialog.focusIndex)
 	}
 
+	dialog, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if dialog.focusIndex != 4 {
+		t.Errorf("After fourth Tab, focusIndex = %d, want 4 (from field)", dialog.focusIndex)
+	}
+
 	dialog, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyTab})
 	if dialog.focusIndex != 0 {
-		t.Errorf("After fourth Tab, focusIndex = %d, want 0 (wrap around)", dialog.focusIndex)
+		t.Errorf("After fifth Tab, focusIndex = %d, want 0 (wrap around)", dialog.focusIndex)
+	}
+}
+
+func TestNewDialog_WorktreeBase(t *testing.T) {
+	dialog := NewNewDialog()
+	dialog.SetSize(80, 40)
+	dialog.Show()
+	dialog.worktreeEnabled = true
+	dialog.focusIndex = 4
+	dialog.updateFocus()
+
+	dialog, _ = dialog.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" release/1.2 ")})
+	if got := dialog.GetWorktreeBase(); got != "release/1.2" {
+		t.Errorf("GetWorktreeBase = %q, want %q", got, "release/1.2")
+	}
+	if !strings.Contains(dialog.View(), "From:") {
+		t.Error("View should contain 'From:' label when worktree enabled")
+	}
+
+	dialog.ShowInGroup("default", "default", "")
+	if dialog.GetWorktreeBase() != "" {
+		t.Error("reopening should clear the base")
 	}
 }
 
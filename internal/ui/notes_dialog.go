@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// notesCharLimit caps notes edited in the TUI; the CLI accepts longer text.
+const notesCharLimit = 500
+
+// NotesDialog edits a session's free-form notes ("e" key). Notes are shown
+// in the preview pane header.
+type NotesDialog struct {
+	visible       bool
+	width, height int
+	sessionID     string
+	title         string
+	input         textinput.Model
+}
+
+// NewNotesDialog creates a new notes dialog.
+func NewNotesDialog() *NotesDialog {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. fixing flaky auth test, waiting on review"
+	ti.CharLimit = notesCharLimit
+	ti.Width = 50
+
+	return &NotesDialog{input: ti}
+}
+
+// Show opens the dialog for a session with its current notes.
+func (d *NotesDialog) Show(sessionID, title, notes string) {
+	d.visible = true
+	d.sessionID = sessionID
+	d.title = title
+	// textinput is single-line; multi-line notes set via the CLI are joined
+	d.input.SetValue(strings.Join(strings.Fields(notes), " "))
+	d.input.CursorEnd()
+	d.input.Focus()
+}
+
+// Hide closes the dialog.
+func (d *NotesDialog) Hide() {
+	d.visible = false
+	d.sessionID = ""
+	d.input.Blur()
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *NotesDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *NotesDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// SessionID returns the ID of the session being edited.
+func (d *NotesDialog) SessionID() string {
+	return d.sessionID
+}
+
+// GetValue returns the edited notes, trimmed.
+func (d *NotesDialog) GetValue() string {
+	return strings.TrimSpace(d.input.Value())
+}
+
+// Update handles text input. Enter and Esc are handled by Home.
+func (d *NotesDialog) Update(msg tea.KeyMsg) (*NotesDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return d, cmd
+}
+
+// View renders the notes dialog.
+func (d *NotesDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	dialogWidth := 60
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+	d.input.Width = dialogWidth - 8
+
+	titleStyle := DialogTitleStyle.Width(dialogWidth - 4)
+	subtitleStyle := lipgloss.NewStyle().Foreground(ColorCyan)
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+
+	content := lipgloss.JoinVertical(
+		lipgloss.Center,
+		titleStyle.Render("Session Notes"),
+		subtitleStyle.Render(d.title),
+		"",
+		d.input.View(),
+		"",
+		hintStyle.Render("Enter save │ Ctrl+U clear │ Esc cancel"),
+	)
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(content)
+
+	return centerInScreen(box, d.width, d.height)
+}
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestNotesDialog_EditNotes(t *testing.T) {
+	d := NewNotesDialog()
+	if d.IsVisible() {
+		t.Fatal("new dialog should be hidden")
+	}
+
+	// Multi-line notes from the CLI are joined for the single-line editor
+	d.Show("sess-1", "My Project", "fixing flaky auth test\nwaiting on review")
+	if !d.IsVisible() || d.SessionID() != "sess-1" {
+		t.Fatal("dialog should be visible for sess-1 after Show")
+	}
+	if got := d.GetValue(); got != "fixing flaky auth test waiting on review" {
+		t.Errorf("GetValue = %q", got)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(" (PR 42) ")})
+	if got := d.GetValue(); got != "fixing flaky auth test waiting on review (PR 42)" {
+		t.Errorf("after typing: GetValue = %q", got)
+	}
+
+	d.SetSize(100, 40)
+	if view := d.View(); !strings.Contains(view, "Session Notes") || !strings.Contains(view, "My Project") {
+		t.Errorf("view should show the title and session name:\n%s", view)
+	}
+
+	d.Hide()
+	if d.IsVisible() || d.SessionID() != "" || d.View() != "" {
+		t.Error("Hide should close the dialog and forget the session")
+	}
+}
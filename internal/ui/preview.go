@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 // Preview shows session terminal content
@@ -68,10 +69,9 @@ func (p *Preview) View() string {
 		for _, line := range lines {
 			// Truncate long lines
 			maxWidth := p.width - 4
-			if maxWidth > 0 && len(line) > maxWidth {
-				truncateAt := maxWidth - 3
-				if truncateAt > 0 {
-					line = line[:truncateAt] + "..."
+			if maxWidth > 0 && runewidth.StringWidth(line) > maxWidth {
+				if maxWidth > 3 {
+					line = runewidth.Truncate(line, maxWidth, "...")
 				} else {
 					line = "..."
 				}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ProfilePickerDialog lists profiles so the user can switch to another one.
+// Switching restarts the TUI on the chosen profile (see Home.SwitchProfile).
+type ProfilePickerDialog struct {
+	visible       bool
+	width, height int
+	profiles      []string
+	current       string
+	cursor        int
+}
+
+// NewProfilePickerDialog creates a new profile picker dialog.
+func NewProfilePickerDialog() *ProfilePickerDialog {
+	return &ProfilePickerDialog{}
+}
+
+// Show opens the picker with the cursor on the current profile.
+func (d *ProfilePickerDialog) Show(profiles []string, current string) {
+	d.visible = true
+	d.current = current
+	d.profiles = profiles
+	d.cursor = 0
+	for i, p := range profiles {
+		if p == current {
+			d.cursor = i
+			break
+		}
+	}
+}
+
+// Hide closes the dialog and resets state.
+func (d *ProfilePickerDialog) Hide() {
+	d.visible = false
+	d.cursor = 0
+	d.profiles = nil
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *ProfilePickerDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *ProfilePickerDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// GetSelected returns the profile at the current cursor position, or "".
+func (d *ProfilePickerDialog) GetSelected() string {
+	if len(d.profiles) == 0 || d.cursor >= len(d.profiles) {
+		return ""
+	}
+	return d.profiles[d.cursor]
+}
+
+// Update handles key events for the picker.
+func (d *ProfilePickerDialog) Update(msg tea.KeyMsg) (*ProfilePickerDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+
+	switch msg.String() {
+	case "j", "down":
+		if len(d.profiles) > 0 {
+			d.cursor = (d.cursor + 1) % len(d.profiles)
+		}
+	case "k", "up":
+		if len(d.profiles) > 0 {
+			d.cursor = (d.cursor - 1 + len(d.profiles)) % len(d.profiles)
+		}
+	case "esc":
+		d.Hide()
+	case "enter":
+		// Selection confirmed: parent handles the action
+	}
+
+	return d, nil
+}
+
+// View renders the profile picker dialog.
+func (d *ProfilePickerDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent).
+		MarginBottom(1)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	currentStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Switch Profile"))
+
+	if len(d.profiles) == 0 {
+		lines = append(lines, normalStyle.Render("No profiles found"))
+	} else {
+		for i, p := range d.profiles {
+			label := p
+			if i == d.cursor {
+				label = "> " + selectedStyle.Render(label)
+			} else {
+				label = "  " + normalStyle.Render(label)
+			}
+			if p == d.current {
+				label += currentStyle.Render(" (current)")
+			}
+			lines = append(lines, label)
+		}
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("Enter switch | Esc cancel | j/k navigate"))
+
+	content := strings.Join(lines, "\n")
+
+	dialogWidth := 40
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = d.width - 10
+		if dialogWidth < 30 {
+			dialogWidth = 30
+		}
+	}
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(content)
+
+	return centerInScreen(box, d.width, d.height)
+}
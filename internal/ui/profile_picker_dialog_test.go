@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestProfilePicker_ShowSelectsCurrent(t *testing.T) {
+	d := NewProfilePickerDialog()
+	if d.IsVisible() || d.GetSelected() != "" {
+		t.Fatal("new dialog should be hidden with nothing selected")
+	}
+
+	d.Show([]string{"default", "personal", "work"}, "work")
+	if !d.IsVisible() {
+		t.Fatal("dialog should be visible after Show")
+	}
+	if got := d.GetSelected(); got != "work" {
+		t.Errorf("cursor should start on current profile, got %q", got)
+	}
+
+	// Wraps around from the last entry
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if got := d.GetSelected(); got != "default" {
+		t.Errorf("after j: got %q, want default", got)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'k'}})
+	if got := d.GetSelected(); got != "work" {
+		t.Errorf("after k: got %q, want work", got)
+	}
+
+	d.SetSize(100, 40)
+	view := d.View()
+	if !strings.Contains(view, "personal") || !strings.Contains(view, "(current)") {
+		t.Errorf("view should list profiles and mark the current one:\n%s", view)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsVisible() {
+		t.Error("esc should hide the dialog")
+	}
+}
+
+func TestProfilePicker_EnterRequestsSwitch(t *testing.T) {
+	h := NewHome()
+	h.profile = "default"
+	h.profilePickerDialog.Show([]string{"default", "work"}, "default")
+
+	// Choosing the current profile just closes the picker
+	h.handleProfilePickerDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if h.SwitchProfile() != "" {
+		t.Errorf("selecting the current profile should not switch, got %q", h.SwitchProfile())
+	}
+
+	h.profilePickerDialog.Show([]string{"default", "work"}, "default")
+	h.handleProfilePickerDialogKey(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd := h.handleProfilePickerDialogKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if h.SwitchProfile() != "work" {
+		t.Errorf("SwitchProfile = %q, want work", h.SwitchProfile())
+	}
+	if cmd == nil {
+		t.Error("switching profile should quit the TUI")
+	}
+	if h.profilePickerDialog.IsVisible() {
+		t.Error("picker should close after selection")
+	}
+}
@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// projectPickerRows is how many projects the picker shows at once.
+const projectPickerRows = 8
+
+// projectsDiscoveredMsg carries the projects found for the picker
+type projectsDiscoveredMsg struct {
+	projects []session.Project
+}
+
+// ProjectPicker is the New Session dialog's "pick a project" mode (ctrl+o):
+// a filterable list of the git repositories under [projects] roots
+type ProjectPicker struct {
+	visible  bool
+	loading  bool
+	filter   textinput.Model
+	projects []session.Project // All discovered projects
+	matches  []session.Project // Projects matching the filter
+	cursor   int
+	roots    []string // Searched roots, for the empty-list hint
+}
+
+// NewProjectPicker creates a hidden project picker
+func NewProjectPicker() *ProjectPicker {
+	filter := textinput.New()
+	filter.Placeholder = "filter projects"
+	filter.CharLimit = 100
+	filter.Width = 40
+	return &ProjectPicker{filter: filter}
+}
+
+// Show opens the picker and returns a command that discovers projects in
+// the background
+func (p *ProjectPicker) Show() tea.Cmd {
+	settings := session.GetProjectsSettings()
+	p.visible = true
+	p.loading = true
+	p.projects = nil
+	p.matches = nil
+	p.cursor = 0
+	p.roots = settings.GetRoots()
+	p.filter.SetValue("")
+	p.filter.Focus()
+
+	roots, depth := p.roots, settings.GetMaxDepth()
+	return func() tea.Msg {
+		return projectsDiscoveredMsg{projects: session.DiscoverProjects(roots, depth)}
+	}
+}
+
+// Hide closes the picker
+func (p *ProjectPicker) Hide() {
+	p.visible = false
+	p.loading = false
+	p.filter.Blur()
+}
+
+// IsVisible returns whether the picker is open
+func (p *ProjectPicker) IsVisible() bool {
+	return p.visible
+}
+
+// SetProjects fills the picker with discovered projects
+func (p *ProjectPicker) SetProjects(projects []session.Project) {
+	p.loading = false
+	p.projects = projects
+	p.applyFilter()
+}
+
+// Selected returns the highlighted project, or nil if none matches
+func (p *ProjectPicker) Selected() *session.Project {
+	if p.cursor < 0 || p.cursor >= len(p.matches) {
+		return nil
+	}
+	return &p.matches[p.cursor]
+}
+
+// applyFilter keeps the projects whose name or path contains the filter,
+// ignoring case, with names matching first.
+func (p *ProjectPicker) applyFilter() {
+	query := strings.ToLower(strings.TrimSpace(p.filter.Value()))
+	p.matches = nil
+	var byPath []session.Project
+	for _, proj := range p.projects {
+		switch {
+		case strings.Contains(strings.ToLower(proj.Name), query):
+			p.matches = append(p.matches, proj)
+		case strings.Contains(strings.ToLower(proj.Path), query):
+			byPath = append(byPath, proj)
+		}
+	}
+	p.matches = append(p.matches, byPath...)
+	p.cursor = 0
+}
+
+// Update handles keys while the picker is open. Enter, Tab and Esc are
+// left to the dialog.
+func (p *ProjectPicker) Update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "down", "ctrl+n":
+		if len(p.matches) > 0 {
+			p.cursor = (p.cursor + 1) % len(p.matches)
+		}
+		return nil
+	case "up", "ctrl+p":
+		if len(p.matches) > 0 {
+			p.cursor = (p.cursor - 1 + len(p.matches)) % len(p.matches)
+		}
+		return nil
+	}
+
+	oldFilter := p.filter.Value()
+	var cmd tea.Cmd
+	p.filter, cmd = p.filter.Update(msg)
+	if p.filter.Value() != oldFilter {
+		p.applyFilter()
+	}
+	return cmd
+}
+
+// View renders the filter and the list of matching projects
+func (p *ProjectPicker) View() string {
+	dimStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	nameStyle := lipgloss.NewStyle().Foreground(ColorText)
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorCyan).Bold(true)
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Foreground(ColorCyan).Bold(true).Render("▶ Project:"))
+	b.WriteString("\n  ")
+	b.WriteString(p.filter.View())
+	b.WriteString("\n\n")
+
+	switch {
+	case p.loading:
+		b.WriteString(dimStyle.Render("  Scanning " + strings.Join(p.roots, ", ") + "..."))
+		b.WriteString("\n")
+		return b.String()
+	case len(p.projects) == 0:
+		b.WriteString(dimStyle.Render("  No git repositories under " + strings.Join(p.roots, ", ")))
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render("  (set roots under [projects] in config.toml)"))
+		b.WriteString("\n")
+		return b.String()
+	case len(p.matches) == 0:
+		b.WriteString(dimStyle.Render("  No matching projects"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
+	// Scrolling window that follows the cursor
+	start := 0
+	if p.cursor >= projectPickerRows {
+		start = p.cursor - projectPickerRows + 1
+	}
+	end := min(start+projectPickerRows, len(p.matches))
+	if start > 0 {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("    ↑ %d more above", start)))
+		b.WriteString("\n")
+	}
+	for i := start; i < end; i++ {
+		proj := p.matches[i]
+		style, prefix := nameStyle, "    "
+		if i == p.cursor {
+			style, prefix = selectedStyle, "  ▶ "
+		}
+		b.WriteString(style.Render(prefix + proj.Name))
+		b.WriteString(dimStyle.Render("  " + tildePath(proj.Path)))
+		b.WriteString("\n")
+	}
+	if end < len(p.matches) {
+		b.WriteString(dimStyle.Render(fmt.Sprintf("    ↓ %d more below", len(p.matches)-end)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// promptQueueShown is how many queued prompts the dialog lists.
+const promptQueueShown = 5
+
+// promptSnippetRows is how many snippets the picker shows at once.
+const promptSnippetRows = 10
+
+// PromptDialog is a one-line input for sending a prompt to a session without
+// attaching, or queueing it until the agent is ready ("p" key). Home sends or
+// queues the prompt; the dialog only tracks input and mode. ctrl+o opens a
+// picker over the configured snippets.
+type PromptDialog struct {
+	visible       bool
+	width, height int
+	sessionID     string
+	title         string
+	input         textinput.Model
+	queueMode     bool     // Enter queues instead of sending now
+	queued        []string // The session's pending prompts
+
+	snippets      []session.Snippet // Already expanded for the session
+	picking       bool              // Snippet picker is open
+	snippetCursor int
+}
+
+// NewPromptDialog creates a new prompt dialog.
+func NewPromptDialog() *PromptDialog {
+	ti := textinput.New()
+	ti.Placeholder = "follow-up for the agent"
+	ti.CharLimit = 4000
+	ti.Width = 60
+
+	return &PromptDialog{input: ti}
+}
+
+// Show opens the dialog for a session with an empty input. queued lists the
+// session's pending prompts; queueMode picks what Enter does first.
+func (d *PromptDialog) Show(sessionID, title string, queued []string, queueMode bool) {
+	d.visible = true
+	d.sessionID = sessionID
+	d.title = title
+	d.queued = queued
+	d.queueMode = queueMode
+	d.picking = false
+	d.snippetCursor = 0
+	d.input.SetValue("")
+	d.input.Focus()
+}
+
+// SetSnippets replaces the snippets offered by the picker.
+func (d *PromptDialog) SetSnippets(snippets []session.Snippet) {
+	d.snippets = snippets
+	d.snippetCursor = 0
+}
+
+// PickingSnippet reports whether the snippet picker is open.
+func (d *PromptDialog) PickingSnippet() bool {
+	return d.picking
+}
+
+// SelectedSnippet returns the snippet under the picker cursor.
+func (d *PromptDialog) SelectedSnippet() (session.Snippet, bool) {
+	if !d.picking || d.snippetCursor >= len(d.snippets) {
+		return session.Snippet{}, false
+	}
+	return d.snippets[d.snippetCursor], true
+}
+
+// SetQueued replaces the listed pending prompts.
+func (d *PromptDialog) SetQueued(queued []string) {
+	d.queued = queued
+}
+
+// QueueMode reports whether Enter queues the prompt instead of sending it.
+func (d *PromptDialog) QueueMode() bool {
+	return d.queueMode
+}
+
+// Hide closes the dialog and resets state.
+func (d *PromptDialog) Hide() {
+	d.visible = false
+	d.sessionID = ""
+	d.queued = nil
+	d.snippets = nil
+	d.picking = false
+	d.input.Blur()
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *PromptDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *PromptDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// SessionID returns the ID of the session the prompt is for.
+func (d *PromptDialog) SessionID() string {
+	return d.sessionID
+}
+
+// GetPrompt returns the typed prompt, trimmed.
+func (d *PromptDialog) GetPrompt() string {
+	return strings.TrimSpace(d.input.Value())
+}
+
+// Update handles text input, Tab (send/queue toggle) and the snippet
+// picker. Enter, Esc and ctrl+x outside the picker, and sending a snippet
+// directly, are handled by Home.
+func (d *PromptDialog) Update(msg tea.KeyMsg) (*PromptDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	if d.picking {
+		d.updatePicker(msg)
+		return d, nil
+	}
+	switch msg.String() {
+	case "tab":
+		d.queueMode = !d.queueMode
+		return d, nil
+	case "ctrl+o":
+		d.picking = true
+		return d, nil
+	}
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return d, cmd
+}
+
+// updatePicker moves the snippet cursor; Enter inserts the snippet into the
+// input and Esc goes back to typing.
+func (d *PromptDialog) updatePicker(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "j", "down", "ctrl+n":
+		if len(d.snippets) > 0 {
+			d.snippetCursor = (d.snippetCursor + 1) % len(d.snippets)
+		}
+	case "k", "up", "ctrl+p":
+		if len(d.snippets) > 0 {
+			d.snippetCursor = (d.snippetCursor - 1 + len(d.snippets)) % len(d.snippets)
+		}
+	case "enter":
+		if s, ok := d.SelectedSnippet(); ok {
+			value := d.input.Value()
+			if strings.TrimSpace(value) != "" && !strings.HasSuffix(value, " ") {
+				value += " "
+			}
+			d.input.SetValue(value + s.Text)
+			d.input.CursorEnd()
+		}
+		d.picking = false
+	case "esc", "ctrl+o":
+		d.picking = false
+	}
+}
+
+// View renders the prompt dialog.
+func (d *PromptDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	dialogWidth := 72
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	titleStyle := DialogTitleStyle.Width(dialogWidth - 4)
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+
+	d.input.Width = dialogWidth - 8
+	content := lipgloss.NewStyle().Foreground(ColorCyan).Render(d.title) + "\n\n" + d.input.View()
+
+	if d.picking {
+		content += "\n\n" + d.snippetList(dialogWidth)
+		sendHint := "s send now"
+		if d.queueMode {
+			sendHint = "s queue"
+		}
+		dialogContent := lipgloss.JoinVertical(
+			lipgloss.Left,
+			titleStyle.Render("Insert Snippet"),
+			"",
+			content,
+			"",
+			hintStyle.Render("Enter insert │ "+sendHint+" │ Esc back"),
+		)
+		return centerInScreen(DialogBoxStyle.Width(dialogWidth).Render(dialogContent), d.width, d.height)
+	}
+
+	if len(d.queued) > 0 {
+		lines := []string{"", dimStyle.Render(fmt.Sprintf("Queued (%d):", len(d.queued)))}
+		for i, p := range d.queued[:min(len(d.queued), promptQueueShown)] {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d. %s", i+1, runewidth.Truncate(p, dialogWidth-12, "…"))))
+		}
+		if extra := len(d.queued) - promptQueueShown; extra > 0 {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("  +%d more", extra)))
+		}
+		content += "\n" + strings.Join(lines, "\n")
+	}
+
+	title, hint := "Send Prompt", "Enter send │ Tab queue instead │ ctrl+o snippets │ Esc cancel"
+	if d.queueMode {
+		title, hint = "Queue Prompt", "Enter queue │ Tab send now │ ctrl+o snippets │ ctrl+x clear queue │ Esc cancel"
+	}
+
+	dialogContent := lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render(title),
+		"",
+		content,
+		"",
+		hintStyle.Render(hint),
+	)
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(dialogContent)
+
+	return centerInScreen(box, d.width, d.height)
+}
+
+// snippetList renders the picker rows: name and a one-line preview.
+func (d *PromptDialog) snippetList(dialogWidth int) string {
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	if len(d.snippets) == 0 {
+		return dimStyle.Render("No snippets yet. Add them under [snippets] in config.toml")
+	}
+
+	nameWidth := 0
+	for _, s := range d.snippets {
+		nameWidth = max(nameWidth, runewidth.StringWidth(s.Name))
+	}
+	nameWidth = min(nameWidth, 20)
+
+	var lines []string
+	start := max(0, d.snippetCursor-promptSnippetRows+1)
+	end := min(start+promptSnippetRows, len(d.snippets))
+	for i := start; i < end; i++ {
+		s := d.snippets[i]
+		name := runewidth.FillRight(runewidth.Truncate(s.Name, nameWidth, "…"), nameWidth)
+		preview := strings.Join(strings.Fields(s.Text), " ")
+		preview = runewidth.Truncate(preview, max(dialogWidth-nameWidth-12, 10), "…")
+		if i == d.snippetCursor {
+			lines = append(lines, lipgloss.NewStyle().Foreground(ColorAccent).Bold(true).Render("> "+name)+"  "+dimStyle.Render(preview))
+		} else {
+			lines = append(lines, lipgloss.NewStyle().Foreground(ColorText).Render("  "+name)+"  "+dimStyle.Render(preview))
+		}
+	}
+	if len(d.snippets) > promptSnippetRows {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d", d.snippetCursor+1, len(d.snippets))))
+	}
+	return strings.Join(lines, "\n")
+}
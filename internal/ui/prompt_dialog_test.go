@@ -0,0 +1,87 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestPromptDialog_Input(t *testing.T) {
+	d := NewPromptDialog()
+	d.Show("sess-1", "My Project", nil, false)
+	if !d.IsVisible() || d.SessionID() != "sess-1" {
+		t.Fatal("Show should open the dialog for sess-1")
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("  now add tests  ")})
+	if got := d.GetPrompt(); got != "now add tests" {
+		t.Errorf("GetPrompt = %q, want trimmed input", got)
+	}
+
+	d.Hide()
+	d.Show("sess-2", "Other", nil, false)
+	if d.GetPrompt() != "" {
+		t.Error("reopening should start with an empty prompt")
+	}
+}
+
+func TestPromptDialog_QueueMode(t *testing.T) {
+	d := NewPromptDialog()
+	d.SetSize(120, 40)
+	d.Show("sess-1", "My Project", []string{"run tests", "open a PR"}, true)
+	if !d.QueueMode() {
+		t.Fatal("Show should honour the initial queue mode")
+	}
+	if view := d.View(); !strings.Contains(view, "Queued (2)") || !strings.Contains(view, "2. open a PR") {
+		t.Errorf("queue mode should list pending prompts:\n%s", view)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if d.QueueMode() {
+		t.Error("Tab should switch to send mode")
+	}
+	if d.GetPrompt() != "" {
+		t.Error("Tab must not be typed into the prompt")
+	}
+}
+
+func TestPromptDialog_SnippetPicker(t *testing.T) {
+	d := NewPromptDialog()
+	d.SetSize(120, 40)
+	d.Show("sess-1", "My Project", nil, false)
+	d.SetSnippets([]session.Snippet{
+		{Name: "review", Text: "review main"},
+		{Name: "tests", Text: "run the tests"},
+	})
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("please")})
+	d.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !d.PickingSnippet() {
+		t.Fatal("ctrl+o should open the snippet picker")
+	}
+	if view := d.View(); !strings.Contains(view, "Insert Snippet") || !strings.Contains(view, "run the tests") {
+		t.Errorf("picker should list snippets:\n%s", view)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if s, ok := d.SelectedSnippet(); !ok || s.Name != "tests" {
+		t.Errorf("SelectedSnippet = %+v, want tests", s)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if d.PickingSnippet() {
+		t.Error("Enter should close the picker")
+	}
+	if got := d.GetPrompt(); got != "please run the tests" {
+		t.Errorf("GetPrompt = %q, want snippet appended to typed text", got)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.PickingSnippet() || !d.IsVisible() {
+		t.Error("Esc should return from the picker to the input")
+	}
+}
@@ -0,0 +1,153 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// quickSwitcherOrder ranks statuses in the quick switcher: sessions that need
+// attention first, errored sessions last.
+var quickSwitcherOrder = map[session.Status]int{
+	session.StatusNeedsInput: 0,
+	session.StatusWaiting:    1,
+	session.StatusRunning:    2,
+	session.StatusStarting:   3,
+	session.StatusIdle:       4,
+	session.StatusError:      5,
+}
+
+// QuickSwitcher is a standalone picker for `agent-deck popup`, sized for a
+// tmux display-popup. It lists sessions by status and quits once one is
+// chosen; the caller reads Selected() and switches the tmux client.
+type QuickSwitcher struct {
+	sessions      []*session.Instance
+	cursor        int
+	selected      *session.Instance
+	width, height int
+}
+
+// NewQuickSwitcher creates a switcher over instances, sorted by status and
+// then by their original order.
+func NewQuickSwitcher(instances []*session.Instance) *QuickSwitcher {
+	sessions := make([]*session.Instance, len(instances))
+	copy(sessions, instances)
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return quickSwitcherRank(sessions[i].Status) < quickSwitcherRank(sessions[j].Status)
+	})
+	return &QuickSwitcher{sessions: sessions}
+}
+
+func quickSwitcherRank(status session.Status) int {
+	if rank, ok := quickSwitcherOrder[status]; ok {
+		return rank
+	}
+	return len(quickSwitcherOrder)
+}
+
+// Selected returns the chosen session, or nil if the switcher was cancelled.
+func (q *QuickSwitcher) Selected() *session.Instance {
+	return q.selected
+}
+
+// Init implements tea.Model.
+func (q *QuickSwitcher) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model. Number keys 1-9 pick a session directly so a
+// switch takes a single keystroke.
+func (q *QuickSwitcher) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		q.width = msg.Width
+		q.height = msg.Height
+
+	case tea.KeyMsg:
+		key := msg.String()
+		switch key {
+		case "j", "down", "ctrl+n":
+			if len(q.sessions) > 0 {
+				q.cursor = (q.cursor + 1) % len(q.sessions)
+			}
+		case "k", "up", "ctrl+p":
+			if len(q.sessions) > 0 {
+				q.cursor = (q.cursor - 1 + len(q.sessions)) % len(q.sessions)
+			}
+		case "enter":
+			if len(q.sessions) > 0 {
+				q.selected = q.sessions[q.cursor]
+				return q, tea.Quit
+			}
+		case "esc", "q", "ctrl+c":
+			return q, tea.Quit
+		default:
+			if len(key) == 1 && key[0] >= '1' && key[0] <= '9' {
+				if idx := int(key[0] - '1'); idx < len(q.sessions) {
+					q.selected = q.sessions[idx]
+					return q, tea.Quit
+				}
+			}
+		}
+	}
+	return q, nil
+}
+
+// View implements tea.Model.
+func (q *QuickSwitcher) View() string {
+	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+	headerStyle := lipgloss.NewStyle().Foreground(ColorComment)
+	selectedStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+	normalStyle := lipgloss.NewStyle().Foreground(ColorText)
+	dimStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+	footerStyle := lipgloss.NewStyle().Foreground(ColorComment).Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Switch Session"), "")
+
+	if len(q.sessions) == 0 {
+		lines = append(lines, normalStyle.Render("No sessions"))
+	}
+
+	// Keep the cursor visible when the popup is shorter than the list
+	// (title, blank line, footer and one header per status take space too).
+	start := 0
+	if visible := q.height - 8; visible > 0 && q.cursor >= visible {
+		start = q.cursor - visible + 1
+	}
+
+	var lastStatus session.Status
+	for i := start; i < len(q.sessions); i++ {
+		inst := q.sessions[i]
+		if i == start || inst.Status != lastStatus {
+			lines = append(lines, headerStyle.Render(strings.ToUpper(string(inst.Status))))
+			lastStatus = inst.Status
+		}
+
+		num := "  "
+		if i < 9 {
+			num = fmt.Sprintf("%d ", i+1)
+		}
+		label := fmt.Sprintf("%s %s", statusIndicator(inst.Status), inst.Title)
+		detail := ""
+		if inst.GroupPath != "" {
+			detail = dimStyle.Render("  " + inst.GroupPath)
+		}
+		if i == q.cursor {
+			lines = append(lines, "> "+dimStyle.Render(num)+selectedStyle.Render(label)+detail)
+		} else {
+			lines = append(lines, "  "+dimStyle.Render(num)+normalStyle.Render(label)+detail)
+		}
+	}
+
+	footer := []string{"", footerStyle.Render("1-9/Enter switch | j/k navigate | Esc cancel")}
+	if q.height > len(footer) && len(lines)+len(footer) > q.height {
+		lines = lines[:q.height-len(footer)]
+	}
+	return strings.Join(append(lines, footer...), "\n")
+}
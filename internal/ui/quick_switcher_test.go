@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestQuickSwitcher_SortsByStatus(t *testing.T) {
+	instances := append(makeTestInstances(),
+		&session.Instance{ID: "id-4", Title: "broken", Status: session.StatusError},
+		&session.Instance{ID: "id-5", Title: "reviewer", Status: session.StatusWaiting},
+	)
+	q := NewQuickSwitcher(instances)
+
+	var got []string
+	for _, inst := range q.sessions {
+		got = append(got, inst.Title)
+	}
+	want := "backend-agent,reviewer,frontend-agent,data-pipeline,broken"
+	if strings.Join(got, ",") != want {
+		t.Errorf("order = %v, want %s", got, want)
+	}
+	if instances[0].Title != "frontend-agent" {
+		t.Error("input slice must not be reordered")
+	}
+}
+
+func TestQuickSwitcher_NumberKeySelects(t *testing.T) {
+	q := NewQuickSwitcher(makeTestInstances())
+
+	_, cmd := q.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if q.Selected() == nil || q.Selected().Title != "frontend-agent" {
+		t.Fatalf("selected = %v, want frontend-agent", q.Selected())
+	}
+	if cmd == nil {
+		t.Error("selection should quit")
+	}
+
+	q = NewQuickSwitcher(makeTestInstances())
+	if _, cmd := q.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'9'}}); cmd != nil || q.Selected() != nil {
+		t.Error("out-of-range number should be ignored")
+	}
+}
+
+func TestQuickSwitcher_NavigateAndCancel(t *testing.T) {
+	q := NewQuickSwitcher(makeTestInstances())
+
+	q.Update(tea.KeyMsg{Type: tea.KeyUp}) // wraps to the last entry
+	q.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if q.Selected() == nil || q.Selected().Title != "data-pipeline" {
+		t.Fatalf("selected = %v, want data-pipeline", q.Selected())
+	}
+
+	q = NewQuickSwitcher(makeTestInstances())
+	_, cmd := q.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil || q.Selected() != nil {
+		t.Error("esc should quit without a selection")
+	}
+}
+
+func TestQuickSwitcher_ViewGroupsByStatus(t *testing.T) {
+	view := NewQuickSwitcher(makeTestInstances()).View()
+	for _, want := range []string{"WAITING", "RUNNING", "IDLE", "1 ", "backend-agent"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+	if strings.Index(view, "WAITING") > strings.Index(view, "RUNNING") {
+		t.Error("waiting sessions should be listed first")
+	}
+}
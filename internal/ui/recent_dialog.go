@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const recentSessionsMax = 10 // Sessions in the jump list
+
+// RecentDialog lists the most recently attached sessions ("ctrl+o" key),
+// like an editor's buffer switcher. It opens on the session before the
+// last one, so ctrl+o Enter flips between two sessions; pressing ctrl+o
+// again steps further back. Home attaches on Enter; the dialog only tracks
+// selection.
+type RecentDialog struct {
+	visible       bool
+	width, height int
+	sessions      []*session.Instance
+	cursor        int
+}
+
+// NewRecentDialog creates a new recent sessions dialog.
+func NewRecentDialog() *RecentDialog {
+	return &RecentDialog{}
+}
+
+// Show opens the dialog on sessions, most recent first.
+func (d *RecentDialog) Show(sessions []*session.Instance) {
+	d.visible = true
+	d.sessions = sessions
+	d.cursor = 0
+	if len(sessions) > 1 {
+		d.cursor = 1
+	}
+}
+
+// Hide closes the dialog and resets state.
+func (d *RecentDialog) Hide() {
+	d.visible = false
+	d.sessions = nil
+	d.cursor = 0
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *RecentDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *RecentDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// Selected returns the highlighted session, or nil.
+func (d *RecentDialog) Selected() *session.Instance {
+	if d.cursor < len(d.sessions) {
+		return d.sessions[d.cursor]
+	}
+	return nil
+}
+
+// Update handles navigation. Enter and space are handled by Home.
+func (d *RecentDialog) Update(msg tea.KeyMsg) (*RecentDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	n := len(d.sessions)
+	switch msg.String() {
+	case "ctrl+o", "j", "down", "tab":
+		if n > 0 {
+			d.cursor = (d.cursor + 1) % n
+		}
+	case "k", "up", "shift+tab":
+		if n > 0 {
+			d.cursor = (d.cursor - 1 + n) % n
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		if i := int(msg.String()[0] - '1'); i < n {
+			d.cursor = i
+		}
+	case "esc", "q":
+		d.Hide()
+	}
+	return d, nil
+}
+
+// View renders the recent sessions dialog.
+func (d *RecentDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 64
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Recent Sessions"))
+	lines = append(lines, "")
+	if len(d.sessions) == 0 {
+		lines = append(lines, normalStyle.Render("No session attached yet"))
+	}
+	for i, inst := range d.sessions {
+		meta := formatRelativeTime(inst.LastAccessedAt)
+		if inst.GroupPath != "" {
+			meta = inst.GroupPath + " · " + meta
+		}
+		num := "   "
+		if i < 9 {
+			num = string(rune('1'+i)) + ". "
+		}
+		titleWidth := max(dialogWidth-10-runewidth.StringWidth(meta)-2, 10)
+		title := runewidth.Truncate(inst.Title, titleWidth, "...")
+
+		style, cursor := normalStyle, "  "
+		if i == d.cursor {
+			style, cursor = selectedStyle, "> "
+		}
+		lines = append(lines, cursor+style.Render(num+title)+"  "+dimStyle.Render(meta))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("^O/j/k move | 1-9 pick | Enter attach | Space select | Esc close"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
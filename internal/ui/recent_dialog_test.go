@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestRecentDialogCycles(t *testing.T) {
+	a := &session.Instance{ID: "a", Title: "a"}
+	b := &session.Instance{ID: "b", Title: "b"}
+	c := &session.Instance{ID: "c", Title: "c"}
+	d := NewRecentDialog()
+
+	d.Show([]*session.Instance{a, b, c})
+	if d.Selected() != b {
+		t.Fatal("should open on the session before the last one")
+	}
+	ctrlO := tea.KeyMsg{Type: tea.KeyCtrlO}
+	d.Update(ctrlO)
+	if d.Selected() != c {
+		t.Error("ctrl+o should step back")
+	}
+	d.Update(ctrlO)
+	if d.Selected() != a {
+		t.Error("ctrl+o should wrap around")
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	if d.Selected() != c {
+		t.Error("3 should pick the third session")
+	}
+
+	d.Show([]*session.Instance{a})
+	if d.Selected() != a {
+		t.Error("a single session should be selected")
+	}
+	d.Show(nil)
+	if d.Selected() != nil || d.View() == "" {
+		t.Error("an empty list has no selection but still renders")
+	}
+}
+
+func TestHomeRecentJumpList(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 30
+	home.initialLoading = false
+
+	now := time.Now()
+	first := session.NewInstance("first", "/tmp/first")
+	first.LastAccessedAt = now.Add(-time.Hour)
+	second := session.NewInstance("second", "/tmp/second")
+	second.LastAccessedAt = now
+	never := session.NewInstance("never", "/tmp/never")
+	setSessions(home, first, second, never)
+	home.rebuildFlatItems()
+
+	home.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !home.recentDialog.IsVisible() || len(home.recentDialog.sessions) != 2 {
+		t.Fatal("ctrl+o should list the two attached sessions")
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{' '}})
+	if home.recentDialog.IsVisible() {
+		t.Error("space should close the list")
+	}
+	if sel := home.getSelectedSession(); sel != first {
+		t.Errorf("space should select the previous session, got %v", sel)
+	}
+}
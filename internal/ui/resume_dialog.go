@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+const resumeListRows = 15 // Conversations shown at once
+
+// ResumeDialog lists past Claude conversations for a project ("U" key).
+// Home loads the list and creates or jumps to the session; the dialog only
+// tracks selection.
+type ResumeDialog struct {
+	visible       bool
+	width, height int
+	projectPath   string
+	groupPath     string
+	convs         []session.ClaudeConversation
+	openIn        map[string]string // Claude session ID -> title of the session that has it open
+	cursor        int
+}
+
+// NewResumeDialog creates a new resume dialog.
+func NewResumeDialog() *ResumeDialog {
+	return &ResumeDialog{}
+}
+
+// Show opens the dialog with the conversations for projectPath. A resumed
+// session goes into groupPath. openIn maps conversations already open in
+// agent-deck to the session's title.
+func (d *ResumeDialog) Show(projectPath, groupPath string, convs []session.ClaudeConversation, openIn map[string]string) {
+	d.visible = true
+	d.projectPath = projectPath
+	d.groupPath = groupPath
+	d.convs = convs
+	d.openIn = openIn
+	d.cursor = 0
+}
+
+// Hide closes the dialog and resets state.
+func (d *ResumeDialog) Hide() {
+	d.visible = false
+	d.convs = nil
+	d.openIn = nil
+	d.cursor = 0
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *ResumeDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *ResumeDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// ProjectPath returns the project the conversations belong to.
+func (d *ResumeDialog) ProjectPath() string {
+	return d.projectPath
+}
+
+// GroupPath returns the group a resumed session is created in.
+func (d *ResumeDialog) GroupPath() string {
+	return d.groupPath
+}
+
+// GetSelected returns the conversation at the cursor, or nil.
+func (d *ResumeDialog) GetSelected() *session.ClaudeConversation {
+	if len(d.convs) == 0 || d.cursor >= len(d.convs) {
+		return nil
+	}
+	return &d.convs[d.cursor]
+}
+
+// Update handles navigation keys. Enter is handled by Home.
+func (d *ResumeDialog) Update(msg tea.KeyMsg) (*ResumeDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		if len(d.convs) > 0 {
+			d.cursor = (d.cursor + 1) % len(d.convs)
+		}
+	case "k", "up":
+		if len(d.convs) > 0 {
+			d.cursor = (d.cursor - 1 + len(d.convs)) % len(d.convs)
+		}
+	case "esc":
+		d.Hide()
+	}
+	return d, nil
+}
+
+// View renders the resume dialog.
+func (d *ResumeDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	selectedStyle := lipgloss.NewStyle().
+		Foreground(ColorAccent).
+		Bold(true)
+
+	normalStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	openStyle := lipgloss.NewStyle().
+		Foreground(ColorGreen)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 72
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Resume Claude Conversation"))
+	lines = append(lines, dimStyle.Render(truncatePath(d.projectPath, dialogWidth-4)))
+	lines = append(lines, "")
+	if len(d.convs) == 0 {
+		lines = append(lines, normalStyle.Render("No past conversations for this project"))
+	}
+
+	start := max(0, d.cursor-resumeListRows+1)
+	end := min(start+resumeListRows, len(d.convs))
+	for i := start; i < end; i++ {
+		c := d.convs[i]
+		meta := fmt.Sprintf("%s · %s", formatRelativeTime(c.ModTime), formatFileSize(c.FileSize))
+		summary := runewidth.Truncate(c.Summary, max(dialogWidth-6-runewidth.StringWidth(meta)-2, 10), "...")
+
+		style, prefix := normalStyle, "  "
+		if i == d.cursor {
+			style, prefix = selectedStyle, "> "
+		}
+		line := prefix + style.Render(summary) + "  " + dimStyle.Render(meta)
+		lines = append(lines, line)
+		if title, ok := d.openIn[c.SessionID]; ok {
+			lines = append(lines, "    "+openStyle.Render("● open in "+title))
+		}
+	}
+	if len(d.convs) > resumeListRows {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("  %d of %d", d.cursor+1, len(d.convs))))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("Enter resume (or jump to the open session) | Esc close"))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
+
+// formatFileSize renders a byte count as B, KB or MB.
+func formatFileSize(n int64) string {
+	switch {
+	case n < 1024:
+		return fmt.Sprintf("%d B", n)
+	case n < 1024*1024:
+		return fmt.Sprintf("%.0f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	}
+}
@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestResumeDialog_NavigateAndOpenMarker(t *testing.T) {
+	d := NewResumeDialog()
+	d.SetSize(120, 50)
+	d.Show("/tmp/proj", "work", []session.ClaudeConversation{
+		{SessionID: "a", Summary: "first", ModTime: time.Now()},
+		{SessionID: "b", Summary: "second", ModTime: time.Now()},
+	}, map[string]string{"b": "my-session"})
+
+	if got := d.GetSelected(); got == nil || got.SessionID != "a" {
+		t.Fatalf("initial selection = %+v, want a", got)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := d.GetSelected(); got == nil || got.SessionID != "b" {
+		t.Errorf("after down = %+v, want b", got)
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := d.GetSelected(); got == nil || got.SessionID != "a" {
+		t.Errorf("down should wrap to the top, got %+v", got)
+	}
+	if d.GroupPath() != "work" || d.ProjectPath() != "/tmp/proj" {
+		t.Errorf("paths = %q, %q", d.ProjectPath(), d.GroupPath())
+	}
+
+	if view := d.View(); !strings.Contains(view, "open in my-session") {
+		t.Errorf("view should mark conversations that are already open:\n%s", view)
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsVisible() || d.GetSelected() != nil {
+		t.Error("Esc should hide and clear the dialog")
+	}
+}
@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// rowCache holds the session list rows drawn in the last frame, so an
+// unchanged row costs a key build and a map lookup instead of a dozen
+// lipgloss renders. Keys are sessionRow and groupRow values: everything a
+// row depends on, so a row is re-rendered exactly when it would look
+// different. Rows not drawn in a frame are dropped at the next one, which
+// bounds the cache to what's on screen however large the deck.
+type rowCache struct {
+	prev, next map[any]string
+	generation uint64 // styleGeneration the rows were rendered with
+}
+
+// startFrame begins a frame: the rows drawn in the last one stay
+// available, older ones go. A theme switch drops everything.
+func (c *rowCache) startFrame() {
+	if gen := styleGeneration.Load(); gen != c.generation {
+		c.prev, c.next, c.generation = nil, nil, gen
+		return
+	}
+	c.prev, c.next = c.next, nil
+}
+
+func (c *rowCache) get(key any) (string, bool) {
+	if row, ok := c.next[key]; ok {
+		return row, true
+	}
+	row, ok := c.prev[key]
+	if ok {
+		c.put(key, row)
+	}
+	return row, ok
+}
+
+func (c *rowCache) put(key any, row string) {
+	if c.next == nil {
+		c.next = make(map[any]string)
+	}
+	c.next[key] = row
+}
+
+// sessionRow is everything a session row depends on, snapshotted once per
+// frame. It is both the input of drawSessionRow and the row's cache key.
+type sessionRow struct {
+	id, title, tool, model string
+	status                 session.Status
+	dead, yolo             bool
+	queued, restarts       int
+	restartsExhausted      bool
+
+	// Git: the live status of the project path when known, else the branch
+	// a worktree session was created on
+	gitKnown                      bool
+	branch, marks, worktreeBranch string
+
+	// Detailed density only
+	path, lastActive string
+
+	// Place in the tree
+	level                                        int
+	subSession, lastSub, parentLast, lastInGroup bool
+
+	selected       bool
+	num            int
+	density        ListDensity
+	nerd, numbered bool
+}
+
+// sessionRowFor snapshots what item's row shows.
+func (h *Home) sessionRowFor(item session.Item, selected bool, num int) sessionRow {
+	inst := item.Session
+	row := sessionRow{
+		id:          inst.ID,
+		title:       inst.Title,
+		tool:        inst.GetToolThreadSafe(),
+		model:       inst.GetDetectedModelThreadSafe(),
+		status:      inst.GetStatusThreadSafe(),
+		queued:      inst.QueuedPromptCount(),
+		restarts:    inst.AutoRestartCount(),
+		level:       item.Level,
+		subSession:  item.IsSubSession,
+		lastSub:     item.IsLastSubSession,
+		parentLast:  item.ParentIsLastInGroup,
+		lastInGroup: item.IsLastInGroup,
+		selected:    selected,
+		num:         num,
+		density:     h.listDensity,
+		nerd:        h.nerdIcons,
+		numbered:    h.numberSessions,
+	}
+	row.dead = row.status == session.StatusError && inst.IsDead()
+	row.yolo = row.tool == "gemini" && inst.GeminiYoloMode != nil && *inst.GeminiYoloMode
+	if row.restarts > 0 {
+		row.restartsExhausted = inst.AutoRestartExhausted()
+	}
+	if st, ok := h.cachedGitStatus(inst.ProjectPath); ok {
+		row.gitKnown, row.branch, row.marks = true, st.Branch, formatGitMarks(st, "")
+	}
+	if inst.IsWorktree() {
+		row.worktreeBranch = inst.WorktreeBranch
+	}
+	if h.listDensity == DensityDetailed {
+		row.path = tildePath(inst.ProjectPath)
+		row.lastActive = formatRelativeTime(inst.IdleSince())
+	}
+	return row
+}
+
+// groupRow is everything a group header depends on, like sessionRow.
+type groupRow struct {
+	path, name                          string
+	level, rootNum                      int
+	expanded, muted, selected, numbered bool
+	sortMode                            session.GroupSortMode
+	counts                              groupCounts
+}
+
+// groupCounts tallies the sessions of a group and its subgroups.
+type groupCounts struct {
+	total, running, needsInput, waiting int
+}
+
+// groupRowFor snapshots what item's header shows.
+func (h *Home) groupRowFor(item session.Item, selected bool) groupRow {
+	group := item.Group
+	if h.frameGroupCounts == nil {
+		h.frameGroupCounts = h.countGroupSessions()
+	}
+	return groupRow{
+		path:     group.Path,
+		name:     group.Name,
+		level:    item.Level,
+		rootNum:  item.RootGroupNum,
+		expanded: group.Expanded,
+		muted:    group.Muted,
+		selected: selected,
+		numbered: h.numberSessions,
+		sortMode: group.SortMode,
+		counts:   h.frameGroupCounts[group.Path],
+	}
+}
+
+// countGroupSessions tallies every group's sessions, subgroups included, in
+// one pass over the tree rather than one per group header.
+func (h *Home) countGroupSessions() map[string]groupCounts {
+	counts := make(map[string]groupCounts, len(h.sessions.Tree().Groups))
+	for path, g := range h.sessions.Tree().Groups {
+		var own groupCounts
+		for _, sess := range g.Sessions {
+			own.total++
+			switch sess.GetStatusThreadSafe() {
+			case session.StatusRunning:
+				own.running++
+			case session.StatusNeedsInput:
+				own.needsInput++
+			case session.StatusWaiting:
+				own.waiting++
+			}
+		}
+		// Credit the group and each of its ancestors
+		for p := path; ; {
+			c := counts[p]
+			c.total += own.total
+			c.running += own.running
+			c.needsInput += own.needsInput
+			c.waiting += own.waiting
+			counts[p] = c
+			i := strings.LastIndexByte(p, '/')
+			if i < 0 {
+				break
+			}
+			p = p[:i]
+		}
+	}
+	return counts
+}
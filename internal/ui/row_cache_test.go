@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestRowCacheReusesUnchangedRows(t *testing.T) {
+	home := NewHome()
+	home.initialLoading = false
+	var instances []*session.Instance
+	for i := range 5 {
+		instances = append(instances, session.NewInstanceWithGroup(fmt.Sprintf("s%d", i), "/tmp/p", "work"))
+	}
+	setSessions(home, instances...)
+	home.rebuildFlatItems()
+
+	first := home.renderSessionList(80, 20)
+	if n := len(home.rowCache.next); n != len(home.flatItems) {
+		t.Fatalf("cached %d rows, want one per drawn item (%d)", n, len(home.flatItems))
+	}
+	if second := home.renderSessionList(80, 20); second != first {
+		t.Errorf("an unchanged list should render the same:\n%s\n%s", first, second)
+	}
+
+	instances[2].Status = session.StatusRunning
+	third := home.renderSessionList(80, 20)
+	if third == first || !strings.Contains(third, "● 1") {
+		t.Errorf("a status change should re-render the session and its group:\n%s", third)
+	}
+	if n := len(home.rowCache.next); n != len(home.flatItems) {
+		t.Errorf("rows not drawn should be dropped, cache holds %d", n)
+	}
+
+	InitTheme("light")
+	defer InitTheme("dark")
+	home.rowCache.startFrame()
+	if home.rowCache.prev != nil || home.rowCache.next != nil {
+		t.Error("a theme switch should drop every cached row")
+	}
+}
+
+func TestCountGroupSessions(t *testing.T) {
+	home := NewHome()
+	a := session.NewInstanceWithGroup("a", "/tmp/a", "work")
+	b := session.NewInstanceWithGroup("b", "/tmp/b", "work/api")
+	c := session.NewInstanceWithGroup("c", "/tmp/c", "work/api/v2")
+	b.Status = session.StatusRunning
+	c.Status = session.StatusWaiting
+	setSessions(home, a, b, c)
+
+	counts := home.countGroupSessions()
+	if got, want := counts["work"], (groupCounts{total: 3, running: 1, waiting: 1}); got != want {
+		t.Errorf("work = %+v, want %+v", got, want)
+	}
+	if got, want := counts["work/api/v2"], (groupCounts{total: 1, waiting: 1}); got != want {
+		t.Errorf("work/api/v2 = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,220 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+// ScrollbackViewer shows a session's captured scrollback full screen ("z"
+// key), so long agent output can be read without attaching. It opens at the
+// bottom and keeps following new output until scrolled up.
+type ScrollbackViewer struct {
+	visible       bool
+	width, height int
+	sessionID     string
+	title         string
+	raw           []string // Captured lines, cleaned
+	lines         []string // Wrapped to the current width
+	offset        int      // First visible line
+}
+
+// NewScrollbackViewer creates a new scrollback viewer.
+func NewScrollbackViewer() *ScrollbackViewer {
+	return &ScrollbackViewer{}
+}
+
+// Show opens the viewer on a session's scrollback, scrolled to the end.
+func (v *ScrollbackViewer) Show(sessionID, title, content string) {
+	v.visible = true
+	v.sessionID = sessionID
+	v.title = title
+	v.setContent(content)
+	v.offset = v.maxOffset()
+}
+
+// Hide closes the viewer and resets state.
+func (v *ScrollbackViewer) Hide() {
+	v.visible = false
+	v.sessionID = ""
+	v.raw = nil
+	v.lines = nil
+	v.offset = 0
+}
+
+// IsVisible returns whether the viewer is currently shown.
+func (v *ScrollbackViewer) IsVisible() bool {
+	return v.visible
+}
+
+// SessionID returns the session being viewed.
+func (v *ScrollbackViewer) SessionID() string {
+	return v.sessionID
+}
+
+// SetSize updates the viewer dimensions and re-wraps the scrollback.
+func (v *ScrollbackViewer) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+	if v.visible {
+		following := v.offset >= v.maxOffset()
+		v.render()
+		v.offset = min(v.offset, v.maxOffset())
+		if following {
+			v.offset = v.maxOffset()
+		}
+	}
+}
+
+// Refresh replaces the scrollback with a newer capture. The view stays
+// where it is unless it was at the bottom, in which case it follows.
+func (v *ScrollbackViewer) Refresh(content string) {
+	if !v.visible {
+		return
+	}
+	following := v.offset >= v.maxOffset()
+	v.setContent(content)
+	if following {
+		v.offset = v.maxOffset()
+	}
+	v.offset = min(v.offset, v.maxOffset())
+}
+
+func (v *ScrollbackViewer) setContent(content string) {
+	content = strings.TrimRight(strings.ReplaceAll(content, "\t", "    "), "\n ")
+	v.raw = strings.Split(content, "\n")
+	for i, line := range v.raw {
+		v.raw[i] = stripControlChars(tmux.StripANSI(line))
+	}
+	v.render()
+}
+
+// boxWidth is the width of the dialog box, leaving a margin on each side.
+func (v *ScrollbackViewer) boxWidth() int {
+	if v.width <= 0 {
+		return 100
+	}
+	return max(v.width-8, 30)
+}
+
+// pageSize is the number of lines visible at once.
+func (v *ScrollbackViewer) pageSize() int {
+	if v.height <= 0 {
+		return 30
+	}
+	return max(v.height-12, 5) // Title, position, footer and box padding
+}
+
+func (v *ScrollbackViewer) maxOffset() int {
+	return max(0, len(v.lines)-v.pageSize())
+}
+
+// render wraps long lines at the box width so nothing is cut off.
+func (v *ScrollbackViewer) render() {
+	textWidth := v.boxWidth() - 6
+	v.lines = v.lines[:0]
+	for _, line := range v.raw {
+		v.lines = append(v.lines, hardWrap(line, textWidth)...)
+	}
+}
+
+// hardWrap splits a line into pieces at most width columns wide.
+func hardWrap(line string, width int) []string {
+	if width <= 0 || runewidth.StringWidth(line) <= width {
+		return []string{line}
+	}
+	var out []string
+	var b strings.Builder
+	w := 0
+	for _, r := range line {
+		rw := runewidth.RuneWidth(r)
+		if w+rw > width {
+			out = append(out, b.String())
+			b.Reset()
+			w = 0
+		}
+		b.WriteRune(r)
+		w += rw
+	}
+	return append(out, b.String())
+}
+
+// Update handles scrolling keys.
+func (v *ScrollbackViewer) Update(msg tea.KeyMsg) (*ScrollbackViewer, tea.Cmd) {
+	if !v.visible {
+		return v, nil
+	}
+	half := max(v.pageSize()/2, 1)
+	switch msg.String() {
+	case "j", "down":
+		v.offset++
+	case "k", "up":
+		v.offset--
+	case "ctrl+d":
+		v.offset += half
+	case "ctrl+u":
+		v.offset -= half
+	case "pgdown", "ctrl+f", " ":
+		v.offset += v.pageSize()
+	case "pgup", "ctrl+b":
+		v.offset -= v.pageSize()
+	case "g", "home":
+		v.offset = 0
+	case "G", "end":
+		v.offset = v.maxOffset()
+	case "esc", "q", "z":
+		v.Hide()
+		return v, nil
+	}
+	v.offset = max(0, min(v.offset, v.maxOffset()))
+	return v, nil
+}
+
+// View renders the scrollback viewer.
+func (v *ScrollbackViewer) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	textStyle := lipgloss.NewStyle().
+		Foreground(ColorText)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Output: "+v.title))
+	end := min(v.offset+v.pageSize(), len(v.lines))
+	info := fmt.Sprintf("lines %d-%d of %d", v.offset+1, end, len(v.lines))
+	if v.offset >= v.maxOffset() {
+		info += " · following"
+	}
+	lines = append(lines, dimStyle.Render(info))
+	lines = append(lines, "")
+
+	for _, line := range v.lines[v.offset:end] {
+		lines = append(lines, textStyle.Render(line))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("j/k scroll | PgUp/PgDn page | ctrl+u/d half page | g/G top/end | Esc close"))
+
+	box := DialogBoxStyle.
+		Width(v.boxWidth()).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, v.width, v.height)
+}
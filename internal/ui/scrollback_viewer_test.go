@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func scrollbackLines(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "\x1b[32moutput line %03d\x1b[0m\n", i)
+	}
+	return b.String()
+}
+
+func TestScrollbackViewer_OpensAtEndAndFollows(t *testing.T) {
+	v := NewScrollbackViewer()
+	v.SetSize(100, 30)
+	v.Show("s1", "alpha", scrollbackLines(100))
+
+	view := v.View()
+	if !strings.Contains(view, "Output: alpha") || !strings.Contains(view, "output line 099") {
+		t.Errorf("viewer should open at the end of the output:\n%s", view)
+	}
+	if strings.Contains(view, "\x1b[32m") {
+		t.Error("captured escape codes should be stripped")
+	}
+
+	v.Refresh(scrollbackLines(120))
+	if !strings.Contains(v.View(), "output line 119") {
+		t.Error("viewer at the bottom should follow new output")
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	v.Refresh(scrollbackLines(140))
+	if v.offset != 0 || !strings.Contains(v.View(), "output line 000") {
+		t.Errorf("scrolled-up viewer should stay put, offset = %d", v.offset)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if v.IsVisible() || v.SessionID() != "" {
+		t.Error("Esc should close the viewer")
+	}
+}
+
+func TestHardWrap(t *testing.T) {
+	got := hardWrap("abcdefghij", 4)
+	want := []string{"abcd", "efgh", "ij"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("hardWrap = %q, want %q", got, want)
+	}
+	if got := hardWrap("short", 10); len(got) != 1 || got[0] != "short" {
+		t.Errorf("hardWrap(short) = %q", got)
+	}
+}
@@ -2,12 +2,17 @@ package ui
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/sahilm/fuzzy"
 )
 
 var (
@@ -34,12 +39,22 @@ var (
 type Search struct {
 	input          textinput.Model
 	results        []*session.Instance
+	matches        []searchMatch // What each result matched, for highlighting
 	cursor         int
 	width          int
 	height         int
 	visible        bool
 	allItems       []*session.Instance
 	switchToGlobal bool // Flag to signal switch to global search
+
+	// Output search (ctrl+o): match the query against each session's
+	// captured scrollback instead of its title
+	contentMode    bool
+	wantContent    bool              // Home should capture scrollback
+	contentLoading bool              // Capture in progress
+	contents       map[string]string // Session ID -> scrollback
+	snippets       []string          // Last matching line, per result
+	matchCounts    []int             // Matching lines, per result
 }
 
 // NewSearch creates a new search overlay
@@ -75,6 +90,40 @@ func (s *Search) Show() {
 	s.visible = true
 	s.input.Focus()
 	s.switchToGlobal = false
+	s.setContentMode(false)
+}
+
+// WantsContent returns true once after output search is turned on, when the
+// parent should capture every session's scrollback and call SetContents.
+func (s *Search) WantsContent() bool {
+	if s.wantContent {
+		s.wantContent = false
+		return true
+	}
+	return false
+}
+
+// SetContents supplies the captured scrollback for output search.
+func (s *Search) SetContents(contents map[string]string) {
+	if !s.contentMode {
+		return
+	}
+	s.contents = contents
+	s.contentLoading = false
+	s.updateResults()
+}
+
+func (s *Search) setContentMode(on bool) {
+	s.contentMode = on
+	s.wantContent = on
+	s.contentLoading = on
+	s.contents = nil
+	if on {
+		s.input.Placeholder = "Search session output..."
+	} else {
+		s.input.Placeholder = "Search sessions..."
+	}
+	s.updateResults()
 }
 
 // WantsSwitchToGlobal returns true if user pressed Tab to switch to global search
@@ -141,6 +190,10 @@ func (s *Search) Update(msg tea.Msg) (*Search, tea.Cmd) {
 			}
 			return s, nil
 
+		case "ctrl+o":
+			s.setContentMode(!s.contentMode)
+			return s, nil
+
 		case "tab":
 			// Signal to switch to global search
 			s.switchToGlobal = true
@@ -162,8 +215,186 @@ func (s *Search) Update(msg tea.Msg) (*Search, tea.Cmd) {
 // updateResults filters the items based on the current input
 func (s *Search) updateResults() {
 	query := s.input.Value()
-	s.results = session.FilterByQuery(s.allItems, query)
 	s.cursor = 0
+	s.snippets, s.matchCounts = nil, nil
+	s.matches = nil
+	if !s.contentMode {
+		s.results, s.matches = rankSessions(s.allItems, query, time.Now())
+		return
+	}
+	s.results = nil
+	if strings.TrimSpace(query) == "" {
+		return
+	}
+	type hit struct {
+		inst    *session.Instance
+		snippet string
+		count   int
+	}
+	var hits []hit
+	for _, inst := range s.allItems {
+		if snippet, count := grepContent(s.contents[inst.ID], query); count > 0 {
+			hits = append(hits, hit{inst, stripControlChars(snippet), count})
+		}
+	}
+	// Sessions that mention it most first
+	sort.SliceStable(hits, func(a, b int) bool { return hits[a].count > hits[b].count })
+	for _, h := range hits {
+		s.results = append(s.results, h.inst)
+		s.snippets = append(s.snippets, h.snippet)
+		s.matchCounts = append(s.matchCounts, h.count)
+	}
+}
+
+// Fuzzy ranking. Each query word is scored fzf-style against the title,
+// group path, project path and tool; its best field counts, with the other
+// fields handicapped so title matches win. Recently attached sessions get up
+// to mruBoost extra, about one well-placed matching character.
+const mruBoost = 15
+
+// searchField is a session field fuzzy search looks at.
+type searchField struct {
+	name    string
+	value   func(*session.Instance) string
+	penalty int
+}
+
+var searchFields = []searchField{
+	{"title", func(i *session.Instance) string { return i.Title }, 0},
+	{"group", func(i *session.Instance) string { return i.GroupPath }, 5},
+	{"path", func(i *session.Instance) string { return i.ProjectPath }, 10},
+	{"tool", func(i *session.Instance) string { return i.Tool }, 10},
+}
+
+// searchMatch records where a result matched: byte offsets into the title,
+// and the first other field a query word matched in.
+type searchMatch struct {
+	title        []int
+	field        string
+	fieldIndexes []int
+}
+
+// statusQueries are search words that filter by status instead.
+var statusQueries = map[string]bool{
+	"waiting": true, "needs_input": true, "running": true, "idle": true, "error": true,
+}
+
+// rankSessions returns the sessions matching query, best first. An empty
+// query lists every session, most recently attached first.
+func rankSessions(items []*session.Instance, query string, now time.Time) ([]*session.Instance, []searchMatch) {
+	query = strings.TrimSpace(query)
+	if statusQueries[strings.ToLower(query)] {
+		results := session.FilterByQuery(items, query)
+		return results, make([]searchMatch, len(results))
+	}
+	words := strings.Fields(query)
+
+	type hit struct {
+		inst  *session.Instance
+		match searchMatch
+		score float64
+	}
+	var hits []hit
+	for _, inst := range items {
+		h := hit{inst: inst, score: mruBoost * recency(inst.LastAccessedAt, now)}
+		matchedAll := true
+		for _, word := range words {
+			best, bestField := 0, -1
+			var bestIndexes []int
+			for n, f := range searchFields {
+				m := fuzzy.Find(word, []string{f.value(inst)})
+				if len(m) == 0 {
+					continue
+				}
+				if score := m[0].Score - f.penalty; bestField < 0 || score > best {
+					best, bestField, bestIndexes = score, n, m[0].MatchedIndexes
+				}
+			}
+			if bestField < 0 {
+				matchedAll = false
+				break
+			}
+			h.score += float64(best)
+			if bestField == 0 {
+				h.match.title = append(h.match.title, bestIndexes...)
+			} else if h.match.field == "" {
+				h.match.field = searchFields[bestField].value(inst)
+				h.match.fieldIndexes = bestIndexes
+			}
+		}
+		if matchedAll {
+			hits = append(hits, h)
+		}
+	}
+	sort.SliceStable(hits, func(a, b int) bool { return hits[a].score > hits[b].score })
+
+	results := make([]*session.Instance, len(hits))
+	matches := make([]searchMatch, len(hits))
+	for n, h := range hits {
+		results[n], matches[n] = h.inst, h.match
+	}
+	return results, matches
+}
+
+// recency is 1 for a session attached just now, falling to 0.5 after a day
+// and 0 for one never attached.
+func recency(lastAccessed, now time.Time) float64 {
+	if lastAccessed.IsZero() {
+		return 0
+	}
+	return 1 / (1 + now.Sub(lastAccessed).Hours()/24)
+}
+
+// highlightMatches renders s with the characters at the given byte offsets
+// in hl and the rest in base.
+func highlightMatches(s string, offsets []int, base, hl lipgloss.Style) string {
+	if len(offsets) == 0 {
+		return base.Render(s)
+	}
+	matched := make(map[int]bool, len(offsets))
+	for _, o := range offsets {
+		matched[o] = true
+	}
+	var b, run strings.Builder
+	inMatch := false
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if inMatch {
+			b.WriteString(hl.Render(run.String()))
+		} else {
+			b.WriteString(base.Render(run.String()))
+		}
+		run.Reset()
+	}
+	for i, r := range s {
+		if matched[i] != inMatch {
+			flush()
+			inMatch = matched[i]
+		}
+		run.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
+
+// grepContent counts the lines of content containing query, ignoring case,
+// and returns the last of them (the most recent output) trimmed.
+func grepContent(content, query string) (string, int) {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if content == "" || query == "" {
+		return "", 0
+	}
+	var last string
+	count := 0
+	for _, line := range strings.Split(content, "\n") {
+		if strings.Contains(strings.ToLower(line), query) {
+			last = line
+			count++
+		}
+	}
+	return strings.TrimSpace(last), count
 }
 
 // View renders the search overlay
@@ -172,11 +403,24 @@ func (s *Search) View() string {
 		return ""
 	}
 
+	// Wrap in overlay box - responsive width
+	overlayWidth := 60
+	if s.width > 0 && s.width < overlayWidth+10 {
+		overlayWidth = s.width - 10
+		if overlayWidth < 30 {
+			overlayWidth = 30
+		}
+	}
+
 	// Header
+	title := "🔍 Local Search (Agent Deck sessions)"
+	if s.contentMode {
+		title = "🔍 Output Search (session scrollback)"
+	}
 	header := lipgloss.NewStyle().
 		Foreground(ColorAccent).
 		Bold(true).
-		Render("🔍 Local Search (Agent Deck sessions)")
+		Render(title)
 
 	// Build search input box
 	searchBox := searchBoxStyle.Render(s.input.View())
@@ -184,18 +428,43 @@ func (s *Search) View() string {
 	// Build results list
 	var resultsStr strings.Builder
 	maxResults := 10
+	if s.contentMode {
+		maxResults = 6 // Two lines each
+	}
 	if len(s.results) > maxResults {
 		s.results = s.results[:maxResults]
 	}
 
 	for i, item := range s.results {
-		var line string
+		base := lipgloss.NewStyle()
+		hl := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+		dim := lipgloss.NewStyle().Foreground(ColorTextDim)
+		rowStyle, marker := resultItemStyle, "  "
 		if i == s.cursor {
-			line = selectedResultStyle.Render("› " + item.Title + " (" + item.Tool + ")")
-		} else {
-			line = resultItemStyle.Render("  " + item.Title + " (" + item.Tool + ")")
+			base = lipgloss.NewStyle().Background(ColorAccent).Foreground(ColorBg)
+			hl = base.Bold(true).Underline(true)
+			dim = base
+			rowStyle, marker = selectedResultStyle, "› "
+		}
+		var match searchMatch
+		if i < len(s.matches) {
+			match = s.matches[i]
+		}
+		text := base.Render(marker) + highlightMatches(item.Title, match.title, base, hl) + base.Render(" ("+item.Tool+")")
+		if match.field != "" && match.field != item.Tool {
+			field := runewidth.Truncate(match.field, max(overlayWidth-runewidth.StringWidth(item.Title)-len(item.Tool)-16, 10), "…")
+			if field == match.field {
+				field = highlightMatches(field, match.fieldIndexes, dim, hl)
+			} else {
+				field = dim.Render(field)
+			}
+			text += base.Render(" · ") + field
+		}
+		resultsStr.WriteString(rowStyle.Render(text))
+		if i < len(s.snippets) {
+			snippet := fmt.Sprintf("%d× %s", s.matchCounts[i], snippetAround(s.snippets[i], s.input.Value(), overlayWidth-16))
+			resultsStr.WriteString("\n" + resultItemStyle.Render("    "+lipgloss.NewStyle().Foreground(ColorTextDim).Render(snippet)))
 		}
-		resultsStr.WriteString(line)
 		if i < len(s.results)-1 {
 			resultsStr.WriteString("\n")
 		}
@@ -208,7 +477,17 @@ func (s *Search) View() string {
 
 	// Show filter hint when search is empty
 	hintStr := ""
-	if s.input.Value() == "" {
+	if s.contentMode && s.contentLoading {
+		hintStr = lipgloss.NewStyle().
+			Foreground(ColorComment).
+			Italic(true).
+			Render(fmt.Sprintf("  Capturing output of %d sessions...", len(s.allItems)))
+	} else if s.contentMode && s.input.Value() == "" {
+		hintStr = lipgloss.NewStyle().
+			Foreground(ColorComment).
+			Italic(true).
+			Render("  Searches every session's scrollback, e.g. a file name")
+	} else if s.input.Value() == "" {
 		hintStr = lipgloss.NewStyle().
 			Foreground(ColorComment).
 			Italic(true).
@@ -216,9 +495,13 @@ func (s *Search) View() string {
 	}
 
 	// Keyboard shortcuts hint
+	modeHint := "[^O] Output"
+	if s.contentMode {
+		modeHint = "[^O] Titles"
+	}
 	keysHint := lipgloss.NewStyle().
 		Foreground(ColorComment).
-		Render("  [Enter] Select  [↑↓] Navigate  [Tab] Global  [Esc] Cancel")
+		Render("  [Enter] Select  [↑↓] Navigate  " + modeHint + "  [Tab] Global  [Esc] Cancel")
 
 	// Combine everything
 	var content string
@@ -228,20 +511,31 @@ func (s *Search) View() string {
 		content = header + "\n\n" + searchBox + "\n\n" + resultsStr.String() + "\n" + countStr + "\n" + keysHint
 	}
 
-	// Wrap in overlay box - responsive width
-	overlayWidth := 60
-	if s.width > 0 && s.width < overlayWidth+10 {
-		overlayWidth = s.width - 10
-		if overlayWidth < 30 {
-			overlayWidth = 30
-		}
-	}
 	overlay := overlayStyle.Width(overlayWidth).Render(content)
 
 	// Center in the screen
 	return centerInScreen(overlay, s.width, s.height)
 }
 
+// snippetAround cuts line to width columns, keeping the first match of
+// query in view.
+func snippetAround(line, query string, width int) string {
+	if width <= 0 || runewidth.StringWidth(line) <= width {
+		return line
+	}
+	runes := []rune(line)
+	start := 0
+	if idx := strings.Index(strings.ToLower(line), strings.ToLower(strings.TrimSpace(query))); idx > 0 && idx <= len(line) {
+		// Show some context before the match
+		start = min(max(0, utf8.RuneCountInString(line[:idx])-width/3), len(runes))
+	}
+	snippet := string(runes[start:])
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	return runewidth.Truncate(snippet, width, "…")
+}
+
 // formatCount formats the result count
 func formatCount(count int) string {
 	if count == 0 {
@@ -259,9 +553,7 @@ func centerInScreen(content string, screenWidth, screenHeight int) string {
 	contentHeight := len(lines)
 	contentWidth := 0
 	for _, line := range lines {
-		if len(line) > contentWidth {
-			contentWidth = len(line)
-		}
+		contentWidth = max(contentWidth, lipgloss.Width(line))
 	}
 
 	// Calculate vertical padding
@@ -1,7 +1,13 @@
 package ui
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
 )
@@ -95,3 +101,110 @@ func TestSearchView(t *testing.T) {
 		t.Error("View should not be empty when visible")
 	}
 }
+
+func TestSearchOutputMode(t *testing.T) {
+	s := NewSearch()
+	a := &session.Instance{ID: "a", Title: "api", Tool: "claude"}
+	b := &session.Instance{ID: "b", Title: "web", Tool: "claude"}
+	c := &session.Instance{ID: "c", Title: "payments_service", Tool: "claude"}
+	s.SetItems([]*session.Instance{a, b, c})
+	s.Show()
+
+	s.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !s.contentMode || !s.WantsContent() {
+		t.Fatal("ctrl+o should turn on output search and ask for scrollback")
+	}
+	if s.WantsContent() {
+		t.Error("WantsContent should only fire once")
+	}
+	s.SetContents(map[string]string{
+		"a": "Edit payments_service.go\nok",
+		"b": "Read payments_service.go\nEdit PAYMENTS_SERVICE.go\ndone",
+	})
+	for _, r := range "payments_service" {
+		s.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+
+	// Ranked by matching lines; the title match alone doesn't count
+	if len(s.results) != 2 || s.results[0] != b || s.results[1] != a {
+		t.Fatalf("results = %v", s.results)
+	}
+	if s.matchCounts[0] != 2 || s.snippets[0] != "Edit PAYMENTS_SERVICE.go" {
+		t.Errorf("first hit = %d %q", s.matchCounts[0], s.snippets[0])
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyCtrlO})
+	if s.contentMode || len(s.results) != 1 || s.results[0] != c {
+		t.Errorf("ctrl+o again should go back to title search, got %v", s.results)
+	}
+}
+
+func TestSnippetAround(t *testing.T) {
+	line := "some long prefix text before the match payments_service.go and a long tail after it"
+	got := snippetAround(line, "payments", 30)
+	if !strings.Contains(got, "payments") || runewidth.StringWidth(got) > 30 {
+		t.Errorf("snippetAround = %q", got)
+	}
+	if got := snippetAround("short", "x", 30); got != "short" {
+		t.Errorf("short line changed: %q", got)
+	}
+}
+
+func TestRankSessions(t *testing.T) {
+	now := time.Now()
+	api := &session.Instance{Title: "api-server", GroupPath: "work", ProjectPath: "/src/api", Tool: "claude"}
+	web := &session.Instance{Title: "frontend", GroupPath: "work/web", ProjectPath: "/src/apiclient", Tool: "claude", LastAccessedAt: now}
+	old := &session.Instance{Title: "apiary", GroupPath: "home", ProjectPath: "/src/bees", Tool: "shell", LastAccessedAt: now.Add(-30 * 24 * time.Hour)}
+	items := []*session.Instance{api, web, old}
+
+	results, matches := rankSessions(items, "api", now)
+	if len(results) != 3 || results[2] != web {
+		t.Fatalf("a title match should beat a project path match: %v", results)
+	}
+	if len(matches[0].title) != 3 {
+		t.Errorf("title offsets = %v", matches[0].title)
+	}
+	if matches[2].field != "/src/apiclient" || len(matches[2].fieldIndexes) != 3 {
+		t.Errorf("path match = %+v", matches[2])
+	}
+
+	// Fuzzy, not substring: characters in order
+	results, _ = rankSessions(items, "asv", now)
+	if len(results) != 1 || results[0] != api {
+		t.Errorf("asv should only match api-server: %v", results)
+	}
+
+	// Every word has to match somewhere
+	results, _ = rankSessions(items, "fr web", now)
+	if len(results) != 1 || results[0] != web {
+		t.Errorf("fr web: %v", results)
+	}
+
+	// No query: most recently attached first
+	results, _ = rankSessions(items, "", now)
+	if results[0] != web || results[1] != old || results[2] != api {
+		t.Errorf("empty query order: %v", results)
+	}
+
+	// Recent use breaks ties between equal matches
+	twin := &session.Instance{Title: "api-server", Tool: "claude", LastAccessedAt: now}
+	results, _ = rankSessions([]*session.Instance{api, twin}, "api-server", now)
+	if results[0] != twin {
+		t.Error("the recently attached twin should rank first")
+	}
+
+	// Status words still filter by status
+	api.Status = session.StatusError
+	results, _ = rankSessions(items, "error", now)
+	if len(results) != 1 || results[0] != api {
+		t.Errorf("error: %v", results)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	upper := lipgloss.NewStyle().Transform(strings.ToUpper)
+	got := highlightMatches("héllo", []int{0, 1}, lipgloss.NewStyle(), upper)
+	if got != "HÉllo" {
+		t.Errorf("highlightMatches = %q", got)
+	}
+}
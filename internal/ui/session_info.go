@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
+)
+
+const sessionInfoLabelWidth = 14
+
+// SessionInfoDialog shows everything agent-deck knows about one session
+// ("I" key): tmux session and pane, timing, command, tool session IDs,
+// token usage, notes and the recent status history. Fields are read from
+// the live session on every render; usage is read from the transcript by
+// Home and arrives later.
+type SessionInfoDialog struct {
+	visible       bool
+	width, height int
+	inst          *session.Instance
+	usage         session.Usage
+	usageOK       bool // The tool has readable usage
+	usageLoaded   bool // False while Home reads the transcript
+	offset        int  // First visible line when the page is taller than the screen
+}
+
+// NewSessionInfoDialog creates a new session info dialog.
+func NewSessionInfoDialog() *SessionInfoDialog {
+	return &SessionInfoDialog{}
+}
+
+// Show opens the dialog on a session. Usage shows as loading until SetUsage.
+func (d *SessionInfoDialog) Show(inst *session.Instance) {
+	d.visible = true
+	d.inst = inst
+	d.usage = session.Usage{}
+	d.usageOK = false
+	d.usageLoaded = false
+	d.offset = 0
+}
+
+// Hide closes the dialog.
+func (d *SessionInfoDialog) Hide() {
+	d.visible = false
+	d.inst = nil
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *SessionInfoDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SessionID returns the session shown, or "" when hidden.
+func (d *SessionInfoDialog) SessionID() string {
+	if d.inst == nil {
+		return ""
+	}
+	return d.inst.ID
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *SessionInfoDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// SetUsage records the session's token usage; ok is false when its tool
+// has none to read.
+func (d *SessionInfoDialog) SetUsage(usage session.Usage, ok bool) {
+	d.usage = usage
+	d.usageOK = ok
+	d.usageLoaded = true
+}
+
+// pageSize is the number of lines visible at once.
+func (d *SessionInfoDialog) pageSize() int {
+	if d.height <= 0 {
+		return 40
+	}
+	return max(d.height-8, 5) // Title, footer and box padding
+}
+
+// Update handles scrolling and closing.
+func (d *SessionInfoDialog) Update(msg tea.KeyMsg) (*SessionInfoDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		d.offset++
+	case "k", "up":
+		d.offset--
+	case "esc", "q", "I", "enter":
+		d.Hide()
+	}
+	d.offset = max(d.offset, 0) // Clamped from above in View
+	return d, nil
+}
+
+// infoRows returns the session's details as label/value pairs. Empty
+// values are left out.
+func (d *SessionInfoDialog) infoRows(now time.Time) [][2]string {
+	inst := d.inst
+	var rows [][2]string
+	add := func(label, value string) {
+		if value != "" {
+			rows = append(rows, [2]string{label, value})
+		}
+	}
+
+	status := strings.ReplaceAll(string(inst.GetStatusThreadSafe()), "_", " ")
+	if reason := inst.GetErrorReasonThreadSafe(); inst.GetStatusThreadSafe() == session.StatusError && reason != "" {
+		status += " · " + reason
+	}
+	add("Status", status)
+	add("ID", inst.ID)
+	tool := inst.Tool
+	if model := inst.GetDetectedModelThreadSafe(); model != "" {
+		tool += " · " + model
+	}
+	add("Tool", tool)
+	group := inst.GroupPath
+	if group == "" {
+		group = "(ungrouped)"
+	}
+	add("Group", group)
+	add("Path", inst.ProjectPath)
+	if inst.IsWorktree() {
+		add("Worktree", inst.WorktreeBranch+" · from "+inst.WorktreeRepoRoot)
+	}
+	add("Command", inst.Command)
+	add("Wrapper", inst.Wrapper)
+
+	if ts := inst.GetTmuxSession(); ts != nil {
+		add("tmux session", ts.Name)
+		if pane, ok := tmux.GetCachedPaneInfo(ts.Name); ok {
+			pid := strconv.Itoa(pane.PID)
+			if pane.CurrentCommand != "" {
+				pid += " · running " + pane.CurrentCommand
+			}
+			add("PID", pid)
+			add("Pane size", fmt.Sprintf("%dx%d", pane.Width, pane.Height))
+		}
+	}
+
+	if !inst.CreatedAt.IsZero() {
+		add("Created", inst.CreatedAt.Local().Format("2006-01-02 15:04")+" · up "+formatDuration(now.Sub(inst.CreatedAt).Truncate(time.Minute)))
+	}
+	add("Last activity", formatRelativeTime(inst.IdleSince()))
+	if !inst.LastAccessedAt.IsZero() {
+		add("Last attached", formatRelativeTime(inst.LastAccessedAt))
+	}
+
+	add("Claude session", inst.ClaudeSessionID)
+	add("Gemini session", inst.GeminiSessionID)
+	add("Codex session", inst.CodexSessionID)
+	add("OpenCode", inst.OpenCodeSessionID)
+	if inst.ForkedFromID != "" {
+		add("Forked from", inst.ForkedFromID+" · "+formatRelativeTime(inst.ForkedAt))
+	}
+
+	switch {
+	case !d.usageLoaded:
+		add("Tokens", "reading transcript...")
+	case d.usageOK:
+		usage := fmt.Sprintf("%s tokens · %d turns · $%.2f", formatNumber(d.usage.TotalTokens()), d.usage.Turns, d.usage.Cost)
+		add("Tokens", usage)
+	}
+
+	if len(inst.Tags) > 0 {
+		add("Tags", "#"+strings.Join(inst.Tags, " #"))
+	}
+	add("Notes", inst.Notes)
+	return rows
+}
+
+// View renders the session info dialog.
+func (d *SessionInfoDialog) View() string {
+	if !d.visible || d.inst == nil {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	headingStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorText)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim).
+		Width(sessionInfoLabelWidth)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 80
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 40)
+	}
+	valueStyle := lipgloss.NewStyle().
+		Foreground(ColorText).
+		Width(dialogWidth - 6 - sessionInfoLabelWidth)
+
+	now := time.Now()
+	var body []string
+	for _, row := range d.infoRows(now) {
+		value := lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(row[0]), valueStyle.Render(row[1]))
+		body = append(body, strings.Split(value, "\n")...)
+	}
+
+	// Status history, newest first, each with how long it lasted
+	if history := d.inst.GetStatusHistory(); len(history) > 0 {
+		body = append(body, "", headingStyle.Render("Status history"))
+		for k := len(history) - 1; k >= 0; k-- {
+			t := history[k]
+			var lasted string
+			if k == len(history)-1 {
+				lasted = formatDuration(now.Sub(t.At).Truncate(time.Second)) + " so far"
+			} else {
+				lasted = formatDuration(history[k+1].At.Sub(t.At).Truncate(time.Second))
+			}
+			line := fmt.Sprintf("  %s  %s %s", t.At.Local().Format("15:04:05"),
+				StatusIndicator(string(t.Status)), strings.ReplaceAll(string(t.Status), "_", " "))
+			body = append(body, line+dimStyle.Render(" · "+lasted))
+		}
+	}
+
+	maxOffset := max(0, len(body)-d.pageSize())
+	d.offset = min(d.offset, maxOffset)
+	end := min(d.offset+d.pageSize(), len(body))
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Session: "+d.inst.Title))
+	lines = append(lines, "")
+	lines = append(lines, body[d.offset:end]...)
+	lines = append(lines, "")
+	footer := "Esc close"
+	if maxOffset > 0 {
+		footer = "j/k scroll | " + footer
+	}
+	lines = append(lines, footerStyle.Render(footer))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
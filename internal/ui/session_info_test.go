@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestSessionInfoDialog(t *testing.T) {
+	inst := session.NewInstance("api", "/tmp/api")
+	inst.GroupPath = "work"
+	inst.ClaudeSessionID = "abc-123"
+	inst.Notes = "fix the flaky login test"
+
+	d := NewSessionInfoDialog()
+	d.SetSize(120, 60)
+	d.Show(inst)
+	view := d.View()
+	for _, want := range []string{"Session: api", "/tmp/api", "work", "abc-123", "fix the flaky login test", "reading transcript"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q", want)
+		}
+	}
+
+	d.SetUsage(session.Usage{InputTokens: 1000, OutputTokens: 500, Turns: 3, Cost: 0.25}, true)
+	if view := d.View(); !strings.Contains(view, "1,500 tokens · 3 turns · $0.25") {
+		t.Error("view should show the usage")
+	}
+	d.SetUsage(session.Usage{}, false)
+	if view := d.View(); strings.Contains(view, "Tokens") {
+		t.Error("a tool without usage should leave the row out")
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if d.IsVisible() || d.SessionID() != "" {
+		t.Error("esc should close the dialog")
+	}
+}
+
+func TestHomeSessionInfoKey(t *testing.T) {
+	home := NewHome()
+	home.width = 120
+	home.height = 40
+	home.initialLoading = false
+	inst := session.NewInstance("api", "/tmp/api")
+	setSessions(home, inst)
+	home.rebuildFlatItems()
+	for i, item := range home.flatItems {
+		if item.Session == inst {
+			home.cursor = i
+		}
+	}
+
+	_, cmd := home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'I'}})
+	if home.sessionInfoDialog.SessionID() != inst.ID {
+		t.Fatal("I should open the selected session's details")
+	}
+	if cmd == nil {
+		t.Fatal("opening the details should read the session's usage")
+	}
+	home.Update(sessionInfoUsageMsg{sessionID: "other", ok: true})
+	if !strings.Contains(home.View(), "reading transcript") {
+		t.Error("usage for another session should be ignored")
+	}
+	home.Update(sessionInfoUsageMsg{sessionID: inst.ID})
+	if strings.Contains(home.View(), "reading transcript") {
+		t.Error("the session's usage should be recorded")
+	}
+}
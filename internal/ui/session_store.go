@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// SessionStore holds the sessions and the group tree they're organized in.
+// Update handlers change it on the UI goroutine while status polling, the
+// notification sync and the web view read it from their own goroutines, so
+// every access goes through its lock.
+//
+// Only the UI goroutine writes, so it may read the tree Tree returns
+// without locking. Other goroutines read through All, Get and View.
+type SessionStore struct {
+	mu        sync.RWMutex
+	instances []*session.Instance
+	byID      map[string]*session.Instance // O(1) lookup by ID
+	tree      *session.GroupTree
+}
+
+// NewSessionStore returns an empty store.
+func NewSessionStore() *SessionStore {
+	return &SessionStore{
+		byID: make(map[string]*session.Instance),
+		tree: session.NewGroupTree(nil),
+	}
+}
+
+// All returns a copy of the session list, safe to keep and iterate.
+func (s *SessionStore) All() []*session.Instance {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.instances)
+}
+
+// Len returns the number of sessions.
+func (s *SessionStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.instances)
+}
+
+// Get returns the session with the given ID, or nil.
+func (s *SessionStore) Get(id string) *session.Instance {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.byID[id]
+}
+
+// Snapshot returns a copy of the session list and of the tree, taken
+// together, e.g. for saving them.
+func (s *SessionStore) Snapshot() ([]*session.Instance, *session.GroupTree) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return slices.Clone(s.instances), s.tree.ShallowCopyForSave()
+}
+
+// Tree returns the group tree. Only the UI goroutine may use it directly;
+// changes go through Update or Regroup.
+func (s *SessionStore) Tree() *session.GroupTree {
+	return s.tree
+}
+
+// View runs fn with the store read-locked. fn must not keep instances or
+// tree, nor change them.
+func (s *SessionStore) View(fn func(instances []*session.Instance, tree *session.GroupTree)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(s.instances, s.tree)
+}
+
+// Update runs fn with the store write-locked, for changes to the tree that
+// keep every session where it is: expanding, collapsing, creating groups.
+func (s *SessionStore) Update(fn func(tree *session.GroupTree)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.tree)
+}
+
+// Regroup runs fn with the store write-locked, for changes that move
+// sessions between or within groups, then takes the session order from
+// the tree.
+func (s *SessionStore) Regroup(fn func(tree *session.GroupTree)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(s.tree)
+	s.instances = s.tree.GetAllInstances()
+}
+
+// Add appends sessions to the list and to their groups.
+func (s *SessionStore) Add(instances ...*session.Instance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, inst := range instances {
+		s.instances = append(s.instances, inst)
+		s.byID[inst.ID] = inst
+		s.tree.AddSession(inst)
+	}
+}
+
+// Remove takes the session with the given ID out of the list and its
+// group, and returns it. Nil when there is no such session.
+func (s *SessionStore) Remove(id string) *session.Instance {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inst := s.byID[id]
+	if inst == nil {
+		return nil
+	}
+	s.instances = slices.DeleteFunc(s.instances, func(i *session.Instance) bool { return i.ID == id })
+	delete(s.byID, id)
+	s.tree.RemoveSession(inst)
+	return inst
+}
+
+// Replace swaps in a freshly loaded session list and its tree.
+func (s *SessionStore) Replace(instances []*session.Instance, tree *session.GroupTree) {
+	byID := make(map[string]*session.Instance, len(instances))
+	for _, inst := range instances {
+		byID[inst.ID] = inst
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.instances = instances
+	s.byID = byID
+	s.tree = tree
+}
+
+// DedupClaudeSessions makes sure no two sessions share a Claude session ID,
+// e.g. after adding one whose ID was detected from a shared directory.
+func (s *SessionStore) DedupClaudeSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session.UpdateClaudeSessionsWithDedup(s.instances)
+}
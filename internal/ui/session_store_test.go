@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// setSessions replaces home's sessions, grouped by their group paths.
+func setSessions(home *Home, instances ...*session.Instance) {
+	home.sessions.Replace(instances, session.NewGroupTree(instances))
+}
+
+func TestSessionStoreAddRemove(t *testing.T) {
+	s := NewSessionStore()
+	a := session.NewInstanceWithGroup("a", "/tmp/a", "work")
+	b := session.NewInstanceWithGroup("b", "/tmp/b", "work")
+	s.Add(a, b)
+
+	if s.Len() != 2 || s.Get(a.ID) != a {
+		t.Fatalf("Add should list and index sessions, len = %d", s.Len())
+	}
+	if got := len(s.Tree().Groups["work"].Sessions); got != 2 {
+		t.Errorf("work has %d sessions, want 2", got)
+	}
+
+	if s.Remove(a.ID) != a || s.Get(a.ID) != nil || s.Len() != 1 {
+		t.Error("Remove should return the session and forget it")
+	}
+	if got := s.Tree().Groups["work"].Sessions; len(got) != 1 || got[0] != b {
+		t.Errorf("work should only hold b, got %v", got)
+	}
+	if s.Remove("missing") != nil {
+		t.Error("removing an unknown ID should return nil")
+	}
+}
+
+func TestSessionStoreRegroup(t *testing.T) {
+	s := NewSessionStore()
+	a := session.NewInstanceWithGroup("a", "/tmp/a", "work")
+	b := session.NewInstanceWithGroup("b", "/tmp/b", "play")
+	s.Add(a, b)
+
+	s.Regroup(func(tree *session.GroupTree) { tree.MoveSessionToGroup(b, "work") })
+	all := s.All()
+	if len(all) != 2 || b.GroupPath != "work" {
+		t.Fatalf("b should have moved to work, got %q", b.GroupPath)
+	}
+	if got := len(s.Tree().Groups["work"].Sessions); got != 2 {
+		t.Errorf("work has %d sessions, want 2", got)
+	}
+}
+
+func TestSessionStoreConcurrentReads(t *testing.T) {
+	s := NewSessionStore()
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for _, inst := range s.All() {
+					_ = s.Get(inst.ID)
+				}
+				_, _ = s.Snapshot()
+			}
+		}()
+	}
+	for i := range 100 {
+		inst := session.NewInstanceWithGroup("s", "/tmp/s", "work")
+		s.Add(inst)
+		s.Update(func(tree *session.GroupTree) { tree.ToggleGroup("work") })
+		if i%2 == 0 {
+			s.Remove(inst.ID)
+		}
+	}
+	close(done)
+	wg.Wait()
+	if s.Len() != 50 {
+		t.Errorf("len = %d, want 50", s.Len())
+	}
+}
@@ -1,6 +1,9 @@
 package ui
 
 import (
+	"maps"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -45,7 +48,9 @@ type SettingsPanel struct {
 	profile      string
 
 	// Setting values
-	selectedTheme       int // 0=dark, 1=light, 2=system
+	selectedTheme       int // Index into themeValues: 0=dark, 1=light, 2=system, ...
+	themeNames          []string
+	themeValues         []string
 	selectedTool        int // 0=claude, 1=gemini, 2=opencode, 3=codex, 4=none
 	dangerousMode       bool
 	claudeConfigDir     string
@@ -83,13 +88,36 @@ var toolValues = []string{"claude", "gemini", "opencode", "codex", ""}
 var tierNames = []string{"Auto", "Instant", "Balanced"}
 var tierValues = []string{"auto", "instant", "balanced"}
 
-// Theme names for radio selection
-var themeNames = []string{"Dark", "Light", "System"}
-var themeValues = []string{"dark", "light", "system"}
+// Theme names for radio selection. Themes added after System are appended so
+// existing indexes stay stable; [themes.*] palettes are added by themeOptions.
+var themeNames = []string{"Dark", "Light", "System", "Solarized", "Solarized Light", "High Contrast"}
+var themeValues = []string{"dark", "light", "system", "solarized", "solarized-light", "high-contrast"}
+
+// themeOptions returns the built-in theme choices followed by custom themes
+func themeOptions(config *session.UserConfig) (names, values []string) {
+	names = append([]string(nil), themeNames...)
+	values = append([]string(nil), themeValues...)
+	if config == nil {
+		return names, values
+	}
+	var custom []string
+	for name := range config.Themes {
+		if !slices.Contains(values, name) {
+			custom = append(custom, name)
+		}
+	}
+	sort.Strings(custom)
+	names = append(names, custom...)
+	values = append(values, custom...)
+	return names, values
+}
 
 // NewSettingsPanel creates a new settings panel
 func NewSettingsPanel() *SettingsPanel {
+	names, values := themeOptions(nil)
 	return &SettingsPanel{
+		themeNames:          names,
+		themeValues:         values,
 		logMaxSizeMB:        10,
 		logMaxLines:         10000,
 		removeOrphans:       true,
@@ -147,13 +175,13 @@ func (s *SettingsPanel) SetProfile(profile string) {
 // LoadConfig populates panel values from a UserConfig
 func (s *SettingsPanel) LoadConfig(config *session.UserConfig) {
 	// Load theme
-	switch config.Theme {
-	case "light":
-		s.selectedTheme = 1
-	case "system":
-		s.selectedTheme = 2
-	default:
-		s.selectedTheme = 0
+	s.themeNames, s.themeValues = themeOptions(config)
+	s.selectedTheme = 0
+	for i, val := range s.themeValues {
+		if val == config.Theme {
+			s.selectedTheme = i
+			break
+		}
 	}
 
 	// Default tool
@@ -221,15 +249,23 @@ func (s *SettingsPanel) LoadConfig(config *session.UserConfig) {
 
 // GetConfig returns a UserConfig with current panel values
 func (s *SettingsPanel) GetConfig() *session.UserConfig {
-	config := &session.UserConfig{
-		DefaultTool: "",
-		Tools:       make(map[string]session.ToolDef),
-		MCPs:        make(map[string]session.MCPDef),
+	// Start from the loaded config and overwrite only what the panel edits,
+	// so settings it doesn't show survive a save
+	config := &session.UserConfig{}
+	if s.originalConfig != nil {
+		copied := *s.originalConfig
+		config = &copied
+	}
+	if config.Tools == nil {
+		config.Tools = make(map[string]session.ToolDef)
+	}
+	if config.MCPs == nil {
+		config.MCPs = make(map[string]session.MCPDef)
 	}
 
 	// Theme
-	if s.selectedTheme < len(themeValues) {
-		config.Theme = themeValues[s.selectedTheme]
+	if s.selectedTheme < len(s.themeValues) {
+		config.Theme = s.themeValues[s.selectedTheme]
 	}
 
 	// Default tool
@@ -237,7 +273,8 @@ func (s *SettingsPanel) GetConfig() *session.UserConfig {
 		config.DefaultTool = toolValues[s.selectedTool]
 	}
 
-	// Claude settings
+	// Claude settings. When editing a profile-specific override the global
+	// config dir stays as loaded.
 	dangerousModeVal := s.dangerousMode
 	config.Claude.DangerousMode = &dangerousModeVal
 	if !s.claudeConfigIsScope {
@@ -275,20 +312,10 @@ func (s *SettingsPanel) GetConfig() *session.UserConfig {
 	// Maintenance settings
 	config.Maintenance.Enabled = s.maintenanceEnabled
 
-	// Preserve original MCPs and Tools if we have them
-	if s.originalConfig != nil {
-		config.MCPs = s.originalConfig.MCPs
-		config.Tools = s.originalConfig.Tools
-		config.MCPPool = s.originalConfig.MCPPool
-		config.Profiles = s.originalConfig.Profiles
-		// Keep global Claude config when editing profile-specific override.
-		if s.claudeConfigIsScope {
-			config.Claude.ConfigDir = s.originalConfig.Claude.ConfigDir
-		}
-	}
-
-	// Apply profile-specific Claude override after original profile map is restored.
+	// Apply profile-specific Claude override to a copy of the profile map,
+	// leaving the loaded config untouched
 	if s.claudeConfigIsScope && s.profile != "" {
+		config.Profiles = maps.Clone(config.Profiles)
 		if config.Profiles == nil {
 			config.Profiles = make(map[string]session.ProfileSettings)
 		}
@@ -355,7 +382,7 @@ func (s *SettingsPanel) adjustValue(delta int) bool {
 	switch setting {
 	case SettingTheme:
 		newVal := s.selectedTheme + delta
-		if newVal >= 0 && newVal < len(themeNames) {
+		if newVal >= 0 && newVal < len(s.themeNames) {
 			s.selectedTheme = newVal
 			changed = true
 		}
@@ -553,7 +580,8 @@ func (s *SettingsPanel) View() string {
 		content.WriteString(warningStyle.Render(" (restart required)"))
 	}
 	content.WriteString("\n")
-	themeRow := s.renderRadioGroup(themeNames, s.selectedTheme, s.cursor == int(SettingTheme))
+	themeRow := s.renderRadioGroupWrapped(s.themeNames, s.selectedTheme, dialogWidth-6)
+	themeExtraLines := strings.Count(themeRow, "\n")
 	if s.cursor == int(SettingTheme) {
 		themeRow = highlightStyle.Render(themeRow)
 	}
@@ -756,6 +784,9 @@ func (s *SettingsPanel) View() string {
 			38, // SettingMaintenanceEnabled
 		}
 		cursorLine := cursorToLine[s.cursor]
+		if s.cursor != int(SettingTheme) {
+			cursorLine += themeExtraLines // Theme options wrap onto extra lines
+		}
 
 		// Ensure cursor is visible with 2 lines of context
 		if cursorLine-2 < s.scrollOffset {
@@ -828,6 +859,30 @@ func (s *SettingsPanel) renderCheckbox(label string, checked bool) string {
 
 // renderRadioGroup renders a group of radio options
 func (s *SettingsPanel) renderRadioGroup(options []string, selected int, focused bool) string {
+	return strings.Join(radioParts(options, selected), "  ")
+}
+
+// renderRadioGroupWrapped renders radio options across as many lines as
+// needed to stay within maxWidth.
+func (s *SettingsPanel) renderRadioGroupWrapped(options []string, selected int, maxWidth int) string {
+	var lines []string
+	var line string
+	for _, part := range radioParts(options, selected) {
+		if line != "" && lipgloss.Width(line)+2+lipgloss.Width(part) > maxWidth {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += "  "
+		}
+		line += part
+	}
+	lines = append(lines, line)
+	return strings.Join(lines, "\n  ")
+}
+
+// radioParts renders each radio option, marking the selected one
+func radioParts(options []string, selected int) []string {
 	var parts []string
 	for i, opt := range options {
 		if i == selected {
@@ -838,7 +893,7 @@ func (s *SettingsPanel) renderRadioGroup(options []string, selected int, focused
 			parts = append(parts, style.Render(" "+opt))
 		}
 	}
-	return strings.Join(parts, "  ")
+	return parts
 }
 
 // renderNumber renders a number input with label and suffix
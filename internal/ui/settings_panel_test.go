@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/asheshgoplani/agent-deck/internal/session"
@@ -645,6 +647,8 @@ func TestSettingsPanel_LoadConfig_Theme(t *testing.T) {
 		{"dark", "dark", 0},
 		{"light", "light", 1},
 		{"system", "system", 2},
+		{"solarized", "solarized", 3},
+		{"high-contrast", "high-contrast", 5},
 		{"empty defaults to dark", "", 0},
 		{"invalid defaults to dark", "invalid", 0},
 	}
@@ -662,6 +666,27 @@ func TestSettingsPanel_LoadConfig_Theme(t *testing.T) {
 	}
 }
 
+func TestSettingsPanel_CustomThemeOption(t *testing.T) {
+	panel := NewSettingsPanel()
+	config := &session.UserConfig{
+		Theme:  "paper",
+		Themes: map[string]session.ThemePalette{"paper": {Base: "light"}},
+	}
+	panel.LoadConfig(config)
+
+	if got := panel.themeValues[panel.selectedTheme]; got != "paper" {
+		t.Fatalf("selected theme = %q, want paper", got)
+	}
+	panel.originalConfig = config
+	saved := panel.GetConfig()
+	if saved.Theme != "paper" {
+		t.Errorf("Theme: got %q, want paper", saved.Theme)
+	}
+	if _, ok := saved.Themes["paper"]; !ok {
+		t.Error("custom [themes] palettes should be preserved on save")
+	}
+}
+
 func TestSettingsPanel_GetConfig_Theme(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -815,3 +840,64 @@ func TestSettingsPanel_PreviewSettings_ViewContains(t *testing.T) {
 		}
 	}
 }
+
+// TestSettingsPanel_SaveKeepsOtherSettings saves from the panel and checks
+// that settings it doesn't show survive the round trip through config.toml.
+func TestSettingsPanel_SaveKeepsOtherSettings(t *testing.T) {
+	tests := []struct {
+		name  string
+		toml  string
+		check func(*session.UserConfig) bool
+	}{
+		{"multiplexer", `multiplexer = "screen"`, func(c *session.UserConfig) bool {
+			return c.Multiplexer == "screen"
+		}},
+		{"claude settings the panel doesn't edit", "[claude]\nhooks_enabled = false", func(c *session.UserConfig) bool {
+			return c.Claude.HooksEnabled != nil && !*c.Claude.HooksEnabled
+		}},
+		{"snippets", "[snippets]\nreview = \"Review {{branch}}\"", func(c *session.UserConfig) bool {
+			return c.Snippets["review"] == "Review {{branch}}"
+		}},
+		{"cleanup", "[cleanup]\nidle_hours = 72\naction = \"kill\"", func(c *session.UserConfig) bool {
+			return c.Cleanup.IdleHours == 72 && c.Cleanup.Action == "kill"
+		}},
+		{"confirm_delete", "confirm_delete = false", func(c *session.UserConfig) bool {
+			return c.ConfirmDelete != nil && !*c.ConfirmDelete
+		}},
+		{"number_keys", `number_keys = "sessions"`, func(c *session.UserConfig) bool {
+			return c.NumberKeys == "sessions"
+		}},
+		{"tool_icons", `tool_icons = "text"`, func(c *session.UserConfig) bool {
+			return c.ToolIcons == "text"
+		}},
+		{"projects", "[projects]\nroots = [\"~/work\"]\nmax_depth = 2", func(c *session.UserConfig) bool {
+			return len(c.Projects.Roots) == 1 && c.Projects.Roots[0] == "~/work" && c.Projects.MaxDepth == 2
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			t.Setenv("AGENTDECK_HOME", dir)
+			session.ClearUserConfigCache()
+			defer session.ClearUserConfigCache()
+			path := filepath.Join(dir, session.UserConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.toml+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			panel := NewSettingsPanel()
+			panel.Show()
+			if err := session.SaveUserConfig(panel.GetConfig()); err != nil {
+				t.Fatal(err)
+			}
+			config, err := session.ReloadUserConfig()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.check(config) {
+				saved, _ := os.ReadFile(path)
+				t.Errorf("setting lost on save; config.toml:\n%s", saved)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/asheshgoplani/agent-deck/internal/session"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 )
 
 // SkillColumn identifies the focused column.
@@ -434,9 +435,7 @@ func (d *SkillDialog) renderColumn(title string, items []SkillDialogItem, select
 		if item.Candidate.Source != "" {
 			label += " [" + item.Candidate.Source + "]"
 		}
-		if len(label) > colWidth-4 {
-			label = label[:colWidth-7] + "..."
-		}
+		label = runewidth.Truncate(label, colWidth-4, "...")
 
 		if i == selectedIdx && focused {
 			lines = append(lines, lipgloss.NewStyle().
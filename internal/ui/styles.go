@@ -3,27 +3,36 @@ package ui
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 // Theme represents the current color scheme
 type Theme string
 
 const (
-	ThemeDark  Theme = "dark"
-	ThemeLight Theme = "light"
+	ThemeDark           Theme = "dark"
+	ThemeLight          Theme = "light"
+	ThemeSolarized      Theme = "solarized"
+	ThemeSolarizedLight Theme = "solarized-light"
+	ThemeHighContrast   Theme = "high-contrast"
 )
 
 // currentTheme holds the active theme (set at init)
 var currentTheme Theme = ThemeDark
 
-// Dark Theme - Tokyo Night
-var darkColors = struct {
+// Palette is the set of colors a theme provides
+type Palette struct {
 	Bg, Surface, Border, Text, TextDim  lipgloss.Color
 	Accent, Purple, Cyan, Green, Yellow lipgloss.Color
 	Orange, Red, Comment                lipgloss.Color
-}{
+}
+
+// Dark Theme - Tokyo Night
+var darkColors = Palette{
 	Bg:      lipgloss.Color("#1a1b26"),
 	Surface: lipgloss.Color("#24283b"),
 	Border:  lipgloss.Color("#414868"),
@@ -40,11 +49,7 @@ var darkColors = struct {
 }
 
 // Light Theme - Tokyo Night Light variant
-var lightColors = struct {
-	Bg, Surface, Border, Text, TextDim  lipgloss.Color
-	Accent, Purple, Cyan, Green, Yellow lipgloss.Color
-	Orange, Red, Comment                lipgloss.Color
-}{
+var lightColors = Palette{
 	Bg:      lipgloss.Color("#d5d6db"),
 	Surface: lipgloss.Color("#e9e9ec"),
 	Border:  lipgloss.Color("#9699a3"),
@@ -60,6 +65,66 @@ var lightColors = struct {
 	Comment: lipgloss.Color("#6a6d7c"),
 }
 
+// Solarized Theme - Solarized Dark
+var solarizedColors = Palette{
+	Bg:      lipgloss.Color("#002b36"),
+	Surface: lipgloss.Color("#073642"),
+	Border:  lipgloss.Color("#586e75"),
+	Text:    lipgloss.Color("#93a1a1"),
+	TextDim: lipgloss.Color("#839496"),
+	Accent:  lipgloss.Color("#268bd2"),
+	Purple:  lipgloss.Color("#6c71c4"),
+	Cyan:    lipgloss.Color("#2aa198"),
+	Green:   lipgloss.Color("#859900"),
+	Yellow:  lipgloss.Color("#b58900"),
+	Orange:  lipgloss.Color("#cb4b16"),
+	Red:     lipgloss.Color("#dc322f"),
+	Comment: lipgloss.Color("#839496"),
+}
+
+// Solarized Light Theme
+var solarizedLightColors = Palette{
+	Bg:      lipgloss.Color("#fdf6e3"),
+	Surface: lipgloss.Color("#eee8d5"),
+	Border:  lipgloss.Color("#93a1a1"),
+	Text:    lipgloss.Color("#073642"),
+	TextDim: lipgloss.Color("#586e75"),
+	Accent:  lipgloss.Color("#268bd2"),
+	Purple:  lipgloss.Color("#6c71c4"),
+	Cyan:    lipgloss.Color("#2aa198"),
+	Green:   lipgloss.Color("#859900"),
+	Yellow:  lipgloss.Color("#b58900"),
+	Orange:  lipgloss.Color("#cb4b16"),
+	Red:     lipgloss.Color("#dc322f"),
+	Comment: lipgloss.Color("#586e75"),
+}
+
+// High Contrast Theme - pure black background, saturated colors
+var highContrastColors = Palette{
+	Bg:      lipgloss.Color("#000000"),
+	Surface: lipgloss.Color("#1c1c1c"),
+	Border:  lipgloss.Color("#ffffff"),
+	Text:    lipgloss.Color("#ffffff"),
+	TextDim: lipgloss.Color("#d0d0d0"),
+	Accent:  lipgloss.Color("#00afff"),
+	Purple:  lipgloss.Color("#ff87ff"),
+	Cyan:    lipgloss.Color("#00ffff"),
+	Green:   lipgloss.Color("#00ff00"),
+	Yellow:  lipgloss.Color("#ffff00"),
+	Orange:  lipgloss.Color("#ffaf00"),
+	Red:     lipgloss.Color("#ff5f5f"),
+	Comment: lipgloss.Color("#d0d0d0"),
+}
+
+// builtinPalettes maps built-in theme names (session.BuiltinThemes) to palettes
+var builtinPalettes = map[Theme]Palette{
+	ThemeDark:           darkColors,
+	ThemeLight:          lightColors,
+	ThemeSolarized:      solarizedColors,
+	ThemeSolarizedLight: solarizedLightColors,
+	ThemeHighContrast:   highContrastColors,
+}
+
 // Active color variables (set by InitTheme)
 var (
 	ColorBg      lipgloss.Color
@@ -81,46 +146,71 @@ var (
 // Write lock held by InitTheme; read lock held by GetToolStyle (map access).
 var themeMu sync.RWMutex
 
-// InitTheme sets the active color palette based on theme name
-// Must be called before any UI rendering
+// styleGeneration counts initStyles calls, so caches of rendered output can
+// tell when a theme switch has made them stale.
+var styleGeneration atomic.Uint64
+
+// InitTheme sets the active color palette based on theme name: a built-in
+// theme, or a [themes.<name>] palette from config.toml. Unknown names fall
+// back to dark. Must be called before any UI rendering
 func InitTheme(theme string) {
+	palette, name := resolvePalette(theme)
+
 	themeMu.Lock()
 	defer themeMu.Unlock()
-	if theme == "light" {
-		currentTheme = ThemeLight
-		ColorBg = lightColors.Bg
-		ColorSurface = lightColors.Surface
-		ColorBorder = lightColors.Border
-		ColorText = lightColors.Text
-		ColorTextDim = lightColors.TextDim
-		ColorAccent = lightColors.Accent
-		ColorPurple = lightColors.Purple
-		ColorCyan = lightColors.Cyan
-		ColorGreen = lightColors.Green
-		ColorYellow = lightColors.Yellow
-		ColorOrange = lightColors.Orange
-		ColorRed = lightColors.Red
-		ColorComment = lightColors.Comment
-	} else {
-		currentTheme = ThemeDark
-		ColorBg = darkColors.Bg
-		ColorSurface = darkColors.Surface
-		ColorBorder = darkColors.Border
-		ColorText = darkColors.Text
-		ColorTextDim = darkColors.TextDim
-		ColorAccent = darkColors.Accent
-		ColorPurple = darkColors.Purple
-		ColorCyan = darkColors.Cyan
-		ColorGreen = darkColors.Green
-		ColorYellow = darkColors.Yellow
-		ColorOrange = darkColors.Orange
-		ColorRed = darkColors.Red
-		ColorComment = darkColors.Comment
-	}
+	currentTheme = name
+	ColorBg = palette.Bg
+	ColorSurface = palette.Surface
+	ColorBorder = palette.Border
+	ColorText = palette.Text
+	ColorTextDim = palette.TextDim
+	ColorAccent = palette.Accent
+	ColorPurple = palette.Purple
+	ColorCyan = palette.Cyan
+	ColorGreen = palette.Green
+	ColorYellow = palette.Yellow
+	ColorOrange = palette.Orange
+	ColorRed = palette.Red
+	ColorComment = palette.Comment
 	// Reinitialize styles with new colors
 	initStyles()
 }
 
+// resolvePalette finds the palette for a theme name. User-defined palettes
+// start from their base theme (default dark) and override the colors they set.
+func resolvePalette(theme string) (Palette, Theme) {
+	if palette, ok := builtinPalettes[Theme(theme)]; ok {
+		return palette, Theme(theme)
+	}
+	if def := session.GetThemePalette(theme); def != nil {
+		palette, ok := builtinPalettes[Theme(def.Base)]
+		if !ok {
+			palette = darkColors
+		}
+		overrideColor(&palette.Bg, def.Bg)
+		overrideColor(&palette.Surface, def.Surface)
+		overrideColor(&palette.Border, def.Border)
+		overrideColor(&palette.Text, def.Text)
+		overrideColor(&palette.TextDim, def.TextDim)
+		overrideColor(&palette.Accent, def.Accent)
+		overrideColor(&palette.Purple, def.Purple)
+		overrideColor(&palette.Cyan, def.Cyan)
+		overrideColor(&palette.Green, def.Green)
+		overrideColor(&palette.Yellow, def.Yellow)
+		overrideColor(&palette.Orange, def.Orange)
+		overrideColor(&palette.Red, def.Red)
+		overrideColor(&palette.Comment, def.Comment)
+		return palette, Theme(theme)
+	}
+	return darkColors, ThemeDark
+}
+
+func overrideColor(dst *lipgloss.Color, value string) {
+	if value != "" {
+		*dst = lipgloss.Color(value)
+	}
+}
+
 // GetCurrentTheme returns the active theme
 func GetCurrentTheme() Theme {
 	return currentTheme
@@ -148,6 +238,7 @@ var (
 var (
 	RunningStyle        lipgloss.Style
 	WaitingStyle        lipgloss.Style
+	NeedsInputStyle     lipgloss.Style
 	IdleStyle           lipgloss.Style
 	ErrorIndicatorStyle lipgloss.Style
 )
@@ -234,11 +325,12 @@ var (
 	TreeConnectorSelStyle lipgloss.Style
 
 	// Session status indicator styles
-	SessionStatusRunning  lipgloss.Style
-	SessionStatusWaiting  lipgloss.Style
-	SessionStatusIdle     lipgloss.Style
-	SessionStatusError    lipgloss.Style
-	SessionStatusSelStyle lipgloss.Style
+	SessionStatusRunning    lipgloss.Style
+	SessionStatusWaiting    lipgloss.Style
+	SessionStatusNeedsInput lipgloss.Style
+	SessionStatusIdle       lipgloss.Style
+	SessionStatusError      lipgloss.Style
+	SessionStatusSelStyle   lipgloss.Style
 
 	// Session title styles by state
 	SessionTitleDefault  lipgloss.Style
@@ -249,13 +341,23 @@ var (
 	// Selection indicator
 	SessionSelectionPrefix lipgloss.Style
 
+	// Session badge styles
+	SessionModelStyle            lipgloss.Style
+	SessionYoloStyle             lipgloss.Style
+	SessionQueueStyle            lipgloss.Style
+	SessionRestartStyle          lipgloss.Style
+	SessionRestartExhaustedStyle lipgloss.Style
+	SessionGitStyle              lipgloss.Style
+	SessionDetailStyle           lipgloss.Style
+
 	// Group item styles
-	GroupExpandStyle   lipgloss.Style
-	GroupNameStyle     lipgloss.Style
-	GroupCountStyle    lipgloss.Style
-	GroupHotkeyStyle   lipgloss.Style
-	GroupStatusRunning lipgloss.Style
-	GroupStatusWaiting lipgloss.Style
+	GroupExpandStyle      lipgloss.Style
+	GroupNameStyle        lipgloss.Style
+	GroupCountStyle       lipgloss.Style
+	GroupHotkeyStyle      lipgloss.Style
+	GroupStatusRunning    lipgloss.Style
+	GroupStatusWaiting    lipgloss.Style
+	GroupStatusNeedsInput lipgloss.Style
 
 	// Group selected styles
 	GroupNameSelStyle   lipgloss.Style
@@ -293,6 +395,8 @@ var LogoFrames = [][]string{
 // initStyles initializes all style variables with current theme colors
 // Called by InitTheme after color variables are set
 func initStyles() {
+	defer styleGeneration.Add(1)
+
 	// Base Styles
 	BaseStyle = lipgloss.NewStyle().
 		Foreground(ColorText).
@@ -341,6 +445,10 @@ func initStyles() {
 		Foreground(ColorYellow).
 		Bold(true)
 
+	NeedsInputStyle = lipgloss.NewStyle().
+		Foreground(ColorOrange).
+		Bold(true)
+
 	IdleStyle = lipgloss.NewStyle().
 		Foreground(ColorComment)
 
@@ -487,6 +595,7 @@ func initStyles() {
 	// Session status indicator styles
 	SessionStatusRunning = lipgloss.NewStyle().Foreground(ColorGreen)
 	SessionStatusWaiting = lipgloss.NewStyle().Foreground(ColorYellow)
+	SessionStatusNeedsInput = lipgloss.NewStyle().Foreground(ColorOrange).Bold(true)
 	SessionStatusIdle = lipgloss.NewStyle().Foreground(ColorTextDim)
 	SessionStatusError = lipgloss.NewStyle().Foreground(ColorRed)
 	SessionStatusSelStyle = lipgloss.NewStyle().Foreground(ColorBg).Background(ColorAccent)
@@ -500,6 +609,15 @@ func initStyles() {
 	// Selection indicator
 	SessionSelectionPrefix = lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
 
+	// Session badge styles
+	SessionModelStyle = lipgloss.NewStyle().Foreground(ColorTextDim)
+	SessionYoloStyle = lipgloss.NewStyle().Foreground(ColorYellow).Bold(true)
+	SessionQueueStyle = lipgloss.NewStyle().Foreground(ColorYellow)
+	SessionRestartStyle = lipgloss.NewStyle().Foreground(ColorYellow)
+	SessionRestartExhaustedStyle = lipgloss.NewStyle().Foreground(ColorRed)
+	SessionGitStyle = lipgloss.NewStyle().Foreground(ColorCyan)
+	SessionDetailStyle = lipgloss.NewStyle().Foreground(ColorTextDim)
+
 	// Group item styles
 	GroupExpandStyle = lipgloss.NewStyle().Foreground(ColorText)
 	GroupNameStyle = lipgloss.NewStyle().Bold(true).Foreground(ColorCyan)
@@ -507,6 +625,7 @@ func initStyles() {
 	GroupHotkeyStyle = lipgloss.NewStyle().Foreground(ColorComment)
 	GroupStatusRunning = lipgloss.NewStyle().Foreground(ColorGreen)
 	GroupStatusWaiting = lipgloss.NewStyle().Foreground(ColorYellow)
+	GroupStatusNeedsInput = lipgloss.NewStyle().Foreground(ColorOrange)
 
 	// Group selected styles
 	GroupNameSelStyle = lipgloss.NewStyle().Bold(true).Foreground(ColorBg).Background(ColorAccent)
@@ -560,7 +679,7 @@ func MenuKey(key, description string) string {
 
 // StatusIndicator returns a styled status indicator.
 // Read-locked to protect against concurrent style access during live theme switches.
-// Standard symbols: ● running, ◐ waiting, ○ idle, ✕ error, ⟳ starting
+// Standard symbols: ● running, ◆ needs input, ◐ waiting, ○ idle, ✕ error, ⟳ starting
 func StatusIndicator(status string) string {
 	themeMu.RLock()
 	defer themeMu.RUnlock()
@@ -569,6 +688,8 @@ func StatusIndicator(status string) string {
 		return RunningStyle.Render("●")
 	case "waiting":
 		return WaitingStyle.Render("◐")
+	case "needs_input":
+		return NeedsInputStyle.Render("◆")
 	case "idle":
 		return IdleStyle.Render("○")
 	case "error":
@@ -1,7 +1,13 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
 )
 
 func TestColorsDefined(t *testing.T) {
@@ -135,3 +141,58 @@ func TestToolStyleCache_ReinitializedOnThemeChange(t *testing.T) {
 	// Reset to dark for other tests
 	InitTheme("dark")
 }
+
+func TestInitTheme_Builtins(t *testing.T) {
+	defer InitTheme("dark")
+	for _, name := range session.BuiltinThemes {
+		palette, ok := builtinPalettes[Theme(name)]
+		if !ok {
+			t.Errorf("built-in theme %q has no palette", name)
+			continue
+		}
+		InitTheme(name)
+		if GetCurrentTheme() != Theme(name) {
+			t.Errorf("InitTheme(%q): current theme = %v", name, GetCurrentTheme())
+		}
+		if ColorBg != palette.Bg || ColorText != palette.Text {
+			t.Errorf("InitTheme(%q) did not apply its palette", name)
+		}
+	}
+}
+
+func TestInitTheme_CustomPalette(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	session.ClearUserConfigCache()
+	defer session.ClearUserConfigCache()
+	defer InitTheme("dark")
+
+	configDir := filepath.Join(tempDir, ".agent-deck")
+	if err := os.MkdirAll(configDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	config := `
+theme = "paper"
+
+[themes.paper]
+base = "light"
+accent = "#005f87"
+`
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	InitTheme("paper")
+	if GetCurrentTheme() != Theme("paper") {
+		t.Errorf("current theme = %v, want paper", GetCurrentTheme())
+	}
+	if ColorAccent != lipgloss.Color("#005f87") {
+		t.Errorf("ColorAccent = %v, want override #005f87", ColorAccent)
+	}
+	if ColorBg != lightColors.Bg {
+		t.Errorf("ColorBg = %v, want base light bg %v", ColorBg, lightColors.Bg)
+	}
+	if got := session.GetTheme(); got != "paper" {
+		t.Errorf("session.GetTheme() = %q, want paper", got)
+	}
+}
@@ -0,0 +1,206 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// TagDialogMode represents the tag dialog mode
+type TagDialogMode int
+
+const (
+	TagDialogEdit   TagDialogMode = iota // Edit a session's tags ("t")
+	TagDialogFilter                      // Pick a tag to filter the list by ("T")
+)
+
+// TagDialog edits session tags and picks the tag filter. Home applies the
+// result; the dialog only tracks input and selection.
+type TagDialog struct {
+	visible       bool
+	mode          TagDialogMode
+	width, height int
+
+	// Edit mode
+	sessionID string
+	title     string
+	input     textinput.Model
+
+	// Filter mode
+	tags   []string
+	counts map[string]int
+	cursor int
+}
+
+// NewTagDialog creates a new tag dialog.
+func NewTagDialog() *TagDialog {
+	ti := textinput.New()
+	ti.Placeholder = "bug, exp, client-x"
+	ti.CharLimit = 200
+	ti.Width = 40
+
+	return &TagDialog{input: ti}
+}
+
+// ShowEdit opens the dialog to edit a session's tags.
+func (d *TagDialog) ShowEdit(sessionID, title string, tags []string) {
+	d.visible = true
+	d.mode = TagDialogEdit
+	d.sessionID = sessionID
+	d.title = title
+	d.input.SetValue(strings.Join(tags, ", "))
+	d.input.CursorEnd()
+	d.input.Focus()
+}
+
+// ShowFilter opens the tag picker with the cursor on the active filter.
+func (d *TagDialog) ShowFilter(counts map[string]int, current string) {
+	d.visible = true
+	d.mode = TagDialogFilter
+	d.counts = counts
+	d.tags = make([]string, 0, len(counts))
+	for t := range counts {
+		d.tags = append(d.tags, t)
+	}
+	sort.Strings(d.tags)
+	d.cursor = 0
+	for i, t := range d.tags {
+		if t == current {
+			d.cursor = i
+			break
+		}
+	}
+}
+
+// Hide closes the dialog and resets state.
+func (d *TagDialog) Hide() {
+	d.visible = false
+	d.sessionID = ""
+	d.tags = nil
+	d.counts = nil
+	d.cursor = 0
+	d.input.Blur()
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *TagDialog) IsVisible() bool {
+	return d.visible
+}
+
+// Mode returns the current dialog mode.
+func (d *TagDialog) Mode() TagDialogMode {
+	return d.mode
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *TagDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// SessionID returns the ID of the session being edited.
+func (d *TagDialog) SessionID() string {
+	return d.sessionID
+}
+
+// GetTags returns the edited tags, normalized.
+func (d *TagDialog) GetTags() []string {
+	return session.ParseTags(d.input.Value())
+}
+
+// GetSelectedTag returns the tag at the cursor in filter mode, or "".
+func (d *TagDialog) GetSelectedTag() string {
+	if len(d.tags) == 0 || d.cursor >= len(d.tags) {
+		return ""
+	}
+	return d.tags[d.cursor]
+}
+
+// Update handles input and navigation. Enter and Esc are handled by Home.
+func (d *TagDialog) Update(msg tea.KeyMsg) (*TagDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+
+	if d.mode == TagDialogFilter {
+		switch msg.String() {
+		case "j", "down":
+			if len(d.tags) > 0 {
+				d.cursor = (d.cursor + 1) % len(d.tags)
+			}
+		case "k", "up":
+			if len(d.tags) > 0 {
+				d.cursor = (d.cursor - 1 + len(d.tags)) % len(d.tags)
+			}
+		}
+		return d, nil
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return d, cmd
+}
+
+// View renders the tag dialog.
+func (d *TagDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	dialogWidth := 48
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 30)
+	}
+
+	titleStyle := DialogTitleStyle.Width(dialogWidth - 4)
+	hintStyle := lipgloss.NewStyle().Foreground(ColorComment)
+
+	var title, content, hint string
+	switch d.mode {
+	case TagDialogEdit:
+		title = "Session Tags"
+		d.input.Width = dialogWidth - 8
+		content = lipgloss.NewStyle().Foreground(ColorCyan).Render(d.title) + "\n\n" + d.input.View()
+		hint = "Enter save │ Esc cancel │ comma or space separated"
+	case TagDialogFilter:
+		title = "Filter by Tag"
+		selectedStyle := lipgloss.NewStyle().Foreground(ColorAccent).Bold(true)
+		normalStyle := lipgloss.NewStyle().Foreground(ColorText)
+		countStyle := lipgloss.NewStyle().Foreground(ColorTextDim)
+		var lines []string
+		if len(d.tags) == 0 {
+			lines = append(lines, normalStyle.Render("No tagged sessions (press t on a session)"))
+		}
+		for i, t := range d.tags {
+			count := countStyle.Render(fmt.Sprintf(" (%d)", d.counts[t]))
+			if i == d.cursor {
+				lines = append(lines, "> "+selectedStyle.Render(t)+count)
+			} else {
+				lines = append(lines, "  "+normalStyle.Render(t)+count)
+			}
+		}
+		content = strings.Join(lines, "\n")
+		hint = "Enter filter │ 0 clear │ Esc cancel"
+	}
+
+	dialogContent := lipgloss.JoinVertical(
+		lipgloss.Left,
+		titleStyle.Render(title),
+		"",
+		content,
+		"",
+		hintStyle.Render(hint),
+	)
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(dialogContent)
+
+	return centerInScreen(box, d.width, d.height)
+}
@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestTagDialog_Edit(t *testing.T) {
+	d := NewTagDialog()
+	d.ShowEdit("sess-1", "My Project", []string{"bug"})
+	if !d.IsVisible() || d.Mode() != TagDialogEdit || d.SessionID() != "sess-1" {
+		t.Fatal("ShowEdit should open edit mode for sess-1")
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(", Client-X exp")})
+	if got := d.GetTags(); !slices.Equal(got, []string{"bug", "client-x", "exp"}) {
+		t.Errorf("GetTags = %v", got)
+	}
+
+	d.Hide()
+	if d.IsVisible() || d.SessionID() != "" {
+		t.Error("Hide should close the dialog and forget the session")
+	}
+}
+
+func TestTagDialog_Filter(t *testing.T) {
+	d := NewTagDialog()
+	d.ShowFilter(map[string]int{"exp": 1, "bug": 3, "client-x": 2}, "exp")
+	if got := d.GetSelectedTag(); got != "exp" {
+		t.Errorf("cursor should start on the active filter, got %q", got)
+	}
+
+	// Tags are sorted; j wraps from the last one
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}})
+	if got := d.GetSelectedTag(); got != "bug" {
+		t.Errorf("after j: got %q, want bug", got)
+	}
+
+	d.SetSize(100, 40)
+	if view := d.View(); !strings.Contains(view, "client-x") || !strings.Contains(view, "(3)") {
+		t.Errorf("view should list tags with counts:\n%s", view)
+	}
+
+	d.ShowFilter(nil, "")
+	if d.GetSelectedTag() != "" {
+		t.Error("empty picker should have no selection")
+	}
+}
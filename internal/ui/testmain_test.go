@@ -13,6 +13,10 @@ import (
 func TestMain(m *testing.M) {
 	// Force _test profile for all tests in this package
 	os.Setenv("AGENTDECK_PROFILE", "_test")
+	// Tests isolate storage by overriding HOME; XDG dirs from the
+	// environment would send it to the real data and config dirs instead.
+	os.Unsetenv("XDG_DATA_HOME")
+	os.Unsetenv("XDG_CONFIG_HOME")
 
 	// Run tests
 	code := m.Run()
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// ToastLevel is a toast's kind, which sets its icon, color and how long it
+// stays on screen.
+type ToastLevel int
+
+const (
+	ToastInfo ToastLevel = iota
+	ToastSuccess
+	ToastWarning
+)
+
+const (
+	toastDuration     = 4 * time.Second // Info and success
+	toastWarnDuration = 8 * time.Second // Warnings
+	toastMaxVisible   = 3               // Older toasts are dropped from the screen first
+	toastMaxWidth     = 60
+	toastLogSize      = 100 // Toasts kept for the log
+)
+
+// Toast is one notification.
+type Toast struct {
+	Level ToastLevel
+	Text  string
+	At    time.Time
+}
+
+// duration returns how long the toast stays on screen.
+func (t Toast) duration() time.Duration {
+	if t.Level == ToastWarning {
+		return toastWarnDuration
+	}
+	return toastDuration
+}
+
+// icon returns the toast's leading symbol.
+func (t Toast) icon() string {
+	switch t.Level {
+	case ToastSuccess:
+		return "✓"
+	case ToastWarning:
+		return "⚠"
+	default:
+		return "•"
+	}
+}
+
+// style returns the toast's text style.
+func (t Toast) style() lipgloss.Style {
+	switch t.Level {
+	case ToastSuccess:
+		return SuccessStyle
+	case ToastWarning:
+		return WarningStyle
+	default:
+		return lipgloss.NewStyle().Foreground(ColorText)
+	}
+}
+
+// Toasts holds the transient notifications drawn in the bottom-right corner
+// of the TUI and a log of recent ones. Each toast dismisses itself after a
+// few seconds. Safe for concurrent use: the status worker posts toasts too.
+type Toasts struct {
+	mu      sync.Mutex
+	visible []Toast // Oldest first
+	log     []Toast // Oldest first, at most toastLogSize
+}
+
+// NewToasts creates an empty toast stack.
+func NewToasts() *Toasts {
+	return &Toasts{}
+}
+
+// Push shows a toast and adds it to the log. Repeating the newest visible
+// toast restarts its timer instead of stacking a copy.
+func (t *Toasts) Push(level ToastLevel, text string) {
+	t.PushAt(level, text, time.Now())
+}
+
+// PushAt is Push with an explicit time, for tests.
+func (t *Toasts) PushAt(level ToastLevel, text string, now time.Time) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	toast := Toast{Level: level, Text: text, At: now}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if n := len(t.visible); n > 0 && t.visible[n-1].Level == level && t.visible[n-1].Text == text {
+		t.visible[n-1].At = now
+	} else {
+		t.visible = append(t.visible, toast)
+		if len(t.visible) > toastMaxVisible {
+			t.visible = t.visible[len(t.visible)-toastMaxVisible:]
+		}
+	}
+	t.log = append(t.log, toast)
+	if len(t.log) > toastLogSize {
+		t.log = t.log[len(t.log)-toastLogSize:]
+	}
+}
+
+// Expire removes toasts whose time is up.
+func (t *Toasts) Expire(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	kept := t.visible[:0]
+	for _, toast := range t.visible {
+		if now.Sub(toast.At) < toast.duration() {
+			kept = append(kept, toast)
+		}
+	}
+	t.visible = kept
+}
+
+// Dismiss hides every toast on screen. They stay in the log.
+func (t *Toasts) Dismiss() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.visible = nil
+}
+
+// Last returns the newest toast on screen.
+func (t *Toasts) Last() (Toast, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.visible) == 0 {
+		return Toast{}, false
+	}
+	return t.visible[len(t.visible)-1], true
+}
+
+// Log returns the logged toasts, newest first.
+func (t *Toasts) Log() []Toast {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := make([]Toast, len(t.log))
+	for i, toast := range t.log {
+		out[len(t.log)-1-i] = toast
+	}
+	return out
+}
+
+// Lines renders the toasts on screen, oldest first, each at most maxWidth
+// cells wide. Nil when there are none.
+func (t *Toasts) Lines(maxWidth int) []string {
+	t.mu.Lock()
+	visible := append([]Toast(nil), t.visible...)
+	t.mu.Unlock()
+
+	width := min(maxWidth, toastMaxWidth)
+	if width < 10 {
+		return nil
+	}
+	var lines []string
+	for _, toast := range visible {
+		text := runewidth.Truncate(toast.Text, width-4, "...")
+		lines = append(lines, toast.style().
+			Background(ColorSurface).
+			Padding(0, 1).
+			Render(toast.icon()+" "+text))
+	}
+	return lines
+}
+
+// overlayBottomRight draws lines over the bottom-right corner of content,
+// one per row, keeping what content shows to their left.
+func overlayBottomRight(content string, lines []string, width int) string {
+	if len(lines) == 0 {
+		return content
+	}
+	rows := strings.Split(content, "\n")
+	start := max(len(rows)-len(lines), 0)
+	for i, line := range lines[max(len(lines)-len(rows), 0):] {
+		row := rows[start+i]
+		left := width - lipgloss.Width(line) - 1
+		if left < 0 {
+			continue
+		}
+		row = ansi.Truncate(row, left, "")
+		if strings.Contains(row, "\x1b[") {
+			row += ansi.ResetStyle // Don't carry a cut-off style into the toast's padding
+		}
+		if pad := left - lipgloss.Width(row); pad > 0 {
+			row += strings.Repeat(" ", pad)
+		}
+		rows[start+i] = row + line
+	}
+	return strings.Join(rows, "\n")
+}
@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// ToastLogDialog lists recent notifications, newest first ("H" key), so a
+// toast that went away before it was read can be found again.
+type ToastLogDialog struct {
+	visible       bool
+	width, height int
+	toasts        []Toast
+	offset        int // First visible toast
+}
+
+// NewToastLogDialog creates a new notification log dialog.
+func NewToastLogDialog() *ToastLogDialog {
+	return &ToastLogDialog{}
+}
+
+// Show opens the dialog on the logged toasts, newest first.
+func (d *ToastLogDialog) Show(toasts []Toast) {
+	d.visible = true
+	d.toasts = toasts
+	d.offset = 0
+}
+
+// Hide closes the dialog.
+func (d *ToastLogDialog) Hide() {
+	d.visible = false
+	d.toasts = nil
+}
+
+// IsVisible returns whether the dialog is currently shown.
+func (d *ToastLogDialog) IsVisible() bool {
+	return d.visible
+}
+
+// SetSize updates the dialog dimensions for centering.
+func (d *ToastLogDialog) SetSize(w, h int) {
+	d.width = w
+	d.height = h
+}
+
+// pageSize is the number of toasts visible at once.
+func (d *ToastLogDialog) pageSize() int {
+	if d.height <= 0 {
+		return 20
+	}
+	return max(d.height-8, 5) // Title, footer and box padding
+}
+
+// Update handles scrolling and closing.
+func (d *ToastLogDialog) Update(msg tea.KeyMsg) (*ToastLogDialog, tea.Cmd) {
+	if !d.visible {
+		return d, nil
+	}
+	switch msg.String() {
+	case "j", "down":
+		d.offset++
+	case "k", "up":
+		d.offset--
+	case "esc", "q", "H", "enter":
+		d.Hide()
+	}
+	d.offset = max(min(d.offset, len(d.toasts)-d.pageSize()), 0)
+	return d, nil
+}
+
+// View renders the notification log.
+func (d *ToastLogDialog) View() string {
+	if !d.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	dialogWidth := 80
+	if d.width > 0 && d.width < dialogWidth+10 {
+		dialogWidth = max(d.width-10, 40)
+	}
+	textWidth := dialogWidth - 6 - 11 // Box padding, time and icon
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Notifications"))
+	lines = append(lines, "")
+	if len(d.toasts) == 0 {
+		lines = append(lines, dimStyle.Render("Nothing yet"))
+	}
+	end := min(d.offset+d.pageSize(), len(d.toasts))
+	for _, toast := range d.toasts[d.offset:end] {
+		text := runewidth.Truncate(toast.Text, textWidth, "...")
+		lines = append(lines, dimStyle.Render(toast.At.Local().Format("15:04:05"))+" "+
+			toast.style().Render(toast.icon()+" "+text))
+	}
+	lines = append(lines, "")
+	footer := "Esc close"
+	if len(d.toasts) > d.pageSize() {
+		footer = "j/k scroll | " + footer
+	}
+	lines = append(lines, footerStyle.Render(footer))
+
+	box := DialogBoxStyle.
+		Width(dialogWidth).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, d.width, d.height)
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestToastsExpireAndLog(t *testing.T) {
+	toasts := NewToasts()
+	now := time.Now()
+	toasts.PushAt(ToastSuccess, "Saved", now)
+	toasts.PushAt(ToastWarning, "not running", now)
+	toasts.PushAt(ToastWarning, "not running", now.Add(time.Second)) // Restarts the timer
+
+	if lines := toasts.Lines(80); len(lines) != 2 {
+		t.Fatalf("a repeated toast should not stack, got %d lines", len(lines))
+	}
+
+	toasts.Expire(now.Add(toastDuration))
+	if last, ok := toasts.Last(); !ok || last.Text != "not running" || len(toasts.Lines(80)) != 1 {
+		t.Error("the info toast should expire before the warning")
+	}
+	toasts.Expire(now.Add(time.Second + toastWarnDuration))
+	if _, ok := toasts.Last(); ok {
+		t.Error("every toast should be gone")
+	}
+
+	log := toasts.Log()
+	if len(log) != 3 || log[0].Text != "not running" || log[2].Text != "Saved" {
+		t.Errorf("log should keep every toast, newest first: %v", log)
+	}
+
+	for i := range toastLogSize + toastMaxVisible {
+		toasts.PushAt(ToastInfo, fmt.Sprint(i), now)
+	}
+	if len(toasts.Log()) != toastLogSize || len(toasts.Lines(80)) != toastMaxVisible {
+		t.Errorf("log = %d, visible = %d; want them capped", len(toasts.Log()), len(toasts.Lines(80)))
+	}
+}
+
+func TestOverlayBottomRight(t *testing.T) {
+	content := strings.Repeat(strings.Repeat("x", 40)+"\n", 4) + strings.Repeat("x", 40)
+	got := strings.Split(overlayBottomRight(content, []string{"toast"}, 40), "\n")
+	if len(got) != 5 || got[3] != strings.Repeat("x", 40) {
+		t.Fatal("only the last row should change")
+	}
+	if !strings.HasSuffix(got[4], "toast") || lipgloss.Width(got[4]) != 39 {
+		t.Errorf("last row = %q, want the toast right-aligned", got[4])
+	}
+	if strings.Contains(got[4], "\x1b[") {
+		t.Errorf("last row = %q, an unstyled row needs no style reset", got[4])
+	}
+}
+
+func TestHomeToastLog(t *testing.T) {
+	home := NewHome()
+	home.width = 100
+	home.height = 30
+	home.initialLoading = false
+
+	home.toast(ToastSuccess, "Sent prompt to '%s'", "api")
+	if !strings.Contains(home.View(), "Sent prompt to 'api'") {
+		t.Error("the toast should be drawn over the list")
+	}
+
+	home.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	if !home.toastLogDialog.IsVisible() {
+		t.Fatal("H should open the notification log")
+	}
+	if _, ok := home.toasts.Last(); ok {
+		t.Error("opening the log should clear the toasts on screen")
+	}
+	if !strings.Contains(home.View(), "Sent prompt to 'api'") {
+		t.Error("the log should list the toast")
+	}
+	home.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if home.toastLogDialog.IsVisible() {
+		t.Error("esc should close the log")
+	}
+}
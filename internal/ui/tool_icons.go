@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"os"
+	"strings"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// toolGlyphs are the Nerd Font glyphs shown for built-in tools in the
+// session list when nerd icons are on.
+var toolGlyphs = map[string]string{
+	"claude":   "\U000F06A9", // nf-md-robot
+	"gemini":   "\U000F0674", // nf-md-creation (sparkles)
+	"codex":    "\U000F0169", // nf-md-code_braces
+	"opencode": "\U000F0174", // nf-md-code_tags
+	"cursor":   "\U000F01BF", // nf-md-cursor_default
+	"aider":    "\uF121",     // nf-fa-code
+	"shell":    "\uF489",     // nf-oct-terminal
+}
+
+// nerdFontTerminals ship the Nerd Font symbols as a built-in fallback, so
+// the glyphs render whatever font is configured.
+var nerdFontTerminals = []string{"wezterm", "ghostty"}
+
+// toolGlyph returns the Nerd Font glyph for a tool: a [tools.*] nerd_icon,
+// else the built-in one. Empty when there is none, e.g. a custom tool
+// without nerd_icon.
+func toolGlyph(tool string) string {
+	if def := session.GetToolDef(tool); def != nil && def.NerdIcon != "" {
+		return def.NerdIcon
+	}
+	if tool == "" {
+		tool = "shell"
+	}
+	return toolGlyphs[tool]
+}
+
+// toolLabel returns what the session list shows for a tool: its glyph when
+// nerd icons are on and it has one, else its name.
+func toolLabel(tool string, nerd bool) string {
+	if nerd {
+		if glyph := toolGlyph(tool); glyph != "" {
+			return glyph
+		}
+	}
+	return tool
+}
+
+// useNerdIcons resolves the tool_icons setting: "nerd" and "text" force
+// glyphs or names; anything else detects a Nerd Font.
+func useNerdIcons(mode string) bool {
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "nerd":
+		return true
+	case "text":
+		return false
+	default:
+		return detectNerdFont()
+	}
+}
+
+// detectNerdFont guesses whether the terminal can draw Nerd Font glyphs.
+// Fonts can't be queried, so this trusts NERD_FONT (set by many Nerd Font
+// setups and dotfiles) and terminals that bundle the symbols.
+func detectNerdFont() bool {
+	if v := strings.ToLower(os.Getenv("NERD_FONT")); v != "" {
+		return v != "0" && v != "false" && v != "no"
+	}
+	term := strings.ToLower(os.Getenv("TERM_PROGRAM"))
+	for _, t := range nerdFontTerminals {
+		if term == t {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package ui
+
+import "testing"
+
+func TestUseNerdIcons(t *testing.T) {
+	t.Setenv("NERD_FONT", "")
+	t.Setenv("TERM_PROGRAM", "Apple_Terminal")
+	if useNerdIcons("") || useNerdIcons("auto") {
+		t.Error("auto should fall back to text without a Nerd Font")
+	}
+	if !useNerdIcons("nerd") || useNerdIcons("TEXT") {
+		t.Error("nerd and text should force the mode")
+	}
+
+	t.Setenv("TERM_PROGRAM", "WezTerm")
+	if !useNerdIcons("auto") {
+		t.Error("WezTerm bundles the Nerd Font symbols")
+	}
+	t.Setenv("NERD_FONT", "0")
+	if useNerdIcons("auto") {
+		t.Error("NERD_FONT=0 should turn glyphs off")
+	}
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("NERD_FONT", "1")
+	if !useNerdIcons("auto") {
+		t.Error("NERD_FONT=1 should turn glyphs on")
+	}
+}
+
+func TestToolLabel(t *testing.T) {
+	if got := toolLabel("claude", false); got != "claude" {
+		t.Errorf("text label = %q, want the tool name", got)
+	}
+	if got := toolLabel("claude", true); got != toolGlyphs["claude"] {
+		t.Errorf("nerd label = %q, want the claude glyph", got)
+	}
+	if got := toolLabel("my-custom-tool", true); got != "my-custom-tool" {
+		t.Errorf("a tool without a glyph should keep its name, got %q", got)
+	}
+}
@@ -0,0 +1,209 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+// TranscriptViewer shows a session's conversation, parsed from the agent's
+// transcript file, with role-colored messages ("V" key).
+type TranscriptViewer struct {
+	visible       bool
+	width, height int
+	title         string
+	tool          string
+	messages      []session.TranscriptMessage
+	lines         []string // Rendered for the current width
+	offset        int      // First visible line
+}
+
+// NewTranscriptViewer creates a new transcript viewer.
+func NewTranscriptViewer() *TranscriptViewer {
+	return &TranscriptViewer{}
+}
+
+// Show opens the viewer scrolled to the end of the conversation.
+func (v *TranscriptViewer) Show(title, tool string, messages []session.TranscriptMessage) {
+	v.visible = true
+	v.title = title
+	v.tool = tool
+	v.messages = messages
+	v.render()
+	v.offset = v.maxOffset()
+}
+
+// Hide closes the viewer and resets state.
+func (v *TranscriptViewer) Hide() {
+	v.visible = false
+	v.messages = nil
+	v.lines = nil
+	v.offset = 0
+}
+
+// IsVisible returns whether the viewer is currently shown.
+func (v *TranscriptViewer) IsVisible() bool {
+	return v.visible
+}
+
+// SetSize updates the viewer dimensions and re-wraps the messages.
+func (v *TranscriptViewer) SetSize(w, h int) {
+	v.width = w
+	v.height = h
+	if v.visible {
+		v.render()
+		v.offset = min(v.offset, v.maxOffset())
+	}
+}
+
+// boxWidth is the width of the dialog box, leaving a margin on each side.
+func (v *TranscriptViewer) boxWidth() int {
+	if v.width <= 0 {
+		return 100
+	}
+	return max(v.width-8, 30)
+}
+
+// pageSize is the number of transcript lines visible at once.
+func (v *TranscriptViewer) pageSize() int {
+	if v.height <= 0 {
+		return 30
+	}
+	return max(v.height-12, 5) // Title, position, footer and box padding
+}
+
+func (v *TranscriptViewer) maxOffset() int {
+	return max(0, len(v.lines)-v.pageSize())
+}
+
+// render wraps every message to the box width under a colored role header.
+func (v *TranscriptViewer) render() {
+	textWidth := v.boxWidth() - 6
+	body := lipgloss.NewStyle().Foreground(ColorText).Width(textWidth - 2)
+	dim := lipgloss.NewStyle().Foreground(ColorTextDim)
+
+	v.lines = nil
+	for i, m := range v.messages {
+		if i > 0 {
+			v.lines = append(v.lines, "")
+		}
+		header := lipgloss.NewStyle().Bold(true).Foreground(transcriptRoleColor(m.Role)).
+			Render(transcriptRoleLabel(m.Role, v.tool))
+		if !m.Time.IsZero() {
+			header += " " + dim.Render(m.Time.Local().Format("15:04"))
+		}
+		v.lines = append(v.lines, header)
+
+		style := body
+		if m.Role == session.RoleTool || m.Role == session.RoleSystem {
+			style = dim.Width(textWidth - 2)
+		}
+		for _, line := range strings.Split(style.Render(m.Text), "\n") {
+			v.lines = append(v.lines, "  "+line)
+		}
+	}
+}
+
+// transcriptRoleLabel names the speaker of a message.
+func transcriptRoleLabel(role, tool string) string {
+	switch role {
+	case session.RoleUser:
+		return "You"
+	case session.RoleAssistant:
+		if tool == "aider" {
+			return "Aider"
+		}
+		return "Claude"
+	case session.RoleTool:
+		return "Tool"
+	default:
+		return "System"
+	}
+}
+
+func transcriptRoleColor(role string) lipgloss.Color {
+	switch role {
+	case session.RoleUser:
+		return ColorGreen
+	case session.RoleAssistant:
+		return ColorCyan
+	case session.RoleTool:
+		return ColorYellow
+	default:
+		return ColorComment
+	}
+}
+
+// Update handles scrolling keys.
+func (v *TranscriptViewer) Update(msg tea.KeyMsg) (*TranscriptViewer, tea.Cmd) {
+	if !v.visible {
+		return v, nil
+	}
+	half := max(v.pageSize()/2, 1)
+	switch msg.String() {
+	case "j", "down":
+		v.offset++
+	case "k", "up":
+		v.offset--
+	case "ctrl+d", "pgdown", " ":
+		v.offset += half
+	case "ctrl+u", "pgup":
+		v.offset -= half
+	case "g", "home":
+		v.offset = 0
+	case "G", "end":
+		v.offset = v.maxOffset()
+	case "esc", "q", "V":
+		v.Hide()
+		return v, nil
+	}
+	v.offset = max(0, min(v.offset, v.maxOffset()))
+	return v, nil
+}
+
+// View renders the transcript viewer.
+func (v *TranscriptViewer) View() string {
+	if !v.visible {
+		return ""
+	}
+
+	titleStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorAccent)
+
+	dimStyle := lipgloss.NewStyle().
+		Foreground(ColorTextDim)
+
+	footerStyle := lipgloss.NewStyle().
+		Foreground(ColorComment).
+		Italic(true)
+
+	var lines []string
+	lines = append(lines, titleStyle.Render("Transcript: "+v.title))
+	if len(v.lines) > v.pageSize() {
+		end := min(v.offset+v.pageSize(), len(v.lines))
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("lines %d-%d of %d", v.offset+1, end, len(v.lines))))
+	} else {
+		lines = append(lines, dimStyle.Render(fmt.Sprintf("%d messages", len(v.messages))))
+	}
+	lines = append(lines, "")
+
+	if len(v.messages) == 0 {
+		lines = append(lines, dimStyle.Render("No messages yet"))
+	}
+	end := min(v.offset+v.pageSize(), len(v.lines))
+	lines = append(lines, v.lines[v.offset:end]...)
+
+	lines = append(lines, "")
+	lines = append(lines, footerStyle.Render("j/k scroll | ctrl+d/u half page | g/G top/end | Esc close"))
+
+	box := DialogBoxStyle.
+		Width(v.boxWidth()).
+		Render(strings.Join(lines, "\n"))
+
+	return centerInScreen(box, v.width, v.height)
+}
@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/asheshgoplani/agent-deck/internal/session"
+)
+
+func TestTranscriptViewer_Scroll(t *testing.T) {
+	var msgs []session.TranscriptMessage
+	for i := 0; i < 40; i++ {
+		msgs = append(msgs, session.TranscriptMessage{Role: session.RoleUser, Text: fmt.Sprintf("msg-%02d", i)})
+	}
+	v := NewTranscriptViewer()
+	v.SetSize(100, 30)
+	v.Show("alpha", "claude", msgs)
+
+	view := v.View()
+	if !strings.Contains(view, "msg-39") || strings.Contains(view, "msg-00") {
+		t.Errorf("viewer should open at the end:\n%s", view)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	view = v.View()
+	if !strings.Contains(view, "msg-00") || !strings.Contains(view, "You") {
+		t.Errorf("g should jump to the top:\n%s", view)
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if v.offset != 0 {
+		t.Errorf("scrolling above the top should clamp, offset = %d", v.offset)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if v.IsVisible() {
+		t.Error("Esc should close the viewer")
+	}
+}
@@ -75,11 +75,7 @@ type UpdateInfo struct {
 
 // getCacheDir returns the cache directory path
 func getCacheDir() (string, error) {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return "", err
-	}
-	return filepath.Join(home, ".agent-deck"), nil
+	return session.GetAgentDeckDir()
 }
 
 // loadCache loads the update cache from disk
@@ -532,12 +528,10 @@ func extractBinaryFromTarGz(tarPath string) ([]byte, error) {
 // This keeps bridge behavior in sync with the currently running binary.
 func UpdateBridgePy() error {
 	// Get the conductor directory
-	home, err := os.UserHomeDir()
+	conductorDir, err := session.ConductorDir()
 	if err != nil {
-		return fmt.Errorf("failed to get home directory: %w", err)
+		return fmt.Errorf("failed to get conductor directory: %w", err)
 	}
-
-	conductorDir := filepath.Join(home, ".agent-deck", "conductor")
 	bridgePath := filepath.Join(conductorDir, "bridge.py")
 
 	// Check if conductor directory exists
@@ -155,6 +155,7 @@ func TestFormatChangelogForDisplay(t *testing.T) {
 func TestUpdateBridgePy_NoConductorDir(t *testing.T) {
 	tmpHome := t.TempDir()
 	t.Setenv("HOME", tmpHome)
+	t.Setenv("XDG_DATA_HOME", "")
 
 	err := UpdateBridgePy()
 	require.NoError(t, err)
@@ -167,6 +168,7 @@ func TestUpdateBridgePy_NoConductorDir(t *testing.T) {
 func TestUpdateBridgePy_UsesEmbeddedTemplateAndBacksUpExistingFile(t *testing.T) {
 	tmpHome := t.TempDir()
 	t.Setenv("HOME", tmpHome)
+	t.Setenv("XDG_DATA_HOME", "")
 
 	condDir := filepath.Join(tmpHome, ".agent-deck", "conductor")
 	require.NoError(t, os.MkdirAll(condDir, 0o755))
@@ -524,7 +524,7 @@ func (p *pushService) syncOnce(ctx context.Context) {
 		if prev == status {
 			continue
 		}
-		if status != "waiting" && status != "error" && status != "idle" {
+		if status != "waiting" && status != "needs_input" && status != "error" && status != "idle" {
 			continue
 		}
 
@@ -699,6 +699,9 @@ func pushTitleForStatus(tr pushTransition) string {
 	if tr.Status == "idle" {
 		return fmt.Sprintf("Agent Deck: %s (idle)", sessionName)
 	}
+	if tr.Status == "needs_input" {
+		return fmt.Sprintf("Agent Deck: %s (needs approval)", sessionName)
+	}
 	return fmt.Sprintf("Agent Deck: %s (waiting)", sessionName)
 }
 
@@ -710,7 +713,7 @@ func pushBodyForStatus(tr pushTransition) string {
 	if tr.Status == "" {
 		return fmt.Sprintf("%s changed status.", sessionName)
 	}
-	return fmt.Sprintf("%s changed to %s.", sessionName, tr.Status)
+	return fmt.Sprintf("%s changed to %s.", sessionName, strings.ReplaceAll(tr.Status, "_", " "))
 }
 
 func shouldNotifySubscription(sub pushSubscription, tr pushTransition) bool {
@@ -210,7 +210,6 @@ func defaultLoadHookStatuses() map[string]*session.HookStatus {
 func (s *SessionDataService) refreshStatuses(instances []*session.Instance) {
 	// Keep tmux caches warm so per-instance status checks reflect current pane state.
 	tmux.RefreshExistingSessions()
-	tmux.RefreshPaneInfoCache()
 
 	var hooksByInstance map[string]*session.HookStatus
 	if s.loadHookStatuses != nil {
@@ -12,6 +12,7 @@ import (
 func TestDefaultLoadHookStatuses(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
 
 	hooksDir := session.GetHooksDir()
 	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
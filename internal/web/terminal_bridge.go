@@ -13,6 +13,8 @@ import (
 
 	"github.com/creack/pty"
 	"github.com/gorilla/websocket"
+
+	"github.com/asheshgoplani/agent-deck/internal/tmux"
 )
 
 var ErrTmuxSessionNotFound = errors.New("tmux session not found")
@@ -191,6 +193,13 @@ func tmuxSessionExists(name string) (bool, error) {
 }
 
 func tmuxCommand(args ...string) *exec.Cmd {
+	// A configured dedicated socket wins over whatever server $TMUX points at
+	if tmux.SocketName() != "" {
+		cmd := tmux.Command(args...)
+		cmd.Env = environWithoutTMUX(os.Environ())
+		return cmd
+	}
+
 	socketPath, hasSocket := tmuxSocketFromEnv()
 
 	finalArgs := args
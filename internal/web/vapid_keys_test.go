@@ -9,6 +9,7 @@ import (
 func TestEnsurePushVAPIDKeysCreatesAndReuses(t *testing.T) {
 	home := t.TempDir()
 	t.Setenv("HOME", home)
+	t.Setenv("XDG_DATA_HOME", "")
 
 	pub1, priv1, generated1, err := EnsurePushVAPIDKeys("test-profile", "mailto:test@example.com")
 	if err != nil {